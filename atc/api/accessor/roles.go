@@ -23,6 +23,7 @@ var DefaultRoles = map[string]string{
 	atc.BuildResources:                ViewerRole,
 	atc.AbortBuild:                    OperatorRole,
 	atc.GetBuildPreparation:           ViewerRole,
+	atc.GetBuildStepOutput:            ViewerRole,
 	atc.GetJob:                        ViewerRole,
 	atc.CreateJobBuild:                OperatorRole,
 	atc.RerunJobBuild:                 OperatorRole,
@@ -38,6 +39,8 @@ var DefaultRoles = map[string]string{
 	atc.JobBadge:                      ViewerRole,
 	atc.MainJobBadge:                  ViewerRole,
 	atc.ClearTaskCache:                OperatorRole,
+	atc.ListJobCaches:                 ViewerRole,
+	atc.ClearJobCaches:                OperatorRole,
 	atc.ListAllResources:              ViewerRole,
 	atc.ListResources:                 ViewerRole,
 	atc.ListResourceTypes:             ViewerRole,
@@ -47,11 +50,13 @@ var DefaultRoles = map[string]string{
 	atc.CheckResource:                 OperatorRole,
 	atc.CheckResourceWebHook:          OperatorRole,
 	atc.CheckResourceType:             OperatorRole,
+	atc.ListResourceChecks:            ViewerRole,
 	atc.ListResourceVersions:          ViewerRole,
 	atc.GetResourceVersion:            ViewerRole,
 	atc.EnableResourceVersion:         OperatorRole,
 	atc.DisableResourceVersion:        OperatorRole,
 	atc.PinResourceVersion:            OperatorRole,
+	atc.PromoteResourceVersion:        OperatorRole,
 	atc.ListBuildsWithVersionAsInput:  ViewerRole,
 	atc.ListBuildsWithVersionAsOutput: ViewerRole,
 	atc.GetResourceCausality:          ViewerRole,
@@ -72,15 +77,28 @@ var DefaultRoles = map[string]string{
 	atc.RegisterWorker:                MemberRole,
 	atc.LandWorker:                    MemberRole,
 	atc.RetireWorker:                  MemberRole,
+	atc.EvictWorker:                   MemberRole,
 	atc.PruneWorker:                   MemberRole,
+	atc.QuarantineWorker:              MemberRole,
+	atc.ReleaseWorker:                 MemberRole,
 	atc.HeartbeatWorker:               MemberRole,
 	atc.ListWorkers:                   ViewerRole,
+	atc.GetWorkerDemand:               ViewerRole,
 	atc.DeleteWorker:                  MemberRole,
+	atc.CreateWorkerEnrollmentToken:   OwnerRole,
+	atc.ListWorkerKeys:                ViewerRole,
+	atc.RevokeWorkerKey:               OwnerRole,
+	atc.EnrollWorkerKey:               MemberRole,
+	atc.AuthorizeWorkerKey:            MemberRole,
+	atc.UseWorkerKey:                  MemberRole,
 	atc.SetLogLevel:                   MemberRole,
 	atc.GetLogLevel:                   ViewerRole,
 	atc.DownloadCLI:                   ViewerRole,
 	atc.GetInfo:                       ViewerRole,
 	atc.GetInfoCreds:                  ViewerRole,
+	atc.ListTeamCredentialUsages:      ViewerRole,
+	atc.ListAllCredentialUsages:       ViewerRole,
+	atc.ClearCredentialCache:          OperatorRole,
 	atc.ListContainers:                ViewerRole,
 	atc.GetContainer:                  ViewerRole,
 	atc.HijackContainer:               MemberRole,
@@ -25,6 +25,7 @@ import (
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/demand/demandfakes"
 	"github.com/concourse/concourse/atc/gc/gcfakes"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
@@ -40,37 +41,43 @@ var (
 	externalURL = "https://example.com"
 	clusterName = "Test Cluster"
 
-	fakeWorkerPool          *workerfakes.FakePool
-	fakeVolumeRepository    *dbfakes.FakeVolumeRepository
-	fakeContainerRepository *dbfakes.FakeContainerRepository
-	fakeDestroyer           *gcfakes.FakeDestroyer
-	dbTeamFactory           *dbfakes.FakeTeamFactory
-	dbPipelineFactory       *dbfakes.FakePipelineFactory
-	dbJobFactory            *dbfakes.FakeJobFactory
-	dbResourceFactory       *dbfakes.FakeResourceFactory
-	dbResourceConfigFactory *dbfakes.FakeResourceConfigFactory
-	fakePipeline            *dbfakes.FakePipeline
-	fakeAccess              *accessorfakes.FakeAccess
-	fakeAccessor            *accessorfakes.FakeAccessFactory
-	dbWorkerFactory         *dbfakes.FakeWorkerFactory
-	dbWorkerTeamFactory     *dbfakes.FakeTeamFactory
-	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
-	build                   *dbfakes.FakeBuild
-	dbBuildFactory          *dbfakes.FakeBuildFactory
-	dbUserFactory           *dbfakes.FakeUserFactory
-	dbCheckFactory          *dbfakes.FakeCheckFactory
-	dbTeam                  *dbfakes.FakeTeam
-	dbWall                  *dbfakes.FakeWall
-	fakeSecretManager       *credsfakes.FakeSecrets
-	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
-	fakePolicyChecker       *policycheckerfakes.FakePolicyChecker
-	credsManagers           creds.Managers
-	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
-	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
-	isTLSEnabled            bool
-	cliDownloadsDir         string
-	logger                  *lagertest.TestLogger
-	fakeClock               *fakeclock.FakeClock
+	fakeWorkerPool             *workerfakes.FakePool
+	fakeWorkerDemand           *demandfakes.FakeCalculator
+	fakeVolumeRepository       *dbfakes.FakeVolumeRepository
+	fakeContainerRepository    *dbfakes.FakeContainerRepository
+	fakeDestroyer              *gcfakes.FakeDestroyer
+	dbTeamFactory              *dbfakes.FakeTeamFactory
+	dbPipelineFactory          *dbfakes.FakePipelineFactory
+	dbJobFactory               *dbfakes.FakeJobFactory
+	dbResourceFactory          *dbfakes.FakeResourceFactory
+	dbResourceConfigFactory    *dbfakes.FakeResourceConfigFactory
+	fakePipeline               *dbfakes.FakePipeline
+	fakeAccess                 *accessorfakes.FakeAccess
+	fakeAccessor               *accessorfakes.FakeAccessFactory
+	dbWorkerFactory            *dbfakes.FakeWorkerFactory
+	dbWorkerTeamFactory        *dbfakes.FakeTeamFactory
+	dbWorkerLifecycle          *dbfakes.FakeWorkerLifecycle
+	build                      *dbfakes.FakeBuild
+	dbBuildFactory             *dbfakes.FakeBuildFactory
+	dbUserFactory              *dbfakes.FakeUserFactory
+	dbWorkerKeyFactory         *dbfakes.FakeWorkerKeyFactory
+	dbCredentialUsageFactory   *dbfakes.FakeCredentialUsageFactory
+	dbCacheInvalidationFactory *dbfakes.FakeCacheInvalidationFactory
+	dbCheckFactory             *dbfakes.FakeCheckFactory
+	dbTeam                     *dbfakes.FakeTeam
+	dbWall                     *dbfakes.FakeWall
+	fakeSecretManager          *credsfakes.FakeResolvingSecrets
+	fakeVarSourcePool          *credsfakes.FakeVarSourcePool
+	leasedVars                 *creds.LeasedVars
+	fakePolicyChecker          *policycheckerfakes.FakePolicyChecker
+	credsManagers              creds.Managers
+	interceptTimeoutFactory    *containerserverfakes.FakeInterceptTimeoutFactory
+	interceptTimeout           *containerserverfakes.FakeInterceptTimeout
+	isTLSEnabled               bool
+	requirePinComment          bool
+	cliDownloadsDir            string
+	logger                     *lagertest.TestLogger
+	fakeClock                  *fakeclock.FakeClock
 
 	constructedEventHandler *fakeEventHandlerFactory
 
@@ -107,6 +114,9 @@ var _ = BeforeEach(func() {
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 	dbUserFactory = new(dbfakes.FakeUserFactory)
+	dbWorkerKeyFactory = new(dbfakes.FakeWorkerKeyFactory)
+	dbCredentialUsageFactory = new(dbfakes.FakeCredentialUsageFactory)
+	dbCacheInvalidationFactory = new(dbfakes.FakeCacheInvalidationFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
 
@@ -132,13 +142,15 @@ var _ = BeforeEach(func() {
 	dbWorkerLifecycle = new(dbfakes.FakeWorkerLifecycle)
 
 	fakeWorkerPool = new(workerfakes.FakePool)
+	fakeWorkerDemand = new(demandfakes.FakeCalculator)
 
 	fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
 	fakeDestroyer = new(gcfakes.FakeDestroyer)
 
-	fakeSecretManager = new(credsfakes.FakeSecrets)
+	fakeSecretManager = new(credsfakes.FakeResolvingSecrets)
 	fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+	leasedVars = creds.NewLeasedVars()
 	credsManagers = make(creds.Managers)
 
 	fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
@@ -154,6 +166,7 @@ var _ = BeforeEach(func() {
 	sink = lager.NewReconfigurableSink(lager.NewPrettySink(GinkgoWriter, lager.DEBUG), lager.DEBUG)
 
 	isTLSEnabled = false
+	requirePinComment = false
 
 	build = new(dbfakes.FakeBuild)
 
@@ -199,21 +212,28 @@ var _ = BeforeEach(func() {
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		dbUserFactory,
+		dbWorkerKeyFactory,
+		dbCredentialUsageFactory,
+		dbCacheInvalidationFactory,
 
 		constructedEventHandler.Construct,
 
 		fakeWorkerPool,
+		fakeWorkerDemand,
 
 		sink,
 
 		isTLSEnabled,
+		requirePinComment,
 
 		cliDownloadsDir,
 		"1.2.3",
 		"4.5.6",
 		fakeSecretManager,
 		fakeVarSourcePool,
+		leasedVars,
 		credsManagers,
+		nil,
 		interceptTimeoutFactory,
 		time.Second,
 		dbWall,
@@ -1,7 +1,10 @@
 package api_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,10 +12,12 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -1530,4 +1535,200 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/steps/:plan_id/outputs/:name", func() {
+		var (
+			format   string
+			response *http.Response
+
+			fakeStepOutput   *dbfakes.FakeWorkerArtifact
+			fakeOutputVolume *dbfakes.FakeCreatedVolume
+			fakeWorkerVolume *workerfakes.FakeVolume
+		)
+
+		BeforeEach(func() {
+			format = ""
+
+			fakeStepOutput = new(dbfakes.FakeWorkerArtifact)
+			fakeOutputVolume = new(dbfakes.FakeCreatedVolume)
+			fakeOutputVolume.HandleReturns("some-handle")
+			fakeWorkerVolume = new(workerfakes.FakeVolume)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/steps/some-plan/outputs/some-output?format=" + format)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+
+				build.PipelineIDReturns(0)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when not authorized for the build's team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+
+				fakePipeline.PublicReturns(true)
+				build.PipelineReturns(fakePipeline, true, nil)
+				build.PipelineIDReturns(1)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			It("returns 403 even if the pipeline is public", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(build.StepOutputCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				build.TeamIDReturns(734)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the output is found", func() {
+				BeforeEach(func() {
+					build.StepOutputReturns(fakeStepOutput, true, nil)
+					fakeStepOutput.VolumeReturns(fakeOutputVolume, true, nil)
+					fakeWorkerPool.FindVolumeReturns(fakeWorkerVolume, true, nil)
+
+					buf := new(bytes.Buffer)
+					gzWriter := gzip.NewWriter(buf)
+					tarWriter := tar.NewWriter(gzWriter)
+					Expect(tarWriter.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755})).To(Succeed())
+					Expect(tarWriter.WriteHeader(&tar.Header{Name: "./some-file", Typeflag: tar.TypeReg, Mode: 0644, Size: 12})).To(Succeed())
+					_, err := tarWriter.Write([]byte("some-content"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(tarWriter.Close()).To(Succeed())
+					Expect(gzWriter.Close()).To(Succeed())
+
+					fakeWorkerVolume.StreamOutReturns(ioutil.NopCloser(buf), nil)
+				})
+
+				It("looks up the output of the step", func() {
+					planID, name := build.StepOutputArgsForCall(0)
+					Expect(planID).To(Equal(atc.PlanID("some-plan")))
+					Expect(name).To(Equal("some-output"))
+
+					Expect(fakeStepOutput.VolumeArgsForCall(0)).To(Equal(734))
+
+					_, teamID, handle := fakeWorkerPool.FindVolumeArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+					Expect(handle).To(Equal("some-handle"))
+				})
+
+				It("streams out the volume as a tarball", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/gzip"))
+
+					_, path, encoding := fakeWorkerVolume.StreamOutArgsForCall(0)
+					Expect(path).To(Equal("/"))
+					Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+
+					gzReader, err := gzip.NewReader(response.Body)
+					Expect(err).ToNot(HaveOccurred())
+
+					tarReader := tar.NewReader(gzReader)
+					_, err = tarReader.Next()
+					Expect(err).ToNot(HaveOccurred())
+					hdr, err := tarReader.Next()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(hdr.Name).To(Equal("./some-file"))
+				})
+
+				Context("when zip is requested", func() {
+					BeforeEach(func() {
+						format = "zip"
+					})
+
+					It("converts the tarball into a zip archive", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/zip"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).ToNot(HaveOccurred())
+
+						zipReader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(zipReader.File).To(HaveLen(1))
+						Expect(zipReader.File[0].Name).To(Equal("some-file"))
+
+						entry, err := zipReader.File[0].Open()
+						Expect(err).ToNot(HaveOccurred())
+
+						content, err := ioutil.ReadAll(entry)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(content)).To(Equal("some-content"))
+					})
+				})
+
+				Context("when an unknown format is requested", func() {
+					BeforeEach(func() {
+						format = "rar"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when the output is not found", func() {
+				BeforeEach(func() {
+					build.StepOutputReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the output's volume is gone", func() {
+				BeforeEach(func() {
+					build.StepOutputReturns(fakeStepOutput, true, nil)
+					fakeStepOutput.VolumeReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the volume is gone from the worker", func() {
+				BeforeEach(func() {
+					build.StepOutputReturns(fakeStepOutput, true, nil)
+					fakeStepOutput.VolumeReturns(fakeOutputVolume, true, nil)
+					fakeWorkerPool.FindVolumeReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the output fails", func() {
+				BeforeEach(func() {
+					build.StepOutputReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 type EventHandlerFactory func(lager.Logger, db.Build) http.Handler
@@ -18,6 +19,7 @@ type Server struct {
 	teamFactory         db.TeamFactory
 	buildFactory        db.BuildFactory
 	eventHandlerFactory EventHandlerFactory
+	workerPool          worker.Pool
 	rejector            auth.Rejector
 }
 
@@ -27,6 +29,7 @@ func NewServer(
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
 	eventHandlerFactory EventHandlerFactory,
+	workerPool worker.Pool,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -36,6 +39,7 @@ func NewServer(
 		teamFactory:         teamFactory,
 		buildFactory:        buildFactory,
 		eventHandlerFactory: eventHandlerFactory,
+		workerPool:          workerPool,
 
 		rejector: auth.UnauthorizedRejector{},
 	}
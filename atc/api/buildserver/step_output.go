@@ -0,0 +1,162 @@
+package buildserver
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildStepOutput(build db.Build) http.Handler {
+	logger := s.logger.Session("get-build-step-output")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+		name := r.FormValue(":name")
+
+		format := r.FormValue(atc.StepOutputQueryFormat)
+		if format != "" && format != "tgz" && format != "zip" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		logger := logger.WithData(lager.Data{
+			"build":  build.ID(),
+			"plan":   planID,
+			"output": name,
+		})
+
+		stepOutput, found, err := build.StepOutput(planID, name)
+		if err != nil {
+			logger.Error("failed-to-find-step-output", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		outputVolume, found, err := stepOutput.Volume(build.TeamID())
+		if err != nil {
+			logger.Error("failed-to-get-step-output-volume", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		workerVolume, found, err := s.workerPool.FindVolume(logger, build.TeamID(), outputVolume.Handle())
+		if err != nil {
+			logger.Error("failed-to-get-worker-volume", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		reader, err := workerVolume.StreamOut(r.Context(), "/", baggageclaim.GzipEncoding)
+		if err != nil {
+			logger.Error("failed-to-stream-volume-contents", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		defer reader.Close()
+
+		if format == "zip" {
+			w.Header().Set("Content-Type", "application/zip")
+
+			err = tgzToZip(w, reader)
+		} else {
+			w.Header().Set("Content-Type", "application/gzip")
+
+			_, err = io.Copy(w, reader)
+		}
+		if err != nil {
+			logger.Error("failed-to-write-step-output", err)
+		}
+	})
+}
+
+// tgzToZip rewrites a gzipped tarball as a zip archive. Volumes can only be
+// streamed out as tarballs, so the conversion happens on the fly.
+func tgzToZip(dest io.Writer, src io.Reader) error {
+	gzReader, err := gzip.NewReader(src)
+	if err != nil {
+		return err
+	}
+
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	zipWriter := zip.NewWriter(dest)
+
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(hdr.Name, "./"), "/")
+		if name == "" || name == "." {
+			continue
+		}
+
+		var content io.Reader
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+		case tar.TypeReg:
+			content = tarReader
+		case tar.TypeSymlink:
+			content = strings.NewReader(hdr.Linkname)
+		default:
+			// zip has no way of representing devices, fifos and the like
+			continue
+		}
+
+		zipHdr, err := zip.FileInfoHeader(hdr.FileInfo())
+		if err != nil {
+			return err
+		}
+
+		zipHdr.Name = name
+		if hdr.Typeflag == tar.TypeDir {
+			zipHdr.Name = strings.TrimSuffix(name, "/") + "/"
+		} else {
+			zipHdr.Method = zip.Deflate
+		}
+
+		entry, err := zipWriter.CreateHeader(zipHdr)
+		if err != nil {
+			return err
+		}
+
+		if content != nil {
+			_, err = io.Copy(entry, content)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return zipWriter.Close()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
+	"github.com/concourse/concourse/vars"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
 	"sigs.k8s.io/yaml"
@@ -793,6 +794,32 @@ jobs:
 								ExpectCredsValidationFail()
 							})
 
+							Context("when the param is known to be backed by a lease", func() {
+								BeforeEach(func() {
+									payload = `---
+resources:
+- name: some-resource
+  type: some-type
+  source:
+    FOO: ((LEASED))
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource`
+
+									request.Header.Set("Content-Type", "application/x-yaml")
+									request.Body = ioutil.NopCloser(bytes.NewBufferString(payload))
+
+									leasedVars.Record("a-team", "a-pipeline", vars.Reference{Path: "LEASED"})
+									fakeSecretManager.GetReturns(nil, nil, false, nil)
+								})
+
+								It("passes validation without looking it up", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(fakeSecretManager.GetCallCount()).To(BeZero())
+								})
+							})
+
 							Context("when there is param in resource webhook token", func() {
 								BeforeEach(func() {
 									payload = `---
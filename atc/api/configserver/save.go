@@ -3,6 +3,7 @@ package configserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -104,7 +105,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if checkCredentials {
-		variables := creds.NewVariables(s.secretManager, teamName, pipelineName, false)
+		variables := creds.NewUnleasedVariables(session, s.leasedVars, creds.NewVariables(s.secretManager, teamName, pipelineName, false), teamName, pipelineName)
 
 		errs := validateCredParams(variables, config, session)
 		if errs != nil {
@@ -128,6 +129,19 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	errorMessages, err = configvalidate.ValidatePassedPipelines(config, team)
+	if err != nil {
+		session.Error("failed-to-validate-passed-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
+		s.handleBadRequest(w, errorMessages...)
+		return
+	}
+
 	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
@@ -155,41 +169,32 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
 }
 
-// Simply validate that the credentials exist; don't do anything with the actual secrets
+// Simply validate that the credentials exist; don't do anything with the actual secrets.
+// Vars backed by a lease aren't looked up, so they're assumed to exist.
 func validateCredParams(credMgrVars vars.Variables, config atc.Config, session lager.Logger) error {
 	var errs error
 
 	for _, resourceType := range config.ResourceTypes {
 		_, err := creds.NewSource(credMgrVars, resourceType.Source).Evaluate()
-		if err != nil {
-			errs = multierror.Append(errs, err)
-		}
+		errs = appendCredError(errs, err)
 	}
 
 	for _, resource := range config.Resources {
 		_, err := creds.NewSource(credMgrVars, resource.Source).Evaluate()
-		if err != nil {
-			errs = multierror.Append(errs, err)
-		}
+		errs = appendCredError(errs, err)
 
 		_, err = creds.NewString(credMgrVars, resource.WebhookToken).Evaluate()
-		if err != nil {
-			errs = multierror.Append(errs, err)
-		}
+		errs = appendCredError(errs, err)
 	}
 
 	for _, job := range config.Jobs {
 		_ = job.StepConfig().Visit(atc.StepRecursor{
 			OnTask: func(step *atc.TaskStep) error {
 				err := creds.NewTaskEnvValidator(credMgrVars, step.Params).Validate()
-				if err != nil {
-					errs = multierror.Append(errs, err)
-				}
+				errs = appendCredError(errs, err)
 
 				err = creds.NewTaskVarsValidator(credMgrVars, step.Vars).Validate()
-				if err != nil {
-					errs = multierror.Append(errs, err)
-				}
+				errs = appendCredError(errs, err)
 
 				if step.Config != nil {
 					// embedded task - we can fully validate it, interpolating with cred mgr variables
@@ -203,9 +208,7 @@ func validateCredParams(credMgrVars vars.Variables, config atc.Config, session l
 					}
 					taskConfigSource = exec.ValidatingConfigSource{ConfigSource: taskConfigSource}
 					_, err = taskConfigSource.FetchConfig(context.TODO(), session, nil)
-					if err != nil {
-						errs = multierror.Append(errs, err)
-					}
+					errs = appendCredError(errs, err)
 				}
 
 				return nil
@@ -220,6 +223,14 @@ func validateCredParams(credMgrVars vars.Variables, config atc.Config, session l
 	return errs
 }
 
+func appendCredError(errs error, err error) error {
+	if err == nil || errors.As(err, &creds.LeasedVarError{}) {
+		return errs
+	}
+
+	return multierror.Append(errs, err)
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages ...string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusBadRequest)
@@ -10,16 +10,19 @@ type Server struct {
 	logger        lager.Logger
 	teamFactory   db.TeamFactory
 	secretManager creds.Secrets
+	leasedVars    *creds.LeasedVars
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	secretManager creds.Secrets,
+	leasedVars *creds.LeasedVars,
 ) *Server {
 	return &Server{
 		logger:        logger,
 		teamFactory:   teamFactory,
 		secretManager: secretManager,
+		leasedVars:    leasedVars,
 	}
 }
@@ -0,0 +1,370 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/vars"
+	"github.com/concourse/concourse/vars/varsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credentials API", func() {
+	var (
+		response *http.Response
+
+		fakeVariables *varsfakes.FakeVariables
+		pipeline      *dbfakes.FakePipeline
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(varsfakes.FakeVariables)
+		fakeVariables.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+			switch ref.Path {
+			case "some-var":
+				return "some-value", true, nil
+			case "broken-var":
+				return nil, false, errors.New("nope")
+			default:
+				return nil, false, nil
+			}
+		}
+
+		pipeline = new(dbfakes.FakePipeline)
+		pipeline.IDReturns(1)
+		pipeline.NameReturns("some-pipeline")
+		pipeline.VariablesReturns(fakeVariables, nil)
+	})
+
+	usages := func() []atc.CredentialUsage {
+		return []atc.CredentialUsage{
+			{TeamName: "some-team", PipelineID: 1, PipelineName: "some-pipeline", Type: atc.CredentialUsageJob, Name: "some-job", Path: "some-var"},
+			{TeamName: "some-team", PipelineID: 1, PipelineName: "some-pipeline", Type: atc.CredentialUsageResource, Name: "some-resource", Path: "some-var"},
+			{TeamName: "some-team", PipelineID: 1, PipelineName: "some-pipeline", Type: atc.CredentialUsageResource, Name: "some-resource", Path: "missing-var"},
+			{TeamName: "some-team", PipelineID: 1, PipelineName: "some-pipeline", Type: atc.CredentialUsageJob, Name: "some-job", VarSource: "some-source", Path: "broken-var"},
+		}
+	}
+
+	Describe("GET /api/v1/teams/:team_name/credentials/usages", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = "?resolve"
+
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/credentials/usages" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbCredentialUsageFactory.TeamCredentialUsagesReturns(usages(), nil)
+				dbTeam.PipelinesReturns([]db.Pipeline{pipeline}, nil)
+			})
+
+			It("returns 200 with the usages, flagging the ones that don't resolve", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "job", "name": "some-job", "path": "some-var"},
+					{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "resource", "name": "some-resource", "path": "some-var"},
+					{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "resource", "name": "some-resource", "path": "missing-var", "unresolved": true},
+					{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "job", "name": "some-job", "var_source": "some-source", "path": "broken-var", "unresolved": true}
+				]`))
+			})
+
+			It("looks up the usages of the team", func() {
+				teamID, path := dbCredentialUsageFactory.TeamCredentialUsagesArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(path).To(BeEmpty())
+			})
+
+			It("looks up each var once per pipeline", func() {
+				Expect(fakeVariables.GetCallCount()).To(Equal(3))
+				Expect(fakeVariables.GetArgsForCall(2)).To(Equal(vars.Reference{Source: "some-source", Path: "broken-var"}))
+			})
+
+			It("reuses the outcome of looking up the vars", func() {
+				response, err := client.Get(server.URL + "/api/v1/teams/some-team/credentials/usages" + query)
+				Expect(err).NotTo(HaveOccurred())
+
+				var presented []atc.CredentialUsage
+				Expect(json.NewDecoder(response.Body).Decode(&presented)).To(Succeed())
+				Expect(presented[2].Unresolved).To(BeTrue())
+
+				Expect(fakeVariables.GetCallCount()).To(Equal(3))
+			})
+
+			Context("without resolving the vars", func() {
+				BeforeEach(func() {
+					query = ""
+				})
+
+				It("returns the usages without looking up the vars", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[
+						{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "job", "name": "some-job", "path": "some-var"},
+						{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "resource", "name": "some-resource", "path": "some-var"},
+						{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "resource", "name": "some-resource", "path": "missing-var"},
+						{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "job", "name": "some-job", "var_source": "some-source", "path": "broken-var"}
+					]`))
+
+					Expect(dbTeam.PipelinesCallCount()).To(BeZero())
+					Expect(fakeVariables.GetCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a var is known to be backed by a lease", func() {
+				BeforeEach(func() {
+					pipeline.TeamNameReturns("some-team")
+					leasedVars.Record("some-team", "some-pipeline", vars.Reference{Path: "missing-var"})
+				})
+
+				It("does not look it up or flag it", func() {
+					var presented []atc.CredentialUsage
+					Expect(json.NewDecoder(response.Body).Decode(&presented)).To(Succeed())
+					Expect(presented[2].Unresolved).To(BeFalse())
+
+					Expect(fakeVariables.GetCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when the credential manager reports which manager resolved each var", func() {
+				BeforeEach(func() {
+					fakeSecretManager.ResolvedByReturns("some-manager", true)
+				})
+
+				It("marks the resolved vars with their manager", func() {
+					var presented []atc.CredentialUsage
+					Expect(json.NewDecoder(response.Body).Decode(&presented)).To(Succeed())
+					Expect(presented).To(HaveLen(4))
+					Expect(presented[0].ResolvedBy).To(Equal("some-manager"))
+					Expect(presented[1].ResolvedBy).To(Equal("some-manager"))
+					Expect(presented[2].ResolvedBy).To(BeEmpty())
+					Expect(presented[3].ResolvedBy).To(BeEmpty())
+				})
+
+				It("asks for the manager of the pipeline's var", func() {
+					teamName, pipelineName, path := fakeSecretManager.ResolvedByArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(pipelineName).To(Equal("some-pipeline"))
+					Expect(path).To(Equal("some-var"))
+				})
+			})
+
+			Context("with a path", func() {
+				BeforeEach(func() {
+					query = "?path=some-var&resolve"
+				})
+
+				It("only looks up the usages of the var", func() {
+					_, path := dbCredentialUsageFactory.TeamCredentialUsagesArgsForCall(0)
+					Expect(path).To(Equal("some-var"))
+				})
+			})
+
+			Context("when the pipeline's variables can't be created", func() {
+				BeforeEach(func() {
+					pipeline.VariablesReturns(nil, errors.New("nope"))
+				})
+
+				It("flags every usage as unresolved", func() {
+					var presented []atc.CredentialUsage
+					Expect(json.NewDecoder(response.Body).Decode(&presented)).To(Succeed())
+					Expect(presented).To(HaveLen(4))
+					for _, usage := range presented {
+						Expect(usage.Unresolved).To(BeTrue())
+					}
+				})
+			})
+
+			Context("when there are no usages", func() {
+				BeforeEach(func() {
+					dbCredentialUsageFactory.TeamCredentialUsagesReturns(nil, nil)
+				})
+
+				It("returns an empty list", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when looking up the usages fails", func() {
+				BeforeEach(func() {
+					dbCredentialUsageFactory.TeamCredentialUsagesReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/credentials/usages", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = "?path=some-var&resolve"
+
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/credentials/usages" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+
+				dbCredentialUsageFactory.AllCredentialUsagesReturns(usages()[:1], nil)
+				dbPipelineFactory.AllPipelinesReturns([]db.Pipeline{pipeline}, nil)
+			})
+
+			It("returns 200 with the usages from every team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{"team_name": "some-team", "pipeline_id": 1, "pipeline_name": "some-pipeline", "type": "job", "name": "some-job", "path": "some-var"}
+				]`))
+
+				Expect(dbCredentialUsageFactory.AllCredentialUsagesArgsForCall(0)).To(Equal("some-var"))
+			})
+
+			Context("without resolving the vars", func() {
+				BeforeEach(func() {
+					query = "?path=some-var"
+				})
+
+				It("does not look up the pipelines or their vars", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbPipelineFactory.AllPipelinesCallCount()).To(BeZero())
+					Expect(fakeVariables.GetCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when looking up the pipelines fails", func() {
+				BeforeEach(func() {
+					dbPipelineFactory.AllPipelinesReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("Credential Cache API", func() {
+	var response *http.Response
+
+	Describe("DELETE /api/v1/teams/:team_name/credentials/cache", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+
+			dbTeam.NameReturns("some-team")
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/credentials/cache"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbCacheInvalidationFactory.InvalidateCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			})
+
+			It("invalidates the team's cached secrets", func() {
+				teamName, path := dbCacheInvalidationFactory.InvalidateArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+				Expect(path).To(BeEmpty())
+			})
+
+			Context("with a path", func() {
+				BeforeEach(func() {
+					query = "?path=some-var"
+				})
+
+				It("only invalidates the var's cached secrets", func() {
+					_, path := dbCacheInvalidationFactory.InvalidateArgsForCall(0)
+					Expect(path).To(Equal("some-var"))
+				})
+			})
+
+			Context("when invalidating fails", func() {
+				BeforeEach(func() {
+					dbCacheInvalidationFactory.InvalidateReturns(errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,29 @@
+package credentialserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ClearCredentialCache invalidates the team's cached secrets on every ATC,
+// e.g. after rotating a secret, so that builds pick up the new value right
+// away.
+func (s *Server) ClearCredentialCache(team db.Team) http.Handler {
+	logger := s.logger.Session("clear-credential-cache")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.FormValue(atc.CredentialCacheQueryPath)
+
+		err := s.cacheInvalidationFactory.Invalidate(team.Name(), path)
+		if err != nil {
+			logger.Error("failed-to-invalidate-cache", err, lager.Data{"team": team.Name(), "path": path})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
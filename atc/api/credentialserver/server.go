@@ -0,0 +1,43 @@
+package credentialserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/patrickmn/go-cache"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	pipelineFactory          db.PipelineFactory
+	credentialUsageFactory   db.CredentialUsageFactory
+	cacheInvalidationFactory db.CacheInvalidationFactory
+	secretManager            creds.Secrets
+	varSourcePool            creds.VarSourcePool
+	leasedVars               *creds.LeasedVars
+
+	// the outcome of resolving each var of a pipeline, see ResolutionTTL
+	resolutions *cache.Cache
+}
+
+func NewServer(
+	logger lager.Logger,
+	pipelineFactory db.PipelineFactory,
+	credentialUsageFactory db.CredentialUsageFactory,
+	cacheInvalidationFactory db.CacheInvalidationFactory,
+	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	leasedVars *creds.LeasedVars,
+) *Server {
+	return &Server{
+		logger:                   logger,
+		pipelineFactory:          pipelineFactory,
+		credentialUsageFactory:   credentialUsageFactory,
+		cacheInvalidationFactory: cacheInvalidationFactory,
+		secretManager:            secretManager,
+		varSourcePool:            varSourcePool,
+		leasedVars:               leasedVars,
+		resolutions:              cache.New(ResolutionTTL, 2*ResolutionTTL),
+	}
+}
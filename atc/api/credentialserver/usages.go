@@ -0,0 +1,176 @@
+package credentialserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+	"github.com/patrickmn/go-cache"
+)
+
+// ResolutionTTL is how long the outcome of resolving a var is reused for, so
+// that listing the usages repeatedly doesn't hammer the credential managers.
+var ResolutionTTL = time.Minute
+
+func (s *Server) ListTeamCredentialUsages(team db.Team) http.Handler {
+	logger := s.logger.Session("list-team-credential-usages")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usages, err := s.credentialUsageFactory.TeamCredentialUsages(team.ID(), r.FormValue(atc.CredentialUsagesQueryPath))
+		if err != nil {
+			logger.Error("failed-to-get-credential-usages", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if _, resolve := r.URL.Query()[atc.CredentialUsagesResolve]; resolve {
+			pipelines, err := team.Pipelines()
+			if err != nil {
+				logger.Error("failed-to-get-pipelines", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			s.resolve(logger, usages, pipelines)
+		}
+
+		s.respond(logger, w, usages)
+	})
+}
+
+func (s *Server) ListAllCredentialUsages(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-all-credential-usages")
+
+	usages, err := s.credentialUsageFactory.AllCredentialUsages(r.FormValue(atc.CredentialUsagesQueryPath))
+	if err != nil {
+		logger.Error("failed-to-get-credential-usages", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if _, resolve := r.URL.Query()[atc.CredentialUsagesResolve]; resolve {
+		pipelines, err := s.pipelineFactory.AllPipelines()
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.resolve(logger, usages, pipelines)
+	}
+
+	s.respond(logger, w, usages)
+}
+
+func (s *Server) respond(logger lager.Logger, w http.ResponseWriter, usages []atc.CredentialUsage) {
+	if usages == nil {
+		usages = []atc.CredentialUsage{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(usages)
+	if err != nil {
+		logger.Error("failed-to-encode-credential-usages", err)
+	}
+}
+
+type resolution struct {
+	unresolved bool
+	resolvedBy string
+}
+
+// resolve looks up each var through the variables of the pipeline using it,
+// the same way a build would, and flags the ones which are missing or fail
+// to be looked up. The outcome for each var of a pipeline is reused for
+// ResolutionTTL. Vars known to be backed by a lease aren't looked up, so
+// they're never flagged.
+//
+// When the cluster's credential manager is a chain of several, the vars it
+// resolved are also marked with the manager they were resolved by.
+func (s *Server) resolve(logger lager.Logger, usages []atc.CredentialUsage, pipelines []db.Pipeline) {
+	pipelinesByID := map[int]db.Pipeline{}
+	for _, pipeline := range pipelines {
+		pipelinesByID[pipeline.ID()] = pipeline
+	}
+
+	variables := map[int]vars.Variables{}
+
+	for i, usage := range usages {
+		key := fmt.Sprintf("%d/%s:%s", usage.PipelineID, usage.VarSource, usage.Path)
+
+		cached, found := s.resolutions.Get(key)
+		if !found {
+			pipelineVars, created := variables[usage.PipelineID]
+			if !created {
+				pipeline, found := pipelinesByID[usage.PipelineID]
+				if !found {
+					// the pipeline went away in the meantime
+					continue
+				}
+
+				var err error
+				pipelineVars, err = pipeline.Variables(logger, s.secretManager, s.varSourcePool)
+				if err != nil {
+					logger.Error("failed-to-create-pipeline-variables", err, lager.Data{"pipeline": pipeline.Name()})
+				} else {
+					pipelineVars = creds.NewUnleasedVariables(logger, s.leasedVars, pipelineVars, pipeline.TeamName(), pipeline.Name())
+				}
+
+				variables[usage.PipelineID] = pipelineVars
+			}
+
+			cached = s.resolveUsage(logger, usage, pipelineVars)
+			s.resolutions.Set(key, cached, cache.DefaultExpiration)
+		}
+
+		usages[i].Unresolved = cached.(resolution).unresolved
+		usages[i].ResolvedBy = cached.(resolution).resolvedBy
+	}
+}
+
+func (s *Server) resolveUsage(logger lager.Logger, usage atc.CredentialUsage, pipelineVars vars.Variables) resolution {
+	if pipelineVars == nil {
+		return resolution{unresolved: true}
+	}
+
+	_, found, err := pipelineVars.Get(vars.Reference{Source: usage.VarSource, Path: usage.Path})
+	if errors.As(err, &creds.LeasedVarError{}) {
+		// vars backed by a lease are only looked up by builds, which have
+		// resolved it
+		return resolution{}
+	}
+
+	if err != nil {
+		logger.Info("failed-to-resolve-var", lager.Data{"path": usage.Path, "error": err.Error()})
+		return resolution{unresolved: true}
+	}
+
+	if !found {
+		return resolution{unresolved: true}
+	}
+
+	if usage.VarSource != "" {
+		return resolution{}
+	}
+
+	return resolution{resolvedBy: s.resolvedBy(usage)}
+}
+
+func (s *Server) resolvedBy(usage atc.CredentialUsage) string {
+	resolving, ok := s.secretManager.(creds.ResolvingSecrets)
+	if !ok {
+		return ""
+	}
+
+	manager, _ := resolving.ResolvedBy(usage.TeamName, usage.PipelineName, usage.Path)
+	return manager
+}
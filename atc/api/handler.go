@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/concourse/atc/api/cliserver"
 	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/credentialserver"
 	"github.com/concourse/concourse/atc/api/infoserver"
 	"github.com/concourse/concourse/atc/api/jobserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
@@ -24,9 +25,11 @@ import (
 	"github.com/concourse/concourse/atc/api/usersserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
 	"github.com/concourse/concourse/atc/api/wallserver"
+	"github.com/concourse/concourse/atc/api/workerkeyserver"
 	"github.com/concourse/concourse/atc/api/workerserver"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/demand"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/mainredirect"
 	"github.com/concourse/concourse/atc/worker"
@@ -55,21 +58,28 @@ func NewHandler(
 	dbCheckFactory db.CheckFactory,
 	dbResourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	dbWorkerKeyFactory db.WorkerKeyFactory,
+	dbCredentialUsageFactory db.CredentialUsageFactory,
+	dbCacheInvalidationFactory db.CacheInvalidationFactory,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
 
 	workerPool worker.Pool,
+	workerDemand demand.Calculator,
 
 	sink *lager.ReconfigurableSink,
 
 	isTLSEnabled bool,
+	requirePinComment bool,
 
 	cliDownloadsDir string,
 	version string,
 	workerVersion string,
 	secretManager creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	leasedVars *creds.LeasedVars,
 	credsManagers creds.Managers,
+	credsChain []string,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
 	dbWall db.Wall,
@@ -85,21 +95,23 @@ func NewHandler(
 	buildHandlerFactory := buildserver.NewScopedHandlerFactory(logger)
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
-	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
+	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory, workerPool)
 	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
 	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
 
-	versionServer := versionserver.NewServer(logger, externalURL)
+	versionServer := versionserver.NewServer(logger, externalURL, requirePinComment)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager, leasedVars)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
-	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory)
+	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory, workerDemand)
+	workerKeyServer := workerkeyserver.NewServer(logger, dbWorkerKeyFactory)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 	containerServer := containerserver.NewServer(logger, workerPool, secretManager, varSourcePool, interceptTimeoutFactory, interceptUpdateInterval, containerRepository, destroyer, clock)
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
 	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
-	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
+	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers, credsChain)
+	credentialServer := credentialserver.NewServer(logger, dbPipelineFactory, dbCredentialUsageFactory, dbCacheInvalidationFactory, secretManager, varSourcePool, leasedVars)
 	artifactServer := artifactserver.NewServer(logger, workerPool)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
 	wallServer := wallserver.NewServer(dbWall, logger)
@@ -119,6 +131,7 @@ func NewHandler(
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
+		atc.GetBuildStepOutput:  buildHandlerFactory.HandlerFor(buildServer.GetBuildStepOutput),
 
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -138,6 +151,8 @@ func NewHandler(
 		},
 
 		atc.ClearTaskCache: pipelineHandlerFactory.HandlerFor(jobServer.ClearTaskCache),
+		atc.ListJobCaches:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobCaches),
+		atc.ClearJobCaches: pipelineHandlerFactory.HandlerFor(jobServer.ClearJobCaches),
 
 		atc.ListAllPipelines:    http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:       http.HandlerFunc(pipelineServer.ListPipelines),
@@ -164,23 +179,36 @@ func NewHandler(
 		atc.CheckResource:           pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook:    pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 		atc.CheckResourceType:       pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
+		atc.ListResourceChecks:      pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceChecks),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
 		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
+		atc.PromoteResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.PromoteResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
 
-		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
-		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
-		atc.LandWorker:      http.HandlerFunc(workerServer.LandWorker),
-		atc.RetireWorker:    http.HandlerFunc(workerServer.RetireWorker),
-		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
-		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
-		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
+		atc.ListWorkers:      http.HandlerFunc(workerServer.ListWorkers),
+		atc.GetWorkerDemand:  http.HandlerFunc(workerServer.GetWorkerDemand),
+		atc.RegisterWorker:   http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:       http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:     http.HandlerFunc(workerServer.RetireWorker),
+		atc.EvictWorker:      http.HandlerFunc(workerServer.EvictWorker),
+		atc.PruneWorker:      http.HandlerFunc(workerServer.PruneWorker),
+		atc.QuarantineWorker: http.HandlerFunc(workerServer.QuarantineWorker),
+		atc.ReleaseWorker:    http.HandlerFunc(workerServer.ReleaseWorker),
+		atc.HeartbeatWorker:  http.HandlerFunc(workerServer.HeartbeatWorker),
+		atc.DeleteWorker:     http.HandlerFunc(workerServer.DeleteWorker),
+
+		atc.CreateWorkerEnrollmentToken: teamHandlerFactory.HandlerFor(workerKeyServer.CreateWorkerEnrollmentToken),
+		atc.ListWorkerKeys:              teamHandlerFactory.HandlerFor(workerKeyServer.ListWorkerKeys),
+		atc.RevokeWorkerKey:             teamHandlerFactory.HandlerFor(workerKeyServer.RevokeWorkerKey),
+		atc.EnrollWorkerKey:             http.HandlerFunc(workerKeyServer.EnrollWorkerKey),
+		atc.AuthorizeWorkerKey:          http.HandlerFunc(workerKeyServer.AuthorizeWorkerKey),
+		atc.UseWorkerKey:                http.HandlerFunc(workerKeyServer.UseWorkerKey),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -189,6 +217,10 @@ func NewHandler(
 		atc.GetInfo:      http.HandlerFunc(infoServer.Info),
 		atc.GetInfoCreds: http.HandlerFunc(infoServer.Creds),
 
+		atc.ListTeamCredentialUsages: teamHandlerFactory.HandlerFor(credentialServer.ListTeamCredentialUsages),
+		atc.ListAllCredentialUsages:  http.HandlerFunc(credentialServer.ListAllCredentialUsages),
+		atc.ClearCredentialCache:     teamHandlerFactory.HandlerFor(credentialServer.ClearCredentialCache),
+
 		atc.GetUser:              http.HandlerFunc(usersServer.GetUser),
 		atc.ListActiveUsersSince: http.HandlerFunc(usersServer.GetUsersSince),
 
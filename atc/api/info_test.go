@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	awsssm "github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/concourse/concourse/atc/api/infoserver"
 	"github.com/concourse/concourse/atc/creds/credhub"
 	"github.com/concourse/concourse/atc/creds/secretsmanager"
 	"github.com/concourse/concourse/atc/creds/ssm"
@@ -289,6 +291,44 @@ var _ = Describe("Pipelines API", func() {
         }`))
 				})
 			})
+
+			Context("when vault is part of a chain of credential managers", func() {
+				BeforeEach(func() {
+					credServer.RouteToHandler("GET", "/v1/sys/health", ghttp.RespondWithJSONEncoded(
+						http.StatusOK,
+						&vaultapi.HealthResponse{Initialized: true},
+					))
+				})
+
+				It("returns the health of each manager in the chain, in order", func() {
+					infoServer := infoserver.NewServer(lager.NewLogger("test"), "1.2.3", "4.5.6", "https://example.com", "Test Cluster", credsManagers, []string{"vault", "credhub"})
+
+					recorder := httptest.NewRecorder()
+					infoServer.Creds(recorder, httptest.NewRequest("GET", "/api/v1/info/creds", nil))
+
+					body := recorder.Body.Bytes()
+
+					var chainBody struct {
+						Chain []struct {
+							Name   string `json:"name"`
+							Health struct {
+								Error  string `json:"error"`
+								Method string `json:"method"`
+							} `json:"health"`
+						} `json:"chain"`
+					}
+
+					err := json.Unmarshal(body, &chainBody)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(chainBody.Chain).To(HaveLen(2))
+					Expect(chainBody.Chain[0].Name).To(Equal("vault"))
+					Expect(chainBody.Chain[0].Health.Method).To(Equal("/v1/sys/health"))
+					Expect(chainBody.Chain[0].Health.Error).To(BeEmpty())
+					Expect(chainBody.Chain[1].Name).To(Equal("credhub"))
+					Expect(chainBody.Chain[1].Health.Error).To(Equal("not configured"))
+				})
+			})
 		})
 
 		Context("credhub", func() {
@@ -9,13 +9,15 @@ import (
 
 // Creds returns information on the credential manager attached to this instance of concourse.
 // If no credential manager is configured the response will be empty.
+// When a chain of credential managers is configured, the health of each of
+// them is listed under "chain", in order.
 // No actual credentials are shown in the response.
 func (s *Server) Creds(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("creds")
 
 	w.Header().Set("Content-Type", "application/json")
 
-	configuredManagers := make(creds.Managers)
+	configuredManagers := map[string]interface{}{}
 
 	for name, manager := range s.credsManagers {
 		if manager.IsConfigured() {
@@ -23,9 +25,37 @@ func (s *Server) Creds(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if len(s.credsChain) > 0 {
+		chain := []chainedManager{}
+		for _, name := range s.credsChain {
+			chained := chainedManager{Name: name}
+
+			manager, found := s.credsManagers[name]
+			if !found || !manager.IsConfigured() {
+				chained.Health = &creds.HealthResponse{Error: "not configured"}
+			} else {
+				health, err := manager.Health()
+				if err != nil {
+					health = &creds.HealthResponse{Error: err.Error()}
+				}
+
+				chained.Health = health
+			}
+
+			chain = append(chain, chained)
+		}
+
+		configuredManagers["chain"] = chain
+	}
+
 	err := json.NewEncoder(w).Encode(configuredManagers)
 	if err != nil {
 		logger.Error("failed-to-encode-info", err)
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+type chainedManager struct {
+	Name   string                `json:"name"`
+	Health *creds.HealthResponse `json:"health"`
+}
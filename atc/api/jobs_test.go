@@ -2540,6 +2540,196 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/caches")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakePipeline.JobReturns(fakeJob, true, nil)
+			})
+
+			Context("when the job has caches", func() {
+				BeforeEach(func() {
+					fakeJob.KeyedCachesReturns([]db.KeyedCache{
+						{
+							ID:         1,
+							Path:       ".m2",
+							Key:        "maven-abc",
+							Size:       1024,
+							WorkerName: "some-worker",
+							CreatedAt:  time.Unix(100, 0),
+							LastUsed:   time.Unix(200, 0),
+						},
+					}, nil)
+				})
+
+				It("finds the right job", func() {
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("job-name"))
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the caches", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 1,
+							"path": ".m2",
+							"key": "maven-abc",
+							"size": 1024,
+							"worker_name": "some-worker",
+							"created_at": 100,
+							"last_used": 200
+						}
+					]`))
+				})
+			})
+
+			Context("when the job has no caches", func() {
+				It("returns an empty list", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns a 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the caches fails", func() {
+				BeforeEach(func() {
+					fakeJob.KeyedCachesReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns a 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Status Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/job-name/caches", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakePipeline.JobReturns(fakeJob, true, nil)
+				fakeJob.ClearKeyedCachesReturns(2, nil)
+			})
+
+			Context("when no key is passed", func() {
+				It("clears all of the job's caches", func() {
+					Expect(fakeJob.ClearKeyedCachesCallCount()).To(Equal(1))
+					Expect(fakeJob.ClearKeyedCachesArgsForCall(0)).To(Equal(""))
+				})
+
+				It("returns the number of caches removed", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{"caches_removed": 2}`))
+				})
+			})
+
+			Context("when a key is passed", func() {
+				BeforeEach(func() {
+					query := request.URL.Query()
+					query.Add(atc.ClearJobCachesQueryKey, "maven-abc")
+					request.URL.RawQuery = query.Encode()
+				})
+
+				It("clears the caches under the key", func() {
+					Expect(fakeJob.ClearKeyedCachesCallCount()).To(Equal(1))
+					Expect(fakeJob.ClearKeyedCachesArgsForCall(0)).To(Equal("maven-abc"))
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns a 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when clearing the caches fails", func() {
+				BeforeEach(func() {
+					fakeJob.ClearKeyedCachesReturns(0, errors.New("some-error"))
+				})
+
+				It("returns a 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Status Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule", func() {
 		var response *http.Response
 
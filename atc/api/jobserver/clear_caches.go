@@ -0,0 +1,56 @@
+package jobserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/google/jsonapi"
+)
+
+func (s *Server) ClearJobCaches(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("clear-job-caches")
+		jobName := r.FormValue(":job_name")
+		key := r.FormValue(atc.ClearJobCachesQueryKey)
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("could-not-find-job", lager.Data{
+				"jobName": jobName,
+				"key":     key,
+			})
+			w.Header().Set("Content-Type", jsonapi.MediaType)
+			w.WriteHeader(http.StatusNotFound)
+			_ = jsonapi.MarshalErrors(w, []*jsonapi.ErrorObject{{
+				Title:  "Job Not Found Error",
+				Detail: fmt.Sprintf("Job with name '%s' not found.", jobName),
+				Status: "404",
+			}})
+			return
+		}
+
+		rowsDeleted, err := job.ClearKeyedCaches(key)
+		if err != nil {
+			logger.Error("failed-to-clear-keyed-caches", err)
+			w.Header().Set("Content-Type", jsonapi.MediaType)
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = jsonapi.MarshalErrors(w, []*jsonapi.ErrorObject{{
+				Title:  "Clear Job Caches Error",
+				Detail: err.Error(),
+				Status: "500",
+			}})
+			return
+		}
+
+		s.writeJSONResponse(w, atc.ClearTaskCacheResponse{CachesRemoved: rowsDeleted})
+	})
+}
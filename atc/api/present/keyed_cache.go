@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func KeyedCache(cache db.KeyedCache) atc.KeyedCache {
+	return atc.KeyedCache{
+		ID:         cache.ID,
+		Path:       cache.Path,
+		Key:        cache.Key,
+		Size:       cache.Size,
+		WorkerName: cache.WorkerName,
+		CreatedAt:  cache.CreatedAt.Unix(),
+		LastUsed:   cache.LastUsed.Unix(),
+	}
+}
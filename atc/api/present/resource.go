@@ -30,6 +30,11 @@ func Resource(resource db.Resource) atc.Resource {
 	} else if resource.APIPinnedVersion() != nil {
 		atcResource.PinnedVersion = resource.APIPinnedVersion()
 		atcResource.PinnedInConfig = false
+		atcResource.PinnedBy = resource.PinnedBy()
+
+		if !resource.PinExpiresAt().IsZero() {
+			atcResource.PinExpiresAt = resource.PinExpiresAt().Unix()
+		}
 	}
 
 	return atcResource
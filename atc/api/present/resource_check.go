@@ -0,0 +1,42 @@
+package present
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func ResourceCheck(check db.ResourceCheck) atc.ResourceCheck {
+	build := check.Build
+
+	eventsURL, err := atc.Routes.CreatePathForRoute(atc.BuildEvents, rata.Params{
+		"build_id": strconv.Itoa(build.ID()),
+	})
+	if err != nil {
+		panic("failed to generate url: " + err.Error())
+	}
+
+	atcCheck := atc.ResourceCheck{
+		ID:            build.ID(),
+		Status:        atc.BuildStatus(build.Status()),
+		VersionsFound: check.VersionsFound,
+		LatestVersion: check.LatestVersion,
+		EventsURL:     eventsURL,
+	}
+
+	if !build.StartTime().IsZero() {
+		atcCheck.StartTime = build.StartTime().Unix()
+	}
+
+	if !build.EndTime().IsZero() {
+		atcCheck.EndTime = build.EndTime().Unix()
+	}
+
+	if atcCheck.StartTime != 0 && atcCheck.EndTime != 0 {
+		atcCheck.Duration = atcCheck.EndTime - atcCheck.StartTime
+	}
+
+	return atcCheck
+}
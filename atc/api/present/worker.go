@@ -40,8 +40,19 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		State:            string(workerInfo.State()),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Rootless:         workerInfo.Rootless(),
+		Health: &atc.WorkerHealth{
+			Score:                     workerInfo.HealthScore(),
+			ContainerCreationFailures: workerInfo.ContainerCreationFailures(),
+			VolumeStreamingFailures:   workerInfo.VolumeStreamingFailures(),
+			ErroredSteps:              workerInfo.ErroredSteps(),
+			Quarantined:               workerInfo.Quarantined(),
+		},
 	}
 
+	atcWorker.SupportedContainerLimits = workerInfo.SupportedContainerLimits()
+	atcWorker.Features = workerInfo.Features()
+
 	if !workerInfo.StartTime().IsZero() {
 		atcWorker.StartTime = workerInfo.StartTime().Unix()
 	}
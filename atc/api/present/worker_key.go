@@ -0,0 +1,26 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func WorkerKey(key db.WorkerKey) atc.WorkerKey {
+	presented := atc.WorkerKey{
+		ID:          key.ID,
+		TeamName:    key.TeamName,
+		PublicKey:   key.PublicKey,
+		Fingerprint: key.Fingerprint,
+		CreatedAt:   key.CreatedAt.Unix(),
+	}
+
+	if !key.LastUsedAt.IsZero() {
+		presented.LastUsedAt = key.LastUsedAt.Unix()
+	}
+
+	if !key.RevokedAt.IsZero() {
+		presented.RevokedAt = key.RevokedAt.Unix()
+	}
+
+	return presented
+}
@@ -572,6 +572,18 @@ var _ = Describe("Resources API", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 						})
 					})
+
+					Context("when clearing the pin comment", func() {
+						BeforeEach(func() {
+							pinCommentRequestBody.PinComment = ""
+						})
+
+						It("clears the pin comment", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeResource.SetPinCommentCallCount()).To(Equal(1))
+							Expect(fakeResource.SetPinCommentArgsForCall(0)).To(BeEmpty())
+						})
+					})
 				})
 
 				Context("when it fails to find the resource", func() {
@@ -767,6 +779,143 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when looking up the resource fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when it finds the resource", func() {
+				var fakeResource *dbfakes.FakeResource
+
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				Context("when getting the check history fails", func() {
+					BeforeEach(func() {
+						fakeResource.CheckHistoryReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when getting the check history succeeds", func() {
+					BeforeEach(func() {
+						succeededBuild := new(dbfakes.FakeBuild)
+						succeededBuild.IDReturns(12)
+						succeededBuild.StatusReturns(db.BuildStatusSucceeded)
+						succeededBuild.StartTimeReturns(time.Unix(100, 0))
+						succeededBuild.EndTimeReturns(time.Unix(142, 0))
+
+						runningBuild := new(dbfakes.FakeBuild)
+						runningBuild.IDReturns(13)
+						runningBuild.StatusReturns(db.BuildStatusStarted)
+						runningBuild.StartTimeReturns(time.Unix(200, 0))
+
+						fakeResource.CheckHistoryReturns([]db.ResourceCheck{
+							{Build: runningBuild},
+							{
+								Build:         succeededBuild,
+								VersionsFound: 2,
+								LatestVersion: atc.Version{"ref": "abc"},
+							},
+						}, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns Content-Type 'application/json'", func() {
+						expectedHeaderEntries := map[string]string{
+							"Content-Type": "application/json",
+						}
+						Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+					})
+
+					It("returns the checks", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"id": 13,
+								"status": "started",
+								"start_time": 200,
+								"versions_found": 0,
+								"events_url": "/api/v1/builds/13/events"
+							},
+							{
+								"id": 12,
+								"status": "succeeded",
+								"start_time": 100,
+								"end_time": 142,
+								"duration": 42,
+								"versions_found": 2,
+								"latest_version": {"ref": "abc"},
+								"events_url": "/api/v1/builds/12/events"
+							}
+						]`))
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types", func() {
 		var response *http.Response
 
@@ -1088,6 +1237,58 @@ var _ = Describe("Resources API", func() {
 			})
 
 			Context("when the call to get a resource succeeds", func() {
+				Context("when the resource has been checked", func() {
+					var resource1 *dbfakes.FakeResource
+
+					BeforeEach(func() {
+						resource1 = new(dbfakes.FakeResource)
+						resource1.IDReturns(1)
+						resource1.TeamNameReturns("a-team")
+						resource1.PipelineIDReturns(1)
+						resource1.PipelineNameReturns("a-pipeline")
+						resource1.NameReturns("resource-1")
+						resource1.TypeReturns("type-1")
+						resource1.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+
+						fakePipeline.ResourceReturns(resource1, true, nil)
+
+						dbCheckFactory.PrioritizedResourceIDsReturns(map[int]bool{1: true}, nil)
+						dbCheckFactory.NextCheckTimeReturns(time.Unix(1513364941, 0))
+					})
+
+					It("returns the effective next check time", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"name": "resource-1",
+							"pipeline_id": 1,
+							"pipeline_name": "a-pipeline",
+							"team_name": "a-team",
+							"type": "type-1",
+							"last_checked": 1513364881,
+							"next_check": 1513364941
+						}`))
+					})
+
+					It("takes the priority of the resource into account", func() {
+						Expect(dbCheckFactory.NextCheckTimeCallCount()).To(Equal(1))
+						checkable, prioritized := dbCheckFactory.NextCheckTimeArgsForCall(0)
+						Expect(checkable).To(Equal(resource1))
+						Expect(prioritized).To(BeTrue())
+					})
+
+					Context("when getting the prioritized resources fails", func() {
+						BeforeEach(func() {
+							dbCheckFactory.PrioritizedResourceIDsReturns(nil, errors.New("nope"))
+						})
+
+						It("returns a 500 error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
 				Context("when the resource version is pinned via pipeline config", func() {
 					BeforeEach(func() {
 						resource1 := new(dbfakes.FakeResource)
@@ -1204,6 +1405,40 @@ var _ = Describe("Resources API", func() {
 							}`))
 					})
 				})
+
+				Context("when the resource has a pin that expires", func() {
+					BeforeEach(func() {
+						resource1 := new(dbfakes.FakeResource)
+						resource1.TeamNameReturns("a-team")
+						resource1.PipelineIDReturns(1)
+						resource1.PipelineNameReturns("a-pipeline")
+						resource1.NameReturns("resource-1")
+						resource1.TypeReturns("type-1")
+						resource1.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+						resource1.APIPinnedVersionReturns(atc.Version{"version": "v1"})
+						resource1.PinnedByReturns("some-user")
+						resource1.PinExpiresAtReturns(time.Unix(1513368481, 0))
+						fakePipeline.ResourceReturns(resource1, true, nil)
+					})
+
+					It("returns who pinned it and when the pin expires in the response json", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_id": 1,
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"last_checked": 1513364881,
+								"pinned_version": {"version": "v1"},
+								"pinned_by": "some-user",
+								"pin_expires_at": 1513368481
+							}`))
+					})
+				})
 			})
 		})
 
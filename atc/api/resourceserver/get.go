@@ -30,7 +30,15 @@ func (s *Server) GetResource(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		prioritized, err := s.checkFactory.PrioritizedResourceIDs()
+		if err != nil {
+			logger.Error("failed-to-get-prioritized-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		resource := present.Resource(dbResource)
+		resource.NextCheck = s.nextCheck(dbResource, prioritized)
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
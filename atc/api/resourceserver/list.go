@@ -20,12 +20,19 @@ func (s *Server) ListResources(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		prioritized, err := s.checkFactory.PrioritizedResourceIDs()
+		if err != nil {
+			logger.Error("failed-to-get-prioritized-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		presentedResources := []atc.Resource{}
 		for _, resource := range resources {
-			presentedResources = append(
-				presentedResources,
-				present.Resource(resource),
-			)
+			presentedResource := present.Resource(resource)
+			presentedResource.NextCheck = s.nextCheck(resource, prioritized)
+
+			presentedResources = append(presentedResources, presentedResource)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
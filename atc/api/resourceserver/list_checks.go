@@ -0,0 +1,55 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListResourceChecks(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		logger := s.logger.Session("list-resource-checks", lager.Data{
+			"resource": resourceName,
+		})
+
+		dbResource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		checks, err := dbResource.CheckHistory()
+		if err != nil {
+			logger.Error("failed-to-get-check-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedChecks := []atc.ResourceCheck{}
+		for _, check := range checks {
+			presentedChecks = append(presentedChecks, present.ResourceCheck(check))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presentedChecks)
+		if err != nil {
+			logger.Error("failed-to-encode-resource-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -32,3 +32,16 @@ func NewServer(
 		resourceConfigFactory: resourceConfigFactory,
 	}
 }
+
+func (s *Server) nextCheck(resource db.Resource, prioritized map[int]bool) int64 {
+	if resource.LastCheckEndTime().IsZero() {
+		return 0
+	}
+
+	next := s.checkFactory.NextCheckTime(resource, prioritized[resource.ID()])
+	if next.IsZero() {
+		return 0
+	}
+
+	return next.Unix()
+}
@@ -1,16 +1,48 @@
 package versionserver
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
 func (s *Server) PinResourceVersion(pipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("pin-resource-version")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody atc.PinVersionRequestBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if s.requirePinComment && reqBody.PinComment == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "a pin comment is required to pin a version")
+			return
+		}
+
+		var expiresAt time.Time
+		if reqBody.ExpiresIn != "" {
+			expiresIn, err := time.ParseDuration(reqBody.ExpiresIn)
+			if err != nil || expiresIn <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "invalid expiry '%s': must be a positive duration\n", reqBody.ExpiresIn)
+				return
+			}
+
+			expiresAt = time.Now().Add(expiresIn)
+		}
+
 		resourceName := r.FormValue(":resource_name")
 		resource, found, err := pipeline.Resource(resourceName)
 		if err != nil {
@@ -30,7 +62,12 @@ func (s *Server) PinResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		found, err = resource.PinVersion(resourceConfigVersionID)
+		acc := accessor.GetAccessor(r)
+		found, err = resource.PinVersion(resourceConfigVersionID, db.VersionPin{
+			PinnedBy:  acc.UserInfo().DisplayUserId,
+			Comment:   reqBody.PinComment,
+			ExpiresAt: expiresAt,
+		})
 		if err != nil {
 			logger.Error("failed-to-pin-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,128 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) PromoteResourceVersion(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("promote-resource-version")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resourceConfigVersionID, err := strconv.Atoi(r.FormValue(":resource_config_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		jobName := r.URL.Query().Get("job")
+		if jobName == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "job must be specified")
+			return
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("job-not-found", lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		usesResource, err := jobUsesResource(job, resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-job-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !usesResource {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "job '%s' does not interact with resource '%s'\n", jobName, resourceName)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+		build, found, err := job.PromoteVersion(resource, resourceConfigVersionID, acc.UserInfo().DisplayUserId)
+		if err != nil {
+			logger.Error("failed-to-promote-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-version-id-not-found", lager.Data{"resource_config_version_id": resourceConfigVersionID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Info("promoted-resource-version", lager.Data{
+			"resource":                   resourceName,
+			"resource_config_version_id": resourceConfigVersionID,
+			"job":                        jobName,
+			"build":                      build.ID(),
+			"user":                       acc.UserInfo().DisplayUserId,
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(present.Build(build))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func jobUsesResource(job db.Job, resourceName string) (bool, error) {
+	inputs, err := job.Inputs()
+	if err != nil {
+		return false, err
+	}
+
+	for _, input := range inputs {
+		if input.Resource == resourceName {
+			return true, nil
+		}
+	}
+
+	outputs, err := job.Outputs()
+	if err != nil {
+		return false, err
+	}
+
+	for _, output := range outputs {
+		if output.Resource == resourceName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -5,13 +5,15 @@ import (
 )
 
 type Server struct {
-	logger      lager.Logger
-	externalURL string
+	logger            lager.Logger
+	externalURL       string
+	requirePinComment bool
 }
 
-func NewServer(logger lager.Logger, externalURL string) *Server {
+func NewServer(logger lager.Logger, externalURL string, requirePinComment bool) *Server {
 	return &Server{
-		logger:      logger,
-		externalURL: externalURL,
+		logger:            logger,
+		externalURL:       externalURL,
+		requirePinComment: requirePinComment,
 	}
 }
@@ -1,13 +1,18 @@
 package api_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
@@ -678,14 +683,50 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("pinning when a pin comment is required", func() {
+		var (
+			fakeResource *dbfakes.FakeResource
+			recorder     *httptest.ResponseRecorder
+			requestBody  string
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+			recorder = httptest.NewRecorder()
+		})
+
+		JustBeforeEach(func() {
+			request := httptest.NewRequest("PUT", "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", strings.NewReader(requestBody))
+			versionserver.NewServer(logger, "", true).PinResourceVersion(fakePipeline).ServeHTTP(recorder, request)
+		})
+
+		Context("when no comment is given", func() {
+			BeforeEach(func() {
+				requestBody = `{"expires_in":"4h"}`
+			})
+
+			It("returns 400 without pinning", func() {
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(recorder.Body.String()).To(ContainSubstring("a pin comment is required"))
+				Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
 		var response *http.Response
 		var fakeResource *dbfakes.FakeResource
+		var requestBody io.Reader
+
+		BeforeEach(func() {
+			requestBody = nil
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", requestBody)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -715,8 +756,56 @@ var _ = Describe("Versions API", func() {
 					})
 
 					It("tries to pin the right resource config version", func() {
-						resourceConfigVersionID := fakeResource.PinVersionArgsForCall(0)
+						resourceConfigVersionID, pin := fakeResource.PinVersionArgsForCall(0)
 						Expect(resourceConfigVersionID).To(Equal(42))
+						Expect(pin).To(Equal(db.VersionPin{}))
+					})
+
+					Context("when the pin has a comment and an expiry", func() {
+						BeforeEach(func() {
+							fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+							requestBody = strings.NewReader(`{"pin_comment":"hotfix","expires_in":"4h"}`)
+						})
+
+						It("pins the version with who pinned it and when it expires", func() {
+							_, pin := fakeResource.PinVersionArgsForCall(0)
+							Expect(pin.PinnedBy).To(Equal("some-user"))
+							Expect(pin.Comment).To(Equal("hotfix"))
+							Expect(pin.ExpiresAt).To(BeTemporally("~", time.Now().Add(4*time.Hour), time.Minute))
+						})
+					})
+
+					Context("when the expiry is not a valid duration", func() {
+						BeforeEach(func() {
+							requestBody = strings.NewReader(`{"expires_in":"forever"}`)
+						})
+
+						It("returns 400 without pinning", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the expiry is not positive", func() {
+						BeforeEach(func() {
+							requestBody = strings.NewReader(`{"expires_in":"-1h"}`)
+						})
+
+						It("returns 400 without pinning", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							requestBody = strings.NewReader(`{`)
+						})
+
+						It("returns 400 without pinning", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+						})
 					})
 
 					Context("when pinning the resource succeeds", func() {
@@ -792,6 +881,182 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/promote", func() {
+		var response *http.Response
+		var query string
+		var fakeResource *dbfakes.FakeResource
+		var fakeJob *dbfakes.FakeJob
+
+		BeforeEach(func() {
+			query = "?job=some-job"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/promote"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+					fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+				})
+
+				Context("when the resource and job exist", func() {
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakeResource.IDReturns(1)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+						fakeJob = new(dbfakes.FakeJob)
+						fakeJob.InputsReturns([]atc.JobInput{{Name: "some-input", Resource: "resource-name"}}, nil)
+						fakePipeline.JobReturns(fakeJob, true, nil)
+					})
+
+					It("looks up the job", func() {
+						Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+					})
+
+					Context("when promoting the version succeeds", func() {
+						BeforeEach(func() {
+							fakeBuild := new(dbfakes.FakeBuild)
+							fakeBuild.IDReturns(7)
+							fakeBuild.NameReturns("3")
+							fakeBuild.JobNameReturns("some-job")
+							fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+							fakeJob.PromoteVersionReturns(fakeBuild, true, nil)
+						})
+
+						It("promotes the right resource config version as the current user", func() {
+							Expect(fakeJob.PromoteVersionCallCount()).To(Equal(1))
+							resource, resourceConfigVersionID, createdBy := fakeJob.PromoteVersionArgsForCall(0)
+							Expect(resource).To(Equal(fakeResource))
+							Expect(resourceConfigVersionID).To(Equal(42))
+							Expect(createdBy).To(Equal("some-user"))
+						})
+
+						It("returns 200 with the promotion build", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							var build atc.Build
+							err := json.NewDecoder(response.Body).Decode(&build)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(build.ID).To(Equal(7))
+							Expect(build.Status).To(Equal(atc.StatusSucceeded))
+						})
+					})
+
+					Context("when the version does not belong to the resource", func() {
+						BeforeEach(func() {
+							fakeJob.PromoteVersionReturns(nil, false, nil)
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when promoting the version fails", func() {
+						BeforeEach(func() {
+							fakeJob.PromoteVersionReturns(nil, false, errors.New("welp"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the job does not interact with the resource", func() {
+						BeforeEach(func() {
+							fakeJob.InputsReturns(nil, nil)
+							fakeJob.OutputsReturns([]atc.JobOutput{{Name: "other", Resource: "other-resource"}}, nil)
+						})
+
+						It("returns 400 without promoting", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeJob.PromoteVersionCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the job only outputs to the resource", func() {
+						BeforeEach(func() {
+							fakeJob.InputsReturns(nil, nil)
+							fakeJob.OutputsReturns([]atc.JobOutput{{Name: "some-output", Resource: "resource-name"}}, nil)
+							fakeJob.PromoteVersionReturns(new(dbfakes.FakeBuild), true, nil)
+						})
+
+						It("promotes the version", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeJob.PromoteVersionCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when the job is not specified", func() {
+					BeforeEach(func() {
+						query = ""
+						fakePipeline.ResourceReturns(new(dbfakes.FakeResource), true, nil)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the job is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(new(dbfakes.FakeResource), true, nil)
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
@@ -0,0 +1,405 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Worker Keys API", func() {
+	var response *http.Response
+
+	Describe("POST /api/v1/teams/:team_name/worker-enrollment-tokens", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+
+			dbTeam.NameReturns("some-team")
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/teams/some-team/worker-enrollment-tokens"+query, "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			var expires time.Time
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+
+				expires = time.Unix(1613140000, 0)
+				dbWorkerKeyFactory.CreateEnrollmentTokenReturns("some-token", expires, nil)
+			})
+
+			It("returns 201 with the token", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`{
+					"token": "some-token",
+					"team_name": "some-team",
+					"expires_at": 1613140000
+				}`))
+			})
+
+			It("creates a token for the team expiring in an hour", func() {
+				teamID, ttl := dbWorkerKeyFactory.CreateEnrollmentTokenArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(ttl).To(Equal(time.Hour))
+			})
+
+			Context("with a ttl", func() {
+				BeforeEach(func() {
+					query = "?ttl=10m"
+				})
+
+				It("creates a token expiring after the ttl", func() {
+					_, ttl := dbWorkerKeyFactory.CreateEnrollmentTokenArgsForCall(0)
+					Expect(ttl).To(Equal(10 * time.Minute))
+				})
+			})
+
+			Context("with a ttl longer than a day", func() {
+				BeforeEach(func() {
+					query = "?ttl=25h"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbWorkerKeyFactory.CreateEnrollmentTokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when creating the token fails", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.CreateEnrollmentTokenReturns("", time.Time{}, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/worker-keys", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/worker-keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbWorkerKeyFactory.TeamWorkerKeysReturns([]db.WorkerKey{
+					{
+						ID:          1,
+						TeamID:      734,
+						TeamName:    "some-team",
+						PublicKey:   "ssh-ed25519 AAAA",
+						Fingerprint: "SHA256:some-fingerprint",
+						CreatedAt:   time.Unix(1000, 0),
+						LastUsedAt:  time.Unix(2000, 0),
+					},
+					{
+						ID:          2,
+						TeamID:      734,
+						TeamName:    "some-team",
+						PublicKey:   "ssh-ed25519 BBBB",
+						Fingerprint: "SHA256:other-fingerprint",
+						CreatedAt:   time.Unix(3000, 0),
+					},
+				}, nil)
+			})
+
+			It("returns the team's keys", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbWorkerKeyFactory.TeamWorkerKeysArgsForCall(0)).To(Equal(734))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 1,
+						"team_name": "some-team",
+						"public_key": "ssh-ed25519 AAAA",
+						"fingerprint": "SHA256:some-fingerprint",
+						"created_at": 1000,
+						"last_used_at": 2000
+					},
+					{
+						"id": 2,
+						"team_name": "some-team",
+						"public_key": "ssh-ed25519 BBBB",
+						"fingerprint": "SHA256:other-fingerprint",
+						"created_at": 3000
+					}
+				]`))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/worker-keys/:worker_key_id", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/worker-keys/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWorkerKeyFactory.RevokeWorkerKeyCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+				dbWorkerKeyFactory.RevokeWorkerKeyReturns(true, nil)
+			})
+
+			It("revokes the team's key", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				teamID, id := dbWorkerKeyFactory.RevokeWorkerKeyArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(id).To(Equal(42))
+			})
+
+			Context("when the key is not found", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.RevokeWorkerKeyReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/worker-keys/enroll", func() {
+		var enrollment atc.WorkerKeyEnrollment
+
+		BeforeEach(func() {
+			enrollment = atc.WorkerKeyEnrollment{
+				Token:     "some-token",
+				PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFkKmHiUZn1xRtmrI8bvtQ1C8WD4xGspjNNm/i9bGLMX some-comment",
+			}
+
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(enrollment)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Post(server.URL+"/api/v1/worker-keys/enroll", "application/json", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not system", func() {
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWorkerKeyFactory.EnrollWorkerKeyCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+
+				dbWorkerKeyFactory.EnrollWorkerKeyReturns(db.WorkerKey{
+					ID:          1,
+					TeamName:    "some-team",
+					PublicKey:   "ssh-ed25519 AAAA",
+					Fingerprint: "SHA256:some-fingerprint",
+					CreatedAt:   time.Unix(1000, 0),
+				}, true, nil)
+			})
+
+			It("enrolls the key with its fingerprint", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				token, publicKey, fingerprint := dbWorkerKeyFactory.EnrollWorkerKeyArgsForCall(0)
+				Expect(token).To(Equal("some-token"))
+				Expect(publicKey).To(Equal("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFkKmHiUZn1xRtmrI8bvtQ1C8WD4xGspjNNm/i9bGLMX"))
+				Expect(fingerprint).To(HavePrefix("SHA256:"))
+			})
+
+			Context("when the public key is malformed", func() {
+				BeforeEach(func() {
+					enrollment.PublicKey = "bogus"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbWorkerKeyFactory.EnrollWorkerKeyCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the token is invalid", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.EnrollWorkerKeyReturns(db.WorkerKey{}, false, nil)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when the key is already enrolled", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.EnrollWorkerKeyReturns(db.WorkerKey{}, false, db.ErrWorkerKeyAlreadyEnrolled)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/worker-keys/authorized", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/worker-keys/authorized?fingerprint=SHA256%3Asome%2Ffingerprint")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not system", func() {
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			Context("when the key is enrolled", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.AuthorizeWorkerKeyReturns(db.WorkerKey{
+						ID:          1,
+						TeamName:    "some-team",
+						PublicKey:   "ssh-ed25519 AAAA",
+						Fingerprint: "SHA256:some/fingerprint",
+						CreatedAt:   time.Unix(1000, 0),
+					}, true, nil)
+				})
+
+				It("returns the key", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbWorkerKeyFactory.AuthorizeWorkerKeyArgsForCall(0)).To(Equal("SHA256:some/fingerprint"))
+
+					var key atc.WorkerKey
+					Expect(json.NewDecoder(response.Body).Decode(&key)).To(Succeed())
+					Expect(key.TeamName).To(Equal("some-team"))
+				})
+			})
+
+			Context("when the key is not enrolled", func() {
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/worker-keys/used", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/worker-keys/used?fingerprint=SHA256%3Asome%2Ffingerprint", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not system", func() {
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWorkerKeyFactory.UseWorkerKeyCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("records that the key was used", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(dbWorkerKeyFactory.UseWorkerKeyArgsForCall(0)).To(Equal("SHA256:some/fingerprint"))
+			})
+
+			Context("when recording fails", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.UseWorkerKeyReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
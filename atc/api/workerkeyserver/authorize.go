@@ -0,0 +1,49 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+)
+
+// AuthorizeWorkerKey is called by the TSA to look up the team of an
+// enrolled key by its fingerprint, both when a worker connects and
+// periodically for as long as it stays connected to find out whether it has
+// been revoked.
+func (s *Server) AuthorizeWorkerKey(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("authorize-worker-key")
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsSystem() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	fingerprint := r.URL.Query().Get("fingerprint")
+	if fingerprint == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	key, found, err := s.workerKeyFactory.AuthorizeWorkerKey(fingerprint)
+	if err != nil {
+		logger.Error("failed-to-authorize-worker-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(present.WorkerKey(key))
+	if err != nil {
+		logger.Error("failed-to-encode-worker-key", err)
+	}
+}
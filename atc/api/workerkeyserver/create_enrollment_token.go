@@ -0,0 +1,60 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	defaultEnrollmentTokenTTL = time.Hour
+	maxEnrollmentTokenTTL     = 24 * time.Hour
+)
+
+func (s *Server) CreateWorkerEnrollmentToken(team db.Team) http.Handler {
+	logger := s.logger.Session("create-worker-enrollment-token")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ttl := defaultEnrollmentTokenTTL
+
+		ttlStr := r.URL.Query().Get("ttl")
+		if len(ttlStr) > 0 {
+			var err error
+			ttl, err = time.ParseDuration(ttlStr)
+			if err != nil || ttl <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "malformed ttl")
+				return
+			}
+
+			if ttl > maxEnrollmentTokenTTL {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "ttl must not exceed %s", maxEnrollmentTokenTTL)
+				return
+			}
+		}
+
+		token, expires, err := s.workerKeyFactory.CreateEnrollmentToken(team.ID(), ttl)
+		if err != nil {
+			logger.Error("failed-to-create-enrollment-token", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(atc.WorkerEnrollmentToken{
+			Token:     token,
+			TeamName:  team.Name(),
+			ExpiresAt: expires.Unix(),
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-enrollment-token", err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/crypto/ssh"
+)
+
+// EnrollWorkerKey is called by the TSA when a worker connects with an
+// enrollment token and a key which isn't authorized yet.
+func (s *Server) EnrollWorkerKey(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("enroll-worker-key")
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsSystem() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var enrollment atc.WorkerKeyEnrollment
+	err := json.NewDecoder(r.Body).Decode(&enrollment)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(enrollment.PublicKey))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid public key: %s", err)
+		return
+	}
+
+	key, enrolled, err := s.workerKeyFactory.EnrollWorkerKey(
+		enrollment.Token,
+		strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		ssh.FingerprintSHA256(publicKey),
+	)
+	if err != nil {
+		if err == db.ErrWorkerKeyAlreadyEnrolled {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		logger.Error("failed-to-enroll-worker-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !enrolled {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, "enrollment token is invalid or has expired")
+		return
+	}
+
+	logger.Info("enrolled", lager.Data{
+		"team":        key.TeamName,
+		"fingerprint": key.Fingerprint,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(present.WorkerKey(key))
+	if err != nil {
+		logger.Error("failed-to-encode-worker-key", err)
+	}
+}
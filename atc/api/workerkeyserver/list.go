@@ -0,0 +1,36 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListWorkerKeys(team db.Team) http.Handler {
+	logger := s.logger.Session("list-worker-keys")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys, err := s.workerKeyFactory.TeamWorkerKeys(team.ID())
+		if err != nil {
+			logger.Error("failed-to-get-worker-keys", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.WorkerKey{}
+		for _, key := range keys {
+			presented = append(presented, present.WorkerKey(key))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-worker-keys", err)
+		}
+	})
+}
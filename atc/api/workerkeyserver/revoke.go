@@ -0,0 +1,37 @@
+package workerkeyserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// RevokeWorkerKey marks the key as revoked, keeping it in the database along
+// with when it was revoked. Workers connected with the key are disconnected
+// by the TSA the next time it checks the key.
+func (s *Server) RevokeWorkerKey(team db.Team) http.Handler {
+	logger := s.logger.Session("revoke-worker-key")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.FormValue(":worker_key_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		revoked, err := s.workerKeyFactory.RevokeWorkerKey(team.ID(), id)
+		if err != nil {
+			logger.Error("failed-to-revoke-worker-key", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !revoked {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,22 @@
+package workerkeyserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	workerKeyFactory db.WorkerKeyFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	workerKeyFactory db.WorkerKeyFactory,
+) *Server {
+	return &Server{
+		logger:           logger,
+		workerKeyFactory: workerKeyFactory,
+	}
+}
@@ -0,0 +1,34 @@
+package workerkeyserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+// UseWorkerKey is called by the TSA once a worker has connected with an
+// enrolled key, to record when the key was last used.
+func (s *Server) UseWorkerKey(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("use-worker-key")
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsSystem() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	fingerprint := r.URL.Query().Get("fingerprint")
+	if fingerprint == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err := s.workerKeyFactory.UseWorkerKey(fingerprint)
+	if err != nil {
+		logger.Error("failed-to-use-worker-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
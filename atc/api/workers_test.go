@@ -46,12 +46,17 @@ var _ = Describe("Workers API", func() {
 				teamWorker1.GardenAddrReturns(&gardenAddr1)
 				bcURL1 := "1.2.3.4:8888"
 				teamWorker1.BaggageclaimURLReturns(&bcURL1)
+				teamWorker1.HealthScoreReturns(1)
 
 				teamWorker2 = new(dbfakes.FakeWorker)
 				gardenAddr2 := "5.6.7.8:7777"
 				teamWorker2.GardenAddrReturns(&gardenAddr2)
 				bcURL2 := "5.6.7.8:8888"
 				teamWorker2.BaggageclaimURLReturns(&bcURL2)
+				teamWorker2.HealthScoreReturns(0.25)
+				teamWorker2.ContainerCreationFailuresReturns(3)
+				teamWorker2.ErroredStepsReturns(1)
+				teamWorker2.QuarantinedReturns(true)
 			})
 
 			It("fetches workers by team name from worker user context", func() {
@@ -87,10 +92,17 @@ var _ = Describe("Workers API", func() {
 						{
 							GardenAddr:      "1.2.3.4:7777",
 							BaggageclaimURL: "1.2.3.4:8888",
+							Health:          &atc.WorkerHealth{Score: 1},
 						},
 						{
 							GardenAddr:      "5.6.7.8:7777",
 							BaggageclaimURL: "5.6.7.8:8888",
+							Health: &atc.WorkerHealth{
+								Score:                     0.25,
+								ContainerCreationFailures: 3,
+								ErroredSteps:              1,
+								Quarantined:               true,
+							},
 						},
 					}))
 				})
@@ -126,10 +138,17 @@ var _ = Describe("Workers API", func() {
 						{
 							GardenAddr:      "1.2.3.4:7777",
 							BaggageclaimURL: "1.2.3.4:8888",
+							Health:          &atc.WorkerHealth{Score: 1},
 						},
 						{
 							GardenAddr:      "5.6.7.8:7777",
 							BaggageclaimURL: "5.6.7.8:8888",
+							Health: &atc.WorkerHealth{
+								Score:                     0.25,
+								ContainerCreationFailures: 3,
+								ErroredSteps:              1,
+								Quarantined:               true,
+							},
 						},
 					}))
 
@@ -158,6 +177,109 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/workers/demand", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/workers/demand")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				fakeWorkerDemand.DemandReturns(atc.WorkerDemand{
+					Pools: []atc.WorkerPoolDemand{
+						{
+							WorkerPool: atc.WorkerPool{
+								Platform: "linux",
+								Tags:     []string{"gpu"},
+								Team:     "some-team",
+							},
+							Workers:          2,
+							WaitingSteps:     3,
+							ActiveContainers: 10,
+							ActiveVolumes:    20,
+							ActiveTasks:      4,
+						},
+					},
+					QueuedBuilds: map[string]int{"some-team": 5},
+				}, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				expectedHeaderEntries := map[string]string{
+					"Content-Type": "application/json",
+				}
+				Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+			})
+
+			It("returns the demand for each worker pool", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"pools": [
+						{
+							"platform": "linux",
+							"tags": ["gpu"],
+							"team": "some-team",
+							"workers": 2,
+							"waiting_steps": 3,
+							"active_containers": 10,
+							"active_volumes": 20,
+							"active_tasks": 4
+						}
+					],
+					"queued_builds": {"some-team": 5}
+				}`))
+			})
+
+			Context("when calculating the demand fails", func() {
+				BeforeEach(func() {
+					fakeWorkerDemand.DemandReturns(atc.WorkerDemand{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not calculate the demand", func() {
+				Expect(fakeWorkerDemand.DemandCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("POST /api/v1/workers", func() {
 		var (
 			worker    atc.Worker
@@ -585,6 +707,206 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/workers/:worker_name/quarantine", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/"+workerName+"/quarantine", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.QuarantineReturns(nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the request is authenticated as system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("sees if the worker exists and attempts to quarantine it", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+				Expect(fakeWorker.QuarantineCallCount()).To(Equal(1))
+			})
+
+			Context("when quarantining the worker fails", func() {
+				var returnedErr error
+
+				BeforeEach(func() {
+					returnedErr = errors.New("some-error")
+					fakeWorker.QuarantineReturns(returnedErr)
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the request is authorized as the worker's owner", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when the request is authorized as the wrong team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not attempt to find the worker", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/workers/:worker_name/release", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/"+workerName+"/release", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.ReleaseReturns(nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the request is authenticated as system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("sees if the worker exists and attempts to release it", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+				Expect(fakeWorker.ReleaseCallCount()).To(Equal(1))
+			})
+
+			Context("when releasing the worker fails", func() {
+				var returnedErr error
+
+				BeforeEach(func() {
+					returnedErr = errors.New("some-error")
+					fakeWorker.ReleaseReturns(returnedErr)
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the request is authorized as the worker's owner", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when the request is authorized as the wrong team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not attempt to find the worker", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/retire", func() {
 		var (
 			response   *http.Response
@@ -686,6 +1008,107 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/workers/:worker_name/evict", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/workers/"+workerName+"/evict", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeAccess.IsAuthenticatedReturns(true)
+
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+			fakeWorker.EvictReturns(nil)
+		})
+
+		Context("when autheticated as system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("sees if the worker exists and attempts to evict it", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
+				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
+
+				Expect(fakeWorker.EvictCallCount()).To(Equal(1))
+			})
+
+			Context("when evicting the worker fails", func() {
+				var returnedErr error
+
+				BeforeEach(func() {
+					returnedErr = errors.New("some-error")
+					fakeWorker.EvictReturns(returnedErr)
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authorized as as the worker's owner", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when authorized as some other team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not attempt to find the worker", func() {
+				Expect(dbWorkerFactory.GetWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/prune", func() {
 		var (
 			response   *http.Response
@@ -795,6 +1218,7 @@ var _ = Describe("Workers API", func() {
 			fakeWorker.StateReturns(db.WorkerStateRunning)
 			fakeWorker.TeamNameReturns("some-team")
 			fakeWorker.EphemeralReturns(true)
+			fakeWorker.HealthScoreReturns(0.9)
 
 			ttlStr = "30s"
 			ttl, err = time.ParseDuration(ttlStr)
@@ -848,7 +1272,14 @@ var _ = Describe("Workers API", func() {
 				"tags": ["some-tag"],
 				"team": "some-team",
 				"start_time": 0,
-				"version": ""
+				"version": "",
+				"health": {
+					"score": 0.9,
+					"container_creation_failures": 0,
+					"volume_streaming_failures": 0,
+					"errored_steps": 0,
+					"quarantined": false
+				}
 			}`))
 		})
 
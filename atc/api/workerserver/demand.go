@@ -0,0 +1,24 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (s *Server) GetWorkerDemand(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-worker-demand")
+
+	demand, err := s.workerDemand.Demand()
+	if err != nil {
+		logger.Error("failed-to-calculate-worker-demand", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(demand)
+	if err != nil {
+		logger.Error("failed-to-encode-worker-demand", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -47,6 +47,7 @@ func (s *Server) HeartbeatWorker(w http.ResponseWriter, r *http.Request) {
 		Platform:   registration.Platform,
 		TeamName:   registration.Team,
 		Tags:       registration.Tags,
+		Metrics:    registration.ContainerMetrics,
 	}.Emit(s.logger)
 
 	metric.WorkerVolumes{
@@ -69,6 +69,7 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		Platform:   registration.Platform,
 		TeamName:   registration.Team,
 		Tags:       registration.Tags,
+		Metrics:    registration.ContainerMetrics,
 	}.Emit(s.logger)
 
 	metric.WorkerVolumes{
@@ -0,0 +1,30 @@
+package workerserver
+
+import "net/http"
+
+func (s *Server) ReleaseWorker(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("releasing-worker")
+	workerName := r.FormValue(":worker_name")
+
+	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-finding-worker-to-release", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Error("failed-to-find-worker", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = worker.Release()
+	if err != nil {
+		logger.Error("failed-to-release-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
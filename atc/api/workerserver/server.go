@@ -3,6 +3,7 @@ package workerserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/demand"
 )
 
 type Server struct {
@@ -10,17 +11,19 @@ type Server struct {
 
 	teamFactory     db.TeamFactory
 	dbWorkerFactory db.WorkerFactory
+	workerDemand    demand.Calculator
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	dbWorkerFactory db.WorkerFactory,
-
+	workerDemand demand.Calculator,
 ) *Server {
 	return &Server{
 		logger:          logger,
 		teamFactory:     teamFactory,
 		dbWorkerFactory: dbWorkerFactory,
+		workerDemand:    workerDemand,
 	}
 }
@@ -38,14 +38,18 @@ import (
 	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
+	"github.com/concourse/concourse/atc/demand"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/pins"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/prewarm"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/algorithm"
+	"github.com/concourse/concourse/atc/scrub"
 	"github.com/concourse/concourse/atc/syslog"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
@@ -111,6 +115,7 @@ type RunCommand struct {
 	Logger flag.Lager
 
 	varSourcePool creds.VarSourcePool
+	leasedVars    *creds.LeasedVars
 
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -153,6 +158,11 @@ type RunCommand struct {
 	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that has webhook defined."`
 	MaxChecksPerSecond                  int           `long:"max-checks-per-second" description:"Maximum number of checks that can be started per second. If not specified, this will be calculated as (# of resources)/(resource checking interval). -1 value will remove this maximum limit of checks per second."`
 
+	AdaptiveResourceChecking            bool          `long:"enable-adaptive-resource-checking" description:"Check resources which have not produced new versions in a while progressively less often, and check resources which are needed by pending manually triggered builds or were recently used first."`
+	AdaptiveResourceCheckingMaxInterval time.Duration `long:"adaptive-resource-checking-max-interval" default:"1h" description:"Maximum interval on which to check for new versions of idle resources when adaptive resource checking is enabled."`
+
+	RequirePinComment bool `long:"require-pin-comment" description:"Require a comment when pinning a version of a resource through the API."`
+
 	ContainerPlacementStrategyOptions worker.ContainerPlacementStrategyOptions `group:"Container Placement Strategy"`
 
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
@@ -160,6 +170,9 @@ type RunCommand struct {
 
 	GardenRequestTimeout time.Duration `long:"garden-request-timeout" default:"5m" description:"How long to wait for requests to Garden to complete. 0 means no timeout."`
 
+	WorkerQuarantineThreshold   float64 `long:"worker-quarantine-threshold" default:"0" description:"Health score (from 0 to 1) below which workers are automatically quarantined, i.e. no longer get containers placed on them until released. The score drops as workers fail to create containers, stream volumes or run steps. 0 means workers are never quarantined automatically."`
+	WorkerQuarantineMaxFraction float64 `long:"worker-quarantine-max-fraction" default:"0.5" description:"Largest fraction (from 0 to 1) of all workers which may be quarantined automatically at once."`
+
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 	WebPublicDir    flag.Dir `long:"web-public-dir" description:"Web public/ directory to serve live for local development."`
 
@@ -194,9 +207,23 @@ type RunCommand struct {
 		HijackGracePeriod      time.Duration `long:"hijack-grace-period" default:"5m" description:"Period after which hijacked containers will be garbage collected"`
 		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
+		ChecksToRetain         int           `long:"checks-to-retain" default:"5" description:"Number of completed checks to retain per resource for the check history."`
 		VarSourceRecyclePeriod time.Duration `long:"var-source-recycle-period" default:"5m" description:"Period after which to reap var_sources that are not used."`
+		KeyedCacheMaxIdle      time.Duration `long:"keyed-cache-max-idle" default:"168h" description:"Period after which keyed caches which have not been restored by any build are removed."`
+		KeyedCacheMaxTeamSize  int64         `long:"keyed-cache-max-team-size" default:"0" description:"Maximum total size in bytes of each team's keyed caches. The least recently used caches are removed once it is exceeded. 0 means no limit."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	ImagePrewarming struct {
+		Workers  int    `long:"workers" default:"0" description:"Number of compatible workers on which to pre-fetch each new version of the images used by pipeline tasks. 0 disables pre-warming."`
+		Priority string `long:"priority" default:"low" choice:"low" choice:"high" description:"With 'low', images are only pre-warmed while no steps are waiting for a worker. With 'high', they are pre-warmed regardless."`
+	} `group:"Image Pre-warming" namespace:"image-prewarming"`
+
+	ResourceCacheScrubbing struct {
+		Interval  time.Duration `long:"interval" default:"0" description:"Interval on which to verify resource cache volumes against the checksums recorded when they were fetched. 0 disables scrubbing."`
+		Period    time.Duration `long:"period" default:"24h" description:"Period after which a resource cache volume is verified again, whether by scrubbing or before streaming it with --enable-resource-cache-verification."`
+		BatchSize int           `long:"batch-size" default:"10" description:"Maximum number of volumes to verify on each worker per interval."`
+	} `group:"Resource Cache Scrubbing" namespace:"resource-cache-scrubbing"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
@@ -209,8 +236,21 @@ type RunCommand struct {
 
 	JobSchedulingMaxInFlight uint64 `long:"job-scheduling-max-in-flight" default:"32" description:"Maximum number of jobs to be scheduling at the same time"`
 
-	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
-	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
+	DefaultCpuLimit         *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
+	DefaultMemoryLimit      *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
+	DefaultPidsLimit        *int    `long:"default-task-pids-limit" description:"Default maximum number of processes per task, 0 means unlimited"`
+	DefaultIOReadBPSLimit   *string `long:"default-task-io-read-bps-limit" description:"Default maximum bytes read per second per task, 0 means unlimited"`
+	DefaultIOWriteBPSLimit  *string `long:"default-task-io-write-bps-limit" description:"Default maximum bytes written per second per task, 0 means unlimited"`
+	DefaultIOReadIOPSLimit  *int    `long:"default-task-io-read-iops-limit" description:"Default maximum read operations per second per task, 0 means unlimited"`
+	DefaultIOWriteIOPSLimit *int    `long:"default-task-io-write-iops-limit" description:"Default maximum write operations per second per task, 0 means unlimited"`
+
+	MaxCpuLimit         *int    `long:"max-task-cpu-limit" description:"Maximum number of cpu shares per task, 0 means not specified. Will override larger values configured in tasks"`
+	MaxMemoryLimit      *string `long:"max-task-memory-limit" description:"Maximum memory per task, 0 means not specified. Will override larger values configured in tasks"`
+	MaxPidsLimit        *int    `long:"max-task-pids-limit" description:"Maximum number of processes per task, 0 means not specified. Will override larger values configured in tasks"`
+	MaxIOReadBPSLimit   *string `long:"max-task-io-read-bps-limit" description:"Maximum bytes read per second per task, 0 means not specified. Will override larger values configured in tasks"`
+	MaxIOWriteBPSLimit  *string `long:"max-task-io-write-bps-limit" description:"Maximum bytes written per second per task, 0 means not specified. Will override larger values configured in tasks"`
+	MaxIOReadIOPSLimit  *int    `long:"max-task-io-read-iops-limit" description:"Maximum read operations per second per task, 0 means not specified. Will override larger values configured in tasks"`
+	MaxIOWriteIOPSLimit *int    `long:"max-task-io-write-iops-limit" description:"Maximum write operations per second per task, 0 means not specified. Will override larger values configured in tasks"`
 
 	Auditor struct {
 		EnableBuildAuditLog     bool `long:"enable-build-auditing" description:"Enable auditing for all api requests connected to builds."`
@@ -249,6 +289,9 @@ type RunCommand struct {
 		EnableAcrossStep                     bool `long:"enable-across-step" description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
 		EnablePipelineInstances              bool `long:"enable-pipeline-instances" description:"Enable pipeline instances"`
 		EnableP2PVolumeStreaming             bool `long:"enable-p2p-volume-streaming" description:"Enable P2P volume streaming"`
+		EnableImageLayerStore                bool `long:"enable-image-layer-store" description:"Keep registry images fetched for image_resource in a content-addressed store on each worker, shared across teams and resource caches. Requires all workers to be upgraded."`
+		EnableDeltaVolumeStreaming           bool `long:"enable-delta-volume-streaming" description:"When streaming a resource's volume or a keyed cache to a worker which has an older copy of it, only stream the files which changed. Only applies to workers started with --enable-delta-volume-streaming."`
+		EnableResourceCacheVerification      bool `long:"enable-resource-cache-verification" description:"Before streaming a resource cache volume to another worker, verify its contents against the checksum recorded when it was fetched, invalidating the cache if they differ. Volumes are verified at most once per --resource-cache-scrubbing-period. Requires all workers to be upgraded."`
 	} `group:"Feature Flags"`
 
 	BaseResourceTypeDefaults flag.File `long:"base-resource-type-defaults" description:"Base resource type defaults"`
@@ -519,6 +562,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnableBuildRerunWhenWorkerDisappears = cmd.FeatureFlags.EnableBuildRerunWhenWorkerDisappears
 	atc.EnableAcrossStep = cmd.FeatureFlags.EnableAcrossStep
 	atc.EnablePipelineInstances = cmd.FeatureFlags.EnablePipelineInstances
+	atc.EnableImageLayerStore = cmd.FeatureFlags.EnableImageLayerStore
 
 	if cmd.BaseResourceTypeDefaults.Path() != "" {
 		content, err := ioutil.ReadFile(cmd.BaseResourceTypeDefaults.Path())
@@ -612,6 +656,8 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		clock.NewClock(),
 	)
 
+	cmd.leasedVars = creds.NewLeasedVars()
+
 	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, workerConn, backendConn, gcConn, storage, lockFactory, secretManager)
 	if err != nil {
 		return nil, err
@@ -686,7 +732,7 @@ func (cmd *RunCommand) constructMembers(
 		return nil, err
 	}
 
-	gcComponents, err := cmd.gcComponents(logger, gcConn, lockFactory)
+	gcComponents, err := cmd.gcComponents(logger, gcConn, lockFactory, secretManager)
 	if err != nil {
 		return nil, err
 	}
@@ -767,7 +813,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	userFactory := db.NewUserFactory(dbConn)
 
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
-	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
+	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory, cmd.recordResourceCacheChecksums())
 	resourceFetcher := worker.NewFetcher(clock.NewClock(), lockFactory, fetchSourceFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
 
@@ -797,9 +843,14 @@ func (cmd *RunCommand) constructAPIMembers(
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
 		cmd.GardenRequestTimeout,
+		db.WorkerQuarantine{
+			Threshold:   cmd.WorkerQuarantineThreshold,
+			MaxFraction: cmd.WorkerQuarantineMaxFraction,
+		},
 	)
 
-	pool := worker.NewPool(workerProvider)
+	dbWaitingStepFactory := db.NewWaitingStepFactory(dbConn)
+	pool := worker.NewPool(workerProvider, dbWaitingStepFactory)
 
 	credsManagers := cmd.CredentialManagers
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -812,6 +863,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		Interval:            cmd.ResourceCheckingInterval,
 		IntervalWithWebhook: cmd.ResourceWithWebhookCheckingInterval,
 		Timeout:             cmd.GlobalResourceCheckTimeout,
+		AdaptiveMaxInterval: cmd.adaptiveCheckingMaxInterval(),
 	})
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
 	dbClock := db.NewClock()
@@ -840,6 +892,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		displayUserIdGenerator,
 	)
 
+	dbCacheInvalidationFactory := db.NewCacheInvalidationFactory(dbConn)
+
 	middleware := token.NewMiddleware(cmd.Auth.AuthFlags.SecureCookies)
 
 	apiHandler, err := cmd.constructAPIHandler(
@@ -858,7 +912,11 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		userFactory,
+		db.NewWorkerKeyFactory(dbConn),
+		db.NewCredentialUsageFactory(dbConn),
+		dbCacheInvalidationFactory,
 		pool,
+		demand.NewCalculator(dbWorkerFactory, dbWaitingStepFactory, dbBuildFactory),
 		secretManager,
 		credsManagers,
 		accessFactory,
@@ -969,6 +1027,13 @@ func (cmd *RunCommand) constructAPIMembers(
 			cmd.nonTLSBindAddr(),
 			httpHandler,
 		)},
+		{Name: "secret-cache-invalidator", Runner: creds.NewCacheInvalidationListener(
+			logger.Session("secret-cache-invalidator"),
+			dbConn.Bus(),
+			dbCacheInvalidationFactory,
+			secretManager,
+			cmd.varSourcePool,
+		)},
 	}
 
 	if httpsHandler != nil {
@@ -1007,7 +1072,7 @@ func (cmd *RunCommand) backendComponents(
 
 	resourceFactory := resource.NewResourceFactory()
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
-	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
+	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory, cmd.recordResourceCacheChecksums())
 	resourceFetcher := worker.NewFetcher(clock.NewClock(), lockFactory, fetchSourceFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
 
@@ -1016,16 +1081,19 @@ func (cmd *RunCommand) backendComponents(
 		Interval:            cmd.ResourceCheckingInterval,
 		IntervalWithWebhook: cmd.ResourceWithWebhookCheckingInterval,
 		Timeout:             cmd.GlobalResourceCheckTimeout,
+		AdaptiveMaxInterval: cmd.adaptiveCheckingMaxInterval(),
 	})
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
 	dbJobFactory := db.NewJobFactory(dbConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(dbConn, lockFactory)
+	dbResourceFactory := db.NewResourceFactory(dbConn, lockFactory)
 
 	alg := algorithm.New(db.NewVersionsDB(dbConn, algorithmLimitRows, schedulerCache))
 
 	dbWorkerBaseResourceTypeFactory := db.NewWorkerBaseResourceTypeFactory(dbConn)
 	dbTaskCacheFactory := db.NewTaskCacheFactory(dbConn)
 	dbWorkerTaskCacheFactory := db.NewWorkerTaskCacheFactory(dbConn)
+	dbKeyedCacheFactory := db.NewKeyedCacheFactory(dbConn)
 	dbVolumeRepository := db.NewVolumeRepository(dbConn)
 	dbWorkerFactory := db.NewWorkerFactory(dbConn)
 	workerVersion, err := workerVersion()
@@ -1055,17 +1123,27 @@ func (cmd *RunCommand) backendComponents(
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
 		cmd.GardenRequestTimeout,
+		db.WorkerQuarantine{
+			Threshold:   cmd.WorkerQuarantineThreshold,
+			MaxFraction: cmd.WorkerQuarantineMaxFraction,
+		},
 	)
 
-	pool := worker.NewPool(workerProvider)
+	dbWaitingStepFactory := db.NewWaitingStepFactory(dbConn)
+	pool := worker.NewPool(workerProvider, dbWaitingStepFactory)
 	artifactStreamer := worker.NewArtifactStreamer(pool, compressionLib)
-	artifactSourcer := worker.NewArtifactSourcer(compressionLib, pool, cmd.FeatureFlags.EnableP2PVolumeStreaming, cmd.P2pVolumeStreamingTimeout)
+	artifactSourcer := worker.NewArtifactSourcer(compressionLib, pool, cmd.FeatureFlags.EnableP2PVolumeStreaming, cmd.P2pVolumeStreamingTimeout, cmd.FeatureFlags.EnableDeltaVolumeStreaming, cmd.FeatureFlags.EnableResourceCacheVerification, cmd.ResourceCacheScrubbing.Period)
 
 	defaultLimits, err := cmd.parseDefaultLimits()
 	if err != nil {
 		return nil, err
 	}
 
+	maxLimits, err := cmd.parseMaxLimits()
+	if err != nil {
+		return nil, err
+	}
+
 	buildContainerStrategy, err := cmd.chooseBuildContainerStrategy()
 	if err != nil {
 		return nil, err
@@ -1089,8 +1167,10 @@ func (cmd *RunCommand) backendComponents(
 		dbBuildFactory,
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
+		dbKeyedCacheFactory,
 		secretManager,
 		defaultLimits,
+		maxLimits,
 		buildContainerStrategy,
 		lockFactory,
 		rateLimiter,
@@ -1117,7 +1197,7 @@ func (cmd *RunCommand) backendComponents(
 				Name:     atc.ComponentLidarScanner,
 				Interval: cmd.LidarScannerInterval,
 			},
-			Runnable: lidar.NewScanner(dbCheckFactory),
+			Runnable: lidar.NewScanner(dbCheckFactory, cmd.AdaptiveResourceChecking),
 		},
 		{
 			Component: atc.Component{
@@ -1163,6 +1243,61 @@ func (cmd *RunCommand) backendComponents(
 				syslogDrainConfigured,
 			),
 		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentPinExpirer,
+				Interval: time.Minute,
+			},
+			Runnable: pins.NewExpirer(dbResourceFactory, dbCheckFactory),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentWorkerDemand,
+				Interval: 10 * time.Second,
+			},
+			Runnable: demand.NewReporter(
+				demand.NewCalculator(dbWorkerFactory, dbWaitingStepFactory, dbBuildFactory),
+				dbWaitingStepFactory,
+			),
+		},
+	}
+
+	if cmd.ImagePrewarming.Workers > 0 {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentImagePrewarmer,
+				Interval: 10 * time.Minute,
+			},
+			Runnable: prewarm.NewPrewarmer(
+				dbPipelineFactory,
+				db.NewPrewarmedImageFactory(dbConn),
+				dbResourceConfigFactory,
+				dbResourceCacheFactory,
+				dbWaitingStepFactory,
+				workerProvider,
+				resourceFactory,
+				secretManager,
+				cmd.varSourcePool,
+				cmd.leasedVars,
+				cmd.ImagePrewarming.Workers,
+				cmd.ImagePrewarming.Priority,
+			),
+		})
+	}
+
+	if cmd.ResourceCacheScrubbing.Interval > 0 {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentResourceCacheScrubber,
+				Interval: cmd.ResourceCacheScrubbing.Interval,
+			},
+			Runnable: scrub.NewScrubber(
+				dbVolumeRepository,
+				workerProvider,
+				cmd.ResourceCacheScrubbing.Period,
+				cmd.ResourceCacheScrubbing.BatchSize,
+			),
+		})
 	}
 
 	if syslogDrainConfigured {
@@ -1188,6 +1323,7 @@ func (cmd *RunCommand) gcComponents(
 	logger lager.Logger,
 	gcConn db.Conn,
 	lockFactory lock.LockFactory,
+	secretManager creds.Secrets,
 ) ([]RunnableComponent, error) {
 	dbWorkerLifecycle := db.NewWorkerLifecycle(gcConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(gcConn)
@@ -1199,6 +1335,8 @@ func (cmd *RunCommand) gcComponents(
 	dbResourceConfigFactory := db.NewResourceConfigFactory(gcConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(gcConn, lockFactory)
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
+	dbKeyedCacheLifecycle := db.NewKeyedCacheLifecycle(gcConn)
+	dbSecretLeaseLifecycle := db.NewSecretLeaseLifecycle(gcConn)
 
 	dbVolumeRepository := db.NewVolumeRepository(gcConn)
 
@@ -1223,7 +1361,9 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorCheckSessions:     gc.NewResourceConfigCheckSessionCollector(resourceConfigCheckSessionLifecycle),
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
-		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle),
+		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle, cmd.GC.ChecksToRetain),
+		atc.ComponentCollectorKeyedCaches:       gc.NewKeyedCacheCollector(dbKeyedCacheLifecycle, cmd.GC.KeyedCacheMaxIdle, cmd.GC.KeyedCacheMaxTeamSize),
+		atc.ComponentCollectorSecretLeases:      gc.NewSecretLeaseCollector(dbSecretLeaseLifecycle, creds.NewLeaseKeeper(secretManager)),
 	}
 
 	var components []RunnableComponent
@@ -1308,37 +1448,72 @@ func workerVersion() (version.Version, error) {
 }
 
 func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error) {
+	if len(cmd.CredentialManagement.Chain) > 0 {
+		return cmd.chainedSecretManager(logger)
+	}
+
 	var secretsFactory creds.SecretsFactory = noop.NewNoopFactory()
 	for name, manager := range cmd.CredentialManagers {
 		if !manager.IsConfigured() {
 			continue
 		}
 
-		credsLogger := logger.Session("credential-manager", lager.Data{
-			"name": name,
-		})
-
-		credsLogger.Info("configured credentials manager")
-
-		err := manager.Init(credsLogger)
+		var err error
+		secretsFactory, err = cmd.initCredentialManager(logger, name, manager)
 		if err != nil {
 			return nil, err
 		}
 
-		err = manager.Validate()
-		if err != nil {
-			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
+		break
+	}
+
+	return cmd.CredentialManagement.NewSecrets(secretsFactory), nil
+}
+
+func (cmd *RunCommand) chainedSecretManager(logger lager.Logger) (creds.Secrets, error) {
+	var secretsFactories []creds.NamedSecretsFactory
+	for _, name := range cmd.CredentialManagement.Chain {
+		manager, found := cmd.CredentialManagers[name]
+		if !found {
+			return nil, fmt.Errorf("unknown credential manager '%s' in chain", name)
 		}
 
-		secretsFactory, err = manager.NewSecretsFactory(credsLogger)
+		if !manager.IsConfigured() {
+			return nil, fmt.Errorf("credential manager '%s' in chain is not configured", name)
+		}
+
+		secretsFactory, err := cmd.initCredentialManager(logger, name, manager)
 		if err != nil {
 			return nil, err
 		}
 
-		break
+		secretsFactories = append(secretsFactories, creds.NamedSecretsFactory{
+			Name:           name,
+			SecretsFactory: secretsFactory,
+		})
 	}
 
-	return cmd.CredentialManagement.NewSecrets(secretsFactory), nil
+	return cmd.CredentialManagement.NewChainedSecrets(logger.Session("credential-manager-chain"), secretsFactories)
+}
+
+func (cmd *RunCommand) initCredentialManager(logger lager.Logger, name string, manager creds.Manager) (creds.SecretsFactory, error) {
+	credsLogger := logger.Session("credential-manager", lager.Data{
+		"name": name,
+	})
+
+	credsLogger.Info("configured credentials manager")
+
+	err := manager.Init(credsLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
+	}
+
+	return manager.NewSecretsFactory(credsLogger)
 }
 
 func (cmd *RunCommand) newKey() *encryption.Key {
@@ -1470,21 +1645,95 @@ func (cmd *RunCommand) tlsConfig(logger lager.Logger, dbConn db.Conn) (*tls.Conf
 }
 
 func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
+	return containerLimitFlags{
+		cpu:         cmd.DefaultCpuLimit,
+		memory:      cmd.DefaultMemoryLimit,
+		pids:        cmd.DefaultPidsLimit,
+		ioReadBPS:   cmd.DefaultIOReadBPSLimit,
+		ioWriteBPS:  cmd.DefaultIOWriteBPSLimit,
+		ioReadIOPS:  cmd.DefaultIOReadIOPSLimit,
+		ioWriteIOPS: cmd.DefaultIOWriteIOPSLimit,
+	}.parse()
+}
+
+func (cmd *RunCommand) parseMaxLimits() (atc.ContainerLimits, error) {
+	return containerLimitFlags{
+		cpu:         cmd.MaxCpuLimit,
+		memory:      cmd.MaxMemoryLimit,
+		pids:        cmd.MaxPidsLimit,
+		ioReadBPS:   cmd.MaxIOReadBPSLimit,
+		ioWriteBPS:  cmd.MaxIOWriteBPSLimit,
+		ioReadIOPS:  cmd.MaxIOReadIOPSLimit,
+		ioWriteIOPS: cmd.MaxIOWriteIOPSLimit,
+	}.parse()
+}
+
+type containerLimitFlags struct {
+	cpu         *int
+	memory      *string
+	pids        *int
+	ioReadBPS   *string
+	ioWriteBPS  *string
+	ioReadIOPS  *int
+	ioWriteIOPS *int
+}
+
+func (flags containerLimitFlags) parse() (atc.ContainerLimits, error) {
 	limits := atc.ContainerLimits{}
-	if cmd.DefaultCpuLimit != nil {
-		cpu := atc.CPULimit(*cmd.DefaultCpuLimit)
+	if flags.cpu != nil {
+		cpu := atc.CPULimit(*flags.cpu)
 		limits.CPU = &cpu
 	}
-	if cmd.DefaultMemoryLimit != nil {
-		memory, err := atc.ParseMemoryLimit(*cmd.DefaultMemoryLimit)
+	if flags.memory != nil {
+		memory, err := atc.ParseMemoryLimit(*flags.memory)
 		if err != nil {
 			return atc.ContainerLimits{}, err
 		}
 		limits.Memory = &memory
 	}
+	if flags.pids != nil {
+		pids := atc.PidsLimit(*flags.pids)
+		limits.Pids = &pids
+	}
+
+	io := atc.IOLimits{}
+	if flags.ioReadBPS != nil {
+		readBPS, err := atc.ParseBandwidthLimit(*flags.ioReadBPS)
+		if err != nil {
+			return atc.ContainerLimits{}, err
+		}
+		io.ReadBPS = &readBPS
+	}
+	if flags.ioWriteBPS != nil {
+		writeBPS, err := atc.ParseBandwidthLimit(*flags.ioWriteBPS)
+		if err != nil {
+			return atc.ContainerLimits{}, err
+		}
+		io.WriteBPS = &writeBPS
+	}
+	if flags.ioReadIOPS != nil {
+		readIOPS := atc.IOPSLimit(*flags.ioReadIOPS)
+		io.ReadIOPS = &readIOPS
+	}
+	if flags.ioWriteIOPS != nil {
+		writeIOPS := atc.IOPSLimit(*flags.ioWriteIOPS)
+		io.WriteIOPS = &writeIOPS
+	}
+	if io != (atc.IOLimits{}) {
+		limits.IO = &io
+	}
+
 	return limits, nil
 }
 
+func (cmd *RunCommand) adaptiveCheckingMaxInterval() time.Duration {
+	if !cmd.AdaptiveResourceChecking {
+		return 0
+	}
+
+	return cmd.AdaptiveResourceCheckingMaxInterval
+}
+
 func (cmd *RunCommand) defaultBindIP() net.IP {
 	URL := cmd.BindIP.String()
 	if URL == "0.0.0.0" {
@@ -1558,6 +1807,20 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	if cmd.WorkerQuarantineThreshold < 0 || cmd.WorkerQuarantineThreshold > 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--worker-quarantine-threshold must be between 0 and 1"),
+		)
+	}
+
+	if cmd.WorkerQuarantineMaxFraction < 0 || cmd.WorkerQuarantineMaxFraction > 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--worker-quarantine-max-fraction must be between 0 and 1"),
+		)
+	}
+
 	if err := cmd.validateCustomRoles(); err != nil {
 		errs = multierror.Append(errs, err)
 	}
@@ -1669,8 +1932,10 @@ func (cmd *RunCommand) constructEngine(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	keyedCacheFactory db.KeyedCacheFactory,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
+	maxLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	lockFactory lock.LockFactory,
 	rateLimiter engine.RateLimiter,
@@ -1687,7 +1952,9 @@ func (cmd *RunCommand) constructEngine(
 				buildFactory,
 				resourceCacheFactory,
 				resourceConfigFactory,
+				keyedCacheFactory,
 				defaultLimits,
+				maxLimits,
 				strategy,
 				cmd.GlobalResourceCheckTimeout,
 			),
@@ -1700,6 +1967,7 @@ func (cmd *RunCommand) constructEngine(
 		),
 		secretManager,
 		cmd.varSourcePool,
+		cmd.leasedVars,
 	)
 }
 
@@ -1866,7 +2134,11 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbCheckFactory db.CheckFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	dbWorkerKeyFactory db.WorkerKeyFactory,
+	dbCredentialUsageFactory db.CredentialUsageFactory,
+	dbCacheInvalidationFactory db.CacheInvalidationFactory,
 	workerPool worker.Pool,
+	workerDemand demand.Calculator,
 	secretManager creds.Secrets,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
@@ -1942,21 +2214,28 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbCheckFactory,
 		resourceConfigFactory,
 		dbUserFactory,
+		dbWorkerKeyFactory,
+		dbCredentialUsageFactory,
+		dbCacheInvalidationFactory,
 
 		buildserver.NewEventHandler,
 
 		workerPool,
+		workerDemand,
 
 		reconfigurableSink,
 
 		cmd.isTLSEnabled(),
+		cmd.RequirePinComment,
 
 		cmd.CLIArtifactsDir.Path(),
 		concourse.Version,
 		concourse.WorkerVersion,
 		secretManager,
 		cmd.varSourcePool,
+		cmd.leasedVars,
 		credsManagers,
+		cmd.CredentialManagement.Chain,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
 		dbWall,
@@ -1989,6 +2268,12 @@ func (cmd *RunCommand) isTLSEnabled() bool {
 	return cmd.TLSBindPort != 0
 }
 
+// recordResourceCacheChecksums returns whether fetched resource caches need a
+// checksum, which is only the case when something verifies them.
+func (cmd *RunCommand) recordResourceCacheChecksums() bool {
+	return cmd.FeatureFlags.EnableResourceCacheVerification || cmd.ResourceCacheScrubbing.Interval > 0
+}
+
 type drainRunner struct {
 	logger  lager.Logger
 	drainer component.Drainable
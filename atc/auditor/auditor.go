@@ -64,6 +64,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.BuildResources,
 		atc.AbortBuild,
 		atc.GetBuildPreparation,
+		atc.GetBuildStepOutput,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
@@ -113,6 +114,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.CheckResource,
 		atc.CheckResourceWebHook,
 		atc.CheckResourceType,
+		atc.ListResourceChecks,
 		atc.ListResourceVersions,
 		atc.GetResourceVersion,
 		atc.EnableResourceVersion,
@@ -120,17 +122,26 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.PinResourceVersion,
 		atc.GetResourceCausality:
 		return a.EnableResourceAuditLog
+	case atc.PromoteResourceVersion:
+		// promoting a version lets it skip past a job without running it, so
+		// it's always audited
+		return true
 	case
 		atc.SaveConfig,
 		atc.GetConfig,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
+		atc.ListJobCaches,
+		atc.ClearJobCaches,
 		atc.SetLogLevel,
 		atc.GetLogLevel,
 		atc.DownloadCLI,
 		atc.GetInfo,
 		atc.GetInfoCreds,
+		atc.ListTeamCredentialUsages,
+		atc.ListAllCredentialUsages,
+		atc.ClearCredentialCache,
 		atc.ListActiveUsersSince,
 		atc.GetUser,
 		atc.GetWall,
@@ -147,10 +158,20 @@ func (a *auditor) ValidateAction(action string) bool {
 	case atc.RegisterWorker,
 		atc.LandWorker,
 		atc.RetireWorker,
+		atc.EvictWorker,
 		atc.PruneWorker,
+		atc.QuarantineWorker,
+		atc.ReleaseWorker,
 		atc.HeartbeatWorker,
 		atc.ListWorkers,
-		atc.DeleteWorker:
+		atc.GetWorkerDemand,
+		atc.DeleteWorker,
+		atc.CreateWorkerEnrollmentToken,
+		atc.ListWorkerKeys,
+		atc.RevokeWorkerKey,
+		atc.EnrollWorkerKey,
+		atc.AuthorizeWorkerKey,
+		atc.UseWorkerKey:
 		return a.EnableWorkerAuditLog
 	case atc.ListVolumes,
 		atc.ListDestroyingVolumes,
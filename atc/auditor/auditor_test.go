@@ -367,6 +367,20 @@ var _ = Describe("Audit", func() {
 		})
 	})
 
+	Describe("PromoteResourceVersion", func() {
+		BeforeEach(func() {
+			EnableResourceAuditLog = false
+			dummyAction = "PromoteResourceVersion"
+		})
+
+		It("Creates a log even when EnableResourceAuditLog is false", func() {
+			aud.Audit(dummyAction, userName, req)
+			logs := logger.Logs()
+			Expect(logs).To(HaveLen(1))
+			Expect(logs[0].Data["action"]).To(Equal(dummyAction))
+		})
+	})
+
 	Describe("EnableSystemAuditLog", func() {
 
 		Context("When EnableSystemAuditLog is false with a System action", func() {
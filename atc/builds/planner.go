@@ -56,10 +56,12 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		Vars:              step.Vars,
 		Tags:              step.Tags,
 		Params:            step.Params,
+		SecretFiles:       step.SecretFiles,
 		InputMapping:      step.InputMapping,
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
 		Timeout:           step.Timeout,
+		KeepOutputs:       step.KeepOutputs,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
@@ -238,11 +238,13 @@ var factoryTests = []PlannerTest{
 			ConfigPath:        "some-task-file",
 			Vars:              atc.Params{"some": "vars"},
 			Params:            atc.TaskEnv{"SOME": "PARAMS"},
+			SecretFiles:       map[string]string{"key.pem": "((key))"},
 			Tags:              atc.Tags{"tag-1", "tag-2"},
 			InputMapping:      map[string]string{"generic": "specific"},
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			KeepOutputs:       "24h",
 		},
 
 		PlanJSON: `{
@@ -257,11 +259,13 @@ var factoryTests = []PlannerTest{
 				"config_path": "some-task-file",
 				"vars": {"some": "vars"},
 				"params": {"SOME": "PARAMS"},
+				"secret_files": {"key.pem": "((key))"},
 				"tags": ["tag-1", "tag-2"],
 				"input_mapping": {"generic": "specific"},
 				"output_mapping": {"specific": "generic"},
 				"image": "some-image",
 				"timeout": "1h",
+				"keep_outputs": "24h",
 				"resource_types": [
 					{
 						"name": "some-resource-type",
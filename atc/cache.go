@@ -3,4 +3,6 @@ package atc
 const (
 	TeamCacheName    = "teams"
 	TeamCacheChannel = "team_cache"
+
+	SecretCacheChannel = "secret_cache"
 )
@@ -7,6 +7,7 @@ const (
 	ComponentBuildTracker               = "tracker"
 	ComponentLidarScanner               = "scanner"
 	ComponentBuildReaper                = "reaper"
+	ComponentPinExpirer                 = "pin_expirer"
 	ComponentSyslogDrainer              = "drainer"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
@@ -14,12 +15,17 @@ const (
 	ComponentCollectorCheckSessions     = "collector_check_sessions"
 	ComponentCollectorChecks            = "collector_checks"
 	ComponentCollectorContainers        = "collector_containers"
+	ComponentCollectorKeyedCaches       = "collector_keyed_caches"
 	ComponentCollectorResourceCacheUses = "collector_resource_cache_uses"
 	ComponentCollectorResourceCaches    = "collector_resource_caches"
 	ComponentCollectorResourceConfigs   = "collector_resource_configs"
+	ComponentCollectorSecretLeases      = "collector_secret_leases"
 	ComponentCollectorVolumes           = "collector_volumes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
+	ComponentWorkerDemand               = "worker_demand"
+	ComponentImagePrewarmer             = "image_prewarmer"
+	ComponentResourceCacheScrubber      = "resource_cache_scrubber"
 )
 
 type Component struct {
@@ -1,6 +1,7 @@
 package compression
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/concourse/baggageclaim"
@@ -10,5 +11,19 @@ import (
 
 type Compression interface {
 	NewReader(io.ReadCloser) (io.ReadCloser, error)
+	NewWriter(io.Writer) (io.WriteCloser, error)
 	Encoding() baggageclaim.Encoding
 }
+
+// ForEncoding returns the Compression which reads and writes streams in the
+// given encoding.
+func ForEncoding(encoding baggageclaim.Encoding) (Compression, error) {
+	switch encoding {
+	case baggageclaim.GzipEncoding:
+		return NewGzipCompression(), nil
+	case baggageclaim.ZstdEncoding:
+		return NewZstdCompression(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+}
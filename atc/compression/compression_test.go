@@ -1,6 +1,9 @@
 package compression_test
 
 import (
+	"bytes"
+	"io/ioutil"
+
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/compression"
 
@@ -13,6 +16,27 @@ var _ = Describe("Compression", func() {
 		comp compression.Compression
 	)
 
+	itRoundTrips := func() {
+		It("reads back what it writes", func() {
+			buf := new(bytes.Buffer)
+
+			w, err := comp.NewWriter(buf)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = w.Write([]byte("some-content"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(w.Close()).To(Succeed())
+
+			r, err := comp.NewReader(ioutil.NopCloser(buf))
+			Expect(err).ToNot(HaveOccurred())
+
+			content, err := ioutil.ReadAll(r)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("some-content"))
+			Expect(r.Close()).To(Succeed())
+		})
+	}
+
 	Describe("Gzip", func() {
 		BeforeEach(func() {
 			comp = compression.NewGzipCompression()
@@ -21,6 +45,8 @@ var _ = Describe("Compression", func() {
 		It("returns gzip", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.GzipEncoding))
 		})
+
+		itRoundTrips()
 	})
 
 	Describe("Zstd", func() {
@@ -31,5 +57,24 @@ var _ = Describe("Compression", func() {
 		It("returns zstd", func() {
 			Expect(comp.Encoding()).To(Equal(baggageclaim.ZstdEncoding))
 		})
+
+		itRoundTrips()
+	})
+
+	Describe("ForEncoding", func() {
+		It("returns the compression for the encoding", func() {
+			comp, err := compression.ForEncoding(baggageclaim.ZstdEncoding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(comp.Encoding()).To(Equal(baggageclaim.ZstdEncoding))
+
+			comp, err = compression.ForEncoding(baggageclaim.GzipEncoding)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(comp.Encoding()).To(Equal(baggageclaim.GzipEncoding))
+		})
+
+		It("errors for unknown encodings", func() {
+			_, err := compression.ForEncoding("bogus")
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
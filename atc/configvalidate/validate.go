@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/gobwas/glob"
 )
 
@@ -360,6 +361,63 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+// PipelineFinder looks up the pipelines of the team that a config is being
+// saved to. It is satisfied by db.Team.
+type PipelineFinder interface {
+	Pipeline(atc.PipelineRef) (db.Pipeline, bool, error)
+}
+
+// ValidatePassedPipelines checks that every `passed:` constraint referencing a
+// job in another pipeline refers to an existing job of an existing pipeline.
+//
+// Unlike Validate, this requires looking up other pipelines, so it is
+// performed separately once the team is known.
+func ValidatePassedPipelines(c atc.Config, pipelines PipelineFinder) ([]string, error) {
+	var errorMessages []string
+
+	for _, job := range c.Jobs {
+		err := job.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				identifier := fmt.Sprintf("jobs.%s.plan.get(%s).passed", job.Name, step.Name)
+
+				for _, passedJob := range step.PassedPipelineJobs {
+					pipeline, found, err := pipelines.Pipeline(passedJob.PipelineRef())
+					if err != nil {
+						return err
+					}
+
+					if !found {
+						errorMessages = append(errorMessages,
+							fmt.Sprintf("%s: unknown pipeline '%s'", identifier, passedJob.PipelineRef()))
+						continue
+					}
+
+					_, found, err = pipeline.Job(passedJob.Job)
+					if err != nil {
+						return err
+					}
+
+					if !found {
+						errorMessages = append(errorMessages,
+							fmt.Sprintf("%s: unknown job '%s' in pipeline '%s'", identifier, passedJob.Job, passedJob.PipelineRef()))
+					}
+				}
+
+				return nil
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return nil, nil
+	}
+
+	return []string{formatErr("jobs", compositeErr(errorMessages))}, nil
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -2,10 +2,12 @@ package configvalidate_test
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 
 	// load dummy credential manager
 	_ "github.com/concourse/concourse/atc/creds/dummy"
@@ -979,6 +981,26 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan keeps its outputs for an invalid duration", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:        "lol",
+							ConfigPath:  "task.yml",
+							KeepOutputs: "forever",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol): invalid keep_outputs duration 'forever'"))
+				})
+			})
+
 			Context("when a task plan is invalid", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1752,6 +1774,49 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name: "some-resource",
+							PassedPipelineJobs: []atc.PassedJob{
+								{
+									Pipeline:     "other-pipeline",
+									InstanceVars: atc.InstanceVars{"branch": "main"},
+									Job:          "other-job",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a malformed job in another pipeline", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.GetStep{
+							Name: "some-resource",
+							PassedPipelineJobs: []atc.PassedJob{
+								{Pipeline: "other-pipeline"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].get(some-resource).passed: job of pipeline 'other-pipeline' is missing a name"))
+				})
+			})
+
 			Context("when a load_var has no name or file defined", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -2124,3 +2189,87 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("ValidatePassedPipelines", func() {
+	var (
+		config        atc.Config
+		fakeTeam      *dbfakes.FakeTeam
+		fakePipeline  *dbfakes.FakePipeline
+		errorMessages []string
+		err           error
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.GetStep{
+								Name:   "some-resource",
+								Passed: []string{"some-other-job"},
+								PassedPipelineJobs: []atc.PassedJob{
+									{Pipeline: "other-pipeline", Job: "other-job"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakeTeam.PipelineReturns(fakePipeline, true, nil)
+		fakePipeline.JobReturns(new(dbfakes.FakeJob), true, nil)
+	})
+
+	JustBeforeEach(func() {
+		errorMessages, err = configvalidate.ValidatePassedPipelines(config, fakeTeam)
+	})
+
+	It("only looks up jobs in other pipelines", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(errorMessages).To(BeEmpty())
+
+		Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
+		Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "other-pipeline"}))
+		Expect(fakePipeline.JobCallCount()).To(Equal(1))
+		Expect(fakePipeline.JobArgsForCall(0)).To(Equal("other-job"))
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(nil, false, nil)
+		})
+
+		It("returns an error message", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.plan.get(some-resource).passed: unknown pipeline 'other-pipeline'"))
+		})
+	})
+
+	Context("when the job does not exist", func() {
+		BeforeEach(func() {
+			fakePipeline.JobReturns(nil, false, nil)
+		})
+
+		It("returns an error message", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.plan.get(some-resource).passed: unknown job 'other-job' in pipeline 'other-pipeline'"))
+		})
+	})
+
+	Context("when looking up the pipeline fails", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(nil, false, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError("disaster"))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package atc
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -10,9 +11,105 @@ import (
 
 var memoryRegex = regexp.MustCompile(`^([0-9]+)([GMK]?[B])?$`)
 
+// Names of the kinds of container limits, as advertised by workers in
+// SupportedContainerLimits.
+const (
+	ContainerLimitCPU    = "cpu"
+	ContainerLimitMemory = "memory"
+	ContainerLimitPids   = "pids"
+	ContainerLimitIO     = "io"
+)
+
+// DefaultSupportedContainerLimits are the limits that workers which do not
+// advertise their supported limits are assumed to support.
+var DefaultSupportedContainerLimits = []string{
+	ContainerLimitCPU,
+	ContainerLimitMemory,
+}
+
 type ContainerLimits struct {
 	CPU    *CPULimit    `json:"cpu,omitempty"`
 	Memory *MemoryLimit `json:"memory,omitempty"`
+	Pids   *PidsLimit   `json:"pids,omitempty"`
+	IO     *IOLimits    `json:"io,omitempty"`
+}
+
+// IOLimits throttle the block IO of a container. Bandwidths are in bytes per
+// second and may use the same units as memory limits.
+type IOLimits struct {
+	ReadBPS   *BandwidthLimit `json:"read_bps,omitempty"`
+	WriteBPS  *BandwidthLimit `json:"write_bps,omitempty"`
+	ReadIOPS  *IOPSLimit      `json:"read_iops,omitempty"`
+	WriteIOPS *IOPSLimit      `json:"write_iops,omitempty"`
+}
+
+// WithDefaults returns the limits with any unset limit taken from defaults.
+func (limits ContainerLimits) WithDefaults(defaults ContainerLimits) ContainerLimits {
+	if limits.CPU == nil {
+		limits.CPU = defaults.CPU
+	}
+	if limits.Memory == nil {
+		limits.Memory = defaults.Memory
+	}
+	if limits.Pids == nil {
+		limits.Pids = defaults.Pids
+	}
+
+	if defaults.IO != nil {
+		io := IOLimits{}
+		if limits.IO != nil {
+			io = *limits.IO
+		}
+		if io.ReadBPS == nil {
+			io.ReadBPS = defaults.IO.ReadBPS
+		}
+		if io.WriteBPS == nil {
+			io.WriteBPS = defaults.IO.WriteBPS
+		}
+		if io.ReadIOPS == nil {
+			io.ReadIOPS = defaults.IO.ReadIOPS
+		}
+		if io.WriteIOPS == nil {
+			io.WriteIOPS = defaults.IO.WriteIOPS
+		}
+		limits.IO = &io
+	}
+
+	return limits
+}
+
+// Capped returns the limits with every limit lowered to at most its maximum.
+// Unset and unlimited (zero) limits are left alone, so that a maximum on a
+// kind of limit which not every worker enforces does not restrict placement
+// of tasks which never asked for it.
+func (limits ContainerLimits) Capped(max ContainerLimits) ContainerLimits {
+	limits.CPU = (*CPULimit)(capLimit((*uint64)(limits.CPU), (*uint64)(max.CPU)))
+	limits.Memory = (*MemoryLimit)(capLimit((*uint64)(limits.Memory), (*uint64)(max.Memory)))
+	limits.Pids = (*PidsLimit)(capLimit((*uint64)(limits.Pids), (*uint64)(max.Pids)))
+
+	if limits.IO != nil && max.IO != nil {
+		io := *limits.IO
+		io.ReadBPS = (*BandwidthLimit)(capLimit((*uint64)(io.ReadBPS), (*uint64)(max.IO.ReadBPS)))
+		io.WriteBPS = (*BandwidthLimit)(capLimit((*uint64)(io.WriteBPS), (*uint64)(max.IO.WriteBPS)))
+		io.ReadIOPS = (*IOPSLimit)(capLimit((*uint64)(io.ReadIOPS), (*uint64)(max.IO.ReadIOPS)))
+		io.WriteIOPS = (*IOPSLimit)(capLimit((*uint64)(io.WriteIOPS), (*uint64)(max.IO.WriteIOPS)))
+		limits.IO = &io
+	}
+
+	return limits
+}
+
+func capLimit(limit, max *uint64) *uint64 {
+	if max == nil || *max == 0 {
+		return limit
+	}
+
+	if limit != nil && *limit > *max {
+		capped := *max
+		return &capped
+	}
+
+	return limit
 }
 
 type CPULimit uint64
@@ -26,32 +123,80 @@ func (c *CPULimit) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type PidsLimit uint64
+
+func (p *PidsLimit) UnmarshalJSON(data []byte) error {
+	var target float64
+	if err := json.Unmarshal(data, &target); err != nil {
+		return errors.New("pids limit must be an integer")
+	}
+	*p = PidsLimit(target)
+	return nil
+}
+
+type IOPSLimit uint64
+
+func (i *IOPSLimit) UnmarshalJSON(data []byte) error {
+	var target float64
+	if err := json.Unmarshal(data, &target); err != nil {
+		return errors.New("iops limit must be an integer")
+	}
+	*i = IOPSLimit(target)
+	return nil
+}
+
 type MemoryLimit uint64
 
 func (m *MemoryLimit) UnmarshalJSON(data []byte) error {
+	size, err := unmarshalByteSize(data, "memory")
+	if err != nil {
+		return err
+	}
+	*m = MemoryLimit(size)
+	return nil
+}
+
+func ParseMemoryLimit(limit string) (MemoryLimit, error) {
+	size, err := parseByteSize(limit, "memory")
+	return MemoryLimit(size), err
+}
+
+type BandwidthLimit uint64
+
+func (b *BandwidthLimit) UnmarshalJSON(data []byte) error {
+	size, err := unmarshalByteSize(data, "bandwidth")
+	if err != nil {
+		return err
+	}
+	*b = BandwidthLimit(size)
+	return nil
+}
+
+func ParseBandwidthLimit(limit string) (BandwidthLimit, error) {
+	size, err := parseByteSize(limit, "bandwidth")
+	return BandwidthLimit(size), err
+}
+
+func unmarshalByteSize(data []byte, kind string) (uint64, error) {
 	var dst interface{}
 	if err := json.Unmarshal(data, &dst); err != nil {
-		return err
+		return 0, err
 	}
 	switch v := dst.(type) {
 	case float64:
-		*m = MemoryLimit(v)
+		return uint64(v), nil
 	case string:
-		var err error
-		*m, err = ParseMemoryLimit(v)
-		if err != nil {
-			return err
-		}
+		return parseByteSize(v, kind)
 	}
-	return nil
+	return 0, nil
 }
 
-func ParseMemoryLimit(limit string) (MemoryLimit, error) {
+func parseByteSize(limit string, kind string) (uint64, error) {
 	limit = strings.ToUpper(limit)
 	matches := memoryRegex.FindStringSubmatch(limit)
 
 	if len(matches) != 3 {
-		return 0, errors.New("could not parse container memory limit")
+		return 0, fmt.Errorf("could not parse container %s limit", kind)
 	}
 
 	value, err := strconv.ParseUint(matches[1], 10, 64)
@@ -72,5 +217,5 @@ func ParseMemoryLimit(limit string) (MemoryLimit, error) {
 		power = 0
 	}
 
-	return MemoryLimit(value * (1 << power)), nil
+	return value * (1 << power), nil
 }
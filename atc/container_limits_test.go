@@ -0,0 +1,87 @@
+package atc_test
+
+import (
+	. "github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerLimits", func() {
+	var (
+		cpu     CPULimit
+		memory  MemoryLimit
+		pids    PidsLimit
+		readBPS BandwidthLimit
+	)
+
+	BeforeEach(func() {
+		cpu = CPULimit(1024)
+		memory = MemoryLimit(2048)
+		pids = PidsLimit(100)
+		readBPS = BandwidthLimit(4096)
+	})
+
+	Describe("WithDefaults", func() {
+		It("fills in the limits which are not set", func() {
+			defaultCPU := CPULimit(512)
+			limits := ContainerLimits{
+				CPU: &cpu,
+				IO:  &IOLimits{},
+			}.WithDefaults(ContainerLimits{
+				CPU:    &defaultCPU,
+				Memory: &memory,
+				IO:     &IOLimits{ReadBPS: &readBPS},
+			})
+
+			Expect(limits).To(Equal(ContainerLimits{
+				CPU:    &cpu,
+				Memory: &memory,
+				IO:     &IOLimits{ReadBPS: &readBPS},
+			}))
+		})
+	})
+
+	Describe("Capped", func() {
+		It("lowers the limits which exceed the maximum", func() {
+			maxCPU := CPULimit(512)
+			limits := ContainerLimits{
+				CPU:    &cpu,
+				Memory: &memory,
+			}.Capped(ContainerLimits{
+				CPU: &maxCPU,
+			})
+
+			Expect(limits).To(Equal(ContainerLimits{
+				CPU:    &maxCPU,
+				Memory: &memory,
+			}))
+		})
+
+		It("leaves unset and unlimited limits alone", func() {
+			unlimited := PidsLimit(0)
+			limits := ContainerLimits{
+				Pids: &unlimited,
+			}.Capped(ContainerLimits{
+				Pids: &pids,
+				IO:   &IOLimits{ReadBPS: &readBPS},
+			})
+
+			Expect(limits).To(Equal(ContainerLimits{
+				Pids: &unlimited,
+			}))
+		})
+
+		It("leaves limits below the maximum alone", func() {
+			maxMemory := MemoryLimit(4096)
+			limits := ContainerLimits{
+				Memory: &memory,
+			}.Capped(ContainerLimits{
+				Memory: &maxMemory,
+			})
+
+			Expect(limits).To(Equal(ContainerLimits{
+				Memory: &memory,
+			}))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package atc
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/vars"
+)
+
+// The parts of a pipeline config that credentials can be used from.
+const (
+	CredentialUsageJob          = "job"
+	CredentialUsageResource     = "resource"
+	CredentialUsageResourceType = "resource_type"
+	CredentialUsageVarSource    = "var_source"
+)
+
+// CredentialUsage is a reference to a credential var from a pipeline.
+type CredentialUsage struct {
+	TeamName             string       `json:"team_name"`
+	PipelineID           int          `json:"pipeline_id"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+
+	// The kind and name of the job, resource, resource type or var source
+	// referencing the var.
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// The var source the var is looked up in, empty for the credential
+	// manager configured for the cluster.
+	VarSource string `json:"var_source,omitempty"`
+	Path      string `json:"path"`
+
+	// Whether the var can't currently be resolved. Vars are only looked up
+	// when asked to resolve them.
+	Unresolved bool `json:"unresolved,omitempty"`
+
+	// The credential manager which resolved the var, when the cluster's
+	// credential manager is a chain of several.
+	ResolvedBy string `json:"resolved_by,omitempty"`
+}
+
+// CredentialUsages returns the vars referenced by each job, resource,
+// resource type and var source in the config. Local vars, which are set by
+// load_var steps rather than coming from a credential manager, are left out.
+//
+// Vars referenced from task config files are only known once the task runs,
+// so they aren't included either.
+func (config Config) CredentialUsages() ([]CredentialUsage, error) {
+	var usages []CredentialUsage
+
+	add := func(usageType string, name string, config interface{}) error {
+		payload, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+
+		refs, err := vars.NewTemplate(payload).References()
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range refs {
+			if ref.Source == "." {
+				continue
+			}
+
+			usages = append(usages, CredentialUsage{
+				Type:      usageType,
+				Name:      name,
+				VarSource: ref.Source,
+				Path:      ref.Path,
+			})
+		}
+
+		return nil
+	}
+
+	for _, varSource := range config.VarSources {
+		err := add(CredentialUsageVarSource, varSource.Name, varSource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		err := add(CredentialUsageResourceType, resourceType.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, resource := range config.Resources {
+		err := add(CredentialUsageResource, resource.Name, resource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, job := range config.Jobs {
+		err := add(CredentialUsageJob, job.Name, job)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return usages, nil
+}
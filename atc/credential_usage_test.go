@@ -0,0 +1,68 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredentialUsages", func() {
+	It("returns the vars referenced throughout the config", func() {
+		config := atc.Config{
+			VarSources: atc.VarSourceConfigs{
+				{
+					Name:   "vault",
+					Type:   "vault",
+					Config: map[string]interface{}{"client_token": "((vault-token))"},
+				},
+			},
+			ResourceTypes: atc.ResourceTypes{
+				{
+					Name:   "some-type",
+					Type:   "registry-image",
+					Source: atc.Source{"password": "((registry.password))"},
+				},
+			},
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"private_key": "((vault:deploy-key))", "uri": "some-uri"},
+				},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.LoadVarStep{
+								Name: "version",
+								File: "some-file",
+							},
+						},
+						{
+							Config: &atc.TaskStep{
+								Name:       "some-task",
+								ConfigPath: "some-file",
+								Params: atc.TaskEnv{
+									"TOKEN":   "((api-token))",
+									"VERSION": "((.:version))",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		usages, err := config.CredentialUsages()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(Equal([]atc.CredentialUsage{
+			{Type: atc.CredentialUsageVarSource, Name: "vault", Path: "vault-token"},
+			{Type: atc.CredentialUsageResourceType, Name: "some-type", Path: "registry"},
+			{Type: atc.CredentialUsageResource, Name: "some-resource", VarSource: "vault", Path: "deploy-key"},
+			{Type: atc.CredentialUsageJob, Name: "some-job", Path: "api-token"},
+		}))
+	})
+})
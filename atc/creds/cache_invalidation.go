@@ -0,0 +1,108 @@
+package creds
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+// CacheInvalidation is a request to invalidate the cached secrets of a team,
+// or only the ones of a single var. It is recorded in the database so that
+// every ATC can act on it.
+type CacheInvalidation struct {
+	ID       int
+	TeamName string
+	Path     string
+}
+
+//go:generate counterfeiter . CacheInvalidations
+
+type CacheInvalidations interface {
+	LatestCacheInvalidationID() (int, error)
+	CacheInvalidationsSince(id int) ([]CacheInvalidation, error)
+}
+
+//go:generate counterfeiter . Notifications
+
+type Notifications interface {
+	Listen(string) (chan bool, error)
+	Unlisten(string, chan bool) error
+}
+
+// CacheInvalidationListener invalidates the caches of the cluster's secrets
+// and of the var sources whenever a cache invalidation is recorded, as
+// announced on the secret cache channel.
+type CacheInvalidationListener struct {
+	logger        lager.Logger
+	notifications Notifications
+	invalidations CacheInvalidations
+	secrets       Secrets
+	varSourcePool VarSourcePool
+}
+
+func NewCacheInvalidationListener(
+	logger lager.Logger,
+	notifications Notifications,
+	invalidations CacheInvalidations,
+	secrets Secrets,
+	varSourcePool VarSourcePool,
+) *CacheInvalidationListener {
+	return &CacheInvalidationListener{
+		logger:        logger,
+		notifications: notifications,
+		invalidations: invalidations,
+		secrets:       secrets,
+		varSourcePool: varSourcePool,
+	}
+}
+
+func (l *CacheInvalidationListener) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	notifier, err := l.notifications.Listen(atc.SecretCacheChannel)
+	if err != nil {
+		return err
+	}
+
+	defer l.notifications.Unlisten(atc.SecretCacheChannel, notifier)
+
+	// the caches start out empty, so there's no catching up to do
+	lastID, err := l.invalidations.LatestCacheInvalidationID()
+	if err != nil {
+		return err
+	}
+
+	close(ready)
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-notifier:
+			// also notified when the connection was lost, in which case any
+			// invalidations recorded in the meantime are picked up
+			lastID = l.invalidate(lastID)
+		}
+	}
+}
+
+func (l *CacheInvalidationListener) invalidate(lastID int) int {
+	invalidations, err := l.invalidations.CacheInvalidationsSince(lastID)
+	if err != nil {
+		l.logger.Error("failed-to-get-cache-invalidations", err)
+		return lastID
+	}
+
+	for _, invalidation := range invalidations {
+		l.logger.Info("invalidating", lager.Data{
+			"team": invalidation.TeamName,
+			"path": invalidation.Path,
+		})
+
+		invalidateCache(l.secrets, invalidation.TeamName, invalidation.Path)
+		l.varSourcePool.InvalidateCache(invalidation.TeamName, invalidation.Path)
+
+		lastID = invalidation.ID
+	}
+
+	return lastID
+}
@@ -0,0 +1,117 @@
+package creds_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type invalidatedSecrets struct {
+	*credsfakes.FakeSecrets
+
+	invalidations chan creds.CacheInvalidation
+}
+
+func (s *invalidatedSecrets) InvalidateCache(teamName string, path string) {
+	s.invalidations <- creds.CacheInvalidation{TeamName: teamName, Path: path}
+}
+
+var _ = Describe("CacheInvalidationListener", func() {
+	var (
+		fakeNotifications *credsfakes.FakeNotifications
+		fakeInvalidations *credsfakes.FakeCacheInvalidations
+		fakeVarSourcePool *credsfakes.FakeVarSourcePool
+		secrets           *invalidatedSecrets
+
+		notifier chan bool
+		process  ifrit.Process
+	)
+
+	BeforeEach(func() {
+		notifier = make(chan bool, 1)
+
+		fakeNotifications = new(credsfakes.FakeNotifications)
+		fakeNotifications.ListenReturns(notifier, nil)
+
+		fakeInvalidations = new(credsfakes.FakeCacheInvalidations)
+		fakeInvalidations.LatestCacheInvalidationIDReturns(41, nil)
+
+		fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+
+		secrets = &invalidatedSecrets{
+			FakeSecrets:   new(credsfakes.FakeSecrets),
+			invalidations: make(chan creds.CacheInvalidation, 10),
+		}
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(creds.NewCacheInvalidationListener(
+			lagertest.NewTestLogger("test"),
+			fakeNotifications,
+			fakeInvalidations,
+			secrets,
+			fakeVarSourcePool,
+		))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		<-process.Wait()
+	})
+
+	It("listens on the secret cache channel", func() {
+		Expect(fakeNotifications.ListenArgsForCall(0)).To(Equal(atc.SecretCacheChannel))
+	})
+
+	Context("when notified", func() {
+		BeforeEach(func() {
+			fakeInvalidations.CacheInvalidationsSinceReturnsOnCall(0, []creds.CacheInvalidation{
+				{ID: 42, TeamName: "some-team"},
+				{ID: 43, TeamName: "other-team", Path: "some-var"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			notifier <- true
+		})
+
+		It("invalidates the caches for the invalidations recorded since it started", func() {
+			Eventually(secrets.invalidations).Should(Receive(Equal(creds.CacheInvalidation{TeamName: "some-team"})))
+			Eventually(secrets.invalidations).Should(Receive(Equal(creds.CacheInvalidation{TeamName: "other-team", Path: "some-var"})))
+
+			Expect(fakeInvalidations.CacheInvalidationsSinceArgsForCall(0)).To(Equal(41))
+
+			Eventually(fakeVarSourcePool.InvalidateCacheCallCount).Should(Equal(2))
+			teamName, path := fakeVarSourcePool.InvalidateCacheArgsForCall(1)
+			Expect(teamName).To(Equal("other-team"))
+			Expect(path).To(Equal("some-var"))
+		})
+
+		It("picks up where it left off on the next notification", func() {
+			Eventually(fakeVarSourcePool.InvalidateCacheCallCount).Should(Equal(2))
+
+			notifier <- false
+
+			Eventually(fakeInvalidations.CacheInvalidationsSinceCallCount).Should(Equal(2))
+			Expect(fakeInvalidations.CacheInvalidationsSinceArgsForCall(1)).To(Equal(43))
+		})
+	})
+
+	Context("when listening fails", func() {
+		BeforeEach(func() {
+			fakeNotifications.ListenReturns(nil, errors.New("nope"))
+		})
+
+		It("exits with the error", func() {
+			Eventually(process.Wait()).Should(Receive(MatchError("nope")))
+		})
+	})
+})
@@ -1,8 +1,10 @@
 package creds
 
 import (
+	"sync"
 	"time"
 
+	"github.com/concourse/concourse/vars"
 	"github.com/patrickmn/go-cache"
 )
 
@@ -13,10 +15,28 @@ type SecretCacheConfig struct {
 	PurgeInterval    time.Duration `long:"secret-cache-purge-interval" default:"10m" description:"If the cache is enabled, expired items will be removed on this interval"`
 }
 
+const (
+	// how many secret paths are kept track of before sweeping out the ones
+	// which are no longer cached
+	minTrackedPaths = 1000
+
+	// how long a secret path is kept track of regardless of being cached, as
+	// its var is looked up right after it is tracked
+	trackedPathGracePeriod = time.Minute
+)
+
 type CachedSecrets struct {
 	secrets     Secrets
 	cacheConfig SecretCacheConfig
 	cache       *cache.Cache
+
+	// the secret paths each team's vars were looked up at, keyed by team and
+	// var path, along with when they were last looked up at, so that the
+	// cache can be invalidated for them
+	pathsLock    sync.Mutex
+	paths        map[string]map[string]map[string]time.Time
+	trackedPaths int
+	sweepAt      int
 }
 
 type CacheEntry struct {
@@ -33,23 +53,37 @@ func NewCachedSecrets(secrets Secrets, cacheConfig SecretCacheConfig) *CachedSec
 		secrets:     secrets,
 		cacheConfig: cacheConfig,
 		cache:       cache.New(cacheConfig.Duration, cacheConfig.PurgeInterval),
+		paths:       map[string]map[string]map[string]time.Time{},
+		sweepAt:     minTrackedPaths,
 	}
 }
 
 func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, _, found, err := cs.GetLeased(secretPath)
+	return value, expiration, found, err
+}
+
+// GetLeased is the same as Get, except that it also returns the lease backing
+// the secret. Secrets backed by a lease are never cached, as each one is
+// revoked once the build which fetched it finishes.
+func (cs *CachedSecrets) GetLeased(secretPath string) (interface{}, *time.Time, *vars.Lease, bool, error) {
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
 		result := entry.(CacheEntry)
-		return result.value, result.expiration, result.found, nil
+		return result.value, result.expiration, nil, result.found, nil
 	}
 
 	// otherwise, let's make a request to the underlying secret manager
-	value, expiration, found, err := cs.secrets.Get(secretPath)
+	value, expiration, lease, found, err := getLeased(cs.secrets, secretPath)
 
 	// we don't want to cache errors, let the errors be retried the next time around
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, nil, false, err
+	}
+
+	if lease != nil {
+		return value, expiration, lease, found, nil
 	}
 
 	// here we want to cache secret value, expiration, and found flag too
@@ -62,6 +96,12 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 		if expiration != nil {
 			// if secret lease time expires sooner, make duration smaller than default duration
 			itemDuration := time.Until(*expiration)
+			if itemDuration <= 0 {
+				// already expired; a non-positive duration would otherwise
+				// mean the default duration, or no expiration at all
+				return value, expiration, nil, found, nil
+			}
+
 			if itemDuration < duration {
 				duration = itemDuration
 			}
@@ -71,9 +111,138 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 		cs.cache.Set(secretPath, entry, cs.cacheConfig.DurationNotFound)
 	}
 
-	return value, expiration, found, nil
+	return value, expiration, nil, found, nil
+}
+
+func (cs *CachedSecrets) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	return renewLease(cs.secrets, leaseID, increment)
 }
 
+func (cs *CachedSecrets) RevokeLease(leaseID string) error {
+	return revokeLease(cs.secrets, leaseID)
+}
+
+// NewSecretLookupPaths returns the lookup paths of the underlying secrets,
+// keeping track of the secret paths each of the team's vars are looked up at.
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
-	return cs.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
+	lookupPaths := cs.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
+
+	tracked := make([]SecretLookupPath, len(lookupPaths))
+	for i, lookupPath := range lookupPaths {
+		tracked[i] = trackedLookupPath{
+			SecretLookupPath: lookupPath,
+			cache:            cs,
+			teamName:         teamName,
+		}
+	}
+
+	return tracked
+}
+
+// InvalidateCache removes the cached secrets the team's vars were looked up
+// at, or only the ones of the var with the given path. Secrets which are
+// shared with other teams are removed for them too.
+func (cs *CachedSecrets) InvalidateCache(teamName string, path string) {
+	cs.pathsLock.Lock()
+	defer cs.pathsLock.Unlock()
+
+	teamPaths := cs.paths[teamName]
+
+	for varPath, secretPaths := range teamPaths {
+		if path != "" && varPath != path {
+			continue
+		}
+
+		for secretPath := range secretPaths {
+			cs.cache.Delete(secretPath)
+		}
+
+		cs.trackedPaths -= len(secretPaths)
+		delete(teamPaths, varPath)
+	}
+
+	if path != "" {
+		// managers without lookup paths look vars up at their own path
+		cs.cache.Delete(path)
+	}
+}
+
+func (cs *CachedSecrets) track(teamName string, varPath string, secretPath string) {
+	cs.pathsLock.Lock()
+	defer cs.pathsLock.Unlock()
+
+	teamPaths, found := cs.paths[teamName]
+	if !found {
+		teamPaths = map[string]map[string]time.Time{}
+		cs.paths[teamName] = teamPaths
+	}
+
+	secretPaths, found := teamPaths[varPath]
+	if !found {
+		secretPaths = map[string]time.Time{}
+		teamPaths[varPath] = secretPaths
+	}
+
+	if _, found := secretPaths[secretPath]; !found {
+		cs.trackedPaths++
+	}
+
+	secretPaths[secretPath] = time.Now()
+
+	if cs.trackedPaths >= cs.sweepAt {
+		cs.sweep()
+	}
+}
+
+// sweep stops keeping track of the secret paths which are no longer cached,
+// as there's nothing left to invalidate for them. The next sweep happens once
+// the number of tracked paths has doubled, so that sweeping stays cheap.
+func (cs *CachedSecrets) sweep() {
+	for teamName, teamPaths := range cs.paths {
+		for varPath, secretPaths := range teamPaths {
+			for secretPath, trackedAt := range secretPaths {
+				if time.Since(trackedAt) < trackedPathGracePeriod {
+					continue
+				}
+
+				if _, cached := cs.cache.Get(secretPath); cached {
+					continue
+				}
+
+				delete(secretPaths, secretPath)
+				cs.trackedPaths--
+			}
+
+			if len(secretPaths) == 0 {
+				delete(teamPaths, varPath)
+			}
+		}
+
+		if len(teamPaths) == 0 {
+			delete(cs.paths, teamName)
+		}
+	}
+
+	cs.sweepAt = 2 * cs.trackedPaths
+	if cs.sweepAt < minTrackedPaths {
+		cs.sweepAt = minTrackedPaths
+	}
+}
+
+type trackedLookupPath struct {
+	SecretLookupPath
+
+	cache    *CachedSecrets
+	teamName string
+}
+
+func (path trackedLookupPath) VariableToSecretPath(varPath string) (string, error) {
+	secretPath, err := path.SecretLookupPath.VariableToSecretPath(varPath)
+	if err != nil {
+		return "", err
+	}
+
+	path.cache.track(path.teamName, varPath, secretPath)
+
+	return secretPath, nil
 }
@@ -0,0 +1,87 @@
+package creds
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type noSecrets struct{}
+
+func (noSecrets) Get(string) (interface{}, *time.Time, bool, error) {
+	return nil, nil, false, nil
+}
+
+func (noSecrets) NewSecretLookupPaths(string, string, bool) []SecretLookupPath {
+	return nil
+}
+
+var _ = Describe("Tracking the paths of cached secrets", func() {
+	var cachedSecrets *CachedSecrets
+
+	BeforeEach(func() {
+		cachedSecrets = NewCachedSecrets(noSecrets{}, SecretCacheConfig{
+			Duration:         time.Minute,
+			DurationNotFound: time.Minute,
+			PurgeInterval:    time.Minute,
+		})
+	})
+
+	trackedPaths := func() []string {
+		var tracked []string
+		for _, teamPaths := range cachedSecrets.paths {
+			for _, secretPaths := range teamPaths {
+				for secretPath := range secretPaths {
+					tracked = append(tracked, secretPath)
+				}
+			}
+		}
+		return tracked
+	}
+
+	backdate := func() {
+		for _, teamPaths := range cachedSecrets.paths {
+			for _, secretPaths := range teamPaths {
+				for secretPath := range secretPaths {
+					secretPaths[secretPath] = time.Now().Add(-2 * trackedPathGracePeriod)
+				}
+			}
+		}
+	}
+
+	It("stops tracking the paths which are no longer cached", func() {
+		cachedSecrets.track("team", "cached", "/concourse/team/cached")
+		cachedSecrets.track("team", "expired", "/concourse/team/expired")
+		cachedSecrets.track("other-team", "expired", "/concourse/other-team/expired")
+		cachedSecrets.cache.Set("/concourse/team/cached", CacheEntry{}, time.Minute)
+		backdate()
+
+		cachedSecrets.sweep()
+
+		Expect(trackedPaths()).To(ConsistOf("/concourse/team/cached"))
+		Expect(cachedSecrets.trackedPaths).To(Equal(1))
+		Expect(cachedSecrets.paths).ToNot(HaveKey("other-team"))
+	})
+
+	It("keeps tracking paths which were just looked up at", func() {
+		cachedSecrets.track("team", "foo", "/concourse/team/foo")
+
+		cachedSecrets.sweep()
+
+		Expect(trackedPaths()).To(ConsistOf("/concourse/team/foo"))
+	})
+
+	It("sweeps once enough paths are tracked", func() {
+		for i := 0; i < minTrackedPaths-1; i++ {
+			cachedSecrets.track("team", fmt.Sprintf("var-%d", i), fmt.Sprintf("/concourse/team/var-%d", i))
+		}
+		backdate()
+
+		cachedSecrets.track("team", "foo", "/concourse/team/foo")
+
+		Expect(trackedPaths()).To(ConsistOf("/concourse/team/foo"))
+		Expect(cachedSecrets.trackedPaths).To(Equal(1))
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/vars"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -22,6 +23,26 @@ func makeGetStub(name string, value interface{}, expiration *time.Time, found bo
 	}
 }
 
+type leasedSecrets struct {
+	*credsfakes.FakeSecrets
+
+	lease *vars.Lease
+	reads int
+}
+
+func (ls *leasedSecrets) GetLeased(secretPath string) (interface{}, *time.Time, *vars.Lease, bool, error) {
+	ls.reads++
+	return "some-dynamic-value", nil, ls.lease, true, nil
+}
+
+func (ls *leasedSecrets) RenewLease(string, time.Duration) (time.Duration, error) {
+	return 0, nil
+}
+
+func (ls *leasedSecrets) RevokeLease(string) error {
+	return nil
+}
+
 var _ = Describe("Caching of secrets", func() {
 
 	var secretManager *credsfakes.FakeSecrets
@@ -162,4 +183,107 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	It("should not cache secrets backed by a lease", func() {
+		underlying := &leasedSecrets{
+			FakeSecrets: new(credsfakes.FakeSecrets),
+			lease:       &vars.Lease{ID: "some-lease"},
+		}
+		cachedSecretManager = creds.NewCachedSecrets(underlying, cacheConfig)
+
+		value, _, lease, found, err := cachedSecretManager.GetLeased("foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-dynamic-value"))
+		Expect(lease.ID).To(Equal("some-lease"))
+
+		_, _, lease, _, err = cachedSecretManager.GetLeased("foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lease.ID).To(Equal("some-lease"))
+		Expect(underlying.reads).To(Equal(2))
+	})
+
+	It("should only cache secrets until they expire", func() {
+		expiration := time.Now().Add(200 * time.Millisecond)
+		secretManager.GetStub = makeGetStub("foo", "value", &expiration, true, nil, &underlyingReads, &underlyingMisses)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(1))
+
+		time.Sleep(time.Until(expiration) + time.Millisecond)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(2))
+	})
+
+	It("should not cache secrets which have already expired", func() {
+		expiration := time.Now().Add(-time.Second)
+		secretManager.GetStub = makeGetStub("foo", "value", &expiration, true, nil, &underlyingReads, &underlyingMisses)
+
+		value, _, found, err := cachedSecretManager.Get("foo")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("value"))
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(2))
+	})
+
+	Describe("InvalidateCache", func() {
+		var teamVars, otherTeamVars vars.Variables
+
+		BeforeEach(func() {
+			secretManager.NewSecretLookupPathsStub = func(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+				return []creds.SecretLookupPath{
+					creds.NewSecretLookupWithPrefix("/concourse/" + teamName + "/" + pipelineName + "/"),
+					creds.NewSecretLookupWithPrefix("/concourse/" + teamName + "/"),
+				}
+			}
+
+			secretManager.GetStub = func(secretPath string) (interface{}, *time.Time, bool, error) {
+				underlyingReads++
+				return secretPath, nil, true, nil
+			}
+
+			teamVars = creds.NewVariables(cachedSecretManager, "team", "pipeline", false)
+			otherTeamVars = creds.NewVariables(cachedSecretManager, "other-team", "pipeline", false)
+
+			for _, variables := range []vars.Variables{teamVars, otherTeamVars} {
+				for _, path := range []string{"foo", "bar"} {
+					_, _, err := variables.Get(vars.Reference{Path: path})
+					Expect(err).ToNot(HaveOccurred())
+				}
+			}
+
+			Expect(underlyingReads).To(Equal(4))
+		})
+
+		lookup := func(variables vars.Variables, path string) {
+			_, _, err := variables.Get(vars.Reference{Path: path})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("removes the team's cached secrets", func() {
+			cachedSecretManager.InvalidateCache("team", "")
+
+			lookup(teamVars, "foo")
+			lookup(teamVars, "bar")
+			Expect(underlyingReads).To(Equal(6))
+
+			lookup(otherTeamVars, "foo")
+			lookup(otherTeamVars, "bar")
+			Expect(underlyingReads).To(Equal(6))
+		})
+
+		It("removes the cached secrets of a var", func() {
+			cachedSecretManager.InvalidateCache("team", "foo")
+
+			lookup(teamVars, "foo")
+			Expect(underlyingReads).To(Equal(5))
+
+			lookup(teamVars, "bar")
+			lookup(otherTeamVars, "foo")
+			Expect(underlyingReads).To(Equal(5))
+		})
+	})
 })
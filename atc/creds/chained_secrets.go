@@ -0,0 +1,301 @@
+package creds
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/vars"
+	"github.com/golang/groupcache/lru"
+)
+
+// maxResolvedVars is the number of vars the chain remembers the resolving
+// manager of. Once exceeded, the least recently resolved vars are forgotten.
+const maxResolvedVars = 10000
+
+// ChainRule routes the lookups for a team, for vars whose path starts with a
+// prefix, or for both, to a single credential manager in the chain.
+type ChainRule struct {
+	Team       string
+	PathPrefix string
+	Manager    string
+}
+
+func (rule *ChainRule) UnmarshalFlag(value string) error {
+	i := strings.LastIndex(value, "=")
+	if i == -1 {
+		return fmt.Errorf("invalid credential manager rule '%s' (must be [team:TEAM][,path:PREFIX]=MANAGER)", value)
+	}
+
+	rule.Manager = value[i+1:]
+	if rule.Manager == "" {
+		return fmt.Errorf("invalid credential manager rule '%s' (missing manager)", value)
+	}
+
+	for _, match := range strings.Split(value[:i], ",") {
+		kv := strings.SplitN(match, ":", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return fmt.Errorf("invalid credential manager rule '%s' (must be [team:TEAM][,path:PREFIX]=MANAGER)", value)
+		}
+
+		switch kv[0] {
+		case "team":
+			rule.Team = kv[1]
+		case "path":
+			rule.PathPrefix = kv[1]
+		default:
+			return fmt.Errorf("invalid credential manager rule '%s' (unknown match '%s')", value, kv[0])
+		}
+	}
+
+	return nil
+}
+
+func (rule ChainRule) matches(teamName string, path string) bool {
+	if rule.Team != "" && rule.Team != teamName {
+		return false
+	}
+
+	return strings.HasPrefix(path, rule.PathPrefix)
+}
+
+// NamedSecrets is the Secrets of a credential manager along with the name it
+// is configured as.
+type NamedSecrets struct {
+	Name    string
+	Secrets Secrets
+}
+
+// ChainedSecrets looks up vars in an ordered chain of credential managers,
+// e.g. while migrating from one to another.
+//
+// Each manager is asked in turn, using its own lookup paths, until one of
+// them has the var. A var matching one of the rules is only looked up in the
+// manager the rule points to. The manager resolving each var is logged
+// whenever it changes and reported by ResolvedBy, so that it's possible to
+// tell when a var has moved from one manager to the next.
+type ChainedSecrets struct {
+	logger   lager.Logger
+	managers []NamedSecrets
+	rules    []ChainRule
+
+	// the manager which last resolved each var, keyed by team, pipeline and
+	// var path
+	resolvedBy   *lru.Cache
+	resolvedByMu *sync.Mutex // lru.Cache is not safe for concurrent access
+}
+
+func NewChainedSecrets(logger lager.Logger, managers []NamedSecrets, rules []ChainRule) (*ChainedSecrets, error) {
+	names := map[string]bool{}
+	for _, manager := range managers {
+		names[manager.Name] = true
+	}
+
+	for _, rule := range rules {
+		if !names[rule.Manager] {
+			return nil, fmt.Errorf("credential manager rule refers to '%s', which is not in the chain", rule.Manager)
+		}
+	}
+
+	return &ChainedSecrets{
+		logger:       logger,
+		managers:     managers,
+		rules:        rules,
+		resolvedBy:   lru.New(maxResolvedVars),
+		resolvedByMu: &sync.Mutex{},
+	}, nil
+}
+
+// Get asks each manager in turn for the secret at the given path. It is only
+// used directly by managers without lookup paths; vars are normally looked
+// up through NewVariables, which uses each manager's own lookup paths.
+func (cs *ChainedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, _, found, err := cs.GetLeased(secretPath)
+	return value, expiration, found, err
+}
+
+// GetLeased is the same as Get, but also returns the lease backing the
+// secret, if any.
+func (cs *ChainedSecrets) GetLeased(secretPath string) (interface{}, *time.Time, *vars.Lease, bool, error) {
+	for _, manager := range cs.managers {
+		value, expiration, lease, found, err := getLeased(manager.Secrets, secretPath)
+		if err != nil {
+			return nil, nil, nil, false, fmt.Errorf("credential manager '%s': %w", manager.Name, err)
+		}
+
+		if found {
+			return value, expiration, lease, true, nil
+		}
+	}
+
+	return nil, nil, nil, false, nil
+}
+
+// RenewLease renews the lease with the first manager in the chain which
+// supports leases.
+func (cs *ChainedSecrets) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	for _, manager := range cs.managers {
+		duration, err := renewLease(manager.Secrets, leaseID, increment)
+		if err == ErrLeasesNotSupported {
+			continue
+		}
+
+		return duration, err
+	}
+
+	return 0, ErrLeasesNotSupported
+}
+
+// RevokeLease revokes the lease with the first manager in the chain which
+// supports leases.
+func (cs *ChainedSecrets) RevokeLease(leaseID string) error {
+	for _, manager := range cs.managers {
+		err := revokeLease(manager.Secrets, leaseID)
+		if err == ErrLeasesNotSupported {
+			continue
+		}
+
+		return err
+	}
+
+	return ErrLeasesNotSupported
+}
+
+// InvalidateCache invalidates the cache of every manager in the chain.
+func (cs *ChainedSecrets) InvalidateCache(teamName string, path string) {
+	for _, manager := range cs.managers {
+		invalidateCache(manager.Secrets, teamName, path)
+	}
+}
+
+// NewSecretLookupPaths returns the lookup paths of every manager in the
+// chain, in order.
+func (cs *ChainedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
+	var paths []SecretLookupPath
+	for _, manager := range cs.managers {
+		paths = append(paths, manager.Secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)...)
+	}
+
+	return paths
+}
+
+// NewVariables returns the vars of the given team and pipeline, looked up in
+// each manager using its own lookup paths.
+func (cs *ChainedSecrets) NewVariables(teamName string, pipelineName string, allowRootPath bool) vars.Variables {
+	lookups := make([]namedLookup, len(cs.managers))
+	for i, manager := range cs.managers {
+		lookups[i] = namedLookup{
+			name: manager.Name,
+			lookup: VariableLookupFromSecrets{
+				Secrets:     manager.Secrets,
+				LookupPaths: manager.Secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath),
+			},
+		}
+	}
+
+	return chainedVariables{
+		chain:        cs,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+		lookups:      lookups,
+	}
+}
+
+// ResolvedBy returns the name of the manager which last resolved the var of
+// the given team and pipeline. Only the most recently resolved vars are
+// remembered.
+func (cs *ChainedSecrets) ResolvedBy(teamName string, pipelineName string, path string) (string, bool) {
+	cs.resolvedByMu.Lock()
+	defer cs.resolvedByMu.Unlock()
+
+	manager, found := cs.resolvedBy.Get(resolvedKey(teamName, pipelineName, path))
+	if !found {
+		return "", false
+	}
+
+	return manager.(string), true
+}
+
+func (cs *ChainedSecrets) resolved(teamName string, pipelineName string, path string, manager string) bool {
+	cs.resolvedByMu.Lock()
+	defer cs.resolvedByMu.Unlock()
+
+	key := resolvedKey(teamName, pipelineName, path)
+
+	previous, found := cs.resolvedBy.Get(key)
+	if found && previous == manager {
+		return false
+	}
+
+	cs.resolvedBy.Add(key, manager)
+
+	return true
+}
+
+func resolvedKey(teamName string, pipelineName string, path string) string {
+	return teamName + "/" + pipelineName + "/" + path
+}
+
+type namedLookup struct {
+	name   string
+	lookup VariableLookupFromSecrets
+}
+
+type chainedVariables struct {
+	chain        *ChainedSecrets
+	teamName     string
+	pipelineName string
+	lookups      []namedLookup
+}
+
+func (cv chainedVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	val, _, found, err := cv.GetLeased(ref)
+	return val, found, err
+}
+
+func (cv chainedVariables) GetLeased(ref vars.Reference) (interface{}, *vars.Lease, bool, error) {
+	manager := ""
+	for _, rule := range cv.chain.rules {
+		if rule.matches(cv.teamName, ref.Path) {
+			manager = rule.Manager
+			break
+		}
+	}
+
+	for _, l := range cv.lookups {
+		if manager != "" && l.name != manager {
+			continue
+		}
+
+		val, lease, found, err := l.lookup.GetLeased(ref)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("credential manager '%s': %w", l.name, err)
+		}
+
+		if found {
+			cv.resolved(ref.Path, l.name)
+			return val, lease, true, nil
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
+func (cv chainedVariables) resolved(path string, manager string) {
+	if !cv.chain.resolved(cv.teamName, cv.pipelineName, path, manager) {
+		return
+	}
+
+	cv.chain.logger.Info("var-resolved", lager.Data{
+		"team":     cv.teamName,
+		"pipeline": cv.pipelineName,
+		"var":      path,
+		"manager":  manager,
+	})
+}
+
+func (cv chainedVariables) List() ([]vars.Reference, error) {
+	return nil, nil
+}
@@ -0,0 +1,193 @@
+package creds_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/creds/dummy"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChainedSecrets", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		oldManager creds.Secrets
+		newManager creds.Secrets
+		rules      []creds.ChainRule
+
+		secrets   creds.Secrets
+		variables vars.Variables
+		err       error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		oldManager = dummy.NewSecretsFactory([]dummy.VarFlag{
+			{Name: "team/pipeline/migrated", Value: "old-value"},
+			{Name: "team/legacy", Value: "legacy-value"},
+			{Name: "other-team/shared", Value: "old-shared-value"},
+		}).NewSecrets()
+
+		newManager = dummy.NewSecretsFactory([]dummy.VarFlag{
+			{Name: "team/pipeline/migrated", Value: "new-value"},
+			{Name: "team/shared", Value: "new-shared-value"},
+			{Name: "other-team/shared", Value: "new-shared-value"},
+		}).NewSecrets()
+
+		rules = nil
+	})
+
+	JustBeforeEach(func() {
+		secrets, err = creds.NewChainedSecrets(logger, []creds.NamedSecrets{
+			{Name: "new", Secrets: newManager},
+			{Name: "old", Secrets: oldManager},
+		}, rules)
+		Expect(err).ToNot(HaveOccurred())
+
+		variables = creds.NewVariables(secrets, "team", "pipeline", false)
+	})
+
+	lookup := func(path string) (interface{}, bool) {
+		val, found, err := variables.Get(vars.Reference{Path: path})
+		Expect(err).ToNot(HaveOccurred())
+		return val, found
+	}
+
+	It("looks up vars in each manager in order", func() {
+		val, found := lookup("migrated")
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("new-value"))
+
+		val, found = lookup("legacy")
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("legacy-value"))
+
+		_, found = lookup("missing")
+		Expect(found).To(BeFalse())
+	})
+
+	It("logs which manager resolved each var", func() {
+		lookup("migrated")
+		lookup("legacy")
+
+		logs := logger.LogMessages()
+		Expect(logs).To(HaveLen(2))
+		Expect(logger.Logs()[0].Data).To(HaveKeyWithValue("var", "migrated"))
+		Expect(logger.Logs()[0].Data).To(HaveKeyWithValue("manager", "new"))
+		Expect(logger.Logs()[1].Data).To(HaveKeyWithValue("var", "legacy"))
+		Expect(logger.Logs()[1].Data).To(HaveKeyWithValue("manager", "old"))
+	})
+
+	It("only logs the resolving manager when it changes", func() {
+		lookup("migrated")
+		lookup("migrated")
+
+		Expect(logger.LogMessages()).To(HaveLen(1))
+	})
+
+	It("reports which manager resolved each var", func() {
+		lookup("migrated")
+		lookup("legacy")
+
+		resolving, ok := secrets.(creds.ResolvingSecrets)
+		Expect(ok).To(BeTrue())
+
+		manager, found := resolving.ResolvedBy("team", "pipeline", "migrated")
+		Expect(found).To(BeTrue())
+		Expect(manager).To(Equal("new"))
+
+		manager, found = resolving.ResolvedBy("team", "pipeline", "legacy")
+		Expect(found).To(BeTrue())
+		Expect(manager).To(Equal("old"))
+
+		_, found = resolving.ResolvedBy("team", "other-pipeline", "migrated")
+		Expect(found).To(BeFalse())
+	})
+
+	It("returns the lookup paths of every manager", func() {
+		Expect(secrets.NewSecretLookupPaths("team", "pipeline", false)).To(HaveLen(6))
+	})
+
+	Context("when a rule routes a team to a manager", func() {
+		BeforeEach(func() {
+			rules = []creds.ChainRule{{Team: "team", Manager: "old"}}
+		})
+
+		It("only looks up the team's vars in that manager", func() {
+			val, found := lookup("migrated")
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("old-value"))
+
+			_, found = lookup("shared")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when a rule routes a path prefix to a manager", func() {
+		BeforeEach(func() {
+			rules = []creds.ChainRule{{PathPrefix: "mig", Manager: "old"}}
+		})
+
+		It("only looks up the matching vars in that manager", func() {
+			val, found := lookup("migrated")
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("old-value"))
+
+			val, found = lookup("shared")
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("new-shared-value"))
+		})
+	})
+
+	Context("when a manager fails", func() {
+		BeforeEach(func() {
+			fakeSecrets := new(credsfakes.FakeSecrets)
+			fakeSecrets.GetReturns(nil, nil, false, errors.New("nope"))
+			newManager = fakeSecrets
+		})
+
+		It("returns the error along with the manager's name", func() {
+			_, _, err := variables.Get(vars.Reference{Path: "legacy"})
+			Expect(err).To(MatchError(ContainSubstring("credential manager 'new'")))
+		})
+	})
+
+	Context("when a rule refers to a manager outside the chain", func() {
+		It("errors", func() {
+			_, err := creds.NewChainedSecrets(logger, []creds.NamedSecrets{
+				{Name: "new", Secrets: newManager},
+			}, []creds.ChainRule{{Team: "team", Manager: "old"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ChainRule", func() {
+	DescribeTable("UnmarshalFlag",
+		func(value string, expected creds.ChainRule, valid bool) {
+			var rule creds.ChainRule
+			err := rule.UnmarshalFlag(value)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rule).To(Equal(expected))
+		},
+		Entry("team", "team:main=vault", creds.ChainRule{Team: "main", Manager: "vault"}, true),
+		Entry("path prefix", "path:aws.=vault", creds.ChainRule{PathPrefix: "aws.", Manager: "vault"}, true),
+		Entry("team and path prefix", "team:main,path:aws.=vault", creds.ChainRule{Team: "main", PathPrefix: "aws.", Manager: "vault"}, true),
+		Entry("missing manager", "team:main=", creds.ChainRule{}, false),
+		Entry("missing match", "vault", creds.ChainRule{}, false),
+		Entry("unknown match", "pipeline:main=vault", creds.ChainRule{}, false),
+	)
+})
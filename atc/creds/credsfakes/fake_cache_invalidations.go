@@ -0,0 +1,186 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeCacheInvalidations struct {
+	CacheInvalidationsSinceStub        func(int) ([]creds.CacheInvalidation, error)
+	cacheInvalidationsSinceMutex       sync.RWMutex
+	cacheInvalidationsSinceArgsForCall []struct {
+		arg1 int
+	}
+	cacheInvalidationsSinceReturns struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}
+	cacheInvalidationsSinceReturnsOnCall map[int]struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}
+	LatestCacheInvalidationIDStub        func() (int, error)
+	latestCacheInvalidationIDMutex       sync.RWMutex
+	latestCacheInvalidationIDArgsForCall []struct {
+	}
+	latestCacheInvalidationIDReturns struct {
+		result1 int
+		result2 error
+	}
+	latestCacheInvalidationIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCacheInvalidations) CacheInvalidationsSince(arg1 int) ([]creds.CacheInvalidation, error) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	ret, specificReturn := fake.cacheInvalidationsSinceReturnsOnCall[len(fake.cacheInvalidationsSinceArgsForCall)]
+	fake.cacheInvalidationsSinceArgsForCall = append(fake.cacheInvalidationsSinceArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.CacheInvalidationsSinceStub
+	fakeReturns := fake.cacheInvalidationsSinceReturns
+	fake.recordInvocation("CacheInvalidationsSince", []interface{}{arg1})
+	fake.cacheInvalidationsSinceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCacheInvalidations) CacheInvalidationsSinceCallCount() int {
+	fake.cacheInvalidationsSinceMutex.RLock()
+	defer fake.cacheInvalidationsSinceMutex.RUnlock()
+	return len(fake.cacheInvalidationsSinceArgsForCall)
+}
+
+func (fake *FakeCacheInvalidations) CacheInvalidationsSinceCalls(stub func(int) ([]creds.CacheInvalidation, error)) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	defer fake.cacheInvalidationsSinceMutex.Unlock()
+	fake.CacheInvalidationsSinceStub = stub
+}
+
+func (fake *FakeCacheInvalidations) CacheInvalidationsSinceArgsForCall(i int) int {
+	fake.cacheInvalidationsSinceMutex.RLock()
+	defer fake.cacheInvalidationsSinceMutex.RUnlock()
+	argsForCall := fake.cacheInvalidationsSinceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCacheInvalidations) CacheInvalidationsSinceReturns(result1 []creds.CacheInvalidation, result2 error) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	defer fake.cacheInvalidationsSinceMutex.Unlock()
+	fake.CacheInvalidationsSinceStub = nil
+	fake.cacheInvalidationsSinceReturns = struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidations) CacheInvalidationsSinceReturnsOnCall(i int, result1 []creds.CacheInvalidation, result2 error) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	defer fake.cacheInvalidationsSinceMutex.Unlock()
+	fake.CacheInvalidationsSinceStub = nil
+	if fake.cacheInvalidationsSinceReturnsOnCall == nil {
+		fake.cacheInvalidationsSinceReturnsOnCall = make(map[int]struct {
+			result1 []creds.CacheInvalidation
+			result2 error
+		})
+	}
+	fake.cacheInvalidationsSinceReturnsOnCall[i] = struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidations) LatestCacheInvalidationID() (int, error) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	ret, specificReturn := fake.latestCacheInvalidationIDReturnsOnCall[len(fake.latestCacheInvalidationIDArgsForCall)]
+	fake.latestCacheInvalidationIDArgsForCall = append(fake.latestCacheInvalidationIDArgsForCall, struct {
+	}{})
+	stub := fake.LatestCacheInvalidationIDStub
+	fakeReturns := fake.latestCacheInvalidationIDReturns
+	fake.recordInvocation("LatestCacheInvalidationID", []interface{}{})
+	fake.latestCacheInvalidationIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCacheInvalidations) LatestCacheInvalidationIDCallCount() int {
+	fake.latestCacheInvalidationIDMutex.RLock()
+	defer fake.latestCacheInvalidationIDMutex.RUnlock()
+	return len(fake.latestCacheInvalidationIDArgsForCall)
+}
+
+func (fake *FakeCacheInvalidations) LatestCacheInvalidationIDCalls(stub func() (int, error)) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	defer fake.latestCacheInvalidationIDMutex.Unlock()
+	fake.LatestCacheInvalidationIDStub = stub
+}
+
+func (fake *FakeCacheInvalidations) LatestCacheInvalidationIDReturns(result1 int, result2 error) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	defer fake.latestCacheInvalidationIDMutex.Unlock()
+	fake.LatestCacheInvalidationIDStub = nil
+	fake.latestCacheInvalidationIDReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidations) LatestCacheInvalidationIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	defer fake.latestCacheInvalidationIDMutex.Unlock()
+	fake.LatestCacheInvalidationIDStub = nil
+	if fake.latestCacheInvalidationIDReturnsOnCall == nil {
+		fake.latestCacheInvalidationIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.latestCacheInvalidationIDReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidations) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cacheInvalidationsSinceMutex.RLock()
+	defer fake.cacheInvalidationsSinceMutex.RUnlock()
+	fake.latestCacheInvalidationIDMutex.RLock()
+	defer fake.latestCacheInvalidationIDMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCacheInvalidations) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.CacheInvalidations = new(FakeCacheInvalidations)
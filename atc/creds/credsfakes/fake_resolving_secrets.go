@@ -0,0 +1,288 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeResolvingSecrets struct {
+	GetStub        func(string) (interface{}, *time.Time, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 string
+	}
+	getReturns struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}
+	NewSecretLookupPathsStub        func(string, string, bool) []creds.SecretLookupPath
+	newSecretLookupPathsMutex       sync.RWMutex
+	newSecretLookupPathsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	newSecretLookupPathsReturns struct {
+		result1 []creds.SecretLookupPath
+	}
+	newSecretLookupPathsReturnsOnCall map[int]struct {
+		result1 []creds.SecretLookupPath
+	}
+	ResolvedByStub        func(string, string, string) (string, bool)
+	resolvedByMutex       sync.RWMutex
+	resolvedByArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	resolvedByReturns struct {
+		result1 string
+		result2 bool
+	}
+	resolvedByReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResolvingSecrets) Get(arg1 string) (interface{}, *time.Time, bool, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeResolvingSecrets) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeResolvingSecrets) GetCalls(stub func(string) (interface{}, *time.Time, bool, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeResolvingSecrets) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResolvingSecrets) GetReturns(result1 interface{}, result2 *time.Time, result3 bool, result4 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeResolvingSecrets) GetReturnsOnCall(i int, result1 interface{}, result2 *time.Time, result3 bool, result4 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 *time.Time
+			result3 bool
+			result4 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeResolvingSecrets) NewSecretLookupPaths(arg1 string, arg2 string, arg3 bool) []creds.SecretLookupPath {
+	fake.newSecretLookupPathsMutex.Lock()
+	ret, specificReturn := fake.newSecretLookupPathsReturnsOnCall[len(fake.newSecretLookupPathsArgsForCall)]
+	fake.newSecretLookupPathsArgsForCall = append(fake.newSecretLookupPathsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.NewSecretLookupPathsStub
+	fakeReturns := fake.newSecretLookupPathsReturns
+	fake.recordInvocation("NewSecretLookupPaths", []interface{}{arg1, arg2, arg3})
+	fake.newSecretLookupPathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResolvingSecrets) NewSecretLookupPathsCallCount() int {
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	return len(fake.newSecretLookupPathsArgsForCall)
+}
+
+func (fake *FakeResolvingSecrets) NewSecretLookupPathsCalls(stub func(string, string, bool) []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = stub
+}
+
+func (fake *FakeResolvingSecrets) NewSecretLookupPathsArgsForCall(i int) (string, string, bool) {
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	argsForCall := fake.newSecretLookupPathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResolvingSecrets) NewSecretLookupPathsReturns(result1 []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = nil
+	fake.newSecretLookupPathsReturns = struct {
+		result1 []creds.SecretLookupPath
+	}{result1}
+}
+
+func (fake *FakeResolvingSecrets) NewSecretLookupPathsReturnsOnCall(i int, result1 []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = nil
+	if fake.newSecretLookupPathsReturnsOnCall == nil {
+		fake.newSecretLookupPathsReturnsOnCall = make(map[int]struct {
+			result1 []creds.SecretLookupPath
+		})
+	}
+	fake.newSecretLookupPathsReturnsOnCall[i] = struct {
+		result1 []creds.SecretLookupPath
+	}{result1}
+}
+
+func (fake *FakeResolvingSecrets) ResolvedBy(arg1 string, arg2 string, arg3 string) (string, bool) {
+	fake.resolvedByMutex.Lock()
+	ret, specificReturn := fake.resolvedByReturnsOnCall[len(fake.resolvedByArgsForCall)]
+	fake.resolvedByArgsForCall = append(fake.resolvedByArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ResolvedByStub
+	fakeReturns := fake.resolvedByReturns
+	fake.recordInvocation("ResolvedBy", []interface{}{arg1, arg2, arg3})
+	fake.resolvedByMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResolvingSecrets) ResolvedByCallCount() int {
+	fake.resolvedByMutex.RLock()
+	defer fake.resolvedByMutex.RUnlock()
+	return len(fake.resolvedByArgsForCall)
+}
+
+func (fake *FakeResolvingSecrets) ResolvedByCalls(stub func(string, string, string) (string, bool)) {
+	fake.resolvedByMutex.Lock()
+	defer fake.resolvedByMutex.Unlock()
+	fake.ResolvedByStub = stub
+}
+
+func (fake *FakeResolvingSecrets) ResolvedByArgsForCall(i int) (string, string, string) {
+	fake.resolvedByMutex.RLock()
+	defer fake.resolvedByMutex.RUnlock()
+	argsForCall := fake.resolvedByArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResolvingSecrets) ResolvedByReturns(result1 string, result2 bool) {
+	fake.resolvedByMutex.Lock()
+	defer fake.resolvedByMutex.Unlock()
+	fake.ResolvedByStub = nil
+	fake.resolvedByReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeResolvingSecrets) ResolvedByReturnsOnCall(i int, result1 string, result2 bool) {
+	fake.resolvedByMutex.Lock()
+	defer fake.resolvedByMutex.Unlock()
+	fake.ResolvedByStub = nil
+	if fake.resolvedByReturnsOnCall == nil {
+		fake.resolvedByReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+		})
+	}
+	fake.resolvedByReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeResolvingSecrets) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	fake.resolvedByMutex.RLock()
+	defer fake.resolvedByMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeResolvingSecrets) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.ResolvingSecrets = new(FakeResolvingSecrets)
@@ -28,6 +28,12 @@ type FakeVarSourcePool struct {
 		result1 creds.Secrets
 		result2 error
 	}
+	InvalidateCacheStub        func(string, string)
+	invalidateCacheMutex       sync.RWMutex
+	invalidateCacheArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
 	SizeStub        func() int
 	sizeMutex       sync.RWMutex
 	sizeArgsForCall []struct {
@@ -132,6 +138,39 @@ func (fake *FakeVarSourcePool) FindOrCreateReturnsOnCall(i int, result1 creds.Se
 	}{result1, result2}
 }
 
+func (fake *FakeVarSourcePool) InvalidateCache(arg1 string, arg2 string) {
+	fake.invalidateCacheMutex.Lock()
+	fake.invalidateCacheArgsForCall = append(fake.invalidateCacheArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.InvalidateCacheStub
+	fake.recordInvocation("InvalidateCache", []interface{}{arg1, arg2})
+	fake.invalidateCacheMutex.Unlock()
+	if stub != nil {
+		fake.InvalidateCacheStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheCallCount() int {
+	fake.invalidateCacheMutex.RLock()
+	defer fake.invalidateCacheMutex.RUnlock()
+	return len(fake.invalidateCacheArgsForCall)
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheCalls(stub func(string, string)) {
+	fake.invalidateCacheMutex.Lock()
+	defer fake.invalidateCacheMutex.Unlock()
+	fake.InvalidateCacheStub = stub
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheArgsForCall(i int) (string, string) {
+	fake.invalidateCacheMutex.RLock()
+	defer fake.invalidateCacheMutex.RUnlock()
+	argsForCall := fake.invalidateCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeVarSourcePool) Size() int {
 	fake.sizeMutex.Lock()
 	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
@@ -192,6 +231,8 @@ func (fake *FakeVarSourcePool) Invocations() map[string][][]interface{} {
 	defer fake.closeMutex.RUnlock()
 	fake.findOrCreateMutex.RLock()
 	defer fake.findOrCreateMutex.RUnlock()
+	fake.invalidateCacheMutex.RLock()
+	defer fake.invalidateCacheMutex.RUnlock()
 	fake.sizeMutex.RLock()
 	defer fake.sizeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
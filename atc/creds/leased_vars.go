@@ -0,0 +1,110 @@
+package creds
+
+import (
+	"fmt"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/vars"
+	"github.com/golang/groupcache/lru"
+)
+
+// maxLeasedVars is the number of vars backed by a lease which are remembered.
+// Once exceeded, the least recently leased vars are forgotten.
+const maxLeasedVars = 10000
+
+// LeasedVarError is returned when looking up a var backed by a lease outside
+// of a build, which is the only place its lease can be kept track of.
+type LeasedVarError struct {
+	Name string
+}
+
+func (err LeasedVarError) Error() string {
+	return fmt.Sprintf("var '%s' is backed by a lease and can only be used by builds", err.Name)
+}
+
+// LeasedVars remembers the vars known to be backed by a lease, keyed by team,
+// pipeline, var source and path. Builds record the vars they lease, so that
+// the rest of the ATC no longer looks them up.
+type LeasedVars struct {
+	lock  sync.Mutex // lru.Cache is not safe for concurrent access
+	cache *lru.Cache
+}
+
+func NewLeasedVars() *LeasedVars {
+	return &LeasedVars{
+		cache: lru.New(maxLeasedVars),
+	}
+}
+
+// Record remembers that the var of the given team and pipeline is backed by
+// a lease, so that it's no longer looked up outside of builds.
+func (leased *LeasedVars) Record(teamName string, pipelineName string, ref vars.Reference) {
+	leased.lock.Lock()
+	defer leased.lock.Unlock()
+
+	leased.cache.Add(leasedVarKey(teamName, pipelineName, ref), true)
+}
+
+func (leased *LeasedVars) isLeased(teamName string, pipelineName string, ref vars.Reference) bool {
+	leased.lock.Lock()
+	defer leased.lock.Unlock()
+
+	_, found := leased.cache.Get(leasedVarKey(teamName, pipelineName, ref))
+	return found
+}
+
+func leasedVarKey(teamName string, pipelineName string, ref vars.Reference) string {
+	return teamName + "/" + pipelineName + "/" + ref.Source + ":" + ref.Path
+}
+
+// NewUnleasedVariables wraps the variables of a pipeline which are looked up
+// outside of a build, e.g. to check that they exist. Nothing keeps track of
+// leases there, so vars known to be backed by one fail with a LeasedVarError
+// without being looked up, rather than issuing a new lease every time. A
+// lease which is issued anyway is revoked straight away.
+func NewUnleasedVariables(logger lager.Logger, leasedVars *LeasedVars, variables vars.Variables, teamName string, pipelineName string) vars.Variables {
+	return unleasedVariables{
+		logger:       logger,
+		leasedVars:   leasedVars,
+		variables:    variables,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+type unleasedVariables struct {
+	logger       lager.Logger
+	leasedVars   *LeasedVars
+	variables    vars.Variables
+	teamName     string
+	pipelineName string
+}
+
+func (v unleasedVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	if v.leasedVars.isLeased(v.teamName, v.pipelineName, ref) {
+		return nil, false, LeasedVarError{Name: ref.String()}
+	}
+
+	val, lease, found, err := vars.GetLeased(v.variables, ref)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if lease != nil {
+		v.leasedVars.Record(v.teamName, v.pipelineName, ref)
+
+		err := lease.Keeper.RevokeLease(lease.ID)
+		if err != nil {
+			v.logger.Error("failed-to-revoke-lease", err, lager.Data{"lease": lease.ID})
+		}
+
+		return nil, false, LeasedVarError{Name: ref.String()}
+	}
+
+	return val, found, nil
+}
+
+func (v unleasedVariables) List() ([]vars.Reference, error) {
+	return v.variables.List()
+}
@@ -0,0 +1,116 @@
+package creds_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+	"github.com/concourse/concourse/vars/varsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewUnleasedVariables", func() {
+	var (
+		fakeVariables *varsfakes.FakeVariables
+		fakeKeeper    *varsfakes.FakeLeaseKeeper
+		leasedVars    *creds.LeasedVars
+
+		variables vars.Variables
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(varsfakes.FakeVariables)
+		fakeKeeper = new(varsfakes.FakeLeaseKeeper)
+		leasedVars = creds.NewLeasedVars()
+	})
+
+	JustBeforeEach(func() {
+		variables = creds.NewUnleasedVariables(lagertest.NewTestLogger("test"), leasedVars, leasingVariables{
+			Variables: fakeVariables,
+			leased:    map[string]*vars.Lease{"leased": {ID: "some-lease", Keeper: fakeKeeper}},
+		}, "some-team", "some-pipeline")
+	})
+
+	It("looks up vars which aren't backed by a lease", func() {
+		fakeVariables.GetReturns("some-value", true, nil)
+
+		val, found, err := variables.Get(vars.Reference{Path: "plain"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("some-value"))
+	})
+
+	Context("when a var turns out to be backed by a lease", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			_, _, err = variables.Get(vars.Reference{Path: "leased"})
+		})
+
+		It("errors", func() {
+			Expect(err).To(Equal(creds.LeasedVarError{Name: "leased"}))
+		})
+
+		It("revokes the lease straight away", func() {
+			Expect(fakeKeeper.RevokeLeaseCallCount()).To(Equal(1))
+			Expect(fakeKeeper.RevokeLeaseArgsForCall(0)).To(Equal("some-lease"))
+		})
+
+		It("does not look the var up again", func() {
+			_, _, err := variables.Get(vars.Reference{Path: "leased"})
+			Expect(err).To(Equal(creds.LeasedVarError{Name: "leased"}))
+			Expect(fakeKeeper.RevokeLeaseCallCount()).To(Equal(1))
+		})
+
+		Context("when revoking the lease fails", func() {
+			BeforeEach(func() {
+				fakeKeeper.RevokeLeaseReturns(errors.New("nope"))
+			})
+
+			It("still errors", func() {
+				Expect(err).To(Equal(creds.LeasedVarError{Name: "leased"}))
+			})
+		})
+	})
+
+	Context("when a build has recorded the var as leased", func() {
+		BeforeEach(func() {
+			leasedVars.Record("some-team", "some-pipeline", vars.Reference{Path: "plain"})
+		})
+
+		It("does not look it up", func() {
+			_, _, err := variables.Get(vars.Reference{Path: "plain"})
+			Expect(err).To(Equal(creds.LeasedVarError{Name: "plain"}))
+			Expect(fakeVariables.GetCallCount()).To(BeZero())
+		})
+
+		It("still looks up the var for other pipelines", func() {
+			other := creds.NewUnleasedVariables(lagertest.NewTestLogger("test"), leasedVars, fakeVariables, "some-team", "other-pipeline")
+
+			_, _, err := other.Get(vars.Reference{Path: "plain"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+		})
+	})
+})
+
+// leasingVariables backs the given vars with a lease, looking up the rest in
+// the wrapped variables.
+type leasingVariables struct {
+	vars.Variables
+
+	leased map[string]*vars.Lease
+}
+
+func (v leasingVariables) GetLeased(ref vars.Reference) (interface{}, *vars.Lease, bool, error) {
+	lease, found := v.leased[ref.Path]
+	if found {
+		return "leased-value", lease, true, nil
+	}
+
+	val, found, err := v.Variables.Get(ref)
+	return val, nil, found, err
+}
@@ -25,6 +25,9 @@ type Managers map[string]Manager
 type CredentialManagementConfig struct {
 	RetryConfig SecretRetryConfig
 	CacheConfig SecretCacheConfig
+
+	Chain []string    `long:"credential-manager-chain" value-name:"NAME" description:"Name of a configured credential manager to look up vars in. Can be specified multiple times to look up vars in each manager in the given order. By default, the only configured manager is used."`
+	Rules []ChainRule `long:"credential-manager-rule" value-name:"[team:TEAM][,path:PREFIX]=NAME" description:"Look up the vars of a team, the vars starting with a prefix, or both, only in the given manager of the chain. Can be specified multiple times; the first matching rule applies."`
 }
 
 // NewSecrets creates a Secrets object from secretsFactory based on configs.
@@ -37,6 +40,27 @@ func (c CredentialManagementConfig) NewSecrets(secretsFactory SecretsFactory) Se
 	return result
 }
 
+// NewChainedSecrets creates a Secrets object looking up vars in each of the
+// named secretsFactories in order, each wrapped according to the configs.
+func (c CredentialManagementConfig) NewChainedSecrets(logger lager.Logger, secretsFactories []NamedSecretsFactory) (Secrets, error) {
+	managers := make([]NamedSecrets, len(secretsFactories))
+	for i, factory := range secretsFactories {
+		managers[i] = NamedSecrets{
+			Name:    factory.Name,
+			Secrets: c.NewSecrets(factory.SecretsFactory),
+		}
+	}
+
+	return NewChainedSecrets(logger, managers, c.Rules)
+}
+
+// NamedSecretsFactory is the SecretsFactory of a credential manager along
+// with the name it is configured as.
+type NamedSecretsFactory struct {
+	Name           string
+	SecretsFactory SecretsFactory
+}
+
 type HealthResponse struct {
 	Response interface{} `json:"response,omitempty"`
 	Error    string      `json:"error,omitempty"`
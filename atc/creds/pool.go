@@ -16,6 +16,9 @@ type VarSourcePool interface {
 	FindOrCreate(lager.Logger, map[string]interface{}, ManagerFactory) (Secrets, error)
 	Size() int
 	Close()
+
+	// InvalidateCache invalidates the cache of every var source in the pool.
+	InvalidateCache(teamName string, path string)
 }
 
 type inPoolManager struct {
@@ -115,6 +118,15 @@ func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]i
 	return pool.pool[key].getSecrets(), nil
 }
 
+func (pool *varSourcePool) InvalidateCache(teamName string, path string) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	for _, manager := range pool.pool {
+		invalidateCache(manager.secrets, teamName, path)
+	}
+}
+
 func (pool *varSourcePool) Close() {
 	pool.closeOnce.Do(func() {
 		close(pool.closed)
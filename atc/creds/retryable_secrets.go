@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/concourse/concourse/vars"
 	"github.com/concourse/retryhttp"
 )
 
@@ -23,20 +24,34 @@ func NewRetryableSecrets(secrets Secrets, retryConfig SecretRetryConfig) Secrets
 
 // Get retrieves the value and expiration of an individual secret
 func (rs RetryableSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	result, expiration, _, exists, err := rs.GetLeased(secretPath)
+	return result, expiration, exists, err
+}
+
+// GetLeased retrieves the value, expiration and lease of an individual secret
+func (rs RetryableSecrets) GetLeased(secretPath string) (interface{}, *time.Time, *vars.Lease, bool, error) {
 	r := &retryhttp.DefaultRetryer{}
 	for i := 0; i < rs.retryConfig.Attempts-1; i++ {
-		result, expiration, exists, err := rs.secrets.Get(secretPath)
+		result, expiration, lease, exists, err := getLeased(rs.secrets, secretPath)
 		if err != nil && r.IsRetryable(err) {
 			time.Sleep(rs.retryConfig.Interval)
 			continue
 		}
-		return result, expiration, exists, err
+		return result, expiration, lease, exists, err
 	}
-	result, expiration, exists, err := rs.secrets.Get(secretPath)
+	result, expiration, lease, exists, err := getLeased(rs.secrets, secretPath)
 	if err != nil {
 		err = fmt.Errorf("%s (after %d retries)", err, rs.retryConfig.Attempts)
 	}
-	return result, expiration, exists, err
+	return result, expiration, lease, exists, err
+}
+
+func (rs RetryableSecrets) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	return renewLease(rs.secrets, leaseID, increment)
+}
+
+func (rs RetryableSecrets) RevokeLease(leaseID string) error {
+	return revokeLease(rs.secrets, leaseID)
 }
 
 // NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
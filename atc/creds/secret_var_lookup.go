@@ -10,6 +10,10 @@ type VariableLookupFromSecrets struct {
 }
 
 func NewVariables(secrets Secrets, teamName string, pipelineName string, allowRootPath bool) vars.Variables {
+	if variables, ok := secrets.(VariablesSecrets); ok {
+		return variables.NewVariables(teamName, pipelineName, allowRootPath)
+	}
+
 	return VariableLookupFromSecrets{
 		Secrets:     secrets,
 		LookupPaths: secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath),
@@ -17,43 +21,50 @@ func NewVariables(secrets Secrets, teamName string, pipelineName string, allowRo
 }
 
 func (sl VariableLookupFromSecrets) Get(ref vars.Reference) (interface{}, bool, error) {
-	val, found, err := sl.get(ref.Path)
+	result, _, found, err := sl.GetLeased(ref)
+	return result, found, err
+}
+
+// GetLeased is the same as Get, but also returns the lease backing the secret,
+// if any.
+func (sl VariableLookupFromSecrets) GetLeased(ref vars.Reference) (interface{}, *vars.Lease, bool, error) {
+	val, lease, found, err := sl.get(ref.Path)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
 	if !found {
-		return nil, false, nil
+		return nil, nil, false, nil
 	}
 	result, err := vars.Traverse(val, ref.String(), ref.Fields)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, false, err
 	}
-	return result, true, nil
+	return result, lease, true, nil
 }
 
-func (sl VariableLookupFromSecrets) get(path string) (interface{}, bool, error) {
+func (sl VariableLookupFromSecrets) get(path string) (interface{}, *vars.Lease, bool, error) {
 	if len(sl.LookupPaths) == 0 {
 		// if no paths are specified (i.e. for fake & noop secret managers), then try 1-to-1 var->secret mapping
-		result, _, found, err := sl.Secrets.Get(path)
-		return result, found, err
+		result, _, lease, found, err := getLeased(sl.Secrets, path)
+		return result, lease, found, err
 	}
 	// try to find a secret according to our var->secret lookup paths
 	for _, rule := range sl.LookupPaths {
 		// prepends any additional prefix paths to front of the path
 		secretPath, err := rule.VariableToSecretPath(path)
 		if err != nil {
-			return nil, false, err
+			return nil, nil, false, err
 		}
-		result, _, found, err := sl.Secrets.Get(secretPath)
+		result, _, lease, found, err := getLeased(sl.Secrets, secretPath)
 		if err != nil {
-			return nil, false, err
+			return nil, nil, false, err
 		}
 		if !found {
 			continue
 		}
-		return result, true, nil
+		return result, lease, true, nil
 	}
-	return nil, false, nil
+	return nil, nil, false, nil
 }
 
 func (sl VariableLookupFromSecrets) List() ([]vars.Reference, error) {
@@ -1,7 +1,10 @@
 package creds
 
 import (
+	"errors"
 	"time"
+
+	"github.com/concourse/concourse/vars"
 )
 
 //go:generate counterfeiter . SecretsFactory
@@ -20,3 +23,101 @@ type Secrets interface {
 	// NewSecretLookupPaths returns an instance of lookup policy, which can transform pipeline ((var)) into one or more secret paths, based on team name and pipeline name
 	NewSecretLookupPaths(string, string, bool) []SecretLookupPath
 }
+
+// LeasedSecrets is implemented by Secrets which may return dynamic secrets
+// backed by a lease, which must be renewed while in use and revoked once no
+// longer needed.
+type LeasedSecrets interface {
+	Secrets
+	vars.LeaseKeeper
+
+	// GetLeased is the same as Get, but also returns the lease backing the
+	// secret, if any.
+	GetLeased(string) (interface{}, *time.Time, *vars.Lease, bool, error)
+}
+
+// CachingSecrets is implemented by Secrets which cache the secrets they look
+// up, and so need to be told when they change.
+type CachingSecrets interface {
+	Secrets
+
+	// InvalidateCache removes the cached secrets the team's vars were looked
+	// up at, or only the ones of the var with the given path.
+	InvalidateCache(teamName string, path string)
+}
+
+// VariablesSecrets is implemented by Secrets which look up vars themselves
+// rather than through a single list of lookup paths, e.g. a chain of
+// credential managers each with their own lookup paths.
+type VariablesSecrets interface {
+	Secrets
+
+	// NewVariables returns the vars of the given team and pipeline.
+	NewVariables(teamName string, pipelineName string, allowRootPath bool) vars.Variables
+}
+
+//go:generate counterfeiter . ResolvingSecrets
+
+// ResolvingSecrets is implemented by Secrets which pick which of several
+// credential managers each var is resolved by.
+type ResolvingSecrets interface {
+	Secrets
+
+	// ResolvedBy returns the name of the credential manager which last
+	// resolved the var of the given team and pipeline, if it's still known.
+	ResolvedBy(teamName string, pipelineName string, path string) (string, bool)
+}
+
+// ErrLeasesNotSupported is returned when renewing or revoking a lease with a
+// credential manager which does not issue leases.
+var ErrLeasesNotSupported = errors.New("credential manager does not support leases")
+
+func getLeased(secrets Secrets, secretPath string) (interface{}, *time.Time, *vars.Lease, bool, error) {
+	if leased, ok := secrets.(LeasedSecrets); ok {
+		return leased.GetLeased(secretPath)
+	}
+
+	value, expiration, found, err := secrets.Get(secretPath)
+	return value, expiration, nil, found, err
+}
+
+func invalidateCache(secrets Secrets, teamName string, path string) {
+	if caching, ok := secrets.(CachingSecrets); ok {
+		caching.InvalidateCache(teamName, path)
+	}
+}
+
+func renewLease(secrets Secrets, leaseID string, increment time.Duration) (time.Duration, error) {
+	if leased, ok := secrets.(LeasedSecrets); ok {
+		return leased.RenewLease(leaseID, increment)
+	}
+
+	return 0, ErrLeasesNotSupported
+}
+
+func revokeLease(secrets Secrets, leaseID string) error {
+	if leased, ok := secrets.(LeasedSecrets); ok {
+		return leased.RevokeLease(leaseID)
+	}
+
+	return ErrLeasesNotSupported
+}
+
+// NewLeaseKeeper returns a vars.LeaseKeeper which renews and revokes leases
+// through the given secrets, returning ErrLeasesNotSupported if they don't
+// issue leases.
+func NewLeaseKeeper(secrets Secrets) vars.LeaseKeeper {
+	return leaseKeeper{secrets}
+}
+
+type leaseKeeper struct {
+	secrets Secrets
+}
+
+func (keeper leaseKeeper) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	return renewLease(keeper.secrets, leaseID, increment)
+}
+
+func (keeper leaseKeeper) RevokeLease(leaseID string) error {
+	return revokeLease(keeper.secrets, leaseID)
+}
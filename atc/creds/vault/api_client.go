@@ -196,6 +196,24 @@ func (ac *APIClient) Renew() (time.Duration, error) {
 	return time.Duration(secret.Auth.LeaseDuration) * time.Second, nil
 }
 
+// RenewLease extends the lease of a dynamic secret by the given increment.
+// Returns the duration the lease is valid for, which may be less than
+// requested.
+func (ac *APIClient) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	secret, err := ac.client().Sys().Renew(leaseID, int(increment.Seconds()))
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(secret.LeaseDuration) * time.Second, nil
+}
+
+// RevokeLease revokes the lease of a dynamic secret, invalidating the
+// credentials it was issued with.
+func (ac *APIClient) RevokeLease(leaseID string) error {
+	return ac.client().Sys().Revoke(leaseID)
+}
+
 func (ac *APIClient) client() *vaultapi.Client {
 	return ac.clientValue.Load().(*vaultapi.Client)
 }
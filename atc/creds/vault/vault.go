@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
 
 	vaultapi "github.com/hashicorp/vault/api"
 )
@@ -21,10 +22,20 @@ type SecretReader interface {
 	Read(path string) (*vaultapi.Secret, error)
 }
 
+// A LeaseManager renews and revokes the leases of dynamic secrets, e.g.
+// credentials generated by the AWS or database secrets engines.
+type LeaseManager interface {
+	RenewLease(leaseID string, increment time.Duration) (time.Duration, error)
+	RevokeLease(leaseID string) error
+}
+
+var _ creds.LeasedSecrets = Vault{}
+
 // Vault converts a vault secret to our completely untyped secret
 // data.
 type Vault struct {
 	SecretReader    SecretReader
+	LeaseManager    LeaseManager
 	Prefix          string
 	LookupTemplates []*creds.SecretTemplate
 	SharedPath      string
@@ -51,28 +62,62 @@ func (v Vault) NewSecretLookupPaths(teamName string, pipelineName string, allowR
 
 // Get retrieves the value and expiration of an individual secret
 func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	val, expiration, _, found, err := v.GetLeased(secretPath)
+	return val, expiration, found, err
+}
+
+// GetLeased retrieves the value, expiration and lease of an individual
+// secret. Only dynamic secrets have a lease; secrets read from a KV engine
+// don't.
+func (v Vault) GetLeased(secretPath string) (interface{}, *time.Time, *vars.Lease, bool, error) {
 	if v.LoggedIn != nil {
 		select {
 		case <-v.LoggedIn:
 		case <-time.After(v.LoginTimeout):
-			return nil, nil, false, VaultLoginTimeout{}
+			return nil, nil, nil, false, VaultLoginTimeout{}
 		}
 	}
 
 	secret, expiration, found, err := v.findSecret(secretPath)
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, nil, false, err
 	}
 	if !found {
-		return nil, nil, false, nil
+		return nil, nil, nil, false, nil
+	}
+
+	var lease *vars.Lease
+	if secret.LeaseID != "" && v.LeaseManager != nil {
+		lease = &vars.Lease{
+			ID:        secret.LeaseID,
+			Duration:  time.Duration(secret.LeaseDuration) * time.Second,
+			Renewable: secret.Renewable,
+			Keeper:    v,
+		}
 	}
 
 	val, found := secret.Data["value"]
 	if found {
-		return val, expiration, true, nil
+		return val, expiration, lease, true, nil
+	}
+
+	return secret.Data, expiration, lease, true, nil
+}
+
+func (v Vault) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	if v.LeaseManager == nil {
+		return 0, creds.ErrLeasesNotSupported
+	}
+
+	return v.LeaseManager.RenewLease(leaseID, increment)
+}
+
+func (v Vault) RevokeLease(leaseID string) error {
+	if v.LeaseManager == nil {
+		return creds.ErrLeasesNotSupported
 	}
 
-	return secret.Data, expiration, true, nil
+	return v.LeaseManager.RevokeLease(leaseID)
 }
 
 func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, error) {
@@ -36,6 +36,21 @@ func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
 	return nil, nil
 }
 
+type MockLeaseManager struct {
+	renewed []string
+	revoked []string
+}
+
+func (mlm *MockLeaseManager) RenewLease(leaseID string, increment time.Duration) (time.Duration, error) {
+	mlm.renewed = append(mlm.renewed, leaseID)
+	return increment, nil
+}
+
+func (mlm *MockLeaseManager) RevokeLease(leaseID string) error {
+	mlm.revoked = append(mlm.revoked, leaseID)
+	return nil
+}
+
 func createMockV2Secret(value string) *vaultapi.Secret {
 	return &vaultapi.Secret{
 		Data: map[string]interface{}{
@@ -300,6 +315,80 @@ var _ = Describe("Vault", func() {
 			})
 		})
 	})
+
+	Describe("GetLeased()", func() {
+		var leaseManager *MockLeaseManager
+
+		BeforeEach(func() {
+			close(loggedInCh)
+
+			leaseManager = &MockLeaseManager{}
+			v.LeaseManager = leaseManager
+		})
+
+		Context("when the secret is dynamic", func() {
+			BeforeEach(func() {
+				v.SecretReader = &MockSecretReader{&[]MockSecret{
+					{
+						path: "/concourse/team/pipeline/aws",
+						secret: &vaultapi.Secret{
+							LeaseID:       "aws/creds/some-role/some-lease",
+							LeaseDuration: 3600,
+							Renewable:     true,
+							Data:          map[string]interface{}{"access_key": "some-key"},
+						},
+					}},
+				}
+			})
+
+			It("returns the lease along with the secret", func() {
+				value, lease, found, err := vars.GetLeased(variables, vars.Reference{Path: "aws", Fields: []string{"access_key"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-key"))
+
+				Expect(lease).ToNot(BeNil())
+				Expect(lease.ID).To(Equal("aws/creds/some-role/some-lease"))
+				Expect(lease.Duration).To(Equal(time.Hour))
+				Expect(lease.Renewable).To(BeTrue())
+			})
+
+			It("renews and revokes the lease through the lease manager", func() {
+				_, lease, _, err := vars.GetLeased(variables, vars.Reference{Path: "aws", Fields: []string{"access_key"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = lease.Keeper.RenewLease(lease.ID, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(leaseManager.renewed).To(Equal([]string{"aws/creds/some-role/some-lease"}))
+
+				err = lease.Keeper.RevokeLease(lease.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(leaseManager.revoked).To(Equal([]string{"aws/creds/some-role/some-lease"}))
+			})
+		})
+
+		Context("when the secret is static", func() {
+			BeforeEach(func() {
+				v.SecretReader = &MockSecretReader{&[]MockSecret{
+					{
+						path: "/concourse/team/pipeline/foo",
+						secret: &vaultapi.Secret{
+							LeaseDuration: 3600,
+							Data:          map[string]interface{}{"value": "bar"},
+						},
+					}},
+				}
+			})
+
+			It("returns no lease", func() {
+				value, lease, found, err := vars.GetLeased(variables, varFoo)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("bar"))
+				Expect(lease).To(BeNil())
+			})
+		})
+	})
 })
 
 // The below tests use ghttp handlers to mock a real vault API to the api_client.
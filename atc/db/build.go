@@ -82,6 +82,7 @@ var buildsQuery = psql.Select(`
 		b.end_time,
 		b.reap_time,
 		j.name,
+		COALESCE(j.interruptible, false),
 		r.name,
 		rt.name,
 		b.pipeline_id,
@@ -126,6 +127,7 @@ type Build interface {
 
 	JobID() int
 	JobName() string
+	Interruptible() bool
 
 	ResourceID() int
 	ResourceName() string
@@ -178,6 +180,10 @@ type Build interface {
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
+	StepOutput(planID atc.PlanID, name string) (WorkerArtifact, bool, error)
+
+	SaveSecretLease(leaseID string, expiresAt time.Time) error
+	RemoveSecretLease(leaseID string) error
 
 	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
@@ -185,6 +191,7 @@ type Build interface {
 
 	Resources() ([]BuildInput, []BuildOutput, error)
 	SaveImageResourceVersion(UsedResourceCache) error
+	SaveCheckResult(versions []atc.Version, newVersions int) error
 
 	Delete() (bool, error)
 	MarkAsAborted() error
@@ -217,8 +224,9 @@ type build struct {
 	teamID   int
 	teamName string
 
-	jobID   int
-	jobName string
+	jobID            int
+	jobName          string
+	jobInterruptible bool
 
 	resourceID   int
 	resourceName string
@@ -352,6 +360,7 @@ func (b *build) ID() int                      { return b.id }
 func (b *build) Name() string                 { return b.name }
 func (b *build) JobID() int                   { return b.jobID }
 func (b *build) JobName() string              { return b.jobName }
+func (b *build) Interruptible() bool          { return b.jobInterruptible }
 func (b *build) ResourceID() int              { return b.resourceID }
 func (b *build) ResourceName() string         { return b.resourceName }
 func (b *build) ResourceTypeID() int          { return b.resourceTypeID }
@@ -867,6 +876,30 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// SaveCheckResult records the result of a check build so that it can be shown
+// in the resource's check history. Only newVersions, the number of versions
+// which the check found for the first time, are counted as found; the latest
+// version is the last one returned by the check.
+func (b *build) SaveCheckResult(versions []atc.Version, newVersions int) error {
+	var latestVersion interface{}
+	if len(versions) > 0 {
+		versionJSON, err := json.Marshal(versions[len(versions)-1])
+		if err != nil {
+			return err
+		}
+
+		latestVersion = string(versionJSON)
+	}
+
+	_, err := psql.Insert("check_build_results").
+		Columns("build_id", "versions_found", "latest_version").
+		Values(b.id, newVersions, latestVersion).
+		Suffix("ON CONFLICT (build_id) DO UPDATE SET versions_found = EXCLUDED.versions_found, latest_version = EXCLUDED.latest_version").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
 func (b *build) SaveImageResourceVersion(rc UsedResourceCache) error {
 	var jobID sql.NullInt64
 	if b.jobID != 0 {
@@ -1128,6 +1161,7 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 		From("worker_artifacts").
 		Where(sq.Eq{
 			"build_id": b.id,
+			"plan_id":  nil,
 		}).
 		RunWith(b.conn).
 		Query()
@@ -1154,6 +1188,63 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	return artifacts, nil
 }
 
+// StepOutput returns the most recently saved output with the given name of
+// the step with the given plan ID.
+func (b *build) StepOutput(planID atc.PlanID, name string) (WorkerArtifact, bool, error) {
+	wa := artifact{
+		conn:    b.conn,
+		buildID: b.id,
+	}
+
+	err := psql.Select("id", "name", "created_at").
+		From("worker_artifacts").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+			"name":     name,
+		}).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&wa.id, &wa.name, &wa.createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return &wa, true, nil
+}
+
+// SaveSecretLease records the lease of a dynamic secret used by the build, or
+// updates its expiry once it has been renewed. Leases are recorded so that
+// they can be revoked even if the build's ATC goes away.
+func (b *build) SaveSecretLease(leaseID string, expiresAt time.Time) error {
+	_, err := psql.Insert("build_secret_leases").
+		Columns("build_id", "lease_id", "expires_at").
+		Values(b.id, leaseID, expiresAt).
+		Suffix("ON CONFLICT (build_id, lease_id) DO UPDATE SET expires_at = EXCLUDED.expires_at").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// RemoveSecretLease forgets the lease of a dynamic secret once it has been
+// revoked.
+func (b *build) RemoveSecretLease(leaseID string) error {
+	_, err := psql.Delete("build_secret_leases").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"lease_id": leaseID,
+		}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
 func (b *build) SaveOutput(
 	resourceType string,
 	source atc.Source,
@@ -1792,6 +1883,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&endTime,
 		&reapTime,
 		&jobName,
+		&b.jobInterruptible,
 		&resourceName,
 		&resourceTypeName,
 		&pipelineID,
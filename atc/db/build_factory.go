@@ -19,6 +19,7 @@ type BuildFactory interface {
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	PendingBuildsPerTeam() (map[string]int, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
 }
@@ -133,6 +134,40 @@ func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// PendingBuildsPerTeam counts the builds which have been created but not yet
+// started, by the name of the team they belong to.
+func (f *buildFactory) PendingBuildsPerTeam() (map[string]int, error) {
+	rows, err := psql.Select("t.name", "COUNT(*)").
+		From("builds b").
+		Join("teams t ON t.id = b.team_id").
+		Where(sq.Eq{"b.status": BuildStatusPending}).
+		GroupBy("t.name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	pending := map[string]int{}
+	for rows.Next() {
+		var (
+			teamName string
+			count    int
+		)
+
+		err = rows.Scan(&teamName, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		pending[teamName] = count
+	}
+
+	return pending, nil
+}
+
 func getBuilds(buildsQuery sq.SelectBuilder, conn Conn, lockFactory lock.LockFactory) ([]Build, error) {
 	rows, err := buildsQuery.RunWith(conn).Query()
 	if err != nil {
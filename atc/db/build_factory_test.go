@@ -493,6 +493,32 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("PendingBuildsPerTeam", func() {
+		BeforeEach(func() {
+			_, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			startedBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("counts the builds which have not started yet per team", func() {
+			pending, err := buildFactory.PendingBuildsPerTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(Equal(map[string]int{
+				"some-team":    1,
+				"default-team": 1,
+			}))
+		})
+	})
+
 	Describe("AllBuilds by date", func() {
 		var build1DB db.Build
 		var build2DB db.Build
@@ -2369,7 +2369,7 @@ var _ = Describe("Build", func() {
 
 				rcv := scenario.ResourceVersion("some-other-resource", atc.Version{"some": "other-version"})
 
-				found, err := scenario.Resource("some-other-resource").PinVersion(rcv.ID())
+				found, err := scenario.Resource("some-other-resource").PinVersion(rcv.ID(), db.VersionPin{})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// cacheInvalidationRetention is how long cache invalidations are kept
+// around for ATCs which lost their connection to pick them up.
+const cacheInvalidationRetention = time.Hour
+
+//go:generate counterfeiter . CacheInvalidationFactory
+
+// CacheInvalidationFactory records requests to invalidate the secrets cached
+// by every ATC.
+type CacheInvalidationFactory interface {
+	creds.CacheInvalidations
+
+	// Invalidate records an invalidation of the team's cached secrets, or
+	// only of the var with the given path, and notifies every ATC of it.
+	Invalidate(teamName string, path string) error
+}
+
+type cacheInvalidationFactory struct {
+	conn Conn
+}
+
+func NewCacheInvalidationFactory(conn Conn) CacheInvalidationFactory {
+	return &cacheInvalidationFactory{
+		conn: conn,
+	}
+}
+
+func (factory *cacheInvalidationFactory) Invalidate(teamName string, path string) error {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Insert("cache_invalidations").
+		Columns("team_name", "path").
+		Values(teamName, path).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("cache_invalidations").
+		Where(sq.Expr(fmt.Sprintf("created_at < now() - '%d seconds'::interval", int(cacheInvalidationRetention.Seconds())))).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return factory.conn.Bus().Notify(atc.SecretCacheChannel)
+}
+
+func (factory *cacheInvalidationFactory) LatestCacheInvalidationID() (int, error) {
+	var id int
+	err := psql.Select("COALESCE(MAX(id), 0)").
+		From("cache_invalidations").
+		RunWith(factory.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (factory *cacheInvalidationFactory) CacheInvalidationsSince(id int) ([]creds.CacheInvalidation, error) {
+	rows, err := psql.Select("id", "team_name", "path").
+		From("cache_invalidations").
+		Where(sq.Gt{"id": id}).
+		OrderBy("id").
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var invalidations []creds.CacheInvalidation
+	for rows.Next() {
+		var invalidation creds.CacheInvalidation
+		err = rows.Scan(&invalidation.ID, &invalidation.TeamName, &invalidation.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		invalidations = append(invalidations, invalidation)
+	}
+
+	return invalidations, nil
+}
@@ -0,0 +1,75 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CacheInvalidationFactory", func() {
+	var factory db.CacheInvalidationFactory
+
+	BeforeEach(func() {
+		factory = db.NewCacheInvalidationFactory(dbConn)
+	})
+
+	It("starts out without invalidations", func() {
+		id, err := factory.LatestCacheInvalidationID()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id).To(BeZero())
+	})
+
+	Describe("Invalidate", func() {
+		var notifier chan bool
+
+		BeforeEach(func() {
+			var err error
+			notifier, err = dbConn.Bus().Listen(atc.SecretCacheChannel)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(dbConn.Bus().Unlisten(atc.SecretCacheChannel, notifier)).To(Succeed())
+		})
+
+		It("records the invalidations and notifies every ATC", func() {
+			Expect(factory.Invalidate("some-team", "")).To(Succeed())
+			Eventually(notifier).Should(Receive())
+
+			firstID, err := factory.LatestCacheInvalidationID()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(factory.Invalidate("other-team", "some-var")).To(Succeed())
+			Eventually(notifier).Should(Receive())
+
+			invalidations, err := factory.CacheInvalidationsSince(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(invalidations).To(HaveLen(2))
+			Expect(invalidations[0]).To(Equal(creds.CacheInvalidation{ID: firstID, TeamName: "some-team"}))
+			Expect(invalidations[1].TeamName).To(Equal("other-team"))
+			Expect(invalidations[1].Path).To(Equal("some-var"))
+
+			invalidations, err = factory.CacheInvalidationsSince(firstID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(invalidations).To(HaveLen(1))
+			Expect(invalidations[0].Path).To(Equal("some-var"))
+		})
+
+		It("removes invalidations older than an hour", func() {
+			_, err := dbConn.Exec(`INSERT INTO cache_invalidations (team_name, created_at) VALUES ('some-team', $1)`, time.Now().Add(-2*time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(factory.Invalidate("other-team", "")).To(Succeed())
+
+			invalidations, err := factory.CacheInvalidationsSince(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(invalidations).To(HaveLen(1))
+			Expect(invalidations[0].TeamName).To(Equal("other-team"))
+		})
+	})
+})
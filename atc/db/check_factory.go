@@ -27,6 +27,7 @@ type Checkable interface {
 	CheckEvery() *atc.CheckEvery
 	CheckTimeout() string
 	LastCheckEndTime() time.Time
+	LastNewVersionTime() time.Time
 	CurrentPinnedVersion() atc.Version
 
 	HasWebhook() bool
@@ -41,8 +42,16 @@ type CheckFactory interface {
 	TryCreateCheck(context.Context, Checkable, ResourceTypes, atc.Version, bool) (Build, bool, error)
 	Resources() ([]Resource, error)
 	ResourceTypes() ([]ResourceType, error)
+
+	PrioritizedResourceIDs() (map[int]bool, error)
+	NextCheckTime(checkable Checkable, prioritized bool) time.Time
 }
 
+// idleIntervalDivisor determines how quickly the check interval of an idle
+// resource grows when adaptive checking is enabled: the resource is checked
+// once per this fraction of the time since it last produced a new version.
+const idleIntervalDivisor = 10
+
 type checkFactory struct {
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -55,12 +64,18 @@ type checkFactory struct {
 	defaultCheckTimeout             time.Duration
 	defaultCheckInterval            time.Duration
 	defaultWithWebhookCheckInterval time.Duration
+	adaptiveMaxInterval             time.Duration
 }
 
 type CheckDurations struct {
 	Timeout             time.Duration
 	Interval            time.Duration
 	IntervalWithWebhook time.Duration
+
+	// AdaptiveMaxInterval is the ceiling up to which the check interval of
+	// resources which have not produced new versions in a while is increased.
+	// Adaptive checking is disabled when it is zero.
+	AdaptiveMaxInterval time.Duration
 }
 
 func NewCheckFactory(
@@ -82,6 +97,7 @@ func NewCheckFactory(
 		defaultCheckTimeout:             durations.Timeout,
 		defaultCheckInterval:            durations.Interval,
 		defaultWithWebhookCheckInterval: durations.IntervalWithWebhook,
+		adaptiveMaxInterval:             durations.AdaptiveMaxInterval,
 	}
 }
 
@@ -104,13 +120,7 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 		}
 	}
 
-	interval := c.defaultCheckInterval
-	if checkable.HasWebhook() {
-		interval = c.defaultWithWebhookCheckInterval
-	}
-	if checkable.CheckEvery() != nil && !checkable.CheckEvery().Never {
-		interval = checkable.CheckEvery().Interval
-	}
+	interval := c.checkInterval(checkable)
 
 	if !manuallyTriggered && time.Now().Before(checkable.LastCheckEndTime().Add(interval)) {
 		// skip creating the check if its interval hasn't elapsed yet
@@ -135,6 +145,98 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 	return build, true, nil
 }
 
+// NextCheckTime returns the time at which the checkable is next due to be
+// checked, or the zero time if it is never checked periodically.
+//
+// When adaptive checking is enabled, resources that have not produced a new
+// version in a while are checked progressively less often, up to the
+// configured maximum interval. Prioritized resources, resources with webhooks
+// and resources configuring their own check_every interval are never backed
+// off.
+func (c *checkFactory) NextCheckTime(checkable Checkable, prioritized bool) time.Time {
+	if checkable.CheckEvery() != nil && checkable.CheckEvery().Never {
+		return time.Time{}
+	}
+
+	interval := c.checkInterval(checkable)
+
+	backOff := c.adaptiveMaxInterval > interval &&
+		!prioritized &&
+		!checkable.HasWebhook() &&
+		checkable.CheckEvery() == nil &&
+		!checkable.LastNewVersionTime().IsZero()
+
+	if backOff {
+		idleInterval := time.Since(checkable.LastNewVersionTime()) / idleIntervalDivisor
+		if idleInterval > c.adaptiveMaxInterval {
+			idleInterval = c.adaptiveMaxInterval
+		}
+
+		if idleInterval > interval {
+			interval = idleInterval
+		}
+	}
+
+	return checkable.LastCheckEndTime().Add(interval)
+}
+
+func (c *checkFactory) checkInterval(checkable Checkable) time.Duration {
+	interval := c.defaultCheckInterval
+	if checkable.HasWebhook() {
+		interval = c.defaultWithWebhookCheckInterval
+	}
+	if checkable.CheckEvery() != nil && !checkable.CheckEvery().Never {
+		interval = checkable.CheckEvery().Interval
+	}
+
+	return interval
+}
+
+// PrioritizedResourceIDs returns the IDs of the resources which should be
+// checked before all others: trigger inputs of jobs with pending manually
+// triggered builds, and resources used as inputs to builds within the
+// adaptive checking interval ceiling.
+//
+// Resources are only prioritized when adaptive checking is enabled; otherwise
+// no resources are returned without querying the database.
+func (c *checkFactory) PrioritizedResourceIDs() (map[int]bool, error) {
+	if c.adaptiveMaxInterval == 0 {
+		return map[int]bool{}, nil
+	}
+
+	rows, err := c.conn.Query(`
+		SELECT ji.resource_id
+		FROM job_inputs ji
+		JOIN builds b ON b.job_id = ji.job_id
+		WHERE ji.trigger
+		AND b.status = 'pending'
+		AND b.manually_triggered
+		UNION
+		SELECT i.resource_id
+		FROM build_resource_config_version_inputs i
+		JOIN builds b ON b.id = i.build_id
+		WHERE b.start_time > $1
+	`, time.Now().Add(-c.adaptiveMaxInterval))
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	ids := map[int]bool{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[id] = true
+	}
+
+	return ids, nil
+}
+
 func (c *checkFactory) Resources() ([]Resource, error) {
 	var resources []Resource
 
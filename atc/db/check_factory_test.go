@@ -244,6 +244,180 @@ var _ = Describe("CheckFactory", func() {
 		})
 	})
 
+	Describe("NextCheckTime", func() {
+		var (
+			adaptiveCheckFactory db.CheckFactory
+			fakeResource         *dbfakes.FakeResource
+			lastCheckEndTime     time.Time
+			prioritized          bool
+			nextCheckTime        time.Time
+		)
+
+		BeforeEach(func() {
+			adaptiveCheckFactory = db.NewCheckFactory(dbConn, lockFactory, fakeSecrets, fakeVarSourcePool, db.CheckDurations{
+				Timeout:             defaultCheckTimeout,
+				Interval:            time.Minute,
+				IntervalWithWebhook: time.Minute,
+				AdaptiveMaxInterval: time.Hour,
+			})
+
+			lastCheckEndTime = time.Now().Add(-time.Minute)
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.LastCheckEndTimeReturns(lastCheckEndTime)
+			fakeResource.LastNewVersionTimeReturns(time.Now().Add(-100 * time.Minute))
+
+			prioritized = false
+		})
+
+		JustBeforeEach(func() {
+			nextCheckTime = adaptiveCheckFactory.NextCheckTime(fakeResource, prioritized)
+		})
+
+		It("backs off in proportion to the time since the last new version", func() {
+			Expect(nextCheckTime).To(BeTemporally("~", lastCheckEndTime.Add(10*time.Minute), time.Second))
+		})
+
+		Context("when the resource has been idle for very long", func() {
+			BeforeEach(func() {
+				fakeResource.LastNewVersionTimeReturns(time.Now().Add(-30 * 24 * time.Hour))
+			})
+
+			It("does not back off past the maximum interval", func() {
+				Expect(nextCheckTime).To(Equal(lastCheckEndTime.Add(time.Hour)))
+			})
+		})
+
+		Context("when the resource recently produced a new version", func() {
+			BeforeEach(func() {
+				fakeResource.LastNewVersionTimeReturns(time.Now().Add(-time.Minute))
+			})
+
+			It("uses the default interval", func() {
+				Expect(nextCheckTime).To(Equal(lastCheckEndTime.Add(time.Minute)))
+			})
+		})
+
+		Context("when the resource is prioritized", func() {
+			BeforeEach(func() {
+				prioritized = true
+			})
+
+			It("uses the default interval", func() {
+				Expect(nextCheckTime).To(Equal(lastCheckEndTime.Add(time.Minute)))
+			})
+		})
+
+		Context("when the resource configures its own interval", func() {
+			BeforeEach(func() {
+				fakeResource.CheckEveryReturns(&atc.CheckEvery{Interval: 2 * time.Minute})
+			})
+
+			It("uses the configured interval", func() {
+				Expect(nextCheckTime).To(Equal(lastCheckEndTime.Add(2 * time.Minute)))
+			})
+		})
+
+		Context("when CheckEvery is never", func() {
+			BeforeEach(func() {
+				fakeResource.CheckEveryReturns(&atc.CheckEvery{Never: true})
+			})
+
+			It("returns the zero time", func() {
+				Expect(nextCheckTime).To(BeZero())
+			})
+		})
+
+		Context("when adaptive checking is disabled", func() {
+			BeforeEach(func() {
+				adaptiveCheckFactory = checkFactory
+			})
+
+			It("uses the default interval", func() {
+				Expect(nextCheckTime).To(Equal(lastCheckEndTime.Add(defaultCheckInterval)))
+			})
+		})
+	})
+
+	Describe("PrioritizedResourceIDs", func() {
+		var (
+			adaptiveCheckFactory db.CheckFactory
+			pipeline             db.Pipeline
+			job                  db.Job
+			resource             db.Resource
+		)
+
+		BeforeEach(func() {
+			var found bool
+
+			adaptiveCheckFactory = db.NewCheckFactory(dbConn, lockFactory, fakeSecrets, fakeVarSourcePool, db.CheckDurations{
+				Timeout:             defaultCheckTimeout,
+				Interval:            defaultCheckInterval,
+				IntervalWithWebhook: defaultWebhookCheckInterval,
+				AdaptiveMaxInterval: time.Hour,
+			})
+
+			pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "prioritized-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.GetStep{
+									Name:    "some-resource",
+									Trigger: true,
+								},
+							},
+						},
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+			}, db.ConfigVersion(0), false)
+			Expect(err).NotTo(HaveOccurred())
+
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("does not include resources without pending manual triggers", func() {
+			ids, err := adaptiveCheckFactory.PrioritizedResourceIDs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).ToNot(HaveKey(resource.ID()))
+		})
+
+		Context("when a job with the resource as a trigger input has a pending manually triggered build", func() {
+			BeforeEach(func() {
+				_, err = job.CreateBuild("some-user")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("includes the resource", func() {
+				ids, err := adaptiveCheckFactory.PrioritizedResourceIDs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(HaveKey(resource.ID()))
+			})
+
+			Context("when adaptive checking is disabled", func() {
+				It("does not include any resources", func() {
+					ids, err := checkFactory.PrioritizedResourceIDs()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ids).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Describe("Resources", func() {
 		var (
 			resources       []db.Resource
@@ -3,7 +3,7 @@ package db
 //go:generate counterfeiter . CheckLifecycle
 
 type CheckLifecycle interface {
-	DeleteCompletedChecks() error
+	DeleteCompletedChecks(checksToRetain int) error
 }
 
 type checkLifecycle struct {
@@ -16,19 +16,30 @@ func NewCheckLifecycle(conn Conn) CheckLifecycle {
 	}
 }
 
-func (cl *checkLifecycle) DeleteCompletedChecks() error {
+// DeleteCompletedChecks removes completed check builds along with their
+// events. The most recent checksToRetain completed checks of each resource are
+// kept around for the check history, as is the latest check of each resource
+// and resource type.
+//
+// The checks of each resource are looked up separately through the
+// builds_resource_id_idx index so that each run only has to look at the
+// checks which have completed since the previous run, rather than ranking
+// every check build.
+func (cl *checkLifecycle) DeleteCompletedChecks(checksToRetain int) error {
 	_, err := cl.conn.Exec(`
-      WITH resource_builds AS (
-        SELECT build_id
-        FROM resources
-        WHERE build_id IS NOT NULL
-      ),
-      deleted_builds AS (
+      WITH deleted_builds AS (
         DELETE FROM builds USING (
-          SELECT id
-          FROM builds b
-          WHERE completed AND resource_id IS NOT NULL
-          AND NOT EXISTS ( SELECT 1 FROM resource_builds WHERE build_id = b.id )
+          SELECT old.id
+          FROM resources r
+          CROSS JOIN LATERAL (
+            SELECT id
+            FROM builds
+            WHERE resource_id = r.id
+            AND completed
+            ORDER BY id DESC
+            OFFSET $1
+          ) old
+          WHERE old.id IS DISTINCT FROM r.build_id
             UNION ALL
           SELECT id
           FROM builds b
@@ -38,6 +49,6 @@ func (cl *checkLifecycle) DeleteCompletedChecks() error {
 		RETURNING builds.id
       )
       DELETE FROM check_build_events USING deleted_builds WHERE build_id = deleted_builds.id
-    `)
+    `, checksToRetain)
 	return err
 }
@@ -60,7 +60,7 @@ var _ = Describe("Check Lifecycle", func() {
 		resourceTypeBuild := createFinishedCheck(defaultResourceType, plan)
 
 		By("attempting to delete completed checks when there are no newer checks")
-		err := lifecycle.DeleteCompletedChecks()
+		err := lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(resourceBuild)).To(BeTrue())
 		Expect(exists(resourceTypeBuild)).To(BeTrue())
@@ -69,7 +69,7 @@ var _ = Describe("Check Lifecycle", func() {
 		createFinishedCheck(defaultResource, plan)
 
 		By("deleting completed checks")
-		err = lifecycle.DeleteCompletedChecks()
+		err = lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 
 		Expect(exists(resourceBuild)).To(BeFalse())
@@ -81,7 +81,7 @@ var _ = Describe("Check Lifecycle", func() {
 		createFinishedCheck(defaultResourceType, plan)
 
 		By("deleting completed checks")
-		err = lifecycle.DeleteCompletedChecks()
+		err = lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 
 		Expect(exists(resourceTypeBuild)).To(BeFalse())
@@ -92,7 +92,7 @@ var _ = Describe("Check Lifecycle", func() {
 		c1 := createUnfinishedCheck(defaultResource, plan)
 		c2 := createUnfinishedCheck(defaultResource, plan)
 
-		err := lifecycle.DeleteCompletedChecks()
+		err := lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c1)).To(BeTrue())
 		Expect(exists(c2)).To(BeTrue())
@@ -100,7 +100,7 @@ var _ = Describe("Check Lifecycle", func() {
 		By("finishing the first check should allow it to be deleted")
 		finish(c1)
 
-		err = lifecycle.DeleteCompletedChecks()
+		err = lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c1)).To(BeFalse())
 		Expect(exists(c2)).To(BeTrue())
@@ -108,7 +108,7 @@ var _ = Describe("Check Lifecycle", func() {
 		By("finishing the second check should NOT allow it to be deleted")
 		finish(c2)
 
-		err = lifecycle.DeleteCompletedChecks()
+		err = lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c2)).To(BeTrue())
 	})
@@ -119,12 +119,25 @@ var _ = Describe("Check Lifecycle", func() {
 
 		createFinishedCheck(defaultResource, plan)
 
-		err := lifecycle.DeleteCompletedChecks()
+		err := lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(c1)).To(BeFalse())
 		Expect(exists(c2)).To(BeFalse())
 	})
 
+	It("retains the given number of completed checks per resource", func() {
+		c1 := createFinishedCheck(defaultResource, plan)
+		c2 := createFinishedCheck(defaultResource, plan)
+		c3 := createFinishedCheck(defaultResource, plan)
+
+		err := lifecycle.DeleteCompletedChecks(2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists(c1)).To(BeFalse())
+		Expect(numBuildEventsForCheck(c1)).To(Equal(0))
+		Expect(exists(c2)).To(BeTrue())
+		Expect(exists(c3)).To(BeTrue())
+	})
+
 	It("ignores job builds", func() {
 		build, err := defaultJob.CreateBuild("foo")
 		Expect(err).ToNot(HaveOccurred())
@@ -136,7 +149,7 @@ var _ = Describe("Check Lifecycle", func() {
 		_, err = defaultJob.CreateBuild("foo")
 		Expect(err).ToNot(HaveOccurred())
 
-		err = lifecycle.DeleteCompletedChecks()
+		err = lifecycle.DeleteCompletedChecks(0)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(exists(build)).To(BeTrue())
 	})
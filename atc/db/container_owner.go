@@ -120,6 +120,39 @@ func (c buildStepContainerOwner) sqlMap() map[string]interface{} {
 	}
 }
 
+// NewPrewarmedImageContainerOwner references an image being pre-warmed by the
+// image prewarmer. Once the pre-warming finishes or times out, or the image
+// disappears, the container can be removed.
+func NewPrewarmedImageContainerOwner(
+	prewarmedImageID int,
+	teamID int,
+) ContainerOwner {
+	return prewarmedImageContainerOwner{
+		PrewarmedImageID: prewarmedImageID,
+		TeamID:           teamID,
+	}
+}
+
+type prewarmedImageContainerOwner struct {
+	PrewarmedImageID int
+	TeamID           int
+}
+
+func (c prewarmedImageContainerOwner) Find(Conn) (sq.Eq, bool, error) {
+	return sq.Eq(c.sqlMap()), true, nil
+}
+
+func (c prewarmedImageContainerOwner) Create(Tx, string) (map[string]interface{}, error) {
+	return c.sqlMap(), nil
+}
+
+func (c prewarmedImageContainerOwner) sqlMap() map[string]interface{} {
+	return map[string]interface{}{
+		"prewarmed_image_id": c.PrewarmedImageID,
+		"team_id":            c.TeamID,
+	}
+}
+
 // NewResourceConfigCheckSessionContainerOwner references a resource config and
 // worker base resource type, with an expiry. When the resource config or
 // worker base resource type disappear, or the expiry is reached, the container
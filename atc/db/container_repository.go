@@ -226,12 +226,14 @@ func (repository *containerRepository) FindOrphanedContainers() ([]CreatingConta
 		LeftJoin("builds b ON b.id = c.build_id").
 		LeftJoin("containers icc ON icc.id = c.image_check_container_id").
 		LeftJoin("containers igc ON igc.id = c.image_get_container_id").
+		LeftJoin("prewarmed_images pi ON pi.id = c.prewarmed_image_id").
 		Where(sq.Or{
 			sq.Eq{
 				"c.build_id":                         nil,
 				"c.image_check_container_id":         nil,
 				"c.image_get_container_id":           nil,
 				"c.resource_config_check_session_id": nil,
+				"c.prewarmed_image_id":               nil,
 			},
 			sq.And{
 				sq.NotEq{"c.build_id": nil},
@@ -245,6 +247,10 @@ func (repository *containerRepository) FindOrphanedContainers() ([]CreatingConta
 				sq.NotEq{"c.image_get_container_id": nil},
 				sq.NotEq{"igc.state": atc.ContainerStateCreating},
 			},
+			sq.And{
+				sq.NotEq{"c.prewarmed_image_id": nil},
+				sq.Expr("(pi.warming_until IS NULL OR pi.warming_until < now())"),
+			},
 		}).
 		ToSql()
 	if err != nil {
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . CredentialUsageFactory
+
+// CredentialUsageFactory finds the pipelines, jobs, resources and so on
+// referencing credential vars, as recorded whenever a pipeline is configured.
+type CredentialUsageFactory interface {
+	// TeamCredentialUsages returns the usages from the team's pipelines,
+	// optionally only the ones of vars with the given path.
+	TeamCredentialUsages(teamID int, path string) ([]atc.CredentialUsage, error)
+
+	// AllCredentialUsages returns the usages from every team's pipelines,
+	// optionally only the ones of vars with the given path.
+	AllCredentialUsages(path string) ([]atc.CredentialUsage, error)
+}
+
+type credentialUsageFactory struct {
+	conn Conn
+}
+
+func NewCredentialUsageFactory(conn Conn) CredentialUsageFactory {
+	return &credentialUsageFactory{
+		conn: conn,
+	}
+}
+
+func (factory *credentialUsageFactory) TeamCredentialUsages(teamID int, path string) ([]atc.CredentialUsage, error) {
+	return factory.credentialUsages(sq.Eq{"p.team_id": teamID}, path)
+}
+
+func (factory *credentialUsageFactory) AllCredentialUsages(path string) ([]atc.CredentialUsage, error) {
+	return factory.credentialUsages(sq.Expr("true"), path)
+}
+
+func (factory *credentialUsageFactory) credentialUsages(where sq.Sqlizer, path string) ([]atc.CredentialUsage, error) {
+	query := psql.Select("t.name", "p.id", "p.name", "p.instance_vars", "u.type", "u.name", "u.var_source", "u.path").
+		From("credential_usages u").
+		Join("pipelines p ON p.id = u.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		Where(where).
+		Where(sq.Eq{"p.archived": false}).
+		OrderBy("t.name", "p.name", "p.id", "u.path", "u.var_source", "u.type", "u.name")
+
+	if path != "" {
+		query = query.Where(sq.Eq{"u.path": path})
+	}
+
+	rows, err := query.RunWith(factory.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var usages []atc.CredentialUsage
+	for rows.Next() {
+		var (
+			usage        atc.CredentialUsage
+			instanceVars sql.NullString
+		)
+
+		err = rows.Scan(&usage.TeamName, &usage.PipelineID, &usage.PipelineName, &instanceVars, &usage.Type, &usage.Name, &usage.VarSource, &usage.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &usage.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// saveCredentialUsages replaces the credential usages recorded for a
+// pipeline with the ones in its new config.
+func saveCredentialUsages(tx Tx, config atc.Config, pipelineID int) error {
+	usages, err := config.CredentialUsages()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("credential_usages").
+		Where(sq.Eq{"pipeline_id": pipelineID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for _, usage := range usages {
+		_, err = psql.Insert("credential_usages").
+			Columns("pipeline_id", "type", "name", "var_source", "path").
+			Values(pipelineID, usage.Type, usage.Name, usage.VarSource, usage.Path).
+			Suffix("ON CONFLICT DO NOTHING").
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredentialUsageFactory", func() {
+	var (
+		factory db.CredentialUsageFactory
+
+		otherTeam db.Team
+		pipeline  db.Pipeline
+	)
+
+	pipelineConfig := func(job string, varName string) atc.Config {
+		return atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "some-base-resource-type",
+					Source: atc.Source{"private_key": "((deploy-key))"},
+				},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: job,
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.TaskStep{
+								Name:       "some-task",
+								ConfigPath: "some-file",
+								Params:     atc.TaskEnv{"TOKEN": "((" + varName + "))"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		factory = db.NewCredentialUsageFactory(dbConn)
+
+		var err error
+		pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "usages-pipeline"}, pipelineConfig("some-job", "api-token"), db.ConfigVersion(0), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "usages-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, pipelineConfig("other-job", "other-token"), db.ConfigVersion(0), false)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("TeamCredentialUsages", func() {
+		It("returns the usages recorded for the team's pipelines", func() {
+			usages, err := factory.TeamCredentialUsages(defaultTeam.ID(), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(ConsistOf(
+				atc.CredentialUsage{
+					TeamName:     "default-team",
+					PipelineID:   pipeline.ID(),
+					PipelineName: "usages-pipeline",
+					Type:         atc.CredentialUsageResource,
+					Name:         "some-resource",
+					Path:         "deploy-key",
+				},
+				atc.CredentialUsage{
+					TeamName:     "default-team",
+					PipelineID:   pipeline.ID(),
+					PipelineName: "usages-pipeline",
+					Type:         atc.CredentialUsageJob,
+					Name:         "some-job",
+					Path:         "api-token",
+				},
+			))
+		})
+
+		It("filters by path", func() {
+			usages, err := factory.TeamCredentialUsages(defaultTeam.ID(), "api-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(HaveLen(1))
+			Expect(usages[0].Name).To(Equal("some-job"))
+		})
+
+		Context("when the pipeline is reconfigured", func() {
+			BeforeEach(func() {
+				_, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "usages-pipeline"}, pipelineConfig("some-job", "new-token"), pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("replaces the usages", func() {
+				usages, err := factory.TeamCredentialUsages(defaultTeam.ID(), "api-token")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usages).To(BeEmpty())
+
+				usages, err = factory.TeamCredentialUsages(defaultTeam.ID(), "new-token")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usages).To(HaveLen(1))
+			})
+		})
+
+		Context("when the pipeline is archived", func() {
+			BeforeEach(func() {
+				Expect(pipeline.Archive()).To(Succeed())
+			})
+
+			It("leaves out its usages", func() {
+				usages, err := factory.TeamCredentialUsages(defaultTeam.ID(), "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usages).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("AllCredentialUsages", func() {
+		It("returns the usages recorded for every team's pipelines", func() {
+			usages, err := factory.AllCredentialUsages("")
+			Expect(err).ToNot(HaveOccurred())
+
+			var teams []string
+			for _, usage := range usages {
+				teams = append(teams, usage.TeamName)
+			}
+
+			Expect(teams).To(ContainElement("default-team"))
+			Expect(teams).To(ContainElement("usages-team"))
+		})
+
+		It("filters by path", func() {
+			usages, err := factory.AllCredentialUsages("other-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(HaveLen(1))
+			Expect(usages[0].TeamName).To(Equal("usages-team"))
+			Expect(usages[0].Name).To(Equal("other-job"))
+		})
+	})
+})
@@ -42,6 +42,9 @@ var (
 	resourceConfigFactory               db.ResourceConfigFactory
 	resourceCacheFactory                db.ResourceCacheFactory
 	taskCacheFactory                    db.TaskCacheFactory
+	keyedCacheFactory                   db.KeyedCacheFactory
+	keyedCacheLifecycle                 db.KeyedCacheLifecycle
+	prewarmedImageFactory               db.PrewarmedImageFactory
 	checkFactory                        db.CheckFactory
 	workerBaseResourceTypeFactory       db.WorkerBaseResourceTypeFactory
 	workerTaskCacheFactory              db.WorkerTaskCacheFactory
@@ -116,6 +119,9 @@ var _ = BeforeEach(func() {
 	resourceConfigFactory = db.NewResourceConfigFactory(dbConn, lockFactory)
 	resourceCacheFactory = db.NewResourceCacheFactory(dbConn, lockFactory)
 	taskCacheFactory = db.NewTaskCacheFactory(dbConn)
+	keyedCacheFactory = db.NewKeyedCacheFactory(dbConn)
+	keyedCacheLifecycle = db.NewKeyedCacheLifecycle(dbConn)
+	prewarmedImageFactory = db.NewPrewarmedImageFactory(dbConn)
 	checkFactory = db.NewCheckFactory(dbConn, lockFactory, fakeSecrets, fakeVarSourcePool, db.CheckDurations{
 		Timeout:             defaultCheckTimeout,
 		Interval:            defaultCheckInterval,
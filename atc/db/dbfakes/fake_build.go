@@ -197,6 +197,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	InterruptibleStub        func() bool
+	interruptibleMutex       sync.RWMutex
+	interruptibleArgsForCall []struct {
+	}
+	interruptibleReturns struct {
+		result1 bool
+	}
+	interruptibleReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsAbortedStub        func() bool
 	isAbortedMutex       sync.RWMutex
 	isAbortedArgsForCall []struct {
@@ -428,6 +438,17 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RemoveSecretLeaseStub        func(string) error
+	removeSecretLeaseMutex       sync.RWMutex
+	removeSecretLeaseArgsForCall []struct {
+		arg1 string
+	}
+	removeSecretLeaseReturns struct {
+		result1 error
+	}
+	removeSecretLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RerunNumberStub        func() int
 	rerunNumberMutex       sync.RWMutex
 	rerunNumberArgsForCall []struct {
@@ -524,6 +545,18 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	SaveCheckResultStub        func([]atc.Version, int) error
+	saveCheckResultMutex       sync.RWMutex
+	saveCheckResultArgsForCall []struct {
+		arg1 []atc.Version
+		arg2 int
+	}
+	saveCheckResultReturns struct {
+		result1 error
+	}
+	saveCheckResultReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -582,6 +615,18 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveSecretLeaseStub        func(string, time.Time) error
+	saveSecretLeaseMutex       sync.RWMutex
+	saveSecretLeaseArgsForCall []struct {
+		arg1 string
+		arg2 time.Time
+	}
+	saveSecretLeaseReturns struct {
+		result1 error
+	}
+	saveSecretLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -657,6 +702,22 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	StepOutputStub        func(atc.PlanID, string) (db.WorkerArtifact, bool, error)
+	stepOutputMutex       sync.RWMutex
+	stepOutputArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 string
+	}
+	stepOutputReturns struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}
+	stepOutputReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}
 	SyslogTagStub        func(event.OriginID) string
 	syslogTagMutex       sync.RWMutex
 	syslogTagArgsForCall []struct {
@@ -1581,6 +1642,59 @@ func (fake *FakeBuild) InterceptibleReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Interruptible() bool {
+	fake.interruptibleMutex.Lock()
+	ret, specificReturn := fake.interruptibleReturnsOnCall[len(fake.interruptibleArgsForCall)]
+	fake.interruptibleArgsForCall = append(fake.interruptibleArgsForCall, struct {
+	}{})
+	stub := fake.InterruptibleStub
+	fakeReturns := fake.interruptibleReturns
+	fake.recordInvocation("Interruptible", []interface{}{})
+	fake.interruptibleMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) InterruptibleCallCount() int {
+	fake.interruptibleMutex.RLock()
+	defer fake.interruptibleMutex.RUnlock()
+	return len(fake.interruptibleArgsForCall)
+}
+
+func (fake *FakeBuild) InterruptibleCalls(stub func() bool) {
+	fake.interruptibleMutex.Lock()
+	defer fake.interruptibleMutex.Unlock()
+	fake.InterruptibleStub = stub
+}
+
+func (fake *FakeBuild) InterruptibleReturns(result1 bool) {
+	fake.interruptibleMutex.Lock()
+	defer fake.interruptibleMutex.Unlock()
+	fake.InterruptibleStub = nil
+	fake.interruptibleReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) InterruptibleReturnsOnCall(i int, result1 bool) {
+	fake.interruptibleMutex.Lock()
+	defer fake.interruptibleMutex.Unlock()
+	fake.InterruptibleStub = nil
+	if fake.interruptibleReturnsOnCall == nil {
+		fake.interruptibleReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.interruptibleReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) IsAborted() bool {
 	fake.isAbortedMutex.Lock()
 	ret, specificReturn := fake.isAbortedReturnsOnCall[len(fake.isAbortedArgsForCall)]
@@ -2770,6 +2884,67 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RemoveSecretLease(arg1 string) error {
+	fake.removeSecretLeaseMutex.Lock()
+	ret, specificReturn := fake.removeSecretLeaseReturnsOnCall[len(fake.removeSecretLeaseArgsForCall)]
+	fake.removeSecretLeaseArgsForCall = append(fake.removeSecretLeaseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveSecretLeaseStub
+	fakeReturns := fake.removeSecretLeaseReturns
+	fake.recordInvocation("RemoveSecretLease", []interface{}{arg1})
+	fake.removeSecretLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RemoveSecretLeaseCallCount() int {
+	fake.removeSecretLeaseMutex.RLock()
+	defer fake.removeSecretLeaseMutex.RUnlock()
+	return len(fake.removeSecretLeaseArgsForCall)
+}
+
+func (fake *FakeBuild) RemoveSecretLeaseCalls(stub func(string) error) {
+	fake.removeSecretLeaseMutex.Lock()
+	defer fake.removeSecretLeaseMutex.Unlock()
+	fake.RemoveSecretLeaseStub = stub
+}
+
+func (fake *FakeBuild) RemoveSecretLeaseArgsForCall(i int) string {
+	fake.removeSecretLeaseMutex.RLock()
+	defer fake.removeSecretLeaseMutex.RUnlock()
+	argsForCall := fake.removeSecretLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) RemoveSecretLeaseReturns(result1 error) {
+	fake.removeSecretLeaseMutex.Lock()
+	defer fake.removeSecretLeaseMutex.Unlock()
+	fake.RemoveSecretLeaseStub = nil
+	fake.removeSecretLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RemoveSecretLeaseReturnsOnCall(i int, result1 error) {
+	fake.removeSecretLeaseMutex.Lock()
+	defer fake.removeSecretLeaseMutex.Unlock()
+	fake.RemoveSecretLeaseStub = nil
+	if fake.removeSecretLeaseReturnsOnCall == nil {
+		fake.removeSecretLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeSecretLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) RerunNumber() int {
 	fake.rerunNumberMutex.Lock()
 	ret, specificReturn := fake.rerunNumberReturnsOnCall[len(fake.rerunNumberArgsForCall)]
@@ -3256,6 +3431,73 @@ func (fake *FakeBuild) ResourcesCheckedReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) SaveCheckResult(arg1 []atc.Version, arg2 int) error {
+	var arg1Copy []atc.Version
+	if arg1 != nil {
+		arg1Copy = make([]atc.Version, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveCheckResultMutex.Lock()
+	ret, specificReturn := fake.saveCheckResultReturnsOnCall[len(fake.saveCheckResultArgsForCall)]
+	fake.saveCheckResultArgsForCall = append(fake.saveCheckResultArgsForCall, struct {
+		arg1 []atc.Version
+		arg2 int
+	}{arg1Copy, arg2})
+	stub := fake.SaveCheckResultStub
+	fakeReturns := fake.saveCheckResultReturns
+	fake.recordInvocation("SaveCheckResult", []interface{}{arg1Copy, arg2})
+	fake.saveCheckResultMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveCheckResultCallCount() int {
+	fake.saveCheckResultMutex.RLock()
+	defer fake.saveCheckResultMutex.RUnlock()
+	return len(fake.saveCheckResultArgsForCall)
+}
+
+func (fake *FakeBuild) SaveCheckResultCalls(stub func([]atc.Version, int) error) {
+	fake.saveCheckResultMutex.Lock()
+	defer fake.saveCheckResultMutex.Unlock()
+	fake.SaveCheckResultStub = stub
+}
+
+func (fake *FakeBuild) SaveCheckResultArgsForCall(i int) ([]atc.Version, int) {
+	fake.saveCheckResultMutex.RLock()
+	defer fake.saveCheckResultMutex.RUnlock()
+	argsForCall := fake.saveCheckResultArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveCheckResultReturns(result1 error) {
+	fake.saveCheckResultMutex.Lock()
+	defer fake.saveCheckResultMutex.Unlock()
+	fake.SaveCheckResultStub = nil
+	fake.saveCheckResultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveCheckResultReturnsOnCall(i int, result1 error) {
+	fake.saveCheckResultMutex.Lock()
+	defer fake.saveCheckResultMutex.Unlock()
+	fake.SaveCheckResultStub = nil
+	if fake.saveCheckResultReturnsOnCall == nil {
+		fake.saveCheckResultReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveCheckResultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -3516,6 +3758,68 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveSecretLease(arg1 string, arg2 time.Time) error {
+	fake.saveSecretLeaseMutex.Lock()
+	ret, specificReturn := fake.saveSecretLeaseReturnsOnCall[len(fake.saveSecretLeaseArgsForCall)]
+	fake.saveSecretLeaseArgsForCall = append(fake.saveSecretLeaseArgsForCall, struct {
+		arg1 string
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.SaveSecretLeaseStub
+	fakeReturns := fake.saveSecretLeaseReturns
+	fake.recordInvocation("SaveSecretLease", []interface{}{arg1, arg2})
+	fake.saveSecretLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveSecretLeaseCallCount() int {
+	fake.saveSecretLeaseMutex.RLock()
+	defer fake.saveSecretLeaseMutex.RUnlock()
+	return len(fake.saveSecretLeaseArgsForCall)
+}
+
+func (fake *FakeBuild) SaveSecretLeaseCalls(stub func(string, time.Time) error) {
+	fake.saveSecretLeaseMutex.Lock()
+	defer fake.saveSecretLeaseMutex.Unlock()
+	fake.SaveSecretLeaseStub = stub
+}
+
+func (fake *FakeBuild) SaveSecretLeaseArgsForCall(i int) (string, time.Time) {
+	fake.saveSecretLeaseMutex.RLock()
+	defer fake.saveSecretLeaseMutex.RUnlock()
+	argsForCall := fake.saveSecretLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveSecretLeaseReturns(result1 error) {
+	fake.saveSecretLeaseMutex.Lock()
+	defer fake.saveSecretLeaseMutex.Unlock()
+	fake.SaveSecretLeaseStub = nil
+	fake.saveSecretLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveSecretLeaseReturnsOnCall(i int, result1 error) {
+	fake.saveSecretLeaseMutex.Lock()
+	defer fake.saveSecretLeaseMutex.Unlock()
+	fake.SaveSecretLeaseStub = nil
+	if fake.saveSecretLeaseReturnsOnCall == nil {
+		fake.saveSecretLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveSecretLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -3914,6 +4218,74 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) StepOutput(arg1 atc.PlanID, arg2 string) (db.WorkerArtifact, bool, error) {
+	fake.stepOutputMutex.Lock()
+	ret, specificReturn := fake.stepOutputReturnsOnCall[len(fake.stepOutputArgsForCall)]
+	fake.stepOutputArgsForCall = append(fake.stepOutputArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.StepOutputStub
+	fakeReturns := fake.stepOutputReturns
+	fake.recordInvocation("StepOutput", []interface{}{arg1, arg2})
+	fake.stepOutputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) StepOutputCallCount() int {
+	fake.stepOutputMutex.RLock()
+	defer fake.stepOutputMutex.RUnlock()
+	return len(fake.stepOutputArgsForCall)
+}
+
+func (fake *FakeBuild) StepOutputCalls(stub func(atc.PlanID, string) (db.WorkerArtifact, bool, error)) {
+	fake.stepOutputMutex.Lock()
+	defer fake.stepOutputMutex.Unlock()
+	fake.StepOutputStub = stub
+}
+
+func (fake *FakeBuild) StepOutputArgsForCall(i int) (atc.PlanID, string) {
+	fake.stepOutputMutex.RLock()
+	defer fake.stepOutputMutex.RUnlock()
+	argsForCall := fake.stepOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) StepOutputReturns(result1 db.WorkerArtifact, result2 bool, result3 error) {
+	fake.stepOutputMutex.Lock()
+	defer fake.stepOutputMutex.Unlock()
+	fake.StepOutputStub = nil
+	fake.stepOutputReturns = struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) StepOutputReturnsOnCall(i int, result1 db.WorkerArtifact, result2 bool, result3 error) {
+	fake.stepOutputMutex.Lock()
+	defer fake.stepOutputMutex.Unlock()
+	fake.StepOutputStub = nil
+	if fake.stepOutputReturnsOnCall == nil {
+		fake.stepOutputReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 bool
+			result3 error
+		})
+	}
+	fake.stepOutputReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) SyslogTag(arg1 event.OriginID) string {
 	fake.syslogTagMutex.Lock()
 	ret, specificReturn := fake.syslogTagReturnsOnCall[len(fake.syslogTagArgsForCall)]
@@ -4233,6 +4605,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.inputsReadyMutex.RUnlock()
 	fake.interceptibleMutex.RLock()
 	defer fake.interceptibleMutex.RUnlock()
+	fake.interruptibleMutex.RLock()
+	defer fake.interruptibleMutex.RUnlock()
 	fake.isAbortedMutex.RLock()
 	defer fake.isAbortedMutex.RUnlock()
 	fake.isCompletedMutex.RLock()
@@ -4277,6 +4651,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.removeSecretLeaseMutex.RLock()
+	defer fake.removeSecretLeaseMutex.RUnlock()
 	fake.rerunNumberMutex.RLock()
 	defer fake.rerunNumberMutex.RUnlock()
 	fake.rerunOfMutex.RLock()
@@ -4295,6 +4671,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
 	defer fake.resourcesCheckedMutex.RUnlock()
+	fake.saveCheckResultMutex.RLock()
+	defer fake.saveCheckResultMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
@@ -4303,6 +4681,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveSecretLeaseMutex.RLock()
+	defer fake.saveSecretLeaseMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
@@ -4317,6 +4697,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.stepOutputMutex.RLock()
+	defer fake.stepOutputMutex.RUnlock()
 	fake.syslogTagMutex.RLock()
 	defer fake.syslogTagMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -72,6 +72,18 @@ type FakeBuildFactory struct {
 	markNonInterceptibleBuildsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PendingBuildsPerTeamStub        func() (map[string]int, error)
+	pendingBuildsPerTeamMutex       sync.RWMutex
+	pendingBuildsPerTeamArgsForCall []struct {
+	}
+	pendingBuildsPerTeamReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	pendingBuildsPerTeamReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	PublicBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	publicBuildsMutex       sync.RWMutex
 	publicBuildsArgsForCall []struct {
@@ -406,6 +418,62 @@ func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeBuildFactory) PendingBuildsPerTeam() (map[string]int, error) {
+	fake.pendingBuildsPerTeamMutex.Lock()
+	ret, specificReturn := fake.pendingBuildsPerTeamReturnsOnCall[len(fake.pendingBuildsPerTeamArgsForCall)]
+	fake.pendingBuildsPerTeamArgsForCall = append(fake.pendingBuildsPerTeamArgsForCall, struct {
+	}{})
+	stub := fake.PendingBuildsPerTeamStub
+	fakeReturns := fake.pendingBuildsPerTeamReturns
+	fake.recordInvocation("PendingBuildsPerTeam", []interface{}{})
+	fake.pendingBuildsPerTeamMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) PendingBuildsPerTeamCallCount() int {
+	fake.pendingBuildsPerTeamMutex.RLock()
+	defer fake.pendingBuildsPerTeamMutex.RUnlock()
+	return len(fake.pendingBuildsPerTeamArgsForCall)
+}
+
+func (fake *FakeBuildFactory) PendingBuildsPerTeamCalls(stub func() (map[string]int, error)) {
+	fake.pendingBuildsPerTeamMutex.Lock()
+	defer fake.pendingBuildsPerTeamMutex.Unlock()
+	fake.PendingBuildsPerTeamStub = stub
+}
+
+func (fake *FakeBuildFactory) PendingBuildsPerTeamReturns(result1 map[string]int, result2 error) {
+	fake.pendingBuildsPerTeamMutex.Lock()
+	defer fake.pendingBuildsPerTeamMutex.Unlock()
+	fake.PendingBuildsPerTeamStub = nil
+	fake.pendingBuildsPerTeamReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) PendingBuildsPerTeamReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.pendingBuildsPerTeamMutex.Lock()
+	defer fake.pendingBuildsPerTeamMutex.Unlock()
+	fake.PendingBuildsPerTeamStub = nil
+	if fake.pendingBuildsPerTeamReturnsOnCall == nil {
+		fake.pendingBuildsPerTeamReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.pendingBuildsPerTeamReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) PublicBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.publicBuildsMutex.Lock()
 	ret, specificReturn := fake.publicBuildsReturnsOnCall[len(fake.publicBuildsArgsForCall)]
@@ -559,6 +627,8 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.getDrainableBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
+	fake.pendingBuildsPerTeamMutex.RLock()
+	defer fake.pendingBuildsPerTeamMutex.RUnlock()
 	fake.publicBuildsMutex.RLock()
 	defer fake.publicBuildsMutex.RUnlock()
 	fake.visibleBuildsMutex.RLock()
@@ -0,0 +1,263 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeCacheInvalidationFactory struct {
+	CacheInvalidationsSinceStub        func(int) ([]creds.CacheInvalidation, error)
+	cacheInvalidationsSinceMutex       sync.RWMutex
+	cacheInvalidationsSinceArgsForCall []struct {
+		arg1 int
+	}
+	cacheInvalidationsSinceReturns struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}
+	cacheInvalidationsSinceReturnsOnCall map[int]struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}
+	InvalidateStub        func(string, string) error
+	invalidateMutex       sync.RWMutex
+	invalidateArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	invalidateReturns struct {
+		result1 error
+	}
+	invalidateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	LatestCacheInvalidationIDStub        func() (int, error)
+	latestCacheInvalidationIDMutex       sync.RWMutex
+	latestCacheInvalidationIDArgsForCall []struct {
+	}
+	latestCacheInvalidationIDReturns struct {
+		result1 int
+		result2 error
+	}
+	latestCacheInvalidationIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCacheInvalidationFactory) CacheInvalidationsSince(arg1 int) ([]creds.CacheInvalidation, error) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	ret, specificReturn := fake.cacheInvalidationsSinceReturnsOnCall[len(fake.cacheInvalidationsSinceArgsForCall)]
+	fake.cacheInvalidationsSinceArgsForCall = append(fake.cacheInvalidationsSinceArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.CacheInvalidationsSinceStub
+	fakeReturns := fake.cacheInvalidationsSinceReturns
+	fake.recordInvocation("CacheInvalidationsSince", []interface{}{arg1})
+	fake.cacheInvalidationsSinceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCacheInvalidationFactory) CacheInvalidationsSinceCallCount() int {
+	fake.cacheInvalidationsSinceMutex.RLock()
+	defer fake.cacheInvalidationsSinceMutex.RUnlock()
+	return len(fake.cacheInvalidationsSinceArgsForCall)
+}
+
+func (fake *FakeCacheInvalidationFactory) CacheInvalidationsSinceCalls(stub func(int) ([]creds.CacheInvalidation, error)) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	defer fake.cacheInvalidationsSinceMutex.Unlock()
+	fake.CacheInvalidationsSinceStub = stub
+}
+
+func (fake *FakeCacheInvalidationFactory) CacheInvalidationsSinceArgsForCall(i int) int {
+	fake.cacheInvalidationsSinceMutex.RLock()
+	defer fake.cacheInvalidationsSinceMutex.RUnlock()
+	argsForCall := fake.cacheInvalidationsSinceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCacheInvalidationFactory) CacheInvalidationsSinceReturns(result1 []creds.CacheInvalidation, result2 error) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	defer fake.cacheInvalidationsSinceMutex.Unlock()
+	fake.CacheInvalidationsSinceStub = nil
+	fake.cacheInvalidationsSinceReturns = struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidationFactory) CacheInvalidationsSinceReturnsOnCall(i int, result1 []creds.CacheInvalidation, result2 error) {
+	fake.cacheInvalidationsSinceMutex.Lock()
+	defer fake.cacheInvalidationsSinceMutex.Unlock()
+	fake.CacheInvalidationsSinceStub = nil
+	if fake.cacheInvalidationsSinceReturnsOnCall == nil {
+		fake.cacheInvalidationsSinceReturnsOnCall = make(map[int]struct {
+			result1 []creds.CacheInvalidation
+			result2 error
+		})
+	}
+	fake.cacheInvalidationsSinceReturnsOnCall[i] = struct {
+		result1 []creds.CacheInvalidation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidationFactory) Invalidate(arg1 string, arg2 string) error {
+	fake.invalidateMutex.Lock()
+	ret, specificReturn := fake.invalidateReturnsOnCall[len(fake.invalidateArgsForCall)]
+	fake.invalidateArgsForCall = append(fake.invalidateArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.InvalidateStub
+	fakeReturns := fake.invalidateReturns
+	fake.recordInvocation("Invalidate", []interface{}{arg1, arg2})
+	fake.invalidateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCacheInvalidationFactory) InvalidateCallCount() int {
+	fake.invalidateMutex.RLock()
+	defer fake.invalidateMutex.RUnlock()
+	return len(fake.invalidateArgsForCall)
+}
+
+func (fake *FakeCacheInvalidationFactory) InvalidateCalls(stub func(string, string) error) {
+	fake.invalidateMutex.Lock()
+	defer fake.invalidateMutex.Unlock()
+	fake.InvalidateStub = stub
+}
+
+func (fake *FakeCacheInvalidationFactory) InvalidateArgsForCall(i int) (string, string) {
+	fake.invalidateMutex.RLock()
+	defer fake.invalidateMutex.RUnlock()
+	argsForCall := fake.invalidateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCacheInvalidationFactory) InvalidateReturns(result1 error) {
+	fake.invalidateMutex.Lock()
+	defer fake.invalidateMutex.Unlock()
+	fake.InvalidateStub = nil
+	fake.invalidateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCacheInvalidationFactory) InvalidateReturnsOnCall(i int, result1 error) {
+	fake.invalidateMutex.Lock()
+	defer fake.invalidateMutex.Unlock()
+	fake.InvalidateStub = nil
+	if fake.invalidateReturnsOnCall == nil {
+		fake.invalidateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.invalidateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCacheInvalidationFactory) LatestCacheInvalidationID() (int, error) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	ret, specificReturn := fake.latestCacheInvalidationIDReturnsOnCall[len(fake.latestCacheInvalidationIDArgsForCall)]
+	fake.latestCacheInvalidationIDArgsForCall = append(fake.latestCacheInvalidationIDArgsForCall, struct {
+	}{})
+	stub := fake.LatestCacheInvalidationIDStub
+	fakeReturns := fake.latestCacheInvalidationIDReturns
+	fake.recordInvocation("LatestCacheInvalidationID", []interface{}{})
+	fake.latestCacheInvalidationIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCacheInvalidationFactory) LatestCacheInvalidationIDCallCount() int {
+	fake.latestCacheInvalidationIDMutex.RLock()
+	defer fake.latestCacheInvalidationIDMutex.RUnlock()
+	return len(fake.latestCacheInvalidationIDArgsForCall)
+}
+
+func (fake *FakeCacheInvalidationFactory) LatestCacheInvalidationIDCalls(stub func() (int, error)) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	defer fake.latestCacheInvalidationIDMutex.Unlock()
+	fake.LatestCacheInvalidationIDStub = stub
+}
+
+func (fake *FakeCacheInvalidationFactory) LatestCacheInvalidationIDReturns(result1 int, result2 error) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	defer fake.latestCacheInvalidationIDMutex.Unlock()
+	fake.LatestCacheInvalidationIDStub = nil
+	fake.latestCacheInvalidationIDReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidationFactory) LatestCacheInvalidationIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.latestCacheInvalidationIDMutex.Lock()
+	defer fake.latestCacheInvalidationIDMutex.Unlock()
+	fake.LatestCacheInvalidationIDStub = nil
+	if fake.latestCacheInvalidationIDReturnsOnCall == nil {
+		fake.latestCacheInvalidationIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.latestCacheInvalidationIDReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCacheInvalidationFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cacheInvalidationsSinceMutex.RLock()
+	defer fake.cacheInvalidationsSinceMutex.RUnlock()
+	fake.invalidateMutex.RLock()
+	defer fake.invalidateMutex.RUnlock()
+	fake.latestCacheInvalidationIDMutex.RLock()
+	defer fake.latestCacheInvalidationIDMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCacheInvalidationFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.CacheInvalidationFactory = new(FakeCacheInvalidationFactory)
@@ -4,12 +4,37 @@ package dbfakes
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeCheckFactory struct {
+	NextCheckTimeStub        func(db.Checkable, bool) time.Time
+	nextCheckTimeMutex       sync.RWMutex
+	nextCheckTimeArgsForCall []struct {
+		arg1 db.Checkable
+		arg2 bool
+	}
+	nextCheckTimeReturns struct {
+		result1 time.Time
+	}
+	nextCheckTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	PrioritizedResourceIDsStub        func() (map[int]bool, error)
+	prioritizedResourceIDsMutex       sync.RWMutex
+	prioritizedResourceIDsArgsForCall []struct {
+	}
+	prioritizedResourceIDsReturns struct {
+		result1 map[int]bool
+		result2 error
+	}
+	prioritizedResourceIDsReturnsOnCall map[int]struct {
+		result1 map[int]bool
+		result2 error
+	}
 	ResourceTypesStub        func() ([]db.ResourceType, error)
 	resourceTypesMutex       sync.RWMutex
 	resourceTypesArgsForCall []struct {
@@ -57,6 +82,124 @@ type FakeCheckFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCheckFactory) NextCheckTime(arg1 db.Checkable, arg2 bool) time.Time {
+	fake.nextCheckTimeMutex.Lock()
+	ret, specificReturn := fake.nextCheckTimeReturnsOnCall[len(fake.nextCheckTimeArgsForCall)]
+	fake.nextCheckTimeArgsForCall = append(fake.nextCheckTimeArgsForCall, struct {
+		arg1 db.Checkable
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.NextCheckTimeStub
+	fakeReturns := fake.nextCheckTimeReturns
+	fake.recordInvocation("NextCheckTime", []interface{}{arg1, arg2})
+	fake.nextCheckTimeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckFactory) NextCheckTimeCallCount() int {
+	fake.nextCheckTimeMutex.RLock()
+	defer fake.nextCheckTimeMutex.RUnlock()
+	return len(fake.nextCheckTimeArgsForCall)
+}
+
+func (fake *FakeCheckFactory) NextCheckTimeCalls(stub func(db.Checkable, bool) time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = stub
+}
+
+func (fake *FakeCheckFactory) NextCheckTimeArgsForCall(i int) (db.Checkable, bool) {
+	fake.nextCheckTimeMutex.RLock()
+	defer fake.nextCheckTimeMutex.RUnlock()
+	argsForCall := fake.nextCheckTimeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckFactory) NextCheckTimeReturns(result1 time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = nil
+	fake.nextCheckTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheckFactory) NextCheckTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.nextCheckTimeMutex.Lock()
+	defer fake.nextCheckTimeMutex.Unlock()
+	fake.NextCheckTimeStub = nil
+	if fake.nextCheckTimeReturnsOnCall == nil {
+		fake.nextCheckTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nextCheckTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheckFactory) PrioritizedResourceIDs() (map[int]bool, error) {
+	fake.prioritizedResourceIDsMutex.Lock()
+	ret, specificReturn := fake.prioritizedResourceIDsReturnsOnCall[len(fake.prioritizedResourceIDsArgsForCall)]
+	fake.prioritizedResourceIDsArgsForCall = append(fake.prioritizedResourceIDsArgsForCall, struct {
+	}{})
+	stub := fake.PrioritizedResourceIDsStub
+	fakeReturns := fake.prioritizedResourceIDsReturns
+	fake.recordInvocation("PrioritizedResourceIDs", []interface{}{})
+	fake.prioritizedResourceIDsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckFactory) PrioritizedResourceIDsCallCount() int {
+	fake.prioritizedResourceIDsMutex.RLock()
+	defer fake.prioritizedResourceIDsMutex.RUnlock()
+	return len(fake.prioritizedResourceIDsArgsForCall)
+}
+
+func (fake *FakeCheckFactory) PrioritizedResourceIDsCalls(stub func() (map[int]bool, error)) {
+	fake.prioritizedResourceIDsMutex.Lock()
+	defer fake.prioritizedResourceIDsMutex.Unlock()
+	fake.PrioritizedResourceIDsStub = stub
+}
+
+func (fake *FakeCheckFactory) PrioritizedResourceIDsReturns(result1 map[int]bool, result2 error) {
+	fake.prioritizedResourceIDsMutex.Lock()
+	defer fake.prioritizedResourceIDsMutex.Unlock()
+	fake.PrioritizedResourceIDsStub = nil
+	fake.prioritizedResourceIDsReturns = struct {
+		result1 map[int]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckFactory) PrioritizedResourceIDsReturnsOnCall(i int, result1 map[int]bool, result2 error) {
+	fake.prioritizedResourceIDsMutex.Lock()
+	defer fake.prioritizedResourceIDsMutex.Unlock()
+	fake.PrioritizedResourceIDsStub = nil
+	if fake.prioritizedResourceIDsReturnsOnCall == nil {
+		fake.prioritizedResourceIDsReturnsOnCall = make(map[int]struct {
+			result1 map[int]bool
+			result2 error
+		})
+	}
+	fake.prioritizedResourceIDsReturnsOnCall[i] = struct {
+		result1 map[int]bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCheckFactory) ResourceTypes() ([]db.ResourceType, error) {
 	fake.resourceTypesMutex.Lock()
 	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
@@ -243,6 +386,10 @@ func (fake *FakeCheckFactory) TryCreateCheckReturnsOnCall(i int, result1 db.Buil
 func (fake *FakeCheckFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.nextCheckTimeMutex.RLock()
+	defer fake.nextCheckTimeMutex.RUnlock()
+	fake.prioritizedResourceIDsMutex.RLock()
+	defer fake.prioritizedResourceIDsMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.resourcesMutex.RLock()
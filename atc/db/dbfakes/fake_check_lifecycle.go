@@ -8,9 +8,10 @@ import (
 )
 
 type FakeCheckLifecycle struct {
-	DeleteCompletedChecksStub        func() error
+	DeleteCompletedChecksStub        func(int) error
 	deleteCompletedChecksMutex       sync.RWMutex
 	deleteCompletedChecksArgsForCall []struct {
+		arg1 int
 	}
 	deleteCompletedChecksReturns struct {
 		result1 error
@@ -22,17 +23,18 @@ type FakeCheckLifecycle struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeCheckLifecycle) DeleteCompletedChecks() error {
+func (fake *FakeCheckLifecycle) DeleteCompletedChecks(arg1 int) error {
 	fake.deleteCompletedChecksMutex.Lock()
 	ret, specificReturn := fake.deleteCompletedChecksReturnsOnCall[len(fake.deleteCompletedChecksArgsForCall)]
 	fake.deleteCompletedChecksArgsForCall = append(fake.deleteCompletedChecksArgsForCall, struct {
-	}{})
+		arg1 int
+	}{arg1})
 	stub := fake.DeleteCompletedChecksStub
 	fakeReturns := fake.deleteCompletedChecksReturns
-	fake.recordInvocation("DeleteCompletedChecks", []interface{}{})
+	fake.recordInvocation("DeleteCompletedChecks", []interface{}{arg1})
 	fake.deleteCompletedChecksMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -46,12 +48,19 @@ func (fake *FakeCheckLifecycle) DeleteCompletedChecksCallCount() int {
 	return len(fake.deleteCompletedChecksArgsForCall)
 }
 
-func (fake *FakeCheckLifecycle) DeleteCompletedChecksCalls(stub func() error) {
+func (fake *FakeCheckLifecycle) DeleteCompletedChecksCalls(stub func(int) error) {
 	fake.deleteCompletedChecksMutex.Lock()
 	defer fake.deleteCompletedChecksMutex.Unlock()
 	fake.DeleteCompletedChecksStub = stub
 }
 
+func (fake *FakeCheckLifecycle) DeleteCompletedChecksArgsForCall(i int) int {
+	fake.deleteCompletedChecksMutex.RLock()
+	defer fake.deleteCompletedChecksMutex.RUnlock()
+	argsForCall := fake.deleteCompletedChecksArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeCheckLifecycle) DeleteCompletedChecksReturns(result1 error) {
 	fake.deleteCompletedChecksMutex.Lock()
 	defer fake.deleteCompletedChecksMutex.Unlock()
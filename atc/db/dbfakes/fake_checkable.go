@@ -92,6 +92,16 @@ type FakeCheckable struct {
 	lastCheckEndTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastNewVersionTimeStub        func() time.Time
+	lastNewVersionTimeMutex       sync.RWMutex
+	lastNewVersionTimeArgsForCall []struct {
+	}
+	lastNewVersionTimeReturns struct {
+		result1 time.Time
+	}
+	lastNewVersionTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -618,6 +628,59 @@ func (fake *FakeCheckable) LastCheckEndTimeReturnsOnCall(i int, result1 time.Tim
 	}{result1}
 }
 
+func (fake *FakeCheckable) LastNewVersionTime() time.Time {
+	fake.lastNewVersionTimeMutex.Lock()
+	ret, specificReturn := fake.lastNewVersionTimeReturnsOnCall[len(fake.lastNewVersionTimeArgsForCall)]
+	fake.lastNewVersionTimeArgsForCall = append(fake.lastNewVersionTimeArgsForCall, struct {
+	}{})
+	stub := fake.LastNewVersionTimeStub
+	fakeReturns := fake.lastNewVersionTimeReturns
+	fake.recordInvocation("LastNewVersionTime", []interface{}{})
+	fake.lastNewVersionTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckable) LastNewVersionTimeCallCount() int {
+	fake.lastNewVersionTimeMutex.RLock()
+	defer fake.lastNewVersionTimeMutex.RUnlock()
+	return len(fake.lastNewVersionTimeArgsForCall)
+}
+
+func (fake *FakeCheckable) LastNewVersionTimeCalls(stub func() time.Time) {
+	fake.lastNewVersionTimeMutex.Lock()
+	defer fake.lastNewVersionTimeMutex.Unlock()
+	fake.LastNewVersionTimeStub = stub
+}
+
+func (fake *FakeCheckable) LastNewVersionTimeReturns(result1 time.Time) {
+	fake.lastNewVersionTimeMutex.Lock()
+	defer fake.lastNewVersionTimeMutex.Unlock()
+	fake.LastNewVersionTimeStub = nil
+	fake.lastNewVersionTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheckable) LastNewVersionTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastNewVersionTimeMutex.Lock()
+	defer fake.lastNewVersionTimeMutex.Unlock()
+	fake.LastNewVersionTimeStub = nil
+	if fake.lastNewVersionTimeReturnsOnCall == nil {
+		fake.lastNewVersionTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastNewVersionTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCheckable) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1277,6 +1340,8 @@ func (fake *FakeCheckable) Invocations() map[string][][]interface{} {
 	defer fake.hasWebhookMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
+	fake.lastNewVersionTimeMutex.RLock()
+	defer fake.lastNewVersionTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pipelineMutex.RLock()
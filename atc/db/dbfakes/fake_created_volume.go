@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -91,6 +92,20 @@ type FakeCreatedVolume struct {
 		result1 db.WorkerArtifact
 		result2 error
 	}
+	InitializeKeyedCacheStub        func(int, string, string, int64) error
+	initializeKeyedCacheMutex       sync.RWMutex
+	initializeKeyedCacheArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 int64
+	}
+	initializeKeyedCacheReturns struct {
+		result1 error
+	}
+	initializeKeyedCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InitializeResourceCacheStub        func(db.UsedResourceCache) error
 	initializeResourceCacheMutex       sync.RWMutex
 	initializeResourceCacheArgsForCall []struct {
@@ -102,6 +117,22 @@ type FakeCreatedVolume struct {
 	initializeResourceCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitializeStepOutputStub        func(string, int, atc.PlanID, time.Time) (db.WorkerArtifact, error)
+	initializeStepOutputMutex       sync.RWMutex
+	initializeStepOutputArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 atc.PlanID
+		arg4 time.Time
+	}
+	initializeStepOutputReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	initializeStepOutputReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
 	InitializeTaskCacheStub        func(int, string, string) error
 	initializeTaskCacheMutex       sync.RWMutex
 	initializeTaskCacheArgsForCall []struct {
@@ -115,6 +146,26 @@ type FakeCreatedVolume struct {
 	initializeTaskCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InvalidateResourceCacheStub        func() error
+	invalidateResourceCacheMutex       sync.RWMutex
+	invalidateResourceCacheArgsForCall []struct {
+	}
+	invalidateResourceCacheReturns struct {
+		result1 error
+	}
+	invalidateResourceCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkResourceCacheVerifiedStub        func() error
+	markResourceCacheVerifiedMutex       sync.RWMutex
+	markResourceCacheVerifiedArgsForCall []struct {
+	}
+	markResourceCacheVerifiedReturns struct {
+		result1 error
+	}
+	markResourceCacheVerifiedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ParentHandleStub        func() string
 	parentHandleMutex       sync.RWMutex
 	parentHandleArgsForCall []struct {
@@ -135,6 +186,33 @@ type FakeCreatedVolume struct {
 	pathReturnsOnCall map[int]struct {
 		result1 string
 	}
+	RecordResourceCacheChecksumStub        func(string) error
+	recordResourceCacheChecksumMutex       sync.RWMutex
+	recordResourceCacheChecksumArgsForCall []struct {
+		arg1 string
+	}
+	recordResourceCacheChecksumReturns struct {
+		result1 error
+	}
+	recordResourceCacheChecksumReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResourceCacheChecksumStub        func() (string, time.Time, bool, error)
+	resourceCacheChecksumMutex       sync.RWMutex
+	resourceCacheChecksumArgsForCall []struct {
+	}
+	resourceCacheChecksumReturns struct {
+		result1 string
+		result2 time.Time
+		result3 bool
+		result4 error
+	}
+	resourceCacheChecksumReturnsOnCall map[int]struct {
+		result1 string
+		result2 time.Time
+		result3 bool
+		result4 error
+	}
 	ResourceTypeStub        func() (*db.VolumeResourceType, error)
 	resourceTypeMutex       sync.RWMutex
 	resourceTypeArgsForCall []struct {
@@ -610,6 +688,70 @@ func (fake *FakeCreatedVolume) InitializeArtifactReturnsOnCall(i int, result1 db
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) InitializeKeyedCache(arg1 int, arg2 string, arg3 string, arg4 int64) error {
+	fake.initializeKeyedCacheMutex.Lock()
+	ret, specificReturn := fake.initializeKeyedCacheReturnsOnCall[len(fake.initializeKeyedCacheArgsForCall)]
+	fake.initializeKeyedCacheArgsForCall = append(fake.initializeKeyedCacheArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.InitializeKeyedCacheStub
+	fakeReturns := fake.initializeKeyedCacheReturns
+	fake.recordInvocation("InitializeKeyedCache", []interface{}{arg1, arg2, arg3, arg4})
+	fake.initializeKeyedCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheCallCount() int {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	return len(fake.initializeKeyedCacheArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheCalls(stub func(int, string, string, int64) error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = stub
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheArgsForCall(i int) (int, string, string, int64) {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	argsForCall := fake.initializeKeyedCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheReturns(result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	fake.initializeKeyedCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheReturnsOnCall(i int, result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	if fake.initializeKeyedCacheReturnsOnCall == nil {
+		fake.initializeKeyedCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeKeyedCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) InitializeResourceCache(arg1 db.UsedResourceCache) error {
 	fake.initializeResourceCacheMutex.Lock()
 	ret, specificReturn := fake.initializeResourceCacheReturnsOnCall[len(fake.initializeResourceCacheArgsForCall)]
@@ -671,6 +813,73 @@ func (fake *FakeCreatedVolume) InitializeResourceCacheReturnsOnCall(i int, resul
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) InitializeStepOutput(arg1 string, arg2 int, arg3 atc.PlanID, arg4 time.Time) (db.WorkerArtifact, error) {
+	fake.initializeStepOutputMutex.Lock()
+	ret, specificReturn := fake.initializeStepOutputReturnsOnCall[len(fake.initializeStepOutputArgsForCall)]
+	fake.initializeStepOutputArgsForCall = append(fake.initializeStepOutputArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 atc.PlanID
+		arg4 time.Time
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.InitializeStepOutputStub
+	fakeReturns := fake.initializeStepOutputReturns
+	fake.recordInvocation("InitializeStepOutput", []interface{}{arg1, arg2, arg3, arg4})
+	fake.initializeStepOutputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCreatedVolume) InitializeStepOutputCallCount() int {
+	fake.initializeStepOutputMutex.RLock()
+	defer fake.initializeStepOutputMutex.RUnlock()
+	return len(fake.initializeStepOutputArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) InitializeStepOutputCalls(stub func(string, int, atc.PlanID, time.Time) (db.WorkerArtifact, error)) {
+	fake.initializeStepOutputMutex.Lock()
+	defer fake.initializeStepOutputMutex.Unlock()
+	fake.InitializeStepOutputStub = stub
+}
+
+func (fake *FakeCreatedVolume) InitializeStepOutputArgsForCall(i int) (string, int, atc.PlanID, time.Time) {
+	fake.initializeStepOutputMutex.RLock()
+	defer fake.initializeStepOutputMutex.RUnlock()
+	argsForCall := fake.initializeStepOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCreatedVolume) InitializeStepOutputReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.initializeStepOutputMutex.Lock()
+	defer fake.initializeStepOutputMutex.Unlock()
+	fake.InitializeStepOutputStub = nil
+	fake.initializeStepOutputReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCreatedVolume) InitializeStepOutputReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.initializeStepOutputMutex.Lock()
+	defer fake.initializeStepOutputMutex.Unlock()
+	fake.InitializeStepOutputStub = nil
+	if fake.initializeStepOutputReturnsOnCall == nil {
+		fake.initializeStepOutputReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.initializeStepOutputReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCreatedVolume) InitializeTaskCache(arg1 int, arg2 string, arg3 string) error {
 	fake.initializeTaskCacheMutex.Lock()
 	ret, specificReturn := fake.initializeTaskCacheReturnsOnCall[len(fake.initializeTaskCacheArgsForCall)]
@@ -734,6 +943,112 @@ func (fake *FakeCreatedVolume) InitializeTaskCacheReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) InvalidateResourceCache() error {
+	fake.invalidateResourceCacheMutex.Lock()
+	ret, specificReturn := fake.invalidateResourceCacheReturnsOnCall[len(fake.invalidateResourceCacheArgsForCall)]
+	fake.invalidateResourceCacheArgsForCall = append(fake.invalidateResourceCacheArgsForCall, struct {
+	}{})
+	stub := fake.InvalidateResourceCacheStub
+	fakeReturns := fake.invalidateResourceCacheReturns
+	fake.recordInvocation("InvalidateResourceCache", []interface{}{})
+	fake.invalidateResourceCacheMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) InvalidateResourceCacheCallCount() int {
+	fake.invalidateResourceCacheMutex.RLock()
+	defer fake.invalidateResourceCacheMutex.RUnlock()
+	return len(fake.invalidateResourceCacheArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) InvalidateResourceCacheCalls(stub func() error) {
+	fake.invalidateResourceCacheMutex.Lock()
+	defer fake.invalidateResourceCacheMutex.Unlock()
+	fake.InvalidateResourceCacheStub = stub
+}
+
+func (fake *FakeCreatedVolume) InvalidateResourceCacheReturns(result1 error) {
+	fake.invalidateResourceCacheMutex.Lock()
+	defer fake.invalidateResourceCacheMutex.Unlock()
+	fake.InvalidateResourceCacheStub = nil
+	fake.invalidateResourceCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) InvalidateResourceCacheReturnsOnCall(i int, result1 error) {
+	fake.invalidateResourceCacheMutex.Lock()
+	defer fake.invalidateResourceCacheMutex.Unlock()
+	fake.InvalidateResourceCacheStub = nil
+	if fake.invalidateResourceCacheReturnsOnCall == nil {
+		fake.invalidateResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.invalidateResourceCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) MarkResourceCacheVerified() error {
+	fake.markResourceCacheVerifiedMutex.Lock()
+	ret, specificReturn := fake.markResourceCacheVerifiedReturnsOnCall[len(fake.markResourceCacheVerifiedArgsForCall)]
+	fake.markResourceCacheVerifiedArgsForCall = append(fake.markResourceCacheVerifiedArgsForCall, struct {
+	}{})
+	stub := fake.MarkResourceCacheVerifiedStub
+	fakeReturns := fake.markResourceCacheVerifiedReturns
+	fake.recordInvocation("MarkResourceCacheVerified", []interface{}{})
+	fake.markResourceCacheVerifiedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) MarkResourceCacheVerifiedCallCount() int {
+	fake.markResourceCacheVerifiedMutex.RLock()
+	defer fake.markResourceCacheVerifiedMutex.RUnlock()
+	return len(fake.markResourceCacheVerifiedArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) MarkResourceCacheVerifiedCalls(stub func() error) {
+	fake.markResourceCacheVerifiedMutex.Lock()
+	defer fake.markResourceCacheVerifiedMutex.Unlock()
+	fake.MarkResourceCacheVerifiedStub = stub
+}
+
+func (fake *FakeCreatedVolume) MarkResourceCacheVerifiedReturns(result1 error) {
+	fake.markResourceCacheVerifiedMutex.Lock()
+	defer fake.markResourceCacheVerifiedMutex.Unlock()
+	fake.MarkResourceCacheVerifiedStub = nil
+	fake.markResourceCacheVerifiedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) MarkResourceCacheVerifiedReturnsOnCall(i int, result1 error) {
+	fake.markResourceCacheVerifiedMutex.Lock()
+	defer fake.markResourceCacheVerifiedMutex.Unlock()
+	fake.MarkResourceCacheVerifiedStub = nil
+	if fake.markResourceCacheVerifiedReturnsOnCall == nil {
+		fake.markResourceCacheVerifiedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markResourceCacheVerifiedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) ParentHandle() string {
 	fake.parentHandleMutex.Lock()
 	ret, specificReturn := fake.parentHandleReturnsOnCall[len(fake.parentHandleArgsForCall)]
@@ -840,6 +1155,129 @@ func (fake *FakeCreatedVolume) PathReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) RecordResourceCacheChecksum(arg1 string) error {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	ret, specificReturn := fake.recordResourceCacheChecksumReturnsOnCall[len(fake.recordResourceCacheChecksumArgsForCall)]
+	fake.recordResourceCacheChecksumArgsForCall = append(fake.recordResourceCacheChecksumArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RecordResourceCacheChecksumStub
+	fakeReturns := fake.recordResourceCacheChecksumReturns
+	fake.recordInvocation("RecordResourceCacheChecksum", []interface{}{arg1})
+	fake.recordResourceCacheChecksumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) RecordResourceCacheChecksumCallCount() int {
+	fake.recordResourceCacheChecksumMutex.RLock()
+	defer fake.recordResourceCacheChecksumMutex.RUnlock()
+	return len(fake.recordResourceCacheChecksumArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) RecordResourceCacheChecksumCalls(stub func(string) error) {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	defer fake.recordResourceCacheChecksumMutex.Unlock()
+	fake.RecordResourceCacheChecksumStub = stub
+}
+
+func (fake *FakeCreatedVolume) RecordResourceCacheChecksumArgsForCall(i int) string {
+	fake.recordResourceCacheChecksumMutex.RLock()
+	defer fake.recordResourceCacheChecksumMutex.RUnlock()
+	argsForCall := fake.recordResourceCacheChecksumArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCreatedVolume) RecordResourceCacheChecksumReturns(result1 error) {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	defer fake.recordResourceCacheChecksumMutex.Unlock()
+	fake.RecordResourceCacheChecksumStub = nil
+	fake.recordResourceCacheChecksumReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) RecordResourceCacheChecksumReturnsOnCall(i int, result1 error) {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	defer fake.recordResourceCacheChecksumMutex.Unlock()
+	fake.RecordResourceCacheChecksumStub = nil
+	if fake.recordResourceCacheChecksumReturnsOnCall == nil {
+		fake.recordResourceCacheChecksumReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordResourceCacheChecksumReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) ResourceCacheChecksum() (string, time.Time, bool, error) {
+	fake.resourceCacheChecksumMutex.Lock()
+	ret, specificReturn := fake.resourceCacheChecksumReturnsOnCall[len(fake.resourceCacheChecksumArgsForCall)]
+	fake.resourceCacheChecksumArgsForCall = append(fake.resourceCacheChecksumArgsForCall, struct {
+	}{})
+	stub := fake.ResourceCacheChecksumStub
+	fakeReturns := fake.resourceCacheChecksumReturns
+	fake.recordInvocation("ResourceCacheChecksum", []interface{}{})
+	fake.resourceCacheChecksumMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeCreatedVolume) ResourceCacheChecksumCallCount() int {
+	fake.resourceCacheChecksumMutex.RLock()
+	defer fake.resourceCacheChecksumMutex.RUnlock()
+	return len(fake.resourceCacheChecksumArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) ResourceCacheChecksumCalls(stub func() (string, time.Time, bool, error)) {
+	fake.resourceCacheChecksumMutex.Lock()
+	defer fake.resourceCacheChecksumMutex.Unlock()
+	fake.ResourceCacheChecksumStub = stub
+}
+
+func (fake *FakeCreatedVolume) ResourceCacheChecksumReturns(result1 string, result2 time.Time, result3 bool, result4 error) {
+	fake.resourceCacheChecksumMutex.Lock()
+	defer fake.resourceCacheChecksumMutex.Unlock()
+	fake.ResourceCacheChecksumStub = nil
+	fake.resourceCacheChecksumReturns = struct {
+		result1 string
+		result2 time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeCreatedVolume) ResourceCacheChecksumReturnsOnCall(i int, result1 string, result2 time.Time, result3 bool, result4 error) {
+	fake.resourceCacheChecksumMutex.Lock()
+	defer fake.resourceCacheChecksumMutex.Unlock()
+	fake.ResourceCacheChecksumStub = nil
+	if fake.resourceCacheChecksumReturnsOnCall == nil {
+		fake.resourceCacheChecksumReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 time.Time
+			result3 bool
+			result4 error
+		})
+	}
+	fake.resourceCacheChecksumReturnsOnCall[i] = struct {
+		result1 string
+		result2 time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *FakeCreatedVolume) ResourceType() (*db.VolumeResourceType, error) {
 	fake.resourceTypeMutex.Lock()
 	ret, specificReturn := fake.resourceTypeReturnsOnCall[len(fake.resourceTypeArgsForCall)]
@@ -1190,14 +1628,26 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.handleMutex.RUnlock()
 	fake.initializeArtifactMutex.RLock()
 	defer fake.initializeArtifactMutex.RUnlock()
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
+	fake.initializeStepOutputMutex.RLock()
+	defer fake.initializeStepOutputMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
+	fake.invalidateResourceCacheMutex.RLock()
+	defer fake.invalidateResourceCacheMutex.RUnlock()
+	fake.markResourceCacheVerifiedMutex.RLock()
+	defer fake.markResourceCacheVerifiedMutex.RUnlock()
 	fake.parentHandleMutex.RLock()
 	defer fake.parentHandleMutex.RUnlock()
 	fake.pathMutex.RLock()
 	defer fake.pathMutex.RUnlock()
+	fake.recordResourceCacheChecksumMutex.RLock()
+	defer fake.recordResourceCacheChecksumMutex.RUnlock()
+	fake.resourceCacheChecksumMutex.RLock()
+	defer fake.resourceCacheChecksumMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
 	fake.taskIdentifierMutex.RLock()
@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeCredentialUsageFactory struct {
+	AllCredentialUsagesStub        func(string) ([]atc.CredentialUsage, error)
+	allCredentialUsagesMutex       sync.RWMutex
+	allCredentialUsagesArgsForCall []struct {
+		arg1 string
+	}
+	allCredentialUsagesReturns struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
+	allCredentialUsagesReturnsOnCall map[int]struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
+	TeamCredentialUsagesStub        func(int, string) ([]atc.CredentialUsage, error)
+	teamCredentialUsagesMutex       sync.RWMutex
+	teamCredentialUsagesArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	teamCredentialUsagesReturns struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
+	teamCredentialUsagesReturnsOnCall map[int]struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCredentialUsageFactory) AllCredentialUsages(arg1 string) ([]atc.CredentialUsage, error) {
+	fake.allCredentialUsagesMutex.Lock()
+	ret, specificReturn := fake.allCredentialUsagesReturnsOnCall[len(fake.allCredentialUsagesArgsForCall)]
+	fake.allCredentialUsagesArgsForCall = append(fake.allCredentialUsagesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AllCredentialUsagesStub
+	fakeReturns := fake.allCredentialUsagesReturns
+	fake.recordInvocation("AllCredentialUsages", []interface{}{arg1})
+	fake.allCredentialUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCredentialUsageFactory) AllCredentialUsagesCallCount() int {
+	fake.allCredentialUsagesMutex.RLock()
+	defer fake.allCredentialUsagesMutex.RUnlock()
+	return len(fake.allCredentialUsagesArgsForCall)
+}
+
+func (fake *FakeCredentialUsageFactory) AllCredentialUsagesCalls(stub func(string) ([]atc.CredentialUsage, error)) {
+	fake.allCredentialUsagesMutex.Lock()
+	defer fake.allCredentialUsagesMutex.Unlock()
+	fake.AllCredentialUsagesStub = stub
+}
+
+func (fake *FakeCredentialUsageFactory) AllCredentialUsagesArgsForCall(i int) string {
+	fake.allCredentialUsagesMutex.RLock()
+	defer fake.allCredentialUsagesMutex.RUnlock()
+	argsForCall := fake.allCredentialUsagesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCredentialUsageFactory) AllCredentialUsagesReturns(result1 []atc.CredentialUsage, result2 error) {
+	fake.allCredentialUsagesMutex.Lock()
+	defer fake.allCredentialUsagesMutex.Unlock()
+	fake.AllCredentialUsagesStub = nil
+	fake.allCredentialUsagesReturns = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCredentialUsageFactory) AllCredentialUsagesReturnsOnCall(i int, result1 []atc.CredentialUsage, result2 error) {
+	fake.allCredentialUsagesMutex.Lock()
+	defer fake.allCredentialUsagesMutex.Unlock()
+	fake.AllCredentialUsagesStub = nil
+	if fake.allCredentialUsagesReturnsOnCall == nil {
+		fake.allCredentialUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.CredentialUsage
+			result2 error
+		})
+	}
+	fake.allCredentialUsagesReturnsOnCall[i] = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCredentialUsageFactory) TeamCredentialUsages(arg1 int, arg2 string) ([]atc.CredentialUsage, error) {
+	fake.teamCredentialUsagesMutex.Lock()
+	ret, specificReturn := fake.teamCredentialUsagesReturnsOnCall[len(fake.teamCredentialUsagesArgsForCall)]
+	fake.teamCredentialUsagesArgsForCall = append(fake.teamCredentialUsagesArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.TeamCredentialUsagesStub
+	fakeReturns := fake.teamCredentialUsagesReturns
+	fake.recordInvocation("TeamCredentialUsages", []interface{}{arg1, arg2})
+	fake.teamCredentialUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCredentialUsageFactory) TeamCredentialUsagesCallCount() int {
+	fake.teamCredentialUsagesMutex.RLock()
+	defer fake.teamCredentialUsagesMutex.RUnlock()
+	return len(fake.teamCredentialUsagesArgsForCall)
+}
+
+func (fake *FakeCredentialUsageFactory) TeamCredentialUsagesCalls(stub func(int, string) ([]atc.CredentialUsage, error)) {
+	fake.teamCredentialUsagesMutex.Lock()
+	defer fake.teamCredentialUsagesMutex.Unlock()
+	fake.TeamCredentialUsagesStub = stub
+}
+
+func (fake *FakeCredentialUsageFactory) TeamCredentialUsagesArgsForCall(i int) (int, string) {
+	fake.teamCredentialUsagesMutex.RLock()
+	defer fake.teamCredentialUsagesMutex.RUnlock()
+	argsForCall := fake.teamCredentialUsagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCredentialUsageFactory) TeamCredentialUsagesReturns(result1 []atc.CredentialUsage, result2 error) {
+	fake.teamCredentialUsagesMutex.Lock()
+	defer fake.teamCredentialUsagesMutex.Unlock()
+	fake.TeamCredentialUsagesStub = nil
+	fake.teamCredentialUsagesReturns = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCredentialUsageFactory) TeamCredentialUsagesReturnsOnCall(i int, result1 []atc.CredentialUsage, result2 error) {
+	fake.teamCredentialUsagesMutex.Lock()
+	defer fake.teamCredentialUsagesMutex.Unlock()
+	fake.TeamCredentialUsagesStub = nil
+	if fake.teamCredentialUsagesReturnsOnCall == nil {
+		fake.teamCredentialUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.CredentialUsage
+			result2 error
+		})
+	}
+	fake.teamCredentialUsagesReturnsOnCall[i] = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCredentialUsageFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allCredentialUsagesMutex.RLock()
+	defer fake.allCredentialUsagesMutex.RUnlock()
+	fake.teamCredentialUsagesMutex.RLock()
+	defer fake.teamCredentialUsagesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCredentialUsageFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.CredentialUsageFactory = new(FakeCredentialUsageFactory)
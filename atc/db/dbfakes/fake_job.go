@@ -85,6 +85,19 @@ type FakeJob struct {
 		result2 db.Pagination
 		result3 error
 	}
+	ClearKeyedCachesStub        func(string) (int64, error)
+	clearKeyedCachesMutex       sync.RWMutex
+	clearKeyedCachesArgsForCall []struct {
+		arg1 string
+	}
+	clearKeyedCachesReturns struct {
+		result1 int64
+		result2 error
+	}
+	clearKeyedCachesReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	ClearTaskCacheStub        func(string, string) (int64, error)
 	clearTaskCacheMutex       sync.RWMutex
 	clearTaskCacheArgsForCall []struct {
@@ -239,6 +252,18 @@ type FakeJob struct {
 		result1 []atc.JobInput
 		result2 error
 	}
+	KeyedCachesStub        func() ([]db.KeyedCache, error)
+	keyedCachesMutex       sync.RWMutex
+	keyedCachesArgsForCall []struct {
+	}
+	keyedCachesReturns struct {
+		result1 []db.KeyedCache
+		result2 error
+	}
+	keyedCachesReturnsOnCall map[int]struct {
+		result1 []db.KeyedCache
+		result2 error
+	}
 	MaxInFlightStub        func() int
 	maxInFlightMutex       sync.RWMutex
 	maxInFlightArgsForCall []struct {
@@ -345,6 +370,23 @@ type FakeJob struct {
 	pipelineRefReturnsOnCall map[int]struct {
 		result1 atc.PipelineRef
 	}
+	PromoteVersionStub        func(db.Resource, int, string) (db.Build, bool, error)
+	promoteVersionMutex       sync.RWMutex
+	promoteVersionArgsForCall []struct {
+		arg1 db.Resource
+		arg2 int
+		arg3 string
+	}
+	promoteVersionReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	promoteVersionReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -827,6 +869,70 @@ func (fake *FakeJob) BuildsWithTimeReturnsOnCall(i int, result1 []db.Build, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) ClearKeyedCaches(arg1 string) (int64, error) {
+	fake.clearKeyedCachesMutex.Lock()
+	ret, specificReturn := fake.clearKeyedCachesReturnsOnCall[len(fake.clearKeyedCachesArgsForCall)]
+	fake.clearKeyedCachesArgsForCall = append(fake.clearKeyedCachesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ClearKeyedCachesStub
+	fakeReturns := fake.clearKeyedCachesReturns
+	fake.recordInvocation("ClearKeyedCaches", []interface{}{arg1})
+	fake.clearKeyedCachesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) ClearKeyedCachesCallCount() int {
+	fake.clearKeyedCachesMutex.RLock()
+	defer fake.clearKeyedCachesMutex.RUnlock()
+	return len(fake.clearKeyedCachesArgsForCall)
+}
+
+func (fake *FakeJob) ClearKeyedCachesCalls(stub func(string) (int64, error)) {
+	fake.clearKeyedCachesMutex.Lock()
+	defer fake.clearKeyedCachesMutex.Unlock()
+	fake.ClearKeyedCachesStub = stub
+}
+
+func (fake *FakeJob) ClearKeyedCachesArgsForCall(i int) string {
+	fake.clearKeyedCachesMutex.RLock()
+	defer fake.clearKeyedCachesMutex.RUnlock()
+	argsForCall := fake.clearKeyedCachesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) ClearKeyedCachesReturns(result1 int64, result2 error) {
+	fake.clearKeyedCachesMutex.Lock()
+	defer fake.clearKeyedCachesMutex.Unlock()
+	fake.ClearKeyedCachesStub = nil
+	fake.clearKeyedCachesReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) ClearKeyedCachesReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.clearKeyedCachesMutex.Lock()
+	defer fake.clearKeyedCachesMutex.Unlock()
+	fake.ClearKeyedCachesStub = nil
+	if fake.clearKeyedCachesReturnsOnCall == nil {
+		fake.clearKeyedCachesReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.clearKeyedCachesReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) ClearTaskCache(arg1 string, arg2 string) (int64, error) {
 	fake.clearTaskCacheMutex.Lock()
 	ret, specificReturn := fake.clearTaskCacheReturnsOnCall[len(fake.clearTaskCacheArgsForCall)]
@@ -1571,6 +1677,62 @@ func (fake *FakeJob) InputsReturnsOnCall(i int, result1 []atc.JobInput, result2
 	}{result1, result2}
 }
 
+func (fake *FakeJob) KeyedCaches() ([]db.KeyedCache, error) {
+	fake.keyedCachesMutex.Lock()
+	ret, specificReturn := fake.keyedCachesReturnsOnCall[len(fake.keyedCachesArgsForCall)]
+	fake.keyedCachesArgsForCall = append(fake.keyedCachesArgsForCall, struct {
+	}{})
+	stub := fake.KeyedCachesStub
+	fakeReturns := fake.keyedCachesReturns
+	fake.recordInvocation("KeyedCaches", []interface{}{})
+	fake.keyedCachesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) KeyedCachesCallCount() int {
+	fake.keyedCachesMutex.RLock()
+	defer fake.keyedCachesMutex.RUnlock()
+	return len(fake.keyedCachesArgsForCall)
+}
+
+func (fake *FakeJob) KeyedCachesCalls(stub func() ([]db.KeyedCache, error)) {
+	fake.keyedCachesMutex.Lock()
+	defer fake.keyedCachesMutex.Unlock()
+	fake.KeyedCachesStub = stub
+}
+
+func (fake *FakeJob) KeyedCachesReturns(result1 []db.KeyedCache, result2 error) {
+	fake.keyedCachesMutex.Lock()
+	defer fake.keyedCachesMutex.Unlock()
+	fake.KeyedCachesStub = nil
+	fake.keyedCachesReturns = struct {
+		result1 []db.KeyedCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) KeyedCachesReturnsOnCall(i int, result1 []db.KeyedCache, result2 error) {
+	fake.keyedCachesMutex.Lock()
+	defer fake.keyedCachesMutex.Unlock()
+	fake.KeyedCachesStub = nil
+	if fake.keyedCachesReturnsOnCall == nil {
+		fake.keyedCachesReturnsOnCall = make(map[int]struct {
+			result1 []db.KeyedCache
+			result2 error
+		})
+	}
+	fake.keyedCachesReturnsOnCall[i] = struct {
+		result1 []db.KeyedCache
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) MaxInFlight() int {
 	fake.maxInFlightMutex.Lock()
 	ret, specificReturn := fake.maxInFlightReturnsOnCall[len(fake.maxInFlightArgsForCall)]
@@ -2110,6 +2272,75 @@ func (fake *FakeJob) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
 	}{result1}
 }
 
+func (fake *FakeJob) PromoteVersion(arg1 db.Resource, arg2 int, arg3 string) (db.Build, bool, error) {
+	fake.promoteVersionMutex.Lock()
+	ret, specificReturn := fake.promoteVersionReturnsOnCall[len(fake.promoteVersionArgsForCall)]
+	fake.promoteVersionArgsForCall = append(fake.promoteVersionArgsForCall, struct {
+		arg1 db.Resource
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PromoteVersionStub
+	fakeReturns := fake.promoteVersionReturns
+	fake.recordInvocation("PromoteVersion", []interface{}{arg1, arg2, arg3})
+	fake.promoteVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) PromoteVersionCallCount() int {
+	fake.promoteVersionMutex.RLock()
+	defer fake.promoteVersionMutex.RUnlock()
+	return len(fake.promoteVersionArgsForCall)
+}
+
+func (fake *FakeJob) PromoteVersionCalls(stub func(db.Resource, int, string) (db.Build, bool, error)) {
+	fake.promoteVersionMutex.Lock()
+	defer fake.promoteVersionMutex.Unlock()
+	fake.PromoteVersionStub = stub
+}
+
+func (fake *FakeJob) PromoteVersionArgsForCall(i int) (db.Resource, int, string) {
+	fake.promoteVersionMutex.RLock()
+	defer fake.promoteVersionMutex.RUnlock()
+	argsForCall := fake.promoteVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeJob) PromoteVersionReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.promoteVersionMutex.Lock()
+	defer fake.promoteVersionMutex.Unlock()
+	fake.PromoteVersionStub = nil
+	fake.promoteVersionReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) PromoteVersionReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.promoteVersionMutex.Lock()
+	defer fake.promoteVersionMutex.Unlock()
+	fake.PromoteVersionStub = nil
+	if fake.promoteVersionReturnsOnCall == nil {
+		fake.promoteVersionReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.promoteVersionReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2924,6 +3155,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
 	defer fake.buildsWithTimeMutex.RUnlock()
+	fake.clearKeyedCachesMutex.RLock()
+	defer fake.clearKeyedCachesMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
 	fake.configMutex.RLock()
@@ -2950,6 +3183,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.inputsMutex.RLock()
 	defer fake.inputsMutex.RUnlock()
+	fake.keyedCachesMutex.RLock()
+	defer fake.keyedCachesMutex.RUnlock()
 	fake.maxInFlightMutex.RLock()
 	defer fake.maxInFlightMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -2970,6 +3205,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.pipelineRefMutex.RLock()
 	defer fake.pipelineRefMutex.RUnlock()
+	fake.promoteVersionMutex.RLock()
+	defer fake.promoteVersionMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -0,0 +1,196 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeKeyedCacheLifecycle struct {
+	RemoveKeyedCachesExceedingTeamSizeStub        func(int64) (int64, error)
+	removeKeyedCachesExceedingTeamSizeMutex       sync.RWMutex
+	removeKeyedCachesExceedingTeamSizeArgsForCall []struct {
+		arg1 int64
+	}
+	removeKeyedCachesExceedingTeamSizeReturns struct {
+		result1 int64
+		result2 error
+	}
+	removeKeyedCachesExceedingTeamSizeReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	RemoveUnusedKeyedCachesStub        func(time.Duration) (int64, error)
+	removeUnusedKeyedCachesMutex       sync.RWMutex
+	removeUnusedKeyedCachesArgsForCall []struct {
+		arg1 time.Duration
+	}
+	removeUnusedKeyedCachesReturns struct {
+		result1 int64
+		result2 error
+	}
+	removeUnusedKeyedCachesReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveKeyedCachesExceedingTeamSize(arg1 int64) (int64, error) {
+	fake.removeKeyedCachesExceedingTeamSizeMutex.Lock()
+	ret, specificReturn := fake.removeKeyedCachesExceedingTeamSizeReturnsOnCall[len(fake.removeKeyedCachesExceedingTeamSizeArgsForCall)]
+	fake.removeKeyedCachesExceedingTeamSizeArgsForCall = append(fake.removeKeyedCachesExceedingTeamSizeArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	stub := fake.RemoveKeyedCachesExceedingTeamSizeStub
+	fakeReturns := fake.removeKeyedCachesExceedingTeamSizeReturns
+	fake.recordInvocation("RemoveKeyedCachesExceedingTeamSize", []interface{}{arg1})
+	fake.removeKeyedCachesExceedingTeamSizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveKeyedCachesExceedingTeamSizeCallCount() int {
+	fake.removeKeyedCachesExceedingTeamSizeMutex.RLock()
+	defer fake.removeKeyedCachesExceedingTeamSizeMutex.RUnlock()
+	return len(fake.removeKeyedCachesExceedingTeamSizeArgsForCall)
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveKeyedCachesExceedingTeamSizeCalls(stub func(int64) (int64, error)) {
+	fake.removeKeyedCachesExceedingTeamSizeMutex.Lock()
+	defer fake.removeKeyedCachesExceedingTeamSizeMutex.Unlock()
+	fake.RemoveKeyedCachesExceedingTeamSizeStub = stub
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveKeyedCachesExceedingTeamSizeArgsForCall(i int) int64 {
+	fake.removeKeyedCachesExceedingTeamSizeMutex.RLock()
+	defer fake.removeKeyedCachesExceedingTeamSizeMutex.RUnlock()
+	argsForCall := fake.removeKeyedCachesExceedingTeamSizeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveKeyedCachesExceedingTeamSizeReturns(result1 int64, result2 error) {
+	fake.removeKeyedCachesExceedingTeamSizeMutex.Lock()
+	defer fake.removeKeyedCachesExceedingTeamSizeMutex.Unlock()
+	fake.RemoveKeyedCachesExceedingTeamSizeStub = nil
+	fake.removeKeyedCachesExceedingTeamSizeReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveKeyedCachesExceedingTeamSizeReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.removeKeyedCachesExceedingTeamSizeMutex.Lock()
+	defer fake.removeKeyedCachesExceedingTeamSizeMutex.Unlock()
+	fake.RemoveKeyedCachesExceedingTeamSizeStub = nil
+	if fake.removeKeyedCachesExceedingTeamSizeReturnsOnCall == nil {
+		fake.removeKeyedCachesExceedingTeamSizeReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.removeKeyedCachesExceedingTeamSizeReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveUnusedKeyedCaches(arg1 time.Duration) (int64, error) {
+	fake.removeUnusedKeyedCachesMutex.Lock()
+	ret, specificReturn := fake.removeUnusedKeyedCachesReturnsOnCall[len(fake.removeUnusedKeyedCachesArgsForCall)]
+	fake.removeUnusedKeyedCachesArgsForCall = append(fake.removeUnusedKeyedCachesArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.RemoveUnusedKeyedCachesStub
+	fakeReturns := fake.removeUnusedKeyedCachesReturns
+	fake.recordInvocation("RemoveUnusedKeyedCaches", []interface{}{arg1})
+	fake.removeUnusedKeyedCachesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveUnusedKeyedCachesCallCount() int {
+	fake.removeUnusedKeyedCachesMutex.RLock()
+	defer fake.removeUnusedKeyedCachesMutex.RUnlock()
+	return len(fake.removeUnusedKeyedCachesArgsForCall)
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveUnusedKeyedCachesCalls(stub func(time.Duration) (int64, error)) {
+	fake.removeUnusedKeyedCachesMutex.Lock()
+	defer fake.removeUnusedKeyedCachesMutex.Unlock()
+	fake.RemoveUnusedKeyedCachesStub = stub
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveUnusedKeyedCachesArgsForCall(i int) time.Duration {
+	fake.removeUnusedKeyedCachesMutex.RLock()
+	defer fake.removeUnusedKeyedCachesMutex.RUnlock()
+	argsForCall := fake.removeUnusedKeyedCachesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveUnusedKeyedCachesReturns(result1 int64, result2 error) {
+	fake.removeUnusedKeyedCachesMutex.Lock()
+	defer fake.removeUnusedKeyedCachesMutex.Unlock()
+	fake.RemoveUnusedKeyedCachesStub = nil
+	fake.removeUnusedKeyedCachesReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyedCacheLifecycle) RemoveUnusedKeyedCachesReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.removeUnusedKeyedCachesMutex.Lock()
+	defer fake.removeUnusedKeyedCachesMutex.Unlock()
+	fake.RemoveUnusedKeyedCachesStub = nil
+	if fake.removeUnusedKeyedCachesReturnsOnCall == nil {
+		fake.removeUnusedKeyedCachesReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.removeUnusedKeyedCachesReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyedCacheLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.removeKeyedCachesExceedingTeamSizeMutex.RLock()
+	defer fake.removeKeyedCachesExceedingTeamSizeMutex.RUnlock()
+	fake.removeUnusedKeyedCachesMutex.RLock()
+	defer fake.removeUnusedKeyedCachesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeKeyedCacheLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.KeyedCacheLifecycle = new(FakeKeyedCacheLifecycle)
@@ -0,0 +1,359 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakePrewarmedImageFactory struct {
+	FinishWarmingStub        func(int, bool) error
+	finishWarmingMutex       sync.RWMutex
+	finishWarmingArgsForCall []struct {
+		arg1 int
+		arg2 bool
+	}
+	finishWarmingReturns struct {
+		result1 error
+	}
+	finishWarmingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetResourceConfigScopeStub        func(int, int) error
+	setResourceConfigScopeMutex       sync.RWMutex
+	setResourceConfigScopeArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	setResourceConfigScopeReturns struct {
+		result1 error
+	}
+	setResourceConfigScopeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StartWarmingStub        func(int, string, time.Duration) (bool, error)
+	startWarmingMutex       sync.RWMutex
+	startWarmingArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 time.Duration
+	}
+	startWarmingReturns struct {
+		result1 bool
+		result2 error
+	}
+	startWarmingReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SyncStub        func(int, []db.PrewarmedImageRef) ([]db.PrewarmedImage, error)
+	syncMutex       sync.RWMutex
+	syncArgsForCall []struct {
+		arg1 int
+		arg2 []db.PrewarmedImageRef
+	}
+	syncReturns struct {
+		result1 []db.PrewarmedImage
+		result2 error
+	}
+	syncReturnsOnCall map[int]struct {
+		result1 []db.PrewarmedImage
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePrewarmedImageFactory) FinishWarming(arg1 int, arg2 bool) error {
+	fake.finishWarmingMutex.Lock()
+	ret, specificReturn := fake.finishWarmingReturnsOnCall[len(fake.finishWarmingArgsForCall)]
+	fake.finishWarmingArgsForCall = append(fake.finishWarmingArgsForCall, struct {
+		arg1 int
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.FinishWarmingStub
+	fakeReturns := fake.finishWarmingReturns
+	fake.recordInvocation("FinishWarming", []interface{}{arg1, arg2})
+	fake.finishWarmingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrewarmedImageFactory) FinishWarmingCallCount() int {
+	fake.finishWarmingMutex.RLock()
+	defer fake.finishWarmingMutex.RUnlock()
+	return len(fake.finishWarmingArgsForCall)
+}
+
+func (fake *FakePrewarmedImageFactory) FinishWarmingCalls(stub func(int, bool) error) {
+	fake.finishWarmingMutex.Lock()
+	defer fake.finishWarmingMutex.Unlock()
+	fake.FinishWarmingStub = stub
+}
+
+func (fake *FakePrewarmedImageFactory) FinishWarmingArgsForCall(i int) (int, bool) {
+	fake.finishWarmingMutex.RLock()
+	defer fake.finishWarmingMutex.RUnlock()
+	argsForCall := fake.finishWarmingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePrewarmedImageFactory) FinishWarmingReturns(result1 error) {
+	fake.finishWarmingMutex.Lock()
+	defer fake.finishWarmingMutex.Unlock()
+	fake.FinishWarmingStub = nil
+	fake.finishWarmingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePrewarmedImageFactory) FinishWarmingReturnsOnCall(i int, result1 error) {
+	fake.finishWarmingMutex.Lock()
+	defer fake.finishWarmingMutex.Unlock()
+	fake.FinishWarmingStub = nil
+	if fake.finishWarmingReturnsOnCall == nil {
+		fake.finishWarmingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishWarmingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePrewarmedImageFactory) SetResourceConfigScope(arg1 int, arg2 int) error {
+	fake.setResourceConfigScopeMutex.Lock()
+	ret, specificReturn := fake.setResourceConfigScopeReturnsOnCall[len(fake.setResourceConfigScopeArgsForCall)]
+	fake.setResourceConfigScopeArgsForCall = append(fake.setResourceConfigScopeArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.SetResourceConfigScopeStub
+	fakeReturns := fake.setResourceConfigScopeReturns
+	fake.recordInvocation("SetResourceConfigScope", []interface{}{arg1, arg2})
+	fake.setResourceConfigScopeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePrewarmedImageFactory) SetResourceConfigScopeCallCount() int {
+	fake.setResourceConfigScopeMutex.RLock()
+	defer fake.setResourceConfigScopeMutex.RUnlock()
+	return len(fake.setResourceConfigScopeArgsForCall)
+}
+
+func (fake *FakePrewarmedImageFactory) SetResourceConfigScopeCalls(stub func(int, int) error) {
+	fake.setResourceConfigScopeMutex.Lock()
+	defer fake.setResourceConfigScopeMutex.Unlock()
+	fake.SetResourceConfigScopeStub = stub
+}
+
+func (fake *FakePrewarmedImageFactory) SetResourceConfigScopeArgsForCall(i int) (int, int) {
+	fake.setResourceConfigScopeMutex.RLock()
+	defer fake.setResourceConfigScopeMutex.RUnlock()
+	argsForCall := fake.setResourceConfigScopeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePrewarmedImageFactory) SetResourceConfigScopeReturns(result1 error) {
+	fake.setResourceConfigScopeMutex.Lock()
+	defer fake.setResourceConfigScopeMutex.Unlock()
+	fake.SetResourceConfigScopeStub = nil
+	fake.setResourceConfigScopeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePrewarmedImageFactory) SetResourceConfigScopeReturnsOnCall(i int, result1 error) {
+	fake.setResourceConfigScopeMutex.Lock()
+	defer fake.setResourceConfigScopeMutex.Unlock()
+	fake.SetResourceConfigScopeStub = nil
+	if fake.setResourceConfigScopeReturnsOnCall == nil {
+		fake.setResourceConfigScopeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setResourceConfigScopeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePrewarmedImageFactory) StartWarming(arg1 int, arg2 string, arg3 time.Duration) (bool, error) {
+	fake.startWarmingMutex.Lock()
+	ret, specificReturn := fake.startWarmingReturnsOnCall[len(fake.startWarmingArgsForCall)]
+	fake.startWarmingArgsForCall = append(fake.startWarmingArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.StartWarmingStub
+	fakeReturns := fake.startWarmingReturns
+	fake.recordInvocation("StartWarming", []interface{}{arg1, arg2, arg3})
+	fake.startWarmingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePrewarmedImageFactory) StartWarmingCallCount() int {
+	fake.startWarmingMutex.RLock()
+	defer fake.startWarmingMutex.RUnlock()
+	return len(fake.startWarmingArgsForCall)
+}
+
+func (fake *FakePrewarmedImageFactory) StartWarmingCalls(stub func(int, string, time.Duration) (bool, error)) {
+	fake.startWarmingMutex.Lock()
+	defer fake.startWarmingMutex.Unlock()
+	fake.StartWarmingStub = stub
+}
+
+func (fake *FakePrewarmedImageFactory) StartWarmingArgsForCall(i int) (int, string, time.Duration) {
+	fake.startWarmingMutex.RLock()
+	defer fake.startWarmingMutex.RUnlock()
+	argsForCall := fake.startWarmingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePrewarmedImageFactory) StartWarmingReturns(result1 bool, result2 error) {
+	fake.startWarmingMutex.Lock()
+	defer fake.startWarmingMutex.Unlock()
+	fake.StartWarmingStub = nil
+	fake.startWarmingReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrewarmedImageFactory) StartWarmingReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.startWarmingMutex.Lock()
+	defer fake.startWarmingMutex.Unlock()
+	fake.StartWarmingStub = nil
+	if fake.startWarmingReturnsOnCall == nil {
+		fake.startWarmingReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.startWarmingReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrewarmedImageFactory) Sync(arg1 int, arg2 []db.PrewarmedImageRef) ([]db.PrewarmedImage, error) {
+	var arg2Copy []db.PrewarmedImageRef
+	if arg2 != nil {
+		arg2Copy = make([]db.PrewarmedImageRef, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.syncMutex.Lock()
+	ret, specificReturn := fake.syncReturnsOnCall[len(fake.syncArgsForCall)]
+	fake.syncArgsForCall = append(fake.syncArgsForCall, struct {
+		arg1 int
+		arg2 []db.PrewarmedImageRef
+	}{arg1, arg2Copy})
+	stub := fake.SyncStub
+	fakeReturns := fake.syncReturns
+	fake.recordInvocation("Sync", []interface{}{arg1, arg2Copy})
+	fake.syncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePrewarmedImageFactory) SyncCallCount() int {
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	return len(fake.syncArgsForCall)
+}
+
+func (fake *FakePrewarmedImageFactory) SyncCalls(stub func(int, []db.PrewarmedImageRef) ([]db.PrewarmedImage, error)) {
+	fake.syncMutex.Lock()
+	defer fake.syncMutex.Unlock()
+	fake.SyncStub = stub
+}
+
+func (fake *FakePrewarmedImageFactory) SyncArgsForCall(i int) (int, []db.PrewarmedImageRef) {
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	argsForCall := fake.syncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePrewarmedImageFactory) SyncReturns(result1 []db.PrewarmedImage, result2 error) {
+	fake.syncMutex.Lock()
+	defer fake.syncMutex.Unlock()
+	fake.SyncStub = nil
+	fake.syncReturns = struct {
+		result1 []db.PrewarmedImage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrewarmedImageFactory) SyncReturnsOnCall(i int, result1 []db.PrewarmedImage, result2 error) {
+	fake.syncMutex.Lock()
+	defer fake.syncMutex.Unlock()
+	fake.SyncStub = nil
+	if fake.syncReturnsOnCall == nil {
+		fake.syncReturnsOnCall = make(map[int]struct {
+			result1 []db.PrewarmedImage
+			result2 error
+		})
+	}
+	fake.syncReturnsOnCall[i] = struct {
+		result1 []db.PrewarmedImage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePrewarmedImageFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.finishWarmingMutex.RLock()
+	defer fake.finishWarmingMutex.RUnlock()
+	fake.setResourceConfigScopeMutex.RLock()
+	defer fake.setResourceConfigScopeMutex.RUnlock()
+	fake.startWarmingMutex.RLock()
+	defer fake.startWarmingMutex.RUnlock()
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePrewarmedImageFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.PrewarmedImageFactory = new(FakePrewarmedImageFactory)
@@ -41,6 +41,18 @@ type FakeResource struct {
 	checkEveryReturnsOnCall map[int]struct {
 		result1 *atc.CheckEvery
 	}
+	CheckHistoryStub        func() ([]db.ResourceCheck, error)
+	checkHistoryMutex       sync.RWMutex
+	checkHistoryArgsForCall []struct {
+	}
+	checkHistoryReturns struct {
+		result1 []db.ResourceCheck
+		result2 error
+	}
+	checkHistoryReturnsOnCall map[int]struct {
+		result1 []db.ResourceCheck
+		result2 error
+	}
 	CheckPlanStub        func(atc.Version, time.Duration, db.ResourceTypes, atc.Source) atc.CheckPlan
 	checkPlanMutex       sync.RWMutex
 	checkPlanArgsForCall []struct {
@@ -199,6 +211,16 @@ type FakeResource struct {
 	lastCheckStartTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastNewVersionTimeStub        func() time.Time
+	lastNewVersionTimeMutex       sync.RWMutex
+	lastNewVersionTimeArgsForCall []struct {
+	}
+	lastNewVersionTimeReturns struct {
+		result1 time.Time
+	}
+	lastNewVersionTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -229,10 +251,21 @@ type FakeResource struct {
 	pinCommentReturnsOnCall map[int]struct {
 		result1 string
 	}
-	PinVersionStub        func(int) (bool, error)
+	PinExpiresAtStub        func() time.Time
+	pinExpiresAtMutex       sync.RWMutex
+	pinExpiresAtArgsForCall []struct {
+	}
+	pinExpiresAtReturns struct {
+		result1 time.Time
+	}
+	pinExpiresAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	PinVersionStub        func(int, db.VersionPin) (bool, error)
 	pinVersionMutex       sync.RWMutex
 	pinVersionArgsForCall []struct {
 		arg1 int
+		arg2 db.VersionPin
 	}
 	pinVersionReturns struct {
 		result1 bool
@@ -242,6 +275,16 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	PinnedByStub        func() string
+	pinnedByMutex       sync.RWMutex
+	pinnedByArgsForCall []struct {
+	}
+	pinnedByReturns struct {
+		result1 string
+	}
+	pinnedByReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -410,6 +453,18 @@ type FakeResource struct {
 	typeReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnpinExpiredVersionStub        func() (bool, error)
+	unpinExpiredVersionMutex       sync.RWMutex
+	unpinExpiredVersionArgsForCall []struct {
+	}
+	unpinExpiredVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	unpinExpiredVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	UnpinVersionStub        func() error
 	unpinVersionMutex       sync.RWMutex
 	unpinVersionArgsForCall []struct {
@@ -625,6 +680,62 @@ func (fake *FakeResource) CheckEveryReturnsOnCall(i int, result1 *atc.CheckEvery
 	}{result1}
 }
 
+func (fake *FakeResource) CheckHistory() ([]db.ResourceCheck, error) {
+	fake.checkHistoryMutex.Lock()
+	ret, specificReturn := fake.checkHistoryReturnsOnCall[len(fake.checkHistoryArgsForCall)]
+	fake.checkHistoryArgsForCall = append(fake.checkHistoryArgsForCall, struct {
+	}{})
+	stub := fake.CheckHistoryStub
+	fakeReturns := fake.checkHistoryReturns
+	fake.recordInvocation("CheckHistory", []interface{}{})
+	fake.checkHistoryMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) CheckHistoryCallCount() int {
+	fake.checkHistoryMutex.RLock()
+	defer fake.checkHistoryMutex.RUnlock()
+	return len(fake.checkHistoryArgsForCall)
+}
+
+func (fake *FakeResource) CheckHistoryCalls(stub func() ([]db.ResourceCheck, error)) {
+	fake.checkHistoryMutex.Lock()
+	defer fake.checkHistoryMutex.Unlock()
+	fake.CheckHistoryStub = stub
+}
+
+func (fake *FakeResource) CheckHistoryReturns(result1 []db.ResourceCheck, result2 error) {
+	fake.checkHistoryMutex.Lock()
+	defer fake.checkHistoryMutex.Unlock()
+	fake.CheckHistoryStub = nil
+	fake.checkHistoryReturns = struct {
+		result1 []db.ResourceCheck
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) CheckHistoryReturnsOnCall(i int, result1 []db.ResourceCheck, result2 error) {
+	fake.checkHistoryMutex.Lock()
+	defer fake.checkHistoryMutex.Unlock()
+	fake.CheckHistoryStub = nil
+	if fake.checkHistoryReturnsOnCall == nil {
+		fake.checkHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceCheck
+			result2 error
+		})
+	}
+	fake.checkHistoryReturnsOnCall[i] = struct {
+		result1 []db.ResourceCheck
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) CheckPlan(arg1 atc.Version, arg2 time.Duration, arg3 db.ResourceTypes, arg4 atc.Source) atc.CheckPlan {
 	fake.checkPlanMutex.Lock()
 	ret, specificReturn := fake.checkPlanReturnsOnCall[len(fake.checkPlanArgsForCall)]
@@ -1424,6 +1535,59 @@ func (fake *FakeResource) LastCheckStartTimeReturnsOnCall(i int, result1 time.Ti
 	}{result1}
 }
 
+func (fake *FakeResource) LastNewVersionTime() time.Time {
+	fake.lastNewVersionTimeMutex.Lock()
+	ret, specificReturn := fake.lastNewVersionTimeReturnsOnCall[len(fake.lastNewVersionTimeArgsForCall)]
+	fake.lastNewVersionTimeArgsForCall = append(fake.lastNewVersionTimeArgsForCall, struct {
+	}{})
+	stub := fake.LastNewVersionTimeStub
+	fakeReturns := fake.lastNewVersionTimeReturns
+	fake.recordInvocation("LastNewVersionTime", []interface{}{})
+	fake.lastNewVersionTimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastNewVersionTimeCallCount() int {
+	fake.lastNewVersionTimeMutex.RLock()
+	defer fake.lastNewVersionTimeMutex.RUnlock()
+	return len(fake.lastNewVersionTimeArgsForCall)
+}
+
+func (fake *FakeResource) LastNewVersionTimeCalls(stub func() time.Time) {
+	fake.lastNewVersionTimeMutex.Lock()
+	defer fake.lastNewVersionTimeMutex.Unlock()
+	fake.LastNewVersionTimeStub = stub
+}
+
+func (fake *FakeResource) LastNewVersionTimeReturns(result1 time.Time) {
+	fake.lastNewVersionTimeMutex.Lock()
+	defer fake.lastNewVersionTimeMutex.Unlock()
+	fake.LastNewVersionTimeStub = nil
+	fake.lastNewVersionTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) LastNewVersionTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.lastNewVersionTimeMutex.Lock()
+	defer fake.lastNewVersionTimeMutex.Unlock()
+	fake.LastNewVersionTimeStub = nil
+	if fake.lastNewVersionTimeReturnsOnCall == nil {
+		fake.lastNewVersionTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastNewVersionTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeResource) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1583,18 +1747,72 @@ func (fake *FakeResource) PinCommentReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeResource) PinVersion(arg1 int) (bool, error) {
+func (fake *FakeResource) PinExpiresAt() time.Time {
+	fake.pinExpiresAtMutex.Lock()
+	ret, specificReturn := fake.pinExpiresAtReturnsOnCall[len(fake.pinExpiresAtArgsForCall)]
+	fake.pinExpiresAtArgsForCall = append(fake.pinExpiresAtArgsForCall, struct {
+	}{})
+	stub := fake.PinExpiresAtStub
+	fakeReturns := fake.pinExpiresAtReturns
+	fake.recordInvocation("PinExpiresAt", []interface{}{})
+	fake.pinExpiresAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PinExpiresAtCallCount() int {
+	fake.pinExpiresAtMutex.RLock()
+	defer fake.pinExpiresAtMutex.RUnlock()
+	return len(fake.pinExpiresAtArgsForCall)
+}
+
+func (fake *FakeResource) PinExpiresAtCalls(stub func() time.Time) {
+	fake.pinExpiresAtMutex.Lock()
+	defer fake.pinExpiresAtMutex.Unlock()
+	fake.PinExpiresAtStub = stub
+}
+
+func (fake *FakeResource) PinExpiresAtReturns(result1 time.Time) {
+	fake.pinExpiresAtMutex.Lock()
+	defer fake.pinExpiresAtMutex.Unlock()
+	fake.PinExpiresAtStub = nil
+	fake.pinExpiresAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) PinExpiresAtReturnsOnCall(i int, result1 time.Time) {
+	fake.pinExpiresAtMutex.Lock()
+	defer fake.pinExpiresAtMutex.Unlock()
+	fake.PinExpiresAtStub = nil
+	if fake.pinExpiresAtReturnsOnCall == nil {
+		fake.pinExpiresAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.pinExpiresAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) PinVersion(arg1 int, arg2 db.VersionPin) (bool, error) {
 	fake.pinVersionMutex.Lock()
 	ret, specificReturn := fake.pinVersionReturnsOnCall[len(fake.pinVersionArgsForCall)]
 	fake.pinVersionArgsForCall = append(fake.pinVersionArgsForCall, struct {
 		arg1 int
-	}{arg1})
+		arg2 db.VersionPin
+	}{arg1, arg2})
 	stub := fake.PinVersionStub
 	fakeReturns := fake.pinVersionReturns
-	fake.recordInvocation("PinVersion", []interface{}{arg1})
+	fake.recordInvocation("PinVersion", []interface{}{arg1, arg2})
 	fake.pinVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1608,17 +1826,17 @@ func (fake *FakeResource) PinVersionCallCount() int {
 	return len(fake.pinVersionArgsForCall)
 }
 
-func (fake *FakeResource) PinVersionCalls(stub func(int) (bool, error)) {
+func (fake *FakeResource) PinVersionCalls(stub func(int, db.VersionPin) (bool, error)) {
 	fake.pinVersionMutex.Lock()
 	defer fake.pinVersionMutex.Unlock()
 	fake.PinVersionStub = stub
 }
 
-func (fake *FakeResource) PinVersionArgsForCall(i int) int {
+func (fake *FakeResource) PinVersionArgsForCall(i int) (int, db.VersionPin) {
 	fake.pinVersionMutex.RLock()
 	defer fake.pinVersionMutex.RUnlock()
 	argsForCall := fake.pinVersionArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeResource) PinVersionReturns(result1 bool, result2 error) {
@@ -1647,6 +1865,59 @@ func (fake *FakeResource) PinVersionReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeResource) PinnedBy() string {
+	fake.pinnedByMutex.Lock()
+	ret, specificReturn := fake.pinnedByReturnsOnCall[len(fake.pinnedByArgsForCall)]
+	fake.pinnedByArgsForCall = append(fake.pinnedByArgsForCall, struct {
+	}{})
+	stub := fake.PinnedByStub
+	fakeReturns := fake.pinnedByReturns
+	fake.recordInvocation("PinnedBy", []interface{}{})
+	fake.pinnedByMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PinnedByCallCount() int {
+	fake.pinnedByMutex.RLock()
+	defer fake.pinnedByMutex.RUnlock()
+	return len(fake.pinnedByArgsForCall)
+}
+
+func (fake *FakeResource) PinnedByCalls(stub func() string) {
+	fake.pinnedByMutex.Lock()
+	defer fake.pinnedByMutex.Unlock()
+	fake.PinnedByStub = stub
+}
+
+func (fake *FakeResource) PinnedByReturns(result1 string) {
+	fake.pinnedByMutex.Lock()
+	defer fake.pinnedByMutex.Unlock()
+	fake.PinnedByStub = nil
+	fake.pinnedByReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedByReturnsOnCall(i int, result1 string) {
+	fake.pinnedByMutex.Lock()
+	defer fake.pinnedByMutex.Unlock()
+	fake.PinnedByStub = nil
+	if fake.pinnedByReturnsOnCall == nil {
+		fake.pinnedByReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pinnedByReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResource) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2520,6 +2791,62 @@ func (fake *FakeResource) TypeReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) UnpinExpiredVersion() (bool, error) {
+	fake.unpinExpiredVersionMutex.Lock()
+	ret, specificReturn := fake.unpinExpiredVersionReturnsOnCall[len(fake.unpinExpiredVersionArgsForCall)]
+	fake.unpinExpiredVersionArgsForCall = append(fake.unpinExpiredVersionArgsForCall, struct {
+	}{})
+	stub := fake.UnpinExpiredVersionStub
+	fakeReturns := fake.unpinExpiredVersionReturns
+	fake.recordInvocation("UnpinExpiredVersion", []interface{}{})
+	fake.unpinExpiredVersionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) UnpinExpiredVersionCallCount() int {
+	fake.unpinExpiredVersionMutex.RLock()
+	defer fake.unpinExpiredVersionMutex.RUnlock()
+	return len(fake.unpinExpiredVersionArgsForCall)
+}
+
+func (fake *FakeResource) UnpinExpiredVersionCalls(stub func() (bool, error)) {
+	fake.unpinExpiredVersionMutex.Lock()
+	defer fake.unpinExpiredVersionMutex.Unlock()
+	fake.UnpinExpiredVersionStub = stub
+}
+
+func (fake *FakeResource) UnpinExpiredVersionReturns(result1 bool, result2 error) {
+	fake.unpinExpiredVersionMutex.Lock()
+	defer fake.unpinExpiredVersionMutex.Unlock()
+	fake.UnpinExpiredVersionStub = nil
+	fake.unpinExpiredVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) UnpinExpiredVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.unpinExpiredVersionMutex.Lock()
+	defer fake.unpinExpiredVersionMutex.Unlock()
+	fake.UnpinExpiredVersionStub = nil
+	if fake.unpinExpiredVersionReturnsOnCall == nil {
+		fake.unpinExpiredVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.unpinExpiredVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) UnpinVersion() error {
 	fake.unpinVersionMutex.Lock()
 	ret, specificReturn := fake.unpinVersionReturnsOnCall[len(fake.unpinVersionArgsForCall)]
@@ -2771,6 +3098,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.buildSummaryMutex.RUnlock()
 	fake.checkEveryMutex.RLock()
 	defer fake.checkEveryMutex.RUnlock()
+	fake.checkHistoryMutex.RLock()
+	defer fake.checkHistoryMutex.RUnlock()
 	fake.checkPlanMutex.RLock()
 	defer fake.checkPlanMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
@@ -2799,14 +3128,20 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.lastCheckEndTimeMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
+	fake.lastNewVersionTimeMutex.RLock()
+	defer fake.lastNewVersionTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
 	defer fake.notifyScanMutex.RUnlock()
 	fake.pinCommentMutex.RLock()
 	defer fake.pinCommentMutex.RUnlock()
+	fake.pinExpiresAtMutex.RLock()
+	defer fake.pinExpiresAtMutex.RUnlock()
 	fake.pinVersionMutex.RLock()
 	defer fake.pinVersionMutex.RUnlock()
+	fake.pinnedByMutex.RLock()
+	defer fake.pinnedByMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -2839,6 +3174,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.typeMutex.RLock()
 	defer fake.typeMutex.RUnlock()
+	fake.unpinExpiredVersionMutex.RLock()
+	defer fake.unpinExpiredVersionMutex.RUnlock()
 	fake.unpinVersionMutex.RLock()
 	defer fake.unpinVersionMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
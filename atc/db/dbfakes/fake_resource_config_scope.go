@@ -98,17 +98,19 @@ type FakeResourceConfigScope struct {
 	resourceConfigReturnsOnCall map[int]struct {
 		result1 db.ResourceConfig
 	}
-	SaveVersionsStub        func(db.SpanContext, []atc.Version) error
+	SaveVersionsStub        func(db.SpanContext, []atc.Version) (int, error)
 	saveVersionsMutex       sync.RWMutex
 	saveVersionsArgsForCall []struct {
 		arg1 db.SpanContext
 		arg2 []atc.Version
 	}
 	saveVersionsReturns struct {
-		result1 error
+		result1 int
+		result2 error
 	}
 	saveVersionsReturnsOnCall map[int]struct {
-		result1 error
+		result1 int
+		result2 error
 	}
 	UpdateLastCheckEndTimeStub        func() (bool, error)
 	updateLastCheckEndTimeMutex       sync.RWMutex
@@ -546,7 +548,7 @@ func (fake *FakeResourceConfigScope) ResourceConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeResourceConfigScope) SaveVersions(arg1 db.SpanContext, arg2 []atc.Version) error {
+func (fake *FakeResourceConfigScope) SaveVersions(arg1 db.SpanContext, arg2 []atc.Version) (int, error) {
 	var arg2Copy []atc.Version
 	if arg2 != nil {
 		arg2Copy = make([]atc.Version, len(arg2))
@@ -566,9 +568,9 @@ func (fake *FakeResourceConfigScope) SaveVersions(arg1 db.SpanContext, arg2 []at
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeResourceConfigScope) SaveVersionsCallCount() int {
@@ -577,7 +579,7 @@ func (fake *FakeResourceConfigScope) SaveVersionsCallCount() int {
 	return len(fake.saveVersionsArgsForCall)
 }
 
-func (fake *FakeResourceConfigScope) SaveVersionsCalls(stub func(db.SpanContext, []atc.Version) error) {
+func (fake *FakeResourceConfigScope) SaveVersionsCalls(stub func(db.SpanContext, []atc.Version) (int, error)) {
 	fake.saveVersionsMutex.Lock()
 	defer fake.saveVersionsMutex.Unlock()
 	fake.SaveVersionsStub = stub
@@ -590,27 +592,30 @@ func (fake *FakeResourceConfigScope) SaveVersionsArgsForCall(i int) (db.SpanCont
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeResourceConfigScope) SaveVersionsReturns(result1 error) {
+func (fake *FakeResourceConfigScope) SaveVersionsReturns(result1 int, result2 error) {
 	fake.saveVersionsMutex.Lock()
 	defer fake.saveVersionsMutex.Unlock()
 	fake.SaveVersionsStub = nil
 	fake.saveVersionsReturns = struct {
-		result1 error
-	}{result1}
+		result1 int
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeResourceConfigScope) SaveVersionsReturnsOnCall(i int, result1 error) {
+func (fake *FakeResourceConfigScope) SaveVersionsReturnsOnCall(i int, result1 int, result2 error) {
 	fake.saveVersionsMutex.Lock()
 	defer fake.saveVersionsMutex.Unlock()
 	fake.SaveVersionsStub = nil
 	if fake.saveVersionsReturnsOnCall == nil {
 		fake.saveVersionsReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 int
+			result2 error
 		})
 	}
 	fake.saveVersionsReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 int
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeResourceConfigScope) UpdateLastCheckEndTime() (bool, error) {
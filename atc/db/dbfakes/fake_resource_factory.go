@@ -35,6 +35,18 @@ type FakeResourceFactory struct {
 		result2 bool
 		result3 error
 	}
+	ResourcesWithExpiredPinsStub        func() ([]db.Resource, error)
+	resourcesWithExpiredPinsMutex       sync.RWMutex
+	resourcesWithExpiredPinsArgsForCall []struct {
+	}
+	resourcesWithExpiredPinsReturns struct {
+		result1 []db.Resource
+		result2 error
+	}
+	resourcesWithExpiredPinsReturnsOnCall map[int]struct {
+		result1 []db.Resource
+		result2 error
+	}
 	VisibleResourcesStub        func([]string) ([]db.Resource, error)
 	visibleResourcesMutex       sync.RWMutex
 	visibleResourcesArgsForCall []struct {
@@ -175,6 +187,62 @@ func (fake *FakeResourceFactory) ResourceReturnsOnCall(i int, result1 db.Resourc
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceFactory) ResourcesWithExpiredPins() ([]db.Resource, error) {
+	fake.resourcesWithExpiredPinsMutex.Lock()
+	ret, specificReturn := fake.resourcesWithExpiredPinsReturnsOnCall[len(fake.resourcesWithExpiredPinsArgsForCall)]
+	fake.resourcesWithExpiredPinsArgsForCall = append(fake.resourcesWithExpiredPinsArgsForCall, struct {
+	}{})
+	stub := fake.ResourcesWithExpiredPinsStub
+	fakeReturns := fake.resourcesWithExpiredPinsReturns
+	fake.recordInvocation("ResourcesWithExpiredPins", []interface{}{})
+	fake.resourcesWithExpiredPinsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceFactory) ResourcesWithExpiredPinsCallCount() int {
+	fake.resourcesWithExpiredPinsMutex.RLock()
+	defer fake.resourcesWithExpiredPinsMutex.RUnlock()
+	return len(fake.resourcesWithExpiredPinsArgsForCall)
+}
+
+func (fake *FakeResourceFactory) ResourcesWithExpiredPinsCalls(stub func() ([]db.Resource, error)) {
+	fake.resourcesWithExpiredPinsMutex.Lock()
+	defer fake.resourcesWithExpiredPinsMutex.Unlock()
+	fake.ResourcesWithExpiredPinsStub = stub
+}
+
+func (fake *FakeResourceFactory) ResourcesWithExpiredPinsReturns(result1 []db.Resource, result2 error) {
+	fake.resourcesWithExpiredPinsMutex.Lock()
+	defer fake.resourcesWithExpiredPinsMutex.Unlock()
+	fake.ResourcesWithExpiredPinsStub = nil
+	fake.resourcesWithExpiredPinsReturns = struct {
+		result1 []db.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceFactory) ResourcesWithExpiredPinsReturnsOnCall(i int, result1 []db.Resource, result2 error) {
+	fake.resourcesWithExpiredPinsMutex.Lock()
+	defer fake.resourcesWithExpiredPinsMutex.Unlock()
+	fake.ResourcesWithExpiredPinsStub = nil
+	if fake.resourcesWithExpiredPinsReturnsOnCall == nil {
+		fake.resourcesWithExpiredPinsReturnsOnCall = make(map[int]struct {
+			result1 []db.Resource
+			result2 error
+		})
+	}
+	fake.resourcesWithExpiredPinsReturnsOnCall[i] = struct {
+		result1 []db.Resource
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceFactory) VisibleResources(arg1 []string) ([]db.Resource, error) {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -251,6 +319,8 @@ func (fake *FakeResourceFactory) Invocations() map[string][][]interface{} {
 	defer fake.allResourcesMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
+	fake.resourcesWithExpiredPinsMutex.RLock()
+	defer fake.resourcesWithExpiredPinsMutex.RUnlock()
 	fake.visibleResourcesMutex.RLock()
 	defer fake.visibleResourcesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,183 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretLeaseLifecycle struct {
+	OrphanedSecretLeasesStub        func() ([]db.SecretLease, error)
+	orphanedSecretLeasesMutex       sync.RWMutex
+	orphanedSecretLeasesArgsForCall []struct {
+	}
+	orphanedSecretLeasesReturns struct {
+		result1 []db.SecretLease
+		result2 error
+	}
+	orphanedSecretLeasesReturnsOnCall map[int]struct {
+		result1 []db.SecretLease
+		result2 error
+	}
+	RemoveSecretLeaseStub        func(int, string) error
+	removeSecretLeaseMutex       sync.RWMutex
+	removeSecretLeaseArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	removeSecretLeaseReturns struct {
+		result1 error
+	}
+	removeSecretLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretLeaseLifecycle) OrphanedSecretLeases() ([]db.SecretLease, error) {
+	fake.orphanedSecretLeasesMutex.Lock()
+	ret, specificReturn := fake.orphanedSecretLeasesReturnsOnCall[len(fake.orphanedSecretLeasesArgsForCall)]
+	fake.orphanedSecretLeasesArgsForCall = append(fake.orphanedSecretLeasesArgsForCall, struct {
+	}{})
+	stub := fake.OrphanedSecretLeasesStub
+	fakeReturns := fake.orphanedSecretLeasesReturns
+	fake.recordInvocation("OrphanedSecretLeases", []interface{}{})
+	fake.orphanedSecretLeasesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretLeaseLifecycle) OrphanedSecretLeasesCallCount() int {
+	fake.orphanedSecretLeasesMutex.RLock()
+	defer fake.orphanedSecretLeasesMutex.RUnlock()
+	return len(fake.orphanedSecretLeasesArgsForCall)
+}
+
+func (fake *FakeSecretLeaseLifecycle) OrphanedSecretLeasesCalls(stub func() ([]db.SecretLease, error)) {
+	fake.orphanedSecretLeasesMutex.Lock()
+	defer fake.orphanedSecretLeasesMutex.Unlock()
+	fake.OrphanedSecretLeasesStub = stub
+}
+
+func (fake *FakeSecretLeaseLifecycle) OrphanedSecretLeasesReturns(result1 []db.SecretLease, result2 error) {
+	fake.orphanedSecretLeasesMutex.Lock()
+	defer fake.orphanedSecretLeasesMutex.Unlock()
+	fake.OrphanedSecretLeasesStub = nil
+	fake.orphanedSecretLeasesReturns = struct {
+		result1 []db.SecretLease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretLeaseLifecycle) OrphanedSecretLeasesReturnsOnCall(i int, result1 []db.SecretLease, result2 error) {
+	fake.orphanedSecretLeasesMutex.Lock()
+	defer fake.orphanedSecretLeasesMutex.Unlock()
+	fake.OrphanedSecretLeasesStub = nil
+	if fake.orphanedSecretLeasesReturnsOnCall == nil {
+		fake.orphanedSecretLeasesReturnsOnCall = make(map[int]struct {
+			result1 []db.SecretLease
+			result2 error
+		})
+	}
+	fake.orphanedSecretLeasesReturnsOnCall[i] = struct {
+		result1 []db.SecretLease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretLeaseLifecycle) RemoveSecretLease(arg1 int, arg2 string) error {
+	fake.removeSecretLeaseMutex.Lock()
+	ret, specificReturn := fake.removeSecretLeaseReturnsOnCall[len(fake.removeSecretLeaseArgsForCall)]
+	fake.removeSecretLeaseArgsForCall = append(fake.removeSecretLeaseArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RemoveSecretLeaseStub
+	fakeReturns := fake.removeSecretLeaseReturns
+	fake.recordInvocation("RemoveSecretLease", []interface{}{arg1, arg2})
+	fake.removeSecretLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretLeaseLifecycle) RemoveSecretLeaseCallCount() int {
+	fake.removeSecretLeaseMutex.RLock()
+	defer fake.removeSecretLeaseMutex.RUnlock()
+	return len(fake.removeSecretLeaseArgsForCall)
+}
+
+func (fake *FakeSecretLeaseLifecycle) RemoveSecretLeaseCalls(stub func(int, string) error) {
+	fake.removeSecretLeaseMutex.Lock()
+	defer fake.removeSecretLeaseMutex.Unlock()
+	fake.RemoveSecretLeaseStub = stub
+}
+
+func (fake *FakeSecretLeaseLifecycle) RemoveSecretLeaseArgsForCall(i int) (int, string) {
+	fake.removeSecretLeaseMutex.RLock()
+	defer fake.removeSecretLeaseMutex.RUnlock()
+	argsForCall := fake.removeSecretLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSecretLeaseLifecycle) RemoveSecretLeaseReturns(result1 error) {
+	fake.removeSecretLeaseMutex.Lock()
+	defer fake.removeSecretLeaseMutex.Unlock()
+	fake.RemoveSecretLeaseStub = nil
+	fake.removeSecretLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretLeaseLifecycle) RemoveSecretLeaseReturnsOnCall(i int, result1 error) {
+	fake.removeSecretLeaseMutex.Lock()
+	defer fake.removeSecretLeaseMutex.Unlock()
+	fake.RemoveSecretLeaseStub = nil
+	if fake.removeSecretLeaseReturnsOnCall == nil {
+		fake.removeSecretLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeSecretLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretLeaseLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.orphanedSecretLeasesMutex.RLock()
+	defer fake.orphanedSecretLeasesMutex.RUnlock()
+	fake.removeSecretLeaseMutex.RLock()
+	defer fake.removeSecretLeaseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretLeaseLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretLeaseLifecycle = new(FakeSecretLeaseLifecycle)
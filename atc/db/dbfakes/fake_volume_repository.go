@@ -155,6 +155,22 @@ type FakeVolumeRepository struct {
 		result2 bool
 		result3 error
 	}
+	FindResourceCacheBaseVolumeStub        func(string, db.UsedResourceCache) (db.CreatedVolume, bool, error)
+	findResourceCacheBaseVolumeMutex       sync.RWMutex
+	findResourceCacheBaseVolumeArgsForCall []struct {
+		arg1 string
+		arg2 db.UsedResourceCache
+	}
+	findResourceCacheBaseVolumeReturns struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
+	findResourceCacheBaseVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
 	FindResourceCacheVolumeStub        func(string, db.UsedResourceCache) (db.CreatedVolume, bool, error)
 	findResourceCacheVolumeMutex       sync.RWMutex
 	findResourceCacheVolumeArgsForCall []struct {
@@ -187,6 +203,23 @@ type FakeVolumeRepository struct {
 		result2 db.CreatedVolume
 		result3 error
 	}
+	FindTaskCacheBaseVolumeStub        func(string, int, string) (db.CreatedVolume, bool, error)
+	findTaskCacheBaseVolumeMutex       sync.RWMutex
+	findTaskCacheBaseVolumeArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 string
+	}
+	findTaskCacheBaseVolumeReturns struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
+	findTaskCacheBaseVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}
 	FindTaskCacheVolumeStub        func(int, string, db.UsedTaskCache) (db.CreatedVolume, bool, error)
 	findTaskCacheVolumeMutex       sync.RWMutex
 	findTaskCacheVolumeArgsForCall []struct {
@@ -242,6 +275,21 @@ type FakeVolumeRepository struct {
 		result1 []db.CreatedVolume
 		result2 error
 	}
+	GetResourceCacheVolumesToVerifyStub        func(string, time.Time, int) ([]db.CreatedVolume, error)
+	getResourceCacheVolumesToVerifyMutex       sync.RWMutex
+	getResourceCacheVolumesToVerifyArgsForCall []struct {
+		arg1 string
+		arg2 time.Time
+		arg3 int
+	}
+	getResourceCacheVolumesToVerifyReturns struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}
+	getResourceCacheVolumesToVerifyReturnsOnCall map[int]struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}
 	GetTeamVolumesStub        func(int) ([]db.CreatedVolume, error)
 	getTeamVolumesMutex       sync.RWMutex
 	getTeamVolumesArgsForCall []struct {
@@ -955,6 +1003,74 @@ func (fake *FakeVolumeRepository) FindCreatedVolumeReturnsOnCall(i int, result1
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeRepository) FindResourceCacheBaseVolume(arg1 string, arg2 db.UsedResourceCache) (db.CreatedVolume, bool, error) {
+	fake.findResourceCacheBaseVolumeMutex.Lock()
+	ret, specificReturn := fake.findResourceCacheBaseVolumeReturnsOnCall[len(fake.findResourceCacheBaseVolumeArgsForCall)]
+	fake.findResourceCacheBaseVolumeArgsForCall = append(fake.findResourceCacheBaseVolumeArgsForCall, struct {
+		arg1 string
+		arg2 db.UsedResourceCache
+	}{arg1, arg2})
+	stub := fake.FindResourceCacheBaseVolumeStub
+	fakeReturns := fake.findResourceCacheBaseVolumeReturns
+	fake.recordInvocation("FindResourceCacheBaseVolume", []interface{}{arg1, arg2})
+	fake.findResourceCacheBaseVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheBaseVolumeCallCount() int {
+	fake.findResourceCacheBaseVolumeMutex.RLock()
+	defer fake.findResourceCacheBaseVolumeMutex.RUnlock()
+	return len(fake.findResourceCacheBaseVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheBaseVolumeCalls(stub func(string, db.UsedResourceCache) (db.CreatedVolume, bool, error)) {
+	fake.findResourceCacheBaseVolumeMutex.Lock()
+	defer fake.findResourceCacheBaseVolumeMutex.Unlock()
+	fake.FindResourceCacheBaseVolumeStub = stub
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheBaseVolumeArgsForCall(i int) (string, db.UsedResourceCache) {
+	fake.findResourceCacheBaseVolumeMutex.RLock()
+	defer fake.findResourceCacheBaseVolumeMutex.RUnlock()
+	argsForCall := fake.findResourceCacheBaseVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheBaseVolumeReturns(result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findResourceCacheBaseVolumeMutex.Lock()
+	defer fake.findResourceCacheBaseVolumeMutex.Unlock()
+	fake.FindResourceCacheBaseVolumeStub = nil
+	fake.findResourceCacheBaseVolumeReturns = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) FindResourceCacheBaseVolumeReturnsOnCall(i int, result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findResourceCacheBaseVolumeMutex.Lock()
+	defer fake.findResourceCacheBaseVolumeMutex.Unlock()
+	fake.FindResourceCacheBaseVolumeStub = nil
+	if fake.findResourceCacheBaseVolumeReturnsOnCall == nil {
+		fake.findResourceCacheBaseVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatedVolume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findResourceCacheBaseVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeRepository) FindResourceCacheVolume(arg1 string, arg2 db.UsedResourceCache) (db.CreatedVolume, bool, error) {
 	fake.findResourceCacheVolumeMutex.Lock()
 	ret, specificReturn := fake.findResourceCacheVolumeReturnsOnCall[len(fake.findResourceCacheVolumeArgsForCall)]
@@ -1091,6 +1207,75 @@ func (fake *FakeVolumeRepository) FindResourceCertsVolumeReturnsOnCall(i int, re
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeRepository) FindTaskCacheBaseVolume(arg1 string, arg2 int, arg3 string) (db.CreatedVolume, bool, error) {
+	fake.findTaskCacheBaseVolumeMutex.Lock()
+	ret, specificReturn := fake.findTaskCacheBaseVolumeReturnsOnCall[len(fake.findTaskCacheBaseVolumeArgsForCall)]
+	fake.findTaskCacheBaseVolumeArgsForCall = append(fake.findTaskCacheBaseVolumeArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.FindTaskCacheBaseVolumeStub
+	fakeReturns := fake.findTaskCacheBaseVolumeReturns
+	fake.recordInvocation("FindTaskCacheBaseVolume", []interface{}{arg1, arg2, arg3})
+	fake.findTaskCacheBaseVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeRepository) FindTaskCacheBaseVolumeCallCount() int {
+	fake.findTaskCacheBaseVolumeMutex.RLock()
+	defer fake.findTaskCacheBaseVolumeMutex.RUnlock()
+	return len(fake.findTaskCacheBaseVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) FindTaskCacheBaseVolumeCalls(stub func(string, int, string) (db.CreatedVolume, bool, error)) {
+	fake.findTaskCacheBaseVolumeMutex.Lock()
+	defer fake.findTaskCacheBaseVolumeMutex.Unlock()
+	fake.FindTaskCacheBaseVolumeStub = stub
+}
+
+func (fake *FakeVolumeRepository) FindTaskCacheBaseVolumeArgsForCall(i int) (string, int, string) {
+	fake.findTaskCacheBaseVolumeMutex.RLock()
+	defer fake.findTaskCacheBaseVolumeMutex.RUnlock()
+	argsForCall := fake.findTaskCacheBaseVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolumeRepository) FindTaskCacheBaseVolumeReturns(result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findTaskCacheBaseVolumeMutex.Lock()
+	defer fake.findTaskCacheBaseVolumeMutex.Unlock()
+	fake.FindTaskCacheBaseVolumeStub = nil
+	fake.findTaskCacheBaseVolumeReturns = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeRepository) FindTaskCacheBaseVolumeReturnsOnCall(i int, result1 db.CreatedVolume, result2 bool, result3 error) {
+	fake.findTaskCacheBaseVolumeMutex.Lock()
+	defer fake.findTaskCacheBaseVolumeMutex.Unlock()
+	fake.FindTaskCacheBaseVolumeStub = nil
+	if fake.findTaskCacheBaseVolumeReturnsOnCall == nil {
+		fake.findTaskCacheBaseVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatedVolume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findTaskCacheBaseVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatedVolume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeRepository) FindTaskCacheVolume(arg1 int, arg2 string, arg3 db.UsedTaskCache) (db.CreatedVolume, bool, error) {
 	fake.findTaskCacheVolumeMutex.Lock()
 	ret, specificReturn := fake.findTaskCacheVolumeReturnsOnCall[len(fake.findTaskCacheVolumeArgsForCall)]
@@ -1344,6 +1529,72 @@ func (fake *FakeVolumeRepository) GetOrphanedVolumesReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesToVerify(arg1 string, arg2 time.Time, arg3 int) ([]db.CreatedVolume, error) {
+	fake.getResourceCacheVolumesToVerifyMutex.Lock()
+	ret, specificReturn := fake.getResourceCacheVolumesToVerifyReturnsOnCall[len(fake.getResourceCacheVolumesToVerifyArgsForCall)]
+	fake.getResourceCacheVolumesToVerifyArgsForCall = append(fake.getResourceCacheVolumesToVerifyArgsForCall, struct {
+		arg1 string
+		arg2 time.Time
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetResourceCacheVolumesToVerifyStub
+	fakeReturns := fake.getResourceCacheVolumesToVerifyReturns
+	fake.recordInvocation("GetResourceCacheVolumesToVerify", []interface{}{arg1, arg2, arg3})
+	fake.getResourceCacheVolumesToVerifyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesToVerifyCallCount() int {
+	fake.getResourceCacheVolumesToVerifyMutex.RLock()
+	defer fake.getResourceCacheVolumesToVerifyMutex.RUnlock()
+	return len(fake.getResourceCacheVolumesToVerifyArgsForCall)
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesToVerifyCalls(stub func(string, time.Time, int) ([]db.CreatedVolume, error)) {
+	fake.getResourceCacheVolumesToVerifyMutex.Lock()
+	defer fake.getResourceCacheVolumesToVerifyMutex.Unlock()
+	fake.GetResourceCacheVolumesToVerifyStub = stub
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesToVerifyArgsForCall(i int) (string, time.Time, int) {
+	fake.getResourceCacheVolumesToVerifyMutex.RLock()
+	defer fake.getResourceCacheVolumesToVerifyMutex.RUnlock()
+	argsForCall := fake.getResourceCacheVolumesToVerifyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesToVerifyReturns(result1 []db.CreatedVolume, result2 error) {
+	fake.getResourceCacheVolumesToVerifyMutex.Lock()
+	defer fake.getResourceCacheVolumesToVerifyMutex.Unlock()
+	fake.GetResourceCacheVolumesToVerifyStub = nil
+	fake.getResourceCacheVolumesToVerifyReturns = struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeRepository) GetResourceCacheVolumesToVerifyReturnsOnCall(i int, result1 []db.CreatedVolume, result2 error) {
+	fake.getResourceCacheVolumesToVerifyMutex.Lock()
+	defer fake.getResourceCacheVolumesToVerifyMutex.Unlock()
+	fake.GetResourceCacheVolumesToVerifyStub = nil
+	if fake.getResourceCacheVolumesToVerifyReturnsOnCall == nil {
+		fake.getResourceCacheVolumesToVerifyReturnsOnCall = make(map[int]struct {
+			result1 []db.CreatedVolume
+			result2 error
+		})
+	}
+	fake.getResourceCacheVolumesToVerifyReturnsOnCall[i] = struct {
+		result1 []db.CreatedVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeRepository) GetTeamVolumes(arg1 int) ([]db.CreatedVolume, error) {
 	fake.getTeamVolumesMutex.Lock()
 	ret, specificReturn := fake.getTeamVolumesReturnsOnCall[len(fake.getTeamVolumesArgsForCall)]
@@ -1632,10 +1883,14 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.findContainerVolumeMutex.RUnlock()
 	fake.findCreatedVolumeMutex.RLock()
 	defer fake.findCreatedVolumeMutex.RUnlock()
+	fake.findResourceCacheBaseVolumeMutex.RLock()
+	defer fake.findResourceCacheBaseVolumeMutex.RUnlock()
 	fake.findResourceCacheVolumeMutex.RLock()
 	defer fake.findResourceCacheVolumeMutex.RUnlock()
 	fake.findResourceCertsVolumeMutex.RLock()
 	defer fake.findResourceCertsVolumeMutex.RUnlock()
+	fake.findTaskCacheBaseVolumeMutex.RLock()
+	defer fake.findTaskCacheBaseVolumeMutex.RUnlock()
 	fake.findTaskCacheVolumeMutex.RLock()
 	defer fake.findTaskCacheVolumeMutex.RUnlock()
 	fake.findVolumesForContainerMutex.RLock()
@@ -1644,6 +1899,8 @@ func (fake *FakeVolumeRepository) Invocations() map[string][][]interface{} {
 	defer fake.getDestroyingVolumesMutex.RUnlock()
 	fake.getOrphanedVolumesMutex.RLock()
 	defer fake.getOrphanedVolumesMutex.RUnlock()
+	fake.getResourceCacheVolumesToVerifyMutex.RLock()
+	defer fake.getResourceCacheVolumesToVerifyMutex.RUnlock()
 	fake.getTeamVolumesMutex.RLock()
 	defer fake.getTeamVolumesMutex.RUnlock()
 	fake.removeDestroyingVolumesMutex.RLock()
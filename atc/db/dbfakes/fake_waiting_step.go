@@ -0,0 +1,242 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWaitingStep struct {
+	DeleteStub        func() error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	HeartbeatStub        func(time.Duration) error
+	heartbeatMutex       sync.RWMutex
+	heartbeatArgsForCall []struct {
+		arg1 time.Duration
+	}
+	heartbeatReturns struct {
+		result1 error
+	}
+	heartbeatReturnsOnCall map[int]struct {
+		result1 error
+	}
+	IDStub        func() int
+	iDMutex       sync.RWMutex
+	iDArgsForCall []struct {
+	}
+	iDReturns struct {
+		result1 int
+	}
+	iDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWaitingStep) Delete() error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+	}{})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWaitingStep) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeWaitingStep) DeleteCalls(stub func() error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *FakeWaitingStep) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingStep) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingStep) Heartbeat(arg1 time.Duration) error {
+	fake.heartbeatMutex.Lock()
+	ret, specificReturn := fake.heartbeatReturnsOnCall[len(fake.heartbeatArgsForCall)]
+	fake.heartbeatArgsForCall = append(fake.heartbeatArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.HeartbeatStub
+	fakeReturns := fake.heartbeatReturns
+	fake.recordInvocation("Heartbeat", []interface{}{arg1})
+	fake.heartbeatMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWaitingStep) HeartbeatCallCount() int {
+	fake.heartbeatMutex.RLock()
+	defer fake.heartbeatMutex.RUnlock()
+	return len(fake.heartbeatArgsForCall)
+}
+
+func (fake *FakeWaitingStep) HeartbeatCalls(stub func(time.Duration) error) {
+	fake.heartbeatMutex.Lock()
+	defer fake.heartbeatMutex.Unlock()
+	fake.HeartbeatStub = stub
+}
+
+func (fake *FakeWaitingStep) HeartbeatArgsForCall(i int) time.Duration {
+	fake.heartbeatMutex.RLock()
+	defer fake.heartbeatMutex.RUnlock()
+	argsForCall := fake.heartbeatArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWaitingStep) HeartbeatReturns(result1 error) {
+	fake.heartbeatMutex.Lock()
+	defer fake.heartbeatMutex.Unlock()
+	fake.HeartbeatStub = nil
+	fake.heartbeatReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingStep) HeartbeatReturnsOnCall(i int, result1 error) {
+	fake.heartbeatMutex.Lock()
+	defer fake.heartbeatMutex.Unlock()
+	fake.HeartbeatStub = nil
+	if fake.heartbeatReturnsOnCall == nil {
+		fake.heartbeatReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.heartbeatReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingStep) ID() int {
+	fake.iDMutex.Lock()
+	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
+	fake.iDArgsForCall = append(fake.iDArgsForCall, struct {
+	}{})
+	stub := fake.IDStub
+	fakeReturns := fake.iDReturns
+	fake.recordInvocation("ID", []interface{}{})
+	fake.iDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWaitingStep) IDCallCount() int {
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	return len(fake.iDArgsForCall)
+}
+
+func (fake *FakeWaitingStep) IDCalls(stub func() int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = stub
+}
+
+func (fake *FakeWaitingStep) IDReturns(result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	fake.iDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWaitingStep) IDReturnsOnCall(i int, result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	if fake.iDReturnsOnCall == nil {
+		fake.iDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.iDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWaitingStep) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.heartbeatMutex.RLock()
+	defer fake.heartbeatMutex.RUnlock()
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWaitingStep) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WaitingStep = new(FakeWaitingStep)
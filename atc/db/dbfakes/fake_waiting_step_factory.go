@@ -0,0 +1,254 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWaitingStepFactory struct {
+	CreateWaitingStepStub        func(db.WaitingStepSpec, time.Duration) (db.WaitingStep, error)
+	createWaitingStepMutex       sync.RWMutex
+	createWaitingStepArgsForCall []struct {
+		arg1 db.WaitingStepSpec
+		arg2 time.Duration
+	}
+	createWaitingStepReturns struct {
+		result1 db.WaitingStep
+		result2 error
+	}
+	createWaitingStepReturnsOnCall map[int]struct {
+		result1 db.WaitingStep
+		result2 error
+	}
+	RemoveExpiredWaitingStepsStub        func() error
+	removeExpiredWaitingStepsMutex       sync.RWMutex
+	removeExpiredWaitingStepsArgsForCall []struct {
+	}
+	removeExpiredWaitingStepsReturns struct {
+		result1 error
+	}
+	removeExpiredWaitingStepsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WaitingStepsStub        func() ([]db.WaitingSteps, error)
+	waitingStepsMutex       sync.RWMutex
+	waitingStepsArgsForCall []struct {
+	}
+	waitingStepsReturns struct {
+		result1 []db.WaitingSteps
+		result2 error
+	}
+	waitingStepsReturnsOnCall map[int]struct {
+		result1 []db.WaitingSteps
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWaitingStepFactory) CreateWaitingStep(arg1 db.WaitingStepSpec, arg2 time.Duration) (db.WaitingStep, error) {
+	fake.createWaitingStepMutex.Lock()
+	ret, specificReturn := fake.createWaitingStepReturnsOnCall[len(fake.createWaitingStepArgsForCall)]
+	fake.createWaitingStepArgsForCall = append(fake.createWaitingStepArgsForCall, struct {
+		arg1 db.WaitingStepSpec
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.CreateWaitingStepStub
+	fakeReturns := fake.createWaitingStepReturns
+	fake.recordInvocation("CreateWaitingStep", []interface{}{arg1, arg2})
+	fake.createWaitingStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWaitingStepFactory) CreateWaitingStepCallCount() int {
+	fake.createWaitingStepMutex.RLock()
+	defer fake.createWaitingStepMutex.RUnlock()
+	return len(fake.createWaitingStepArgsForCall)
+}
+
+func (fake *FakeWaitingStepFactory) CreateWaitingStepCalls(stub func(db.WaitingStepSpec, time.Duration) (db.WaitingStep, error)) {
+	fake.createWaitingStepMutex.Lock()
+	defer fake.createWaitingStepMutex.Unlock()
+	fake.CreateWaitingStepStub = stub
+}
+
+func (fake *FakeWaitingStepFactory) CreateWaitingStepArgsForCall(i int) (db.WaitingStepSpec, time.Duration) {
+	fake.createWaitingStepMutex.RLock()
+	defer fake.createWaitingStepMutex.RUnlock()
+	argsForCall := fake.createWaitingStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWaitingStepFactory) CreateWaitingStepReturns(result1 db.WaitingStep, result2 error) {
+	fake.createWaitingStepMutex.Lock()
+	defer fake.createWaitingStepMutex.Unlock()
+	fake.CreateWaitingStepStub = nil
+	fake.createWaitingStepReturns = struct {
+		result1 db.WaitingStep
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingStepFactory) CreateWaitingStepReturnsOnCall(i int, result1 db.WaitingStep, result2 error) {
+	fake.createWaitingStepMutex.Lock()
+	defer fake.createWaitingStepMutex.Unlock()
+	fake.CreateWaitingStepStub = nil
+	if fake.createWaitingStepReturnsOnCall == nil {
+		fake.createWaitingStepReturnsOnCall = make(map[int]struct {
+			result1 db.WaitingStep
+			result2 error
+		})
+	}
+	fake.createWaitingStepReturnsOnCall[i] = struct {
+		result1 db.WaitingStep
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingStepFactory) RemoveExpiredWaitingSteps() error {
+	fake.removeExpiredWaitingStepsMutex.Lock()
+	ret, specificReturn := fake.removeExpiredWaitingStepsReturnsOnCall[len(fake.removeExpiredWaitingStepsArgsForCall)]
+	fake.removeExpiredWaitingStepsArgsForCall = append(fake.removeExpiredWaitingStepsArgsForCall, struct {
+	}{})
+	stub := fake.RemoveExpiredWaitingStepsStub
+	fakeReturns := fake.removeExpiredWaitingStepsReturns
+	fake.recordInvocation("RemoveExpiredWaitingSteps", []interface{}{})
+	fake.removeExpiredWaitingStepsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWaitingStepFactory) RemoveExpiredWaitingStepsCallCount() int {
+	fake.removeExpiredWaitingStepsMutex.RLock()
+	defer fake.removeExpiredWaitingStepsMutex.RUnlock()
+	return len(fake.removeExpiredWaitingStepsArgsForCall)
+}
+
+func (fake *FakeWaitingStepFactory) RemoveExpiredWaitingStepsCalls(stub func() error) {
+	fake.removeExpiredWaitingStepsMutex.Lock()
+	defer fake.removeExpiredWaitingStepsMutex.Unlock()
+	fake.RemoveExpiredWaitingStepsStub = stub
+}
+
+func (fake *FakeWaitingStepFactory) RemoveExpiredWaitingStepsReturns(result1 error) {
+	fake.removeExpiredWaitingStepsMutex.Lock()
+	defer fake.removeExpiredWaitingStepsMutex.Unlock()
+	fake.RemoveExpiredWaitingStepsStub = nil
+	fake.removeExpiredWaitingStepsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingStepFactory) RemoveExpiredWaitingStepsReturnsOnCall(i int, result1 error) {
+	fake.removeExpiredWaitingStepsMutex.Lock()
+	defer fake.removeExpiredWaitingStepsMutex.Unlock()
+	fake.RemoveExpiredWaitingStepsStub = nil
+	if fake.removeExpiredWaitingStepsReturnsOnCall == nil {
+		fake.removeExpiredWaitingStepsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeExpiredWaitingStepsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWaitingStepFactory) WaitingSteps() ([]db.WaitingSteps, error) {
+	fake.waitingStepsMutex.Lock()
+	ret, specificReturn := fake.waitingStepsReturnsOnCall[len(fake.waitingStepsArgsForCall)]
+	fake.waitingStepsArgsForCall = append(fake.waitingStepsArgsForCall, struct {
+	}{})
+	stub := fake.WaitingStepsStub
+	fakeReturns := fake.waitingStepsReturns
+	fake.recordInvocation("WaitingSteps", []interface{}{})
+	fake.waitingStepsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWaitingStepFactory) WaitingStepsCallCount() int {
+	fake.waitingStepsMutex.RLock()
+	defer fake.waitingStepsMutex.RUnlock()
+	return len(fake.waitingStepsArgsForCall)
+}
+
+func (fake *FakeWaitingStepFactory) WaitingStepsCalls(stub func() ([]db.WaitingSteps, error)) {
+	fake.waitingStepsMutex.Lock()
+	defer fake.waitingStepsMutex.Unlock()
+	fake.WaitingStepsStub = stub
+}
+
+func (fake *FakeWaitingStepFactory) WaitingStepsReturns(result1 []db.WaitingSteps, result2 error) {
+	fake.waitingStepsMutex.Lock()
+	defer fake.waitingStepsMutex.Unlock()
+	fake.WaitingStepsStub = nil
+	fake.waitingStepsReturns = struct {
+		result1 []db.WaitingSteps
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingStepFactory) WaitingStepsReturnsOnCall(i int, result1 []db.WaitingSteps, result2 error) {
+	fake.waitingStepsMutex.Lock()
+	defer fake.waitingStepsMutex.Unlock()
+	fake.WaitingStepsStub = nil
+	if fake.waitingStepsReturnsOnCall == nil {
+		fake.waitingStepsReturnsOnCall = make(map[int]struct {
+			result1 []db.WaitingSteps
+			result2 error
+		})
+	}
+	fake.waitingStepsReturnsOnCall[i] = struct {
+		result1 []db.WaitingSteps
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWaitingStepFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createWaitingStepMutex.RLock()
+	defer fake.createWaitingStepMutex.RUnlock()
+	fake.removeExpiredWaitingStepsMutex.RLock()
+	defer fake.removeExpiredWaitingStepsMutex.RUnlock()
+	fake.waitingStepsMutex.RLock()
+	defer fake.waitingStepsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWaitingStepFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WaitingStepFactory = new(FakeWaitingStepFactory)
@@ -62,6 +62,16 @@ type FakeWorker struct {
 	certsPathReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	ContainerCreationFailuresStub        func() int
+	containerCreationFailuresMutex       sync.RWMutex
+	containerCreationFailuresArgsForCall []struct {
+	}
+	containerCreationFailuresReturns struct {
+		result1 int
+	}
+	containerCreationFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	CreateContainerStub        func(db.ContainerOwner, db.ContainerMetadata) (db.CreatingContainer, error)
 	createContainerMutex       sync.RWMutex
 	createContainerArgsForCall []struct {
@@ -108,6 +118,26 @@ type FakeWorker struct {
 	ephemeralReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	ErroredStepsStub        func() int
+	erroredStepsMutex       sync.RWMutex
+	erroredStepsArgsForCall []struct {
+	}
+	erroredStepsReturns struct {
+		result1 int
+	}
+	erroredStepsReturnsOnCall map[int]struct {
+		result1 int
+	}
+	EvictStub        func() error
+	evictMutex       sync.RWMutex
+	evictArgsForCall []struct {
+	}
+	evictReturns struct {
+		result1 error
+	}
+	evictReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ExpiresAtStub        func() time.Time
 	expiresAtMutex       sync.RWMutex
 	expiresAtArgsForCall []struct {
@@ -118,6 +148,16 @@ type FakeWorker struct {
 	expiresAtReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	FeaturesStub        func() []string
+	featuresMutex       sync.RWMutex
+	featuresArgsForCall []struct {
+	}
+	featuresReturns struct {
+		result1 []string
+	}
+	featuresReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	FindContainerStub        func(db.ContainerOwner) (db.CreatingContainer, db.CreatedContainer, error)
 	findContainerMutex       sync.RWMutex
 	findContainerArgsForCall []struct {
@@ -163,6 +203,16 @@ type FakeWorker struct {
 	hTTPSProxyURLReturnsOnCall map[int]struct {
 		result1 string
 	}
+	HealthScoreStub        func() float64
+	healthScoreMutex       sync.RWMutex
+	healthScoreArgsForCall []struct {
+	}
+	healthScoreReturns struct {
+		result1 float64
+	}
+	healthScoreReturnsOnCall map[int]struct {
+		result1 float64
+	}
 	IncreaseActiveTasksStub        func() (int, error)
 	increaseActiveTasksMutex       sync.RWMutex
 	increaseActiveTasksArgsForCall []struct {
@@ -225,6 +275,50 @@ type FakeWorker struct {
 	pruneReturnsOnCall map[int]struct {
 		result1 error
 	}
+	QuarantineStub        func() error
+	quarantineMutex       sync.RWMutex
+	quarantineArgsForCall []struct {
+	}
+	quarantineReturns struct {
+		result1 error
+	}
+	quarantineReturnsOnCall map[int]struct {
+		result1 error
+	}
+	QuarantinedStub        func() bool
+	quarantinedMutex       sync.RWMutex
+	quarantinedArgsForCall []struct {
+	}
+	quarantinedReturns struct {
+		result1 bool
+	}
+	quarantinedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	RecordHealthStub        func(db.WorkerHealthOutcomes, db.WorkerQuarantine) (bool, error)
+	recordHealthMutex       sync.RWMutex
+	recordHealthArgsForCall []struct {
+		arg1 db.WorkerHealthOutcomes
+		arg2 db.WorkerQuarantine
+	}
+	recordHealthReturns struct {
+		result1 bool
+		result2 error
+	}
+	recordHealthReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ReleaseStub        func() error
+	releaseMutex       sync.RWMutex
+	releaseArgsForCall []struct {
+	}
+	releaseReturns struct {
+		result1 error
+	}
+	releaseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -271,6 +365,16 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RootlessStub        func() bool
+	rootlessMutex       sync.RWMutex
+	rootlessArgsForCall []struct {
+	}
+	rootlessReturns struct {
+		result1 bool
+	}
+	rootlessReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -291,6 +395,16 @@ type FakeWorker struct {
 	stateReturnsOnCall map[int]struct {
 		result1 db.WorkerState
 	}
+	SupportedContainerLimitsStub        func() []string
+	supportedContainerLimitsMutex       sync.RWMutex
+	supportedContainerLimitsArgsForCall []struct {
+	}
+	supportedContainerLimitsReturns struct {
+		result1 []string
+	}
+	supportedContainerLimitsReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	TagsStub        func() []string
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -331,6 +445,16 @@ type FakeWorker struct {
 	versionReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	VolumeStreamingFailuresStub        func() int
+	volumeStreamingFailuresMutex       sync.RWMutex
+	volumeStreamingFailuresArgsForCall []struct {
+	}
+	volumeStreamingFailuresReturns struct {
+		result1 int
+	}
+	volumeStreamingFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -603,6 +727,59 @@ func (fake *FakeWorker) CertsPathReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ContainerCreationFailures() int {
+	fake.containerCreationFailuresMutex.Lock()
+	ret, specificReturn := fake.containerCreationFailuresReturnsOnCall[len(fake.containerCreationFailuresArgsForCall)]
+	fake.containerCreationFailuresArgsForCall = append(fake.containerCreationFailuresArgsForCall, struct {
+	}{})
+	stub := fake.ContainerCreationFailuresStub
+	fakeReturns := fake.containerCreationFailuresReturns
+	fake.recordInvocation("ContainerCreationFailures", []interface{}{})
+	fake.containerCreationFailuresMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ContainerCreationFailuresCallCount() int {
+	fake.containerCreationFailuresMutex.RLock()
+	defer fake.containerCreationFailuresMutex.RUnlock()
+	return len(fake.containerCreationFailuresArgsForCall)
+}
+
+func (fake *FakeWorker) ContainerCreationFailuresCalls(stub func() int) {
+	fake.containerCreationFailuresMutex.Lock()
+	defer fake.containerCreationFailuresMutex.Unlock()
+	fake.ContainerCreationFailuresStub = stub
+}
+
+func (fake *FakeWorker) ContainerCreationFailuresReturns(result1 int) {
+	fake.containerCreationFailuresMutex.Lock()
+	defer fake.containerCreationFailuresMutex.Unlock()
+	fake.ContainerCreationFailuresStub = nil
+	fake.containerCreationFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ContainerCreationFailuresReturnsOnCall(i int, result1 int) {
+	fake.containerCreationFailuresMutex.Lock()
+	defer fake.containerCreationFailuresMutex.Unlock()
+	fake.ContainerCreationFailuresStub = nil
+	if fake.containerCreationFailuresReturnsOnCall == nil {
+		fake.containerCreationFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.containerCreationFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) CreateContainer(arg1 db.ContainerOwner, arg2 db.ContainerMetadata) (db.CreatingContainer, error) {
 	fake.createContainerMutex.Lock()
 	ret, specificReturn := fake.createContainerReturnsOnCall[len(fake.createContainerArgsForCall)]
@@ -830,6 +1007,112 @@ func (fake *FakeWorker) EphemeralReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ErroredSteps() int {
+	fake.erroredStepsMutex.Lock()
+	ret, specificReturn := fake.erroredStepsReturnsOnCall[len(fake.erroredStepsArgsForCall)]
+	fake.erroredStepsArgsForCall = append(fake.erroredStepsArgsForCall, struct {
+	}{})
+	stub := fake.ErroredStepsStub
+	fakeReturns := fake.erroredStepsReturns
+	fake.recordInvocation("ErroredSteps", []interface{}{})
+	fake.erroredStepsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ErroredStepsCallCount() int {
+	fake.erroredStepsMutex.RLock()
+	defer fake.erroredStepsMutex.RUnlock()
+	return len(fake.erroredStepsArgsForCall)
+}
+
+func (fake *FakeWorker) ErroredStepsCalls(stub func() int) {
+	fake.erroredStepsMutex.Lock()
+	defer fake.erroredStepsMutex.Unlock()
+	fake.ErroredStepsStub = stub
+}
+
+func (fake *FakeWorker) ErroredStepsReturns(result1 int) {
+	fake.erroredStepsMutex.Lock()
+	defer fake.erroredStepsMutex.Unlock()
+	fake.ErroredStepsStub = nil
+	fake.erroredStepsReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) ErroredStepsReturnsOnCall(i int, result1 int) {
+	fake.erroredStepsMutex.Lock()
+	defer fake.erroredStepsMutex.Unlock()
+	fake.ErroredStepsStub = nil
+	if fake.erroredStepsReturnsOnCall == nil {
+		fake.erroredStepsReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.erroredStepsReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) Evict() error {
+	fake.evictMutex.Lock()
+	ret, specificReturn := fake.evictReturnsOnCall[len(fake.evictArgsForCall)]
+	fake.evictArgsForCall = append(fake.evictArgsForCall, struct {
+	}{})
+	stub := fake.EvictStub
+	fakeReturns := fake.evictReturns
+	fake.recordInvocation("Evict", []interface{}{})
+	fake.evictMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) EvictCallCount() int {
+	fake.evictMutex.RLock()
+	defer fake.evictMutex.RUnlock()
+	return len(fake.evictArgsForCall)
+}
+
+func (fake *FakeWorker) EvictCalls(stub func() error) {
+	fake.evictMutex.Lock()
+	defer fake.evictMutex.Unlock()
+	fake.EvictStub = stub
+}
+
+func (fake *FakeWorker) EvictReturns(result1 error) {
+	fake.evictMutex.Lock()
+	defer fake.evictMutex.Unlock()
+	fake.EvictStub = nil
+	fake.evictReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) EvictReturnsOnCall(i int, result1 error) {
+	fake.evictMutex.Lock()
+	defer fake.evictMutex.Unlock()
+	fake.EvictStub = nil
+	if fake.evictReturnsOnCall == nil {
+		fake.evictReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.evictReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) ExpiresAt() time.Time {
 	fake.expiresAtMutex.Lock()
 	ret, specificReturn := fake.expiresAtReturnsOnCall[len(fake.expiresAtArgsForCall)]
@@ -883,6 +1166,59 @@ func (fake *FakeWorker) ExpiresAtReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Features() []string {
+	fake.featuresMutex.Lock()
+	ret, specificReturn := fake.featuresReturnsOnCall[len(fake.featuresArgsForCall)]
+	fake.featuresArgsForCall = append(fake.featuresArgsForCall, struct {
+	}{})
+	stub := fake.FeaturesStub
+	fakeReturns := fake.featuresReturns
+	fake.recordInvocation("Features", []interface{}{})
+	fake.featuresMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) FeaturesCallCount() int {
+	fake.featuresMutex.RLock()
+	defer fake.featuresMutex.RUnlock()
+	return len(fake.featuresArgsForCall)
+}
+
+func (fake *FakeWorker) FeaturesCalls(stub func() []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = stub
+}
+
+func (fake *FakeWorker) FeaturesReturns(result1 []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = nil
+	fake.featuresReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) FeaturesReturnsOnCall(i int, result1 []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = nil
+	if fake.featuresReturnsOnCall == nil {
+		fake.featuresReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.featuresReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) FindContainer(arg1 db.ContainerOwner) (db.CreatingContainer, db.CreatedContainer, error) {
 	fake.findContainerMutex.Lock()
 	ret, specificReturn := fake.findContainerReturnsOnCall[len(fake.findContainerArgsForCall)]
@@ -1109,6 +1445,59 @@ func (fake *FakeWorker) HTTPSProxyURLReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) HealthScore() float64 {
+	fake.healthScoreMutex.Lock()
+	ret, specificReturn := fake.healthScoreReturnsOnCall[len(fake.healthScoreArgsForCall)]
+	fake.healthScoreArgsForCall = append(fake.healthScoreArgsForCall, struct {
+	}{})
+	stub := fake.HealthScoreStub
+	fakeReturns := fake.healthScoreReturns
+	fake.recordInvocation("HealthScore", []interface{}{})
+	fake.healthScoreMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) HealthScoreCallCount() int {
+	fake.healthScoreMutex.RLock()
+	defer fake.healthScoreMutex.RUnlock()
+	return len(fake.healthScoreArgsForCall)
+}
+
+func (fake *FakeWorker) HealthScoreCalls(stub func() float64) {
+	fake.healthScoreMutex.Lock()
+	defer fake.healthScoreMutex.Unlock()
+	fake.HealthScoreStub = stub
+}
+
+func (fake *FakeWorker) HealthScoreReturns(result1 float64) {
+	fake.healthScoreMutex.Lock()
+	defer fake.healthScoreMutex.Unlock()
+	fake.HealthScoreStub = nil
+	fake.healthScoreReturns = struct {
+		result1 float64
+	}{result1}
+}
+
+func (fake *FakeWorker) HealthScoreReturnsOnCall(i int, result1 float64) {
+	fake.healthScoreMutex.Lock()
+	defer fake.healthScoreMutex.Unlock()
+	fake.HealthScoreStub = nil
+	if fake.healthScoreReturnsOnCall == nil {
+		fake.healthScoreReturnsOnCall = make(map[int]struct {
+			result1 float64
+		})
+	}
+	fake.healthScoreReturnsOnCall[i] = struct {
+		result1 float64
+	}{result1}
+}
+
 func (fake *FakeWorker) IncreaseActiveTasks() (int, error) {
 	fake.increaseActiveTasksMutex.Lock()
 	ret, specificReturn := fake.increaseActiveTasksReturnsOnCall[len(fake.increaseActiveTasksArgsForCall)]
@@ -1430,6 +1819,230 @@ func (fake *FakeWorker) PruneReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Quarantine() error {
+	fake.quarantineMutex.Lock()
+	ret, specificReturn := fake.quarantineReturnsOnCall[len(fake.quarantineArgsForCall)]
+	fake.quarantineArgsForCall = append(fake.quarantineArgsForCall, struct {
+	}{})
+	stub := fake.QuarantineStub
+	fakeReturns := fake.quarantineReturns
+	fake.recordInvocation("Quarantine", []interface{}{})
+	fake.quarantineMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) QuarantineCallCount() int {
+	fake.quarantineMutex.RLock()
+	defer fake.quarantineMutex.RUnlock()
+	return len(fake.quarantineArgsForCall)
+}
+
+func (fake *FakeWorker) QuarantineCalls(stub func() error) {
+	fake.quarantineMutex.Lock()
+	defer fake.quarantineMutex.Unlock()
+	fake.QuarantineStub = stub
+}
+
+func (fake *FakeWorker) QuarantineReturns(result1 error) {
+	fake.quarantineMutex.Lock()
+	defer fake.quarantineMutex.Unlock()
+	fake.QuarantineStub = nil
+	fake.quarantineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) QuarantineReturnsOnCall(i int, result1 error) {
+	fake.quarantineMutex.Lock()
+	defer fake.quarantineMutex.Unlock()
+	fake.QuarantineStub = nil
+	if fake.quarantineReturnsOnCall == nil {
+		fake.quarantineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.quarantineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) Quarantined() bool {
+	fake.quarantinedMutex.Lock()
+	ret, specificReturn := fake.quarantinedReturnsOnCall[len(fake.quarantinedArgsForCall)]
+	fake.quarantinedArgsForCall = append(fake.quarantinedArgsForCall, struct {
+	}{})
+	stub := fake.QuarantinedStub
+	fakeReturns := fake.quarantinedReturns
+	fake.recordInvocation("Quarantined", []interface{}{})
+	fake.quarantinedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) QuarantinedCallCount() int {
+	fake.quarantinedMutex.RLock()
+	defer fake.quarantinedMutex.RUnlock()
+	return len(fake.quarantinedArgsForCall)
+}
+
+func (fake *FakeWorker) QuarantinedCalls(stub func() bool) {
+	fake.quarantinedMutex.Lock()
+	defer fake.quarantinedMutex.Unlock()
+	fake.QuarantinedStub = stub
+}
+
+func (fake *FakeWorker) QuarantinedReturns(result1 bool) {
+	fake.quarantinedMutex.Lock()
+	defer fake.quarantinedMutex.Unlock()
+	fake.QuarantinedStub = nil
+	fake.quarantinedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) QuarantinedReturnsOnCall(i int, result1 bool) {
+	fake.quarantinedMutex.Lock()
+	defer fake.quarantinedMutex.Unlock()
+	fake.QuarantinedStub = nil
+	if fake.quarantinedReturnsOnCall == nil {
+		fake.quarantinedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.quarantinedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) RecordHealth(arg1 db.WorkerHealthOutcomes, arg2 db.WorkerQuarantine) (bool, error) {
+	fake.recordHealthMutex.Lock()
+	ret, specificReturn := fake.recordHealthReturnsOnCall[len(fake.recordHealthArgsForCall)]
+	fake.recordHealthArgsForCall = append(fake.recordHealthArgsForCall, struct {
+		arg1 db.WorkerHealthOutcomes
+		arg2 db.WorkerQuarantine
+	}{arg1, arg2})
+	stub := fake.RecordHealthStub
+	fakeReturns := fake.recordHealthReturns
+	fake.recordInvocation("RecordHealth", []interface{}{arg1, arg2})
+	fake.recordHealthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) RecordHealthCallCount() int {
+	fake.recordHealthMutex.RLock()
+	defer fake.recordHealthMutex.RUnlock()
+	return len(fake.recordHealthArgsForCall)
+}
+
+func (fake *FakeWorker) RecordHealthCalls(stub func(db.WorkerHealthOutcomes, db.WorkerQuarantine) (bool, error)) {
+	fake.recordHealthMutex.Lock()
+	defer fake.recordHealthMutex.Unlock()
+	fake.RecordHealthStub = stub
+}
+
+func (fake *FakeWorker) RecordHealthArgsForCall(i int) (db.WorkerHealthOutcomes, db.WorkerQuarantine) {
+	fake.recordHealthMutex.RLock()
+	defer fake.recordHealthMutex.RUnlock()
+	argsForCall := fake.recordHealthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) RecordHealthReturns(result1 bool, result2 error) {
+	fake.recordHealthMutex.Lock()
+	defer fake.recordHealthMutex.Unlock()
+	fake.RecordHealthStub = nil
+	fake.recordHealthReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) RecordHealthReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.recordHealthMutex.Lock()
+	defer fake.recordHealthMutex.Unlock()
+	fake.RecordHealthStub = nil
+	if fake.recordHealthReturnsOnCall == nil {
+		fake.recordHealthReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.recordHealthReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) Release() error {
+	fake.releaseMutex.Lock()
+	ret, specificReturn := fake.releaseReturnsOnCall[len(fake.releaseArgsForCall)]
+	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
+	}{})
+	stub := fake.ReleaseStub
+	fakeReturns := fake.releaseReturns
+	fake.recordInvocation("Release", []interface{}{})
+	fake.releaseMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ReleaseCallCount() int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return len(fake.releaseArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseCalls(stub func() error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = stub
+}
+
+func (fake *FakeWorker) ReleaseReturns(result1 error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = nil
+	fake.releaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ReleaseReturnsOnCall(i int, result1 error) {
+	fake.releaseMutex.Lock()
+	defer fake.releaseMutex.Unlock()
+	fake.ReleaseStub = nil
+	if fake.releaseReturnsOnCall == nil {
+		fake.releaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1651,6 +2264,59 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Rootless() bool {
+	fake.rootlessMutex.Lock()
+	ret, specificReturn := fake.rootlessReturnsOnCall[len(fake.rootlessArgsForCall)]
+	fake.rootlessArgsForCall = append(fake.rootlessArgsForCall, struct {
+	}{})
+	stub := fake.RootlessStub
+	fakeReturns := fake.rootlessReturns
+	fake.recordInvocation("Rootless", []interface{}{})
+	fake.rootlessMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RootlessCallCount() int {
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
+	return len(fake.rootlessArgsForCall)
+}
+
+func (fake *FakeWorker) RootlessCalls(stub func() bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = stub
+}
+
+func (fake *FakeWorker) RootlessReturns(result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	fake.rootlessReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) RootlessReturnsOnCall(i int, result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	if fake.rootlessReturnsOnCall == nil {
+		fake.rootlessReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.rootlessReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1757,6 +2423,59 @@ func (fake *FakeWorker) StateReturnsOnCall(i int, result1 db.WorkerState) {
 	}{result1}
 }
 
+func (fake *FakeWorker) SupportedContainerLimits() []string {
+	fake.supportedContainerLimitsMutex.Lock()
+	ret, specificReturn := fake.supportedContainerLimitsReturnsOnCall[len(fake.supportedContainerLimitsArgsForCall)]
+	fake.supportedContainerLimitsArgsForCall = append(fake.supportedContainerLimitsArgsForCall, struct {
+	}{})
+	stub := fake.SupportedContainerLimitsStub
+	fakeReturns := fake.supportedContainerLimitsReturns
+	fake.recordInvocation("SupportedContainerLimits", []interface{}{})
+	fake.supportedContainerLimitsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsCallCount() int {
+	fake.supportedContainerLimitsMutex.RLock()
+	defer fake.supportedContainerLimitsMutex.RUnlock()
+	return len(fake.supportedContainerLimitsArgsForCall)
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsCalls(stub func() []string) {
+	fake.supportedContainerLimitsMutex.Lock()
+	defer fake.supportedContainerLimitsMutex.Unlock()
+	fake.SupportedContainerLimitsStub = stub
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsReturns(result1 []string) {
+	fake.supportedContainerLimitsMutex.Lock()
+	defer fake.supportedContainerLimitsMutex.Unlock()
+	fake.SupportedContainerLimitsStub = nil
+	fake.supportedContainerLimitsReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsReturnsOnCall(i int, result1 []string) {
+	fake.supportedContainerLimitsMutex.Lock()
+	defer fake.supportedContainerLimitsMutex.Unlock()
+	fake.SupportedContainerLimitsStub = nil
+	if fake.supportedContainerLimitsReturnsOnCall == nil {
+		fake.supportedContainerLimitsReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.supportedContainerLimitsReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) Tags() []string {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -1969,6 +2688,59 @@ func (fake *FakeWorker) VersionReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) VolumeStreamingFailures() int {
+	fake.volumeStreamingFailuresMutex.Lock()
+	ret, specificReturn := fake.volumeStreamingFailuresReturnsOnCall[len(fake.volumeStreamingFailuresArgsForCall)]
+	fake.volumeStreamingFailuresArgsForCall = append(fake.volumeStreamingFailuresArgsForCall, struct {
+	}{})
+	stub := fake.VolumeStreamingFailuresStub
+	fakeReturns := fake.volumeStreamingFailuresReturns
+	fake.recordInvocation("VolumeStreamingFailures", []interface{}{})
+	fake.volumeStreamingFailuresMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) VolumeStreamingFailuresCallCount() int {
+	fake.volumeStreamingFailuresMutex.RLock()
+	defer fake.volumeStreamingFailuresMutex.RUnlock()
+	return len(fake.volumeStreamingFailuresArgsForCall)
+}
+
+func (fake *FakeWorker) VolumeStreamingFailuresCalls(stub func() int) {
+	fake.volumeStreamingFailuresMutex.Lock()
+	defer fake.volumeStreamingFailuresMutex.Unlock()
+	fake.VolumeStreamingFailuresStub = stub
+}
+
+func (fake *FakeWorker) VolumeStreamingFailuresReturns(result1 int) {
+	fake.volumeStreamingFailuresMutex.Lock()
+	defer fake.volumeStreamingFailuresMutex.Unlock()
+	fake.VolumeStreamingFailuresStub = nil
+	fake.volumeStreamingFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) VolumeStreamingFailuresReturnsOnCall(i int, result1 int) {
+	fake.volumeStreamingFailuresMutex.Lock()
+	defer fake.volumeStreamingFailuresMutex.Unlock()
+	fake.VolumeStreamingFailuresStub = nil
+	if fake.volumeStreamingFailuresReturnsOnCall == nil {
+		fake.volumeStreamingFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.volumeStreamingFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1982,6 +2754,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.baggageclaimURLMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
+	fake.containerCreationFailuresMutex.RLock()
+	defer fake.containerCreationFailuresMutex.RUnlock()
 	fake.createContainerMutex.RLock()
 	defer fake.createContainerMutex.RUnlock()
 	fake.decreaseActiveTasksMutex.RLock()
@@ -1990,8 +2764,14 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.deleteMutex.RUnlock()
 	fake.ephemeralMutex.RLock()
 	defer fake.ephemeralMutex.RUnlock()
+	fake.erroredStepsMutex.RLock()
+	defer fake.erroredStepsMutex.RUnlock()
+	fake.evictMutex.RLock()
+	defer fake.evictMutex.RUnlock()
 	fake.expiresAtMutex.RLock()
 	defer fake.expiresAtMutex.RUnlock()
+	fake.featuresMutex.RLock()
+	defer fake.featuresMutex.RUnlock()
 	fake.findContainerMutex.RLock()
 	defer fake.findContainerMutex.RUnlock()
 	fake.gardenAddrMutex.RLock()
@@ -2000,6 +2780,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.hTTPProxyURLMutex.RUnlock()
 	fake.hTTPSProxyURLMutex.RLock()
 	defer fake.hTTPSProxyURLMutex.RUnlock()
+	fake.healthScoreMutex.RLock()
+	defer fake.healthScoreMutex.RUnlock()
 	fake.increaseActiveTasksMutex.RLock()
 	defer fake.increaseActiveTasksMutex.RUnlock()
 	fake.landMutex.RLock()
@@ -2012,6 +2794,14 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.platformMutex.RUnlock()
 	fake.pruneMutex.RLock()
 	defer fake.pruneMutex.RUnlock()
+	fake.quarantineMutex.RLock()
+	defer fake.quarantineMutex.RUnlock()
+	fake.quarantinedMutex.RLock()
+	defer fake.quarantinedMutex.RUnlock()
+	fake.recordHealthMutex.RLock()
+	defer fake.recordHealthMutex.RUnlock()
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.resourceCertsMutex.RLock()
@@ -2020,10 +2810,14 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
 	defer fake.stateMutex.RUnlock()
+	fake.supportedContainerLimitsMutex.RLock()
+	defer fake.supportedContainerLimitsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -2032,6 +2826,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.versionMutex.RLock()
 	defer fake.versionMutex.RUnlock()
+	fake.volumeStreamingFailuresMutex.RLock()
+	defer fake.volumeStreamingFailuresMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -10,6 +10,18 @@ import (
 )
 
 type FakeWorkerFactory struct {
+	ActiveTasksPerWorkerStub        func() (map[string]int, error)
+	activeTasksPerWorkerMutex       sync.RWMutex
+	activeTasksPerWorkerArgsForCall []struct {
+	}
+	activeTasksPerWorkerReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	activeTasksPerWorkerReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	BuildContainersCountPerWorkerStub        func() (map[string]int, error)
 	buildContainersCountPerWorkerMutex       sync.RWMutex
 	buildContainersCountPerWorkerArgsForCall []struct {
@@ -107,6 +119,62 @@ type FakeWorkerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeWorkerFactory) ActiveTasksPerWorker() (map[string]int, error) {
+	fake.activeTasksPerWorkerMutex.Lock()
+	ret, specificReturn := fake.activeTasksPerWorkerReturnsOnCall[len(fake.activeTasksPerWorkerArgsForCall)]
+	fake.activeTasksPerWorkerArgsForCall = append(fake.activeTasksPerWorkerArgsForCall, struct {
+	}{})
+	stub := fake.ActiveTasksPerWorkerStub
+	fakeReturns := fake.activeTasksPerWorkerReturns
+	fake.recordInvocation("ActiveTasksPerWorker", []interface{}{})
+	fake.activeTasksPerWorkerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksPerWorkerCallCount() int {
+	fake.activeTasksPerWorkerMutex.RLock()
+	defer fake.activeTasksPerWorkerMutex.RUnlock()
+	return len(fake.activeTasksPerWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksPerWorkerCalls(stub func() (map[string]int, error)) {
+	fake.activeTasksPerWorkerMutex.Lock()
+	defer fake.activeTasksPerWorkerMutex.Unlock()
+	fake.ActiveTasksPerWorkerStub = stub
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksPerWorkerReturns(result1 map[string]int, result2 error) {
+	fake.activeTasksPerWorkerMutex.Lock()
+	defer fake.activeTasksPerWorkerMutex.Unlock()
+	fake.ActiveTasksPerWorkerStub = nil
+	fake.activeTasksPerWorkerReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksPerWorkerReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.activeTasksPerWorkerMutex.Lock()
+	defer fake.activeTasksPerWorkerMutex.Unlock()
+	fake.ActiveTasksPerWorkerStub = nil
+	if fake.activeTasksPerWorkerReturnsOnCall == nil {
+		fake.activeTasksPerWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.activeTasksPerWorkerReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerFactory) BuildContainersCountPerWorker() (map[string]int, error) {
 	fake.buildContainersCountPerWorkerMutex.Lock()
 	ret, specificReturn := fake.buildContainersCountPerWorkerReturnsOnCall[len(fake.buildContainersCountPerWorkerArgsForCall)]
@@ -552,6 +620,8 @@ func (fake *FakeWorkerFactory) WorkersReturnsOnCall(i int, result1 []db.Worker,
 func (fake *FakeWorkerFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.activeTasksPerWorkerMutex.RLock()
+	defer fake.activeTasksPerWorkerMutex.RUnlock()
 	fake.buildContainersCountPerWorkerMutex.RLock()
 	defer fake.buildContainersCountPerWorkerMutex.RUnlock()
 	fake.findWorkersForContainerByOwnerMutex.RLock()
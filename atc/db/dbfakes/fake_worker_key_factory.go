@@ -0,0 +1,530 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWorkerKeyFactory struct {
+	AuthorizeWorkerKeyStub        func(string) (db.WorkerKey, bool, error)
+	authorizeWorkerKeyMutex       sync.RWMutex
+	authorizeWorkerKeyArgsForCall []struct {
+		arg1 string
+	}
+	authorizeWorkerKeyReturns struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}
+	authorizeWorkerKeyReturnsOnCall map[int]struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}
+	CreateEnrollmentTokenStub        func(int, time.Duration) (string, time.Time, error)
+	createEnrollmentTokenMutex       sync.RWMutex
+	createEnrollmentTokenArgsForCall []struct {
+		arg1 int
+		arg2 time.Duration
+	}
+	createEnrollmentTokenReturns struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}
+	createEnrollmentTokenReturnsOnCall map[int]struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}
+	EnrollWorkerKeyStub        func(string, string, string) (db.WorkerKey, bool, error)
+	enrollWorkerKeyMutex       sync.RWMutex
+	enrollWorkerKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	enrollWorkerKeyReturns struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}
+	enrollWorkerKeyReturnsOnCall map[int]struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}
+	RevokeWorkerKeyStub        func(int, int) (bool, error)
+	revokeWorkerKeyMutex       sync.RWMutex
+	revokeWorkerKeyArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	revokeWorkerKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeWorkerKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	TeamWorkerKeysStub        func(int) ([]db.WorkerKey, error)
+	teamWorkerKeysMutex       sync.RWMutex
+	teamWorkerKeysArgsForCall []struct {
+		arg1 int
+	}
+	teamWorkerKeysReturns struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	teamWorkerKeysReturnsOnCall map[int]struct {
+		result1 []db.WorkerKey
+		result2 error
+	}
+	UseWorkerKeyStub        func(string) error
+	useWorkerKeyMutex       sync.RWMutex
+	useWorkerKeyArgsForCall []struct {
+		arg1 string
+	}
+	useWorkerKeyReturns struct {
+		result1 error
+	}
+	useWorkerKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerKeyFactory) AuthorizeWorkerKey(arg1 string) (db.WorkerKey, bool, error) {
+	fake.authorizeWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.authorizeWorkerKeyReturnsOnCall[len(fake.authorizeWorkerKeyArgsForCall)]
+	fake.authorizeWorkerKeyArgsForCall = append(fake.authorizeWorkerKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AuthorizeWorkerKeyStub
+	fakeReturns := fake.authorizeWorkerKeyReturns
+	fake.recordInvocation("AuthorizeWorkerKey", []interface{}{arg1})
+	fake.authorizeWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerKeyFactory) AuthorizeWorkerKeyCallCount() int {
+	fake.authorizeWorkerKeyMutex.RLock()
+	defer fake.authorizeWorkerKeyMutex.RUnlock()
+	return len(fake.authorizeWorkerKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) AuthorizeWorkerKeyCalls(stub func(string) (db.WorkerKey, bool, error)) {
+	fake.authorizeWorkerKeyMutex.Lock()
+	defer fake.authorizeWorkerKeyMutex.Unlock()
+	fake.AuthorizeWorkerKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) AuthorizeWorkerKeyArgsForCall(i int) string {
+	fake.authorizeWorkerKeyMutex.RLock()
+	defer fake.authorizeWorkerKeyMutex.RUnlock()
+	argsForCall := fake.authorizeWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerKeyFactory) AuthorizeWorkerKeyReturns(result1 db.WorkerKey, result2 bool, result3 error) {
+	fake.authorizeWorkerKeyMutex.Lock()
+	defer fake.authorizeWorkerKeyMutex.Unlock()
+	fake.AuthorizeWorkerKeyStub = nil
+	fake.authorizeWorkerKeyReturns = struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerKeyFactory) AuthorizeWorkerKeyReturnsOnCall(i int, result1 db.WorkerKey, result2 bool, result3 error) {
+	fake.authorizeWorkerKeyMutex.Lock()
+	defer fake.authorizeWorkerKeyMutex.Unlock()
+	fake.AuthorizeWorkerKeyStub = nil
+	if fake.authorizeWorkerKeyReturnsOnCall == nil {
+		fake.authorizeWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerKey
+			result2 bool
+			result3 error
+		})
+	}
+	fake.authorizeWorkerKeyReturnsOnCall[i] = struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerKeyFactory) CreateEnrollmentToken(arg1 int, arg2 time.Duration) (string, time.Time, error) {
+	fake.createEnrollmentTokenMutex.Lock()
+	ret, specificReturn := fake.createEnrollmentTokenReturnsOnCall[len(fake.createEnrollmentTokenArgsForCall)]
+	fake.createEnrollmentTokenArgsForCall = append(fake.createEnrollmentTokenArgsForCall, struct {
+		arg1 int
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.CreateEnrollmentTokenStub
+	fakeReturns := fake.createEnrollmentTokenReturns
+	fake.recordInvocation("CreateEnrollmentToken", []interface{}{arg1, arg2})
+	fake.createEnrollmentTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerKeyFactory) CreateEnrollmentTokenCallCount() int {
+	fake.createEnrollmentTokenMutex.RLock()
+	defer fake.createEnrollmentTokenMutex.RUnlock()
+	return len(fake.createEnrollmentTokenArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) CreateEnrollmentTokenCalls(stub func(int, time.Duration) (string, time.Time, error)) {
+	fake.createEnrollmentTokenMutex.Lock()
+	defer fake.createEnrollmentTokenMutex.Unlock()
+	fake.CreateEnrollmentTokenStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) CreateEnrollmentTokenArgsForCall(i int) (int, time.Duration) {
+	fake.createEnrollmentTokenMutex.RLock()
+	defer fake.createEnrollmentTokenMutex.RUnlock()
+	argsForCall := fake.createEnrollmentTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerKeyFactory) CreateEnrollmentTokenReturns(result1 string, result2 time.Time, result3 error) {
+	fake.createEnrollmentTokenMutex.Lock()
+	defer fake.createEnrollmentTokenMutex.Unlock()
+	fake.CreateEnrollmentTokenStub = nil
+	fake.createEnrollmentTokenReturns = struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerKeyFactory) CreateEnrollmentTokenReturnsOnCall(i int, result1 string, result2 time.Time, result3 error) {
+	fake.createEnrollmentTokenMutex.Lock()
+	defer fake.createEnrollmentTokenMutex.Unlock()
+	fake.CreateEnrollmentTokenStub = nil
+	if fake.createEnrollmentTokenReturnsOnCall == nil {
+		fake.createEnrollmentTokenReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 time.Time
+			result3 error
+		})
+	}
+	fake.createEnrollmentTokenReturnsOnCall[i] = struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerKeyFactory) EnrollWorkerKey(arg1 string, arg2 string, arg3 string) (db.WorkerKey, bool, error) {
+	fake.enrollWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.enrollWorkerKeyReturnsOnCall[len(fake.enrollWorkerKeyArgsForCall)]
+	fake.enrollWorkerKeyArgsForCall = append(fake.enrollWorkerKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.EnrollWorkerKeyStub
+	fakeReturns := fake.enrollWorkerKeyReturns
+	fake.recordInvocation("EnrollWorkerKey", []interface{}{arg1, arg2, arg3})
+	fake.enrollWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorkerKeyFactory) EnrollWorkerKeyCallCount() int {
+	fake.enrollWorkerKeyMutex.RLock()
+	defer fake.enrollWorkerKeyMutex.RUnlock()
+	return len(fake.enrollWorkerKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) EnrollWorkerKeyCalls(stub func(string, string, string) (db.WorkerKey, bool, error)) {
+	fake.enrollWorkerKeyMutex.Lock()
+	defer fake.enrollWorkerKeyMutex.Unlock()
+	fake.EnrollWorkerKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) EnrollWorkerKeyArgsForCall(i int) (string, string, string) {
+	fake.enrollWorkerKeyMutex.RLock()
+	defer fake.enrollWorkerKeyMutex.RUnlock()
+	argsForCall := fake.enrollWorkerKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorkerKeyFactory) EnrollWorkerKeyReturns(result1 db.WorkerKey, result2 bool, result3 error) {
+	fake.enrollWorkerKeyMutex.Lock()
+	defer fake.enrollWorkerKeyMutex.Unlock()
+	fake.EnrollWorkerKeyStub = nil
+	fake.enrollWorkerKeyReturns = struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerKeyFactory) EnrollWorkerKeyReturnsOnCall(i int, result1 db.WorkerKey, result2 bool, result3 error) {
+	fake.enrollWorkerKeyMutex.Lock()
+	defer fake.enrollWorkerKeyMutex.Unlock()
+	fake.EnrollWorkerKeyStub = nil
+	if fake.enrollWorkerKeyReturnsOnCall == nil {
+		fake.enrollWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerKey
+			result2 bool
+			result3 error
+		})
+	}
+	fake.enrollWorkerKeyReturnsOnCall[i] = struct {
+		result1 db.WorkerKey
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorkerKeyFactory) RevokeWorkerKey(arg1 int, arg2 int) (bool, error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.revokeWorkerKeyReturnsOnCall[len(fake.revokeWorkerKeyArgsForCall)]
+	fake.revokeWorkerKeyArgsForCall = append(fake.revokeWorkerKeyArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokeWorkerKeyStub
+	fakeReturns := fake.revokeWorkerKeyReturns
+	fake.recordInvocation("RevokeWorkerKey", []interface{}{arg1, arg2})
+	fake.revokeWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) RevokeWorkerKeyCallCount() int {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	return len(fake.revokeWorkerKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) RevokeWorkerKeyCalls(stub func(int, int) (bool, error)) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) RevokeWorkerKeyArgsForCall(i int) (int, int) {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	argsForCall := fake.revokeWorkerKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerKeyFactory) RevokeWorkerKeyReturns(result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	fake.revokeWorkerKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) RevokeWorkerKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	if fake.revokeWorkerKeyReturnsOnCall == nil {
+		fake.revokeWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeWorkerKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) TeamWorkerKeys(arg1 int) ([]db.WorkerKey, error) {
+	fake.teamWorkerKeysMutex.Lock()
+	ret, specificReturn := fake.teamWorkerKeysReturnsOnCall[len(fake.teamWorkerKeysArgsForCall)]
+	fake.teamWorkerKeysArgsForCall = append(fake.teamWorkerKeysArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.TeamWorkerKeysStub
+	fakeReturns := fake.teamWorkerKeysReturns
+	fake.recordInvocation("TeamWorkerKeys", []interface{}{arg1})
+	fake.teamWorkerKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerKeyFactory) TeamWorkerKeysCallCount() int {
+	fake.teamWorkerKeysMutex.RLock()
+	defer fake.teamWorkerKeysMutex.RUnlock()
+	return len(fake.teamWorkerKeysArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) TeamWorkerKeysCalls(stub func(int) ([]db.WorkerKey, error)) {
+	fake.teamWorkerKeysMutex.Lock()
+	defer fake.teamWorkerKeysMutex.Unlock()
+	fake.TeamWorkerKeysStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) TeamWorkerKeysArgsForCall(i int) int {
+	fake.teamWorkerKeysMutex.RLock()
+	defer fake.teamWorkerKeysMutex.RUnlock()
+	argsForCall := fake.teamWorkerKeysArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerKeyFactory) TeamWorkerKeysReturns(result1 []db.WorkerKey, result2 error) {
+	fake.teamWorkerKeysMutex.Lock()
+	defer fake.teamWorkerKeysMutex.Unlock()
+	fake.TeamWorkerKeysStub = nil
+	fake.teamWorkerKeysReturns = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) TeamWorkerKeysReturnsOnCall(i int, result1 []db.WorkerKey, result2 error) {
+	fake.teamWorkerKeysMutex.Lock()
+	defer fake.teamWorkerKeysMutex.Unlock()
+	fake.TeamWorkerKeysStub = nil
+	if fake.teamWorkerKeysReturnsOnCall == nil {
+		fake.teamWorkerKeysReturnsOnCall = make(map[int]struct {
+			result1 []db.WorkerKey
+			result2 error
+		})
+	}
+	fake.teamWorkerKeysReturnsOnCall[i] = struct {
+		result1 []db.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerKeyFactory) UseWorkerKey(arg1 string) error {
+	fake.useWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.useWorkerKeyReturnsOnCall[len(fake.useWorkerKeyArgsForCall)]
+	fake.useWorkerKeyArgsForCall = append(fake.useWorkerKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UseWorkerKeyStub
+	fakeReturns := fake.useWorkerKeyReturns
+	fake.recordInvocation("UseWorkerKey", []interface{}{arg1})
+	fake.useWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorkerKeyFactory) UseWorkerKeyCallCount() int {
+	fake.useWorkerKeyMutex.RLock()
+	defer fake.useWorkerKeyMutex.RUnlock()
+	return len(fake.useWorkerKeyArgsForCall)
+}
+
+func (fake *FakeWorkerKeyFactory) UseWorkerKeyCalls(stub func(string) error) {
+	fake.useWorkerKeyMutex.Lock()
+	defer fake.useWorkerKeyMutex.Unlock()
+	fake.UseWorkerKeyStub = stub
+}
+
+func (fake *FakeWorkerKeyFactory) UseWorkerKeyArgsForCall(i int) string {
+	fake.useWorkerKeyMutex.RLock()
+	defer fake.useWorkerKeyMutex.RUnlock()
+	argsForCall := fake.useWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkerKeyFactory) UseWorkerKeyReturns(result1 error) {
+	fake.useWorkerKeyMutex.Lock()
+	defer fake.useWorkerKeyMutex.Unlock()
+	fake.UseWorkerKeyStub = nil
+	fake.useWorkerKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerKeyFactory) UseWorkerKeyReturnsOnCall(i int, result1 error) {
+	fake.useWorkerKeyMutex.Lock()
+	defer fake.useWorkerKeyMutex.Unlock()
+	fake.UseWorkerKeyStub = nil
+	if fake.useWorkerKeyReturnsOnCall == nil {
+		fake.useWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.useWorkerKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerKeyFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authorizeWorkerKeyMutex.RLock()
+	defer fake.authorizeWorkerKeyMutex.RUnlock()
+	fake.createEnrollmentTokenMutex.RLock()
+	defer fake.createEnrollmentTokenMutex.RUnlock()
+	fake.enrollWorkerKeyMutex.RLock()
+	defer fake.enrollWorkerKeyMutex.RUnlock()
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	fake.teamWorkerKeysMutex.RLock()
+	defer fake.teamWorkerKeysMutex.RUnlock()
+	fake.useWorkerKeyMutex.RLock()
+	defer fake.useWorkerKeyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWorkerKeyFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WorkerKeyFactory = new(FakeWorkerKeyFactory)
@@ -8,6 +8,18 @@ import (
 )
 
 type FakeWorkerLifecycle struct {
+	DeleteEvictedWorkersStub        func() ([]string, error)
+	deleteEvictedWorkersMutex       sync.RWMutex
+	deleteEvictedWorkersArgsForCall []struct {
+	}
+	deleteEvictedWorkersReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteEvictedWorkersReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	DeleteFinishedRetiringWorkersStub        func() ([]string, error)
 	deleteFinishedRetiringWorkersMutex       sync.RWMutex
 	deleteFinishedRetiringWorkersArgsForCall []struct {
@@ -72,6 +84,62 @@ type FakeWorkerLifecycle struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeWorkerLifecycle) DeleteEvictedWorkers() ([]string, error) {
+	fake.deleteEvictedWorkersMutex.Lock()
+	ret, specificReturn := fake.deleteEvictedWorkersReturnsOnCall[len(fake.deleteEvictedWorkersArgsForCall)]
+	fake.deleteEvictedWorkersArgsForCall = append(fake.deleteEvictedWorkersArgsForCall, struct {
+	}{})
+	stub := fake.DeleteEvictedWorkersStub
+	fakeReturns := fake.deleteEvictedWorkersReturns
+	fake.recordInvocation("DeleteEvictedWorkers", []interface{}{})
+	fake.deleteEvictedWorkersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) DeleteEvictedWorkersCallCount() int {
+	fake.deleteEvictedWorkersMutex.RLock()
+	defer fake.deleteEvictedWorkersMutex.RUnlock()
+	return len(fake.deleteEvictedWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) DeleteEvictedWorkersCalls(stub func() ([]string, error)) {
+	fake.deleteEvictedWorkersMutex.Lock()
+	defer fake.deleteEvictedWorkersMutex.Unlock()
+	fake.DeleteEvictedWorkersStub = stub
+}
+
+func (fake *FakeWorkerLifecycle) DeleteEvictedWorkersReturns(result1 []string, result2 error) {
+	fake.deleteEvictedWorkersMutex.Lock()
+	defer fake.deleteEvictedWorkersMutex.Unlock()
+	fake.DeleteEvictedWorkersStub = nil
+	fake.deleteEvictedWorkersReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) DeleteEvictedWorkersReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteEvictedWorkersMutex.Lock()
+	defer fake.deleteEvictedWorkersMutex.Unlock()
+	fake.DeleteEvictedWorkersStub = nil
+	if fake.deleteEvictedWorkersReturnsOnCall == nil {
+		fake.deleteEvictedWorkersReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteEvictedWorkersReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkers() ([]string, error) {
 	fake.deleteFinishedRetiringWorkersMutex.Lock()
 	ret, specificReturn := fake.deleteFinishedRetiringWorkersReturnsOnCall[len(fake.deleteFinishedRetiringWorkersArgsForCall)]
@@ -355,6 +423,8 @@ func (fake *FakeWorkerLifecycle) StallUnresponsiveWorkersReturnsOnCall(i int, re
 func (fake *FakeWorkerLifecycle) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deleteEvictedWorkersMutex.RLock()
+	defer fake.deleteEvictedWorkersMutex.RUnlock()
 	fake.deleteFinishedRetiringWorkersMutex.RLock()
 	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
 	fake.deleteUnresponsiveEphemeralWorkersMutex.RLock()
@@ -194,7 +194,7 @@ func (builder Builder) WithResourceVersions(resourceName string, versions ...atc
 			return fmt.Errorf("find or create scope: %w", err)
 		}
 
-		err = scope.SaveVersions(scenario.SpanContext, versions)
+		_, err = scope.SaveVersions(scenario.SpanContext, versions)
 		if err != nil {
 			return fmt.Errorf("save versions: %w", err)
 		}
@@ -265,7 +265,7 @@ func (builder Builder) WithResourceTypeVersions(resourceTypeName string, version
 			return fmt.Errorf("find or create scope: %w", err)
 		}
 
-		err = scope.SaveVersions(db.SpanContext{}, versions)
+		_, err = scope.SaveVersions(db.SpanContext{}, versions)
 		if err != nil {
 			return fmt.Errorf("save versions: %w", err)
 		}
@@ -568,7 +568,7 @@ func (builder Builder) WithPinnedVersion(resourceName string, pinnedVersion atc.
 			}
 		}
 
-		_, err = resource.PinVersion(version.ID())
+		_, err = resource.PinVersion(version.ID(), db.VersionPin{})
 		if err != nil {
 			return err
 		}
@@ -26,6 +26,14 @@ func (p PinnedVersionNotFound) String() ResolutionFailure {
 	return ResolutionFailure(fmt.Sprintf("pinned version%s not found", text))
 }
 
+type PassedJobMissing struct {
+	PassedJob string
+}
+
+func (p PassedJobMissing) String() ResolutionFailure {
+	return ResolutionFailure(fmt.Sprintf("passed job '%s' no longer exists", p.PassedJob))
+}
+
 type JobSet map[int]bool
 
 type InputMapping map[string]InputResult
@@ -14,6 +14,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/tracing"
 	"github.com/lib/pq"
 )
@@ -28,6 +29,27 @@ type InputConfig struct {
 	PinnedVersion   atc.Version
 	ResourceID      int
 	JobID           int
+
+	// PassedResourceIDs maps passed jobs belonging to other pipelines to the
+	// resource in their pipeline which shares this input's resource config.
+	// Versions of that resource satisfy the passed constraint for this input.
+	PassedResourceIDs map[int]int
+
+	// MissingPassedJobs are the jobs in other pipelines which this input must
+	// have passed through but which no longer exist, e.g. because their
+	// pipeline was destroyed. The input can't be satisfied until the pipeline
+	// is set again.
+	MissingPassedJobs []string
+}
+
+// PassedResourceID returns the ID of the resource whose outputs from the
+// given passed job satisfy the input's passed constraint.
+func (cfg InputConfig) PassedResourceID(passedJobID int) int {
+	if resourceID, found := cfg.PassedResourceIDs[passedJobID]; found {
+		return resourceID
+	}
+
+	return cfg.ResourceID
 }
 
 func (cfgs InputConfigs) String() string {
@@ -81,6 +103,7 @@ type Job interface {
 	ScheduleBuild(Build) (bool, error)
 	CreateBuild(createdBy string) (Build, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
+	PromoteVersion(resource Resource, resourceConfigVersionID int, createdBy string) (Build, bool, error)
 
 	RequestSchedule() error
 	UpdateLastScheduled(time.Time) error
@@ -99,6 +122,9 @@ type Job interface {
 
 	ClearTaskCache(string, string) (int64, error)
 
+	KeyedCaches() ([]KeyedCache, error)
+	ClearKeyedCaches(key string) (int64, error)
+
 	AcquireSchedulingLock(lager.Logger) (lock.Lock, bool, error)
 
 	SetHasNewInputs(bool) error
@@ -293,14 +319,118 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 		inputs = append(inputs, inputConfig)
 	}
 
+	passedResourceIDs, err := j.passedResourceIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	missingPassedJobs, err := j.missingPassedJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, input := range inputs {
+		inputs[i].PassedResourceIDs = passedResourceIDs[input.Name]
+		inputs[i].MissingPassedJobs = missingPassedJobs[input.Name]
+	}
+
 	return inputs, nil
 }
 
+// missingPassedJobs finds, for each input with passed constraints on jobs in
+// other pipelines, the jobs which have since been deleted or removed from
+// their pipeline's config.
+func (j *job) missingPassedJobs() (map[string][]string, error) {
+	rows, err := psql.Select("ji.name", "ji.passed_job_ref").
+		From("job_inputs ji").
+		LeftJoin("jobs pj ON pj.id = ji.passed_job_id").
+		Where(sq.Eq{
+			"ji.job_id": j.id,
+		}).
+		Where(sq.NotEq{
+			"ji.passed_job_ref": nil,
+		}).
+		Where(sq.Or{
+			sq.Eq{"pj.id": nil},
+			sq.Eq{"pj.active": false},
+		}).
+		OrderBy("ji.passed_job_ref").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	missingPassedJobs := map[string][]string{}
+	for rows.Next() {
+		var inputName, passedJob string
+		err = rows.Scan(&inputName, &passedJob)
+		if err != nil {
+			return nil, err
+		}
+
+		missingPassedJobs[inputName] = append(missingPassedJobs[inputName], passedJob)
+	}
+
+	return missingPassedJobs, nil
+}
+
+// passedResourceIDs finds, for each input with passed constraints on jobs in
+// other pipelines, the resource used by the passed job which has the same
+// resource config as the input's resource.
+func (j *job) passedResourceIDs() (map[string]map[int]int, error) {
+	rows, err := psql.Select("ji.name", "ji.passed_job_id", "MIN(r.id)").
+		From("job_inputs ji").
+		Join("jobs pj ON pj.id = ji.passed_job_id").
+		Join("resources ir ON ir.id = ji.resource_id").
+		Join("resources r ON r.pipeline_id = pj.pipeline_id AND r.resource_config_id = ir.resource_config_id").
+		Where(sq.Eq{
+			"ji.job_id": j.id,
+			"r.active":  true,
+		}).
+		Where(sq.NotEq{
+			"pj.pipeline_id": j.pipelineID,
+		}).
+		Where(sq.Or{
+			sq.Expr("EXISTS (SELECT 1 FROM job_inputs pji WHERE pji.job_id = pj.id AND pji.resource_id = r.id)"),
+			sq.Expr("EXISTS (SELECT 1 FROM job_outputs pjo WHERE pjo.job_id = pj.id AND pjo.resource_id = r.id)"),
+		}).
+		GroupBy("ji.name, ji.passed_job_id").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	passedResourceIDs := map[string]map[int]int{}
+	for rows.Next() {
+		var inputName string
+		var passedJobID, resourceID int
+
+		err = rows.Scan(&inputName, &passedJobID, &resourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if passedResourceIDs[inputName] == nil {
+			passedResourceIDs[inputName] = map[int]int{}
+		}
+
+		passedResourceIDs[inputName][passedJobID] = resourceID
+	}
+
+	return passedResourceIDs, nil
+}
+
 func (j *job) Inputs() ([]atc.JobInput, error) {
 	rows, err := psql.Select("ji.name", "r.name", "array_agg(p.name ORDER BY p.id)", "ji.trigger", "ji.version").
 		From("job_inputs ji").
 		Join("resources r ON r.id = ji.resource_id").
-		LeftJoin("jobs p ON p.id = ji.passed_job_id").
+		LeftJoin("jobs p ON p.id = ji.passed_job_id AND p.pipeline_id = ?", j.pipelineID).
 		Where(sq.Eq{
 			"ji.job_id": j.id,
 		}).
@@ -893,6 +1023,187 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 	return rerunBuild, nil
 }
 
+// PromotionPlanID is the ID of the plan of builds recorded by PromoteVersion.
+const PromotionPlanID atc.PlanID = "promote"
+
+// PromoteVersion records a succeeded build of the job which outputs the given
+// version of the resource, without running anything. This allows the version
+// to satisfy `passed:` constraints on the job, e.g. to push a hotfix past a
+// broken job.
+//
+// The returned bool is false if the version does not belong to the resource.
+func (j *job) PromoteVersion(resource Resource, resourceConfigVersionID int, createdBy string) (Build, bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	var versionMD5 string
+	var versionJSON, metadataJSON sql.NullString
+	err = psql.Select("v.version_md5", "v.version", "v.metadata").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"v.id": resourceConfigVersionID,
+			"r.id": resource.ID(),
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&versionMD5, &versionJSON, &metadataJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var version atc.Version
+	if versionJSON.Valid {
+		err = json.Unmarshal([]byte(versionJSON.String), &version)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	var metadata []atc.MetadataField
+	if metadataJSON.Valid {
+		err = json.Unmarshal([]byte(metadataJSON.String), &metadata)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	// the build is given a put of the version as its plan so that it can be
+	// told apart from the job's other builds, with the events of the put
+	// saying who promoted it
+	plan := atc.Plan{
+		ID: PromotionPlanID,
+		Put: &atc.PutPlan{
+			Name:     resource.Name(),
+			Type:     resource.Type(),
+			Resource: resource.Name(),
+		},
+	}
+
+	buildName, err := j.getNewBuildName(tx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	build := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":               buildName,
+		"job_id":             j.id,
+		"pipeline_id":        j.pipelineID,
+		"team_id":            j.teamID,
+		"status":             BuildStatusSucceeded,
+		"manually_triggered": true,
+		"created_by":         createdBy,
+		"scheduled":          true,
+		"inputs_ready":       true,
+		"completed":          true,
+		"start_time":         sq.Expr("now()"),
+		"end_time":           sq.Expr("now()"),
+		"public_plan":        plan.Public(),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = psql.Insert("build_resource_config_version_outputs").
+		Columns("build_id", "resource_id", "version_md5", "name").
+		Values(build.ID(), resource.ID(), versionMD5, resource.Name()).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	outputsJSON, err := json.Marshal(map[string][]string{
+		strconv.Itoa(resource.ID()): {versionMD5},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = psql.Insert("successful_build_outputs").
+		Columns("build_id", "job_id", "outputs").
+		Values(build.ID(), j.id, outputsJSON).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, false, err
+	}
+
+	origin := event.Origin{ID: event.OriginID(plan.ID)}
+
+	promotionEvents := []atc.Event{
+		event.Log{
+			Time:    build.EndTime().Unix(),
+			Origin:  origin,
+			Payload: fmt.Sprintf("version promoted past job '%s' by %s without running the job\n", j.name, createdBy),
+		},
+		event.FinishPut{
+			Time:            build.EndTime().Unix(),
+			Origin:          origin,
+			CreatedVersion:  version,
+			CreatedMetadata: metadata,
+		},
+		event.Status{
+			Status: atc.StatusSucceeded,
+			Time:   build.EndTime().Unix(),
+		},
+	}
+
+	for _, promotionEvent := range promotionEvents {
+		err = build.saveEvent(tx, promotionEvent)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		DROP SEQUENCE %s
+	`, buildEventSeq(build.ID())))
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = requestScheduleOnDownstreamJobs(tx, j.id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = updateTransitionBuildForJob(tx, j.id, build.ID(), BuildStatusSucceeded, 0)
+	if err != nil {
+		return nil, false, err
+	}
+
+	latestNonRerunID, err := latestCompletedNonRerunBuild(tx, j.id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = updateLatestCompletedBuildForJob(tx, j.id, latestNonRerunID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id, latestNonRerunID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
 func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -928,6 +1239,51 @@ func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
 	return rowsDeleted, tx.Commit()
 }
 
+func (j *job) KeyedCaches() ([]KeyedCache, error) {
+	rows, err := keyedCachesQuery.
+		Where(sq.Eq{"kc.job_id": j.id}).
+		OrderBy("kc.last_used DESC", "kc.id DESC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var caches []KeyedCache
+	for rows.Next() {
+		cache, err := scanKeyedCache(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		caches = append(caches, cache)
+	}
+
+	return caches, nil
+}
+
+// ClearKeyedCaches removes the job's caches saved under the key, or all of
+// them if the key is empty. Their volumes are left for gc.
+func (j *job) ClearKeyedCaches(key string) (int64, error) {
+	query := psql.Delete("keyed_caches").
+		Where(sq.Eq{"job_id": j.id})
+
+	if key != "" {
+		query = query.Where(sq.Eq{"key": key})
+	}
+
+	result, err := query.
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (j *job) AcquireSchedulingLock(logger lager.Logger) (lock.Lock, bool, error) {
 	return j.lockFactory.Acquire(
 		logger.Session("lock", lager.Data{
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/tracing"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -672,6 +673,162 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("PromoteVersion", func() {
+		var (
+			scenario        *dbtest.Scenario
+			promotedBuild   db.Build
+			promoted        bool
+			promoteErr      error
+			versionID       int
+			downstreamJob   db.Job
+			scheduleRequest time.Time
+		)
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "upstream-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+						{
+							Name: "downstream-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:   "some-resource",
+										Passed: []string{"upstream-job"},
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-type",
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}),
+				builder.WithResourceVersions("some-resource", atc.Version{"version": "v1"}),
+			)
+
+			rcv, found, err := scenario.Resource("some-resource").FindVersion(atc.Version{"version": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			versionID = rcv.ID()
+
+			downstreamJob = scenario.Job("downstream-job")
+			scheduleRequest = downstreamJob.ScheduleRequestedTime()
+		})
+
+		JustBeforeEach(func() {
+			promotedBuild, promoted, promoteErr = scenario.Job("upstream-job").PromoteVersion(
+				scenario.Resource("some-resource"),
+				versionID,
+				defaultBuildCreatedBy,
+			)
+		})
+
+		It("creates a succeeded build of the job", func() {
+			Expect(promoteErr).ToNot(HaveOccurred())
+			Expect(promoted).To(BeTrue())
+
+			Expect(promotedBuild.JobName()).To(Equal("upstream-job"))
+			Expect(promotedBuild.Status()).To(Equal(db.BuildStatusSucceeded))
+			Expect(promotedBuild.IsManuallyTriggered()).To(BeTrue())
+			Expect(promotedBuild.CreatedBy()).ToNot(BeNil())
+			Expect(*promotedBuild.CreatedBy()).To(Equal(defaultBuildCreatedBy))
+
+			job := scenario.Job("upstream-job")
+			finished, _, err := job.FinishedAndNextBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(finished.ID()).To(Equal(promotedBuild.ID()))
+		})
+
+		It("gives the build a put of the version as its plan", func() {
+			Expect(promotedBuild.PublicPlan()).To(Equal(atc.Plan{
+				ID: db.PromotionPlanID,
+				Put: &atc.PutPlan{
+					Name:     "some-resource",
+					Type:     "some-type",
+					Resource: "some-resource",
+				},
+			}.Public()))
+		})
+
+		It("saves events recording who promoted the version", func() {
+			events, err := promotedBuild.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer db.Close(events)
+
+			origin := event.Origin{ID: event.OriginID(db.PromotionPlanID)}
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Time:    promotedBuild.EndTime().Unix(),
+				Origin:  origin,
+				Payload: fmt.Sprintf("version promoted past job 'upstream-job' by %s without running the job\n", defaultBuildCreatedBy),
+			})))
+
+			Expect(events.Next()).To(Equal(envelope(event.FinishPut{
+				Time:           promotedBuild.EndTime().Unix(),
+				Origin:         origin,
+				CreatedVersion: atc.Version{"version": "v1"},
+			})))
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   promotedBuild.EndTime().Unix(),
+			})))
+		})
+
+		It("records the version as an output of the build", func() {
+			builds, err := scenario.Pipeline.GetBuildsWithVersionAsOutput(scenario.Resource("some-resource").ID(), versionID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(promotedBuild.ID()))
+		})
+
+		It("includes the version in the outputs of the build", func() {
+			_, outputs, err := promotedBuild.Resources()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(outputs).To(ConsistOf(db.BuildOutput{
+				Name:    "some-resource",
+				Version: atc.Version{"version": "v1"},
+			}))
+		})
+
+		It("requests schedule on downstream jobs", func() {
+			downstreamJob = scenario.Job("downstream-job")
+			Expect(downstreamJob.ScheduleRequestedTime()).To(BeTemporally(">", scheduleRequest))
+		})
+
+		Context("when the version does not belong to the resource", func() {
+			BeforeEach(func() {
+				versionID = versionID + 100
+			})
+
+			It("does not create a build", func() {
+				Expect(promoteErr).ToNot(HaveOccurred())
+				Expect(promoted).To(BeFalse())
+
+				builds, _, err := scenario.Job("upstream-job").Builds(db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("ScheduleBuild", func() {
 		var (
 			schedulingBuild            db.Build
@@ -2120,6 +2277,87 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("Keyed caches", func() {
+		var someOtherJob db.Job
+
+		saveCache := func(job db.Job, key string) {
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", team.ID()), db.ContainerMetadata{
+				Type:     "task",
+				StepName: "some-task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(team.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = createdVolume.InitializeKeyedCache(job.ID(), "some-path", key, 1024)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		keys := func(job db.Job) []string {
+			caches, err := job.KeyedCaches()
+			Expect(err).ToNot(HaveOccurred())
+
+			var keys []string
+			for _, cache := range caches {
+				keys = append(keys, cache.Key)
+			}
+
+			return keys
+		}
+
+		BeforeEach(func() {
+			var (
+				err   error
+				found bool
+			)
+
+			someOtherJob, found, err = pipeline.Job("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			saveCache(job, "some-key")
+			saveCache(job, "some-other-key")
+			saveCache(someOtherJob, "some-key")
+		})
+
+		Describe("KeyedCaches", func() {
+			It("returns the job's caches, most recently used first", func() {
+				Expect(keys(job)).To(Equal([]string{"some-other-key", "some-key"}))
+			})
+		})
+
+		Describe("ClearKeyedCaches", func() {
+			Context("when a key is provided", func() {
+				It("removes only the job's cache under the key", func() {
+					rowsDeleted, err := job.ClearKeyedCaches("some-key")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rowsDeleted).To(Equal(int64(1)))
+
+					Expect(keys(job)).To(Equal([]string{"some-other-key"}))
+					Expect(keys(someOtherJob)).To(Equal([]string{"some-key"}))
+				})
+			})
+
+			Context("when a key is not provided", func() {
+				It("removes all of the job's caches", func() {
+					rowsDeleted, err := job.ClearKeyedCaches("")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rowsDeleted).To(Equal(int64(2)))
+
+					Expect(keys(job)).To(BeEmpty())
+					Expect(keys(someOtherJob)).To(Equal([]string{"some-key"}))
+				})
+			})
+		})
+	})
+
 	Describe("New Inputs", func() {
 		It("starts out as false", func() {
 			Expect(job.HasNewInputs()).To(BeFalse())
@@ -2212,6 +2450,136 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("when an input's passed constraint references a job in another pipeline", func() {
+			var upstreamPipeline db.Pipeline
+
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithTeam("some-team"),
+				)
+
+				var err error
+				upstreamPipeline, _, err = scenario.Team.SavePipeline(atc.PipelineRef{Name: "upstream-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "upstream-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.PutStep{
+										Name: "upstream-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "upstream-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				scenario.Run(
+					builder.WithPipeline(atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+								PlanSequence: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name:     "some-input",
+											Resource: "some-resource",
+											PassedPipelineJobs: []atc.PassedJob{
+												{Pipeline: "upstream-pipeline", Job: "upstream-job"},
+											},
+										},
+									},
+								},
+							},
+						},
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "some-resource",
+								Type:   dbtest.BaseResourceType,
+								Source: atc.Source{"some": "source"},
+							},
+						},
+					}),
+					builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}),
+				)
+			})
+
+			It("does not map the passed job to a resource until the resource configs match", func() {
+				upstreamJob, found, err := upstreamPipeline.Job("upstream-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(inputs).To(Equal(db.InputConfigs{
+					{
+						Name:       "some-input",
+						JobID:      scenario.Job("some-job").ID(),
+						ResourceID: scenario.Resource("some-resource").ID(),
+						Passed: db.JobSet{
+							upstreamJob.ID(): true,
+						},
+					},
+				}))
+			})
+
+			Context("when the resource in the other pipeline has the same resource config", func() {
+				BeforeEach(func() {
+					upstreamResource, found, err := upstreamPipeline.Resource("upstream-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
+						dbtest.BaseResourceType,
+						atc.Source{"some": "source"},
+						atc.VersionedResourceTypes{},
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					scope, err := resourceConfig.FindOrCreateScope(upstreamResource)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = upstreamResource.SetResourceConfigScope(scope)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("maps the passed job to the resource in its pipeline", func() {
+					upstreamJob, found, err := upstreamPipeline.Job("upstream-job")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					upstreamResource, found, err := upstreamPipeline.Resource("upstream-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(inputs).To(HaveLen(1))
+					Expect(inputs[0].PassedResourceIDs).To(Equal(map[int]int{
+						upstreamJob.ID(): upstreamResource.ID(),
+					}))
+					Expect(inputs[0].PassedResourceID(upstreamJob.ID())).To(Equal(upstreamResource.ID()))
+				})
+			})
+
+			Context("when the other pipeline is destroyed", func() {
+				BeforeEach(func() {
+					err := upstreamPipeline.Destroy()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps the input constrained by the missing job", func() {
+					Expect(inputs).To(HaveLen(1))
+					Expect(inputs[0].Passed).To(BeEmpty())
+					Expect(inputs[0].MissingPassedJobs).To(Equal([]string{"upstream-pipeline/upstream-job"}))
+				})
+			})
+		})
+
 		Context("when the input is pinned through the get step", func() {
 			BeforeEach(func() {
 				scenario = dbtest.Setup(
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// KeyedCache is the content of a task's cache directory saved under a key.
+// Unlike task caches, which live on each worker, a keyed cache is kept in a
+// single volume and is streamed to whichever worker runs the next build of the
+// job. The volume lives on the worker which ran the build that saved the
+// cache, so the cache is lost along with that worker.
+type KeyedCache struct {
+	ID     int
+	TeamID int
+	JobID  int
+
+	Path string
+	Key  string
+	Size int64
+
+	CreatedAt time.Time
+	LastUsed  time.Time
+
+	// VolumeHandle and WorkerName are empty if the cache's volume has gone
+	// away.
+	VolumeHandle string
+	WorkerName   string
+}
+
+var keyedCachesQuery = psql.Select(
+	"kc.id",
+	"kc.team_id",
+	"kc.job_id",
+	"kc.path",
+	"kc.key",
+	"kc.size",
+	"kc.created_at",
+	"kc.last_used",
+	"v.handle",
+	"v.worker_name",
+).
+	From("keyed_caches kc").
+	LeftJoin("volumes v ON v.keyed_cache_id = kc.id AND v.state = ?", string(VolumeStateCreated))
+
+func scanKeyedCache(row scannable) (KeyedCache, error) {
+	var cache KeyedCache
+	var handle, workerName sql.NullString
+
+	err := row.Scan(
+		&cache.ID,
+		&cache.TeamID,
+		&cache.JobID,
+		&cache.Path,
+		&cache.Key,
+		&cache.Size,
+		&cache.CreatedAt,
+		&cache.LastUsed,
+		&handle,
+		&workerName,
+	)
+	if err != nil {
+		return KeyedCache{}, err
+	}
+
+	cache.VolumeHandle = handle.String
+	cache.WorkerName = workerName.String
+
+	return cache, nil
+}
+
+// escapeLike escapes the wildcards in a LIKE pattern, so that it matches the
+// string literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . KeyedCacheFactory
+
+type KeyedCacheFactory interface {
+	// Find returns the job's cache for the path saved under the key. Failing
+	// that, each of the restore keys is tried in order, returning the most
+	// recently saved cache whose key starts with it.
+	//
+	// Only caches whose volume still exists are returned, and the cache which
+	// is found is marked as used.
+	Find(jobID int, path string, key string, restoreKeys []string) (KeyedCache, bool, error)
+}
+
+type keyedCacheFactory struct {
+	conn Conn
+}
+
+func NewKeyedCacheFactory(conn Conn) KeyedCacheFactory {
+	return &keyedCacheFactory{
+		conn: conn,
+	}
+}
+
+func (f *keyedCacheFactory) Find(jobID int, path string, key string, restoreKeys []string) (KeyedCache, bool, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return KeyedCache{}, false, err
+	}
+
+	defer Rollback(tx)
+
+	cache, found, err := f.find(tx, jobID, path, sq.Eq{"kc.key": key})
+	if err != nil {
+		return KeyedCache{}, false, err
+	}
+
+	for _, restoreKey := range restoreKeys {
+		if found {
+			break
+		}
+
+		cache, found, err = f.find(tx, jobID, path, sq.Like{"kc.key": escapeLike(restoreKey) + "%"})
+		if err != nil {
+			return KeyedCache{}, false, err
+		}
+	}
+
+	if !found {
+		return KeyedCache{}, false, nil
+	}
+
+	err = psql.Update("keyed_caches").
+		Set("last_used", sq.Expr("now()")).
+		Where(sq.Eq{"id": cache.ID}).
+		Suffix("RETURNING last_used").
+		RunWith(tx).
+		QueryRow().
+		Scan(&cache.LastUsed)
+	if err != nil {
+		return KeyedCache{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return KeyedCache{}, false, err
+	}
+
+	return cache, true, nil
+}
+
+func (f *keyedCacheFactory) find(tx Tx, jobID int, path string, keyCondition sq.Sqlizer) (KeyedCache, bool, error) {
+	row := keyedCachesQuery.
+		Where(sq.Eq{
+			"kc.job_id": jobID,
+			"kc.path":   path,
+		}).
+		Where(keyCondition).
+		Where(sq.NotEq{"v.handle": nil}).
+		OrderBy("kc.created_at DESC", "kc.id DESC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow()
+
+	cache, err := scanKeyedCache(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return KeyedCache{}, false, nil
+		}
+
+		return KeyedCache{}, false, err
+	}
+
+	return cache, true, nil
+}
@@ -0,0 +1,124 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyedCacheFactory", func() {
+	saveCache := func(path string, key string) db.CreatedVolume {
+		build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{
+			Type:     "task",
+			StepName: "some-task",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, path)
+		Expect(err).ToNot(HaveOccurred())
+
+		createdVolume, err := creatingVolume.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = createdVolume.InitializeKeyedCache(defaultJob.ID(), path, key, 1024)
+		Expect(err).ToNot(HaveOccurred())
+
+		return createdVolume
+	}
+
+	Describe("Find", func() {
+		Context("when there are no caches", func() {
+			It("returns not found", func() {
+				_, found, err := keyedCacheFactory.Find(defaultJob.ID(), "some-path", "some-key", []string{"some-"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there is a cache saved under the key", func() {
+			var volume db.CreatedVolume
+
+			BeforeEach(func() {
+				saveCache("some-path", "some-other-key")
+				volume = saveCache("some-path", "some-key")
+			})
+
+			It("returns it", func() {
+				cache, found, err := keyedCacheFactory.Find(defaultJob.ID(), "some-path", "some-key", []string{"some-"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(cache.Key).To(Equal("some-key"))
+				Expect(cache.Size).To(Equal(int64(1024)))
+				Expect(cache.TeamID).To(Equal(defaultTeam.ID()))
+				Expect(cache.VolumeHandle).To(Equal(volume.Handle()))
+			})
+
+			It("does not return it for another path", func() {
+				_, found, err := keyedCacheFactory.Find(defaultJob.ID(), "some-other-path", "some-key", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("marks it as used", func() {
+				cache, _, err := keyedCacheFactory.Find(defaultJob.ID(), "some-path", "some-key", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				usedAgain, _, err := keyedCacheFactory.Find(defaultJob.ID(), "some-path", "some-key", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(usedAgain.LastUsed).To(BeTemporally(">", cache.LastUsed))
+			})
+
+			Context("when a new volume is saved under the key", func() {
+				var newVolume db.CreatedVolume
+
+				BeforeEach(func() {
+					newVolume = saveCache("some-path", "some-key")
+				})
+
+				It("returns the new volume", func() {
+					cache, found, err := keyedCacheFactory.Find(defaultJob.ID(), "some-path", "some-key", nil)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(cache.VolumeHandle).To(Equal(newVolume.Handle()))
+				})
+
+				It("releases the old volume", func() {
+					orphaned, err := volumeRepository.GetOrphanedVolumes()
+					Expect(err).ToNot(HaveOccurred())
+
+					var handles []string
+					for _, v := range orphaned {
+						handles = append(handles, v.Handle())
+					}
+
+					Expect(handles).ToNot(ContainElement(newVolume.Handle()))
+				})
+			})
+		})
+
+		Context("when only restore keys match", func() {
+			BeforeEach(func() {
+				saveCache("some-path", "maven-older")
+				saveCache("some-path", "maven-newer")
+				saveCache("some-path", "gradle-newest")
+			})
+
+			It("returns the most recently saved cache matching the first restore key with one", func() {
+				cache, found, err := keyedCacheFactory.Find(defaultJob.ID(), "some-path", "maven-latest", []string{"npm-", "maven-", "gradle-"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(cache.Key).To(Equal("maven-newer"))
+			})
+
+			It("matches restore keys literally", func() {
+				_, found, err := keyedCacheFactory.Find(defaultJob.ID(), "some-path", "some-key", []string{"m%"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . KeyedCacheLifecycle
+
+type KeyedCacheLifecycle interface {
+	// RemoveUnusedKeyedCaches removes caches which haven't been used for
+	// longer than the given duration, along with caches whose volume has gone
+	// away.
+	RemoveUnusedKeyedCaches(maxIdle time.Duration) (int64, error)
+
+	// RemoveKeyedCachesExceedingTeamSize removes the least recently used
+	// caches of each team until the total size of the team's caches is within
+	// the limit.
+	RemoveKeyedCachesExceedingTeamSize(maxSize int64) (int64, error)
+}
+
+type keyedCacheLifecycle struct {
+	conn Conn
+}
+
+func NewKeyedCacheLifecycle(conn Conn) KeyedCacheLifecycle {
+	return &keyedCacheLifecycle{
+		conn: conn,
+	}
+}
+
+func (lifecycle *keyedCacheLifecycle) RemoveUnusedKeyedCaches(maxIdle time.Duration) (int64, error) {
+	unused := sq.Or{
+		sq.Expr("NOT EXISTS (SELECT 1 FROM volumes v WHERE v.keyed_cache_id = keyed_caches.id)"),
+	}
+
+	if maxIdle > 0 {
+		unused = append(unused, sq.Expr(fmt.Sprintf("last_used < now() - '%d seconds'::interval", int(maxIdle.Seconds()))))
+	}
+
+	result, err := psql.Delete("keyed_caches").
+		Where(unused).
+		RunWith(lifecycle.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (lifecycle *keyedCacheLifecycle) RemoveKeyedCachesExceedingTeamSize(maxSize int64) (int64, error) {
+	result, err := psql.Delete("keyed_caches").
+		Where(sq.Expr(`id IN (
+			SELECT id FROM (
+				SELECT id, sum(size) OVER (PARTITION BY team_id ORDER BY last_used DESC, id DESC) AS team_size
+				FROM keyed_caches
+			) c
+			WHERE c.team_size > ?
+		)`, maxSize)).
+		RunWith(lifecycle.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
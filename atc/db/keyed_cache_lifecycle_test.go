@@ -0,0 +1,82 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyedCacheLifecycle", func() {
+	saveCache := func(key string, size int64) {
+		build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{
+			Type:     "task",
+			StepName: "some-task",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+		Expect(err).ToNot(HaveOccurred())
+
+		createdVolume, err := creatingVolume.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = createdVolume.InitializeKeyedCache(defaultJob.ID(), "some-path", key, size)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	keys := func() []string {
+		caches, err := defaultJob.KeyedCaches()
+		Expect(err).ToNot(HaveOccurred())
+
+		var keys []string
+		for _, cache := range caches {
+			keys = append(keys, cache.Key)
+		}
+
+		return keys
+	}
+
+	Describe("RemoveUnusedKeyedCaches", func() {
+		BeforeEach(func() {
+			saveCache("some-old-key", 1)
+			_, err := dbConn.Exec(`UPDATE keyed_caches SET last_used = now() - '2 days'::interval WHERE key = 'some-old-key'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			saveCache("some-key", 1)
+
+			saveCache("some-missing-key", 1)
+			_, err = dbConn.Exec(`UPDATE volumes SET keyed_cache_id = NULL WHERE keyed_cache_id = (SELECT id FROM keyed_caches WHERE key = 'some-missing-key')`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes caches which haven't been used recently or whose volume is gone", func() {
+			removed, err := keyedCacheLifecycle.RemoveUnusedKeyedCaches(24 * time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(int64(2)))
+
+			Expect(keys()).To(ConsistOf("some-key"))
+		})
+	})
+
+	Describe("RemoveKeyedCachesExceedingTeamSize", func() {
+		BeforeEach(func() {
+			saveCache("least-recently-used", 300)
+			saveCache("recently-used", 300)
+			saveCache("most-recently-used", 300)
+		})
+
+		It("removes the least recently used caches beyond the limit", func() {
+			removed, err := keyedCacheLifecycle.RemoveKeyedCachesExceedingTeamSize(700)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(int64(1)))
+
+			Expect(keys()).To(ConsistOf("recently-used", "most-recently-used"))
+		})
+	})
+})
@@ -21,6 +21,7 @@ const (
 	LockTypeDatabaseMigration
 	LockTypeResourceScanning
 	LockTypeJobScheduling
+	LockTypeLayerStorePopulating
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -53,6 +54,10 @@ func NewJobSchedulingLockID(jobID int) LockID {
 	return LockID{LockTypeJobScheduling, jobID}
 }
 
+func NewLayerStorePopulatingLockID(workerName string, digest string) LockID {
+	return LockID{LockTypeLayerStorePopulating, lockIDFromString(workerName + "/" + digest)}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
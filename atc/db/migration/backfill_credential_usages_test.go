@@ -0,0 +1,90 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backfill credential usages", func() {
+	const preMigrationVersion = 1613225000
+	const postMigrationVersion = 1613230000
+
+	var (
+		db *sql.DB
+	)
+
+	type credentialUsage struct {
+		pipelineID int
+		usageType  string
+		name       string
+		varSource  string
+		path       string
+	}
+
+	Context("Up", func() {
+		It("records the credential usages of pipelines which have none recorded", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			_, err := db.Exec(`
+			INSERT INTO teams(id, name) VALUES
+			(1, 'some-team')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+			INSERT INTO pipelines(id, team_id, name, var_sources) VALUES
+			(1, 1, 'pipeline1', '[{"name": "some-source", "type": "vault", "config": {"client_token": "((vault-token))"}}]'),
+			(2, 1, 'pipeline2', '[]')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+			INSERT INTO resources(name, pipeline_id, config, active, type) VALUES
+			('some-resource', 1, '{"name": "some-resource", "type": "git", "source": {"private_key": "((some-source:deploy-key))"}}', true, 'git'),
+			('inactive-resource', 1, '{"name": "inactive-resource", "type": "git", "source": {"private_key": "((old-key))"}}', false, 'git'),
+			('other-resource', 2, '{"name": "other-resource", "type": "git", "source": {"private_key": "((other-key))"}}', true, 'git')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+			INSERT INTO jobs(name, pipeline_id, config, active) VALUES
+			('some-job', 1, '{"name": "some-job", "plan": [{"task": "some-task", "params": {"TOKEN": "((token))", "LOCAL": "((.:local))"}}]}', true)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+			INSERT INTO credential_usages(pipeline_id, type, name, var_source, path) VALUES
+			(2, 'resource', 'other-resource', '', 'already-recorded')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+
+			rows, err := db.Query(`SELECT pipeline_id, type, name, var_source, path FROM credential_usages`)
+			Expect(err).NotTo(HaveOccurred())
+
+			var usages []credentialUsage
+			for rows.Next() {
+				var u credentialUsage
+
+				err := rows.Scan(&u.pipelineID, &u.usageType, &u.name, &u.varSource, &u.path)
+				Expect(err).NotTo(HaveOccurred())
+
+				usages = append(usages, u)
+			}
+
+			_ = db.Close()
+
+			Expect(usages).To(ConsistOf(
+				credentialUsage{pipelineID: 1, usageType: "var_source", name: "some-source", path: "vault-token"},
+				credentialUsage{pipelineID: 1, usageType: "resource", name: "some-resource", varSource: "some-source", path: "deploy-key"},
+				credentialUsage{pipelineID: 1, usageType: "job", name: "some-job", path: "token"},
+				credentialUsage{pipelineID: 2, usageType: "resource", name: "other-resource", path: "already-recorded"},
+			))
+		})
+	})
+})
@@ -0,0 +1,5 @@
+DROP INDEX resource_pins_expires_at_idx;
+
+ALTER TABLE resource_pins
+  DROP COLUMN pinned_by,
+  DROP COLUMN expires_at;
@@ -0,0 +1,5 @@
+CREATE TABLE check_build_results (
+  build_id integer PRIMARY KEY REFERENCES builds (id) ON DELETE CASCADE,
+  versions_found integer NOT NULL,
+  latest_version jsonb
+);
@@ -0,0 +1,2 @@
+ALTER TABLE resource_config_scopes
+  DROP COLUMN last_new_version_time;
@@ -0,0 +1,2 @@
+ALTER TABLE resource_config_scopes
+  ADD COLUMN last_new_version_time timestamp with time zone NOT NULL DEFAULT now();
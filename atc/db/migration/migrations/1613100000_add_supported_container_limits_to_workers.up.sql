@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  ADD COLUMN supported_container_limits text;
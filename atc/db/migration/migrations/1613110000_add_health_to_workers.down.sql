@@ -0,0 +1,6 @@
+ALTER TABLE workers
+  DROP COLUMN health_score,
+  DROP COLUMN container_creation_failures,
+  DROP COLUMN volume_streaming_failures,
+  DROP COLUMN errored_steps,
+  DROP COLUMN quarantined;
@@ -0,0 +1,6 @@
+ALTER TABLE workers
+  ADD COLUMN health_score double precision NOT NULL DEFAULT 1,
+  ADD COLUMN container_creation_failures bigint NOT NULL DEFAULT 0,
+  ADD COLUMN volume_streaming_failures bigint NOT NULL DEFAULT 0,
+  ADD COLUMN errored_steps bigint NOT NULL DEFAULT 0,
+  ADD COLUMN quarantined boolean NOT NULL DEFAULT false;
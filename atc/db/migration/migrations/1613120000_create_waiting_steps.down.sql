@@ -0,0 +1 @@
+DROP TABLE waiting_steps;
@@ -0,0 +1,9 @@
+CREATE TABLE waiting_steps (
+  id bigserial PRIMARY KEY,
+  platform text NOT NULL,
+  tags text NOT NULL,
+  team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+  expires timestamp with time zone NOT NULL
+);
+
+CREATE INDEX waiting_steps_expires_idx ON waiting_steps (expires);
@@ -0,0 +1,23 @@
+-- values can't be added to an enum in a transaction before Postgres 12, so
+-- the type is recreated instead
+ALTER TYPE worker_state RENAME TO worker_state_old;
+
+CREATE TYPE worker_state AS ENUM (
+    'running',
+    'stalled',
+    'landing',
+    'landed',
+    'retiring',
+    'evicting'
+);
+
+ALTER TABLE workers
+  DROP CONSTRAINT addr_when_running,
+  ALTER COLUMN state DROP DEFAULT;
+
+ALTER TABLE workers
+  ALTER COLUMN state TYPE worker_state USING state::text::worker_state,
+  ALTER COLUMN state SET DEFAULT 'running'::worker_state,
+  ADD CONSTRAINT addr_when_running CHECK (((state <> 'stalled'::worker_state) AND (state <> 'landed'::worker_state) AND ((addr IS NOT NULL) OR (baggageclaim_url IS NOT NULL))) OR (state = 'stalled'::worker_state) OR (state = 'landed'::worker_state));
+
+DROP TYPE worker_state_old;
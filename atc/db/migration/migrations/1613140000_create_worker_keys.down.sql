@@ -0,0 +1,3 @@
+DROP TABLE worker_enrollment_tokens;
+
+DROP TABLE worker_keys;
@@ -0,0 +1,17 @@
+CREATE TABLE worker_keys (
+  id bigserial PRIMARY KEY,
+  team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+  public_key text NOT NULL,
+  fingerprint text NOT NULL UNIQUE,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  last_used_at timestamp with time zone,
+  revoked_at timestamp with time zone
+);
+
+CREATE INDEX worker_keys_team_id_idx ON worker_keys (team_id);
+
+CREATE TABLE worker_enrollment_tokens (
+  token_hash text PRIMARY KEY,
+  team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+  expires timestamp with time zone NOT NULL
+);
@@ -0,0 +1,6 @@
+DROP INDEX volumes_keyed_cache_id_idx;
+
+ALTER TABLE volumes
+  DROP COLUMN keyed_cache_id;
+
+DROP TABLE keyed_caches;
@@ -0,0 +1,18 @@
+CREATE TABLE keyed_caches (
+  id serial PRIMARY KEY,
+  team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+  job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+  path text NOT NULL,
+  key text NOT NULL,
+  size bigint NOT NULL DEFAULT 0,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  last_used timestamp with time zone NOT NULL DEFAULT now(),
+  UNIQUE (job_id, path, key)
+);
+
+CREATE INDEX keyed_caches_team_id_last_used_idx ON keyed_caches (team_id, last_used);
+
+ALTER TABLE volumes
+  ADD COLUMN keyed_cache_id integer REFERENCES keyed_caches (id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX volumes_keyed_cache_id_idx ON volumes (keyed_cache_id);
@@ -0,0 +1,5 @@
+DROP INDEX worker_artifacts_build_id_plan_id_idx;
+
+ALTER TABLE worker_artifacts
+  DROP COLUMN plan_id,
+  DROP COLUMN expires_at;
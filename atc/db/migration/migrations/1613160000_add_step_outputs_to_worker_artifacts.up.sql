@@ -0,0 +1,5 @@
+ALTER TABLE worker_artifacts
+  ADD COLUMN plan_id text,
+  ADD COLUMN expires_at timestamp with time zone;
+
+CREATE INDEX worker_artifacts_build_id_plan_id_idx ON worker_artifacts (build_id, plan_id);
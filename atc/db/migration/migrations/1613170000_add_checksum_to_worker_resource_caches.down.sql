@@ -0,0 +1,3 @@
+ALTER TABLE worker_resource_caches
+  DROP COLUMN checksum,
+  DROP COLUMN verified_at;
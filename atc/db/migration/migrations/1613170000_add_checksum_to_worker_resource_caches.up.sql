@@ -0,0 +1,3 @@
+ALTER TABLE worker_resource_caches
+  ADD COLUMN checksum text,
+  ADD COLUMN verified_at timestamp with time zone;
@@ -0,0 +1,10 @@
+-- build_id deliberately has no foreign key, so that the leases of deleted
+-- builds are still around to be revoked
+CREATE TABLE build_secret_leases (
+  build_id integer NOT NULL,
+  lease_id text NOT NULL,
+  expires_at timestamp with time zone NOT NULL,
+  PRIMARY KEY (build_id, lease_id)
+);
+
+CREATE INDEX build_secret_leases_expires_at_idx ON build_secret_leases (expires_at);
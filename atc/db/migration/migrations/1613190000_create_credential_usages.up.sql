@@ -0,0 +1,10 @@
+CREATE TABLE credential_usages (
+  pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+  type text NOT NULL,
+  name text NOT NULL,
+  var_source text NOT NULL DEFAULT '',
+  path text NOT NULL,
+  PRIMARY KEY (pipeline_id, type, name, var_source, path)
+);
+
+CREATE INDEX credential_usages_path_idx ON credential_usages (path);
@@ -0,0 +1,6 @@
+CREATE TABLE cache_invalidations (
+  id bigserial PRIMARY KEY,
+  team_name text NOT NULL,
+  path text NOT NULL DEFAULT '',
+  created_at timestamp with time zone NOT NULL DEFAULT now()
+);
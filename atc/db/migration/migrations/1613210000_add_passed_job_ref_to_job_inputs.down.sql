@@ -0,0 +1,6 @@
+DELETE FROM job_inputs WHERE passed_job_id IS NULL AND passed_job_ref IS NOT NULL;
+
+ALTER TABLE job_inputs DROP CONSTRAINT job_inputs_passed_job_id_fkey;
+ALTER TABLE job_inputs ADD CONSTRAINT job_inputs_passed_job_id_fkey FOREIGN KEY (passed_job_id) REFERENCES jobs(id) ON DELETE CASCADE;
+
+ALTER TABLE job_inputs DROP COLUMN passed_job_ref;
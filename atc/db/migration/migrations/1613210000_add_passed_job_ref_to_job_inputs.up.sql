@@ -0,0 +1,4 @@
+ALTER TABLE job_inputs ADD COLUMN passed_job_ref text;
+
+ALTER TABLE job_inputs DROP CONSTRAINT job_inputs_passed_job_id_fkey;
+ALTER TABLE job_inputs ADD CONSTRAINT job_inputs_passed_job_id_fkey FOREIGN KEY (passed_job_id) REFERENCES jobs(id) ON DELETE SET NULL;
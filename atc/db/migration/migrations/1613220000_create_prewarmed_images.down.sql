@@ -0,0 +1,4 @@
+ALTER TABLE resource_cache_uses DROP COLUMN prewarmed_image_id;
+ALTER TABLE containers DROP COLUMN prewarmed_image_id;
+
+DROP TABLE prewarmed_images;
@@ -0,0 +1,19 @@
+CREATE TABLE prewarmed_images (
+  id serial PRIMARY KEY,
+  pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+  image_key text NOT NULL,
+  resource_id integer REFERENCES resources (id) ON DELETE CASCADE,
+  resource_config_scope_id integer REFERENCES resource_config_scopes (id) ON DELETE SET NULL,
+  version_md5 text,
+  warming_until timestamp with time zone,
+  UNIQUE (pipeline_id, image_key)
+);
+
+CREATE INDEX prewarmed_images_resource_id ON prewarmed_images (resource_id);
+CREATE INDEX prewarmed_images_resource_config_scope_id ON prewarmed_images (resource_config_scope_id);
+
+ALTER TABLE containers ADD COLUMN prewarmed_image_id integer REFERENCES prewarmed_images (id) ON DELETE SET NULL;
+CREATE INDEX containers_prewarmed_image_id ON containers (prewarmed_image_id);
+
+ALTER TABLE resource_cache_uses ADD COLUMN prewarmed_image_id integer REFERENCES prewarmed_images (id) ON DELETE CASCADE;
+CREATE INDEX resource_cache_uses_prewarmed_image_id ON resource_cache_uses (prewarmed_image_id);
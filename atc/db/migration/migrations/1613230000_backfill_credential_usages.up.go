@@ -0,0 +1,177 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/concourse/concourse/vars"
+)
+
+type V7CredentialUsage struct {
+	PipelineID int
+	Type       string
+	Name       string
+	VarSource  string
+	Path       string
+}
+
+type V7NamedConfig struct {
+	Name string `json:"name"`
+}
+
+// Up_1613230000 records the credential usages of the pipelines which were
+// configured before they were recorded, the same way configuring them again
+// would.
+func (m *migrations) Up_1613230000() error {
+	tx := m.Tx
+
+	var usages []V7CredentialUsage
+
+	add := func(pipelineID int, usageType string, name string, config []byte) error {
+		refs, err := vars.NewTemplate(config).References()
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range refs {
+			if ref.Source == "." {
+				continue
+			}
+
+			usages = append(usages, V7CredentialUsage{
+				PipelineID: pipelineID,
+				Type:       usageType,
+				Name:       name,
+				VarSource:  ref.Source,
+				Path:       ref.Path,
+			})
+		}
+
+		return nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT p.id, p.var_sources, p.nonce
+		FROM pipelines p
+		WHERE p.var_sources IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM credential_usages u WHERE u.pipeline_id = p.id)`)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var (
+			pipelineID int
+			configBlob []byte
+			nonce      sql.NullString
+		)
+
+		err = rows.Scan(&pipelineID, &configBlob, &nonce)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		decrypted, err := m.decrypt(configBlob, nonce)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		var varSources []json.RawMessage
+		err = json.Unmarshal(decrypted, &varSources)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		for _, varSource := range varSources {
+			var named V7NamedConfig
+			err = json.Unmarshal(varSource, &named)
+			if err != nil {
+				_ = rows.Close()
+				return err
+			}
+
+			err = add(pipelineID, "var_source", named.Name, varSource)
+			if err != nil {
+				_ = rows.Close()
+				return err
+			}
+		}
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for table, usageType := range map[string]string{
+		"resource_types": "resource_type",
+		"resources":      "resource",
+		"jobs":           "job",
+	} {
+		rows, err := tx.Query(`
+			SELECT c.pipeline_id, c.name, c.config, c.nonce
+			FROM ` + table + ` c
+			WHERE c.active = true
+			AND c.config IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM credential_usages u WHERE u.pipeline_id = c.pipeline_id)`)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var (
+				pipelineID int
+				name       string
+				configBlob []byte
+				nonce      sql.NullString
+			)
+
+			err = rows.Scan(&pipelineID, &name, &configBlob, &nonce)
+			if err != nil {
+				_ = rows.Close()
+				return err
+			}
+
+			decrypted, err := m.decrypt(configBlob, nonce)
+			if err != nil {
+				_ = rows.Close()
+				return err
+			}
+
+			err = add(pipelineID, usageType, name, decrypted)
+			if err != nil {
+				_ = rows.Close()
+				return err
+			}
+		}
+
+		err = rows.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, usage := range usages {
+		_, err = tx.Exec(`
+			INSERT INTO credential_usages (pipeline_id, type, name, var_source, path)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT DO NOTHING`, usage.PipelineID, usage.Type, usage.Name, usage.VarSource, usage.Path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *migrations) decrypt(configBlob []byte, nonce sql.NullString) ([]byte, error) {
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	return m.Strategy.Decrypt(string(configBlob), noncense)
+}
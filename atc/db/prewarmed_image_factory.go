@@ -0,0 +1,274 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// PrewarmedImageRef identifies an image used by a pipeline's tasks.
+type PrewarmedImageRef struct {
+	// Key identifies the image's configuration within the pipeline.
+	Key string
+
+	// Resource is the name of the pipeline resource the image is fetched
+	// from, if any.
+	Resource string
+}
+
+// PrewarmedImage is the pre-warming state of an image used by a pipeline's
+// tasks.
+type PrewarmedImage struct {
+	ID  int
+	Key string
+
+	// ResourceConfigScopeID is the scope holding the image's versions, or 0
+	// if it has not been looked up yet. For images fetched from a pipeline
+	// resource, it is the resource's scope.
+	ResourceConfigScopeID int
+
+	// LatestVersion is the latest version in the image's scope, and
+	// PinnedVersion the version its resource is pinned to, if any.
+	LatestVersion atc.Version
+	PinnedVersion atc.Version
+
+	// WarmedVersionMD5 identifies the version last pre-warmed, once its
+	// pre-warming finished.
+	WarmedVersionMD5 string
+
+	// Warming is set while the image is being pre-warmed, or after failing
+	// to, until the pre-warming times out.
+	Warming bool
+}
+
+//go:generate counterfeiter . PrewarmedImageFactory
+
+// PrewarmedImageFactory keeps track of the images which the image prewarmer
+// fetches onto workers ahead of builds, so that every ATC knows which
+// versions have been pre-warmed.
+type PrewarmedImageFactory interface {
+	// Sync records the images currently used by the pipeline, forgetting the
+	// ones it no longer uses, and returns their state.
+	Sync(pipelineID int, images []PrewarmedImageRef) ([]PrewarmedImage, error)
+
+	// SetResourceConfigScope records the scope holding the image's versions.
+	SetResourceConfigScope(id int, scopeID int) error
+
+	// StartWarming claims pre-warming the given version of the image for up
+	// to the timeout, returning false if it is already pre-warmed or being
+	// pre-warmed. A pre-warming which timed out without finishing can be
+	// claimed again. The resource caches of versions pre-warmed before stop
+	// being kept around.
+	StartWarming(id int, versionMD5 string, timeout time.Duration) (bool, error)
+
+	// FinishWarming releases the image's containers. If pre-warming failed,
+	// it is tried again once the timeout passed.
+	FinishWarming(id int, succeeded bool) error
+}
+
+type prewarmedImageFactory struct {
+	conn Conn
+}
+
+func NewPrewarmedImageFactory(conn Conn) PrewarmedImageFactory {
+	return &prewarmedImageFactory{
+		conn: conn,
+	}
+}
+
+func (factory *prewarmedImageFactory) Sync(pipelineID int, images []PrewarmedImageRef) ([]PrewarmedImage, error) {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	keys := make([]string, len(images))
+	for i, image := range images {
+		keys[i] = image.Key
+	}
+
+	_, err = psql.Delete("prewarmed_images").
+		Where(sq.Eq{"pipeline_id": pipelineID}).
+		Where(sq.Expr("NOT (image_key = ANY(?))", pq.Array(keys))).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range images {
+		var resourceID interface{}
+		if image.Resource != "" {
+			resourceID = sq.Expr("(SELECT id FROM resources WHERE pipeline_id = ? AND name = ?)", pipelineID, image.Resource)
+		}
+
+		_, err = psql.Insert("prewarmed_images").
+			Columns("pipeline_id", "image_key", "resource_id").
+			Values(pipelineID, image.Key, resourceID).
+			Suffix("ON CONFLICT (pipeline_id, image_key) DO NOTHING").
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := psql.Select(
+		"pi.id",
+		"pi.image_key",
+		"COALESCE(r.resource_config_scope_id, pi.resource_config_scope_id, 0)",
+		"v.version",
+		"rp.version",
+		"CASE WHEN pi.warming_until IS NULL THEN COALESCE(pi.version_md5, '') ELSE '' END",
+		"COALESCE(pi.warming_until > now(), false)",
+	).
+		From("prewarmed_images pi").
+		LeftJoin("resources r ON r.id = pi.resource_id").
+		LeftJoin("resource_pins rp ON rp.resource_id = pi.resource_id").
+		JoinClause(`LEFT JOIN LATERAL (
+			SELECT rcv.version
+			FROM resource_config_versions rcv
+			WHERE rcv.resource_config_scope_id = COALESCE(r.resource_config_scope_id, pi.resource_config_scope_id)
+			ORDER BY rcv.check_order DESC
+			LIMIT 1
+		) v ON true`).
+		Where(sq.Eq{"pi.pipeline_id": pipelineID}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var prewarmedImages []PrewarmedImage
+	for rows.Next() {
+		var image PrewarmedImage
+		var latestVersion, pinnedVersion sql.NullString
+		err = rows.Scan(&image.ID, &image.Key, &image.ResourceConfigScopeID, &latestVersion, &pinnedVersion, &image.WarmedVersionMD5, &image.Warming)
+		if err != nil {
+			return nil, err
+		}
+
+		if latestVersion.Valid {
+			err = json.Unmarshal([]byte(latestVersion.String), &image.LatestVersion)
+			if err != nil {
+				return nil, fmt.Errorf("unmarshal latest version: %w", err)
+			}
+		}
+
+		if pinnedVersion.Valid {
+			err = json.Unmarshal([]byte(pinnedVersion.String), &image.PinnedVersion)
+			if err != nil {
+				return nil, fmt.Errorf("unmarshal pinned version: %w", err)
+			}
+		}
+
+		prewarmedImages = append(prewarmedImages, image)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return prewarmedImages, nil
+}
+
+func (factory *prewarmedImageFactory) SetResourceConfigScope(id int, scopeID int) error {
+	_, err := psql.Update("prewarmed_images").
+		Set("resource_config_scope_id", scopeID).
+		Where(sq.Eq{"id": id}).
+		RunWith(factory.conn).
+		Exec()
+	return err
+}
+
+func (factory *prewarmedImageFactory) StartWarming(id int, versionMD5 string, timeout time.Duration) (bool, error) {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("prewarmed_images").
+		Set("version_md5", versionMD5).
+		Set("warming_until", sq.Expr(fmt.Sprintf("now() + '%d seconds'::interval", int(timeout.Seconds())))).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Or{
+			sq.Expr("version_md5 IS DISTINCT FROM ?", versionMD5),
+			sq.Expr("warming_until < now()"),
+		}).
+		Where(sq.Expr("(warming_until IS NULL OR warming_until < now())")).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	_, err = psql.Delete("resource_cache_uses").
+		Where(sq.Eq{"prewarmed_image_id": id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (factory *prewarmedImageFactory) FinishWarming(id int, succeeded bool) error {
+	update := psql.Update("prewarmed_images").
+		Where(sq.Eq{"id": id})
+
+	if succeeded {
+		update = update.Set("warming_until", nil)
+	} else {
+		update = update.Set("version_md5", nil)
+	}
+
+	_, err := update.RunWith(factory.conn).Exec()
+	return err
+}
+
+// scopeHasPrewarmedImages returns whether any image pre-warmed by the image
+// prewarmer has its versions in the given scope.
+func scopeHasPrewarmedImages(tx Tx, rcsID int) (bool, error) {
+	var prewarmed bool
+	err := tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM prewarmed_images WHERE resource_config_scope_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM prewarmed_images pi
+			JOIN resources r ON r.id = pi.resource_id
+			WHERE r.resource_config_scope_id = $1
+		)
+	`, rcsID).Scan(&prewarmed)
+	if err != nil {
+		return false, err
+	}
+
+	return prewarmed, nil
+}
@@ -0,0 +1,188 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrewarmedImageFactory", func() {
+	var scope db.ResourceConfigScope
+
+	BeforeEach(func() {
+		resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(defaultResource.Type(), defaultResource.Source(), atc.VersionedResourceTypes{})
+		Expect(err).ToNot(HaveOccurred())
+
+		scope, err = resourceConfig.FindOrCreateScope(defaultResource)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(defaultResource.SetResourceConfigScope(scope)).To(Succeed())
+	})
+
+	sync := func(refs ...db.PrewarmedImageRef) []db.PrewarmedImage {
+		images, err := prewarmedImageFactory.Sync(defaultPipeline.ID(), refs)
+		Expect(err).ToNot(HaveOccurred())
+		return images
+	}
+
+	Describe("Sync", func() {
+		It("records the images", func() {
+			images := sync(db.PrewarmedImageRef{Key: "some-key"})
+			Expect(images).To(HaveLen(1))
+			Expect(images[0].Key).To(Equal("some-key"))
+			Expect(images[0].ResourceConfigScopeID).To(BeZero())
+			Expect(images[0].LatestVersion).To(BeNil())
+			Expect(images[0].Warming).To(BeFalse())
+
+			Expect(sync(db.PrewarmedImageRef{Key: "some-key"})[0].ID).To(Equal(images[0].ID))
+		})
+
+		It("forgets the images no longer used", func() {
+			sync(db.PrewarmedImageRef{Key: "some-key"}, db.PrewarmedImageRef{Key: "other-key"})
+
+			images := sync(db.PrewarmedImageRef{Key: "other-key"})
+			Expect(images).To(HaveLen(1))
+			Expect(images[0].Key).To(Equal("other-key"))
+
+			Expect(sync()).To(BeEmpty())
+		})
+
+		Context("when the image is fetched from a resource", func() {
+			BeforeEach(func() {
+				_, err := scope.SaveVersions(nil, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the resource's scope and latest version", func() {
+				images := sync(db.PrewarmedImageRef{Key: "some-key", Resource: defaultResource.Name()})
+				Expect(images[0].ResourceConfigScopeID).To(Equal(scope.ID()))
+				Expect(images[0].LatestVersion).To(Equal(atc.Version{"ref": "v2"}))
+				Expect(images[0].PinnedVersion).To(BeNil())
+			})
+		})
+
+		Context("when the image's scope has been recorded", func() {
+			It("returns its latest version", func() {
+				id := sync(db.PrewarmedImageRef{Key: "some-key"})[0].ID
+				Expect(prewarmedImageFactory.SetResourceConfigScope(id, scope.ID())).To(Succeed())
+
+				_, err := scope.SaveVersions(nil, []atc.Version{{"ref": "v1"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				images := sync(db.PrewarmedImageRef{Key: "some-key"})
+				Expect(images[0].ResourceConfigScopeID).To(Equal(scope.ID()))
+				Expect(images[0].LatestVersion).To(Equal(atc.Version{"ref": "v1"}))
+			})
+		})
+	})
+
+	Describe("StartWarming", func() {
+		var id int
+
+		BeforeEach(func() {
+			id = sync(db.PrewarmedImageRef{Key: "some-key"})[0].ID
+		})
+
+		It("claims the version once", func() {
+			started, err := prewarmedImageFactory.StartWarming(id, "some-md5", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			Expect(sync(db.PrewarmedImageRef{Key: "some-key"})[0].Warming).To(BeTrue())
+
+			started, err = prewarmedImageFactory.StartWarming(id, "other-md5", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeFalse())
+		})
+
+		Context("once pre-warming succeeded", func() {
+			BeforeEach(func() {
+				_, err := prewarmedImageFactory.StartWarming(id, "some-md5", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(prewarmedImageFactory.FinishWarming(id, true)).To(Succeed())
+			})
+
+			It("records the version as pre-warmed", func() {
+				images := sync(db.PrewarmedImageRef{Key: "some-key"})
+				Expect(images[0].Warming).To(BeFalse())
+				Expect(images[0].WarmedVersionMD5).To(Equal("some-md5"))
+			})
+
+			It("does not claim the same version again", func() {
+				started, err := prewarmedImageFactory.StartWarming(id, "some-md5", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeFalse())
+			})
+
+			It("claims a new version", func() {
+				started, err := prewarmedImageFactory.StartWarming(id, "other-md5", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+		})
+
+		Context("once pre-warming failed", func() {
+			BeforeEach(func() {
+				_, err := prewarmedImageFactory.StartWarming(id, "some-md5", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(prewarmedImageFactory.FinishWarming(id, false)).To(Succeed())
+			})
+
+			It("backs off until the timeout passed", func() {
+				images := sync(db.PrewarmedImageRef{Key: "some-key"})
+				Expect(images[0].Warming).To(BeTrue())
+				Expect(images[0].WarmedVersionMD5).To(BeEmpty())
+
+				started, err := prewarmedImageFactory.StartWarming(id, "some-md5", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeFalse())
+			})
+		})
+
+		Context("when pre-warming timed out without finishing", func() {
+			BeforeEach(func() {
+				_, err := prewarmedImageFactory.StartWarming(id, "some-md5", 0)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("claims the same version again", func() {
+				started, err := prewarmedImageFactory.StartWarming(id, "some-md5", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+			})
+		})
+
+		It("stops keeping the resource caches of the previous version around", func() {
+			_, err := prewarmedImageFactory.StartWarming(id, "some-md5", 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForPrewarmedImage(id),
+				"some-base-resource-type",
+				atc.Version{"some": "version"},
+				atc.Source{"some": "source"},
+				nil,
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			var uses int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM resource_cache_uses WHERE prewarmed_image_id = $1`, id).Scan(&uses)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uses).To(Equal(1))
+
+			_, err = prewarmedImageFactory.StartWarming(id, "other-md5", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM resource_cache_uses WHERE prewarmed_image_id = $1`, id).Scan(&uses)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uses).To(BeZero())
+		})
+	})
+})
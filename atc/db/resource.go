@@ -36,6 +36,7 @@ type Resource interface {
 	CheckTimeout() string
 	LastCheckStartTime() time.Time
 	LastCheckEndTime() time.Time
+	LastNewVersionTime() time.Time
 	Tags() atc.Tags
 	WebhookToken() string
 	Config() atc.ResourceConfig
@@ -43,6 +44,8 @@ type Resource interface {
 	APIPinnedVersion() atc.Version
 	PinComment() string
 	SetPinComment(string) error
+	PinnedBy() string
+	PinExpiresAt() time.Time
 	ResourceConfigID() int
 	ResourceConfigScopeID() int
 	Icon() string
@@ -60,11 +63,14 @@ type Resource interface {
 	EnableVersion(rcvID int) error
 	DisableVersion(rcvID int) error
 
-	PinVersion(rcvID int) (bool, error)
+	PinVersion(rcvID int, pin VersionPin) (bool, error)
 	UnpinVersion() error
+	UnpinExpiredVersion() (bool, error)
 
 	SetResourceConfigScope(ResourceConfigScope) error
 
+	CheckHistory() ([]ResourceCheck, error)
+
 	CheckPlan(atc.Version, time.Duration, ResourceTypes, atc.Source) atc.CheckPlan
 	CreateBuild(context.Context, bool, atc.Plan) (Build, bool, error)
 
@@ -81,6 +87,7 @@ var (
 		"r.config",
 		"rs.last_check_start_time",
 		"rs.last_check_end_time",
+		"rs.last_new_version_time",
 		"r.pipeline_id",
 		"r.nonce",
 		"r.resource_config_id",
@@ -92,6 +99,8 @@ var (
 		"rp.version",
 		"rp.comment_text",
 		"rp.config",
+		"rp.pinned_by",
+		"rp.expires_at",
 		"b.id",
 		"b.name",
 		"b.status",
@@ -117,15 +126,36 @@ type resource struct {
 	type_                 string
 	lastCheckStartTime    time.Time
 	lastCheckEndTime      time.Time
+	lastNewVersionTime    time.Time
 	config                atc.ResourceConfig
 	configPinnedVersion   atc.Version
 	apiPinnedVersion      atc.Version
 	pinComment            string
+	pinnedBy              string
+	pinExpiresAt          time.Time
 	resourceConfigID      int
 	resourceConfigScopeID int
 	buildSummary          *atc.BuildSummary
 }
 
+// VersionPin describes who is pinning a version via the API and for how
+// long. A zero ExpiresAt means the pin never expires, and an empty Comment
+// leaves any existing comment as-is.
+type VersionPin struct {
+	PinnedBy  string
+	Comment   string
+	ExpiresAt time.Time
+}
+
+// ResourceCheck is a retained check build of a resource along with what the
+// check found. VersionsFound is zero and LatestVersion is nil for checks
+// which are still running or did not succeed.
+type ResourceCheck struct {
+	Build         Build
+	VersionsFound int
+	LatestVersion atc.Version
+}
+
 func newEmptyResource(conn Conn, lockFactory lock.LockFactory) *resource {
 	return &resource{pipelineRef: pipelineRef{conn: conn, lockFactory: lockFactory}}
 }
@@ -169,12 +199,15 @@ func (r *resource) CheckEvery() *atc.CheckEvery      { return r.config.CheckEver
 func (r *resource) CheckTimeout() string             { return r.config.CheckTimeout }
 func (r *resource) LastCheckStartTime() time.Time    { return r.lastCheckStartTime }
 func (r *resource) LastCheckEndTime() time.Time      { return r.lastCheckEndTime }
+func (r *resource) LastNewVersionTime() time.Time    { return r.lastNewVersionTime }
 func (r *resource) Tags() atc.Tags                   { return r.config.Tags }
 func (r *resource) WebhookToken() string             { return r.config.WebhookToken }
 func (r *resource) Config() atc.ResourceConfig       { return r.config }
 func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
 func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
 func (r *resource) PinComment() string               { return r.pinComment }
+func (r *resource) PinnedBy() string                 { return r.pinnedBy }
+func (r *resource) PinExpiresAt() time.Time          { return r.pinExpiresAt }
 func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
 func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
 func (r *resource) Icon() string                     { return r.config.Icon }
@@ -611,7 +644,7 @@ func (r *resource) DisableVersion(rcvID int) error {
 	return r.toggleVersion(rcvID, false)
 }
 
-func (r *resource) PinVersion(rcvID int) (bool, error) {
+func (r *resource) PinVersion(rcvID int, pin VersionPin) (bool, error) {
 	tx, err := r.conn.Begin()
 	if err != nil {
 		return false, err
@@ -633,14 +666,23 @@ func (r *resource) PinVersion(rcvID int) (bool, error) {
 		return false, ErrPinnedThroughConfig
 	}
 
+	var expiresAt interface{}
+	if !pin.ExpiresAt.IsZero() {
+		expiresAt = pin.ExpiresAt
+	}
+
 	results, err := tx.Exec(`
-	    INSERT INTO resource_pins(resource_id, version, comment_text, config)
+	    INSERT INTO resource_pins(resource_id, version, comment_text, config, pinned_by, expires_at)
 			VALUES ($1,
 				( SELECT rcv.version
 				FROM resource_config_versions rcv
 				WHERE rcv.id = $2 ),
-				'', false)
-			ON CONFLICT (resource_id) DO UPDATE SET version=EXCLUDED.version`, r.id, rcvID)
+				$3, false, NULLIF($4, ''), $5)
+			ON CONFLICT (resource_id) DO UPDATE SET
+				version=EXCLUDED.version,
+				comment_text=COALESCE(NULLIF(EXCLUDED.comment_text, ''), resource_pins.comment_text),
+				pinned_by=EXCLUDED.pinned_by,
+				expires_at=EXCLUDED.expires_at`, r.id, rcvID, pin.Comment, pin.PinnedBy, expiresAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -708,6 +750,111 @@ func (r *resource) UnpinVersion() error {
 	return nil
 }
 
+// UnpinExpiredVersion removes the API pin of the resource if its expiry has
+// passed. It returns false if the resource is no longer pinned or the pin has
+// since been replaced by one that has not yet expired.
+func (r *resource) UnpinExpiredVersion() (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	results, err := psql.Delete("resource_pins").
+		Where(sq.Eq{
+			"resource_id": r.id,
+			"config":      false,
+		}).
+		Where(sq.Expr("expires_at <= now()")).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	err = requestScheduleForJobsUsingResource(tx, r.id)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CheckHistory returns the retained check builds of the resource, most recent
+// first.
+func (r *resource) CheckHistory() ([]ResourceCheck, error) {
+	rows, err := buildsQuery.
+		Column("cbr.versions_found").
+		Column("cbr.latest_version").
+		LeftJoin("check_build_results cbr ON cbr.build_id = b.id").
+		Where(sq.Eq{"b.resource_id": r.id}).
+		OrderBy("b.id DESC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	checks := []ResourceCheck{}
+	for rows.Next() {
+		var (
+			versionsFound sql.NullInt64
+			latestVersion sql.NullString
+		)
+
+		build := newEmptyBuild(r.conn, r.lockFactory)
+		err = scanBuild(build, checkHistoryRow{rows, &versionsFound, &latestVersion}, r.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		check := ResourceCheck{
+			Build:         build,
+			VersionsFound: int(versionsFound.Int64),
+		}
+
+		if latestVersion.Valid {
+			err = json.Unmarshal([]byte(latestVersion.String), &check.LatestVersion)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// checkHistoryRow scans the check result columns which are appended after the
+// columns scanned by scanBuild.
+type checkHistoryRow struct {
+	rows          *sql.Rows
+	versionsFound *sql.NullInt64
+	latestVersion *sql.NullString
+}
+
+func (row checkHistoryRow) Scan(dest ...interface{}) error {
+	return row.rows.Scan(append(dest, row.versionsFound, row.latestVersion)...)
+}
+
 func (r *resource) toggleVersion(rcvID int, enable bool) error {
 	tx, err := r.conn.Begin()
 	if err != nil {
@@ -760,7 +907,9 @@ func scanResource(r *resource, row scannable) error {
 	var (
 		configBlob                                        sql.NullString
 		nonce, rcID, rcScopeID, pinnedVersion, pinComment sql.NullString
-		lastCheckStartTime, lastCheckEndTime              pq.NullTime
+		pinnedBy                                          sql.NullString
+		lastCheckStartTime, lastCheckEndTime, pinExpiry   pq.NullTime
+		lastNewVersionTime                                pq.NullTime
 		pinnedThroughConfig                               sql.NullBool
 		pipelineInstanceVars                              sql.NullString
 	)
@@ -773,13 +922,14 @@ func scanResource(r *resource, row scannable) error {
 		endTime   pq.NullTime
 	}
 
-	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &pipelineInstanceVars, &r.teamID, &r.teamName, &pinnedVersion, &pinComment, &pinnedThroughConfig, &build.id, &build.name, &build.status, &build.startTime, &build.endTime)
+	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &lastCheckStartTime, &lastCheckEndTime, &lastNewVersionTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &pipelineInstanceVars, &r.teamID, &r.teamName, &pinnedVersion, &pinComment, &pinnedThroughConfig, &pinnedBy, &pinExpiry, &build.id, &build.name, &build.status, &build.startTime, &build.endTime)
 	if err != nil {
 		return err
 	}
 
 	r.lastCheckStartTime = lastCheckStartTime.Time
 	r.lastCheckEndTime = lastCheckEndTime.Time
+	r.lastNewVersionTime = lastNewVersionTime.Time
 
 	es := r.conn.EncryptionStrategy()
 
@@ -827,6 +977,9 @@ func scanResource(r *resource, row scannable) error {
 		r.pinComment = ""
 	}
 
+	r.pinnedBy = pinnedBy.String
+	r.pinExpiresAt = pinExpiry.Time
+
 	if rcID.Valid {
 		r.resourceConfigID, err = strconv.Atoi(rcID.String)
 		if err != nil {
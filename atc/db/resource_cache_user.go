@@ -33,3 +33,17 @@ func (user forContainer) SQLMap() map[string]interface{} {
 		"container_id": user.ContainerID,
 	}
 }
+
+type forPrewarmedImage struct {
+	PrewarmedImageID int
+}
+
+func ForPrewarmedImage(id int) ResourceCacheUser {
+	return forPrewarmedImage{id}
+}
+
+func (user forPrewarmedImage) SQLMap() map[string]interface{} {
+	return map[string]interface{}{
+		"prewarmed_image_id": user.PrewarmedImageID,
+	}
+}
@@ -25,7 +25,7 @@ type ResourceConfigScope interface {
 	Resource() Resource
 	ResourceConfig() ResourceConfig
 
-	SaveVersions(SpanContext, []atc.Version) error
+	SaveVersions(SpanContext, []atc.Version) (int, error)
 	FindVersion(atc.Version) (ResourceConfigVersion, bool, error)
 	LatestVersion() (ResourceConfigVersion, bool, error)
 
@@ -74,55 +74,81 @@ func (r *resourceConfigScope) LastCheckEndTime() (time.Time, error) {
 // In the case of a check resource from an older version, the versions
 // that already exist in the DB will be re-ordered using
 // incrementCheckOrder to input the correct check order
-func (r *resourceConfigScope) SaveVersions(spanContext SpanContext, versions []atc.Version) error {
+//
+// It returns the number of versions which did not exist yet.
+func (r *resourceConfigScope) SaveVersions(spanContext SpanContext, versions []atc.Version) (int, error) {
 	return saveVersions(r.conn, r.ID(), versions, spanContext)
 }
 
-func saveVersions(conn Conn, rcsID int, versions []atc.Version, spanContext SpanContext) error {
+func saveVersions(conn Conn, rcsID int, versions []atc.Version, spanContext SpanContext) (int, error) {
 	tx, err := conn.Begin()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	defer Rollback(tx)
 
-	var containsNewVersion bool
+	var newVersions int
+	var prewarmed bool
 	for _, version := range versions {
 		newVersion, err := saveResourceVersion(tx, rcsID, version, nil, spanContext)
 		if err != nil {
-			return err
+			return 0, err
 		}
 
-		containsNewVersion = containsNewVersion || newVersion
+		if newVersion {
+			newVersions++
+		}
 	}
 
-	if containsNewVersion {
+	if newVersions > 0 {
 		// bump the check order of all the versions returned by the check if there
 		// is at least one new version within the set of returned versions
 		for _, version := range versions {
 			versionJSON, err := json.Marshal(version)
 			if err != nil {
-				return err
+				return 0, err
 			}
 
 			err = incrementCheckOrder(tx, rcsID, string(versionJSON))
 			if err != nil {
-				return err
+				return 0, err
 			}
 		}
 
+		_, err = psql.Update("resource_config_scopes").
+			Set("last_new_version_time", sq.Expr("now()")).
+			Where(sq.Eq{"id": rcsID}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return 0, err
+		}
+
 		err = requestScheduleForJobsUsingResourceConfigScope(tx, rcsID)
 		if err != nil {
-			return err
+			return 0, err
+		}
+
+		prewarmed, err = scopeHasPrewarmedImages(tx, rcsID)
+		if err != nil {
+			return 0, err
 		}
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	if prewarmed {
+		err = conn.Bus().Notify(atc.ComponentImagePrewarmer)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return newVersions, nil
 }
 
 func (r *resourceConfigScope) FindVersion(v atc.Version) (ResourceConfigVersion, bool, error) {
@@ -79,7 +79,7 @@ var _ = Describe("Resource Config Scope", func() {
 
 		// XXX: Can make test more resilient if there is a method that gives all versions by descending check order
 		It("ensures versioned resources have the correct check_order", func() {
-			err := resourceScope.SaveVersions(nil, originalVersionSlice)
+			_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 			Expect(err).ToNot(HaveOccurred())
 
 			latestVR, found, err := resourceScope.LatestVersion()
@@ -94,7 +94,7 @@ var _ = Describe("Resource Config Scope", func() {
 				{"ref": "v3"},
 			}
 
-			err = resourceScope.SaveVersions(nil, pretendCheckResults)
+			_, err = resourceScope.SaveVersions(nil, pretendCheckResults)
 			Expect(err).ToNot(HaveOccurred())
 
 			latestVR, found, err = resourceScope.LatestVersion()
@@ -105,6 +105,19 @@ var _ = Describe("Resource Config Scope", func() {
 			Expect(latestVR.CheckOrder()).To(Equal(4))
 		})
 
+		It("returns the number of versions which are new", func() {
+			newVersions, err := resourceScope.SaveVersions(nil, originalVersionSlice)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newVersions).To(Equal(2))
+
+			newVersions, err = resourceScope.SaveVersions(nil, []atc.Version{
+				{"ref": "v3"},
+				{"ref": "v4"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newVersions).To(Equal(1))
+		})
+
 		Context("when the versions already exists", func() {
 			var newVersionSlice []atc.Version
 
@@ -114,7 +127,7 @@ var _ = Describe("Resource Config Scope", func() {
 					{"ref": "v3"},
 				}
 
-				err := resourceScope.SaveVersions(nil, originalVersionSlice)
+				_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 				Expect(err).ToNot(HaveOccurred())
 
 				latestVR, found, err := resourceScope.LatestVersion()
@@ -126,7 +139,7 @@ var _ = Describe("Resource Config Scope", func() {
 			})
 
 			It("does not change the check order", func() {
-				err := resourceScope.SaveVersions(nil, newVersionSlice)
+				_, err := resourceScope.SaveVersions(nil, newVersionSlice)
 				Expect(err).ToNot(HaveOccurred())
 
 				latestVR, found, err := resourceScope.LatestVersion()
@@ -139,7 +152,7 @@ var _ = Describe("Resource Config Scope", func() {
 
 			Context("when a new version is added", func() {
 				It("requests schedule on the jobs that use the resource", func() {
-					err := resourceScope.SaveVersions(nil, originalVersionSlice)
+					_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 					Expect(err).ToNot(HaveOccurred())
 
 					requestedSchedule := scenario.Job("some-job").ScheduleRequestedTime()
@@ -148,14 +161,14 @@ var _ = Describe("Resource Config Scope", func() {
 						{"ref": "v0"},
 						{"ref": "v3"},
 					}
-					err = resourceScope.SaveVersions(nil, newVersions)
+					_, err = resourceScope.SaveVersions(nil, newVersions)
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(scenario.Job("some-job").ScheduleRequestedTime()).Should(BeTemporally(">", requestedSchedule))
 				})
 
 				It("does not request schedule on the jobs that use the resource but through passed constraints", func() {
-					err := resourceScope.SaveVersions(nil, originalVersionSlice)
+					_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 					Expect(err).ToNot(HaveOccurred())
 
 					requestedSchedule := scenario.Job("downstream-job").ScheduleRequestedTime()
@@ -164,14 +177,14 @@ var _ = Describe("Resource Config Scope", func() {
 						{"ref": "v0"},
 						{"ref": "v3"},
 					}
-					err = resourceScope.SaveVersions(nil, newVersions)
+					_, err = resourceScope.SaveVersions(nil, newVersions)
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(scenario.Job("downstream-job").ScheduleRequestedTime()).Should(BeTemporally("==", requestedSchedule))
 				})
 
 				It("does not request schedule on the jobs that do not use the resource", func() {
-					err := resourceScope.SaveVersions(nil, originalVersionSlice)
+					_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 					Expect(err).ToNot(HaveOccurred())
 
 					requestedSchedule := scenario.Job("some-other-job").ScheduleRequestedTime()
@@ -180,7 +193,7 @@ var _ = Describe("Resource Config Scope", func() {
 						{"ref": "v0"},
 						{"ref": "v3"},
 					}
-					err = resourceScope.SaveVersions(nil, newVersions)
+					_, err = resourceScope.SaveVersions(nil, newVersions)
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(scenario.Job("some-other-job").ScheduleRequestedTime()).Should(BeTemporally("==", requestedSchedule))
@@ -199,7 +212,7 @@ var _ = Describe("Resource Config Scope", func() {
 					{"ref": "v3"},
 				}
 
-				err := resourceScope.SaveVersions(nil, originalVersionSlice)
+				_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -214,7 +227,7 @@ var _ = Describe("Resource Config Scope", func() {
 			})
 
 			It("disabled versions do not affect fetching the latest version", func() {
-				err := resourceScope.SaveVersions(nil, []atc.Version{{"version": "1"}})
+				_, err := resourceScope.SaveVersions(nil, []atc.Version{{"version": "1"}})
 				Expect(err).ToNot(HaveOccurred())
 
 				savedRCV, found, err := resourceScope.LatestVersion()
@@ -239,7 +252,7 @@ var _ = Describe("Resource Config Scope", func() {
 			})
 
 			It("saving versioned resources updates the latest versioned resource", func() {
-				err := resourceScope.SaveVersions(nil, []atc.Version{{"ref": "4"}, {"ref": "5"}})
+				_, err := resourceScope.SaveVersions(nil, []atc.Version{{"ref": "4"}, {"ref": "5"}})
 				Expect(err).ToNot(HaveOccurred())
 
 				savedVR, found, err := resourceScope.LatestVersion()
@@ -258,7 +271,7 @@ var _ = Describe("Resource Config Scope", func() {
 				{"ref": "v3"},
 			}
 
-			err := resourceScope.SaveVersions(nil, originalVersionSlice)
+			_, err := resourceScope.SaveVersions(nil, originalVersionSlice)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
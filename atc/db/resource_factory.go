@@ -13,6 +13,7 @@ type ResourceFactory interface {
 	Resource(int) (Resource, bool, error)
 	VisibleResources([]string) ([]Resource, error)
 	AllResources() ([]Resource, error)
+	ResourcesWithExpiredPins() ([]Resource, error)
 }
 
 type resourceFactory struct {
@@ -76,6 +77,20 @@ func (r *resourceFactory) AllResources() ([]Resource, error) {
 	return scanResources(rows, r.conn, r.lockFactory)
 }
 
+func (r *resourceFactory) ResourcesWithExpiredPins() ([]Resource, error) {
+	rows, err := resourcesQuery.
+		Where(sq.Eq{"rp.config": false}).
+		Where(sq.Expr("rp.expires_at <= now()")).
+		OrderBy("r.id ASC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanResources(rows, r.conn, r.lockFactory)
+}
+
 func scanResources(resourceRows *sql.Rows, conn Conn, lockFactory lock.LockFactory) ([]Resource, error) {
 	var resources []Resource
 
@@ -986,6 +986,52 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("CheckHistory", func() {
+		var firstBuild, secondBuild db.Build
+
+		BeforeEach(func() {
+			plan := atc.Plan{
+				ID:    "some-plan",
+				Check: &atc.CheckPlan{Name: "wreck"},
+			}
+
+			var (
+				created bool
+				err     error
+			)
+
+			firstBuild, created, err = defaultResource.CreateBuild(context.TODO(), false, plan)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			err = firstBuild.SaveCheckResult([]atc.Version{{"ref": "v1"}, {"ref": "v2"}}, 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = firstBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			secondBuild, created, err = defaultResource.CreateBuild(context.TODO(), true, plan)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+		})
+
+		It("returns the check builds of the resource, most recent first", func() {
+			checks, err := defaultResource.CheckHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checks).To(HaveLen(2))
+
+			Expect(checks[0].Build.ID()).To(Equal(secondBuild.ID()))
+			Expect(checks[0].Build.Status()).To(Equal(db.BuildStatusStarted))
+			Expect(checks[0].VersionsFound).To(BeZero())
+			Expect(checks[0].LatestVersion).To(BeNil())
+
+			Expect(checks[1].Build.ID()).To(Equal(firstBuild.ID()))
+			Expect(checks[1].Build.Status()).To(Equal(db.BuildStatusSucceeded))
+			Expect(checks[1].VersionsFound).To(Equal(1))
+			Expect(checks[1].LatestVersion).To(Equal(atc.Version{"ref": "v2"}))
+		})
+	})
+
 	Describe("PinVersion/UnpinVersion", func() {
 		var (
 			scenario *dbtest.Scenario
@@ -1032,7 +1078,7 @@ var _ = Describe("Resource", func() {
 			)
 
 			BeforeEach(func() {
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), db.VersionPin{})
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 
@@ -1041,7 +1087,7 @@ var _ = Describe("Resource", func() {
 			})
 
 			It("returns not found and does not update anything", func() {
-				found, err := scenario.Resource("some-resource").PinVersion(-1)
+				found, err := scenario.Resource("some-resource").PinVersion(-1, db.VersionPin{})
 				Expect(found).To(BeFalse())
 				Expect(err).To(HaveOccurred())
 
@@ -1053,7 +1099,7 @@ var _ = Describe("Resource", func() {
 			It("requests schedule on all jobs using the resource", func() {
 				requestedSchedule := scenario.Job("job-using-resource").ScheduleRequestedTime()
 
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), db.VersionPin{})
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 
@@ -1063,7 +1109,7 @@ var _ = Describe("Resource", func() {
 			It("does not request schedule on jobs that do not use the resource", func() {
 				requestedSchedule := scenario.Job("not-using-resource").ScheduleRequestedTime()
 
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), db.VersionPin{})
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 
@@ -1073,7 +1119,7 @@ var _ = Describe("Resource", func() {
 
 		Context("when we pin a resource to a version", func() {
 			BeforeEach(func() {
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), db.VersionPin{})
 				Expect(found).To(BeTrue())
 				Expect(err).ToNot(HaveOccurred())
 			})
@@ -1087,7 +1133,7 @@ var _ = Describe("Resource", func() {
 
 			Context("when the resource is pinned by another version already", func() {
 				BeforeEach(func() {
-					found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v3"}).ID())
+					found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v3"}).ID(), db.VersionPin{})
 					Expect(found).To(BeTrue())
 					Expect(err).ToNot(HaveOccurred())
 				})
@@ -1146,6 +1192,89 @@ var _ = Describe("Resource", func() {
 			})
 		})
 
+		Context("when we pin a resource with who pinned it, a comment and an expiry", func() {
+			var expiresAt time.Time
+
+			BeforeEach(func() {
+				expiresAt = time.Now().Add(time.Hour).Truncate(time.Second)
+
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), db.VersionPin{
+					PinnedBy:  "some-user",
+					Comment:   "some-comment",
+					ExpiresAt: expiresAt,
+				})
+				Expect(found).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("records the pin details", func() {
+				resource := scenario.Resource("some-resource")
+				Expect(resource.APIPinnedVersion()).To(Equal(atc.Version{"version": "v1"}))
+				Expect(resource.PinnedBy()).To(Equal("some-user"))
+				Expect(resource.PinComment()).To(Equal("some-comment"))
+				Expect(resource.PinExpiresAt()).To(BeTemporally("==", expiresAt))
+			})
+
+			Context("when the version is re-pinned without a comment or an expiry", func() {
+				BeforeEach(func() {
+					found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v2"}).ID(), db.VersionPin{
+						PinnedBy: "other-user",
+					})
+					Expect(found).To(BeTrue())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps the comment but replaces who pinned it and the expiry", func() {
+					resource := scenario.Resource("some-resource")
+					Expect(resource.APIPinnedVersion()).To(Equal(atc.Version{"version": "v2"}))
+					Expect(resource.PinnedBy()).To(Equal("other-user"))
+					Expect(resource.PinComment()).To(Equal("some-comment"))
+					Expect(resource.PinExpiresAt()).To(BeZero())
+				})
+			})
+
+			Context("when the pin has not expired", func() {
+				It("is not unpinned", func() {
+					unpinned, err := scenario.Resource("some-resource").UnpinExpiredVersion()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(unpinned).To(BeFalse())
+
+					Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(Equal(atc.Version{"version": "v1"}))
+				})
+
+				It("is not returned as a resource with an expired pin", func() {
+					resources, err := db.NewResourceFactory(dbConn, lockFactory).ResourcesWithExpiredPins()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(resources).To(BeEmpty())
+				})
+			})
+
+			Context("when the pin has expired", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`UPDATE resource_pins SET expires_at = now() - interval '1 minute'`)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("is returned as a resource with an expired pin", func() {
+					resources, err := db.NewResourceFactory(dbConn, lockFactory).ResourcesWithExpiredPins()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(resources).To(HaveLen(1))
+					Expect(resources[0].ID()).To(Equal(scenario.Resource("some-resource").ID()))
+				})
+
+				It("is unpinned and requests schedule on jobs using the resource", func() {
+					requestedSchedule := scenario.Job("job-using-resource").ScheduleRequestedTime()
+
+					unpinned, err := scenario.Resource("some-resource").UnpinExpiredVersion()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(unpinned).To(BeTrue())
+
+					Expect(scenario.Resource("some-resource").APIPinnedVersion()).To(BeNil())
+					Expect(scenario.Job("job-using-resource").ScheduleRequestedTime()).Should(BeTemporally(">", requestedSchedule))
+				})
+			})
+		})
+
 		Context("when we pin a resource that is already pinned to a version (through the config)", func() {
 			BeforeEach(func() {
 				scenario.Run(
@@ -1168,7 +1297,7 @@ var _ = Describe("Resource", func() {
 			})
 
 			It("should fail to update the pinned version", func() {
-				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID())
+				found, err := scenario.Resource("some-resource").PinVersion(scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"}).ID(), db.VersionPin{})
 				Expect(found).To(BeFalse())
 				Expect(err).To(Equal(db.ErrPinnedThroughConfig))
 			})
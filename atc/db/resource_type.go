@@ -41,6 +41,7 @@ type ResourceType interface {
 	CheckTimeout() string
 	LastCheckStartTime() time.Time
 	LastCheckEndTime() time.Time
+	LastNewVersionTime() time.Time
 	CurrentPinnedVersion() atc.Version
 	ResourceConfigScopeID() int
 
@@ -151,6 +152,7 @@ var resourceTypesQuery = psql.Select(
 	"ro.id",
 	"ro.last_check_start_time",
 	"ro.last_check_end_time",
+	"ro.last_new_version_time",
 ).
 	From("resource_types r").
 	Join("pipelines p ON p.id = r.pipeline_id").
@@ -184,6 +186,7 @@ type resourceType struct {
 	checkEvery            *atc.CheckEvery
 	lastCheckStartTime    time.Time
 	lastCheckEndTime      time.Time
+	lastNewVersionTime    time.Time
 }
 
 func (t *resourceType) ID() int                       { return t.id }
@@ -196,6 +199,7 @@ func (t *resourceType) CheckEvery() *atc.CheckEvery   { return t.checkEvery }
 func (t *resourceType) CheckTimeout() string          { return "" }
 func (r *resourceType) LastCheckStartTime() time.Time { return r.lastCheckStartTime }
 func (r *resourceType) LastCheckEndTime() time.Time   { return r.lastCheckEndTime }
+func (r *resourceType) LastNewVersionTime() time.Time { return r.lastNewVersionTime }
 func (t *resourceType) Source() atc.Source            { return t.source }
 func (t *resourceType) Defaults() atc.Source          { return t.defaults }
 func (t *resourceType) Params() atc.Params            { return t.params }
@@ -341,16 +345,18 @@ func scanResourceType(t *resourceType, row scannable) error {
 		configJSON                           sql.NullString
 		rcsID, version, nonce                sql.NullString
 		lastCheckStartTime, lastCheckEndTime pq.NullTime
+		lastNewVersionTime                   pq.NullTime
 		pipelineInstanceVars                 sql.NullString
 	)
 
-	err := row.Scan(&t.id, &t.pipelineID, &t.name, &t.type_, &configJSON, &version, &nonce, &t.pipelineName, &pipelineInstanceVars, &t.teamID, &t.teamName, &rcsID, &lastCheckStartTime, &lastCheckEndTime)
+	err := row.Scan(&t.id, &t.pipelineID, &t.name, &t.type_, &configJSON, &version, &nonce, &t.pipelineName, &pipelineInstanceVars, &t.teamID, &t.teamName, &rcsID, &lastCheckStartTime, &lastCheckEndTime, &lastNewVersionTime)
 	if err != nil {
 		return err
 	}
 
 	t.lastCheckStartTime = lastCheckStartTime.Time
 	t.lastCheckEndTime = lastCheckEndTime.Time
+	t.lastNewVersionTime = lastNewVersionTime.Time
 
 	if version.Valid {
 		err = json.Unmarshal([]byte(version.String), &t.version)
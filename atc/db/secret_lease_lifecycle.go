@@ -0,0 +1,78 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// SecretLease is the lease of a dynamic secret used by a build.
+type SecretLease struct {
+	BuildID   int
+	LeaseID   string
+	ExpiresAt time.Time
+}
+
+//go:generate counterfeiter . SecretLeaseLifecycle
+
+type SecretLeaseLifecycle interface {
+	// OrphanedSecretLeases returns the leases which are no longer in use,
+	// i.e. the leases of builds which have finished or been deleted, and the
+	// leases which have expired because the build's ATC stopped renewing
+	// them.
+	OrphanedSecretLeases() ([]SecretLease, error)
+
+	RemoveSecretLease(buildID int, leaseID string) error
+}
+
+type secretLeaseLifecycle struct {
+	conn Conn
+}
+
+func NewSecretLeaseLifecycle(conn Conn) SecretLeaseLifecycle {
+	return &secretLeaseLifecycle{
+		conn: conn,
+	}
+}
+
+func (lifecycle *secretLeaseLifecycle) OrphanedSecretLeases() ([]SecretLease, error) {
+	rows, err := psql.Select("l.build_id", "l.lease_id", "l.expires_at").
+		From("build_secret_leases l").
+		LeftJoin("builds b ON b.id = l.build_id").
+		Where(sq.Or{
+			sq.Eq{"b.id": nil},
+			sq.Eq{"b.completed": true},
+			sq.Expr("l.expires_at < now()"),
+		}).
+		RunWith(lifecycle.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var leases []SecretLease
+	for rows.Next() {
+		var lease SecretLease
+		err = rows.Scan(&lease.BuildID, &lease.LeaseID, &lease.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		leases = append(leases, lease)
+	}
+
+	return leases, nil
+}
+
+func (lifecycle *secretLeaseLifecycle) RemoveSecretLease(buildID int, leaseID string) error {
+	_, err := psql.Delete("build_secret_leases").
+		Where(sq.Eq{
+			"build_id": buildID,
+			"lease_id": leaseID,
+		}).
+		RunWith(lifecycle.conn).
+		Exec()
+	return err
+}
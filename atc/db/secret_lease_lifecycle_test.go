@@ -0,0 +1,98 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secret Lease Lifecycle", func() {
+	var (
+		lifecycle db.SecretLeaseLifecycle
+
+		runningBuild  db.Build
+		finishedBuild db.Build
+	)
+
+	leaseIDs := func(leases []db.SecretLease) []string {
+		ids := []string{}
+		for _, lease := range leases {
+			ids = append(ids, lease.LeaseID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		lifecycle = db.NewSecretLeaseLifecycle(dbConn)
+
+		var err error
+		runningBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		finishedBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = finishedBuild.Finish(db.BuildStatusSucceeded)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = runningBuild.SaveSecretLease("running-lease", time.Now().Add(time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = runningBuild.SaveSecretLease("expired-lease", time.Now().Add(-time.Minute))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = finishedBuild.SaveSecretLease("finished-lease", time.Now().Add(time.Hour))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns the leases of finished builds and expired leases", func() {
+		leases, err := lifecycle.OrphanedSecretLeases()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(leaseIDs(leases)).To(ConsistOf("expired-lease", "finished-lease"))
+	})
+
+	Context("when a lease has been renewed", func() {
+		BeforeEach(func() {
+			err := runningBuild.SaveSecretLease("expired-lease", time.Now().Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is no longer orphaned", func() {
+			leases, err := lifecycle.OrphanedSecretLeases()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaseIDs(leases)).To(ConsistOf("finished-lease"))
+		})
+	})
+
+	Context("when the build has been deleted", func() {
+		BeforeEach(func() {
+			_, err := runningBuild.Delete()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns its leases", func() {
+			leases, err := lifecycle.OrphanedSecretLeases()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaseIDs(leases)).To(ConsistOf("running-lease", "expired-lease", "finished-lease"))
+		})
+	})
+
+	Context("when a lease is removed", func() {
+		BeforeEach(func() {
+			err := lifecycle.RemoveSecretLease(finishedBuild.ID(), "finished-lease")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = runningBuild.RemoveSecretLease("expired-lease")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is no longer returned", func() {
+			leases, err := lifecycle.OrphanedSecretLeases()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leases).To(BeEmpty())
+		})
+	})
+})
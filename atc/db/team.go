@@ -21,6 +21,16 @@ import (
 
 var ErrConfigComparisonFailed = errors.New("comparison with existing config failed during save")
 
+// PassedJobNotFoundError is returned when saving a pipeline whose `passed:`
+// constraints reference a job in another pipeline which does not exist.
+type PassedJobNotFoundError struct {
+	PassedJob atc.PassedJob
+}
+
+func (e PassedJobNotFoundError) Error() string {
+	return fmt.Sprintf("passed job '%s' not found", e.PassedJob)
+}
+
 type ErrPipelineNotFound struct {
 	Name string
 }
@@ -550,7 +560,12 @@ func savePipeline(
 		return 0, false, err
 	}
 
-	err = insertJobPipes(tx, config.Jobs, resourceNameToID, jobNameToID, pipelineID)
+	err = insertJobPipes(tx, config.Jobs, resourceNameToID, jobNameToID, pipelineID, teamID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	err = saveCredentialUsages(tx, config, pipelineID)
 	if err != nil {
 		return 0, false, err
 	}
@@ -1221,7 +1236,7 @@ func saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) (int, erro
 		_, err = psql.Insert("resource_pins").
 			Columns("resource_id", "version", "comment_text", "config").
 			Values(resourceID, version, "", true).
-			Suffix("ON CONFLICT (resource_id) DO UPDATE SET version = EXCLUDED.version, comment_text = EXCLUDED.comment_text, config = true").
+			Suffix("ON CONFLICT (resource_id) DO UPDATE SET version = EXCLUDED.version, comment_text = EXCLUDED.comment_text, config = true, pinned_by = NULL, expires_at = NULL").
 			RunWith(tx).
 			Exec()
 		if err != nil {
@@ -1535,7 +1550,7 @@ func saveJobsAndSerialGroups(tx Tx, jobs atc.JobConfigs, groups atc.GroupConfigs
 	return jobNameToID, nil
 }
 
-func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int) error {
+func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int, teamID int) error {
 	_, err := psql.Delete("job_inputs").
 		Where(sq.Expr(`job_id in (
         SELECT j.id
@@ -1563,7 +1578,7 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 	for _, jobConfig := range jobConfigs {
 		err := jobConfig.StepConfig().Visit(atc.StepRecursor{
 			OnGet: func(step *atc.GetStep) error {
-				return insertJobInput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID)
+				return insertJobInput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID, teamID)
 			},
 			OnPut: func(step *atc.PutStep) error {
 				return insertJobOutput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID)
@@ -1577,44 +1592,50 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 	return nil
 }
 
-func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
-	if len(step.Passed) != 0 {
-		for _, passedJob := range step.Passed {
-			var version sql.NullString
-			if step.Version != nil {
-				versionJSON, err := step.Version.MarshalJSON()
-				if err != nil {
-					return err
-				}
-
-				version = sql.NullString{Valid: true, String: string(versionJSON)}
-			}
-
-			_, err := psql.Insert("job_inputs").
-				Columns("name", "job_id", "resource_id", "passed_job_id", "trigger", "version").
-				Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobNameToID[passedJob], step.Trigger, version).
-				RunWith(tx).
-				Exec()
-			if err != nil {
-				return err
-			}
+func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int, teamID int) error {
+	var version sql.NullString
+	if step.Version != nil {
+		versionJSON, err := step.Version.MarshalJSON()
+		if err != nil {
+			return err
 		}
-	} else {
-		var version sql.NullString
-		if step.Version != nil {
-			versionJSON, err := step.Version.MarshalJSON()
-			if err != nil {
-				return err
-			}
 
-			version = sql.NullString{Valid: true, String: string(versionJSON)}
-		}
+		version = sql.NullString{Valid: true, String: string(versionJSON)}
+	}
 
+	if len(step.Passed) == 0 && len(step.PassedPipelineJobs) == 0 {
 		_, err := psql.Insert("job_inputs").
 			Columns("name", "job_id", "resource_id", "trigger", "version").
 			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], step.Trigger, version).
 			RunWith(tx).
 			Exec()
+		return err
+	}
+
+	for _, passedJob := range step.Passed {
+		_, err := psql.Insert("job_inputs").
+			Columns("name", "job_id", "resource_id", "passed_job_id", "trigger", "version").
+			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobNameToID[passedJob], step.Trigger, version).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, passedJob := range step.PassedPipelineJobs {
+		passedJobID, err := findPassedPipelineJobID(tx, passedJob, teamID)
+		if err != nil {
+			return err
+		}
+
+		// the reference is kept so that the input fails to resolve, rather than
+		// becoming unconstrained, once the job goes away
+		_, err = psql.Insert("job_inputs").
+			Columns("name", "job_id", "resource_id", "passed_job_id", "passed_job_ref", "trigger", "version").
+			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], passedJobID, passedJob.String(), step.Trigger, version).
+			RunWith(tx).
+			Exec()
 		if err != nil {
 			return err
 		}
@@ -1623,6 +1644,43 @@ func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID m
 	return nil
 }
 
+// findPassedPipelineJobID returns the ID of a job in another pipeline
+// referenced by a `passed:` constraint, among the active jobs of the team's
+// pipelines.
+func findPassedPipelineJobID(tx Tx, passedJob atc.PassedJob, teamID int) (int, error) {
+	var instanceVars sql.NullString
+	if passedJob.InstanceVars != nil {
+		bytes, _ := json.Marshal(passedJob.InstanceVars)
+		instanceVars = sql.NullString{
+			String: string(bytes),
+			Valid:  true,
+		}
+	}
+
+	var jobID int
+	err := psql.Select("j.id").
+		From("jobs j").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{
+			"p.team_id":       teamID,
+			"p.name":          passedJob.Pipeline,
+			"p.instance_vars": instanceVars,
+			"j.name":          passedJob.Job,
+			"j.active":        true,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, PassedJobNotFoundError{PassedJob: passedJob}
+		}
+		return 0, err
+	}
+
+	return jobID, nil
+}
+
 func insertJobOutput(tx Tx, step *atc.PutStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
 	_, err := psql.Insert("job_outputs").
 		Columns("name", "job_id", "resource_id").
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -53,6 +54,7 @@ const (
 	VolumeTypeResourceCerts VolumeType = "resource-certs"
 	VolumeTypeTaskCache     VolumeType = "task-cache"
 	VolumeTypeArtifact      VolumeType = "artifact"
+	VolumeTypeKeyedCache    VolumeType = "keyed-cache"
 	VolumeTypeUknown        VolumeType = "unknown" // for migration to life
 )
 
@@ -152,9 +154,15 @@ type CreatedVolume interface {
 	WorkerName() string
 
 	InitializeResourceCache(UsedResourceCache) error
+	RecordResourceCacheChecksum(checksum string) error
+	ResourceCacheChecksum() (string, time.Time, bool, error)
+	MarkResourceCacheVerified() error
+	InvalidateResourceCache() error
 	GetResourceCacheID() int
 	InitializeArtifact(name string, buildID int) (WorkerArtifact, error)
+	InitializeStepOutput(name string, buildID int, planID atc.PlanID, expiresAt time.Time) (WorkerArtifact, error)
 	InitializeTaskCache(jobID int, stepName string, path string) error
+	InitializeKeyedCache(jobID int, path string, key string, size int64) error
 
 	ContainerHandle() string
 	ParentHandle() string
@@ -404,11 +412,102 @@ func (volume *createdVolume) InitializeResourceCache(resourceCache UsedResourceC
 	return nil
 }
 
+// RecordResourceCacheChecksum records the checksum of the volume's contents
+// against the resource cache the volume was initialized as, so that it can
+// later be verified. It does nothing if the volume is not the cache's volume,
+// e.g. because another volume was initialized as the cache first.
+func (volume *createdVolume) RecordResourceCacheChecksum(checksum string) error {
+	_, err := psql.Update("worker_resource_caches").
+		Set("checksum", checksum).
+		Set("verified_at", sq.Expr("now()")).
+		Where(sq.Expr("id = (SELECT worker_resource_cache_id FROM volumes WHERE id = ?)", volume.id)).
+		RunWith(volume.conn).
+		Exec()
+	return err
+}
+
+// ResourceCacheChecksum returns the checksum recorded for the resource cache
+// the volume was initialized as, along with when the volume was last found to
+// match it. It is not found if the volume is not a resource cache or if no
+// checksum was recorded for it.
+func (volume *createdVolume) ResourceCacheChecksum() (string, time.Time, bool, error) {
+	var checksum sql.NullString
+	var verifiedAt pq.NullTime
+	err := psql.Select("wrc.checksum", "wrc.verified_at").
+		From("volumes v").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		Where(sq.Eq{"v.id": volume.id}).
+		RunWith(volume.conn).
+		QueryRow().
+		Scan(&checksum, &verifiedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", time.Time{}, false, nil
+		}
+
+		return "", time.Time{}, false, err
+	}
+
+	if !checksum.Valid {
+		return "", time.Time{}, false, nil
+	}
+
+	return checksum.String, verifiedAt.Time, true, nil
+}
+
+// MarkResourceCacheVerified records that the volume's contents have just been
+// found to match the resource cache's checksum.
+func (volume *createdVolume) MarkResourceCacheVerified() error {
+	_, err := psql.Update("worker_resource_caches").
+		Set("verified_at", sq.Expr("now()")).
+		Where(sq.Expr("id = (SELECT worker_resource_cache_id FROM volumes WHERE id = ?)", volume.id)).
+		RunWith(volume.conn).
+		Exec()
+	return err
+}
+
+// InvalidateResourceCache removes the resource cache from the volume's worker
+// so that it is fetched again by the next build that needs it. The volume is
+// left to be garbage collected once nothing is using it.
+func (volume *createdVolume) InvalidateResourceCache() error {
+	_, err := psql.Delete("worker_resource_caches").
+		Where(sq.Expr("id = (SELECT worker_resource_cache_id FROM volumes WHERE id = ?)", volume.id)).
+		RunWith(volume.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	volume.resourceCacheID = 0
+
+	return nil
+}
+
 func (volume *createdVolume) GetResourceCacheID() int {
 	return volume.resourceCacheID
 }
 
 func (volume *createdVolume) InitializeArtifact(name string, buildID int) (WorkerArtifact, error) {
+	return volume.initializeArtifact(func(tx Tx) (WorkerArtifact, error) {
+		atcWorkerArtifact := atc.WorkerArtifact{
+			Name:    name,
+			BuildID: buildID,
+		}
+
+		return saveWorkerArtifact(tx, volume.conn, atcWorkerArtifact)
+	})
+}
+
+// InitializeStepOutput saves the volume as the output of a build's step so
+// that it can be downloaded for as long as the step's container exists, or
+// until expiresAt if that is later.
+func (volume *createdVolume) InitializeStepOutput(name string, buildID int, planID atc.PlanID, expiresAt time.Time) (WorkerArtifact, error) {
+	return volume.initializeArtifact(func(tx Tx) (WorkerArtifact, error) {
+		return saveStepOutputArtifact(tx, volume.conn, name, buildID, planID, expiresAt)
+	})
+}
+
+func (volume *createdVolume) initializeArtifact(save func(Tx) (WorkerArtifact, error)) (WorkerArtifact, error) {
 	tx, err := volume.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -416,12 +515,7 @@ func (volume *createdVolume) InitializeArtifact(name string, buildID int) (Worke
 
 	defer Rollback(tx)
 
-	atcWorkerArtifact := atc.WorkerArtifact{
-		Name:    name,
-		BuildID: buildID,
-	}
-
-	workerArtifact, err := saveWorkerArtifact(tx, volume.conn, atcWorkerArtifact)
+	workerArtifact, err := save(tx)
 	if err != nil {
 		return nil, err
 	}
@@ -452,6 +546,63 @@ func (volume *createdVolume) InitializeArtifact(name string, buildID int) (Worke
 	return workerArtifact, nil
 }
 
+// InitializeKeyedCache saves the volume as the job's cache for the path under
+// the key, replacing the volume previously saved under the key, if any.
+func (volume *createdVolume) InitializeKeyedCache(jobID int, path string, key string, size int64) error {
+	tx, err := volume.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var keyedCacheID int
+	err = psql.Insert("keyed_caches").
+		Columns("team_id", "job_id", "path", "key", "size").
+		Values(volume.teamID, jobID, path, key, size).
+		Suffix(`ON CONFLICT (job_id, path, key) DO UPDATE SET
+			size = EXCLUDED.size,
+			created_at = now(),
+			last_used = now()
+		RETURNING id`).
+		RunWith(tx).
+		QueryRow().
+		Scan(&keyedCacheID)
+	if err != nil {
+		return err
+	}
+
+	// release the old volume for gc
+	_, err = psql.Update("volumes").
+		Set("keyed_cache_id", nil).
+		Where(sq.Eq{"keyed_cache_id": keyedCacheID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rows, err := psql.Update("volumes").
+		Set("keyed_cache_id", keyedCacheID).
+		Where(sq.Eq{"id": volume.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrVolumeMissing
+	}
+
+	return tx.Commit()
+}
+
 func (volume *createdVolume) InitializeTaskCache(jobID int, stepName string, path string) error {
 	tx, err := volume.conn.Begin()
 	if err != nil {
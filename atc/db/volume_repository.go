@@ -21,6 +21,9 @@ type VolumeRepository interface {
 	CreateBaseResourceTypeVolume(*UsedWorkerBaseResourceType) (CreatingVolume, error)
 
 	FindResourceCacheVolume(workerName string, resourceCache UsedResourceCache) (CreatedVolume, bool, error)
+	FindResourceCacheBaseVolume(workerName string, resourceCache UsedResourceCache) (CreatedVolume, bool, error)
+	FindTaskCacheBaseVolume(workerName string, jobID int, path string) (CreatedVolume, bool, error)
+	GetResourceCacheVolumesToVerify(workerName string, verifiedBefore time.Time, limit int) ([]CreatedVolume, error)
 
 	FindTaskCacheVolume(teamID int, workerName string, taskCache UsedTaskCache) (CreatedVolume, bool, error)
 	CreateTaskCacheVolume(teamID int, uwtc *UsedWorkerTaskCache) (CreatingVolume, error)
@@ -452,6 +455,131 @@ func (repository *volumeRepository) FindResourceCacheVolume(workerName string, r
 	return createdVolume, true, nil
 }
 
+// FindResourceCacheBaseVolume finds the most recently created volume on the
+// worker for another version of the same resource config, to be used as the
+// base for streaming the resource cache with a delta.
+func (repository *volumeRepository) FindResourceCacheBaseVolume(workerName string, resourceCache UsedResourceCache) (CreatedVolume, bool, error) {
+	row := psql.Select(volumeColumns...).
+		From("volumes v").
+		LeftJoin("workers w ON v.worker_name = w.name").
+		LeftJoin("containers c ON v.container_id = c.id").
+		LeftJoin("volumes pv ON v.parent_id = pv.id").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		Join("resource_caches rc ON rc.id = wrc.resource_cache_id").
+		Where(sq.Eq{
+			"v.worker_name":         workerName,
+			"v.state":               string(VolumeStateCreated),
+			"rc.resource_config_id": resourceCache.ResourceConfig().ID(),
+		}).
+		Where(sq.NotEq{
+			"rc.id": resourceCache.ID(),
+		}).
+		OrderBy("v.id DESC").
+		Limit(1).
+		RunWith(repository.conn).
+		QueryRow()
+
+	_, createdVolume, _, _, err := scanVolume(row, repository.conn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if createdVolume == nil {
+		return nil, false, nil
+	}
+
+	return createdVolume, true, nil
+}
+
+// FindTaskCacheBaseVolume finds the most recently created volume on the worker
+// holding the job's cache for the path, either as a keyed cache or as a task
+// cache, to be used as the base for streaming a keyed cache with a delta.
+func (repository *volumeRepository) FindTaskCacheBaseVolume(workerName string, jobID int, path string) (CreatedVolume, bool, error) {
+	row := psql.Select(volumeColumns...).
+		From("volumes v").
+		LeftJoin("workers w ON v.worker_name = w.name").
+		LeftJoin("containers c ON v.container_id = c.id").
+		LeftJoin("volumes pv ON v.parent_id = pv.id").
+		LeftJoin("keyed_caches kc ON kc.id = v.keyed_cache_id").
+		LeftJoin("worker_task_caches wtc ON wtc.id = v.worker_task_cache_id").
+		LeftJoin("task_caches tc ON tc.id = wtc.task_cache_id").
+		Where(sq.Eq{
+			"v.worker_name": workerName,
+			"v.state":       string(VolumeStateCreated),
+		}).
+		Where(sq.Or{
+			sq.Eq{"kc.job_id": jobID, "kc.path": path},
+			sq.Eq{"tc.job_id": jobID, "tc.path": path},
+		}).
+		OrderBy("v.id DESC").
+		Limit(1).
+		RunWith(repository.conn).
+		QueryRow()
+
+	_, createdVolume, _, _, err := scanVolume(row, repository.conn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if createdVolume == nil {
+		return nil, false, nil
+	}
+
+	return createdVolume, true, nil
+}
+
+// GetResourceCacheVolumesToVerify returns the worker's resource cache volumes
+// which have a checksum and have not been verified since the given time, least
+// recently verified first.
+func (repository *volumeRepository) GetResourceCacheVolumesToVerify(workerName string, verifiedBefore time.Time, limit int) ([]CreatedVolume, error) {
+	rows, err := psql.Select(volumeColumns...).
+		From("volumes v").
+		LeftJoin("workers w ON v.worker_name = w.name").
+		LeftJoin("containers c ON v.container_id = c.id").
+		LeftJoin("volumes pv ON v.parent_id = pv.id").
+		Join("worker_resource_caches wrc ON wrc.id = v.worker_resource_cache_id").
+		Where(sq.Eq{
+			"v.worker_name": workerName,
+			"v.state":       string(VolumeStateCreated),
+		}).
+		Where(sq.NotEq{
+			"wrc.checksum": nil,
+		}).
+		Where(sq.Or{
+			sq.Eq{"wrc.verified_at": nil},
+			sq.Lt{"wrc.verified_at": verifiedBefore},
+		}).
+		OrderBy("wrc.verified_at ASC NULLS FIRST").
+		Limit(uint64(limit)).
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var createdVolumes []CreatedVolume
+	for rows.Next() {
+		_, createdVolume, _, _, err := scanVolume(rows, repository.conn)
+		if err != nil {
+			return nil, err
+		}
+
+		if createdVolume != nil {
+			createdVolumes = append(createdVolumes, createdVolume)
+		}
+	}
+
+	return createdVolumes, nil
+}
+
 func (repository *volumeRepository) FindCreatedVolume(handle string) (CreatedVolume, bool, error) {
 	_, createdVolume, err := getVolume(repository.conn, map[string]interface{}{
 		"v.handle": handle,
@@ -482,6 +610,7 @@ func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error
 				"v.worker_task_cache_id":         nil,
 				"v.worker_resource_certs_id":     nil,
 				"v.worker_artifact_id":           nil,
+				"v.keyed_cache_id":               nil,
 			},
 		).
 		Where(sq.Eq{"v.state": string(VolumeStateCreated)}).
@@ -736,6 +865,7 @@ var volumeColumns = []string{
 	when v.worker_task_cache_id is not NULL then 'task-cache'
 	when v.worker_resource_certs_id is not NULL then 'resource-certs'
 	when v.worker_artifact_id is not NULL then 'artifact'
+	when v.keyed_cache_id is not NULL then 'keyed-cache'
 	else 'unknown'
 end`,
 }
@@ -540,6 +540,237 @@ var _ = Describe("VolumeRepository", func() {
 		})
 	})
 
+	Describe("GetResourceCacheVolumesToVerify", func() {
+		var (
+			usedResourceCache db.UsedResourceCache
+			cacheVolume       db.CreatedVolume
+		)
+
+		BeforeEach(func() {
+			build, err := defaultPipeline.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			usedResourceCache, err = resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-type",
+				atc.Version{"some": "version"},
+				atc.Source{
+					"some": "source",
+				},
+				atc.Params{"some": "params"},
+				atc.VersionedResourceTypes{
+					atc.VersionedResourceType{
+						ResourceType: atc.ResourceType{
+							Name: "some-type",
+							Type: "some-base-resource-type",
+							Source: atc.Source{
+								"some-type": "source",
+							},
+						},
+						Version: atc.Version{"some-type": "version"},
+					},
+				},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{
+				Type:     "get",
+				StepName: "some-resource",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			resourceCacheVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+			Expect(err).NotTo(HaveOccurred())
+
+			cacheVolume, err = resourceCacheVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = cacheVolume.InitializeResourceCache(usedResourceCache)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the cache has no checksum", func() {
+			It("does not return the volume", func() {
+				volumes, err := volumeRepository.GetResourceCacheVolumesToVerify(defaultWorker.Name(), time.Now(), 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(BeEmpty())
+			})
+		})
+
+		Context("when the cache has a checksum", func() {
+			BeforeEach(func() {
+				err := cacheVolume.RecordResourceCacheChecksum("some-checksum")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the volume once it has not been verified since the given time", func() {
+				volumes, err := volumeRepository.GetResourceCacheVolumesToVerify(defaultWorker.Name(), time.Now().Add(time.Minute), 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+				Expect(volumes[0].Handle()).To(Equal(cacheVolume.Handle()))
+			})
+
+			It("does not return the volume if it was verified since the given time", func() {
+				volumes, err := volumeRepository.GetResourceCacheVolumesToVerify(defaultWorker.Name(), time.Now().Add(-time.Minute), 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(BeEmpty())
+			})
+
+			It("does not return volumes on other workers", func() {
+				volumes, err := volumeRepository.GetResourceCacheVolumesToVerify("some-other-worker", time.Now().Add(time.Minute), 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("FindResourceCacheBaseVolume", func() {
+		var usedResourceCache db.UsedResourceCache
+
+		findOrCreateResourceCache := func(version atc.Version) db.UsedResourceCache {
+			build, err := defaultPipeline.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			resourceCache, err := resourceCacheFactory.FindOrCreateResourceCache(
+				db.ForBuild(build.ID()),
+				"some-base-resource-type",
+				version,
+				atc.Source{"some": "source"},
+				atc.Params{"some": "params"},
+				atc.VersionedResourceTypes{},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			return resourceCache
+		}
+
+		createCacheVolume := func(resourceCache db.UsedResourceCache) db.CreatedVolume {
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{
+				Type:     "get",
+				StepName: "some-resource",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = createdVolume.InitializeResourceCache(resourceCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			return createdVolume
+		}
+
+		BeforeEach(func() {
+			usedResourceCache = findOrCreateResourceCache(atc.Version{"some": "version"})
+		})
+
+		Context("when there is only a volume for the same resource cache", func() {
+			BeforeEach(func() {
+				createCacheVolume(usedResourceCache)
+			})
+
+			It("does not find a base", func() {
+				_, found, err := volumeRepository.FindResourceCacheBaseVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there are volumes for other versions of the resource", func() {
+			var newestVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				createCacheVolume(findOrCreateResourceCache(atc.Version{"some": "older-version"}))
+				newestVolume = createCacheVolume(findOrCreateResourceCache(atc.Version{"some": "newer-version"}))
+			})
+
+			It("returns the most recently created one", func() {
+				baseVolume, found, err := volumeRepository.FindResourceCacheBaseVolume(defaultWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(baseVolume.Handle()).To(Equal(newestVolume.Handle()))
+			})
+
+			It("does not return volumes on other workers", func() {
+				_, found, err := volumeRepository.FindResourceCacheBaseVolume(otherWorker.Name(), usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("FindTaskCacheBaseVolume", func() {
+		createTaskCacheVolume := func(path string) db.CreatedVolume {
+			taskCache, err := taskCacheFactory.FindOrCreate(defaultJob.ID(), "some-step", path)
+			Expect(err).NotTo(HaveOccurred())
+
+			usedWorkerTaskCache, err := workerTaskCacheFactory.FindOrCreate(db.WorkerTaskCache{
+				TaskCache:  taskCache,
+				WorkerName: defaultWorker.Name(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateTaskCacheVolume(defaultTeam.ID(), usedWorkerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			return createdVolume
+		}
+
+		createKeyedCacheVolume := func(path string, key string) db.CreatedVolume {
+			creatingVolume, err := volumeRepository.CreateVolume(defaultTeam.ID(), defaultWorker.Name(), db.VolumeTypeKeyedCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = createdVolume.InitializeKeyedCache(defaultJob.ID(), path, key, 1024)
+			Expect(err).NotTo(HaveOccurred())
+
+			return createdVolume
+		}
+
+		Context("when there are no caches for the path", func() {
+			BeforeEach(func() {
+				createTaskCacheVolume("other-path")
+				createKeyedCacheVolume("other-path", "some-key")
+			})
+
+			It("does not find a base", func() {
+				_, found, err := volumeRepository.FindTaskCacheBaseVolume(defaultWorker.Name(), defaultJob.ID(), "some-path")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there are task caches and keyed caches for the path", func() {
+			var newestVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				createTaskCacheVolume("some-path")
+				newestVolume = createKeyedCacheVolume("some-path", "some-key")
+			})
+
+			It("returns the most recently created one", func() {
+				baseVolume, found, err := volumeRepository.FindTaskCacheBaseVolume(defaultWorker.Name(), defaultJob.ID(), "some-path")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(baseVolume.Handle()).To(Equal(newestVolume.Handle()))
+			})
+
+			It("does not return volumes on other workers", func() {
+				_, found, err := volumeRepository.FindTaskCacheBaseVolume(otherWorker.Name(), defaultJob.ID(), "some-path")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("RemoveDestroyingVolumes", func() {
 		var failedErr error
 		var numDeleted int
@@ -259,6 +259,54 @@ var _ = Describe("Volume", func() {
 				Expect(createdVolume.Type()).To(Equal(db.VolumeTypeContainer))
 			})
 		})
+
+		Describe("resource cache checksums", func() {
+			It("has no checksum to begin with", func() {
+				_, _, found, err := createdVolume.ResourceCacheChecksum()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when a checksum is recorded", func() {
+				BeforeEach(func() {
+					err := createdVolume.RecordResourceCacheChecksum("some-checksum")
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns the checksum", func() {
+					checksum, verifiedAt, found, err := createdVolume.ResourceCacheChecksum()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(checksum).To(Equal("some-checksum"))
+					Expect(verifiedAt).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+
+				It("counts as verified", func() {
+					volumes, err := volumeRepository.GetResourceCacheVolumesToVerify(defaultWorker.Name(), time.Now().Add(-time.Minute), 10)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(volumes).To(BeEmpty())
+				})
+
+				Context("when the cache is invalidated", func() {
+					BeforeEach(func() {
+						err := createdVolume.InvalidateResourceCache()
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("is no longer found as the resource cache volume", func() {
+						_, found, err := volumeRepository.FindResourceCacheVolume(defaultWorker.Name(), resourceCache)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeFalse())
+					})
+
+					It("no longer has a checksum", func() {
+						_, _, found, err := createdVolume.ResourceCacheChecksum()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeFalse())
+					})
+				})
+			})
+		})
 	})
 
 	Describe("createdVolume.InitializeArtifact", func() {
@@ -297,6 +345,55 @@ var _ = Describe("Volume", func() {
 		})
 	})
 
+	Describe("createdVolume.InitializeStepOutput", func() {
+		var (
+			build          db.Build
+			workerArtifact db.WorkerArtifact
+			createdVolume  db.CreatedVolume
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			createdVolume, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			workerArtifact, err = createdVolume.InitializeStepOutput("some-output", build.ID(), "some-plan", time.Now().Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("associates the worker artifact with the volume", func() {
+			created, found, err := volumeRepository.FindCreatedVolume(createdVolume.Handle())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(created.WorkerArtifactID()).To(Equal(workerArtifact.ID()))
+		})
+
+		It("can be found as the output of the step", func() {
+			stepOutput, found, err := build.StepOutput("some-plan", "some-output")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(stepOutput.ID()).To(Equal(workerArtifact.ID()))
+		})
+
+		It("is not listed as one of the build's artifacts", func() {
+			artifacts, err := build.Artifacts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifacts).To(BeEmpty())
+		})
+	})
+
 	Describe("createdVolume.InitializeTaskCache", func() {
 		Context("when there is a volume that belongs to worker task cache", func() {
 			var (
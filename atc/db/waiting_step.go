@@ -0,0 +1,157 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . WaitingStepFactory
+
+// WaitingStepFactory keeps track of the steps which are waiting for a worker
+// to become available, across all of the web nodes.
+//
+// Waiting steps expire unless they're heartbeated, so that the steps of a web
+// node which went away don't count as demand forever.
+type WaitingStepFactory interface {
+	CreateWaitingStep(spec WaitingStepSpec, ttl time.Duration) (WaitingStep, error)
+	WaitingSteps() ([]WaitingSteps, error)
+	RemoveExpiredWaitingSteps() error
+}
+
+// WaitingStepSpec describes the workers that a waiting step requires.
+type WaitingStepSpec struct {
+	Platform string
+	Tags     []string
+	TeamID   int
+}
+
+// WaitingSteps is the number of steps waiting for the same kind of workers.
+type WaitingSteps struct {
+	Platform string
+	Tags     []string
+	TeamID   int
+	TeamName string
+	Count    int
+}
+
+//go:generate counterfeiter . WaitingStep
+
+type WaitingStep interface {
+	ID() int
+	Heartbeat(ttl time.Duration) error
+	Delete() error
+}
+
+type waitingStepFactory struct {
+	conn Conn
+}
+
+func NewWaitingStepFactory(conn Conn) WaitingStepFactory {
+	return &waitingStepFactory{
+		conn: conn,
+	}
+}
+
+func (f *waitingStepFactory) CreateWaitingStep(spec WaitingStepSpec, ttl time.Duration) (WaitingStep, error) {
+	// tags are sorted so that steps requiring the same set of tags are grouped
+	// together regardless of the order they were configured in
+	tags := append([]string{}, spec.Tags...)
+	sort.Strings(tags)
+
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = psql.Insert("waiting_steps").
+		Columns("platform", "tags", "team_id", "expires").
+		Values(spec.Platform, string(tagsJSON), spec.TeamID, sq.Expr(expiresIn(ttl))).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &waitingStep{id: id, conn: f.conn}, nil
+}
+
+func (f *waitingStepFactory) WaitingSteps() ([]WaitingSteps, error) {
+	rows, err := psql.Select("s.platform", "s.tags", "s.team_id", "t.name", "COUNT(*)").
+		From("waiting_steps s").
+		Join("teams t ON t.id = s.team_id").
+		Where(sq.Expr("s.expires > NOW()")).
+		GroupBy("s.platform", "s.tags", "s.team_id", "t.name").
+		OrderBy("s.platform", "s.tags", "t.name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var waiting []WaitingSteps
+	for rows.Next() {
+		var (
+			steps WaitingSteps
+			tags  string
+		)
+
+		err = rows.Scan(&steps.Platform, &tags, &steps.TeamID, &steps.TeamName, &steps.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(tags), &steps.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		waiting = append(waiting, steps)
+	}
+
+	return waiting, nil
+}
+
+func (f *waitingStepFactory) RemoveExpiredWaitingSteps() error {
+	_, err := psql.Delete("waiting_steps").
+		Where(sq.Expr("expires <= NOW()")).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+type waitingStep struct {
+	id   int
+	conn Conn
+}
+
+func (step *waitingStep) ID() int { return step.id }
+
+func (step *waitingStep) Heartbeat(ttl time.Duration) error {
+	_, err := psql.Update("waiting_steps").
+		Set("expires", sq.Expr(expiresIn(ttl))).
+		Where(sq.Eq{"id": step.id}).
+		RunWith(step.conn).
+		Exec()
+	return err
+}
+
+func (step *waitingStep) Delete() error {
+	_, err := psql.Delete("waiting_steps").
+		Where(sq.Eq{"id": step.id}).
+		RunWith(step.conn).
+		Exec()
+	return err
+}
+
+func expiresIn(ttl time.Duration) string {
+	return fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
+}
@@ -0,0 +1,121 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WaitingStepFactory", func() {
+	var (
+		waitingStepFactory db.WaitingStepFactory
+		otherTeam          db.Team
+	)
+
+	BeforeEach(func() {
+		waitingStepFactory = db.NewWaitingStepFactory(dbConn)
+
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("WaitingSteps", func() {
+		var step db.WaitingStep
+
+		BeforeEach(func() {
+			var err error
+			step, err = waitingStepFactory.CreateWaitingStep(db.WaitingStepSpec{
+				Platform: "linux",
+				Tags:     []string{"b", "a"},
+				TeamID:   defaultTeam.ID(),
+			}, time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = waitingStepFactory.CreateWaitingStep(db.WaitingStepSpec{
+				Platform: "linux",
+				Tags:     []string{"a", "b"},
+				TeamID:   defaultTeam.ID(),
+			}, time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = waitingStepFactory.CreateWaitingStep(db.WaitingStepSpec{
+				Platform: "linux",
+				TeamID:   otherTeam.ID(),
+			}, time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the steps waiting for the same kind of workers", func() {
+			waiting, err := waitingStepFactory.WaitingSteps()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(waiting).To(ConsistOf(
+				db.WaitingSteps{
+					Platform: "linux",
+					Tags:     []string{"a", "b"},
+					TeamID:   defaultTeam.ID(),
+					TeamName: "default-team",
+					Count:    2,
+				},
+				db.WaitingSteps{
+					Platform: "linux",
+					Tags:     []string{},
+					TeamID:   otherTeam.ID(),
+					TeamName: "other-team",
+					Count:    1,
+				},
+			))
+		})
+
+		Context("when a step is no longer waiting", func() {
+			BeforeEach(func() {
+				err := step.Delete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("no longer counts it", func() {
+				waiting, err := waitingStepFactory.WaitingSteps()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(waiting).To(ContainElement(db.WaitingSteps{
+					Platform: "linux",
+					Tags:     []string{"a", "b"},
+					TeamID:   defaultTeam.ID(),
+					TeamName: "default-team",
+					Count:    1,
+				}))
+			})
+		})
+
+		Context("when a step has expired", func() {
+			BeforeEach(func() {
+				err := step.Heartbeat(0)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("no longer counts it", func() {
+				waiting, err := waitingStepFactory.WaitingSteps()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(waiting).To(ContainElement(db.WaitingSteps{
+					Platform: "linux",
+					Tags:     []string{"a", "b"},
+					TeamID:   defaultTeam.ID(),
+					TeamName: "default-team",
+					Count:    1,
+				}))
+			})
+
+			It("is removed along with other expired steps", func() {
+				err := waitingStepFactory.RemoveExpiredWaitingSteps()
+				Expect(err).ToNot(HaveOccurred())
+
+				var count int
+				err = dbConn.QueryRow(`SELECT COUNT(*) FROM waiting_steps`).Scan(&count)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(2))
+			})
+		})
+	})
+})
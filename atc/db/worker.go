@@ -26,6 +26,74 @@ func (e ContainerOwnerDisappearedError) Error() string {
 	return fmt.Sprintf("container owner %T disappeared", e.owner)
 }
 
+// WorkerHealthEvent is a kind of outcome which affects the health score of a
+// worker.
+type WorkerHealthEvent string
+
+const (
+	WorkerHealthContainerCreation = WorkerHealthEvent("container-creation")
+	WorkerHealthVolumeStreaming   = WorkerHealthEvent("volume-streaming")
+	WorkerHealthStep              = WorkerHealthEvent("step")
+)
+
+// workerHealthFailureColumns are the columns counting the failures of each
+// kind of health event.
+var workerHealthFailureColumns = map[WorkerHealthEvent]string{
+	WorkerHealthContainerCreation: "container_creation_failures",
+	WorkerHealthVolumeStreaming:   "volume_streaming_failures",
+	WorkerHealthStep:              "errored_steps",
+}
+
+// workerHealthWeight is the weight of each new outcome in the health score of
+// a worker, which is an exponentially weighted moving average of its recent
+// outcomes, ranging from 0 (everything fails) to 1 (healthy).
+const workerHealthWeight = 0.1
+
+// WorkerHealthOutcome is the outcome of a health event on a worker.
+type WorkerHealthOutcome struct {
+	Event  WorkerHealthEvent
+	Failed bool
+}
+
+// WorkerHealthOutcomes are outcomes of health events on a worker, in the order
+// in which they happened.
+type WorkerHealthOutcomes []WorkerHealthOutcome
+
+// Score returns the health score resulting from the outcomes, starting from
+// the given score.
+func (outcomes WorkerHealthOutcomes) Score(score float64) float64 {
+	decay, gain := outcomes.weights()
+	return score*decay + gain
+}
+
+// weights returns the factor by which the outcomes decay the score they start
+// from, and what they add to it.
+func (outcomes WorkerHealthOutcomes) weights() (float64, float64) {
+	decay, gain := 1.0, 0.0
+	for _, outcome := range outcomes {
+		decay *= 1 - workerHealthWeight
+		gain *= 1 - workerHealthWeight
+
+		if !outcome.Failed {
+			gain += workerHealthWeight
+		}
+	}
+
+	return decay, gain
+}
+
+// WorkerQuarantine configures when workers are quarantined automatically.
+type WorkerQuarantine struct {
+	// Threshold is the health score below which workers are quarantined. 0
+	// disables quarantining.
+	Threshold float64
+
+	// MaxFraction is the largest fraction of all workers which may be
+	// quarantined at once, so that a failure which is not any worker's fault
+	// cannot take out the whole cluster.
+	MaxFraction float64
+}
+
 type WorkerState string
 
 const (
@@ -34,6 +102,7 @@ const (
 	WorkerStateLanding  = WorkerState("landing")
 	WorkerStateLanded   = WorkerState("landed")
 	WorkerStateRetiring = WorkerState("retiring")
+	WorkerStateEvicting = WorkerState("evicting")
 )
 
 func AllWorkerStates() []WorkerState {
@@ -43,6 +112,7 @@ func AllWorkerStates() []WorkerState {
 		WorkerStateLanding,
 		WorkerStateLanded,
 		WorkerStateRetiring,
+		WorkerStateEvicting,
 	}
 }
 
@@ -69,14 +139,28 @@ type Worker interface {
 	StartTime() time.Time
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	Rootless() bool
+	SupportedContainerLimits() []string
+	Features() []string
+
+	HealthScore() float64
+	ContainerCreationFailures() int
+	VolumeStreamingFailures() int
+	ErroredSteps() int
+	Quarantined() bool
 
 	Reload() (bool, error)
 
 	Land() error
 	Retire() error
+	Evict() error
 	Prune() error
 	Delete() error
 
+	RecordHealth(outcomes WorkerHealthOutcomes, quarantine WorkerQuarantine) (bool, error)
+	Quarantine() error
+	Release() error
+
 	ActiveTasks() (int, error)
 	IncreaseActiveTasks() (int, error)
 	DecreaseActiveTasks() (int, error)
@@ -108,6 +192,16 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	rootless         bool
+
+	supportedContainerLimits []string
+	features                 []string
+
+	healthScore               float64
+	containerCreationFailures int
+	volumeStreamingFailures   int
+	erroredSteps              int
+	quarantined               bool
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -128,6 +222,16 @@ func (worker *worker) Tags() []string                          { return worker.t
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) Rootless() bool                          { return worker.rootless }
+
+func (worker *worker) SupportedContainerLimits() []string { return worker.supportedContainerLimits }
+func (worker *worker) Features() []string                 { return worker.features }
+
+func (worker *worker) HealthScore() float64           { return worker.healthScore }
+func (worker *worker) ContainerCreationFailures() int { return worker.containerCreationFailures }
+func (worker *worker) VolumeStreamingFailures() int   { return worker.volumeStreamingFailures }
+func (worker *worker) ErroredSteps() int              { return worker.erroredSteps }
+func (worker *worker) Quarantined() bool              { return worker.quarantined }
 
 func (worker *worker) StartTime() time.Time { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
@@ -203,6 +307,126 @@ func (worker *worker) Retire() error {
 	return nil
 }
 
+// Evict transitions the worker to the evicting state, which stops any more work
+// from being placed on it without waiting for its builds, as the worker is
+// about to go away.
+func (worker *worker) Evict() error {
+	result, err := psql.Update("workers").
+		SetMap(map[string]interface{}{
+			"state": string(WorkerStateEvicting),
+		}).
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	return nil
+}
+
+// RecordHealth updates the health score of the worker with the outcomes of
+// events, quarantining the worker if its score drops below the threshold and
+// not too many workers are quarantined already. It returns whether the worker
+// is quarantined.
+func (worker *worker) RecordHealth(outcomes WorkerHealthOutcomes, quarantine WorkerQuarantine) (bool, error) {
+	failures := map[string]int{}
+	for _, outcome := range outcomes {
+		failureColumn, found := workerHealthFailureColumns[outcome.Event]
+		if !found {
+			return false, fmt.Errorf("unknown worker health event: %s", outcome.Event)
+		}
+
+		if outcome.Failed {
+			failures[failureColumn]++
+		}
+	}
+
+	decay, gain := outcomes.weights()
+
+	update := psql.Update("workers").
+		Set("health_score", sq.Expr("health_score * ? + ?", decay, gain)).
+		Set("quarantined", sq.Expr(
+			`quarantined OR (
+				? > 0 AND health_score * ? + ? < ?
+				AND (SELECT COUNT(*) FROM workers WHERE quarantined) + 1 <= ? * (SELECT COUNT(*) FROM workers)
+			)`,
+			quarantine.Threshold, decay, gain, quarantine.Threshold, quarantine.MaxFraction,
+		))
+
+	for failureColumn, count := range failures {
+		update = update.Set(failureColumn, sq.Expr(failureColumn+" + ?", count))
+	}
+
+	var quarantined bool
+	err := update.
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("RETURNING health_score, quarantined").
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&worker.healthScore, &quarantined)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, ErrWorkerNotPresent
+		}
+		return false, err
+	}
+
+	worker.quarantined = quarantined
+
+	return quarantined, nil
+}
+
+// Quarantine excludes the worker from container placement until it is
+// released.
+func (worker *worker) Quarantine() error {
+	return worker.setQuarantined(true)
+}
+
+// Release lifts the quarantine of the worker and resets its health score.
+func (worker *worker) Release() error {
+	return worker.setQuarantined(false)
+}
+
+func (worker *worker) setQuarantined(quarantined bool) error {
+	values := map[string]interface{}{
+		"quarantined": quarantined,
+	}
+
+	if !quarantined {
+		values["health_score"] = 1
+	}
+
+	result, err := psql.Update("workers").
+		SetMap(values).
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	return nil
+}
+
 func (worker *worker) Prune() error {
 	tx, err := worker.conn.Begin()
 	if err != nil {
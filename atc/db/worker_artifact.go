@@ -54,9 +54,6 @@ func (a *artifact) Volume(teamID int) (CreatedVolume, bool, error) {
 }
 
 func saveWorkerArtifact(tx Tx, conn Conn, atcArtifact atc.WorkerArtifact) (WorkerArtifact, error) {
-
-	var artifactID int
-
 	values := map[string]interface{}{
 		"name": atcArtifact.Name,
 	}
@@ -65,6 +62,28 @@ func saveWorkerArtifact(tx Tx, conn Conn, atcArtifact atc.WorkerArtifact) (Worke
 		values["build_id"] = atcArtifact.BuildID
 	}
 
+	return insertWorkerArtifact(tx, conn, values)
+}
+
+// saveStepOutputArtifact saves an artifact for the output of a step. The
+// artifact lives for as long as the step's container, or until expiresAt if
+// that is later. A zero expiresAt ties it to the container alone.
+func saveStepOutputArtifact(tx Tx, conn Conn, name string, buildID int, planID atc.PlanID, expiresAt time.Time) (WorkerArtifact, error) {
+	values := map[string]interface{}{
+		"name":     name,
+		"build_id": buildID,
+		"plan_id":  string(planID),
+	}
+
+	if !expiresAt.IsZero() {
+		values["expires_at"] = expiresAt
+	}
+
+	return insertWorkerArtifact(tx, conn, values)
+}
+
+func insertWorkerArtifact(tx Tx, conn Conn, values map[string]interface{}) (WorkerArtifact, error) {
+	var artifactID int
 	err := psql.Insert("worker_artifacts").
 		SetMap(values).
 		Suffix("RETURNING id").
@@ -23,7 +23,32 @@ func NewArtifactLifecycle(conn Conn) *artifactLifecycle {
 func (lifecycle *artifactLifecycle) RemoveExpiredArtifacts() error {
 
 	_, err := psql.Delete("worker_artifacts").
-		Where(sq.Expr("created_at < NOW() - interval '12 hours'")).
+		Where(sq.And{
+			sq.Eq{"plan_id": nil},
+			sq.Expr("created_at < NOW() - interval '12 hours'"),
+		}).
+		RunWith(lifecycle.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	// step outputs are kept for as long as the step's container exists, or
+	// until they expire if they were kept for longer
+	_, err = psql.Delete("worker_artifacts a").
+		Where(sq.And{
+			sq.NotEq{"a.plan_id": nil},
+			sq.Or{
+				sq.Eq{"a.expires_at": nil},
+				sq.Expr("a.expires_at < NOW()"),
+			},
+			sq.Expr(`NOT EXISTS (
+				SELECT 1
+				FROM containers c
+				WHERE c.build_id = a.build_id
+				AND c.plan_id = a.plan_id
+			)`),
+		}).
 		RunWith(lifecycle.conn).
 		Exec()
 
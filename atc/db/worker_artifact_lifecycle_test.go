@@ -51,5 +51,85 @@ var _ = Describe("WorkerArtifactLifecycle", func() {
 				Expect(count).To(Equal(1))
 			})
 		})
+
+		Context("when the artifact is the output of a step", func() {
+			var (
+				build     db.Build
+				expiresAt string
+			)
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				expiresAt = "NULL"
+			})
+
+			JustBeforeEach(func() {
+				_, err := dbConn.Exec("INSERT INTO worker_artifacts(name, build_id, plan_id, expires_at, created_at) VALUES('some-output', $1, 'some-plan', "+expiresAt+", NOW() - '13 hours'::interval)", build.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				err = workerArtifactLifecycle.RemoveExpiredArtifacts()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			artifactCount := func() int {
+				var count int
+				err := dbConn.QueryRow("SELECT count(*) from worker_artifacts").Scan(&count)
+				Expect(err).ToNot(HaveOccurred())
+				return count
+			}
+
+			Context("when the step's container exists", func() {
+				BeforeEach(func() {
+					_, err := defaultWorker.CreateContainer(
+						db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+						db.ContainerMetadata{Type: "task"},
+					)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps the record regardless of its age", func() {
+					Expect(artifactCount()).To(Equal(1))
+				})
+
+				Context("when the artifact has expired", func() {
+					BeforeEach(func() {
+						expiresAt = "NOW() - '1 hour'::interval"
+					})
+
+					It("keeps the record", func() {
+						Expect(artifactCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the step's container is gone", func() {
+				It("removes the record", func() {
+					Expect(artifactCount()).To(Equal(0))
+				})
+
+				Context("when the artifact has not expired", func() {
+					BeforeEach(func() {
+						expiresAt = "NOW() + '1 hour'::interval"
+					})
+
+					It("keeps the record", func() {
+						Expect(artifactCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the artifact has expired", func() {
+					BeforeEach(func() {
+						expiresAt = "NOW() - '1 hour'::interval"
+					})
+
+					It("removes the record", func() {
+						Expect(artifactCount()).To(Equal(0))
+					})
+				})
+			})
+		})
 	})
 })
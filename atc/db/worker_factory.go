@@ -23,6 +23,7 @@ type WorkerFactory interface {
 
 	FindWorkersForContainerByOwner(ContainerOwner) ([]Worker, error)
 	BuildContainersCountPerWorker() (map[string]int, error)
+	ActiveTasksPerWorker() (map[string]int, error)
 }
 
 type workerFactory struct {
@@ -54,7 +55,15 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.rootless,
+		w.supported_container_limits,
+		w.features,
+		w.health_score,
+		w.container_creation_failures,
+		w.volume_streaming_failures,
+		w.errored_steps,
+		w.quarantined
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -140,6 +149,9 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+		rootless      sql.NullBool
+		limits        []byte
+		features      []byte
 	)
 
 	err := row.Scan(
@@ -162,6 +174,14 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&rootless,
+		&limits,
+		&features,
+		&worker.healthScore,
+		&worker.containerCreationFailures,
+		&worker.volumeStreamingFailures,
+		&worker.erroredSteps,
+		&worker.quarantined,
 	)
 	if err != nil {
 		return err
@@ -215,6 +235,24 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if rootless.Valid {
+		worker.rootless = rootless.Bool
+	}
+
+	if limits != nil {
+		err = json.Unmarshal(limits, &worker.supportedContainerLimits)
+		if err != nil {
+			return err
+		}
+	}
+
+	if features != nil {
+		err = json.Unmarshal(features, &worker.features)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -245,6 +283,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		When("'landing'::worker_state", "'landing'::worker_state").
 		When("'landed'::worker_state", "'landed'::worker_state").
 		When("'retiring'::worker_state", "'retiring'::worker_state").
+		When("'evicting'::worker_state", "'evicting'::worker_state").
 		Else("'running'::worker_state").
 		ToSql()
 
@@ -364,6 +403,34 @@ func (f *workerFactory) BuildContainersCountPerWorker() (map[string]int, error)
 	return countByWorker, nil
 }
 
+func (f *workerFactory) ActiveTasksPerWorker() (map[string]int, error) {
+	rows, err := psql.Select("name, active_tasks").
+		From("workers").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	activeTasksByWorker := make(map[string]int)
+
+	for rows.Next() {
+		var workerName string
+		var activeTasks int
+
+		err = rows.Scan(&workerName, &activeTasks)
+		if err != nil {
+			return nil, err
+		}
+
+		activeTasksByWorker[workerName] = activeTasks
+	}
+
+	return activeTasksByWorker, nil
+}
+
 func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, conn Conn) (Worker, error) {
 	resourceTypes, err := json.Marshal(atcWorker.ResourceTypes)
 	if err != nil {
@@ -375,6 +442,22 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	var supportedContainerLimits []byte
+	if atcWorker.SupportedContainerLimits != nil {
+		supportedContainerLimits, err = json.Marshal(atcWorker.SupportedContainerLimits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var features []byte
+	if atcWorker.Features != nil {
+		features, err = json.Marshal(atcWorker.Features)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -411,6 +494,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		atcWorker.Rootless,
+		supportedContainerLimits,
+		features,
 	}
 
 	conflictValues := values
@@ -422,7 +508,15 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		conflictValues = append(conflictValues, *teamID)
 	}
 
-	rows, err := psql.Insert("workers").
+	var health struct {
+		score                     float64
+		containerCreationFailures int
+		volumeStreamingFailures   int
+		erroredSteps              int
+		quarantined               bool
+	}
+
+	err = psql.Insert("workers").
 		Columns(
 			"expires",
 			"start_time",
@@ -442,6 +536,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"rootless",
+			"supported_container_limits",
+			"features",
 		).
 		Values(append([]interface{}{
 			sq.Expr(expires),
@@ -466,50 +563,63 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				version = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
-			WHERE `+matchTeamUpsert,
+				ephemeral = ?,
+				rootless = ?,
+				supported_container_limits = ?,
+				features = ?
+			WHERE `+matchTeamUpsert+`
+			RETURNING health_score, container_creation_failures, volume_streaming_failures, errored_steps, quarantined`,
 			conflictValues...,
 		).
 		RunWith(tx).
-		Exec()
-	if err != nil {
-		return nil, err
-	}
-
-	count, err := rows.RowsAffected()
+		QueryRow().
+		Scan(
+			&health.score,
+			&health.containerCreationFailures,
+			&health.volumeStreamingFailures,
+			&health.erroredSteps,
+			&health.quarantined,
+		)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("worker already exists and is either global or owned by another team")
+		}
 		return nil, err
 	}
 
-	if count == 0 {
-		return nil, errors.New("worker already exists and is either global or owned by another team")
-	}
-
 	var workerTeamID int
 	if teamID != nil {
 		workerTeamID = *teamID
 	}
 
 	savedWorker := &worker{
-		name:             atcWorker.Name,
-		version:          workerVersion,
-		state:            workerState,
-		gardenAddr:       &atcWorker.GardenAddr,
-		baggageclaimURL:  &atcWorker.BaggageclaimURL,
-		certsPath:        atcWorker.CertsPath,
-		httpProxyURL:     atcWorker.HTTPProxyURL,
-		httpsProxyURL:    atcWorker.HTTPSProxyURL,
-		noProxy:          atcWorker.NoProxy,
-		activeContainers: atcWorker.ActiveContainers,
-		activeVolumes:    atcWorker.ActiveVolumes,
-		resourceTypes:    atcWorker.ResourceTypes,
-		platform:         atcWorker.Platform,
-		tags:             atcWorker.Tags,
-		teamName:         atcWorker.Team,
-		teamID:           workerTeamID,
-		startTime:        time.Unix(atcWorker.StartTime, 0),
-		ephemeral:        atcWorker.Ephemeral,
-		conn:             conn,
+		name:                      atcWorker.Name,
+		version:                   workerVersion,
+		state:                     workerState,
+		gardenAddr:                &atcWorker.GardenAddr,
+		baggageclaimURL:           &atcWorker.BaggageclaimURL,
+		certsPath:                 atcWorker.CertsPath,
+		httpProxyURL:              atcWorker.HTTPProxyURL,
+		httpsProxyURL:             atcWorker.HTTPSProxyURL,
+		noProxy:                   atcWorker.NoProxy,
+		activeContainers:          atcWorker.ActiveContainers,
+		activeVolumes:             atcWorker.ActiveVolumes,
+		resourceTypes:             atcWorker.ResourceTypes,
+		platform:                  atcWorker.Platform,
+		tags:                      atcWorker.Tags,
+		teamName:                  atcWorker.Team,
+		teamID:                    workerTeamID,
+		startTime:                 time.Unix(atcWorker.StartTime, 0),
+		ephemeral:                 atcWorker.Ephemeral,
+		rootless:                  atcWorker.Rootless,
+		supportedContainerLimits:  atcWorker.SupportedContainerLimits,
+		features:                  atcWorker.Features,
+		healthScore:               health.score,
+		containerCreationFailures: health.containerCreationFailures,
+		volumeStreamingFailures:   health.volumeStreamingFailures,
+		erroredSteps:              health.erroredSteps,
+		quarantined:               health.quarantined,
+		conn:                      conn,
 	}
 
 	workerBaseResourceTypeIDs := []int{}
@@ -21,14 +21,17 @@ var _ = Describe("WorkerFactory", func() {
 
 	BeforeEach(func() {
 		atcWorker = atc.Worker{
-			GardenAddr:       "some-garden-addr",
-			BaggageclaimURL:  "some-bc-url",
-			HTTPProxyURL:     "some-http-proxy-url",
-			HTTPSProxyURL:    "some-https-proxy-url",
-			NoProxy:          "some-no-proxy",
-			Ephemeral:        true,
-			ActiveContainers: 140,
-			ActiveVolumes:    550,
+			GardenAddr:               "some-garden-addr",
+			BaggageclaimURL:          "some-bc-url",
+			HTTPProxyURL:             "some-http-proxy-url",
+			HTTPSProxyURL:            "some-https-proxy-url",
+			NoProxy:                  "some-no-proxy",
+			Ephemeral:                true,
+			Rootless:                 true,
+			SupportedContainerLimits: []string{"cpu", "memory", "pids", "io"},
+			Features:                 []string{atc.WorkerFeatureDeltaVolumeStreaming},
+			ActiveContainers:         140,
+			ActiveVolumes:            550,
 			ResourceTypes: []atc.WorkerResourceType{
 				{
 					Type:       "some-resource-type",
@@ -226,6 +229,9 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.HTTPSProxyURL()).To(Equal("some-https-proxy-url"))
 				Expect(foundWorker.NoProxy()).To(Equal("some-no-proxy"))
 				Expect(foundWorker.Ephemeral()).To(Equal(true))
+				Expect(foundWorker.Rootless()).To(Equal(true))
+				Expect(foundWorker.SupportedContainerLimits()).To(Equal([]string{"cpu", "memory", "pids", "io"}))
+				Expect(foundWorker.Features()).To(Equal([]string{atc.WorkerFeatureDeltaVolumeStreaming}))
 				Expect(foundWorker.ActiveContainers()).To(Equal(140))
 				Expect(foundWorker.ActiveVolumes()).To(Equal(550))
 				Expect(foundWorker.ResourceTypes()).To(Equal([]atc.WorkerResourceType{
@@ -785,4 +791,25 @@ var _ = Describe("WorkerFactory", func() {
 			Expect(containersCountByWorker[worker.Name()]).To(Equal(1))
 		})
 	})
+
+	Describe("ActiveTasksPerWorker", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = worker.IncreaseActiveTasks()
+			Expect(err).ToNot(HaveOccurred())
+			_, err = worker.IncreaseActiveTasks()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns a map of worker to number of active tasks", func() {
+			activeTasksByWorker, err := workerFactory.ActiveTasksPerWorker()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(activeTasksByWorker).To(HaveKeyWithValue(worker.Name(), 2))
+			Expect(activeTasksByWorker).To(HaveKeyWithValue(defaultWorker.Name(), 0))
+		})
+	})
 })
@@ -0,0 +1,288 @@
+package db
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+var ErrWorkerKeyAlreadyEnrolled = errors.New("worker key is already enrolled")
+
+//go:generate counterfeiter . WorkerKeyFactory
+
+// WorkerKeyFactory manages the public keys which are authorized to register
+// workers for a team, and the short-lived tokens with which workers enroll
+// their keys.
+type WorkerKeyFactory interface {
+	CreateEnrollmentToken(teamID int, ttl time.Duration) (string, time.Time, error)
+	EnrollWorkerKey(token string, publicKey string, fingerprint string) (WorkerKey, bool, error)
+
+	AuthorizeWorkerKey(fingerprint string) (WorkerKey, bool, error)
+	UseWorkerKey(fingerprint string) error
+	TeamWorkerKeys(teamID int) ([]WorkerKey, error)
+	RevokeWorkerKey(teamID int, id int) (bool, error)
+}
+
+type WorkerKey struct {
+	ID          int
+	TeamID      int
+	TeamName    string
+	PublicKey   string
+	Fingerprint string
+	CreatedAt   time.Time
+	LastUsedAt  time.Time
+	RevokedAt   time.Time
+}
+
+type workerKeyFactory struct {
+	conn Conn
+}
+
+func NewWorkerKeyFactory(conn Conn) WorkerKeyFactory {
+	return &workerKeyFactory{
+		conn: conn,
+	}
+}
+
+var workerKeysQuery = psql.Select(
+	"k.id",
+	"k.team_id",
+	"t.name",
+	"k.public_key",
+	"k.fingerprint",
+	"k.created_at",
+	"k.last_used_at",
+	"k.revoked_at",
+).
+	From("worker_keys k").
+	Join("teams t ON t.id = k.team_id")
+
+// CreateEnrollmentToken generates a token which may be used once to enroll a
+// worker key for the team until it expires. Only a hash of the token is
+// stored.
+func (f *workerKeyFactory) CreateEnrollmentToken(teamID int, ttl time.Duration) (string, time.Time, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("worker_enrollment_tokens").
+		Where(sq.Expr("expires <= NOW()")).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var expires time.Time
+	err = psql.Insert("worker_enrollment_tokens").
+		Columns("token_hash", "team_id", "expires").
+		Values(hashEnrollmentToken(token), teamID, sq.Expr(expiresIn(ttl))).
+		Suffix("RETURNING expires").
+		RunWith(tx).
+		QueryRow().
+		Scan(&expires)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expires, nil
+}
+
+// EnrollWorkerKey consumes the enrollment token and authorizes the key for
+// the token's team. It returns false if the token is unknown or has expired.
+// A key which has been revoked may be enrolled again.
+func (f *workerKeyFactory) EnrollWorkerKey(token string, publicKey string, fingerprint string) (WorkerKey, bool, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return WorkerKey{}, false, err
+	}
+
+	defer Rollback(tx)
+
+	query, args, err := psql.Delete("worker_enrollment_tokens").
+		Where(sq.Eq{"token_hash": hashEnrollmentToken(token)}).
+		Where(sq.Expr("expires > NOW()")).
+		Suffix("RETURNING team_id").
+		ToSql()
+	if err != nil {
+		return WorkerKey{}, false, err
+	}
+
+	var teamID int
+	err = tx.QueryRow(query, args...).Scan(&teamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkerKey{}, false, nil
+		}
+
+		return WorkerKey{}, false, err
+	}
+
+	var id int
+	err = psql.Insert("worker_keys").
+		Columns("team_id", "public_key", "fingerprint").
+		Values(teamID, publicKey, fingerprint).
+		Suffix(`ON CONFLICT (fingerprint) DO UPDATE SET
+			team_id = EXCLUDED.team_id,
+			public_key = EXCLUDED.public_key,
+			created_at = now(),
+			last_used_at = NULL,
+			revoked_at = NULL
+		WHERE worker_keys.revoked_at IS NOT NULL
+		RETURNING id`).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkerKey{}, false, ErrWorkerKeyAlreadyEnrolled
+		}
+
+		return WorkerKey{}, false, err
+	}
+
+	key, err := scanWorkerKey(workerKeysQuery.
+		Where(sq.Eq{"k.id": id}).
+		RunWith(tx).
+		QueryRow())
+	if err != nil {
+		return WorkerKey{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return WorkerKey{}, false, err
+	}
+
+	return key, true, nil
+}
+
+// AuthorizeWorkerKey finds the enrolled key with the given fingerprint. Keys
+// which have been revoked are returned with the time they were revoked at, so
+// that connections authorized before can be closed.
+func (f *workerKeyFactory) AuthorizeWorkerKey(fingerprint string) (WorkerKey, bool, error) {
+	key, err := scanWorkerKey(workerKeysQuery.
+		Where(sq.Eq{"k.fingerprint": fingerprint}).
+		RunWith(f.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkerKey{}, false, nil
+		}
+
+		return WorkerKey{}, false, err
+	}
+
+	return key, true, nil
+}
+
+// UseWorkerKey records that a worker connected with the key.
+func (f *workerKeyFactory) UseWorkerKey(fingerprint string) error {
+	_, err := psql.Update("worker_keys").
+		Set("last_used_at", sq.Expr("NOW()")).
+		Where(sq.Eq{
+			"fingerprint": fingerprint,
+			"revoked_at":  nil,
+		}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *workerKeyFactory) TeamWorkerKeys(teamID int) ([]WorkerKey, error) {
+	rows, err := workerKeysQuery.
+		Where(sq.Eq{
+			"k.team_id":    teamID,
+			"k.revoked_at": nil,
+		}).
+		OrderBy("k.id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var keys []WorkerKey
+	for rows.Next() {
+		key, err := scanWorkerKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// RevokeWorkerKey marks the key as revoked rather than deleting it, so that
+// the TSA can tell revoked keys from keys it failed to look up.
+func (f *workerKeyFactory) RevokeWorkerKey(teamID int, id int) (bool, error) {
+	result, err := psql.Update("worker_keys").
+		Set("revoked_at", sq.Expr("NOW()")).
+		Where(sq.Eq{
+			"id":         id,
+			"team_id":    teamID,
+			"revoked_at": nil,
+		}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func scanWorkerKey(row scannable) (WorkerKey, error) {
+	var (
+		key      WorkerKey
+		lastUsed pq.NullTime
+		revoked  pq.NullTime
+	)
+
+	err := row.Scan(&key.ID, &key.TeamID, &key.TeamName, &key.PublicKey, &key.Fingerprint, &key.CreatedAt, &lastUsed, &revoked)
+	if err != nil {
+		return WorkerKey{}, err
+	}
+
+	key.LastUsedAt = lastUsed.Time
+	key.RevokedAt = revoked.Time
+
+	return key, nil
+}
+
+func hashEnrollmentToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
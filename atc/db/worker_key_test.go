@@ -0,0 +1,168 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerKeyFactory", func() {
+	var (
+		workerKeyFactory db.WorkerKeyFactory
+		otherTeam        db.Team
+	)
+
+	BeforeEach(func() {
+		workerKeyFactory = db.NewWorkerKeyFactory(dbConn)
+
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("EnrollWorkerKey", func() {
+		var token string
+
+		BeforeEach(func() {
+			var (
+				expires time.Time
+				err     error
+			)
+
+			token, expires, err = workerKeyFactory.CreateEnrollmentToken(defaultTeam.ID(), time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expires).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		})
+
+		It("authorizes the key for the token's team", func() {
+			key, enrolled, err := workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(enrolled).To(BeTrue())
+			Expect(key.TeamID).To(Equal(defaultTeam.ID()))
+			Expect(key.TeamName).To(Equal("default-team"))
+			Expect(key.PublicKey).To(Equal("ssh-ed25519 AAAA"))
+			Expect(key.Fingerprint).To(Equal("SHA256:some-fingerprint"))
+
+			key, found, err := workerKeyFactory.AuthorizeWorkerKey("SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(key.TeamName).To(Equal("default-team"))
+			Expect(key.LastUsedAt).To(BeZero())
+			Expect(key.RevokedAt).To(BeZero())
+		})
+
+		It("only allows the token to be used once", func() {
+			_, enrolled, err := workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(enrolled).To(BeTrue())
+
+			_, enrolled, err = workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 BBBB", "SHA256:other-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(enrolled).To(BeFalse())
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE worker_enrollment_tokens SET expires = NOW() - '1 minute'::INTERVAL`)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not enroll the key", func() {
+				_, enrolled, err := workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(enrolled).To(BeFalse())
+			})
+		})
+
+		Context("when the key is already enrolled", func() {
+			BeforeEach(func() {
+				otherToken, _, err := workerKeyFactory.CreateEnrollmentToken(otherTeam.ID(), time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = workerKeyFactory.EnrollWorkerKey(otherToken, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				_, _, err := workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+				Expect(err).To(Equal(db.ErrWorkerKeyAlreadyEnrolled))
+			})
+		})
+	})
+
+	Describe("UseWorkerKey", func() {
+		BeforeEach(func() {
+			token, _, err := workerKeyFactory.CreateEnrollmentToken(defaultTeam.ID(), time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records when the key was last used", func() {
+			Expect(workerKeyFactory.UseWorkerKey("SHA256:some-fingerprint")).To(Succeed())
+
+			key, _, err := workerKeyFactory.AuthorizeWorkerKey("SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key.LastUsedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+	})
+
+	Describe("RevokeWorkerKey", func() {
+		var key db.WorkerKey
+
+		BeforeEach(func() {
+			token, _, err := workerKeyFactory.CreateEnrollmentToken(defaultTeam.ID(), time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			key, _, err = workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records when the key was revoked", func() {
+			revoked, err := workerKeyFactory.RevokeWorkerKey(defaultTeam.ID(), key.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+
+			key, found, err := workerKeyFactory.AuthorizeWorkerKey("SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(key.RevokedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			keys, err := workerKeyFactory.TeamWorkerKeys(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+
+			revoked, err = workerKeyFactory.RevokeWorkerKey(defaultTeam.ID(), key.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("allows the key to be enrolled again", func() {
+			_, err := workerKeyFactory.RevokeWorkerKey(defaultTeam.ID(), key.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			token, _, err := workerKeyFactory.CreateEnrollmentToken(otherTeam.ID(), time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			enrolledKey, enrolled, err := workerKeyFactory.EnrollWorkerKey(token, "ssh-ed25519 AAAA", "SHA256:some-fingerprint")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(enrolled).To(BeTrue())
+			Expect(enrolledKey.TeamName).To(Equal("other-team"))
+			Expect(enrolledKey.RevokedAt).To(BeZero())
+		})
+
+		It("does not revoke keys of other teams", func() {
+			revoked, err := workerKeyFactory.RevokeWorkerKey(otherTeam.ID(), key.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+
+			keys, err := workerKeyFactory.TeamWorkerKeys(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+		})
+	})
+})
@@ -13,6 +13,7 @@ type WorkerLifecycle interface {
 	StallUnresponsiveWorkers() ([]string, error)
 	LandFinishedLandingWorkers() ([]string, error)
 	DeleteFinishedRetiringWorkers() ([]string, error)
+	DeleteEvictedWorkers() ([]string, error)
 	GetWorkerStateByName() (map[string]WorkerState, error)
 }
 
@@ -122,6 +123,26 @@ func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, err
 	return workersAffected(rows)
 }
 
+// DeleteEvictedWorkers deletes the evicting workers which have stopped
+// heartbeating, i.e. the ones which have gone away.
+func (lifecycle *workerLifecycle) DeleteEvictedWorkers() ([]string, error) {
+	query, args, err := psql.Delete("workers").
+		Where(sq.Eq{"state": string(WorkerStateEvicting)}).
+		Where(sq.Expr("expires < NOW()")).
+		Suffix("RETURNING name").
+		ToSql()
+	if err != nil {
+		return []string{}, err
+	}
+
+	rows, err := lifecycle.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return workersAffected(rows)
+}
+
 func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error) {
 	subQ, subQArgs, err := sq.Select("w.name").
 		Distinct().
@@ -103,6 +103,51 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
+	Describe("DeleteEvictedWorkers", func() {
+		Context("when the evicting worker has heartbeated recently", func() {
+			BeforeEach(func() {
+				worker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(worker.Evict()).To(Succeed())
+			})
+
+			It("leaves the worker alone", func() {
+				deletedWorkers, err := workerLifecycle.DeleteEvictedWorkers()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(BeEmpty())
+			})
+		})
+
+		Context("when the evicting worker has not heartbeated recently", func() {
+			BeforeEach(func() {
+				worker, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(worker.Evict()).To(Succeed())
+			})
+
+			It("deletes the worker", func() {
+				deletedWorkers, err := workerLifecycle.DeleteEvictedWorkers()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(Equal([]string{"some-name"}))
+			})
+		})
+
+		Context("when a running worker has not heartbeated recently", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the worker alone", func() {
+				deletedWorkers, err := workerLifecycle.DeleteEvictedWorkers()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deletedWorkers).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("DeleteFinishedRetiringWorkers", func() {
 		var (
 			dbWorker db.Worker
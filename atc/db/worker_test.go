@@ -48,6 +48,132 @@ var _ = Describe("Worker", func() {
 		}
 	})
 
+	Describe("RecordHealth", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts out healthy", func() {
+			Expect(worker.HealthScore()).To(Equal(1.0))
+			Expect(worker.Quarantined()).To(BeFalse())
+		})
+
+		It("lowers the health score and counts failures", func() {
+			_, err := worker.RecordHealth(WorkerHealthOutcomes{{Event: WorkerHealthContainerCreation, Failed: true}}, WorkerQuarantine{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = worker.RecordHealth(WorkerHealthOutcomes{{Event: WorkerHealthVolumeStreaming, Failed: true}}, WorkerQuarantine{})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = worker.RecordHealth(WorkerHealthOutcomes{{Event: WorkerHealthStep, Failed: false}}, WorkerQuarantine{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.HealthScore()).To(BeNumerically("~", 0.829, 0.001))
+			Expect(worker.ContainerCreationFailures()).To(Equal(1))
+			Expect(worker.VolumeStreamingFailures()).To(Equal(1))
+			Expect(worker.ErroredSteps()).To(Equal(0))
+			Expect(worker.Quarantined()).To(BeFalse())
+		})
+
+		Context("when the score drops below the quarantine threshold", func() {
+			It("quarantines the worker", func() {
+				outcomes := WorkerHealthOutcomes{}
+				for i := 0; i < 7; i++ {
+					outcomes = append(outcomes, WorkerHealthOutcome{Event: WorkerHealthStep, Failed: true})
+				}
+
+				quarantined, err := worker.RecordHealth(outcomes, WorkerQuarantine{Threshold: 0.5, MaxFraction: 1})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(quarantined).To(BeTrue())
+
+				_, err = worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(worker.Quarantined()).To(BeTrue())
+				Expect(worker.ErroredSteps()).To(Equal(7))
+			})
+
+			Context("when too many workers are quarantined already", func() {
+				It("does not quarantine the worker", func() {
+					quarantined, err := worker.RecordHealth(
+						WorkerHealthOutcomes{
+							{Event: WorkerHealthStep, Failed: true},
+							{Event: WorkerHealthStep, Failed: true},
+							{Event: WorkerHealthStep, Failed: true},
+							{Event: WorkerHealthStep, Failed: true},
+							{Event: WorkerHealthStep, Failed: true},
+							{Event: WorkerHealthStep, Failed: true},
+							{Event: WorkerHealthStep, Failed: true},
+						},
+						WorkerQuarantine{Threshold: 0.5, MaxFraction: 0.5},
+					)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(quarantined).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the worker is not present", func() {
+			It("returns an error", func() {
+				err := worker.Delete()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = worker.RecordHealth(WorkerHealthOutcomes{{Event: WorkerHealthStep, Failed: true}}, WorkerQuarantine{})
+				Expect(err).To(Equal(ErrWorkerNotPresent))
+			})
+		})
+	})
+
+	Describe("Quarantine and Release", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("quarantines the worker until it is released", func() {
+			_, err := worker.RecordHealth(WorkerHealthOutcomes{{Event: WorkerHealthStep, Failed: true}}, WorkerQuarantine{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = worker.Quarantine()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.Quarantined()).To(BeTrue())
+
+			err = worker.Release()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.Quarantined()).To(BeFalse())
+			Expect(worker.HealthScore()).To(Equal(1.0))
+			Expect(worker.ErroredSteps()).To(Equal(1))
+		})
+
+		It("keeps the quarantine when the worker registers again", func() {
+			err := worker.Quarantine()
+			Expect(err).NotTo(HaveOccurred())
+
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.Quarantined()).To(BeTrue())
+		})
+
+		Context("when the worker is not present", func() {
+			It("returns an error", func() {
+				err := worker.Delete()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(worker.Quarantine()).To(Equal(ErrWorkerNotPresent))
+				Expect(worker.Release()).To(Equal(ErrWorkerNotPresent))
+			})
+		})
+	})
+
 	Describe("Land", func() {
 		BeforeEach(func() {
 			var err error
@@ -131,6 +257,47 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("Evict", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the worker is present", func() {
+			It("marks the worker as `evicting`", func() {
+				err := worker.Evict()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(worker.State()).To(Equal(WorkerStateEvicting))
+			})
+
+			It("stays `evicting` when it heartbeats", func() {
+				err := worker.Evict()
+				Expect(err).NotTo(HaveOccurred())
+
+				worker, err = workerFactory.HeartbeatWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(worker.State()).To(Equal(WorkerStateEvicting))
+			})
+		})
+
+		Context("when the worker is not present", func() {
+			BeforeEach(func() {
+				err := worker.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				err := worker.Evict()
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(Equal(ErrWorkerNotPresent))
+			})
+		})
+	})
+
 	Describe("Delete", func() {
 		BeforeEach(func() {
 			var err error
@@ -0,0 +1,141 @@
+package demand
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Calculator
+
+// Calculator works out the demand for workers across the cluster.
+type Calculator interface {
+	Demand() (atc.WorkerDemand, error)
+}
+
+// NewCalculator constructs a Calculator which groups workers into pools by
+// their platform, tags and team.
+//
+// Steps waiting for a worker are attributed to the pool of workers owned by
+// their team if the team has any workers of its own, and to the pool of
+// workers shared by all teams otherwise, as that's where they would be
+// placed. Note that the tags of a pool of waiting steps are the ones the
+// steps require, which workers may have more of.
+func NewCalculator(
+	workerFactory db.WorkerFactory,
+	waitingStepFactory db.WaitingStepFactory,
+	buildFactory db.BuildFactory,
+) Calculator {
+	return &calculator{
+		workerFactory:      workerFactory,
+		waitingStepFactory: waitingStepFactory,
+		buildFactory:       buildFactory,
+	}
+}
+
+type calculator struct {
+	workerFactory      db.WorkerFactory
+	waitingStepFactory db.WaitingStepFactory
+	buildFactory       db.BuildFactory
+}
+
+func (c *calculator) Demand() (atc.WorkerDemand, error) {
+	workers, err := c.workerFactory.Workers()
+	if err != nil {
+		return atc.WorkerDemand{}, err
+	}
+
+	waitingSteps, err := c.waitingStepFactory.WaitingSteps()
+	if err != nil {
+		return atc.WorkerDemand{}, err
+	}
+
+	queuedBuilds, err := c.buildFactory.PendingBuildsPerTeam()
+	if err != nil {
+		return atc.WorkerDemand{}, err
+	}
+
+	activeTasks, err := c.workerFactory.ActiveTasksPerWorker()
+	if err != nil {
+		return atc.WorkerDemand{}, err
+	}
+
+	pools := pools{}
+	teamsWithWorkers := map[string]bool{}
+
+	for _, worker := range workers {
+		if worker.TeamName() != "" {
+			teamsWithWorkers[worker.TeamName()] = true
+		}
+
+		if worker.State() != db.WorkerStateRunning || worker.Quarantined() {
+			continue
+		}
+
+		pool := pools.find(worker.Platform(), worker.Tags(), worker.TeamName())
+		pool.Workers++
+		pool.ActiveContainers += worker.ActiveContainers()
+		pool.ActiveVolumes += worker.ActiveVolumes()
+		pool.ActiveTasks += activeTasks[worker.Name()]
+	}
+
+	for _, waiting := range waitingSteps {
+		team := ""
+		if teamsWithWorkers[waiting.TeamName] {
+			team = waiting.TeamName
+		}
+
+		pool := pools.find(waiting.Platform, waiting.Tags, team)
+		pool.WaitingSteps += waiting.Count
+	}
+
+	return atc.WorkerDemand{
+		Pools:        pools.sorted(),
+		QueuedBuilds: queuedBuilds,
+	}, nil
+}
+
+type pools map[string]*atc.WorkerPoolDemand
+
+func (p pools) find(platform string, tags []string, team string) *atc.WorkerPoolDemand {
+	sortedTags := append([]string{}, tags...)
+	sort.Strings(sortedTags)
+
+	pool := atc.WorkerPool{
+		Platform: platform,
+		Tags:     sortedTags,
+		Team:     team,
+	}
+
+	key := poolKey(pool)
+
+	demand, found := p[key]
+	if !found {
+		demand = &atc.WorkerPoolDemand{WorkerPool: pool}
+		p[key] = demand
+	}
+
+	return demand
+}
+
+func (p pools) sorted() []atc.WorkerPoolDemand {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	sorted := make([]atc.WorkerPoolDemand, len(keys))
+	for i, key := range keys {
+		sorted[i] = *p[key]
+	}
+
+	return sorted
+}
+
+func poolKey(pool atc.WorkerPool) string {
+	return pool.Platform + "\x00" + strings.Join(pool.Tags, ",") + "\x00" + pool.Team
+}
@@ -0,0 +1,172 @@
+package demand_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/demand"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Calculator", func() {
+	var (
+		fakeWorkerFactory      *dbfakes.FakeWorkerFactory
+		fakeWaitingStepFactory *dbfakes.FakeWaitingStepFactory
+		fakeBuildFactory       *dbfakes.FakeBuildFactory
+
+		calculator demand.Calculator
+
+		activeTasks map[string]int
+
+		workerDemand atc.WorkerDemand
+		demandErr    error
+	)
+
+	newWorker := func(platform string, tags []string, team string, state db.WorkerState) *dbfakes.FakeWorker {
+		name := fmt.Sprintf("worker-%d", len(activeTasks))
+		activeTasks[name] = 1
+
+		worker := new(dbfakes.FakeWorker)
+		worker.NameReturns(name)
+		worker.PlatformReturns(platform)
+		worker.TagsReturns(tags)
+		worker.TeamNameReturns(team)
+		worker.StateReturns(state)
+		worker.ActiveContainersReturns(10)
+		worker.ActiveVolumesReturns(20)
+		return worker
+	}
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeWaitingStepFactory = new(dbfakes.FakeWaitingStepFactory)
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+
+		activeTasks = map[string]int{}
+		fakeWorkerFactory.ActiveTasksPerWorkerReturns(activeTasks, nil)
+
+		calculator = demand.NewCalculator(fakeWorkerFactory, fakeWaitingStepFactory, fakeBuildFactory)
+	})
+
+	JustBeforeEach(func() {
+		workerDemand, demandErr = calculator.Demand()
+	})
+
+	Context("when there are workers, waiting steps and queued builds", func() {
+		BeforeEach(func() {
+			quarantined := newWorker("linux", nil, "", db.WorkerStateRunning)
+			quarantined.QuarantinedReturns(true)
+
+			fakeWorkerFactory.WorkersReturns([]db.Worker{
+				newWorker("linux", nil, "", db.WorkerStateRunning),
+				newWorker("linux", nil, "", db.WorkerStateRunning),
+				newWorker("linux", nil, "", db.WorkerStateStalled),
+				quarantined,
+				newWorker("linux", []string{"gpu", "big"}, "", db.WorkerStateRunning),
+				newWorker("linux", nil, "some-team", db.WorkerStateRunning),
+				newWorker("windows", nil, "", db.WorkerStateLanding),
+			}, nil)
+
+			fakeWaitingStepFactory.WaitingStepsReturns([]db.WaitingSteps{
+				{Platform: "linux", TeamName: "some-team", Count: 2},
+				{Platform: "linux", TeamName: "other-team", Count: 3},
+				{Platform: "linux", Tags: []string{"gpu", "big"}, TeamName: "other-team", Count: 1},
+				{Platform: "darwin", TeamName: "other-team", Count: 4},
+			}, nil)
+
+			fakeBuildFactory.PendingBuildsPerTeamReturns(map[string]int{
+				"some-team":  1,
+				"other-team": 5,
+			}, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(demandErr).ToNot(HaveOccurred())
+		})
+
+		It("groups running, unquarantined workers and waiting steps into pools", func() {
+			Expect(workerDemand.Pools).To(Equal([]atc.WorkerPoolDemand{
+				{
+					WorkerPool:   atc.WorkerPool{Platform: "darwin", Tags: []string{}},
+					WaitingSteps: 4,
+				},
+				{
+					WorkerPool:       atc.WorkerPool{Platform: "linux", Tags: []string{}},
+					Workers:          2,
+					WaitingSteps:     3,
+					ActiveContainers: 20,
+					ActiveVolumes:    40,
+					ActiveTasks:      2,
+				},
+				{
+					WorkerPool:       atc.WorkerPool{Platform: "linux", Tags: []string{}, Team: "some-team"},
+					Workers:          1,
+					WaitingSteps:     2,
+					ActiveContainers: 10,
+					ActiveVolumes:    20,
+					ActiveTasks:      1,
+				},
+				{
+					WorkerPool:       atc.WorkerPool{Platform: "linux", Tags: []string{"big", "gpu"}},
+					Workers:          1,
+					WaitingSteps:     1,
+					ActiveContainers: 10,
+					ActiveVolumes:    20,
+					ActiveTasks:      1,
+				},
+			}))
+		})
+
+		It("returns the queued builds per team", func() {
+			Expect(workerDemand.QueuedBuilds).To(Equal(map[string]int{
+				"some-team":  1,
+				"other-team": 5,
+			}))
+		})
+	})
+
+	Context("when listing the workers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(demandErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when listing the waiting steps fails", func() {
+		BeforeEach(func() {
+			fakeWaitingStepFactory.WaitingStepsReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(demandErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when counting the active tasks fails", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.ActiveTasksPerWorkerReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(demandErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when counting the queued builds fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.PendingBuildsPerTeamReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(demandErr).To(MatchError("nope"))
+		})
+	})
+})
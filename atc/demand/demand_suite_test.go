@@ -0,0 +1,13 @@
+package demand_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDemand(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Demand Suite")
+}
@@ -0,0 +1,108 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package demandfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/demand"
+)
+
+type FakeCalculator struct {
+	DemandStub        func() (atc.WorkerDemand, error)
+	demandMutex       sync.RWMutex
+	demandArgsForCall []struct {
+	}
+	demandReturns struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}
+	demandReturnsOnCall map[int]struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCalculator) Demand() (atc.WorkerDemand, error) {
+	fake.demandMutex.Lock()
+	ret, specificReturn := fake.demandReturnsOnCall[len(fake.demandArgsForCall)]
+	fake.demandArgsForCall = append(fake.demandArgsForCall, struct {
+	}{})
+	stub := fake.DemandStub
+	fakeReturns := fake.demandReturns
+	fake.recordInvocation("Demand", []interface{}{})
+	fake.demandMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCalculator) DemandCallCount() int {
+	fake.demandMutex.RLock()
+	defer fake.demandMutex.RUnlock()
+	return len(fake.demandArgsForCall)
+}
+
+func (fake *FakeCalculator) DemandCalls(stub func() (atc.WorkerDemand, error)) {
+	fake.demandMutex.Lock()
+	defer fake.demandMutex.Unlock()
+	fake.DemandStub = stub
+}
+
+func (fake *FakeCalculator) DemandReturns(result1 atc.WorkerDemand, result2 error) {
+	fake.demandMutex.Lock()
+	defer fake.demandMutex.Unlock()
+	fake.DemandStub = nil
+	fake.demandReturns = struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCalculator) DemandReturnsOnCall(i int, result1 atc.WorkerDemand, result2 error) {
+	fake.demandMutex.Lock()
+	defer fake.demandMutex.Unlock()
+	fake.DemandStub = nil
+	if fake.demandReturnsOnCall == nil {
+		fake.demandReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerDemand
+			result2 error
+		})
+	}
+	fake.demandReturnsOnCall[i] = struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCalculator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.demandMutex.RLock()
+	defer fake.demandMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCalculator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ demand.Calculator = new(FakeCalculator)
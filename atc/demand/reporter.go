@@ -0,0 +1,99 @@
+package demand
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// NewReporter constructs a component which emits the demand for workers as
+// metrics, and cleans up the steps which stopped waiting without saying so,
+// e.g. because their web node went away.
+//
+// Pools and teams which no longer have any demand are reported once more with
+// a value of zero, so that gauges don't get stuck at their last value.
+func NewReporter(calculator Calculator, waitingStepFactory db.WaitingStepFactory) *reporter {
+	return &reporter{
+		calculator:         calculator,
+		waitingStepFactory: waitingStepFactory,
+		pools:              map[string]atc.WorkerPool{},
+		teams:              map[string]bool{},
+	}
+}
+
+type reporter struct {
+	calculator         Calculator
+	waitingStepFactory db.WaitingStepFactory
+
+	pools map[string]atc.WorkerPool
+	teams map[string]bool
+}
+
+func (r *reporter) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("end")
+
+	err := r.waitingStepFactory.RemoveExpiredWaitingSteps()
+	if err != nil {
+		logger.Error("failed-to-remove-expired-waiting-steps", err)
+		return err
+	}
+
+	demand, err := r.calculator.Demand()
+	if err != nil {
+		logger.Error("failed-to-calculate-worker-demand", err)
+		return err
+	}
+
+	pools := map[string]atc.WorkerPool{}
+	for _, pool := range demand.Pools {
+		pools[poolKey(pool.WorkerPool)] = pool.WorkerPool
+
+		metric.WorkerPoolDemand{
+			Platform:     pool.Platform,
+			Tags:         pool.Tags,
+			TeamName:     pool.Team,
+			Workers:      pool.Workers,
+			WaitingSteps: pool.WaitingSteps,
+			Containers:   pool.ActiveContainers,
+			Volumes:      pool.ActiveVolumes,
+			Tasks:        pool.ActiveTasks,
+		}.Emit(logger)
+	}
+
+	for key, pool := range r.pools {
+		if _, found := pools[key]; !found {
+			metric.WorkerPoolDemand{
+				Platform: pool.Platform,
+				Tags:     pool.Tags,
+				TeamName: pool.Team,
+			}.Emit(logger)
+		}
+	}
+
+	teams := map[string]bool{}
+	for team, builds := range demand.QueuedBuilds {
+		teams[team] = true
+
+		metric.BuildsQueued{
+			TeamName: team,
+			Builds:   builds,
+		}.Emit(logger)
+	}
+
+	for team := range r.teams {
+		if !teams[team] {
+			metric.BuildsQueued{TeamName: team}.Emit(logger)
+		}
+	}
+
+	r.pools = pools
+	r.teams = teams
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package demand_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/demand"
+	"github.com/concourse/concourse/atc/demand/demandfakes"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reporter", func() {
+	var (
+		fakeCalculator         *demandfakes.FakeCalculator
+		fakeWaitingStepFactory *dbfakes.FakeWaitingStepFactory
+		fakeEmitter            *metricfakes.FakeEmitter
+		originalMonitor        *metric.Monitor
+
+		reporter component.Runnable
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("test")
+
+		fakeCalculator = new(demandfakes.FakeCalculator)
+		fakeWaitingStepFactory = new(dbfakes.FakeWaitingStepFactory)
+
+		fakeEmitter = new(metricfakes.FakeEmitter)
+		emitterFactory := new(metricfakes.FakeEmitterFactory)
+		emitterFactory.IsConfiguredReturns(true)
+		emitterFactory.NewEmitterReturns(fakeEmitter, nil)
+
+		originalMonitor = metric.Metrics
+		metric.Metrics = metric.NewMonitor()
+		metric.Metrics.RegisterEmitter(emitterFactory)
+		metric.Metrics.Initialize(logger, "test", map[string]string{}, 1000)
+
+		reporter = demand.NewReporter(fakeCalculator, fakeWaitingStepFactory)
+	})
+
+	AfterEach(func() {
+		metric.Metrics = originalMonitor
+	})
+
+	emitted := func() map[string]float64 {
+		values := map[string]float64{}
+		for i := 0; i < fakeEmitter.EmitCallCount(); i++ {
+			_, event := fakeEmitter.EmitArgsForCall(i)
+			key := event.Name + " " + event.Attributes["platform"] + " " + event.Attributes["tags"] + " " + event.Attributes["team_name"]
+			values[key] = event.Value
+		}
+		return values
+	}
+
+	Context("when the demand is calculated", func() {
+		BeforeEach(func() {
+			fakeCalculator.DemandReturns(atc.WorkerDemand{
+				Pools: []atc.WorkerPoolDemand{
+					{
+						WorkerPool: atc.WorkerPool{
+							Platform: "linux",
+							Tags:     []string{"a", "b"},
+							Team:     "some-team",
+						},
+						Workers:          1,
+						WaitingSteps:     2,
+						ActiveContainers: 3,
+						ActiveVolumes:    4,
+						ActiveTasks:      5,
+					},
+				},
+				QueuedBuilds: map[string]int{"some-team": 6},
+			}, nil)
+
+			Expect(reporter.Run(context.TODO())).To(Succeed())
+		})
+
+		It("removes the expired waiting steps", func() {
+			Expect(fakeWaitingStepFactory.RemoveExpiredWaitingStepsCallCount()).To(Equal(1))
+		})
+
+		It("emits the demand of each pool and the queued builds", func() {
+			Eventually(fakeEmitter.EmitCallCount).Should(Equal(6))
+			Expect(emitted()).To(Equal(map[string]float64{
+				"worker pool workers linux a/b some-team":       1,
+				"worker pool waiting steps linux a/b some-team": 2,
+				"worker pool containers linux a/b some-team":    3,
+				"worker pool volumes linux a/b some-team":       4,
+				"worker pool tasks linux a/b some-team":         5,
+				"builds queued   some-team":                     6,
+			}))
+		})
+
+		Context("when the pool and team no longer have demand", func() {
+			BeforeEach(func() {
+				Eventually(fakeEmitter.EmitCallCount).Should(Equal(6))
+
+				fakeCalculator.DemandReturns(atc.WorkerDemand{}, nil)
+
+				Expect(reporter.Run(context.TODO())).To(Succeed())
+			})
+
+			It("emits zeroes for them once", func() {
+				Eventually(fakeEmitter.EmitCallCount).Should(Equal(12))
+				for key, value := range emitted() {
+					Expect(value).To(BeZero(), key)
+				}
+
+				Expect(reporter.Run(context.TODO())).To(Succeed())
+				Consistently(fakeEmitter.EmitCallCount).Should(Equal(12))
+			})
+		})
+	})
+
+	Context("when removing the expired waiting steps fails", func() {
+		It("errors", func() {
+			fakeWaitingStepFactory.RemoveExpiredWaitingStepsReturns(errors.New("nope"))
+			Expect(reporter.Run(context.TODO())).To(MatchError("nope"))
+		})
+	})
+
+	Context("when calculating the demand fails", func() {
+		It("errors", func() {
+			fakeCalculator.DemandReturns(atc.WorkerDemand{}, errors.New("nope"))
+			Expect(reporter.Run(context.TODO())).To(MatchError("nope"))
+		})
+	})
+})
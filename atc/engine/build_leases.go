@@ -0,0 +1,243 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+// LeaseRenewalInterval is the interval on which the leases of the dynamic
+// secrets used by a build are checked for renewal.
+var LeaseRenewalInterval = 10 * time.Second
+
+// buildLeases keeps track of the leases of the dynamic secrets used by a
+// build. Each lease is recorded in the database, renewed while the build
+// runs, and revoked once it finishes. Leases which are left behind, e.g.
+// because the ATC went away, are revoked by the secret lease collector.
+//
+// Each leased secret is only looked up once per build, so that every step
+// uses the same credentials, e.g. a username and password generated
+// together, rather than issuing a new lease on every lookup. The vars are also
+// recorded as leased, so that they're no longer looked up outside of builds.
+type buildLeases struct {
+	build      db.Build
+	leasedVars *creds.LeasedVars
+	logger     lager.Logger
+
+	lock   sync.Mutex
+	leases map[string]*trackedLease
+
+	// the values of the leased secrets, keyed by var source and path
+	values map[leasedKey]leasedValue
+}
+
+type trackedLease struct {
+	lease     vars.Lease
+	expiresAt time.Time
+}
+
+type leasedKey struct {
+	source string
+	path   string
+}
+
+type leasedValue struct {
+	value interface{}
+	lease vars.Lease
+}
+
+func newBuildLeases(logger lager.Logger, build db.Build, leasedVars *creds.LeasedVars) *buildLeases {
+	return &buildLeases{
+		build:      build,
+		leasedVars: leasedVars,
+		logger:     logger.Session("leases"),
+		leases:     map[string]*trackedLease{},
+		values:     map[leasedKey]leasedValue{},
+	}
+}
+
+// Variables wraps the build's variables so that the leases backing their
+// values are tracked.
+func (l *buildLeases) Variables(variables vars.Variables) vars.Variables {
+	return leaseTrackingVariables{
+		Variables: variables,
+		leases:    l,
+	}
+}
+
+func (l *buildLeases) lookup(key leasedKey) (leasedValue, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	value, found := l.values[key]
+	return value, found
+}
+
+// track records the lease backing the value of the secret, returning the
+// value to use. If the secret was leased concurrently by another step, the
+// value it got is used instead, though both leases are kept track of.
+func (l *buildLeases) track(key leasedKey, value interface{}, lease vars.Lease) leasedValue {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	leased, found := l.values[key]
+	if !found {
+		leased = leasedValue{value: value, lease: lease}
+		l.values[key] = leased
+
+		l.leasedVars.Record(l.build.TeamName(), l.build.PipelineName(), vars.Reference{Source: key.source, Path: key.path})
+	}
+
+	if _, found := l.leases[lease.ID]; found {
+		return leased
+	}
+
+	expiresAt := time.Now().Add(lease.Duration)
+
+	err := l.build.SaveSecretLease(lease.ID, expiresAt)
+	if err != nil {
+		l.logger.Error("failed-to-save-lease", err, lager.Data{"lease": lease.ID})
+	}
+
+	l.leases[lease.ID] = &trackedLease{
+		lease:     lease,
+		expiresAt: expiresAt,
+	}
+
+	return leased
+}
+
+// RenewUntilDone renews the leases which are past half of their duration
+// until the context is done.
+func (l *buildLeases) RenewUntilDone(ctx context.Context) {
+	ticker := time.NewTicker(LeaseRenewalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.renew()
+		}
+	}
+}
+
+// renew renews the leases which are due. The lock is only held while
+// picking them, so that lookups aren't held up by the credential manager.
+func (l *buildLeases) renew() {
+	for _, lease := range l.dueForRenewal() {
+		logger := l.logger.WithData(lager.Data{"lease": lease.ID})
+
+		duration, err := lease.Keeper.RenewLease(lease.ID, lease.Duration)
+		if err != nil {
+			logger.Error("failed-to-renew-lease", err)
+			continue
+		}
+
+		expiresAt := time.Now().Add(duration)
+
+		l.lock.Lock()
+		if tracked, found := l.leases[lease.ID]; found {
+			tracked.expiresAt = expiresAt
+		}
+		l.lock.Unlock()
+
+		err = l.build.SaveSecretLease(lease.ID, expiresAt)
+		if err != nil {
+			logger.Error("failed-to-save-lease", err)
+		}
+	}
+}
+
+func (l *buildLeases) dueForRenewal() []vars.Lease {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var due []vars.Lease
+	for _, tracked := range l.leases {
+		if !tracked.lease.Renewable {
+			continue
+		}
+
+		if time.Until(tracked.expiresAt) > tracked.lease.Duration/2 {
+			continue
+		}
+
+		due = append(due, tracked.lease)
+	}
+
+	return due
+}
+
+// RevokeAll revokes every lease. Leases which fail to be revoked are left in
+// the database for the secret lease collector to retry.
+func (l *buildLeases) RevokeAll() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for id, tracked := range l.leases {
+		logger := l.logger.WithData(lager.Data{"lease": id})
+
+		err := tracked.lease.Keeper.RevokeLease(id)
+		if err != nil {
+			logger.Error("failed-to-revoke-lease", err)
+			continue
+		}
+
+		err = l.build.RemoveSecretLease(id)
+		if err != nil {
+			logger.Error("failed-to-remove-lease", err)
+		}
+
+		delete(l.leases, id)
+	}
+}
+
+type leaseTrackingVariables struct {
+	vars.Variables
+
+	leases *buildLeases
+}
+
+func (v leaseTrackingVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	val, _, found, err := v.GetLeased(ref)
+	return val, found, err
+}
+
+// GetLeased looks up the secret as a whole, so that it can be reused for
+// every field of it the build refers to once it turns out to be leased.
+func (v leaseTrackingVariables) GetLeased(ref vars.Reference) (interface{}, *vars.Lease, bool, error) {
+	key := leasedKey{source: ref.Source, path: ref.Path}
+
+	leased, found := v.leases.lookup(key)
+	if !found {
+		val, lease, found, err := vars.GetLeased(v.Variables, vars.Reference{Source: ref.Source, Path: ref.Path})
+		if err != nil || !found {
+			return nil, nil, found, err
+		}
+
+		if lease == nil {
+			result, err := vars.Traverse(val, ref.String(), ref.Fields)
+			if err != nil {
+				return nil, nil, false, err
+			}
+
+			return result, nil, true, nil
+		}
+
+		leased = v.leases.track(key, val, *lease)
+	}
+
+	result, err := vars.Traverse(leased.value, ref.String(), ref.Fields)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return result, &leased.lease, true, nil
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -31,6 +30,7 @@ type buildStepDelegate struct {
 	stdout          io.Writer
 	policyChecker   policy.Checker
 	artifactSourcer worker.ArtifactSourcer
+	redactor        secretRedactor
 }
 
 func NewBuildStepDelegate(
@@ -66,20 +66,6 @@ func (delegate *buildStepDelegate) StartSpan(
 	return tracing.StartSpan(ctx, component, attrs)
 }
 
-type credVarsIterator struct {
-	line string
-}
-
-func (it *credVarsIterator) YieldCred(name, value string) {
-	for _, lineValue := range strings.Split(value, "\n") {
-		lineValue = strings.TrimSpace(lineValue)
-		// Don't consider a single char as a secret.
-		if len(lineValue) > 1 {
-			it.line = strings.Replace(it.line, lineValue, "((redacted))", -1)
-		}
-	}
-}
-
 func (delegate *buildStepDelegate) Stdout() io.Writer {
 	if delegate.stdout != nil {
 		return delegate.stdout
@@ -289,10 +275,8 @@ func (delegate *buildStepDelegate) FetchImage(
 		}
 	}
 
-	getID := delegate.planID + "/image-get"
-
 	getPlan := atc.Plan{
-		ID: getID,
+		ID: delegate.planID + "/image-get",
 		Get: &atc.GetPlan{
 			Name:    imageName,
 			Type:    image.Type,
@@ -306,7 +290,39 @@ func (delegate *buildStepDelegate) FetchImage(
 		},
 	}
 
-	err = delegate.build.SaveEvent(event.ImageGet{
+	fetchArtifact := func(ctx context.Context) (worker.StreamableArtifactSource, error) {
+		return delegate.fetchImageArtifact(ctx, fetchState, getPlan, imageName)
+	}
+
+	digest := layerStoreDigest(image, types, version)
+	if digest != "" {
+		// the image is only fetched if the selected worker's layer store does
+		// not hold it yet
+		return worker.ImageSpec{
+			ImageDigest:        digest,
+			FetchImageArtifact: fetchArtifact,
+			Privileged:         privileged,
+		}, nil
+	}
+
+	source, err := fetchArtifact(ctx)
+	if err != nil {
+		return worker.ImageSpec{}, err
+	}
+
+	return worker.ImageSpec{
+		ImageArtifactSource: source,
+		Privileged:          privileged,
+	}, nil
+}
+
+func (delegate *buildStepDelegate) fetchImageArtifact(
+	ctx context.Context,
+	fetchState exec.RunState,
+	getPlan atc.Plan,
+	imageName string,
+) (worker.StreamableArtifactSource, error) {
+	err := delegate.build.SaveEvent(event.ImageGet{
 		Time: delegate.clock.Now().Unix(),
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
@@ -314,42 +330,65 @@ func (delegate *buildStepDelegate) FetchImage(
 		PublicPlan: getPlan.Public(),
 	})
 	if err != nil {
-		return worker.ImageSpec{}, fmt.Errorf("save image get event: %w", err)
+		return nil, fmt.Errorf("save image get event: %w", err)
 	}
 
 	ok, err := fetchState.Run(ctx, getPlan)
 	if err != nil {
-		return worker.ImageSpec{}, err
+		return nil, err
 	}
 
 	if !ok {
-		return worker.ImageSpec{}, fmt.Errorf("image fetching failed")
+		return nil, fmt.Errorf("image fetching failed")
 	}
 
 	var cache db.UsedResourceCache
-	if !fetchState.Result(getID, &cache) {
-		return worker.ImageSpec{}, fmt.Errorf("get did not return a cache")
+	if !fetchState.Result(getPlan.ID, &cache) {
+		return nil, fmt.Errorf("get did not return a cache")
 	}
 
 	err = delegate.build.SaveImageResourceVersion(cache)
 	if err != nil {
-		return worker.ImageSpec{}, fmt.Errorf("save image version: %w", err)
+		return nil, fmt.Errorf("save image version: %w", err)
 	}
 
 	art, found := fetchState.ArtifactRepository().ArtifactFor(build.ArtifactName(imageName))
 	if !found {
-		return worker.ImageSpec{}, fmt.Errorf("fetched artifact not found")
+		return nil, fmt.Errorf("fetched artifact not found")
 	}
 
 	source, err := delegate.artifactSourcer.SourceImage(lagerctx.FromContext(ctx), art)
 	if err != nil {
-		return worker.ImageSpec{}, fmt.Errorf("wire image: %w", err)
+		return nil, fmt.Errorf("wire image: %w", err)
 	}
 
-	return worker.ImageSpec{
-		ImageArtifactSource: source,
-		Privileged:          privileged,
-	}, nil
+	return source, nil
+}
+
+// Name of the base resource type whose versions are content digests of the
+// fetched image, making them eligible for the worker's layer store.
+const registryImageType = "registry-image"
+
+// layerStoreDigest returns the digest under which the image can be shared
+// through the worker's layer store, or "" if it cannot be.
+//
+// Only images fetched by the base registry-image type without params are
+// eligible: params may change what gets fetched for the same digest, and a
+// custom type's version says nothing about the content it produces.
+func layerStoreDigest(image atc.ImageResource, types atc.VersionedResourceTypes, version atc.Version) string {
+	if !atc.EnableImageLayerStore {
+		return ""
+	}
+
+	if image.Type != registryImageType || len(image.Params) != 0 {
+		return ""
+	}
+
+	if _, found := types.Lookup(image.Type); found {
+		return ""
+	}
+
+	return version["digest"]
 }
 
 func (delegate *buildStepDelegate) checkImagePolicy(image atc.ImageResource, privileged bool) error {
@@ -386,9 +425,7 @@ func (delegate *buildStepDelegate) checkImagePolicy(image atc.ImageResource, pri
 }
 
 func (delegate *buildStepDelegate) buildOutputFilter(str string) string {
-	it := &credVarsIterator{line: str}
-	delegate.state.IterateInterpolatedCreds(it)
-	return it.line
+	return delegate.redactor.redact(delegate.state, str)
 }
 
 func (delegate *buildStepDelegate) redactImageSource(source atc.Source) (atc.Source, error) {
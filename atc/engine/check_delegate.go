@@ -182,6 +182,17 @@ func (d *checkDelegate) PointToCheckedConfig(scope db.ResourceConfigScope) error
 	return nil
 }
 
+// RecordVersionsFound saves the versions returned by the check, and how many
+// of them were new, for the check history of the resource. Checks which run as
+// part of a job build are not recorded.
+func (d *checkDelegate) RecordVersionsFound(versions []atc.Version, newVersions int) error {
+	if d.build.ResourceID() == 0 {
+		return nil
+	}
+
+	return d.build.SaveCheckResult(versions, newVersions)
+}
+
 func (d *checkDelegate) pipeline() (db.Pipeline, error) {
 	if d.cachedPipeline != nil {
 		return d.cachedPipeline, nil
@@ -309,6 +309,45 @@ var _ = Describe("CheckDelegate", func() {
 		})
 	})
 
+	Describe("RecordVersionsFound", func() {
+		var recordErr error
+
+		JustBeforeEach(func() {
+			recordErr = delegate.RecordVersionsFound([]atc.Version{{"version": "v1"}}, 1)
+		})
+
+		Context("when the build is a check build of a resource", func() {
+			BeforeEach(func() {
+				fakeBuild.ResourceIDReturns(1)
+			})
+
+			It("saves the check result on the build", func() {
+				Expect(recordErr).ToNot(HaveOccurred())
+				Expect(fakeBuild.SaveCheckResultCallCount()).To(Equal(1))
+				versions, newVersions := fakeBuild.SaveCheckResultArgsForCall(0)
+				Expect(versions).To(Equal([]atc.Version{{"version": "v1"}}))
+				Expect(newVersions).To(Equal(1))
+			})
+
+			Context("when saving fails", func() {
+				BeforeEach(func() {
+					fakeBuild.SaveCheckResultReturns(errors.New("nope"))
+				})
+
+				It("returns the error", func() {
+					Expect(recordErr).To(MatchError("nope"))
+				})
+			})
+		})
+
+		Context("when the check runs as part of another build", func() {
+			It("does not save a check result", func() {
+				Expect(recordErr).ToNot(HaveOccurred())
+				Expect(fakeBuild.SaveCheckResultCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PointToCheckedConfig", func() {
 		var pointErr error
 
@@ -37,6 +37,7 @@ func NewEngine(
 	stepperFactory StepperFactory,
 	secrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	leasedVars *creds.LeasedVars,
 ) Engine {
 	return &engine{
 		stepperFactory: stepperFactory,
@@ -46,6 +47,7 @@ func NewEngine(
 
 		globalSecrets: secrets,
 		varSourcePool: varSourcePool,
+		leasedVars:    leasedVars,
 	}
 }
 
@@ -57,6 +59,7 @@ type engine struct {
 
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
+	leasedVars    *creds.LeasedVars
 }
 
 func (engine *engine) Drain(ctx context.Context) {
@@ -78,6 +81,7 @@ func (engine *engine) NewBuild(build db.Build) Runnable {
 		engine.stepperFactory,
 		engine.globalSecrets,
 		engine.varSourcePool,
+		engine.leasedVars,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -89,6 +93,7 @@ func NewBuild(
 	builder StepperFactory,
 	globalSecrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	leasedVars *creds.LeasedVars,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -99,6 +104,7 @@ func NewBuild(
 
 		globalSecrets: globalSecrets,
 		varSourcePool: varSourcePool,
+		leasedVars:    leasedVars,
 
 		release:       release,
 		trackedStates: trackedStates,
@@ -112,6 +118,7 @@ type engineBuild struct {
 
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
+	leasedVars    *creds.LeasedVars
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -181,7 +188,9 @@ func (b *engineBuild) Run(ctx context.Context) {
 
 	logger.Info("running")
 
-	state, err := b.runState(logger, stepper)
+	leases := newBuildLeases(logger, b.build, b.leasedVars)
+
+	state, err := b.runState(logger, stepper, leases)
 	if err != nil {
 		logger.Error("failed-to-create-run-state", err)
 
@@ -194,6 +203,11 @@ func (b *engineBuild) Run(ctx context.Context) {
 	}
 	defer b.clearRunState()
 
+	leasesCtx, stopRenewingLeases := context.WithCancel(ctx)
+	defer stopRenewingLeases()
+
+	go leases.RenewUntilDone(leasesCtx)
+
 	ctx, cancel := context.WithCancel(ctx)
 
 	noleak := make(chan bool)
@@ -227,9 +241,14 @@ func (b *engineBuild) Run(ctx context.Context) {
 
 	select {
 	case <-b.release:
+		// the build's steps keep running while another ATC picks it up, so
+		// its leases are left alone; they are revoked by the secret lease
+		// collector once the build finishes or they expire
 		logger.Info("releasing")
 
 	case <-done:
+		leases.RevokeAll()
+
 		if errors.As(runErr, &exec.Retriable{}) {
 			return
 		}
@@ -313,7 +332,7 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 	}
 }
 
-func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.RunState, error) {
+func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper, leases *buildLeases) (exec.RunState, error) {
 	id := fmt.Sprintf("build:%v", b.build.ID())
 	existingState, ok := b.trackedStates.Load(id)
 	if ok {
@@ -323,7 +342,7 @@ func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.
 	if err != nil {
 		return nil, err
 	}
-	state, _ := b.trackedStates.LoadOrStore(id, exec.NewRunState(stepper, credVars, atc.EnableRedactSecrets))
+	state, _ := b.trackedStates.LoadOrStore(id, exec.NewRunState(stepper, leases.Variables(credVars), atc.EnableRedactSecrets))
 	return state.(exec.RunState), nil
 }
 
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/lager/lagerctx"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -21,6 +22,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/vars"
+	"github.com/concourse/concourse/vars/varsfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -33,6 +35,7 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds   *credsfakes.FakeSecrets
 		fakeVarSourcePool *credsfakes.FakeVarSourcePool
+		leasedVars        *creds.LeasedVars
 	)
 
 	BeforeEach(func() {
@@ -43,6 +46,7 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds = new(credsfakes.FakeSecrets)
 		fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+		leasedVars = creds.NewLeasedVars()
 	})
 
 	Describe("NewBuild", func() {
@@ -52,7 +56,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool)
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool, leasedVars)
 		})
 
 		JustBeforeEach(func() {
@@ -82,6 +86,7 @@ var _ = Describe("Engine", func() {
 				fakeStepperFactory,
 				fakeGlobalCreds,
 				fakeVarSourcePool,
+				leasedVars,
 				release,
 				trackedStates,
 				waitGroup,
@@ -190,6 +195,159 @@ var _ = Describe("Engine", func() {
 									Expect(val).To(Equal("bar"))
 								})
 
+								Context("when a var is backed by a lease", func() {
+									var fakeKeeper *varsfakes.FakeLeaseKeeper
+
+									BeforeEach(func() {
+										fakeKeeper = new(varsfakes.FakeLeaseKeeper)
+										fakeKeeper.RenewLeaseReturns(time.Hour, nil)
+
+										fakeBuild.VariablesReturns(leasedVariables{
+											"foo": vars.Lease{
+												ID:        "some-lease",
+												Duration:  100 * time.Millisecond,
+												Renewable: true,
+												Keeper:    fakeKeeper,
+											},
+										}, nil)
+
+										LeaseRenewalInterval = 10 * time.Millisecond
+
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											_, _, err := state.Get(vars.Reference{Path: "foo"})
+											Expect(err).ToNot(HaveOccurred())
+
+											invokedState <- state
+											time.Sleep(200 * time.Millisecond)
+											return true, nil
+										}
+									})
+
+									AfterEach(func() {
+										LeaseRenewalInterval = 10 * time.Second
+									})
+
+									It("records the lease", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.SaveSecretLeaseCallCount()).To(BeNumerically(">=", 1))
+										leaseID, _ := fakeBuild.SaveSecretLeaseArgsForCall(0)
+										Expect(leaseID).To(Equal("some-lease"))
+									})
+
+									It("redacts the var", func() {
+										state := <-invokedState
+										Expect(state.RedactionEnabled()).To(BeTrue())
+									})
+
+									It("renews the lease while the build runs", func() {
+										waitGroup.Wait()
+										Expect(fakeKeeper.RenewLeaseCallCount()).To(BeNumerically(">=", 1))
+										leaseID, increment := fakeKeeper.RenewLeaseArgsForCall(0)
+										Expect(leaseID).To(Equal("some-lease"))
+										Expect(increment).To(Equal(100 * time.Millisecond))
+									})
+
+									It("revokes the lease once the build finishes", func() {
+										waitGroup.Wait()
+										Expect(fakeKeeper.RevokeLeaseCallCount()).To(Equal(1))
+										Expect(fakeKeeper.RevokeLeaseArgsForCall(0)).To(Equal("some-lease"))
+										Expect(fakeBuild.RemoveSecretLeaseCallCount()).To(Equal(1))
+										Expect(fakeBuild.RemoveSecretLeaseArgsForCall(0)).To(Equal("some-lease"))
+									})
+
+									Context("when revoking the lease fails", func() {
+										BeforeEach(func() {
+											fakeKeeper.RevokeLeaseReturns(errors.New("nope"))
+										})
+
+										It("leaves the lease for the collector", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.RemoveSecretLeaseCallCount()).To(BeZero())
+										})
+									})
+
+									Context("when the build is released", func() {
+										BeforeEach(func() {
+											readyToRelease := make(chan bool)
+
+											go func() {
+												<-readyToRelease
+												release <- true
+											}()
+
+											fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+												_, _, err := state.Get(vars.Reference{Path: "foo"})
+												Expect(err).ToNot(HaveOccurred())
+
+												close(readyToRelease)
+												<-time.After(time.Hour)
+												return true, nil
+											}
+										})
+
+										It("does not revoke the lease", func() {
+											waitGroup.Wait()
+											Expect(fakeKeeper.RevokeLeaseCallCount()).To(BeZero())
+										})
+									})
+								})
+
+								Context("when each lookup of a var issues a new lease", func() {
+									var (
+										fakeKeeper *varsfakes.FakeLeaseKeeper
+										variables  *issuingVariables
+									)
+
+									BeforeEach(func() {
+										fakeKeeper = new(varsfakes.FakeLeaseKeeper)
+										variables = &issuingVariables{keeper: fakeKeeper}
+
+										fakeBuild.TeamNameReturns("leasing-team")
+										fakeBuild.PipelineNameReturns("leasing-pipeline")
+										fakeBuild.VariablesReturns(variables, nil)
+
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											username, found, err := state.Get(vars.Reference{Path: "creds", Fields: []string{"username"}})
+											Expect(err).ToNot(HaveOccurred())
+											Expect(found).To(BeTrue())
+
+											password, found, err := state.Get(vars.Reference{Path: "creds", Fields: []string{"password"}})
+											Expect(err).ToNot(HaveOccurred())
+											Expect(found).To(BeTrue())
+
+											invokedState <- state
+											return username == "user-1" && password == "pass-1", nil
+										}
+									})
+
+									It("only looks the var up once", func() {
+										waitGroup.Wait()
+										Expect(variables.lookups).To(Equal(1))
+									})
+
+									It("uses the same lease for every field of the var", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
+									})
+
+									It("records the var as leased, so it's not looked up outside of builds", func() {
+										waitGroup.Wait()
+
+										unleased := creds.NewUnleasedVariables(logger, leasedVars, variables, fakeBuild.TeamName(), fakeBuild.PipelineName())
+										_, _, err := unleased.Get(vars.Reference{Path: "creds"})
+										Expect(err).To(Equal(creds.LeasedVarError{Name: "creds"}))
+										Expect(variables.lookups).To(Equal(1))
+									})
+
+									It("only records and revokes the one lease", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.SaveSecretLeaseCallCount()).To(Equal(1))
+										Expect(fakeKeeper.RevokeLeaseCallCount()).To(Equal(1))
+										Expect(fakeKeeper.RevokeLeaseArgsForCall(0)).To(Equal("lease-1"))
+									})
+								})
+
 								Context("when the build is released", func() {
 									BeforeEach(func() {
 										readyToRelease := make(chan bool)
@@ -421,3 +579,59 @@ var _ = Describe("Engine", func() {
 		})
 	})
 })
+
+// leasedVariables returns the name of each var as its value, backed by the
+// var's lease.
+type leasedVariables map[string]vars.Lease
+
+func (v leasedVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	val, _, found, err := v.GetLeased(ref)
+	return val, found, err
+}
+
+func (v leasedVariables) GetLeased(ref vars.Reference) (interface{}, *vars.Lease, bool, error) {
+	lease, found := v[ref.Path]
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return ref.Path, &lease, true, nil
+}
+
+func (v leasedVariables) List() ([]vars.Reference, error) {
+	return nil, nil
+}
+
+// issuingVariables issues a new lease, along with new credentials, every time
+// a var is looked up, like a dynamic secret would.
+type issuingVariables struct {
+	keeper  vars.LeaseKeeper
+	lookups int
+}
+
+func (v *issuingVariables) Get(ref vars.Reference) (interface{}, bool, error) {
+	val, _, found, err := v.GetLeased(ref)
+	return val, found, err
+}
+
+func (v *issuingVariables) GetLeased(ref vars.Reference) (interface{}, *vars.Lease, bool, error) {
+	v.lookups++
+
+	val, err := vars.Traverse(map[string]interface{}{
+		"username": fmt.Sprintf("user-%d", v.lookups),
+		"password": fmt.Sprintf("pass-%d", v.lookups),
+	}, ref.String(), ref.Fields)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return val, &vars.Lease{
+		ID:       fmt.Sprintf("lease-%d", v.lookups),
+		Duration: time.Hour,
+		Keeper:   v.keeper,
+	}, true, nil
+}
+
+func (v *issuingVariables) List() ([]vars.Reference, error) {
+	return nil, nil
+}
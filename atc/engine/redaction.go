@@ -0,0 +1,206 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+const redactedSecret = "((redacted))"
+
+// minEncodedSecretLength is the length below which the base64 encoded form of
+// a secret is not redacted, as it would match too much unrelated output.
+const minEncodedSecretLength = 8
+
+// secretRedactor redacts secrets from build output. Along with each line of a
+// secret, the base64, URL and JSON encoded forms of the whole secret are
+// redacted, as that's how secrets tend to leak, e.g. within docker auth
+// configs, URLs, or JSON documents.
+//
+// Building the patterns for every secret is costly, so they are only rebuilt
+// whenever the secrets tracked by the build change.
+type secretRedactor struct {
+	lock     sync.Mutex
+	secrets  map[string]bool
+	replacer *strings.Replacer
+}
+
+func (redactor *secretRedactor) redact(state exec.RunState, text string) string {
+	collector := secretCollector{}
+	state.IterateInterpolatedCreds(collector)
+
+	replacer := redactor.replacerFor(collector)
+	if replacer == nil {
+		return text
+	}
+
+	return replacer.Replace(text)
+}
+
+func (redactor *secretRedactor) replacerFor(secrets secretCollector) *strings.Replacer {
+	redactor.lock.Lock()
+	defer redactor.lock.Unlock()
+
+	if redactor.replacer != nil && len(secrets) == len(redactor.secrets) {
+		unchanged := true
+		for secret := range secrets {
+			if !redactor.secrets[secret] {
+				unchanged = false
+				break
+			}
+		}
+
+		if unchanged {
+			return redactor.replacer
+		}
+	}
+
+	patterns := map[string]bool{}
+	for secret := range secrets {
+		for _, pattern := range redactionPatterns(secret) {
+			patterns[pattern] = true
+		}
+	}
+
+	if len(patterns) == 0 {
+		redactor.secrets = secrets
+		redactor.replacer = nil
+		return nil
+	}
+
+	// the replacer prefers the patterns given first, so go with the longest
+	// ones, e.g. a whole secret over one of its lines
+	sorted := make([]string, 0, len(patterns))
+	for pattern := range patterns {
+		sorted = append(sorted, pattern)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+
+		return sorted[i] < sorted[j]
+	})
+
+	oldnew := make([]string, 0, len(sorted)*2)
+	for _, pattern := range sorted {
+		oldnew = append(oldnew, pattern, redactedSecret)
+	}
+
+	redactor.secrets = secrets
+	redactor.replacer = strings.NewReplacer(oldnew...)
+
+	return redactor.replacer
+}
+
+// secretCollector is a TrackedVarsIterator which collects the distinct values
+// of the secrets.
+type secretCollector map[string]bool
+
+func (collector secretCollector) YieldCred(_, value string) {
+	collector[value] = true
+}
+
+// redactionPatterns returns the strings to redact for a secret.
+//
+// Output is redacted line by line, so a multi-line secret such as a PEM key is
+// redacted one line at a time. Its encoded forms have no line breaks, so they
+// are redacted whole.
+func redactionPatterns(secret string) []string {
+	var patterns []string
+
+	for _, line := range strings.Split(secret, "\n") {
+		line = strings.TrimSpace(line)
+		// Don't consider a single char as a secret.
+		if len(line) > 1 {
+			patterns = append(patterns, line)
+		}
+	}
+
+	if len(strings.TrimSpace(secret)) <= 1 {
+		return patterns
+	}
+
+	patterns = append(patterns, encodedSecrets(secret)...)
+
+	if trimmed := strings.TrimSpace(secret); trimmed != secret {
+		// e.g. a PEM key with a trailing new-line, which may well have been
+		// trimmed before being encoded
+		patterns = append(patterns, encodedSecrets(trimmed)...)
+	}
+
+	return patterns
+}
+
+func encodedSecrets(secret string) []string {
+	var encoded []string
+
+	for _, escaped := range []string{
+		url.QueryEscape(secret),
+		url.PathEscape(secret),
+		jsonEscape(secret, true),
+		jsonEscape(secret, false),
+	} {
+		if escaped != secret && len(escaped) > 1 {
+			encoded = append(encoded, escaped)
+		}
+	}
+
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding} {
+		for offset := 0; offset < 3; offset++ {
+			core := base64Core(encoding, secret, offset)
+			if len(core) >= minEncodedSecretLength {
+				encoded = append(encoded, core)
+			}
+		}
+	}
+
+	return encoded
+}
+
+// jsonEscape returns the secret as it appears within a JSON string.
+func jsonEscape(secret string, escapeHTML bool) string {
+	buf := new(bytes.Buffer)
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(escapeHTML)
+
+	// encoding a string can't fail
+	_ = encoder.Encode(secret)
+
+	escaped := strings.TrimSuffix(buf.String(), "\n")
+
+	return escaped[1 : len(escaped)-1]
+}
+
+// base64Core returns the part of the base64 encoding of the secret which is
+// the same wherever it appears in the encoded data, given that it starts at
+// the given offset (0-2) within a 3 byte block.
+//
+// Each base64 char encodes 6 bits, so the chars encoding bits from the data
+// surrounding the secret differ depending on that data, and are left out.
+func base64Core(encoding *base64.Encoding, secret string, offset int) string {
+	data := make([]byte, offset+len(secret))
+	copy(data[offset:], secret)
+
+	// only the chars of complete blocks are known, as the ones of a partial
+	// block depend on what follows the secret
+	complete := len(data) / 3 * 3
+
+	encoded := encoding.EncodeToString(data[:complete])
+
+	// the first chars encode bits from the bytes preceding the secret
+	skip := (offset*8 + 5) / 6
+	if skip >= len(encoded) {
+		return ""
+	}
+
+	return encoded[skip:]
+}
@@ -22,7 +22,9 @@ type coreStepFactory struct {
 	buildFactory          db.BuildFactory
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	keyedCacheFactory     db.KeyedCacheFactory
 	defaultLimits         atc.ContainerLimits
+	maxLimits             atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	defaultCheckTimeout   time.Duration
 }
@@ -36,7 +38,9 @@ func NewCoreStepFactory(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	keyedCacheFactory db.KeyedCacheFactory,
 	defaultLimits atc.ContainerLimits,
+	maxLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	defaultCheckTimeout time.Duration,
 ) CoreStepFactory {
@@ -49,7 +53,9 @@ func NewCoreStepFactory(
 		buildFactory:          buildFactory,
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		keyedCacheFactory:     keyedCacheFactory,
 		defaultLimits:         defaultLimits,
+		maxLimits:             maxLimits,
 		strategy:              strategy,
 		defaultCheckTimeout:   defaultCheckTimeout,
 	}
@@ -75,6 +81,7 @@ func (factory *coreStepFactory) GetStep(
 		factory.pool,
 	)
 
+	getStep = retryEvicted(getStep, delegateFactory)
 	getStep = exec.LogError(getStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		getStep = exec.RetryError(getStep, delegateFactory)
@@ -131,6 +138,7 @@ func (factory *coreStepFactory) CheckStep(
 		factory.defaultCheckTimeout,
 	)
 
+	checkStep = retryEvicted(checkStep, delegateFactory)
 	checkStep = exec.LogError(checkStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		checkStep = exec.RetryError(checkStep, delegateFactory)
@@ -151,15 +159,18 @@ func (factory *coreStepFactory) TaskStep(
 		plan.ID,
 		*plan.Task,
 		factory.defaultLimits,
+		factory.maxLimits,
 		stepMetadata,
 		containerMetadata,
 		factory.strategy,
 		factory.pool,
 		factory.artifactStreamer,
 		factory.artifactSourcer,
+		factory.keyedCacheFactory,
 		delegateFactory,
 	)
 
+	taskStep = retryEvicted(taskStep, delegateFactory)
 	taskStep = exec.LogError(taskStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		taskStep = exec.RetryError(taskStep, delegateFactory)
@@ -223,3 +234,14 @@ func (factory *coreStepFactory) ArtifactOutputStep(
 ) exec.Step {
 	return exec.NewArtifactOutputStep(plan, build, factory.pool)
 }
+
+// retryEvicted runs the step again when the worker it runs on gets evicted,
+// as long as the build is of an interruptible job. Other steps may not be
+// safe to run again, and are left to error.
+func retryEvicted(step exec.Step, delegateFactory DelegateFactory) exec.Step {
+	if !delegateFactory.build.Interruptible() {
+		return step
+	}
+
+	return exec.RetryEvicted(step, delegateFactory)
+}
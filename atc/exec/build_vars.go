@@ -10,6 +10,7 @@ type buildVariables struct {
 	parentScope interface {
 		vars.Variables
 		IterateInterpolatedCreds(iter vars.TrackedVarsIterator)
+		RedactionEnabled() bool
 	}
 
 	localVars vars.StaticVariables
@@ -77,6 +78,8 @@ func (b *buildVariables) AddLocalVar(name string, val interface{}, redact bool)
 	}
 }
 
+// RedactionEnabled returns whether redaction is enabled, or whether any var
+// backed by a lease has been used, which is always redacted.
 func (b *buildVariables) RedactionEnabled() bool {
-	return b.tracker.Enabled
+	return b.tracker.Enabled || b.parentScope.RedactionEnabled()
 }
@@ -0,0 +1,133 @@
+package exec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// hashFilesRegex matches ((hashFiles path...)) in a cache key. The spaces keep
+// it from being mistaken for a var during interpolation.
+var hashFilesRegex = regexp.MustCompile(`\(\(\s*hashFiles\s+([^)]*?)\s*\)\)`)
+
+// CacheKeyError is returned when a keyed cache's key cannot be evaluated.
+type CacheKeyError struct {
+	Key string
+	Err error
+}
+
+func (err CacheKeyError) Error() string {
+	return fmt.Sprintf("failed to evaluate cache key '%s': %s", err.Key, err.Err)
+}
+
+func (err CacheKeyError) Unwrap() error {
+	return err.Err
+}
+
+// cacheKeyEvaluator replaces each ((hashFiles path...)) in a cache key with a
+// hash of the given files. Paths are relative to the task's working directory,
+// so the first segment of each path names one of the task's inputs.
+type cacheKeyEvaluator struct {
+	streamer     worker.ArtifactStreamer
+	repository   *build.Repository
+	inputs       []atc.TaskInputConfig
+	inputMapping map[string]string
+}
+
+func (evaluator cacheKeyEvaluator) Evaluate(ctx context.Context, logger lager.Logger, key string) (string, error) {
+	var evalErr error
+	evaluated := hashFilesRegex.ReplaceAllStringFunc(key, func(match string) string {
+		if evalErr != nil {
+			return ""
+		}
+
+		paths := strings.Fields(hashFilesRegex.FindStringSubmatch(match)[1])
+
+		var hash string
+		hash, evalErr = evaluator.hashFiles(ctx, logger, paths)
+		return hash
+	})
+	if evalErr != nil {
+		return "", CacheKeyError{Key: key, Err: evalErr}
+	}
+
+	return evaluated, nil
+}
+
+func (evaluator cacheKeyEvaluator) hashFiles(ctx context.Context, logger lager.Logger, paths []string) (string, error) {
+	hash := sha256.New()
+
+	for _, path := range paths {
+		err := evaluator.hashFile(ctx, logger, hash, path)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (evaluator cacheKeyEvaluator) hashFile(ctx context.Context, logger lager.Logger, hash io.Writer, path string) error {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return fmt.Errorf("'%s' does not specify the input it is in", path)
+	}
+
+	artifactName, found := evaluator.inputArtifactName(segs[0])
+	if !found {
+		return fmt.Errorf("'%s' is not in any of the task's inputs", path)
+	}
+
+	artifact, found := evaluator.repository.ArtifactFor(artifactName)
+	if !found {
+		return fmt.Errorf("input '%s' for '%s' is missing", artifactName, path)
+	}
+
+	stream, err := evaluator.streamer.StreamFileFromArtifact(lagerctx.NewContext(ctx, logger), artifact, segs[1])
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return fmt.Errorf("file '%s' not found", path)
+		}
+
+		return err
+	}
+
+	defer stream.Close()
+
+	fmt.Fprintf(hash, "%s\x00", path)
+
+	_, err = io.Copy(hash, stream)
+	return err
+}
+
+func (evaluator cacheKeyEvaluator) inputArtifactName(dir string) (build.ArtifactName, bool) {
+	for _, input := range evaluator.inputs {
+		inputDir := input.Path
+		if inputDir == "" {
+			inputDir = input.Name
+		}
+
+		if strings.Trim(inputDir, "/") != dir {
+			continue
+		}
+
+		if sourceName, ok := evaluator.inputMapping[input.Name]; ok {
+			return build.ArtifactName(sourceName), true
+		}
+
+		return build.ArtifactName(input.Name), true
+	}
+
+	return "", false
+}
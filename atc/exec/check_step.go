@@ -46,6 +46,7 @@ type CheckDelegate interface {
 	FindOrCreateScope(db.ResourceConfig) (db.ResourceConfigScope, error)
 	WaitToRun(context.Context, db.ResourceConfigScope) (lock.Lock, bool, error)
 	PointToCheckedConfig(db.ResourceConfigScope) error
+	RecordVersionsFound([]atc.Version, int) error
 }
 
 func NewCheckStep(
@@ -200,11 +201,16 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 		// TODO: deprecate it.
 		metric.Metrics.ChecksFinishedWithSuccess.Inc()
 
-		err = scope.SaveVersions(db.NewSpanContext(ctx), result.Versions)
+		newVersions, err := scope.SaveVersions(db.NewSpanContext(ctx), result.Versions)
 		if err != nil {
 			return false, fmt.Errorf("save versions: %w", err)
 		}
 
+		err = delegate.RecordVersionsFound(result.Versions, newVersions)
+		if err != nil {
+			return false, fmt.Errorf("record versions found: %w", err)
+		}
+
 		if len(result.Versions) > 0 {
 			state.StoreResult(step.planID, result.Versions[len(result.Versions)-1])
 		}
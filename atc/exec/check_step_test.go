@@ -630,6 +630,22 @@ var _ = Describe("CheckStep", func() {
 					}))
 				})
 
+				Context("when some of the versions are new", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.SaveVersionsReturns(1, nil)
+					})
+
+					It("records the versions and how many were new on the build", func() {
+						Expect(fakeDelegate.RecordVersionsFoundCallCount()).To(Equal(1))
+						versions, newVersions := fakeDelegate.RecordVersionsFoundArgsForCall(0)
+						Expect(versions).To(Equal([]atc.Version{
+							{"version": "1"},
+							{"version": "2"},
+						}))
+						Expect(newVersions).To(Equal(1))
+					})
+				})
+
 				It("stores the latest version as the step result", func() {
 					Expect(fakeRunState.StoreResultCallCount()).To(Equal(1))
 					id, val := fakeRunState.StoreResultArgsForCall(0)
@@ -674,10 +690,10 @@ var _ = Describe("CheckStep", func() {
 
 				Context("after saving", func() {
 					BeforeEach(func() {
-						fakeResourceConfigScope.SaveVersionsStub = func(db.SpanContext, []atc.Version) error {
+						fakeResourceConfigScope.SaveVersionsStub = func(db.SpanContext, []atc.Version) (int, error) {
 							Expect(fakeDelegate.PointToCheckedConfigCallCount()).To(BeZero())
 							Expect(fakeResourceConfigScope.UpdateLastCheckEndTimeCallCount()).To(Equal(0))
-							return nil
+							return 0, nil
 						}
 					})
 
@@ -765,13 +781,32 @@ var _ = Describe("CheckStep", func() {
 				})
 			})
 
+			Context("having RecordVersionsFound failing", func() {
+				var expectedErr error
+
+				BeforeEach(func() {
+					fakeClient.RunCheckStepReturns(worker.CheckResult{
+						Versions: []atc.Version{{"version": "1"}},
+					}, nil)
+
+					expectedErr = errors.New("record-versions-err")
+
+					fakeDelegate.RecordVersionsFoundReturns(expectedErr)
+				})
+
+				It("errors", func() {
+					Expect(stepErr).To(HaveOccurred())
+					Expect(errors.Is(stepErr, expectedErr)).To(BeTrue())
+				})
+			})
+
 			Context("having SaveVersions failing", func() {
 				var expectedErr error
 
 				BeforeEach(func() {
 					expectedErr = errors.New("save-versions-err")
 
-					fakeResourceConfigScope.SaveVersionsReturns(expectedErr)
+					fakeResourceConfigScope.SaveVersionsReturns(0, expectedErr)
 				})
 
 				It("errors", func() {
@@ -74,6 +74,18 @@ type FakeCheckDelegate struct {
 	pointToCheckedConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RecordVersionsFoundStub        func([]atc.Version, int) error
+	recordVersionsFoundMutex       sync.RWMutex
+	recordVersionsFoundArgsForCall []struct {
+		arg1 []atc.Version
+		arg2 int
+	}
+	recordVersionsFoundReturns struct {
+		result1 error
+	}
+	recordVersionsFoundReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -435,6 +447,73 @@ func (fake *FakeCheckDelegate) PointToCheckedConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) RecordVersionsFound(arg1 []atc.Version, arg2 int) error {
+	var arg1Copy []atc.Version
+	if arg1 != nil {
+		arg1Copy = make([]atc.Version, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.recordVersionsFoundMutex.Lock()
+	ret, specificReturn := fake.recordVersionsFoundReturnsOnCall[len(fake.recordVersionsFoundArgsForCall)]
+	fake.recordVersionsFoundArgsForCall = append(fake.recordVersionsFoundArgsForCall, struct {
+		arg1 []atc.Version
+		arg2 int
+	}{arg1Copy, arg2})
+	stub := fake.RecordVersionsFoundStub
+	fakeReturns := fake.recordVersionsFoundReturns
+	fake.recordInvocation("RecordVersionsFound", []interface{}{arg1Copy, arg2})
+	fake.recordVersionsFoundMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckDelegate) RecordVersionsFoundCallCount() int {
+	fake.recordVersionsFoundMutex.RLock()
+	defer fake.recordVersionsFoundMutex.RUnlock()
+	return len(fake.recordVersionsFoundArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) RecordVersionsFoundCalls(stub func([]atc.Version, int) error) {
+	fake.recordVersionsFoundMutex.Lock()
+	defer fake.recordVersionsFoundMutex.Unlock()
+	fake.RecordVersionsFoundStub = stub
+}
+
+func (fake *FakeCheckDelegate) RecordVersionsFoundArgsForCall(i int) ([]atc.Version, int) {
+	fake.recordVersionsFoundMutex.RLock()
+	defer fake.recordVersionsFoundMutex.RUnlock()
+	argsForCall := fake.recordVersionsFoundArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheckDelegate) RecordVersionsFoundReturns(result1 error) {
+	fake.recordVersionsFoundMutex.Lock()
+	defer fake.recordVersionsFoundMutex.Unlock()
+	fake.RecordVersionsFoundStub = nil
+	fake.recordVersionsFoundReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheckDelegate) RecordVersionsFoundReturnsOnCall(i int, result1 error) {
+	fake.recordVersionsFoundMutex.Lock()
+	defer fake.recordVersionsFoundMutex.Unlock()
+	fake.RecordVersionsFoundStub = nil
+	if fake.recordVersionsFoundReturnsOnCall == nil {
+		fake.recordVersionsFoundReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordVersionsFoundReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCheckDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -787,6 +866,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.pointToCheckedConfigMutex.RLock()
 	defer fake.pointToCheckedConfigMutex.RUnlock()
+	fake.recordVersionsFoundMutex.RLock()
+	defer fake.recordVersionsFoundMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
@@ -0,0 +1,57 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// MaxEvictedAttempts is how many times a step is run at most when the workers
+// it runs on keep getting evicted.
+const MaxEvictedAttempts = 3
+
+// RetryEvictedStep runs a step again when it got interrupted by the worker it
+// was running on being evicted, which takes the worker out of the pool so
+// that the step ends up on another one.
+//
+// It must only wrap steps which are safe to be interrupted and run again,
+// i.e. the steps of builds of interruptible jobs.
+type RetryEvictedStep struct {
+	Step
+
+	delegateFactory BuildStepDelegateFactory
+}
+
+func RetryEvicted(step Step, delegateFactory BuildStepDelegateFactory) Step {
+	return RetryEvictedStep{
+		Step: step,
+
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step RetryEvictedStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		runOk, runErr := step.Step.Run(ctx, state)
+
+		var evictedErr worker.WorkerEvictedError
+		if ctx.Err() != nil || attempt >= MaxEvictedAttempts || !errors.As(runErr, &evictedErr) {
+			return runOk, runErr
+		}
+
+		logger.Info("rerunning-step-evicted-from-worker", lager.Data{
+			"worker":  evictedErr.WorkerName,
+			"error":   evictedErr.Err.Error(),
+			"attempt": attempt,
+		})
+
+		delegate := step.delegateFactory.BuildStepDelegate(state)
+		fmt.Fprintf(delegate.Stderr(), "\x1b[1;33mworker %s was evicted; rerunning on another worker\x1b[0m\n\n", evictedErr.WorkerName)
+	}
+}
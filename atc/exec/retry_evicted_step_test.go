@@ -0,0 +1,130 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("RetryEvictedStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep *execfakes.FakeStep
+
+		stderr              *gbytes.Buffer
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		state *execfakes.FakeRunState
+
+		step Step
+
+		evictedErr error
+
+		runOk  bool
+		runErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStep = new(execfakes.FakeStep)
+
+		stderr = gbytes.NewBuffer()
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate.StderrReturns(stderr)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = new(execfakes.FakeRunState)
+
+		step = RetryEvicted(fakeStep, fakeDelegateFactory)
+
+		evictedErr = worker.WorkerEvictedError{
+			WorkerName: "some-worker",
+			Err:        errors.New("connection reset"),
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		runOk, runErr = step.Run(ctx, state)
+	})
+
+	Context("when the step succeeds", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(true, nil)
+		})
+
+		It("runs it once", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(runOk).To(BeTrue())
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the step errors for another reason", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(false, errors.New("nope"))
+		})
+
+		It("returns the error without running it again", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when the worker the step ran on was evicted", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturnsOnCall(0, false, evictedErr)
+			fakeStep.RunReturnsOnCall(1, true, nil)
+		})
+
+		It("runs the step again", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(2))
+			Expect(runOk).To(BeTrue())
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+
+		It("tells the user", func() {
+			Expect(stderr).To(gbytes.Say("worker some-worker was evicted; rerunning on another worker"))
+		})
+
+		Context("when the workers keep getting evicted", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, evictedErr)
+				fakeStep.RunReturnsOnCall(1, false, evictedErr)
+			})
+
+			It("gives up after the maximum number of attempts", func() {
+				Expect(fakeStep.RunCallCount()).To(Equal(MaxEvictedAttempts))
+				Expect(runErr).To(Equal(evictedErr))
+			})
+		})
+
+		Context("when the build is aborted", func() {
+			BeforeEach(func() {
+				fakeStep.RunStub = func(context.Context, RunState) (bool, error) {
+					cancel()
+					return false, evictedErr
+				}
+			})
+
+			It("does not run the step again", func() {
+				Expect(fakeStep.RunCallCount()).To(Equal(1))
+				Expect(runErr).To(Equal(evictedErr))
+			})
+		})
+	})
+})
@@ -134,57 +134,28 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
 	}
 
-	if len(errors) > 0 {
-		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
-
-		for _, e := range errors {
-			fmt.Fprintf(stderr, "- %s", e)
-		}
-
-		delegate.Finished(logger, false)
-		return false, nil
-	}
-
 	var team db.Team
-	if step.plan.Team == "" {
-		team = step.teamFactory.GetByID(step.metadata.TeamID)
-	} else {
-		fmt.Fprintln(stderr, "\x1b[1;33mWARNING: specifying the team in a set_pipeline step is experimental and may be removed in the future!\x1b[0m")
-		fmt.Fprintln(stderr, "")
-		fmt.Fprintln(stderr, "\x1b[33mcontribute to discussion #5731 with feedback: https://github.com/concourse/concourse/discussions/5731\x1b[0m")
-		fmt.Fprintln(stderr, "")
-
-		currentTeam, found, err := step.teamFactory.FindTeam(step.metadata.TeamName)
+	if len(errors) == 0 {
+		team, err = step.targetTeam(stderr)
 		if err != nil {
 			return false, err
 		}
-		if !found {
-			return false, fmt.Errorf("team %s not found", step.metadata.TeamName)
-		}
 
-		targetTeam, found, err := step.teamFactory.FindTeam(step.plan.Team)
+		errors, err = configvalidate.ValidatePassedPipelines(atcConfig, team)
 		if err != nil {
 			return false, err
 		}
-		if !found {
-			return false, fmt.Errorf("team %s not found", step.plan.Team)
-		}
+	}
 
-		permitted := false
-		if targetTeam.ID() == currentTeam.ID() {
-			permitted = true
-		}
-		if currentTeam.Admin() {
-			permitted = true
-		}
-		if !permitted {
-			return false, fmt.Errorf(
-				"only %s team can set another team's pipeline",
-				atc.DefaultTeamName,
-			)
+	if len(errors) > 0 {
+		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
+
+		for _, e := range errors {
+			fmt.Fprintf(stderr, "- %s", e)
 		}
 
-		team = targetTeam
+		delegate.Finished(logger, false)
+		return false, nil
 	}
 
 	pipelineRef := atc.PipelineRef{
@@ -273,6 +244,51 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 	return true, nil
 }
 
+// targetTeam returns the team the pipeline is to be set in, which is the
+// build's team unless another one is configured.
+func (step *SetPipelineStep) targetTeam(stderr io.Writer) (db.Team, error) {
+	if step.plan.Team == "" {
+		return step.teamFactory.GetByID(step.metadata.TeamID), nil
+	}
+
+	fmt.Fprintln(stderr, "\x1b[1;33mWARNING: specifying the team in a set_pipeline step is experimental and may be removed in the future!\x1b[0m")
+	fmt.Fprintln(stderr, "")
+	fmt.Fprintln(stderr, "\x1b[33mcontribute to discussion #5731 with feedback: https://github.com/concourse/concourse/discussions/5731\x1b[0m")
+	fmt.Fprintln(stderr, "")
+
+	currentTeam, found, err := step.teamFactory.FindTeam(step.metadata.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("team %s not found", step.metadata.TeamName)
+	}
+
+	targetTeam, found, err := step.teamFactory.FindTeam(step.plan.Team)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("team %s not found", step.plan.Team)
+	}
+
+	permitted := false
+	if targetTeam.ID() == currentTeam.ID() {
+		permitted = true
+	}
+	if currentTeam.Admin() {
+		permitted = true
+	}
+	if !permitted {
+		return nil, fmt.Errorf(
+			"only %s team can set another team's pipeline",
+			atc.DefaultTeamName,
+		)
+	}
+
+	return targetTeam, nil
+}
+
 type setPipelineSource struct {
 	ctx              context.Context
 	logger           lager.Logger
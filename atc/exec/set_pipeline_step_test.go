@@ -38,6 +38,21 @@ jobs:
 ---
 `
 
+	const pipelineContentWithPassedPipelineJob = `
+---
+resources:
+- name: some-resource
+  type: git
+  source: {uri: some-uri}
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    passed:
+    - pipeline: other-pipeline
+      job: other-job
+`
+
 	const pipelineContent = `
 ---
 jobs:
@@ -268,6 +283,56 @@ jobs:
 			})
 		})
 
+		Context("when pipeline file has a passed constraint on a job in another pipeline", func() {
+			BeforeEach(func() {
+				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: pipelineContentWithPassedPipelineJob}, nil)
+			})
+
+			Context("when the other pipeline does not exist", func() {
+				BeforeEach(func() {
+					fakeTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("should not return error", func() {
+					Expect(stepErr).NotTo(HaveOccurred())
+				})
+
+				It("should look up the other pipeline in the team", func() {
+					Expect(fakeTeamFactory.GetByIDCallCount()).To(Equal(1))
+					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "other-pipeline"}))
+				})
+
+				It("should stderr have error message", func() {
+					Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+					Expect(stderr).To(gbytes.Say("unknown pipeline 'other-pipeline'"))
+				})
+
+				It("should finish unsuccessfully without saving the pipeline", func() {
+					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+					_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+					Expect(succeeded).To(BeFalse())
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the other pipeline has the job", func() {
+				BeforeEach(func() {
+					otherPipeline := new(dbfakes.FakePipeline)
+					otherPipeline.JobReturns(new(dbfakes.FakeJob), true, nil)
+
+					fakeTeam.PipelineReturnsOnCall(0, otherPipeline, true, nil)
+					fakeTeam.PipelineReturnsOnCall(1, nil, false, nil)
+					fakeBuild.SavePipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("should save the pipeline", func() {
+					Expect(stepErr).NotTo(HaveOccurred())
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when pipeline file exists but is empty", func() {
 			BeforeEach(func() {
 				fakeArtifactStreamer.StreamFileFromArtifactReturns(&fakeReadCloser{str: badPipelineContentWithEmptyContent}, nil)
@@ -0,0 +1,21 @@
+package exec
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// saveStepOutput records the volume as an output of the step, so that it can
+// be downloaded from the build while the volume is still around. Failing to
+// do so is logged rather than failing a step which has already run.
+func saveStepOutput(logger lager.Logger, volume worker.Volume, name string, buildID int, planID atc.PlanID, expiresAt time.Time) {
+	logger.Debug("saving-output", lager.Data{"output": name})
+
+	_, err := volume.InitializeStepOutput(name, buildID, planID, expiresAt)
+	if err != nil {
+		logger.Error("failed-to-save-output", err, lager.Data{"output": name})
+	}
+}
@@ -127,10 +127,12 @@ func (configSource BaseResourceTypeDefaultsApplySource) Warnings() []string {
 	return []string{}
 }
 
-// OverrideParamsConfigSource is used to override params in a config source
+// OverrideParamsConfigSource is used to override params and secret files in a
+// config source
 type OverrideParamsConfigSource struct {
 	ConfigSource TaskConfigSource
 	Params       atc.TaskEnv
+	SecretFiles  map[string]string
 	WarningList  []string
 }
 
@@ -154,6 +156,14 @@ func (configSource *OverrideParamsConfigSource) FetchConfig(ctx context.Context,
 		taskConfig.Params[key] = val
 	}
 
+	if len(configSource.SecretFiles) > 0 && taskConfig.SecretFiles == nil {
+		taskConfig.SecretFiles = map[string]string{}
+	}
+
+	for path, val := range configSource.SecretFiles {
+		taskConfig.SecretFiles[path] = val
+	}
+
 	return taskConfig, nil
 }
 
@@ -317,6 +317,34 @@ run: {path: a/file}
 				Expect(configSource.Warnings()[0]).To(ContainSubstring("EXTRA_PARAM was defined in pipeline but missing from task file"))
 			})
 		})
+
+		Context("when override secret files are specified", func() {
+			BeforeEach(func() {
+				config.SecretFiles = map[string]string{"key.pem": "((key))", "ca.pem": "((ca))"}
+
+				configSource = &OverrideParamsConfigSource{
+					ConfigSource: StaticConfigSource{Config: &config},
+					SecretFiles:  map[string]string{"key.pem": "((other-key))", "token": "((token))"},
+				}
+			})
+
+			JustBeforeEach(func() {
+				fetchedConfig, fetchErr = configSource.FetchConfig(context.TODO(), logger, repo)
+			})
+
+			It("returns the config with overridden secret files", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchedConfig.SecretFiles).To(Equal(map[string]string{
+					"key.pem": "((other-key))",
+					"ca.pem":  "((ca))",
+					"token":   "((token))",
+				}))
+			})
+
+			It("returns no warnings", func() {
+				Expect(configSource.Warnings()).To(HaveLen(0))
+			})
+		})
 	})
 
 	Describe("ValidatingConfigSource", func() {
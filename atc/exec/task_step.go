@@ -84,12 +84,14 @@ type TaskStep struct {
 	planID            atc.PlanID
 	plan              atc.TaskPlan
 	defaultLimits     atc.ContainerLimits
+	maxLimits         atc.ContainerLimits
 	metadata          StepMetadata
 	containerMetadata db.ContainerMetadata
 	strategy          worker.ContainerPlacementStrategy
 	workerPool        worker.Pool
 	artifactSourcer   worker.ArtifactSourcer
 	artifactStreamer  worker.ArtifactStreamer
+	keyedCacheFactory db.KeyedCacheFactory
 	delegateFactory   TaskDelegateFactory
 }
 
@@ -97,24 +99,28 @@ func NewTaskStep(
 	planID atc.PlanID,
 	plan atc.TaskPlan,
 	defaultLimits atc.ContainerLimits,
+	maxLimits atc.ContainerLimits,
 	metadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	strategy worker.ContainerPlacementStrategy,
 	workerPool worker.Pool,
 	artifactStreamer worker.ArtifactStreamer,
 	artifactSourcer worker.ArtifactSourcer,
+	keyedCacheFactory db.KeyedCacheFactory,
 	delegateFactory TaskDelegateFactory,
 ) Step {
 	return &TaskStep{
 		planID:            planID,
 		plan:              plan,
 		defaultLimits:     defaultLimits,
+		maxLimits:         maxLimits,
 		metadata:          metadata,
 		containerMetadata: containerMetadata,
 		strategy:          strategy,
 		workerPool:        workerPool,
 		artifactStreamer:  artifactStreamer,
 		artifactSourcer:   artifactSourcer,
+		keyedCacheFactory: keyedCacheFactory,
 		delegateFactory:   delegateFactory,
 	}
 }
@@ -184,8 +190,12 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		ResourceTypes: step.plan.VersionedResourceTypes,
 	}
 
-	// override params
-	taskConfigSource = &OverrideParamsConfigSource{ConfigSource: taskConfigSource, Params: step.plan.Params}
+	// override params and secret files
+	taskConfigSource = &OverrideParamsConfigSource{
+		ConfigSource: taskConfigSource,
+		Params:       step.plan.Params,
+		SecretFiles:  step.plan.SecretFiles,
+	}
 
 	// interpolate template vars
 	taskConfigSource = InterpolateTemplateConfigSource{
@@ -214,21 +224,22 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	if config.Limits == nil {
 		config.Limits = &atc.ContainerLimits{}
 	}
-	if config.Limits.CPU == nil {
-		config.Limits.CPU = step.defaultLimits.CPU
-	}
-	if config.Limits.Memory == nil {
-		config.Limits.Memory = step.defaultLimits.Memory
-	}
+	limits := config.Limits.WithDefaults(step.defaultLimits).Capped(step.maxLimits)
+	config.Limits = &limits
 
 	delegate.Initializing(logger)
 
+	keyedCaches, err := step.restoreKeyedCaches(ctx, logger, repository, config, delegate)
+	if err != nil {
+		return false, err
+	}
+
 	imageSpec, err := step.imageSpec(ctx, logger, state, delegate, config)
 	if err != nil {
 		return false, err
 	}
 
-	containerSpec, err := step.containerSpec(logger, state, imageSpec, config, step.containerMetadata)
+	containerSpec, err := step.containerSpec(logger, state, imageSpec, config, keyedCaches, step.containerMetadata)
 	if err != nil {
 		return false, err
 	}
@@ -290,9 +301,12 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
 
+	step.saveOutputs(logger, config, result.VolumeMounts, step.containerMetadata)
+
 	// Do not initialize caches for one-off builds
 	if step.metadata.JobID != 0 {
-		if err := step.registerCaches(logger, repository, config, result.VolumeMounts, step.containerMetadata); err != nil {
+		succeeded := runErr == nil && result.ExitStatus == 0
+		if err := step.registerCaches(ctx, logger, config, keyedCaches, succeeded, result.VolumeMounts, step.containerMetadata); err != nil {
 			return false, err
 		}
 	}
@@ -351,7 +365,83 @@ func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state
 	return imageSpec, nil
 }
 
-func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, metadata db.ContainerMetadata) ([]worker.InputSource, error) {
+// keyedCache is the outcome of looking up one of the task's keyed caches.
+type keyedCache struct {
+	// key is the evaluated key the cache is saved under after the build.
+	key string
+
+	// volumeHandle is empty if no cache was found.
+	volumeHandle string
+
+	// exactMatch is true if the cache was found under the key itself, in
+	// which case there is no need to save it again.
+	exactMatch bool
+}
+
+func (step *TaskStep) restoreKeyedCaches(ctx context.Context, logger lager.Logger, repository *build.Repository, config atc.TaskConfig, delegate TaskDelegate) (map[string]keyedCache, error) {
+	keyedCaches := map[string]keyedCache{}
+
+	// one-off builds start with empty caches, same as with task caches
+	if step.metadata.JobID == 0 {
+		return keyedCaches, nil
+	}
+
+	evaluator := cacheKeyEvaluator{
+		streamer:     step.artifactStreamer,
+		repository:   repository,
+		inputs:       config.Inputs,
+		inputMapping: step.plan.InputMapping,
+	}
+
+	for _, cacheConfig := range config.Caches {
+		if cacheConfig.Key == "" {
+			continue
+		}
+
+		key, err := evaluator.Evaluate(ctx, logger, cacheConfig.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		var restoreKeys []string
+		for _, restoreKey := range cacheConfig.RestoreKeys {
+			restoreKey, err = evaluator.Evaluate(ctx, logger, restoreKey)
+			if err != nil {
+				return nil, err
+			}
+
+			restoreKeys = append(restoreKeys, restoreKey)
+		}
+
+		cache, found, err := step.keyedCacheFactory.Find(step.metadata.JobID, cacheConfig.Path, key, restoreKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		restored := keyedCache{key: key}
+		if found {
+			restored.volumeHandle = cache.VolumeHandle
+			restored.exactMatch = cache.Key == key
+
+			fmt.Fprintf(delegate.Stderr(), "restoring cache '%s' from key '%s'\n", cacheConfig.Path, cache.Key)
+		} else {
+			fmt.Fprintf(delegate.Stderr(), "no cache found for '%s' under key '%s'\n", cacheConfig.Path, key)
+		}
+
+		logger.Debug("looked-up-keyed-cache", lager.Data{
+			"cache":  cacheConfig.Path,
+			"key":    key,
+			"found":  found,
+			"handle": restored.volumeHandle,
+		})
+
+		keyedCaches[cacheConfig.Path] = restored
+	}
+
+	return keyedCaches, nil
+}
+
+func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, keyedCaches map[string]keyedCache, metadata db.ContainerMetadata) ([]worker.InputSource, error) {
 	inputs := map[string]runtime.Artifact{}
 
 	var missingRequiredInputs []string
@@ -383,12 +473,22 @@ func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Rep
 	}
 
 	for _, cacheConfig := range config.Caches {
-		cacheArt := &runtime.CacheArtifact{
-			TeamID:   step.metadata.TeamID,
-			JobID:    step.metadata.JobID,
-			StepName: step.plan.Name,
-			Path:     cacheConfig.Path,
+		var cacheArt runtime.Artifact
+		if cacheConfig.Key != "" {
+			cacheArt = &runtime.KeyedCacheArtifact{
+				VolumeHandle: keyedCaches[cacheConfig.Path].volumeHandle,
+				JobID:        step.metadata.JobID,
+				Path:         cacheConfig.Path,
+			}
+		} else {
+			cacheArt = &runtime.CacheArtifact{
+				TeamID:   step.metadata.TeamID,
+				JobID:    step.metadata.JobID,
+				StepName: step.plan.Name,
+				Path:     cacheConfig.Path,
+			}
 		}
+
 		ti := taskCacheInput{
 			artifact:      cacheArt,
 			artifactsRoot: metadata.WorkingDirectory,
@@ -405,11 +505,18 @@ func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Rep
 	return containerInputs, nil
 }
 
-func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec worker.ImageSpec, config atc.TaskConfig, metadata db.ContainerMetadata) (worker.ContainerSpec, error) {
+func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec worker.ImageSpec, config atc.TaskConfig, keyedCaches map[string]keyedCache, metadata db.ContainerMetadata) (worker.ContainerSpec, error) {
 	var limits worker.ContainerLimits
 	if config.Limits != nil {
 		limits.CPU = (*uint64)(config.Limits.CPU)
 		limits.Memory = (*uint64)(config.Limits.Memory)
+		limits.Pids = (*uint64)(config.Limits.Pids)
+		if config.Limits.IO != nil {
+			limits.IOReadBPS = (*uint64)(config.Limits.IO.ReadBPS)
+			limits.IOWriteBPS = (*uint64)(config.Limits.IO.WriteBPS)
+			limits.IOReadIOPS = (*uint64)(config.Limits.IO.ReadIOPS)
+			limits.IOWriteIOPS = (*uint64)(config.Limits.IO.WriteIOPS)
+		}
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -417,16 +524,17 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 		TeamID:    step.metadata.TeamID,
 		Type:      metadata.Type,
 
-		Dir:    metadata.WorkingDirectory,
-		Env:    config.Params.Env(),
-		Limits: limits,
-		User:   config.Run.User,
+		Dir:         metadata.WorkingDirectory,
+		Env:         config.Params.Env(),
+		SecretFiles: config.SecretFiles,
+		Limits:      limits,
+		User:        config.Run.User,
 
 		Outputs: worker.OutputPaths{},
 	}
 
 	var err error
-	containerSpec.Inputs, err = step.containerInputs(logger, state.ArtifactRepository(), config, metadata)
+	containerSpec.Inputs, err = step.containerInputs(logger, state.ArtifactRepository(), config, keyedCaches, metadata)
 	if err != nil {
 		return worker.ContainerSpec{}, err
 	}
@@ -469,10 +577,44 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Rep
 	}
 }
 
-func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
+// saveOutputs records the task's outputs against the build so that they can be
+// downloaded for as long as the task's container exists, or for as long as
+// keep_outputs if it is set.
+func (step *TaskStep) saveOutputs(logger lager.Logger, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) {
+	var expiresAt time.Time
+	if step.plan.KeepOutputs != "" {
+		keepOutputs, err := time.ParseDuration(step.plan.KeepOutputs)
+		if err != nil {
+			logger.Error("failed-to-parse-keep-outputs", err)
+		} else {
+			expiresAt = time.Now().Add(keepOutputs)
+		}
+	}
+
+	for _, output := range config.Outputs {
+		outputPath := artifactsPath(output, metadata.WorkingDirectory)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				saveStepOutput(logger, mount.Volume, output.Name, step.metadata.BuildID, step.planID, expiresAt)
+			}
+		}
+	}
+}
+
+func (step *TaskStep) registerCaches(ctx context.Context, logger lager.Logger, config atc.TaskConfig, keyedCaches map[string]keyedCache, succeeded bool, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
 	for _, cacheConfig := range config.Caches {
 		for _, volumeMount := range volumeMounts {
 			if volumeMount.MountPath == filepath.Join(metadata.WorkingDirectory, cacheConfig.Path) {
+				if cacheConfig.Key != "" {
+					err := step.saveKeyedCache(ctx, logger, cacheConfig, keyedCaches[cacheConfig.Path], succeeded, volumeMount.Volume)
+					if err != nil {
+						return err
+					}
+
+					break
+				}
+
 				logger.Debug("initializing-cache", lager.Data{
 					"cache": cacheConfig.Path,
 				})
@@ -496,6 +638,28 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repo
 	return nil
 }
 
+// saveKeyedCache saves the cache under its key, unless the build failed or the
+// cache was restored from the key to begin with.
+func (step *TaskStep) saveKeyedCache(ctx context.Context, logger lager.Logger, cacheConfig atc.TaskCacheConfig, restored keyedCache, succeeded bool, volume worker.Volume) error {
+	if !succeeded || restored.exactMatch {
+		return nil
+	}
+
+	logger.Debug("saving-keyed-cache", lager.Data{
+		"cache": cacheConfig.Path,
+		"key":   restored.key,
+	})
+
+	return volume.InitializeKeyedCache(
+		ctx,
+		logger,
+		step.metadata.JobID,
+		cacheConfig.Path,
+		restored.key,
+		bool(step.plan.Privileged),
+	)
+}
+
 type taskInput struct {
 	config        atc.TaskInputConfig
 	artifact      runtime.Artifact
@@ -2,13 +2,20 @@ package exec_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
@@ -40,6 +47,8 @@ var _ = Describe("TaskStep", func() {
 		fakeArtifactSourcer  *workerfakes.FakeArtifactSourcer
 		fakeStrategy         *workerfakes.FakeContainerPlacementStrategy
 
+		fakeKeyedCacheFactory *dbfakes.FakeKeyedCacheFactory
+
 		spanCtx      context.Context
 		fakeDelegate *execfakes.FakeTaskDelegate
 
@@ -70,11 +79,17 @@ var _ = Describe("TaskStep", func() {
 		planID = atc.PlanID("42")
 
 		shouldRunTaskStep bool
+
+		defaultLimits atc.ContainerLimits
+		maxLimits     atc.ContainerLimits
 	)
 
 	BeforeEach(func() {
 		ctx, cancel = context.WithCancel(context.Background())
 
+		defaultLimits = atc.ContainerLimits{}
+		maxLimits = atc.ContainerLimits{}
+
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
 
@@ -87,6 +102,8 @@ var _ = Describe("TaskStep", func() {
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
+		fakeKeyedCacheFactory = new(dbfakes.FakeKeyedCacheFactory)
+
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
 		fakeDelegate.StdoutReturns(stdoutBuf)
 		fakeDelegate.StderrReturns(stderrBuf)
@@ -136,13 +153,15 @@ var _ = Describe("TaskStep", func() {
 		taskStep = exec.NewTaskStep(
 			plan.ID,
 			*plan.Task,
-			atc.ContainerLimits{},
+			defaultLimits,
+			maxLimits,
 			stepMetadata,
 			containerMetadata,
 			fakeStrategy,
 			fakePool,
 			fakeArtifactStreamer,
 			fakeArtifactSourcer,
+			fakeKeyedCacheFactory,
 			fakeDelegateFactory,
 		)
 
@@ -271,6 +290,61 @@ var _ = Describe("TaskStep", func() {
 			Expect(startEventDelegate).To(Equal(fakeDelegate))
 		})
 
+		It("creates a containerSpec with the configured limits", func() {
+			cpu := uint64(1024)
+			memory := uint64(1024)
+			Expect(containerSpec.Limits).To(Equal(worker.ContainerLimits{
+				CPU:    &cpu,
+				Memory: &memory,
+			}))
+		})
+
+		Context("when default limits are configured", func() {
+			BeforeEach(func() {
+				cpu := atc.CPULimit(512)
+				pids := atc.PidsLimit(100)
+				readBPS := atc.BandwidthLimit(4096)
+				defaultLimits = atc.ContainerLimits{
+					CPU:  &cpu,
+					Pids: &pids,
+					IO:   &atc.IOLimits{ReadBPS: &readBPS},
+				}
+			})
+
+			It("applies them to the limits which are not configured", func() {
+				cpu := uint64(1024)
+				memory := uint64(1024)
+				pids := uint64(100)
+				readBPS := uint64(4096)
+				Expect(containerSpec.Limits).To(Equal(worker.ContainerLimits{
+					CPU:       &cpu,
+					Memory:    &memory,
+					Pids:      &pids,
+					IOReadBPS: &readBPS,
+				}))
+			})
+		})
+
+		Context("when maximum limits are configured", func() {
+			BeforeEach(func() {
+				memory := atc.MemoryLimit(512)
+				pids := atc.PidsLimit(100)
+				maxLimits = atc.ContainerLimits{
+					Memory: &memory,
+					Pids:   &pids,
+				}
+			})
+
+			It("caps the configured limits and leaves unset ones alone", func() {
+				cpu := uint64(1024)
+				memory := uint64(512)
+				Expect(containerSpec.Limits).To(Equal(worker.ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				}))
+			})
+		})
+
 		Context("when privileged", func() {
 			BeforeEach(func() {
 				taskPlan.Privileged = true
@@ -324,6 +398,26 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when secret files are configured", func() {
+			BeforeEach(func() {
+				state.GetStub = vars.StaticVariables{"key": "some-private-key"}.Get
+
+				taskPlan.Config.SecretFiles = map[string]string{"key.pem": "((key))"}
+				taskPlan.SecretFiles = map[string]string{"token": "some-token"}
+			})
+
+			It("passes the interpolated files to the container", func() {
+				Expect(containerSpec.SecretFiles).To(Equal(map[string]string{
+					"key.pem": "some-private-key",
+					"token":   "some-token",
+				}))
+			})
+
+			It("does not put them in the environment", func() {
+				Expect(containerSpec.Env).ToNot(ContainElement(ContainSubstring("some-private-key")))
+			})
+		})
+
 		Context("when rootfs uri is set instead of image resource", func() {
 			BeforeEach(func() {
 				taskPlan.Config.RootfsURI = "some-image"
@@ -618,6 +712,184 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the configuration specifies keyed caches", func() {
+			var (
+				fakeCacheVolume *workerfakes.FakeVolume
+				taskResult      worker.TaskResult
+				expectedKey     string
+			)
+
+			BeforeEach(func() {
+				stepMetadata.JobID = 12
+
+				taskPlan.Config = &atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Inputs: []atc.TaskInputConfig{
+						{Name: "some-input", Path: "some-input-path"},
+					},
+					Caches: []atc.TaskCacheConfig{
+						{
+							Path:        ".m2",
+							Key:         "maven-((hashFiles some-input-path/pom.xml))",
+							RestoreKeys: []string{"maven-"},
+						},
+					},
+				}
+
+				repo.RegisterArtifact("some-input", new(runtimefakes.FakeArtifact))
+
+				fakeArtifactStreamer.StreamFileFromArtifactStub = func(context.Context, runtime.Artifact, string) (io.ReadCloser, error) {
+					return ioutil.NopCloser(strings.NewReader("some-pom")), nil
+				}
+
+				sum := sha256.Sum256([]byte("some-input-path/pom.xml\x00some-pom"))
+				expectedKey = "maven-" + hex.EncodeToString(sum[:])
+
+				fakeCacheVolume = new(workerfakes.FakeVolume)
+				taskResult = worker.TaskResult{
+					ExitStatus: 0,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeCacheVolume,
+							MountPath: "some-artifact-root/.m2",
+						},
+					},
+				}
+				fakeClient.RunTaskStepReturns(taskResult, nil)
+			})
+
+			It("hashes the files in the key", func() {
+				Expect(fakeArtifactStreamer.StreamFileFromArtifactCallCount()).To(Equal(1))
+				_, _, path := fakeArtifactStreamer.StreamFileFromArtifactArgsForCall(0)
+				Expect(path).To(Equal("pom.xml"))
+			})
+
+			It("looks up the cache under the evaluated key", func() {
+				Expect(fakeKeyedCacheFactory.FindCallCount()).To(Equal(1))
+				jobID, path, key, restoreKeys := fakeKeyedCacheFactory.FindArgsForCall(0)
+				Expect(jobID).To(Equal(12))
+				Expect(path).To(Equal(".m2"))
+				Expect(key).To(Equal(expectedKey))
+				Expect(restoreKeys).To(Equal([]string{"maven-"}))
+			})
+
+			Context("when no cache is found", func() {
+				It("starts with an empty cache", func() {
+					_, _, inputMap := fakeArtifactSourcer.SourceInputsAndCachesArgsForCall(0)
+					Expect(inputMap["some-artifact-root/.m2"]).To(Equal(&runtime.KeyedCacheArtifact{
+						JobID: stepMetadata.JobID,
+						Path:  ".m2",
+					}))
+				})
+
+				It("saves the cache under the key", func() {
+					Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(Equal(1))
+					_, _, jobID, path, key, privileged := fakeCacheVolume.InitializeKeyedCacheArgsForCall(0)
+					Expect(jobID).To(Equal(12))
+					Expect(path).To(Equal(".m2"))
+					Expect(key).To(Equal(expectedKey))
+					Expect(privileged).To(BeFalse())
+				})
+
+				It("does not register it as a task cache", func() {
+					Expect(fakeCacheVolume.InitializeTaskCacheCallCount()).To(BeZero())
+				})
+
+				Context("when the task exits nonzero", func() {
+					BeforeEach(func() {
+						taskResult.ExitStatus = 1
+						fakeClient.RunTaskStepReturns(taskResult, nil)
+					})
+
+					It("does not save the cache", func() {
+						Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the task errors", func() {
+					BeforeEach(func() {
+						fakeClient.RunTaskStepReturns(taskResult, errors.New("nope"))
+					})
+
+					It("does not save the cache", func() {
+						Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when a cache is found under a restore key", func() {
+				BeforeEach(func() {
+					fakeKeyedCacheFactory.FindReturns(db.KeyedCache{
+						Key:          "maven-some-older-hash",
+						VolumeHandle: "some-cache-handle",
+					}, true, nil)
+				})
+
+				It("restores it", func() {
+					_, _, inputMap := fakeArtifactSourcer.SourceInputsAndCachesArgsForCall(0)
+					Expect(inputMap["some-artifact-root/.m2"]).To(Equal(&runtime.KeyedCacheArtifact{
+						VolumeHandle: "some-cache-handle",
+						JobID:        stepMetadata.JobID,
+						Path:         ".m2",
+					}))
+
+					Expect(stderrBuf).To(gbytes.Say("restoring cache '.m2' from key 'maven-some-older-hash'"))
+				})
+
+				It("saves the cache under the key", func() {
+					Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(Equal(1))
+					_, _, _, _, key, _ := fakeCacheVolume.InitializeKeyedCacheArgsForCall(0)
+					Expect(key).To(Equal(expectedKey))
+				})
+			})
+
+			Context("when a cache is found under the key", func() {
+				BeforeEach(func() {
+					fakeKeyedCacheFactory.FindStub = func(_ int, _ string, key string, _ []string) (db.KeyedCache, bool, error) {
+						return db.KeyedCache{Key: key, VolumeHandle: "some-cache-handle"}, true, nil
+					}
+				})
+
+				It("does not save it again", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a file in the key does not exist", func() {
+				BeforeEach(func() {
+					fakeArtifactStreamer.StreamFileFromArtifactStub = nil
+					fakeArtifactStreamer.StreamFileFromArtifactReturns(nil, baggageclaim.ErrFileNotFound)
+
+					shouldRunTaskStep = false
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(MatchError(ContainSubstring("file 'some-input-path/pom.xml' not found")))
+					Expect(errors.As(stepErr, &exec.CacheKeyError{})).To(BeTrue())
+				})
+			})
+
+			Context("when the task does not belong to a job (one-off build)", func() {
+				BeforeEach(func() {
+					stepMetadata.JobID = 0
+				})
+
+				It("starts with an empty cache and does not save it", func() {
+					Expect(fakeKeyedCacheFactory.FindCallCount()).To(BeZero())
+
+					_, _, inputMap := fakeArtifactSourcer.SourceInputsAndCachesArgsForCall(0)
+					Expect(inputMap["some-artifact-root/.m2"]).To(Equal(&runtime.KeyedCacheArtifact{Path: ".m2"}))
+
+					Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when the configuration specifies paths for outputs", func() {
 			BeforeEach(func() {
 				taskPlan.Config = &atc.TaskConfig{
@@ -1186,6 +1458,45 @@ var _ = Describe("TaskStep", func() {
 				})
 
 				outputsAreRegistered()
+
+				It("saves the outputs against the build until its container is gone", func() {
+					for i, fakeVolume := range []*workerfakes.FakeVolume{fakeVolume1, fakeVolume2, fakeVolume3} {
+						Expect(fakeVolume.InitializeStepOutputCallCount()).To(Equal(1))
+						name, buildID, savedPlanID, expiresAt := fakeVolume.InitializeStepOutputArgsForCall(0)
+						Expect(name).To(Equal(taskPlan.Config.Outputs[i].Name))
+						Expect(buildID).To(Equal(stepMetadata.BuildID))
+						Expect(savedPlanID).To(Equal(planID))
+						Expect(expiresAt).To(BeZero())
+					}
+				})
+
+				Context("when the outputs are kept", func() {
+					BeforeEach(func() {
+						taskPlan.KeepOutputs = "1h"
+					})
+
+					It("saves the outputs until they expire", func() {
+						_, _, _, expiresAt := fakeVolume1.InitializeStepOutputArgsForCall(0)
+						Expect(expiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+					})
+				})
+
+				Context("when saving an output fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeVolume2.InitializeStepOutputReturns(nil, disaster)
+					})
+
+					It("does not fail the step", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stepOk).To(BeTrue())
+					})
+
+					It("saves the other outputs", func() {
+						Expect(fakeVolume3.InitializeStepOutputCallCount()).To(Equal(1))
+					})
+				})
 			})
 
 			Context("when RunTaskStep errors", func() {
@@ -8,12 +8,14 @@ import (
 )
 
 type checksCollector struct {
-	lifecycle db.CheckLifecycle
+	lifecycle      db.CheckLifecycle
+	checksToRetain int
 }
 
-func NewChecksCollector(lifecycle db.CheckLifecycle) *checksCollector {
+func NewChecksCollector(lifecycle db.CheckLifecycle, checksToRetain int) *checksCollector {
 	return &checksCollector{
-		lifecycle: lifecycle,
+		lifecycle:      lifecycle,
+		checksToRetain: checksToRetain,
 	}
 }
 
@@ -23,7 +25,7 @@ func (c *checksCollector) Run(ctx context.Context) error {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
-	err := c.lifecycle.DeleteCompletedChecks()
+	err := c.lifecycle.DeleteCompletedChecks(c.checksToRetain)
 	if err != nil {
 		logger.Error("failed-to-delete-completed-checks", err)
 		return err
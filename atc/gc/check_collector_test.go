@@ -16,7 +16,7 @@ var _ = Describe("CheckCollector", func() {
 	BeforeEach(func() {
 		fakeLifecycle = new(dbfakes.FakeCheckLifecycle)
 
-		collector = gc.NewChecksCollector(fakeLifecycle)
+		collector = gc.NewChecksCollector(fakeLifecycle, 5)
 	})
 
 	Describe("Run", func() {
@@ -25,6 +25,7 @@ var _ = Describe("CheckCollector", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeLifecycle.DeleteCompletedChecksCallCount()).To(Equal(1))
+			Expect(fakeLifecycle.DeleteCompletedChecksArgsForCall(0)).To(Equal(5))
 		})
 	})
 })
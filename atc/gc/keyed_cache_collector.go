@@ -0,0 +1,65 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type keyedCacheCollector struct {
+	keyedCacheLifecycle db.KeyedCacheLifecycle
+	maxIdle             time.Duration
+	maxTeamSize         int64
+}
+
+func NewKeyedCacheCollector(keyedCacheLifecycle db.KeyedCacheLifecycle, maxIdle time.Duration, maxTeamSize int64) *keyedCacheCollector {
+	return &keyedCacheCollector{
+		keyedCacheLifecycle: keyedCacheLifecycle,
+		maxIdle:             maxIdle,
+		maxTeamSize:         maxTeamSize,
+	}
+}
+
+func (kcc *keyedCacheCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("keyed-cache-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	start := time.Now()
+	defer func() {
+		metric.KeyedCacheCollectorDuration{
+			Duration: time.Since(start),
+		}.Emit(logger)
+	}()
+
+	removed, err := kcc.keyedCacheLifecycle.RemoveUnusedKeyedCaches(kcc.maxIdle)
+	if err != nil {
+		logger.Error("failed-to-remove-unused-keyed-caches", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Debug("removed-unused-keyed-caches", lager.Data{"count": removed})
+	}
+
+	if kcc.maxTeamSize == 0 {
+		return nil
+	}
+
+	removed, err = kcc.keyedCacheLifecycle.RemoveKeyedCachesExceedingTeamSize(kcc.maxTeamSize)
+	if err != nil {
+		logger.Error("failed-to-remove-keyed-caches-exceeding-team-size", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Debug("removed-keyed-caches-exceeding-team-size", lager.Data{"count": removed})
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyedCacheCollector", func() {
+	var collector GcCollector
+	var fakeKeyedCacheLifecycle *dbfakes.FakeKeyedCacheLifecycle
+	var maxTeamSize int64
+
+	BeforeEach(func() {
+		fakeKeyedCacheLifecycle = new(dbfakes.FakeKeyedCacheLifecycle)
+		maxTeamSize = 1024
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewKeyedCacheCollector(fakeKeyedCacheLifecycle, time.Hour, maxTeamSize)
+	})
+
+	Describe("Run", func() {
+		It("removes unused caches and caches exceeding the team size", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeKeyedCacheLifecycle.RemoveUnusedKeyedCachesCallCount()).To(Equal(1))
+			Expect(fakeKeyedCacheLifecycle.RemoveUnusedKeyedCachesArgsForCall(0)).To(Equal(time.Hour))
+
+			Expect(fakeKeyedCacheLifecycle.RemoveKeyedCachesExceedingTeamSizeCallCount()).To(Equal(1))
+			Expect(fakeKeyedCacheLifecycle.RemoveKeyedCachesExceedingTeamSizeArgsForCall(0)).To(Equal(int64(1024)))
+		})
+
+		Context("when there is no team size limit", func() {
+			BeforeEach(func() {
+				maxTeamSize = 0
+			})
+
+			It("only removes unused caches", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeKeyedCacheLifecycle.RemoveUnusedKeyedCachesCallCount()).To(Equal(1))
+				Expect(fakeKeyedCacheLifecycle.RemoveKeyedCachesExceedingTeamSizeCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when removing unused caches fails", func() {
+			BeforeEach(func() {
+				fakeKeyedCacheLifecycle.RemoveUnusedKeyedCachesReturns(0, errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+	})
+})
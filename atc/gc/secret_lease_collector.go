@@ -0,0 +1,74 @@
+package gc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+type secretLeaseCollector struct {
+	secretLeaseLifecycle db.SecretLeaseLifecycle
+	leaseKeeper          vars.LeaseKeeper
+}
+
+// NewSecretLeaseCollector constructs a collector which revokes the leases of
+// dynamic secrets left behind by builds, e.g. because their ATC went away
+// before the build finished.
+//
+// Leases are revoked through the global credential manager. Leases which
+// have already expired, or which were issued by a pipeline's var_source and
+// so can't be revoked, are only forgotten once they expire.
+func NewSecretLeaseCollector(secretLeaseLifecycle db.SecretLeaseLifecycle, leaseKeeper vars.LeaseKeeper) *secretLeaseCollector {
+	return &secretLeaseCollector{
+		secretLeaseLifecycle: secretLeaseLifecycle,
+		leaseKeeper:          leaseKeeper,
+	}
+}
+
+func (slc *secretLeaseCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("secret-lease-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	leases, err := slc.secretLeaseLifecycle.OrphanedSecretLeases()
+	if err != nil {
+		logger.Error("failed-to-get-orphaned-secret-leases", err)
+		return err
+	}
+
+	for _, lease := range leases {
+		llogger := logger.WithData(lager.Data{
+			"build": lease.BuildID,
+			"lease": lease.LeaseID,
+		})
+
+		if lease.ExpiresAt.After(time.Now()) {
+			err := slc.leaseKeeper.RevokeLease(lease.LeaseID)
+			if errors.Is(err, creds.ErrLeasesNotSupported) {
+				continue
+			}
+
+			if err != nil {
+				llogger.Error("failed-to-revoke-lease", err)
+				continue
+			}
+		}
+
+		err := slc.secretLeaseLifecycle.RemoveSecretLease(lease.BuildID, lease.LeaseID)
+		if err != nil {
+			llogger.Error("failed-to-remove-lease", err)
+			continue
+		}
+
+		llogger.Debug("removed-lease")
+	}
+
+	return nil
+}
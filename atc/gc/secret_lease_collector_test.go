@@ -0,0 +1,95 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/vars/varsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretLeaseCollector", func() {
+	var (
+		collector                GcCollector
+		fakeSecretLeaseLifecycle *dbfakes.FakeSecretLeaseLifecycle
+		fakeLeaseKeeper          *varsfakes.FakeLeaseKeeper
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakeSecretLeaseLifecycle = new(dbfakes.FakeSecretLeaseLifecycle)
+		fakeLeaseKeeper = new(varsfakes.FakeLeaseKeeper)
+
+		fakeSecretLeaseLifecycle.OrphanedSecretLeasesReturns([]db.SecretLease{
+			{BuildID: 1, LeaseID: "active-lease", ExpiresAt: time.Now().Add(time.Hour)},
+			{BuildID: 2, LeaseID: "expired-lease", ExpiresAt: time.Now().Add(-time.Hour)},
+		}, nil)
+
+		collector = gc.NewSecretLeaseCollector(fakeSecretLeaseLifecycle, fakeLeaseKeeper)
+	})
+
+	JustBeforeEach(func() {
+		err = collector.Run(context.TODO())
+	})
+
+	It("revokes the leases which have not expired yet", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeLeaseKeeper.RevokeLeaseCallCount()).To(Equal(1))
+		Expect(fakeLeaseKeeper.RevokeLeaseArgsForCall(0)).To(Equal("active-lease"))
+	})
+
+	It("removes the leases", func() {
+		Expect(fakeSecretLeaseLifecycle.RemoveSecretLeaseCallCount()).To(Equal(2))
+
+		buildID, leaseID := fakeSecretLeaseLifecycle.RemoveSecretLeaseArgsForCall(0)
+		Expect(buildID).To(Equal(1))
+		Expect(leaseID).To(Equal("active-lease"))
+
+		buildID, leaseID = fakeSecretLeaseLifecycle.RemoveSecretLeaseArgsForCall(1)
+		Expect(buildID).To(Equal(2))
+		Expect(leaseID).To(Equal("expired-lease"))
+	})
+
+	Context("when revoking a lease fails", func() {
+		BeforeEach(func() {
+			fakeLeaseKeeper.RevokeLeaseReturns(errors.New("nope"))
+		})
+
+		It("keeps the lease around to try again", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSecretLeaseLifecycle.RemoveSecretLeaseCallCount()).To(Equal(1))
+			_, leaseID := fakeSecretLeaseLifecycle.RemoveSecretLeaseArgsForCall(0)
+			Expect(leaseID).To(Equal("expired-lease"))
+		})
+	})
+
+	Context("when the credential manager does not support leases", func() {
+		BeforeEach(func() {
+			fakeLeaseKeeper.RevokeLeaseReturns(creds.ErrLeasesNotSupported)
+		})
+
+		It("waits for the lease to expire", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSecretLeaseLifecycle.RemoveSecretLeaseCallCount()).To(Equal(1))
+			_, leaseID := fakeSecretLeaseLifecycle.RemoveSecretLeaseArgsForCall(0)
+			Expect(leaseID).To(Equal("expired-lease"))
+		})
+	})
+
+	Context("when getting the orphaned leases fails", func() {
+		BeforeEach(func() {
+			fakeSecretLeaseLifecycle.OrphanedSecretLeasesReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
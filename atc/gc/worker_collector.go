@@ -63,6 +63,16 @@ func (wc *workerCollector) Run(ctx context.Context) error {
 		logger.Info("marked-workers-as-retired", lager.Data{"count": len(affected), "workers": affected})
 	}
 
+	affected, err = wc.workerLifecycle.DeleteEvictedWorkers()
+	if err != nil {
+		logger.Error("failed-to-delete-evicted-workers", err)
+		return err
+	}
+
+	if len(affected) > 0 {
+		logger.Info("evicted-workers-removed", lager.Data{"count": len(affected), "workers": affected})
+	}
+
 	affected, err = wc.workerLifecycle.LandFinishedLandingWorkers()
 	if err != nil {
 		logger.Error("failed-to-land-finished-landing-workers", err)
@@ -26,6 +26,7 @@ var _ = Describe("WorkerCollector", func() {
 		fakeWorkerLifecycle.DeleteUnresponsiveEphemeralWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.StallUnresponsiveWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.DeleteFinishedRetiringWorkersReturns(nil, nil)
+		fakeWorkerLifecycle.DeleteEvictedWorkersReturns(nil, nil)
 		fakeWorkerLifecycle.LandFinishedLandingWorkersReturns(nil, nil)
 	})
 
@@ -51,6 +52,13 @@ var _ = Describe("WorkerCollector", func() {
 			Expect(fakeWorkerLifecycle.DeleteFinishedRetiringWorkersCallCount()).To(Equal(1))
 		})
 
+		It("tells the worker factory to delete evicted workers", func() {
+			err := workerCollector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerLifecycle.DeleteEvictedWorkersCallCount()).To(Equal(1))
+		})
+
 		It("tells the worker factory to land finished landing workers", func() {
 			err := workerCollector.Run(context.TODO())
 			Expect(err).NotTo(HaveOccurred())
@@ -74,6 +82,14 @@ var _ = Describe("WorkerCollector", func() {
 			Expect(err).To(MatchError(returnedErr))
 		})
 
+		It("returns an error if deleting evicted workers fails", func() {
+			returnedErr := errors.New("some-error")
+			fakeWorkerLifecycle.DeleteEvictedWorkersReturns(nil, returnedErr)
+
+			err := workerCollector.Run(context.TODO())
+			Expect(err).To(MatchError(returnedErr))
+		})
+
 		It("returns an error if landing finished landing workers fails", func() {
 			returnedErr := errors.New("some-error")
 			fakeWorkerLifecycle.LandFinishedLandingWorkersReturns(nil, returnedErr)
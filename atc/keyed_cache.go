@@ -0,0 +1,14 @@
+package atc
+
+// KeyedCache is the content of a task's cache directory saved under a key at
+// the end of a successful build of a job. It is kept on the worker which ran
+// that build.
+type KeyedCache struct {
+	ID         int    `json:"id"`
+	Path       string `json:"path"`
+	Key        string `json:"key"`
+	Size       int64  `json:"size"`
+	WorkerName string `json:"worker_name,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	LastUsed   int64  `json:"last_used"`
+}
@@ -0,0 +1,46 @@
+package atc
+
+// Volume properties used by a worker's layer store.
+//
+// The layer store is a set of baggageclaim volumes on each worker holding
+// unpacked registry images, keyed by their content digest so that the same
+// image fetched by different teams or through differently configured
+// resources is only kept once per worker. Containers use copy-on-write
+// children of these volumes as their rootfs, and the image is only fetched
+// when the worker's store does not hold it yet.
+//
+// Layer store volumes are not tracked in the database: the worker does not
+// report them to the ATC, and its volume sweeper evicts the least recently
+// used ones once their total size grows beyond the configured maximum.
+const (
+	// LayerStoreProperty is set to "true" on every layer store volume.
+	LayerStoreProperty = "concourse:layer-store"
+
+	// LayerStoreDigestProperty holds the digest of the image in the volume.
+	LayerStoreDigestProperty = "concourse:layer-store-digest"
+
+	// LayerStorePrivilegedProperty records whether the volume was populated
+	// for privileged containers, as its ownership differs.
+	LayerStorePrivilegedProperty = "concourse:layer-store-privileged"
+
+	// LayerStoreReadyProperty is set to "true" once the volume has been fully
+	// populated.
+	LayerStoreReadyProperty = "concourse:layer-store-ready"
+
+	// LayerStoreMetadataProperty holds the image's metadata.json, so that
+	// containers can be created from the volume without streaming it out.
+	LayerStoreMetadataProperty = "concourse:layer-store-metadata"
+
+	// LayerStoreSizeProperty holds the size of the populated volume in bytes,
+	// as measured by the worker when it first sweeps the volume.
+	LayerStoreSizeProperty = "concourse:layer-store-size"
+
+	// LayerStoreLastUsedProperty holds the unix timestamp at which the volume
+	// was last used for a container.
+	LayerStoreLastUsedProperty = "concourse:layer-store-last-used"
+
+	// LayerStoreParentProperty is set on copy-on-write children of a layer
+	// store volume to the parent's handle, preventing its eviction while the
+	// child exists.
+	LayerStoreParentProperty = "concourse:layer-store-parent"
+)
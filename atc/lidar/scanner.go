@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
@@ -12,14 +13,19 @@ import (
 	"github.com/concourse/concourse/tracing"
 )
 
-func NewScanner(checkFactory db.CheckFactory) *scanner {
+func NewScanner(checkFactory db.CheckFactory, adaptive bool) *scanner {
 	return &scanner{
 		checkFactory: checkFactory,
+		adaptive:     adaptive,
 	}
 }
 
 type scanner struct {
 	checkFactory db.CheckFactory
+
+	// adaptive enables checking prioritized resources first and backing off
+	// resources which have not produced new versions in a while.
+	adaptive bool
 }
 
 func (s *scanner) Run(ctx context.Context) error {
@@ -43,9 +49,47 @@ func (s *scanner) Run(ctx context.Context) error {
 		return err
 	}
 
-	waitGroup := new(sync.WaitGroup)
 	resourceTypesChecked := &sync.Map{}
 
+	if !s.adaptive {
+		s.checkAll(spanCtx, resources, resourceTypes, resourceTypesChecked)
+		return nil
+	}
+
+	prioritizedIDs, err := s.checkFactory.PrioritizedResourceIDs()
+	if err != nil {
+		logger.Error("failed-to-get-prioritized-resources", err)
+		return err
+	}
+
+	var prioritized, due []db.Resource
+	for _, resource := range resources {
+		if prioritizedIDs[resource.ID()] {
+			prioritized = append(prioritized, resource)
+			continue
+		}
+
+		if time.Now().Before(s.checkFactory.NextCheckTime(resource, false)) {
+			// backed off since it has not produced a new version in a while
+			continue
+		}
+
+		due = append(due, resource)
+	}
+
+	// create the checks for prioritized resources before all others so that
+	// they are the first to be run
+	s.checkAll(spanCtx, prioritized, resourceTypes, resourceTypesChecked)
+	s.checkAll(spanCtx, due, resourceTypes, resourceTypesChecked)
+
+	return nil
+}
+
+func (s *scanner) checkAll(ctx context.Context, resources []db.Resource, resourceTypes db.ResourceTypes, resourceTypesChecked *sync.Map) {
+	logger := lagerctx.FromContext(ctx)
+
+	waitGroup := new(sync.WaitGroup)
+
 	for _, resource := range resources {
 		waitGroup.Add(1)
 
@@ -58,13 +102,11 @@ func (s *scanner) Run(ctx context.Context) error {
 			}()
 			defer waitGroup.Done()
 
-			s.check(spanCtx, resource, resourceTypes, resourceTypesChecked)
+			s.check(ctx, resource, resourceTypes, resourceTypesChecked)
 		}(resource, resourceTypes)
 	}
 
 	waitGroup.Wait()
-
-	return nil
 }
 
 func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTypes db.ResourceTypes, resourceTypesChecked *sync.Map) {
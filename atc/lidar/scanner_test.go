@@ -22,17 +22,18 @@ var _ = Describe("Scanner", func() {
 		err error
 
 		fakeCheckFactory *dbfakes.FakeCheckFactory
+		adaptive         bool
 
 		scanner Scanner
 	)
 
 	BeforeEach(func() {
 		fakeCheckFactory = new(dbfakes.FakeCheckFactory)
-
-		scanner = lidar.NewScanner(fakeCheckFactory)
+		adaptive = false
 	})
 
 	JustBeforeEach(func() {
+		scanner = lidar.NewScanner(fakeCheckFactory, adaptive)
 		err = scanner.Run(context.TODO())
 	})
 
@@ -218,5 +219,69 @@ var _ = Describe("Scanner", func() {
 				Expect(checked).To(ConsistOf([]string{fakeResourceType.Name(), fakeResource1.Name(), fakeResource2.Name()}))
 			})
 		})
+
+		It("does not look up prioritized resources", func() {
+			Expect(fakeCheckFactory.PrioritizedResourceIDsCallCount()).To(BeZero())
+		})
+
+		Context("when adaptive checking is enabled", func() {
+			var idleResource, busyResource, prioritizedResource *dbfakes.FakeResource
+
+			BeforeEach(func() {
+				adaptive = true
+
+				idleResource = new(dbfakes.FakeResource)
+				idleResource.IDReturns(1)
+				idleResource.NameReturns("idle")
+				idleResource.TypeReturns("some-base-type")
+
+				busyResource = new(dbfakes.FakeResource)
+				busyResource.IDReturns(2)
+				busyResource.NameReturns("busy")
+				busyResource.TypeReturns("some-base-type")
+
+				prioritizedResource = new(dbfakes.FakeResource)
+				prioritizedResource.IDReturns(3)
+				prioritizedResource.NameReturns("prioritized")
+				prioritizedResource.TypeReturns("some-base-type")
+
+				fakeCheckFactory.ResourcesReturns([]db.Resource{idleResource, busyResource, prioritizedResource}, nil)
+				fakeCheckFactory.ResourceTypesReturns([]db.ResourceType{}, nil)
+				fakeCheckFactory.PrioritizedResourceIDsReturns(map[int]bool{3: true}, nil)
+
+				fakeCheckFactory.NextCheckTimeStub = func(checkable db.Checkable, prioritized bool) time.Time {
+					if checkable.Name() == "idle" {
+						return time.Now().Add(time.Hour)
+					}
+
+					return time.Now().Add(-time.Minute)
+				}
+			})
+
+			It("creates checks for the prioritized resource first and skips backed off resources", func() {
+				Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(2))
+
+				_, checkable, _, _, _ := fakeCheckFactory.TryCreateCheckArgsForCall(0)
+				Expect(checkable.Name()).To(Equal("prioritized"))
+
+				_, checkable, _, _, _ = fakeCheckFactory.TryCreateCheckArgsForCall(1)
+				Expect(checkable.Name()).To(Equal("busy"))
+			})
+
+			It("does not compute the next check time of prioritized resources", func() {
+				Expect(fakeCheckFactory.NextCheckTimeCallCount()).To(Equal(2))
+			})
+
+			Context("when fetching prioritized resources fails", func() {
+				BeforeEach(func() {
+					fakeCheckFactory.PrioritizedResourceIDsReturns(nil, errors.New("nope"))
+				})
+
+				It("errors", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
@@ -73,7 +73,10 @@ type Monitor struct {
 	ConcurrentRequests         map[string]*Gauge
 	ConcurrentRequestsLimitHit map[string]*Counter
 
-	VolumesStreamed Counter
+	VolumesStreamed        Counter
+	VolumesStreamedAsDelta Counter
+
+	ResourceCacheCorruptions Counter
 }
 
 var Metrics = NewMonitor()
@@ -62,7 +62,10 @@ type PrometheusEmitter struct {
 
 	checksEnqueued prometheus.Counter
 
-	volumesStreamed prometheus.Counter
+	volumesStreamed        prometheus.Counter
+	volumesStreamedAsDelta prometheus.Counter
+
+	resourceCacheCorruptions prometheus.Counter
 
 	workerContainers        *prometheus.GaugeVec
 	workerUnknownContainers *prometheus.GaugeVec
@@ -71,6 +74,13 @@ type PrometheusEmitter struct {
 	workerTasks             *prometheus.GaugeVec
 	workersRegistered       *prometheus.GaugeVec
 
+	workerPoolWorkers      *prometheus.GaugeVec
+	workerPoolWaitingSteps *prometheus.GaugeVec
+	workerPoolContainers   *prometheus.GaugeVec
+	workerPoolVolumes      *prometheus.GaugeVec
+	workerPoolTasks        *prometheus.GaugeVec
+	buildsQueued           *prometheus.GaugeVec
+
 	workerContainersLabels map[string]map[string]prometheus.Labels
 	workerVolumesLabels    map[string]map[string]prometheus.Labels
 	workerTasksLabels      map[string]map[string]prometheus.Labels
@@ -387,6 +397,75 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(workersRegistered)
 
+	// worker demand metrics
+	workerPoolLabels := []string{"platform", "tags", "team"}
+
+	workerPoolWorkers := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers_pool",
+			Name:      "workers",
+			Help:      "Number of running workers in the pool",
+		},
+		workerPoolLabels,
+	)
+	prometheus.MustRegister(workerPoolWorkers)
+
+	workerPoolWaitingSteps := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers_pool",
+			Name:      "waiting_steps",
+			Help:      "Number of steps across the cluster waiting for a worker in the pool",
+		},
+		workerPoolLabels,
+	)
+	prometheus.MustRegister(workerPoolWaitingSteps)
+
+	workerPoolContainers := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers_pool",
+			Name:      "containers",
+			Help:      "Number of active containers in the pool",
+		},
+		workerPoolLabels,
+	)
+	prometheus.MustRegister(workerPoolContainers)
+
+	workerPoolVolumes := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers_pool",
+			Name:      "volumes",
+			Help:      "Number of active volumes in the pool",
+		},
+		workerPoolLabels,
+	)
+	prometheus.MustRegister(workerPoolVolumes)
+
+	workerPoolTasks := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers_pool",
+			Name:      "tasks",
+			Help:      "Number of active tasks in the pool",
+		},
+		workerPoolLabels,
+	)
+	prometheus.MustRegister(workerPoolTasks)
+
+	buildsQueued := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "queued",
+			Help:      "Number of builds waiting to be started",
+		},
+		[]string{"team"},
+	)
+	prometheus.MustRegister(buildsQueued)
+
 	// http metrics
 	httpRequestsDuration := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -459,6 +538,26 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(volumesStreamed)
 
+	volumesStreamedAsDelta := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "volumes_streamed_as_delta",
+			Help:      "Total number of volumes streamed from one worker to the other by sending only what differs from an older version on the destination",
+		},
+	)
+	prometheus.MustRegister(volumesStreamedAsDelta)
+
+	resourceCacheCorruptions := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "resource_cache_corruptions",
+			Help:      "Total number of resource cache volumes found not to match the checksum recorded when they were fetched",
+		},
+	)
+	prometheus.MustRegister(resourceCacheCorruptions)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -522,7 +621,17 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		workerUnknownContainers: workerUnknownContainers,
 		workerUnknownVolumes:    workerUnknownVolumes,
 
-		volumesStreamed: volumesStreamed,
+		workerPoolWorkers:      workerPoolWorkers,
+		workerPoolWaitingSteps: workerPoolWaitingSteps,
+		workerPoolContainers:   workerPoolContainers,
+		workerPoolVolumes:      workerPoolVolumes,
+		workerPoolTasks:        workerPoolTasks,
+		buildsQueued:           buildsQueued,
+
+		volumesStreamed:        volumesStreamed,
+		volumesStreamedAsDelta: volumesStreamedAsDelta,
+
+		resourceCacheCorruptions: resourceCacheCorruptions,
 	}
 	go emitter.periodicMetricGC()
 
@@ -592,6 +701,19 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workerTasksMetric(logger, event)
 	case "worker state":
 		emitter.workersRegisteredMetric(logger, event)
+	case "worker pool workers":
+		emitter.workerPoolMetric(emitter.workerPoolWorkers, event)
+	case "worker pool waiting steps":
+		emitter.workerPoolMetric(emitter.workerPoolWaitingSteps, event)
+	case "worker pool containers":
+		emitter.workerPoolMetric(emitter.workerPoolContainers, event)
+	case "worker pool volumes":
+		emitter.workerPoolMetric(emitter.workerPoolVolumes, event)
+	case "worker pool tasks":
+		emitter.workerPoolMetric(emitter.workerPoolTasks, event)
+	case "builds queued":
+		emitter.buildsQueued.
+			WithLabelValues(event.Attributes["team_name"]).Set(event.Value)
 	case "http response time":
 		emitter.httpResponseTimeMetrics(logger, event)
 	case "database queries":
@@ -608,6 +730,10 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.checksEnqueued.Add(event.Value)
 	case "volumes streamed":
 		emitter.volumesStreamed.Add(event.Value)
+	case "volumes streamed as delta":
+		emitter.volumesStreamedAsDelta.Add(event.Value)
+	case "resource cache corruptions":
+		emitter.resourceCacheCorruptions.Add(event.Value)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -852,6 +978,14 @@ func (emitter *PrometheusEmitter) workerTasksMetric(logger lager.Logger, event m
 	emitter.workerTasks.With(emitter.workerTasksLabels[worker][key]).Set(event.Value)
 }
 
+func (emitter *PrometheusEmitter) workerPoolMetric(gauge *prometheus.GaugeVec, event metric.Event) {
+	gauge.WithLabelValues(
+		event.Attributes["platform"],
+		event.Attributes["tags"],
+		event.Attributes["team_name"],
+	).Set(event.Value)
+}
+
 func (emitter *PrometheusEmitter) httpResponseTimeMetrics(logger lager.Logger, event metric.Event) {
 	route, exists := event.Attributes["route"]
 	if !exists {
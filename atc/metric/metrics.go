@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -137,6 +138,20 @@ func (event ArtifactCollectorDuration) Emit(logger lager.Logger) {
 	)
 }
 
+type KeyedCacheCollectorDuration struct {
+	Duration time.Duration
+}
+
+func (event KeyedCacheCollectorDuration) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-keyed-cache-collector-duration"),
+		Event{
+			Name:  "gc: keyed cache collector duration (ms)",
+			Value: ms(event.Duration),
+		},
+	)
+}
+
 type ContainerCollectorDuration struct {
 	Duration time.Duration
 }
@@ -193,19 +208,106 @@ type WorkerContainers struct {
 	Containers int
 	TeamName   string
 	Tags       []string
+
+	// Metrics is the summed up resource usage of the containers, if reported
+	// by the worker.
+	Metrics *atc.WorkerContainerMetrics
 }
 
 func (event WorkerContainers) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"worker":    event.WorkerName,
+		"platform":  event.Platform,
+		"team_name": event.TeamName,
+		"tags":      strings.Join(event.Tags[:], "/"),
+	}
+
 	Metrics.emit(
 		logger.Session("worker-containers"),
 		Event{
-			Name:  "worker containers",
-			Value: float64(event.Containers),
+			Name:       "worker containers",
+			Value:      float64(event.Containers),
+			Attributes: attributes,
+		},
+	)
+
+	if event.Metrics == nil {
+		return
+	}
+
+	for _, usage := range []struct {
+		name  string
+		value uint64
+	}{
+		{"worker containers memory", event.Metrics.MemoryBytes},
+		{"worker containers cpu", event.Metrics.CPUNanoseconds},
+		{"worker containers network rx", event.Metrics.NetworkRxBytes},
+		{"worker containers network tx", event.Metrics.NetworkTxBytes},
+	} {
+		Metrics.emit(
+			logger.Session("worker-containers"),
+			Event{
+				Name:       usage.name,
+				Value:      float64(usage.value),
+				Attributes: attributes,
+			},
+		)
+	}
+}
+
+type WorkerPoolDemand struct {
+	Platform string
+	Tags     []string
+	TeamName string
+
+	Workers      int
+	WaitingSteps int
+	Containers   int
+	Volumes      int
+	Tasks        int
+}
+
+func (event WorkerPoolDemand) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"platform":  event.Platform,
+		"team_name": event.TeamName,
+		"tags":      strings.Join(event.Tags, "/"),
+	}
+
+	for _, demand := range []struct {
+		name  string
+		value int
+	}{
+		{"worker pool workers", event.Workers},
+		{"worker pool waiting steps", event.WaitingSteps},
+		{"worker pool containers", event.Containers},
+		{"worker pool volumes", event.Volumes},
+		{"worker pool tasks", event.Tasks},
+	} {
+		Metrics.emit(
+			logger.Session("worker-pool-demand"),
+			Event{
+				Name:       demand.name,
+				Value:      float64(demand.value),
+				Attributes: attributes,
+			},
+		)
+	}
+}
+
+type BuildsQueued struct {
+	TeamName string
+	Builds   int
+}
+
+func (event BuildsQueued) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("builds-queued"),
+		Event{
+			Name:  "builds queued",
+			Value: float64(event.Builds),
 			Attributes: map[string]string{
-				"worker":    event.WorkerName,
-				"platform":  event.Platform,
 				"team_name": event.TeamName,
-				"tags":      strings.Join(event.Tags[:], "/"),
 			},
 		},
 	)
@@ -1,6 +1,7 @@
 package metric_test
 
 import (
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/metric/metricfakes"
@@ -49,6 +50,86 @@ var _ = Describe("Metrics", func() {
 			Expect(event.Value).To(Equal(float64(1)))
 		})
 	})
+
+	Describe("worker containers metric", func() {
+		var (
+			emitter         *smartFakeEmitter
+			originalMonitor *metric.Monitor
+			event           metric.WorkerContainers
+		)
+
+		BeforeEach(func() {
+			emitter = new(smartFakeEmitter)
+
+			emitterFactory := new(metricfakes.FakeEmitterFactory)
+			emitterFactory.IsConfiguredReturns(true)
+			emitterFactory.NewEmitterReturns(emitter, nil)
+
+			originalMonitor = metric.Metrics
+			metric.Metrics = metric.NewMonitor()
+			metric.Metrics.RegisterEmitter(emitterFactory)
+			metric.Metrics.Initialize(testLogger, "test", map[string]string{}, 1000)
+
+			event = metric.WorkerContainers{
+				WorkerName: "some-worker",
+				Platform:   "linux",
+				Containers: 3,
+				TeamName:   "some-team",
+				Tags:       []string{"some", "tags"},
+			}
+		})
+
+		AfterEach(func() {
+			metric.Metrics = originalMonitor
+		})
+
+		It("emits the number of containers", func() {
+			event.Emit(testLogger)
+
+			Eventually(emitter.EmitCallCount).Should(Equal(1))
+			_, emitted := emitter.EmitArgsForCall(0)
+			Expect(emitted.Name).To(Equal("worker containers"))
+			Expect(emitted.Value).To(Equal(float64(3)))
+			Expect(emitted.Attributes).To(Equal(map[string]string{
+				"worker":    "some-worker",
+				"platform":  "linux",
+				"team_name": "some-team",
+				"tags":      "some/tags",
+			}))
+		})
+
+		Context("when the container metrics are reported", func() {
+			BeforeEach(func() {
+				event.Metrics = &atc.WorkerContainerMetrics{
+					MemoryBytes:    1024,
+					CPUNanoseconds: 2000,
+					NetworkRxBytes: 10,
+					NetworkTxBytes: 20,
+				}
+			})
+
+			It("emits the resource usage of the containers", func() {
+				event.Emit(testLogger)
+
+				Eventually(emitter.EmitCallCount).Should(Equal(5))
+
+				values := map[string]float64{}
+				for i := 0; i < emitter.EmitCallCount(); i++ {
+					_, emitted := emitter.EmitArgsForCall(i)
+					Expect(emitted.Attributes["worker"]).To(Equal("some-worker"))
+					values[emitted.Name] = emitted.Value
+				}
+
+				Expect(values).To(Equal(map[string]float64{
+					"worker containers":            3,
+					"worker containers memory":     1024,
+					"worker containers cpu":        2000,
+					"worker containers network rx": 10,
+					"worker containers network tx": 20,
+				}))
+			})
+		})
+	})
 })
 
 type smartFakeEmitter struct {
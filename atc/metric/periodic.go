@@ -75,6 +75,22 @@ func tick(logger lager.Logger, m *Monitor) {
 		},
 	)
 
+	m.emit(
+		logger.Session("volumes-streamed-as-delta"),
+		Event{
+			Name:  "volumes streamed as delta",
+			Value: m.VolumesStreamedAsDelta.Delta(),
+		},
+	)
+
+	m.emit(
+		logger.Session("resource-cache-corruptions"),
+		Event{
+			Name:  "resource cache corruptions",
+			Value: m.ResourceCacheCorruptions.Delta(),
+		},
+	)
+
 	m.emit(
 		logger.Session("containers-created"),
 		Event{
@@ -0,0 +1,113 @@
+package pins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// NewExpirer constructs a component which removes pins that have passed
+// their expiry. A check is then run for each unpinned resource, with a notice
+// in its build log explaining why the pin went away.
+func NewExpirer(resourceFactory db.ResourceFactory, checkFactory db.CheckFactory) *expirer {
+	return &expirer{
+		resourceFactory: resourceFactory,
+		checkFactory:    checkFactory,
+	}
+}
+
+type expirer struct {
+	resourceFactory db.ResourceFactory
+	checkFactory    db.CheckFactory
+}
+
+func (e *expirer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("end")
+
+	resources, err := e.resourceFactory.ResourcesWithExpiredPins()
+	if err != nil {
+		logger.Error("failed-to-get-resources-with-expired-pins", err)
+		return err
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	resourceTypes, err := e.checkFactory.ResourceTypes()
+	if err != nil {
+		logger.Error("failed-to-get-resource-types", err)
+		return err
+	}
+
+	for _, resource := range resources {
+		e.expire(ctx, resource, resourceTypes)
+	}
+
+	return nil
+}
+
+func (e *expirer) expire(ctx context.Context, resource db.Resource, resourceTypes db.ResourceTypes) {
+	logger := lagerctx.FromContext(ctx).Session("expire", lager.Data{
+		"team":     resource.TeamName(),
+		"pipeline": resource.PipelineName(),
+		"resource": resource.Name(),
+	})
+
+	unpinned, err := resource.UnpinExpiredVersion()
+	if err != nil {
+		logger.Error("failed-to-unpin-expired-version", err)
+		return
+	}
+
+	if !unpinned {
+		// the pin was removed or renewed in the meantime
+		return
+	}
+
+	logger.Info("unpinned-expired-version", lager.Data{
+		"version":   resource.APIPinnedVersion(),
+		"pinned-by": resource.PinnedBy(),
+	})
+
+	build, created, err := e.checkFactory.TryCreateCheck(lagerctx.NewContext(ctx, logger), resource, resourceTypes, nil, true)
+	if err != nil {
+		logger.Error("failed-to-create-check", err)
+		return
+	}
+
+	if !created {
+		logger.Info("check-not-created")
+		return
+	}
+
+	err = build.SaveEvent(event.Log{
+		Time:    time.Now().Unix(),
+		Origin:  event.Origin{ID: event.OriginID(build.PrivatePlan().ID)},
+		Payload: expiryNotice(resource),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-expiry-notice", err)
+	}
+}
+
+func expiryNotice(resource db.Resource) string {
+	version, _ := json.Marshal(resource.APIPinnedVersion())
+
+	notice := fmt.Sprintf("version %s was unpinned because the pin expired at %s", version, resource.PinExpiresAt().Format(time.RFC3339))
+
+	if resource.PinnedBy() != "" {
+		notice += fmt.Sprintf(" (pinned by %s)", resource.PinnedBy())
+	}
+
+	return notice + "\n"
+}
@@ -0,0 +1,158 @@
+package pins_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/pins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Expirer interface {
+	Run(ctx context.Context) error
+}
+
+var _ = Describe("Expirer", func() {
+	var (
+		err error
+
+		fakeResourceFactory *dbfakes.FakeResourceFactory
+		fakeCheckFactory    *dbfakes.FakeCheckFactory
+
+		expirer Expirer
+	)
+
+	BeforeEach(func() {
+		fakeResourceFactory = new(dbfakes.FakeResourceFactory)
+		fakeCheckFactory = new(dbfakes.FakeCheckFactory)
+
+		expirer = pins.NewExpirer(fakeResourceFactory, fakeCheckFactory)
+	})
+
+	JustBeforeEach(func() {
+		err = expirer.Run(context.TODO())
+	})
+
+	Context("when fetching resources with expired pins fails", func() {
+		BeforeEach(func() {
+			fakeResourceFactory.ResourcesWithExpiredPinsReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when there are no expired pins", func() {
+		It("does nothing", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeCheckFactory.ResourceTypesCallCount()).To(BeZero())
+			Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a resource has an expired pin", func() {
+		var (
+			fakeResource      *dbfakes.FakeResource
+			fakeBuild         *dbfakes.FakeBuild
+			fakeResourceTypes db.ResourceTypes
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("some-resource")
+			fakeResource.APIPinnedVersionReturns(atc.Version{"version": "v1"})
+			fakeResource.PinnedByReturns("some-user")
+			fakeResource.PinExpiresAtReturns(time.Date(2021, 2, 1, 12, 0, 0, 0, time.UTC))
+			fakeResourceFactory.ResourcesWithExpiredPinsReturns([]db.Resource{fakeResource}, nil)
+
+			fakeResourceTypes = db.ResourceTypes{new(dbfakes.FakeResourceType)}
+			fakeCheckFactory.ResourceTypesReturns(fakeResourceTypes, nil)
+
+			fakeBuild = new(dbfakes.FakeBuild)
+			fakeBuild.PrivatePlanReturns(atc.Plan{ID: "some-plan"})
+		})
+
+		Context("when fetching resource types fails", func() {
+			BeforeEach(func() {
+				fakeCheckFactory.ResourceTypesReturns(nil, errors.New("nope"))
+			})
+
+			It("errors without unpinning", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(fakeResource.UnpinExpiredVersionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the pin is unpinned", func() {
+			BeforeEach(func() {
+				fakeResource.UnpinExpiredVersionReturns(true, nil)
+				fakeCheckFactory.TryCreateCheckReturns(fakeBuild, true, nil)
+			})
+
+			It("creates a manually triggered check for the resource", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+
+				_, checkable, resourceTypes, from, manuallyTriggered := fakeCheckFactory.TryCreateCheckArgsForCall(0)
+				Expect(checkable).To(Equal(fakeResource))
+				Expect(resourceTypes).To(Equal(fakeResourceTypes))
+				Expect(from).To(BeNil())
+				Expect(manuallyTriggered).To(BeTrue())
+			})
+
+			It("posts a notice to the check build log", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+
+				log := savedEvent.(event.Log)
+				Expect(log.Origin.ID).To(Equal(event.OriginID("some-plan")))
+
+				version, _ := json.Marshal(atc.Version{"version": "v1"})
+				Expect(log.Payload).To(Equal("version " + string(version) + " was unpinned because the pin expired at 2021-02-01T12:00:00Z (pinned by some-user)\n"))
+			})
+		})
+
+		Context("when the pin was renewed in the meantime", func() {
+			BeforeEach(func() {
+				fakeResource.UnpinExpiredVersionReturns(false, nil)
+			})
+
+			It("does not create a check", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when unpinning fails", func() {
+			var otherResource *dbfakes.FakeResource
+
+			BeforeEach(func() {
+				fakeResource.UnpinExpiredVersionReturns(false, errors.New("nope"))
+
+				otherResource = new(dbfakes.FakeResource)
+				otherResource.UnpinExpiredVersionReturns(true, nil)
+				fakeResourceFactory.ResourcesWithExpiredPinsReturns([]db.Resource{fakeResource, otherResource}, nil)
+
+				fakeCheckFactory.TryCreateCheckReturns(fakeBuild, true, nil)
+			})
+
+			It("continues with the other resources", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+
+				_, checkable, _, _, _ := fakeCheckFactory.TryCreateCheckArgsForCall(0)
+				Expect(checkable).To(Equal(otherResource))
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package pins_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPins(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pins Suite")
+}
@@ -285,6 +285,10 @@ type TaskPlan struct {
 	// Params to set in the task's environment.
 	Params TaskEnv `json:"params,omitempty"`
 
+	// Files to place in the task's secrets directory, on top of the ones in
+	// its config.
+	SecretFiles map[string]string `json:"secret_files,omitempty"`
+
 	// Remap inputs and output artifacts from task names to other names in the
 	// build plan.
 	InputMapping  map[string]string `json:"input_mapping,omitempty"`
@@ -294,6 +298,10 @@ type TaskPlan struct {
 	// image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// How long to keep the task's outputs around for download after the task
+	// has run, on top of however long its container is kept.
+	KeepOutputs string `json:"keep_outputs,omitempty"`
+
 	// Resource types to have available for use when fetching the task's image.
 	//
 	// XXX(check-refactor): Eliminating this would be great - if we can replace
@@ -0,0 +1,591 @@
+package prewarm
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/vars"
+)
+
+const (
+	// PriorityLow only pre-warms images while no steps are waiting for a
+	// worker, so that pre-warming never competes with builds for capacity.
+	PriorityLow = "low"
+
+	// PriorityHigh pre-warms images regardless of the load on the workers.
+	PriorityHigh = "high"
+)
+
+// prewarmTimeout bounds fetching an image onto the chosen workers. Once it
+// passes, the image's containers can be collected and, if the fetch did not
+// finish, another ATC may try again.
+const prewarmTimeout = 15 * time.Minute
+
+// NewPrewarmer constructs a component which fetches the latest version of
+// every image used by a pipeline's tasks onto a number of compatible workers
+// before any build needs it.
+//
+// Which versions have been pre-warmed is kept in the database, so that every
+// ATC agrees on it across restarts. The component runs whenever a new version
+// of a pre-warmed image is saved, and on its interval to pick up pins and
+// config changes.
+//
+// Images are fetched by running the resource's `get` directly on each chosen
+// worker, without creating a build. The resource cache is kept around for as
+// long as the version is the one pre-warmed. The same workers are chosen for
+// an image every time, as long as they are around, so that new versions land
+// where the previous ones were warmed.
+//
+// Images of custom resource types are not pre-warmed, as fetching them would
+// first require fetching the type's own image.
+func NewPrewarmer(
+	pipelineFactory db.PipelineFactory,
+	prewarmedImageFactory db.PrewarmedImageFactory,
+	resourceConfigFactory db.ResourceConfigFactory,
+	resourceCacheFactory db.ResourceCacheFactory,
+	waitingStepFactory db.WaitingStepFactory,
+	workerProvider worker.WorkerProvider,
+	resourceFactory resource.ResourceFactory,
+	secrets creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	leasedVars *creds.LeasedVars,
+	workers int,
+	priority string,
+) *prewarmer {
+	return &prewarmer{
+		pipelineFactory:       pipelineFactory,
+		prewarmedImageFactory: prewarmedImageFactory,
+		resourceConfigFactory: resourceConfigFactory,
+		resourceCacheFactory:  resourceCacheFactory,
+		waitingStepFactory:    waitingStepFactory,
+		workerProvider:        workerProvider,
+		resourceFactory:       resourceFactory,
+		secrets:               secrets,
+		varSourcePool:         varSourcePool,
+		leasedVars:            leasedVars,
+		workers:               workers,
+		priority:              priority,
+	}
+}
+
+type prewarmer struct {
+	pipelineFactory       db.PipelineFactory
+	prewarmedImageFactory db.PrewarmedImageFactory
+	resourceConfigFactory db.ResourceConfigFactory
+	resourceCacheFactory  db.ResourceCacheFactory
+	waitingStepFactory    db.WaitingStepFactory
+	workerProvider        worker.WorkerProvider
+	resourceFactory       resource.ResourceFactory
+	secrets               creds.Secrets
+	varSourcePool         creds.VarSourcePool
+	leasedVars            *creds.LeasedVars
+	workers               int
+	priority              string
+}
+
+// pipelineImage is an image used by one of a pipeline's tasks, either through
+// `image_resource` or through `image:` pointing at a `get` step.
+type pipelineImage struct {
+	image atc.ImageResource
+
+	// set when the image comes from a pipeline resource, whose scope is used
+	// to look up the latest version
+	resource string
+}
+
+func (p *prewarmer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("end")
+
+	if p.priority == PriorityLow {
+		waiting, err := p.waitingStepFactory.WaitingSteps()
+		if err != nil {
+			logger.Error("failed-to-get-waiting-steps", err)
+			return err
+		}
+
+		if len(waiting) > 0 {
+			logger.Debug("skipping-while-steps-are-waiting-for-workers")
+			return nil
+		}
+	}
+
+	workers, err := p.workerProvider.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+		return err
+	}
+
+	if len(workers) == 0 {
+		return nil
+	}
+
+	pipelines, err := p.pipelineFactory.AllPipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.Paused() || pipeline.Archived() {
+			continue
+		}
+
+		plogger := logger.Session("prewarm", lager.Data{
+			"team":     pipeline.TeamName(),
+			"pipeline": pipeline.Name(),
+		})
+
+		err := p.prewarmPipeline(plogger, pipeline, workers)
+		if err != nil {
+			plogger.Error("failed-to-prewarm-pipeline", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *prewarmer) prewarmPipeline(logger lager.Logger, pipeline db.Pipeline, workers []worker.Worker) error {
+	config, err := pipeline.Config()
+	if err != nil {
+		return err
+	}
+
+	images := map[string]pipelineImage{}
+	refs := []db.PrewarmedImageRef{}
+	for _, image := range pipelineImages(config) {
+		if _, custom := config.ResourceTypes.Lookup(image.image.Type); custom {
+			continue
+		}
+
+		image.image.ApplySourceDefaults(nil)
+
+		key, err := imageKey(pipeline.ID(), image)
+		if err != nil {
+			return err
+		}
+
+		images[key] = image
+		refs = append(refs, db.PrewarmedImageRef{
+			Key:      key,
+			Resource: image.resource,
+		})
+	}
+
+	states, err := p.prewarmedImageFactory.Sync(pipeline.ID(), refs)
+	if err != nil {
+		return err
+	}
+
+	// credentials are only looked up once an image needs them
+	var variables vars.Variables
+	lookupVariables := func() (vars.Variables, error) {
+		if variables != nil {
+			return variables, nil
+		}
+
+		pipelineVars, err := pipeline.Variables(logger, p.secrets, p.varSourcePool)
+		if err != nil {
+			return nil, err
+		}
+
+		// the leases of dynamic secrets are only kept track of by builds
+		variables = creds.NewUnleasedVariables(logger, p.leasedVars, pipelineVars, pipeline.TeamName(), pipeline.Name())
+		return variables, nil
+	}
+
+	for _, state := range states {
+		image, found := images[state.Key]
+		if !found {
+			continue
+		}
+
+		err := p.prewarmImage(logger, pipeline, image, state, lookupVariables, workers)
+		if errors.As(err, &creds.LeasedVarError{}) {
+			logger.Info("skipping-image-using-leased-var", lager.Data{"type": image.image.Type, "error": err.Error()})
+			continue
+		}
+
+		if err != nil {
+			logger.Error("failed-to-prewarm-image", err, lager.Data{"type": image.image.Type})
+		}
+	}
+
+	return nil
+}
+
+func (p *prewarmer) prewarmImage(
+	logger lager.Logger,
+	pipeline db.Pipeline,
+	image pipelineImage,
+	state db.PrewarmedImage,
+	lookupVariables func() (vars.Variables, error),
+	workers []worker.Worker,
+) error {
+	version := image.image.Version
+	if version == nil {
+		version = state.PinnedVersion
+	}
+
+	if version == nil {
+		version = state.LatestVersion
+	}
+
+	if version == nil && state.ResourceConfigScopeID == 0 && image.resource == "" {
+		var err error
+		version, err = p.lookUpScope(pipeline, image, state, lookupVariables)
+		if err != nil {
+			return err
+		}
+	}
+
+	if version == nil {
+		return nil
+	}
+
+	versionMD5, err := versionMD5(version)
+	if err != nil {
+		return err
+	}
+
+	if state.Warming || state.WarmedVersionMD5 == versionMD5 {
+		return nil
+	}
+
+	chosen := p.chooseWorkers(logger, state.Key, worker.WorkerSpec{
+		TeamID:       pipeline.TeamID(),
+		ResourceType: image.image.Type,
+		Tags:         image.image.Tags,
+	}, workers)
+	if len(chosen) == 0 {
+		return nil
+	}
+
+	variables, err := lookupVariables()
+	if err != nil {
+		return err
+	}
+
+	source, err := creds.NewSource(variables, image.image.Source).Evaluate()
+	if err != nil {
+		return err
+	}
+
+	params, err := creds.NewParams(variables, image.image.Params).Evaluate()
+	if err != nil {
+		return err
+	}
+
+	started, err := p.prewarmedImageFactory.StartWarming(state.ID, versionMD5, prewarmTimeout)
+	if err != nil {
+		return err
+	}
+
+	if !started {
+		return nil
+	}
+
+	resourceCache, err := p.resourceCacheFactory.FindOrCreateResourceCache(
+		db.ForPrewarmedImage(state.ID),
+		image.image.Type,
+		version,
+		source,
+		params,
+		nil,
+	)
+	if err != nil {
+		_ = p.prewarmedImageFactory.FinishWarming(state.ID, false)
+		return err
+	}
+
+	logger.Info("prewarming-image", lager.Data{
+		"type":    image.image.Type,
+		"version": version,
+		"workers": len(chosen),
+	})
+
+	go p.fetch(logger, pipeline, image, state.ID, resourceCache, p.resourceFactory.NewResource(source, params, version), chosen)
+
+	return nil
+}
+
+// lookUpScope finds the scope holding the versions of an image configured
+// through `image_resource`, recording it so that new versions saved to it
+// trigger pre-warming. It returns the latest version in the scope, if any.
+func (p *prewarmer) lookUpScope(
+	pipeline db.Pipeline,
+	image pipelineImage,
+	state db.PrewarmedImage,
+	lookupVariables func() (vars.Variables, error),
+) (atc.Version, error) {
+	variables, err := lookupVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := creds.NewSource(variables, image.image.Source).Evaluate()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceConfig, err := p.resourceConfigFactory.FindOrCreateResourceConfig(image.image.Type, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := resourceConfig.FindOrCreateScope(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.prewarmedImageFactory.SetResourceConfigScope(state.ID, scope.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	latest, found, err := scope.LatestVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return atc.Version(latest.Version()), nil
+}
+
+// fetch runs the image's `get` on each of the workers, recording whether it
+// succeeded on all of them once done.
+func (p *prewarmer) fetch(
+	logger lager.Logger,
+	pipeline db.Pipeline,
+	image pipelineImage,
+	prewarmedImageID int,
+	resourceCache db.UsedResourceCache,
+	imageResource resource.Resource,
+	workers []worker.Worker,
+) {
+	ctx, cancel := context.WithTimeout(lagerctx.NewContext(context.Background(), logger), prewarmTimeout)
+	defer cancel()
+
+	name := image.image.Name
+	if name == "" {
+		name = "image"
+	}
+
+	var failed int32
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Add(1)
+
+		go func(w worker.Worker) {
+			defer wg.Done()
+
+			wlogger := logger.Session("fetch", lager.Data{"worker": w.Name()})
+
+			result, err := worker.NewClient(w).RunGetStep(
+				lagerctx.NewContext(ctx, wlogger),
+				db.NewPrewarmedImageContainerOwner(prewarmedImageID, pipeline.TeamID()),
+				worker.ContainerSpec{
+					ImageSpec: worker.ImageSpec{
+						ResourceType: image.image.Type,
+					},
+					TeamID: pipeline.TeamID(),
+					Type:   db.ContainerTypeGet,
+				},
+				db.ContainerMetadata{
+					Type:         db.ContainerTypeGet,
+					PipelineID:   pipeline.ID(),
+					PipelineName: pipeline.Name(),
+					StepName:     name,
+				},
+				runtime.ProcessSpec{
+					Path:         "/opt/resource/in",
+					Args:         []string{resource.ResourcesDir("get")},
+					StdoutWriter: ioutil.Discard,
+					StderrWriter: ioutil.Discard,
+				},
+				noopStartingEventDelegate{},
+				resourceCache,
+				imageResource,
+			)
+			if err != nil {
+				wlogger.Error("failed-to-fetch-image", err)
+				atomic.StoreInt32(&failed, 1)
+				return
+			}
+
+			if result.ExitStatus != 0 {
+				wlogger.Info("fetching-image-failed", lager.Data{"exit-status": result.ExitStatus})
+				atomic.StoreInt32(&failed, 1)
+			}
+		}(w)
+	}
+
+	wg.Wait()
+
+	err := p.prewarmedImageFactory.FinishWarming(prewarmedImageID, atomic.LoadInt32(&failed) == 0)
+	if err != nil {
+		logger.Error("failed-to-finish-prewarming", err)
+	}
+}
+
+type noopStartingEventDelegate struct{}
+
+func (noopStartingEventDelegate) Starting(lager.Logger) {}
+
+// chooseWorkers picks the configured number of workers satisfying the spec
+// using rendezvous hashing, so that an image keeps landing on the same
+// workers as the pool changes.
+func (p *prewarmer) chooseWorkers(logger lager.Logger, key string, spec worker.WorkerSpec, workers []worker.Worker) []worker.Worker {
+	type scored struct {
+		worker worker.Worker
+		score  uint64
+	}
+
+	var candidates []scored
+	for _, w := range workers {
+		if !w.Satisfies(logger, spec) {
+			continue
+		}
+
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(key + "/" + w.Name()))
+
+		candidates = append(candidates, scored{w, hash.Sum64()})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	if len(candidates) > p.workers {
+		candidates = candidates[:p.workers]
+	}
+
+	chosen := make([]worker.Worker, len(candidates))
+	for i, c := range candidates {
+		chosen[i] = c.worker
+	}
+
+	return chosen
+}
+
+// pipelineImages finds the images used by the tasks of every job in the
+// pipeline. Tasks whose config is loaded from a file are skipped, as their
+// image is not known until the build runs.
+func pipelineImages(config atc.Config) []pipelineImage {
+	var images []pipelineImage
+	seen := map[string]bool{}
+
+	add := func(image pipelineImage) {
+		key, err := json.Marshal(struct {
+			Image    atc.ImageResource
+			Resource string
+		}{image.image, image.resource})
+		if err != nil || seen[string(key)] {
+			return
+		}
+
+		seen[string(key)] = true
+		images = append(images, image)
+	}
+
+	for _, job := range config.Jobs {
+		gets := map[string]*atc.GetStep{}
+		var tasks []*atc.TaskStep
+
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				gets[step.Name] = step
+				return nil
+			},
+			OnTask: func(step *atc.TaskStep) error {
+				tasks = append(tasks, step)
+				return nil
+			},
+		})
+
+		for _, task := range tasks {
+			if task.ImageArtifactName != "" {
+				get, found := gets[task.ImageArtifactName]
+				if !found {
+					continue
+				}
+
+				resource, found := config.Resources.Lookup(get.ResourceName())
+				if !found {
+					continue
+				}
+
+				image := atc.ImageResource{
+					Name:   resource.Name,
+					Type:   resource.Type,
+					Source: resource.Source,
+					Params: get.Params,
+					Tags:   get.Tags,
+				}
+
+				if get.Version != nil && get.Version.Pinned != nil {
+					image.Version = get.Version.Pinned
+				}
+
+				add(pipelineImage{image: image, resource: resource.Name})
+				continue
+			}
+
+			if task.Config != nil && task.Config.ImageResource != nil {
+				add(pipelineImage{image: *task.Config.ImageResource})
+			}
+		}
+	}
+
+	return images
+}
+
+func imageKey(pipelineID int, image pipelineImage) (string, error) {
+	payload, err := json.Marshal(struct {
+		PipelineID int         `json:"pipeline_id"`
+		Resource   string      `json:"resource"`
+		Type       string      `json:"type"`
+		Source     atc.Source  `json:"source"`
+		Params     atc.Params  `json:"params"`
+		Version    atc.Version `json:"version"`
+		Tags       atc.Tags    `json:"tags"`
+	}{pipelineID, image.resource, image.image.Type, image.image.Source, image.image.Params, image.image.Version, image.image.Tags})
+	if err != nil {
+		return "", fmt.Errorf("marshal image: %w", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), nil
+}
+
+func versionMD5(version atc.Version) (string, error) {
+	payload, err := json.Marshal(version)
+	if err != nil {
+		return "", fmt.Errorf("marshal version: %w", err)
+	}
+
+	return fmt.Sprintf("%x", md5.Sum(payload)), nil
+}
@@ -0,0 +1,478 @@
+package prewarm_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/prewarm"
+	"github.com/concourse/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Prewarmer interface {
+	Run(ctx context.Context) error
+}
+
+var _ = Describe("Prewarmer", func() {
+	var (
+		err error
+
+		fakePipelineFactory       *dbfakes.FakePipelineFactory
+		fakePrewarmedImageFactory *dbfakes.FakePrewarmedImageFactory
+		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
+		fakeResourceCacheFactory  *dbfakes.FakeResourceCacheFactory
+		fakeWaitingStepFactory    *dbfakes.FakeWaitingStepFactory
+		fakeWorkerProvider        *workerfakes.FakeWorkerProvider
+		fakeResourceFactory       *resourcefakes.FakeResourceFactory
+		leasedVars                *creds.LeasedVars
+
+		fakePipeline       *dbfakes.FakePipeline
+		fakeResourceConfig *dbfakes.FakeResourceConfig
+		fakeScope          *dbfakes.FakeResourceConfigScope
+		fakeVersion        *dbfakes.FakeResourceConfigVersion
+		fakeResourceCache  *dbfakes.FakeUsedResourceCache
+
+		state    db.PrewarmedImage
+		workers  []worker.Worker
+		priority string
+
+		prewarmer Prewarmer
+		ctx       context.Context
+	)
+
+	newWorker := func(name string, satisfies bool) *workerfakes.FakeWorker {
+		w := new(workerfakes.FakeWorker)
+		w.NameReturns(name)
+		w.SatisfiesReturns(satisfies)
+		return w
+	}
+
+	fetchedOn := func() []string {
+		var names []string
+		for _, w := range workers {
+			if w.(*workerfakes.FakeWorker).FetchCallCount() > 0 {
+				names = append(names, w.Name())
+			}
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		fakePrewarmedImageFactory = new(dbfakes.FakePrewarmedImageFactory)
+		fakeResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
+		fakeResourceCacheFactory = new(dbfakes.FakeResourceCacheFactory)
+		leasedVars = creds.NewLeasedVars()
+		fakeWaitingStepFactory = new(dbfakes.FakeWaitingStepFactory)
+		fakeWorkerProvider = new(workerfakes.FakeWorkerProvider)
+		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
+		fakeResourceFactory.NewResourceReturns(new(resourcefakes.FakeResource))
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.IDReturns(1)
+		fakePipeline.TeamIDReturns(2)
+		fakePipeline.VariablesReturns(vars.StaticVariables{"image-repo": "some-repo"}, nil)
+		fakePipeline.ConfigReturns(atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.TaskStep{
+								Name: "some-task",
+								Config: &atc.TaskConfig{
+									ImageResource: &atc.ImageResource{
+										Type:   "registry-image",
+										Source: atc.Source{"repository": "((image-repo))"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, nil)
+		fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+
+		state = db.PrewarmedImage{ID: 7}
+		fakePrewarmedImageFactory.SyncStub = func(_ int, refs []db.PrewarmedImageRef) ([]db.PrewarmedImage, error) {
+			var states []db.PrewarmedImage
+			for _, ref := range refs {
+				s := state
+				s.Key = ref.Key
+				states = append(states, s)
+			}
+			return states, nil
+		}
+		fakePrewarmedImageFactory.StartWarmingReturns(true, nil)
+
+		fakeVersion = new(dbfakes.FakeResourceConfigVersion)
+		fakeVersion.VersionReturns(db.Version{"digest": "sha256:some-digest"})
+
+		fakeScope = new(dbfakes.FakeResourceConfigScope)
+		fakeScope.IDReturns(3)
+		fakeScope.LatestVersionReturns(fakeVersion, true, nil)
+
+		fakeResourceConfig = new(dbfakes.FakeResourceConfig)
+		fakeResourceConfig.FindOrCreateScopeReturns(fakeScope, nil)
+		fakeResourceConfigFactory.FindOrCreateResourceConfigReturns(fakeResourceConfig, nil)
+
+		fakeResourceCache = new(dbfakes.FakeUsedResourceCache)
+		fakeResourceCacheFactory.FindOrCreateResourceCacheReturns(fakeResourceCache, nil)
+
+		workers = []worker.Worker{
+			newWorker("worker-1", true),
+			newWorker("worker-2", true),
+			newWorker("worker-3", true),
+			newWorker("incompatible-worker", false),
+		}
+
+		priority = prewarm.PriorityLow
+
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+	})
+
+	JustBeforeEach(func() {
+		fakeWorkerProvider.RunningWorkersReturns(workers, nil)
+
+		prewarmer = prewarm.NewPrewarmer(
+			fakePipelineFactory,
+			fakePrewarmedImageFactory,
+			fakeResourceConfigFactory,
+			fakeResourceCacheFactory,
+			fakeWaitingStepFactory,
+			fakeWorkerProvider,
+			fakeResourceFactory,
+			new(credsfakes.FakeSecrets),
+			new(credsfakes.FakeVarSourcePool),
+			leasedVars,
+			2,
+			priority,
+		)
+
+		err = prewarmer.Run(ctx)
+	})
+
+	It("succeeds", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("records the pipeline's images", func() {
+		Expect(fakePrewarmedImageFactory.SyncCallCount()).To(Equal(1))
+		pipelineID, refs := fakePrewarmedImageFactory.SyncArgsForCall(0)
+		Expect(pipelineID).To(Equal(1))
+		Expect(refs).To(HaveLen(1))
+		Expect(refs[0].Resource).To(BeEmpty())
+	})
+
+	It("looks up and records the image's scope with evaluated credentials", func() {
+		Expect(fakeResourceConfigFactory.FindOrCreateResourceConfigCallCount()).To(Equal(1))
+		resourceType, source, _ := fakeResourceConfigFactory.FindOrCreateResourceConfigArgsForCall(0)
+		Expect(resourceType).To(Equal("registry-image"))
+		Expect(source).To(Equal(atc.Source{"repository": "some-repo"}))
+
+		Expect(fakeResourceConfig.FindOrCreateScopeCallCount()).To(Equal(1))
+		Expect(fakeResourceConfig.FindOrCreateScopeArgsForCall(0)).To(BeNil())
+
+		Expect(fakePrewarmedImageFactory.SetResourceConfigScopeCallCount()).To(Equal(1))
+		id, scopeID := fakePrewarmedImageFactory.SetResourceConfigScopeArgsForCall(0)
+		Expect(id).To(Equal(7))
+		Expect(scopeID).To(Equal(3))
+	})
+
+	It("claims pre-warming the latest version", func() {
+		Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(Equal(1))
+		id, versionMD5, _ := fakePrewarmedImageFactory.StartWarmingArgsForCall(0)
+		Expect(id).To(Equal(7))
+		Expect(versionMD5).ToNot(BeEmpty())
+	})
+
+	It("keeps the resource cache around for the pre-warmed image", func() {
+		Expect(fakeResourceCacheFactory.FindOrCreateResourceCacheCallCount()).To(Equal(1))
+		user, resourceType, version, source, _, _ := fakeResourceCacheFactory.FindOrCreateResourceCacheArgsForCall(0)
+		Expect(user).To(Equal(db.ForPrewarmedImage(7)))
+		Expect(resourceType).To(Equal("registry-image"))
+		Expect(version).To(Equal(atc.Version{"digest": "sha256:some-digest"}))
+		Expect(source).To(Equal(atc.Source{"repository": "some-repo"}))
+	})
+
+	It("fetches the latest version on the configured number of compatible workers", func() {
+		Eventually(fakePrewarmedImageFactory.FinishWarmingCallCount).Should(Equal(1))
+
+		Expect(fetchedOn()).To(HaveLen(2))
+		Expect(fetchedOn()).ToNot(ContainElement("incompatible-worker"))
+
+		id, succeeded := fakePrewarmedImageFactory.FinishWarmingArgsForCall(0)
+		Expect(id).To(Equal(7))
+		Expect(succeeded).To(BeTrue())
+
+		Expect(fakeResourceFactory.NewResourceCallCount()).To(Equal(1))
+		source, _, version := fakeResourceFactory.NewResourceArgsForCall(0)
+		Expect(source).To(Equal(atc.Source{"repository": "some-repo"}))
+		Expect(version).To(Equal(atc.Version{"digest": "sha256:some-digest"}))
+	})
+
+	It("fetches the image in containers owned by the pre-warmed image", func() {
+		Eventually(fakePrewarmedImageFactory.FinishWarmingCallCount).Should(Equal(1))
+
+		for _, w := range workers {
+			fakeWorker := w.(*workerfakes.FakeWorker)
+			if fakeWorker.FetchCallCount() == 0 {
+				continue
+			}
+
+			_, _, metadata, _, spec, _, _, owner, cache, _ := fakeWorker.FetchArgsForCall(0)
+			Expect(owner).To(Equal(db.NewPrewarmedImageContainerOwner(7, 2)))
+			Expect(metadata.Type).To(Equal(db.ContainerTypeGet))
+			Expect(spec.ImageSpec.ResourceType).To(Equal("registry-image"))
+			Expect(cache).To(Equal(fakeResourceCache))
+		}
+	})
+
+	It("looks for workers satisfying the image's requirements", func() {
+		w := workers[0].(*workerfakes.FakeWorker)
+		_, spec := w.SatisfiesArgsForCall(0)
+		Expect(spec).To(Equal(worker.WorkerSpec{
+			TeamID:       2,
+			ResourceType: "registry-image",
+		}))
+	})
+
+	Context("when fetching fails on a worker", func() {
+		BeforeEach(func() {
+			for _, w := range workers {
+				w.(*workerfakes.FakeWorker).FetchReturns(worker.GetResult{ExitStatus: 1}, nil, nil)
+			}
+		})
+
+		It("records that pre-warming failed", func() {
+			Eventually(fakePrewarmedImageFactory.FinishWarmingCallCount).Should(Equal(1))
+			_, succeeded := fakePrewarmedImageFactory.FinishWarmingArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the image's scope is known", func() {
+		BeforeEach(func() {
+			state.ResourceConfigScopeID = 3
+			state.LatestVersion = atc.Version{"digest": "sha256:latest"}
+		})
+
+		It("uses the latest version without looking up credentials or the scope", func() {
+			Expect(fakeResourceConfigFactory.FindOrCreateResourceConfigCallCount()).To(BeZero())
+
+			_, _, version, _, _, _ := fakeResourceCacheFactory.FindOrCreateResourceCacheArgsForCall(0)
+			Expect(version).To(Equal(atc.Version{"digest": "sha256:latest"}))
+		})
+
+		Context("when run again once the version has been pre-warmed", func() {
+			BeforeEach(func() {
+				fakePrewarmedImageFactory.StartWarmingStub = func(_ int, versionMD5 string, _ time.Duration) (bool, error) {
+					state.WarmedVersionMD5 = versionMD5
+					return true, nil
+				}
+			})
+
+			It("does not look up credentials or pre-warm it again", func() {
+				Expect(prewarmer.Run(ctx)).To(Succeed())
+
+				Expect(fakePipeline.VariablesCallCount()).To(Equal(1))
+				Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the image is being pre-warmed", func() {
+			BeforeEach(func() {
+				state.Warming = true
+			})
+
+			It("does not pre-warm it", func() {
+				Expect(fakePipeline.VariablesCallCount()).To(BeZero())
+				Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the image uses a var known to be backed by a lease", func() {
+		BeforeEach(func() {
+			fakePipeline.TeamNameReturns("some-team")
+			fakePipeline.NameReturns("some-pipeline")
+
+			leasedVars.Record("some-team", "some-pipeline", vars.Reference{Path: "image-repo"})
+		})
+
+		It("skips the image without failing", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeResourceConfigFactory.FindOrCreateResourceConfigCallCount()).To(BeZero())
+			Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when another ATC claimed pre-warming the version", func() {
+		BeforeEach(func() {
+			fakePrewarmedImageFactory.StartWarmingReturns(false, nil)
+		})
+
+		It("does not fetch it", func() {
+			Expect(fakeResourceCacheFactory.FindOrCreateResourceCacheCallCount()).To(BeZero())
+			Consistently(fetchedOn).Should(BeEmpty())
+		})
+	})
+
+	Context("when the image has no versions yet", func() {
+		BeforeEach(func() {
+			fakeScope.LatestVersionReturns(nil, false, nil)
+		})
+
+		It("does not pre-warm it", func() {
+			Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when no workers are compatible", func() {
+		BeforeEach(func() {
+			workers = []worker.Worker{newWorker("incompatible-worker", false)}
+		})
+
+		It("does not pre-warm it", func() {
+			Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the pipeline is paused", func() {
+		BeforeEach(func() {
+			fakePipeline.PausedReturns(true)
+		})
+
+		It("does not pre-warm it", func() {
+			Expect(fakePrewarmedImageFactory.SyncCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the image is of a custom resource type", func() {
+		BeforeEach(func() {
+			config, _ := fakePipeline.Config()
+			config.ResourceTypes = atc.ResourceTypes{{Name: "registry-image", Type: "docker-image"}}
+			fakePipeline.ConfigReturns(config, nil)
+		})
+
+		It("does not pre-warm it", func() {
+			_, refs := fakePrewarmedImageFactory.SyncArgsForCall(0)
+			Expect(refs).To(BeEmpty())
+		})
+	})
+
+	Context("when a task uses an image fetched by a get step", func() {
+		BeforeEach(func() {
+			fakePipeline.ConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-image",
+						Type:   "registry-image",
+						Source: atc.Source{"repository": "some-repo"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.GetStep{
+									Name:     "image",
+									Resource: "some-image",
+									Params:   atc.Params{"format": "oci"},
+								},
+							},
+							{
+								Config: &atc.TaskStep{
+									Name:              "some-task",
+									ConfigPath:        "some/task.yml",
+									ImageArtifactName: "image",
+								},
+							},
+						},
+					},
+				},
+			}, nil)
+
+			state.ResourceConfigScopeID = 3
+			state.LatestVersion = atc.Version{"digest": "sha256:latest"}
+		})
+
+		It("records the image as fetched from the resource", func() {
+			_, refs := fakePrewarmedImageFactory.SyncArgsForCall(0)
+			Expect(refs).To(HaveLen(1))
+			Expect(refs[0].Resource).To(Equal("some-image"))
+		})
+
+		It("fetches it with the get step's params", func() {
+			_, _, _, _, params, _ := fakeResourceCacheFactory.FindOrCreateResourceCacheArgsForCall(0)
+			Expect(params).To(Equal(atc.Params{"format": "oci"}))
+		})
+
+		Context("when the resource is pinned", func() {
+			BeforeEach(func() {
+				state.PinnedVersion = atc.Version{"digest": "sha256:pinned"}
+			})
+
+			It("warms the pinned version", func() {
+				_, _, version, _, _, _ := fakeResourceCacheFactory.FindOrCreateResourceCacheArgsForCall(0)
+				Expect(version).To(Equal(atc.Version{"digest": "sha256:pinned"}))
+			})
+		})
+
+		Context("when the resource has not been checked yet", func() {
+			BeforeEach(func() {
+				state.ResourceConfigScopeID = 0
+				state.LatestVersion = nil
+			})
+
+			It("waits for it to be checked", func() {
+				Expect(fakeResourceConfigFactory.FindOrCreateResourceConfigCallCount()).To(BeZero())
+				Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when steps are waiting for workers", func() {
+		BeforeEach(func() {
+			fakeWaitingStepFactory.WaitingStepsReturns([]db.WaitingSteps{{Count: 1}}, nil)
+		})
+
+		It("does not pre-warm with low priority", func() {
+			Expect(fakePipelineFactory.AllPipelinesCallCount()).To(BeZero())
+		})
+
+		Context("with high priority", func() {
+			BeforeEach(func() {
+				priority = prewarm.PriorityHigh
+			})
+
+			It("pre-warms anyway", func() {
+				Expect(fakePrewarmedImageFactory.StartWarmingCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when getting the pipelines fails", func() {
+		BeforeEach(func() {
+			fakePipelineFactory.AllPipelinesReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
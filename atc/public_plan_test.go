@@ -74,8 +74,10 @@ var _ = Describe("Plan", func() {
 								Tags:       atc.Tags{"tags"},
 								ConfigPath: "some/config/path.yml",
 								Config: &atc.TaskConfig{
-									Params: atc.TaskEnv{"some": "secret"},
+									Params:      atc.TaskEnv{"some": "secret"},
+									SecretFiles: map[string]string{"key.pem": "secret"},
 								},
+								SecretFiles: map[string]string{"other.pem": "secret"},
 							},
 						},
 
@@ -3,3 +3,11 @@ package atc
 type SetPinCommentRequestBody struct {
 	PinComment string `json:"pin_comment"`
 }
+
+type PinVersionRequestBody struct {
+	PinComment string `json:"pin_comment,omitempty"`
+
+	// ExpiresIn is a duration (e.g. "4h") after which the version is
+	// automatically unpinned. The pin never expires if it is empty.
+	ExpiresIn string `json:"expires_in,omitempty"`
+}
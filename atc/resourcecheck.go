@@ -3,3 +3,14 @@ package atc
 type CheckRequestBody struct {
 	From Version `json:"from"`
 }
+
+type ResourceCheck struct {
+	ID            int         `json:"id"`
+	Status        BuildStatus `json:"status"`
+	StartTime     int64       `json:"start_time,omitempty"`
+	EndTime       int64       `json:"end_time,omitempty"`
+	Duration      int64       `json:"duration,omitempty"`
+	VersionsFound int         `json:"versions_found"`
+	LatestVersion Version     `json:"latest_version,omitempty"`
+	EventsURL     string      `json:"events_url"`
+}
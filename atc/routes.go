@@ -14,6 +14,7 @@ const (
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	GetBuildStepOutput  = "GetBuildStepOutput"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -31,6 +32,8 @@ const (
 	MainJobBadge   = "MainJobBadge"
 
 	ClearTaskCache = "ClearTaskCache"
+	ListJobCaches  = "ListJobCaches"
+	ClearJobCaches = "ClearJobCaches"
 
 	ListAllResources     = "ListAllResources"
 	ListResources        = "ListResources"
@@ -39,12 +42,14 @@ const (
 	CheckResource        = "CheckResource"
 	CheckResourceWebHook = "CheckResourceWebHook"
 	CheckResourceType    = "CheckResourceType"
+	ListResourceChecks   = "ListResourceChecks"
 
 	ListResourceVersions          = "ListResourceVersions"
 	GetResourceVersion            = "GetResourceVersion"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
 	PinResourceVersion            = "PinResourceVersion"
+	PromoteResourceVersion        = "PromoteResourceVersion"
 	UnpinResource                 = "UnpinResource"
 	SetPinCommentOnResource       = "SetPinCommentOnResource"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
@@ -68,13 +73,24 @@ const (
 	CreatePipelineBuild = "CreatePipelineBuild"
 	PipelineBadge       = "PipelineBadge"
 
-	RegisterWorker  = "RegisterWorker"
-	LandWorker      = "LandWorker"
-	RetireWorker    = "RetireWorker"
-	PruneWorker     = "PruneWorker"
-	HeartbeatWorker = "HeartbeatWorker"
-	ListWorkers     = "ListWorkers"
-	DeleteWorker    = "DeleteWorker"
+	RegisterWorker   = "RegisterWorker"
+	LandWorker       = "LandWorker"
+	RetireWorker     = "RetireWorker"
+	EvictWorker      = "EvictWorker"
+	PruneWorker      = "PruneWorker"
+	QuarantineWorker = "QuarantineWorker"
+	ReleaseWorker    = "ReleaseWorker"
+	HeartbeatWorker  = "HeartbeatWorker"
+	ListWorkers      = "ListWorkers"
+	GetWorkerDemand  = "GetWorkerDemand"
+	DeleteWorker     = "DeleteWorker"
+
+	CreateWorkerEnrollmentToken = "CreateWorkerEnrollmentToken"
+	ListWorkerKeys              = "ListWorkerKeys"
+	RevokeWorkerKey             = "RevokeWorkerKey"
+	EnrollWorkerKey             = "EnrollWorkerKey"
+	AuthorizeWorkerKey          = "AuthorizeWorkerKey"
+	UseWorkerKey                = "UseWorkerKey"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -83,6 +99,10 @@ const (
 	GetInfo      = "GetInfo"
 	GetInfoCreds = "GetInfoCreds"
 
+	ListTeamCredentialUsages = "ListTeamCredentialUsages"
+	ListAllCredentialUsages  = "ListAllCredentialUsages"
+	ClearCredentialCache     = "ClearCredentialCache"
+
 	ListContainers           = "ListContainers"
 	GetContainer             = "GetContainer"
 	HijackContainer          = "HijackContainer"
@@ -113,8 +133,13 @@ const (
 )
 
 const (
-	ClearTaskCacheQueryPath = "cache_path"
-	SaveConfigCheckCreds    = "check_creds"
+	ClearTaskCacheQueryPath   = "cache_path"
+	ClearJobCachesQueryKey    = "key"
+	SaveConfigCheckCreds      = "check_creds"
+	StepOutputQueryFormat     = "format"
+	CredentialUsagesQueryPath = "path"
+	CredentialUsagesResolve   = "resolve"
+	CredentialCacheQueryPath  = "path"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -131,6 +156,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/steps/:plan_id/outputs/:name", Method: "GET", Name: GetBuildStepOutput},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -147,6 +173,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tasks/:step_name/cache", Method: "DELETE", Name: ClearTaskCache},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", Method: "GET", Name: ListJobCaches},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/caches", Method: "DELETE", Name: ClearJobCaches},
 
 	{Path: "/api/v1/pipelines", Method: "GET", Name: ListAllPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines", Method: "GET", Name: ListPipelines},
@@ -171,12 +199,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check", Method: "POST", Name: CheckResourceType},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", Method: "GET", Name: ListResourceChecks},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/promote", Method: "PUT", Name: PromoteResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment", Method: "PUT", Name: SetPinCommentOnResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
@@ -186,13 +216,24 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/cc.xml", Method: "GET", Name: GetCC},
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
+	{Path: "/api/v1/workers/demand", Method: "GET", Name: GetWorkerDemand},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
 	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
 	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
+	{Path: "/api/v1/workers/:worker_name/evict", Method: "PUT", Name: EvictWorker},
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
+	{Path: "/api/v1/workers/:worker_name/quarantine", Method: "PUT", Name: QuarantineWorker},
+	{Path: "/api/v1/workers/:worker_name/release", Method: "PUT", Name: ReleaseWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
 
+	{Path: "/api/v1/teams/:team_name/worker-enrollment-tokens", Method: "POST", Name: CreateWorkerEnrollmentToken},
+	{Path: "/api/v1/teams/:team_name/worker-keys", Method: "GET", Name: ListWorkerKeys},
+	{Path: "/api/v1/teams/:team_name/worker-keys/:worker_key_id", Method: "DELETE", Name: RevokeWorkerKey},
+	{Path: "/api/v1/worker-keys/enroll", Method: "POST", Name: EnrollWorkerKey},
+	{Path: "/api/v1/worker-keys/authorized", Method: "GET", Name: AuthorizeWorkerKey},
+	{Path: "/api/v1/worker-keys/used", Method: "PUT", Name: UseWorkerKey},
+
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
 
@@ -200,6 +241,10 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/info", Method: "GET", Name: GetInfo},
 	{Path: "/api/v1/info/creds", Method: "GET", Name: GetInfoCreds},
 
+	{Path: "/api/v1/teams/:team_name/credentials/usages", Method: "GET", Name: ListTeamCredentialUsages},
+	{Path: "/api/v1/credentials/usages", Method: "GET", Name: ListAllCredentialUsages},
+	{Path: "/api/v1/teams/:team_name/credentials/cache", Method: "DELETE", Name: ClearCredentialCache},
+
 	{Path: "/api/v1/user", Method: "GET", Name: GetUser},
 	{Path: "/api/v1/users", Method: "GET", Name: ListActiveUsersSince},
 
@@ -50,6 +50,22 @@ func (art CacheArtifact) ID() string {
 	return fmt.Sprintf("%d, %d, %s, %s", art.TeamID, art.JobID, art.StepName, art.Path)
 }
 
+// KeyedCacheArtifact is a keyed cache restored for a task. The VolumeHandle is
+// empty if no cache was found, in which case the task starts with an empty
+// cache directory.
+type KeyedCacheArtifact struct {
+	VolumeHandle string
+
+	// JobID and Path identify the cache regardless of its key, so that an
+	// older copy of it can be found to stream a delta against.
+	JobID int
+	Path  string
+}
+
+func (art KeyedCacheArtifact) ID() string {
+	return art.VolumeHandle
+}
+
 // TODO (Krishna/Sameer): get rid of these - can GetArtifact and TaskArtifact be merged ?
 type GetArtifact struct {
 	VolumeHandle string
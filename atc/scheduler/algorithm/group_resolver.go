@@ -257,7 +257,7 @@ outputs:
 			}
 
 			if candidate == nil {
-				exists, err := r.vdb.VersionExists(ctx, output.ResourceID, output.Version)
+				exists, err := r.vdb.VersionExists(ctx, r.inputConfigs[c].ResourceID, output.Version)
 				if err != nil {
 					tracing.End(span, err)
 					return false, err
@@ -413,7 +413,7 @@ func (r *groupResolver) constrainingCandidates(passedJobID int) map[string][]str
 	constrainingCandidates := map[string][]string{}
 	for passedIndex, passedInput := range r.inputConfigs {
 		if passedInput.Passed[passedJobID] && r.candidates[passedIndex] != nil {
-			resID := strconv.Itoa(passedInput.ResourceID)
+			resID := strconv.Itoa(passedInput.PassedResourceID(passedJobID))
 			constrainingCandidates[resID] = append(constrainingCandidates[resID], string(r.candidates[passedIndex].Version))
 		}
 	}
@@ -425,13 +425,14 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 	inputConfig := r.inputConfigs[candidateIdx]
 	candidate := r.candidates[candidateIdx]
 
-	if inputConfig.ResourceID != output.ResourceID {
-		// unrelated; different resource
+	if !inputConfig.Passed[passedJobID] {
+		// unrelated; this input is unaffected by the current job
 		return false, false, nil
 	}
 
-	if !inputConfig.Passed[passedJobID] {
-		// unrelated; this input is unaffected by the current job
+	if inputConfig.PassedResourceID(passedJobID) != output.ResourceID {
+		// unrelated; different resource (or, for a job in another pipeline, not
+		// the resource sharing this input's resource config)
 		return false, false, nil
 	}
 
@@ -441,7 +442,7 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 		return false, true, nil
 	}
 
-	disabled, err := r.vdb.VersionIsDisabled(ctx, output.ResourceID, output.Version)
+	disabled, err := r.vdb.VersionIsDisabled(ctx, inputConfig.ResourceID, output.Version)
 	if err != nil {
 		return false, false, err
 	}
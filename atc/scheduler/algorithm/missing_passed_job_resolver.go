@@ -0,0 +1,36 @@
+package algorithm
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// missingPassedJobResolver fails to resolve an input constrained by a job in
+// another pipeline which no longer exists, rather than letting any version of
+// its resource through.
+type missingPassedJobResolver struct {
+	inputConfig db.InputConfig
+}
+
+func NewMissingPassedJobResolver(inputConfig db.InputConfig) Resolver {
+	return &missingPassedJobResolver{
+		inputConfig: inputConfig,
+	}
+}
+
+func (r *missingPassedJobResolver) InputConfigs() db.InputConfigs {
+	return db.InputConfigs{r.inputConfig}
+}
+
+func (r *missingPassedJobResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
+	_, span := tracing.StartSpan(ctx, "missingPassedJobResolver.Resolve", tracing.Attrs{
+		"input": r.inputConfig.Name,
+	})
+	defer span.End()
+
+	span.SetStatus(codes.NotFound, "passed job not found")
+	return nil, db.PassedJobMissing{PassedJob: r.inputConfig.MissingPassedJobs[0]}.String(), nil
+}
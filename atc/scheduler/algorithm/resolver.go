@@ -18,7 +18,9 @@ func constructResolvers(
 	resolvers := []Resolver{}
 	inputConfigsWithPassed := db.InputConfigs{}
 	for _, input := range inputs {
-		if len(input.Passed) == 0 {
+		if len(input.MissingPassedJobs) != 0 {
+			resolvers = append(resolvers, NewMissingPassedJobResolver(input))
+		} else if len(input.Passed) == 0 {
 			if input.PinnedVersion != nil {
 				resolvers = append(resolvers, NewPinnedResolver(versions, input))
 			} else {
@@ -0,0 +1,13 @@
+package scrub_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestScrub(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Scrub Suite")
+}
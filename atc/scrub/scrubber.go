@@ -0,0 +1,94 @@
+package scrub
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// NewScrubber constructs a component which verifies the resource cache
+// volumes on each running worker against the checksums recorded when they
+// were fetched.
+//
+// Each run verifies at most batchSize volumes per worker, starting with the
+// ones verified longest ago, and skips volumes which have been verified
+// within the last period. Corrupted volumes are invalidated so that the next
+// build fetches the resource again.
+func NewScrubber(
+	volumeRepository db.VolumeRepository,
+	workerProvider worker.WorkerProvider,
+	period time.Duration,
+	batchSize int,
+) *scrubber {
+	return &scrubber{
+		volumeRepository: volumeRepository,
+		workerProvider:   workerProvider,
+		period:           period,
+		batchSize:        batchSize,
+	}
+}
+
+type scrubber struct {
+	volumeRepository db.VolumeRepository
+	workerProvider   worker.WorkerProvider
+	period           time.Duration
+	batchSize        int
+}
+
+func (s *scrubber) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("end")
+
+	workers, err := s.workerProvider.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+		return err
+	}
+
+	verifiedBefore := time.Now().Add(-s.period)
+
+	for _, w := range workers {
+		wlogger := logger.Session("scrub", lager.Data{"worker": w.Name()})
+
+		volumes, err := s.volumeRepository.GetResourceCacheVolumesToVerify(w.Name(), verifiedBefore, s.batchSize)
+		if err != nil {
+			wlogger.Error("failed-to-get-volumes-to-verify", err)
+			continue
+		}
+
+		for _, dbVolume := range volumes {
+			s.verify(ctx, wlogger, w, dbVolume.Handle())
+		}
+	}
+
+	return nil
+}
+
+func (s *scrubber) verify(ctx context.Context, logger lager.Logger, w worker.Worker, handle string) {
+	logger = logger.WithData(lager.Data{"volume": handle})
+
+	volume, found, err := w.LookupVolume(logger, handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return
+	}
+
+	if !found {
+		// the volume went missing from the worker; the volume collector will
+		// take care of it
+		logger.Debug("volume-not-found")
+		return
+	}
+
+	// volumes verified within the period were not returned to begin with
+	_, err = volume.VerifyResourceCache(ctx, logger, 0)
+	if err != nil {
+		logger.Error("failed-to-verify-resource-cache", err)
+	}
+}
@@ -0,0 +1,156 @@
+package scrub_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/scrub"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Scrubber interface {
+	Run(ctx context.Context) error
+}
+
+var _ = Describe("Scrubber", func() {
+	var (
+		err error
+
+		fakeVolumeRepository *dbfakes.FakeVolumeRepository
+		fakeWorkerProvider   *workerfakes.FakeWorkerProvider
+
+		fakeWorker1 *workerfakes.FakeWorker
+		fakeWorker2 *workerfakes.FakeWorker
+
+		fakeVolume1 *workerfakes.FakeVolume
+		fakeVolume2 *workerfakes.FakeVolume
+
+		scrubber Scrubber
+		ctx      context.Context
+	)
+
+	newDBVolume := func(handle string) *dbfakes.FakeCreatedVolume {
+		v := new(dbfakes.FakeCreatedVolume)
+		v.HandleReturns(handle)
+		return v
+	}
+
+	BeforeEach(func() {
+		fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
+		fakeWorkerProvider = new(workerfakes.FakeWorkerProvider)
+
+		fakeVolume1 = new(workerfakes.FakeVolume)
+		fakeVolume2 = new(workerfakes.FakeVolume)
+
+		fakeWorker1 = new(workerfakes.FakeWorker)
+		fakeWorker1.NameReturns("worker-1")
+		fakeWorker1.LookupVolumeReturns(fakeVolume1, true, nil)
+
+		fakeWorker2 = new(workerfakes.FakeWorker)
+		fakeWorker2.NameReturns("worker-2")
+		fakeWorker2.LookupVolumeReturns(fakeVolume2, true, nil)
+
+		fakeWorkerProvider.RunningWorkersReturns([]worker.Worker{fakeWorker1, fakeWorker2}, nil)
+
+		fakeVolumeRepository.GetResourceCacheVolumesToVerifyStub = func(workerName string, _ time.Time, _ int) ([]db.CreatedVolume, error) {
+			return []db.CreatedVolume{newDBVolume(workerName + "-volume")}, nil
+		}
+
+		scrubber = scrub.NewScrubber(fakeVolumeRepository, fakeWorkerProvider, time.Hour, 5)
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+	})
+
+	JustBeforeEach(func() {
+		err = scrubber.Run(ctx)
+	})
+
+	It("looks for volumes on each running worker which have not been verified within the period", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeVolumeRepository.GetResourceCacheVolumesToVerifyCallCount()).To(Equal(2))
+
+		workerName, verifiedBefore, limit := fakeVolumeRepository.GetResourceCacheVolumesToVerifyArgsForCall(0)
+		Expect(workerName).To(Equal("worker-1"))
+		Expect(verifiedBefore).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+		Expect(limit).To(Equal(5))
+
+		workerName, _, _ = fakeVolumeRepository.GetResourceCacheVolumesToVerifyArgsForCall(1)
+		Expect(workerName).To(Equal("worker-2"))
+	})
+
+	It("verifies the volumes on their workers", func() {
+		Expect(fakeWorker1.LookupVolumeCallCount()).To(Equal(1))
+		_, handle := fakeWorker1.LookupVolumeArgsForCall(0)
+		Expect(handle).To(Equal("worker-1-volume"))
+		Expect(fakeVolume1.VerifyResourceCacheCallCount()).To(Equal(1))
+
+		Expect(fakeWorker2.LookupVolumeCallCount()).To(Equal(1))
+		_, handle = fakeWorker2.LookupVolumeArgsForCall(0)
+		Expect(handle).To(Equal("worker-2-volume"))
+		Expect(fakeVolume2.VerifyResourceCacheCallCount()).To(Equal(1))
+	})
+
+	It("verifies the volumes regardless of when they were last verified", func() {
+		_, _, period := fakeVolume1.VerifyResourceCacheArgsForCall(0)
+		Expect(period).To(BeZero())
+	})
+
+	Context("when getting the running workers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerProvider.RunningWorkersReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(fakeVolumeRepository.GetResourceCacheVolumesToVerifyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting a worker's volumes fails", func() {
+		BeforeEach(func() {
+			fakeVolumeRepository.GetResourceCacheVolumesToVerifyStub = func(workerName string, _ time.Time, _ int) ([]db.CreatedVolume, error) {
+				if workerName == "worker-1" {
+					return nil, errors.New("nope")
+				}
+
+				return []db.CreatedVolume{newDBVolume(workerName + "-volume")}, nil
+			}
+		})
+
+		It("moves on to the next worker", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeWorker1.LookupVolumeCallCount()).To(BeZero())
+			Expect(fakeVolume2.VerifyResourceCacheCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a volume is missing from its worker", func() {
+		BeforeEach(func() {
+			fakeWorker1.LookupVolumeReturns(nil, false, nil)
+		})
+
+		It("skips it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeVolume1.VerifyResourceCacheCallCount()).To(BeZero())
+			Expect(fakeVolume2.VerifyResourceCacheCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when verifying a volume fails", func() {
+		BeforeEach(func() {
+			fakeVolume1.VerifyResourceCacheReturns(false, errors.New("nope"))
+		})
+
+		It("carries on with the other volumes", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeVolume2.VerifyResourceCacheCallCount()).To(Equal(1))
+		})
+	})
+})
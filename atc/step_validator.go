@@ -87,6 +87,13 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		})
 	}
 
+	if plan.KeepOutputs != "" {
+		_, err := time.ParseDuration(plan.KeepOutputs)
+		if err != nil {
+			validator.recordError("invalid keep_outputs duration '%s'", plan.KeepOutputs)
+		}
+	}
+
 	if plan.Config != nil {
 		validator.pushContext(".config")
 
@@ -162,6 +169,18 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 		}
 	}
 
+	// jobs in other pipelines are checked against the database when the
+	// pipeline is saved
+	for _, passedJob := range step.PassedPipelineJobs {
+		if passedJob.Pipeline == "" {
+			validator.recordError("job '%s' is missing a pipeline", passedJob.Job)
+		}
+
+		if passedJob.Job == "" {
+			validator.recordError("job of pipeline '%s' is missing a name", passedJob.PipelineRef())
+		}
+	}
+
 	validator.popContext()
 
 	return nil
@@ -296,6 +296,78 @@ type GetStep struct {
 	Trigger  bool           `json:"trigger,omitempty"`
 	Tags     Tags           `json:"tags,omitempty"`
 	Timeout  string         `json:"timeout,omitempty"`
+
+	// PassedPipelineJobs are the jobs in other pipelines configured within
+	// `passed:` alongside the names of jobs in the same pipeline.
+	PassedPipelineJobs []PassedJob `json:"-"`
+}
+
+// getStepFields is GetStep without its (Un)MarshalJSON methods.
+type getStepFields GetStep
+
+func (step *GetStep) UnmarshalJSON(data []byte) error {
+	var target struct {
+		getStepFields
+		Passed []json.RawMessage `json:"passed,omitempty"`
+	}
+
+	err := json.Unmarshal(data, &target)
+	if err != nil {
+		return err
+	}
+
+	*step = GetStep(target.getStepFields)
+
+	for _, raw := range target.Passed {
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte(`"`)) {
+			var job string
+			err := json.Unmarshal(raw, &job)
+			if err != nil {
+				return err
+			}
+
+			if !strings.Contains(job, "/") {
+				step.Passed = append(step.Passed, job)
+				continue
+			}
+
+			pipelineJob, err := parsePassedJob(job)
+			if err != nil {
+				return fmt.Errorf("invalid passed job '%s': %w", job, err)
+			}
+
+			step.PassedPipelineJobs = append(step.PassedPipelineJobs, pipelineJob)
+		} else {
+			var job PassedJob
+			err := json.Unmarshal(raw, &job)
+			if err != nil {
+				return fmt.Errorf("invalid passed job: %w", err)
+			}
+
+			step.PassedPipelineJobs = append(step.PassedPipelineJobs, job)
+		}
+	}
+
+	return nil
+}
+
+func (step GetStep) MarshalJSON() ([]byte, error) {
+	var passed []interface{}
+	for _, job := range step.Passed {
+		passed = append(passed, job)
+	}
+
+	for _, job := range step.PassedPipelineJobs {
+		passed = append(passed, job)
+	}
+
+	return json.Marshal(struct {
+		getStepFields
+		Passed []interface{} `json:"passed,omitempty"`
+	}{
+		getStepFields: getStepFields(step),
+		Passed:        passed,
+	})
 }
 
 func (step *GetStep) ResourceName() string {
@@ -310,6 +382,58 @@ func (step *GetStep) Visit(v StepVisitor) error {
 	return v.VisitGet(step)
 }
 
+// PassedJob is a job in another pipeline of the same team which versions of a
+// get step's resource must have passed through.
+//
+// Within `passed:` it is configured as `pipeline/job`, or `pipeline/k:v/job`
+// for an instanced pipeline, the same way pipelines are referred to
+// elsewhere. It may also be configured as an object with an explicit
+// `pipeline:` key, e.g. `{pipeline: library, job: publish}`.
+type PassedJob struct {
+	Pipeline     string       `json:"pipeline"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	Job          string       `json:"job"`
+}
+
+// parsePassedJob parses a job in the string form of a PipelineRef followed by
+// the job's name, e.g. `library/publish` or `library/branch:main/publish`.
+func parsePassedJob(value string) (PassedJob, error) {
+	jobIdx := strings.LastIndex(value, "/")
+
+	passed := PassedJob{Job: value[jobIdx+1:]}
+
+	vs := strings.SplitN(value[:jobIdx], "/", 2)
+	passed.Pipeline = vs[0]
+	if passed.Pipeline == "" || passed.Job == "" {
+		return PassedJob{}, errors.New("must be of the form <pipeline>/<job> or <pipeline>/<key:value>/<job>")
+	}
+
+	if len(vs) == 2 {
+		instanceVars, err := ParseInstanceVars(vs[1])
+		if err != nil {
+			return PassedJob{}, err
+		}
+		if len(instanceVars) == 0 {
+			return PassedJob{}, errors.New("must be of the form <pipeline>/<key:value>/<job>")
+		}
+
+		passed.InstanceVars = instanceVars
+	}
+
+	return passed, nil
+}
+
+func (passed PassedJob) PipelineRef() PipelineRef {
+	return PipelineRef{
+		Name:         passed.Pipeline,
+		InstanceVars: passed.InstanceVars,
+	}
+}
+
+func (passed PassedJob) String() string {
+	return fmt.Sprintf("%s/%s", passed.PipelineRef(), passed.Job)
+}
+
 type PutStep struct {
 	Name      string        `json:"put"`
 	Resource  string        `json:"resource,omitempty"`
@@ -338,12 +462,14 @@ type TaskStep struct {
 	ConfigPath        string            `json:"file,omitempty"`
 	Config            *TaskConfig       `json:"config,omitempty"`
 	Params            TaskEnv           `json:"params,omitempty"`
+	SecretFiles       map[string]string `json:"secret_files,omitempty"`
 	Vars              Params            `json:"vars,omitempty"`
 	Tags              Tags              `json:"tags,omitempty"`
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 	Timeout           string            `json:"timeout,omitempty"`
+	KeepOutputs       string            `json:"keep_outputs,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
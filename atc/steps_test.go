@@ -45,6 +45,69 @@ var factoryTests = []StepTest{
 			Timeout:  "1h",
 		},
 	},
+	{
+		Title: "get step with passed jobs in other pipelines",
+		ConfigYAML: `
+			get: some-name
+			passed:
+			- some-job
+			- other-pipeline/some-job
+			- instanced-pipeline/branch:feature,env:prod/some-job
+			- pipeline: other-pipeline
+			  job: other-job
+			- pipeline: instanced-pipeline
+			  instance_vars: {branch: main}
+			  job: other-job
+		`,
+		StepConfig: &atc.GetStep{
+			Name:   "some-name",
+			Passed: []string{"some-job"},
+			PassedPipelineJobs: []atc.PassedJob{
+				{
+					Pipeline: "other-pipeline",
+					Job:      "some-job",
+				},
+				{
+					Pipeline:     "instanced-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "feature", "env": "prod"},
+					Job:          "some-job",
+				},
+				{
+					Pipeline: "other-pipeline",
+					Job:      "other-job",
+				},
+				{
+					Pipeline:     "instanced-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "main"},
+					Job:          "other-job",
+				},
+			},
+		},
+	},
+	{
+		Title: "get step with malformed passed job",
+		ConfigYAML: `
+			get: some-name
+			passed: [[some-job]]
+		`,
+		Err: "invalid passed job",
+	},
+	{
+		Title: "get step with passed job in another pipeline missing its name",
+		ConfigYAML: `
+			get: some-name
+			passed: [other-pipeline/]
+		`,
+		Err: "invalid passed job 'other-pipeline/'",
+	},
+	{
+		Title: "get step with passed job in another pipeline with malformed instance vars",
+		ConfigYAML: `
+			get: some-name
+			passed: [instanced-pipeline/branch/some-job]
+		`,
+		Err: "invalid passed job 'instanced-pipeline/branch/some-job'",
+	},
 	{
 		Title: "put step",
 
@@ -79,11 +142,13 @@ var factoryTests = []StepTest{
 			file: some-task-file
 			vars: {some: vars}
 			params: {SOME: PARAMS}
+			secret_files: {key.pem: ((key))}
 			tags: [tag-1, tag-2]
 			input_mapping: {generic: specific}
 			output_mapping: {specific: generic}
 			image: some-image
 			timeout: 1h
+			keep_outputs: 24h
 		`,
 
 		StepConfig: &atc.TaskStep{
@@ -96,11 +161,13 @@ var factoryTests = []StepTest{
 			ConfigPath:        "some-task-file",
 			Vars:              atc.Params{"some": "vars"},
 			Params:            atc.TaskEnv{"SOME": "PARAMS"},
+			SecretFiles:       map[string]string{"key.pem": "((key))"},
 			Tags:              []string{"tag-1", "tag-2"},
 			InputMapping:      map[string]string{"generic": "specific"},
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			KeepOutputs:       "24h",
 		},
 	},
 	{
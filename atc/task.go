@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"sigs.k8s.io/yaml"
@@ -25,6 +27,17 @@ type TaskConfig struct {
 	// Parameters to pass to the task via environment variables.
 	Params TaskEnv `json:"params,omitempty"`
 
+	// Files to place in a tmpfs mounted at /run/secrets rather than passing
+	// through the environment, keyed by their path relative to it. Values are
+	// normally credential vars, e.g. ((deploy-key)).
+	//
+	// The files are owned by root and readable by every user in the container
+	// (mode 0444), as the user the task runs as is only known once it runs.
+	// Tasks needing stricter permissions, e.g. for an SSH key, have to copy
+	// them. Only workers running the containerd runtime without rootless mode
+	// support secret files.
+	SecretFiles map[string]string `json:"secret_files,omitempty"`
+
 	// Script to execute.
 	Run TaskRunConfig `json:"run,omitempty"`
 
@@ -99,6 +112,8 @@ func (config TaskConfig) Validate() error {
 
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
+	errors = append(errors, config.validateCacheKeys()...)
+	errors = append(errors, config.validateSecretFiles()...)
 
 	if len(errors) > 0 {
 		return TaskValidationError{
@@ -121,6 +136,43 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCacheKeys() []string {
+	var messages []string
+
+	for i, cache := range config.Caches {
+		if cache.Key == "" && len(cache.RestoreKeys) > 0 {
+			messages = append(messages, fmt.Sprintf("  cache in position %d has restore_keys but no key", i))
+		}
+	}
+
+	return messages
+}
+
+func (config TaskConfig) validateSecretFiles() []string {
+	var messages []string
+
+	paths := make([]string, 0, len(config.SecretFiles))
+	for path := range config.SecretFiles {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if path == "" || filepath.IsAbs(path) {
+			messages = append(messages, fmt.Sprintf("  secret file '%s' must have a relative path", path))
+			continue
+		}
+
+		clean := filepath.Clean(path)
+		if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			messages = append(messages, fmt.Sprintf("  secret file '%s' must be within the secrets directory", path))
+		}
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -155,6 +207,17 @@ type TaskOutputConfig struct {
 
 type TaskCacheConfig struct {
 	Path string `json:"path,omitempty"`
+
+	// Key makes the cache a keyed cache, which is saved under the key at the
+	// end of each successful build of the job and restored on whichever worker
+	// runs the next one. The cache is kept on the worker which saved it, so it
+	// is lost if that worker goes away. It may contain ((hashFiles path...)),
+	// which is replaced with a hash of the given files from the task's inputs.
+	Key string `json:"key,omitempty"`
+
+	// RestoreKeys are prefixes tried in order when nothing has been saved under
+	// the key, restoring the most recently saved cache matching one of them.
+	RestoreKeys []string `json:"restore_keys,omitempty"`
 }
 
 type TaskEnv map[string]string
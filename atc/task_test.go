@@ -281,6 +281,46 @@ run: {path: a/file}
 				})
 			})
 
+			Context("when pids and io limits are specified", func() {
+				It("parses the limits with units", func() {
+					data := []byte(`
+platform: beos
+container_limits:
+  pids: 100
+  io: { read_bps: 10MB, write_bps: 1048576, read_iops: 500, write_iops: 200 }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					pids := PidsLimit(100)
+					readBPS := BandwidthLimit(10485760)
+					writeBPS := BandwidthLimit(1048576)
+					readIOPS := IOPSLimit(500)
+					writeIOPS := IOPSLimit(200)
+					Expect(task.Limits).To(Equal(&ContainerLimits{
+						Pids: &pids,
+						IO: &IOLimits{
+							ReadBPS:   &readBPS,
+							WriteBPS:  &writeBPS,
+							ReadIOPS:  &readIOPS,
+							WriteIOPS: &writeIOPS,
+						},
+					}))
+				})
+
+				It("errors on invalid values", func() {
+					data := []byte(`
+platform: beos
+container_limits: { io: { write_bps: fast } }
+
+run: {path: a/file}
+`)
+					_, err := NewTaskConfig(data)
+					Expect(err).To(MatchError(ContainSubstring("could not parse container bandwidth limit")))
+				})
+			})
+
 			Context("when invalid memory limit value is provided", func() {
 				It("throws an error and does not continue", func() {
 					data := []byte(`
@@ -385,6 +425,66 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has keyed caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, TaskCacheConfig{
+					Path:        ".m2",
+					Key:         "maven-((hashFiles pom.xml))",
+					RestoreKeys: []string{"maven-"},
+				})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when restore_keys are given without a key", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, TaskCacheConfig{Path: "some-path"}, TaskCacheConfig{
+						Path:        ".m2",
+						RestoreKeys: []string{"maven-"},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("cache in position 1 has restore_keys but no key")))
+				})
+			})
+		})
+
+		Context("when the task has secret files", func() {
+			BeforeEach(func() {
+				validConfig.SecretFiles = map[string]string{
+					"key.pem":         "((key))",
+					"aws/credentials": "((aws))",
+				}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when a path is absolute", func() {
+				BeforeEach(func() {
+					invalidConfig.SecretFiles = map[string]string{"/etc/key.pem": "((key))"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("secret file '/etc/key.pem' must have a relative path")))
+				})
+			})
+
+			Context("when a path leaves the secrets directory", func() {
+				BeforeEach(func() {
+					invalidConfig.SecretFiles = map[string]string{"../key.pem": "((key))"}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("secret file '../key.pem' must be within the secrets directory")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
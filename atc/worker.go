@@ -20,6 +20,8 @@ type Worker struct {
 	ActiveVolumes    int `json:"active_volumes"`
 	ActiveTasks      int `json:"active_tasks"`
 
+	ContainerMetrics *WorkerContainerMetrics `json:"container_metrics,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
@@ -29,10 +31,31 @@ type Worker struct {
 	Version   string   `json:"version"`
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
+	Rootless  bool     `json:"rootless,omitempty"`
 	State     string   `json:"state"`
+
+	// SupportedContainerLimits lists the kinds of container limits which
+	// the worker is able to enforce. Workers which do not advertise any are
+	// assumed to support DefaultSupportedContainerLimits.
+	SupportedContainerLimits []string `json:"supported_container_limits,omitempty"`
+
+	// Features lists the optional features which the worker has enabled,
+	// e.g. WorkerFeatureDeltaVolumeStreaming.
+	Features []string `json:"features,omitempty"`
+
+	Health *WorkerHealth `json:"health,omitempty"`
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
+
+// WorkerFeatureDeltaVolumeStreaming is advertised by workers which run the
+// delta server in front of their Baggageclaim server.
+const WorkerFeatureDeltaVolumeStreaming = "delta-volume-streaming"
+
+// WorkerFeatureSecretFiles is advertised by workers which mount a tmpfs for
+// the secret files of tasks.
+const WorkerFeatureSecretFiles = "secret-files"
+
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
 var ErrNoWorkers = errors.New("no workers available for checking")
 
@@ -48,6 +71,59 @@ func (w Worker) Validate() error {
 	return nil
 }
 
+// WorkerContainerMetrics is the resource usage of all of the containers on a
+// worker, summed up.
+type WorkerContainerMetrics struct {
+	MemoryBytes    uint64 `json:"memory_bytes"`
+	CPUNanoseconds uint64 `json:"cpu_ns"`
+	NetworkRxBytes uint64 `json:"network_rx_bytes"`
+	NetworkTxBytes uint64 `json:"network_tx_bytes"`
+}
+
+// WorkerHealth summarizes how reliably a worker has been running the
+// containers, volume streams and steps placed on it.
+type WorkerHealth struct {
+	Score                     float64 `json:"score"`
+	ContainerCreationFailures int     `json:"container_creation_failures"`
+	VolumeStreamingFailures   int     `json:"volume_streaming_failures"`
+	ErroredSteps              int     `json:"errored_steps"`
+	Quarantined               bool    `json:"quarantined"`
+}
+
+// WorkerDemand describes the work which is pending in the cluster, so that
+// autoscalers are able to tell how many workers each pool needs.
+type WorkerDemand struct {
+	Pools []WorkerPoolDemand `json:"pools"`
+
+	// QueuedBuilds is the number of builds which have not yet started, by
+	// team. They will need workers, but which pool they'll run on is not known
+	// until their steps run.
+	QueuedBuilds map[string]int `json:"queued_builds"`
+}
+
+// WorkerPool identifies a group of interchangeable workers.
+type WorkerPool struct {
+	Platform string   `json:"platform"`
+	Tags     []string `json:"tags"`
+	Team     string   `json:"team,omitempty"`
+}
+
+type WorkerPoolDemand struct {
+	WorkerPool
+
+	// Workers is the number of workers in the pool which containers can
+	// currently be placed on.
+	Workers int `json:"workers"`
+
+	// WaitingSteps is the number of steps waiting for a worker in the pool to
+	// become available.
+	WaitingSteps int `json:"waiting_steps"`
+
+	ActiveContainers int `json:"active_containers"`
+	ActiveVolumes    int `json:"active_volumes"`
+	ActiveTasks      int `json:"active_tasks"`
+}
+
 type WorkerResourceType struct {
 	Type                 string `json:"type"`
 	Image                string `json:"image"`
@@ -3,6 +3,7 @@ package worker
 import (
 	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -11,12 +12,17 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/tracing"
 	"github.com/hashicorp/go-multierror"
 )
 
+// ErrResourceCacheCorrupted is returned when streaming a resource cache volume
+// whose contents no longer match the checksum recorded when it was fetched.
+var ErrResourceCacheCorrupted = errors.New("resource cache volume is corrupted and has been invalidated; it will be fetched again by the next build")
+
 //go:generate counterfeiter . ArtifactSourcer
 
 type ArtifactSourcer interface {
@@ -25,23 +31,36 @@ type ArtifactSourcer interface {
 }
 
 type artifactSourcer struct {
-	compression         compression.Compression
-	volumeFinder        VolumeFinder
-	enableP2PStreaming  bool
-	p2pStreamingTimeout time.Duration
+	compression          compression.Compression
+	volumeFinder         VolumeFinder
+	enableP2PStreaming   bool
+	p2pStreamingTimeout  time.Duration
+	enableDeltaStreaming bool
+	verifyResourceCaches bool
+	verificationPeriod   time.Duration
 }
 
+// NewArtifactSourcer constructs a sourcer for streaming artifacts between
+// workers. With verifyResourceCaches, resource cache volumes which have not
+// been verified within the verification period are verified before they are
+// streamed.
 func NewArtifactSourcer(
 	compression compression.Compression,
 	volumeFinder VolumeFinder,
 	enableP2PStreaming bool,
 	p2pStreamingTimeout time.Duration,
+	enableDeltaStreaming bool,
+	verifyResourceCaches bool,
+	verificationPeriod time.Duration,
 ) ArtifactSourcer {
 	return artifactSourcer{
-		compression:         compression,
-		volumeFinder:        volumeFinder,
-		enableP2PStreaming:  enableP2PStreaming,
-		p2pStreamingTimeout: p2pStreamingTimeout,
+		compression:          compression,
+		volumeFinder:         volumeFinder,
+		enableP2PStreaming:   enableP2PStreaming,
+		p2pStreamingTimeout:  p2pStreamingTimeout,
+		enableDeltaStreaming: enableDeltaStreaming,
+		verifyResourceCaches: verifyResourceCaches,
+		verificationPeriod:   verificationPeriod,
 	}
 }
 
@@ -52,6 +71,15 @@ func (w artifactSourcer) SourceInputsAndCaches(logger lager.Logger, teamID int,
 			// task caches may not have a volume, it will be discovered on
 			// the worker later. We do not stream task caches
 			source := NewCacheArtifactSource(*cache)
+			inputs = append(inputs, inputSource{source, path})
+		} else if cache, ok := artifact.(*runtime.KeyedCacheArtifact); ok {
+			// keyed caches are streamed from wherever they were saved, but
+			// start out empty if there is nothing to restore
+			source, err := w.sourceKeyedCache(logger, teamID, *cache)
+			if err != nil {
+				return nil, err
+			}
+
 			inputs = append(inputs, inputSource{source, path})
 		} else {
 			artifactVolume, found, err := w.volumeFinder.FindVolume(logger, teamID, artifact.ID())
@@ -62,7 +90,7 @@ func (w artifactSourcer) SourceInputsAndCaches(logger lager.Logger, teamID int,
 				return nil, fmt.Errorf("volume not found for artifact id %v type %T", artifact.ID(), artifact)
 			}
 
-			source := NewStreamableArtifactSource(artifact, artifactVolume, w.compression, w.enableP2PStreaming, w.p2pStreamingTimeout)
+			source := NewStreamableArtifactSource(artifact, artifactVolume, w.compression, w.enableP2PStreaming, w.p2pStreamingTimeout, w.enableDeltaStreaming, w.verifyResourceCaches, w.verificationPeriod)
 			inputs = append(inputs, inputSource{source, path})
 		}
 	}
@@ -70,6 +98,24 @@ func (w artifactSourcer) SourceInputsAndCaches(logger lager.Logger, teamID int,
 	return inputs, nil
 }
 
+func (w artifactSourcer) sourceKeyedCache(logger lager.Logger, teamID int, cache runtime.KeyedCacheArtifact) (ArtifactSource, error) {
+	if cache.VolumeHandle == "" {
+		return emptyArtifactSource{}, nil
+	}
+
+	cacheVolume, found, err := w.volumeFinder.FindVolume(logger, teamID, cache.VolumeHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		logger.Info("keyed-cache-volume-not-found", lager.Data{"handle": cache.VolumeHandle})
+		return emptyArtifactSource{}, nil
+	}
+
+	return NewStreamableArtifactSource(cache, cacheVolume, w.compression, w.enableP2PStreaming, w.p2pStreamingTimeout, w.enableDeltaStreaming, w.verifyResourceCaches, w.verificationPeriod), nil
+}
+
 func (w artifactSourcer) SourceImage(logger lager.Logger, imageArtifact runtime.Artifact) (StreamableArtifactSource, error) {
 	artifactVolume, found, err := w.volumeFinder.FindVolume(logger, 0, imageArtifact.ID())
 	if err != nil {
@@ -79,7 +125,7 @@ func (w artifactSourcer) SourceImage(logger lager.Logger, imageArtifact runtime.
 		return nil, fmt.Errorf("volume not found for artifact id %v type %T", imageArtifact.ID(), imageArtifact)
 	}
 
-	return NewStreamableArtifactSource(imageArtifact, artifactVolume, w.compression, w.enableP2PStreaming, w.p2pStreamingTimeout), nil
+	return NewStreamableArtifactSource(imageArtifact, artifactVolume, w.compression, w.enableP2PStreaming, w.p2pStreamingTimeout, w.enableDeltaStreaming, w.verifyResourceCaches, w.verificationPeriod), nil
 }
 
 //go:generate counterfeiter . ArtifactSource
@@ -105,14 +151,29 @@ type StreamableArtifactSource interface {
 	// StreamFile returns the contents of a single file in the artifact source.
 	// This is used for loading a task's configuration at runtime.
 	StreamFile(context.Context, string) (io.ReadCloser, error)
+
+	// DeltaBaseOn attempts to locate a volume on the given worker holding an
+	// older version of this source, such as a previous version of the same
+	// resource. If one can be found, a copy-on-write child of it can be
+	// brought up to date with `StreamDeltaTo`, which only transfers what has
+	// changed.
+	DeltaBaseOn(lager.Logger, Worker) (Volume, bool, error)
+
+	// StreamDeltaTo copies whatever differs between the source and the
+	// destination volume, which must already contain the base returned by
+	// `DeltaBaseOn`.
+	StreamDeltaTo(context.Context, Volume) error
 }
 
 type artifactSource struct {
-	artifact            runtime.Artifact
-	volume              Volume
-	compression         compression.Compression
-	enabledP2pStreaming bool
-	p2pStreamingTimeout time.Duration
+	artifact              runtime.Artifact
+	volume                Volume
+	compression           compression.Compression
+	enabledP2pStreaming   bool
+	p2pStreamingTimeout   time.Duration
+	enabledDeltaStreaming bool
+	verifyResourceCaches  bool
+	verificationPeriod    time.Duration
 }
 
 func NewStreamableArtifactSource(
@@ -121,13 +182,19 @@ func NewStreamableArtifactSource(
 	compression compression.Compression,
 	enabledP2pStreaming bool,
 	p2pStreamingTimeout time.Duration,
+	enabledDeltaStreaming bool,
+	verifyResourceCaches bool,
+	verificationPeriod time.Duration,
 ) StreamableArtifactSource {
 	return &artifactSource{
-		artifact:            artifact,
-		volume:              volume,
-		compression:         compression,
-		enabledP2pStreaming: enabledP2pStreaming,
-		p2pStreamingTimeout: p2pStreamingTimeout,
+		artifact:              artifact,
+		volume:                volume,
+		compression:           compression,
+		enabledP2pStreaming:   enabledP2pStreaming,
+		p2pStreamingTimeout:   p2pStreamingTimeout,
+		enabledDeltaStreaming: enabledDeltaStreaming,
+		verifyResourceCaches:  verifyResourceCaches,
+		verificationPeriod:    verificationPeriod,
 	}
 }
 
@@ -142,7 +209,11 @@ func (source *artifactSource) StreamTo(
 	ctx, span := tracing.StartSpan(ctx, "artifactSource.StreamTo", nil)
 	defer span.End()
 
-	var err error
+	err := source.verifyResourceCache(ctx, logger)
+	if err != nil {
+		return err
+	}
+
 	if !source.enabledP2pStreaming {
 		err = source.streamTo(ctx, destination)
 	} else {
@@ -157,6 +228,29 @@ func (source *artifactSource) StreamTo(
 	return err
 }
 
+// verifyResourceCache makes sure that a corrupted resource cache volume is not
+// spread to other workers. Verifying reads every file in the volume, so it is
+// done at most once per verification period. Failing to verify the volume,
+// e.g. because its worker is too old to compute its checksum, does not prevent
+// streaming it.
+func (source *artifactSource) verifyResourceCache(ctx context.Context, logger lager.Logger) error {
+	if !source.verifyResourceCaches || source.volume.GetResourceCacheID() == 0 {
+		return nil
+	}
+
+	intact, err := source.volume.VerifyResourceCache(ctx, logger, source.verificationPeriod)
+	if err != nil {
+		logger.Error("failed-to-verify-resource-cache", err)
+		return nil
+	}
+
+	if !intact {
+		return ErrResourceCacheCorrupted
+	}
+
+	return nil
+}
+
 func (source *artifactSource) streamTo(
 	ctx context.Context,
 	destination ArtifactDestination,
@@ -207,6 +301,93 @@ func (source *artifactSource) p2pStreamTo(
 	return source.volume.StreamP2pOut(putCtx, ".", streamInUrl, source.compression.Encoding())
 }
 
+func (source *artifactSource) StreamDeltaTo(
+	ctx context.Context,
+	destination Volume,
+) error {
+	logger := lagerctx.FromContext(ctx).Session("stream-delta-to")
+	logger.Info("start")
+	defer logger.Info("end")
+
+	ctx, span := tracing.StartSpan(ctx, "artifactSource.StreamDeltaTo", nil)
+	defer span.End()
+
+	err := source.verifyResourceCache(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	if !source.enabledP2pStreaming {
+		err = source.streamDeltaTo(ctx, destination)
+	} else {
+		err = source.p2pStreamDeltaTo(ctx, destination)
+	}
+
+	if err == nil {
+		metric.Metrics.VolumesStreamed.Inc()
+		metric.Metrics.VolumesStreamedAsDelta.Inc()
+	}
+
+	return err
+}
+
+func (source *artifactSource) streamDeltaTo(
+	ctx context.Context,
+	destination Volume,
+) error {
+	manifest, err := destination.DeltaManifest(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer manifest.Close()
+
+	_, outSpan := tracing.StartSpan(ctx, "volume.DeltaStreamOut", tracing.Attrs{
+		"origin-volume": source.volume.Handle(),
+		"origin-worker": source.volume.WorkerName(),
+	})
+	defer outSpan.End()
+
+	out, err := source.volume.DeltaStreamOut(ctx, source.compression.Encoding(), manifest)
+	if err != nil {
+		tracing.End(outSpan, err)
+		return err
+	}
+
+	defer out.Close()
+
+	return destination.DeltaStreamIn(ctx, source.compression.Encoding(), out)
+}
+
+func (source *artifactSource) p2pStreamDeltaTo(
+	ctx context.Context,
+	destination Volume,
+) error {
+	getCtx, getCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer getCancel()
+	destUrl, err := destination.GetDeltaP2pUrl(getCtx)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return context.Canceled
+	default:
+	}
+
+	_, outSpan := tracing.StartSpan(ctx, "volume.DeltaStreamP2pOut", tracing.Attrs{
+		"origin-volume": source.volume.Handle(),
+		"origin-worker": source.volume.WorkerName(),
+		"dest-url":      destUrl,
+	})
+	defer outSpan.End()
+
+	putCtx, putCancel := context.WithTimeout(ctx, source.p2pStreamingTimeout)
+	defer putCancel()
+	return source.volume.DeltaStreamP2pOut(putCtx, destUrl, destination.Handle(), source.compression.Encoding())
+}
+
 func (source *artifactSource) StreamFile(
 	ctx context.Context,
 	filepath string,
@@ -257,6 +438,40 @@ func (source *artifactSource) ExistsOn(logger lager.Logger, worker Worker) (Volu
 
 }
 
+// Returns a volume on the worker for another version of the resource cache
+// backing this source's volume, or for an older copy of the keyed cache, if
+// delta streaming is enabled and supported by both workers.
+func (source *artifactSource) DeltaBaseOn(logger lager.Logger, worker Worker) (Volume, bool, error) {
+	if !source.enabledDeltaStreaming || !source.volume.SupportsDeltaStreaming() {
+		return nil, false, nil
+	}
+
+	var baseVolume Volume
+	var found bool
+	var err error
+	if cache, ok := source.artifact.(runtime.KeyedCacheArtifact); ok {
+		baseVolume, found, err = worker.FindDeltaBaseForTaskCache(logger, cache.JobID, cache.Path)
+	} else {
+		var resourceCache db.UsedResourceCache
+		resourceCache, found, err = worker.FindResourceCacheForVolume(source.volume)
+		if err != nil || !found {
+			return nil, false, err
+		}
+
+		baseVolume, found, err = worker.FindDeltaBaseForResourceCache(logger, resourceCache)
+	}
+
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	if !baseVolume.SupportsDeltaStreaming() {
+		return nil, false, nil
+	}
+
+	return baseVolume, true, nil
+}
+
 type cacheArtifactSource struct {
 	runtime.CacheArtifact
 }
@@ -269,6 +484,14 @@ func (source *cacheArtifactSource) ExistsOn(logger lager.Logger, worker Worker)
 	return worker.FindVolumeForTaskCache(logger, source.TeamID, source.JobID, source.StepName, source.Path)
 }
 
+// emptyArtifactSource never exists on a worker and cannot be streamed, so an
+// empty volume is created in its place.
+type emptyArtifactSource struct{}
+
+func (emptyArtifactSource) ExistsOn(lager.Logger, Worker) (Volume, bool, error) {
+	return nil, false, nil
+}
+
 type fileReadMultiCloser struct {
 	reader  io.Reader
 	closers []io.Closer
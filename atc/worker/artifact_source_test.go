@@ -44,7 +44,7 @@ var _ = Describe("ArtifactSourcer", func() {
 			"image": newVolumeWithContent(content{".": []byte("image content")}),
 		}}
 
-		sourcer := worker.NewArtifactSourcer(fakeCompression, vf, false, 0)
+		sourcer := worker.NewArtifactSourcer(fakeCompression, vf, false, 0, false, false, 0)
 		source, err := sourcer.SourceImage(logger, artifact)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -82,7 +82,7 @@ var _ = Describe("ArtifactSourcer", func() {
 			"output": newVolumeWithContent(content{".": []byte("output")})},
 		}
 
-		sourcer := worker.NewArtifactSourcer(fakeCompression, vf, false, 0)
+		sourcer := worker.NewArtifactSourcer(fakeCompression, vf, false, 0, false, false, 0)
 		inputSources, err := sourcer.SourceInputsAndCaches(logger, 0, inputs)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -97,6 +97,35 @@ var _ = Describe("ArtifactSourcer", func() {
 			Not(ExistOnWorker(fakeWorker)),
 		))
 	})
+
+	It("locates keyed caches, falling back on an empty cache", func() {
+		inputs := map[string]runtime.Artifact{
+			"restored_cache": &runtime.KeyedCacheArtifact{VolumeHandle: "cache"},
+			"missing_volume": &runtime.KeyedCacheArtifact{VolumeHandle: "gone"},
+			"new_cache":      &runtime.KeyedCacheArtifact{},
+		}
+		vf := FakeVolumeFinder{Volumes: map[string]worker.Volume{
+			"cache": newVolumeWithContent(content{".": []byte("cache")})},
+		}
+
+		sourcer := worker.NewArtifactSourcer(fakeCompression, vf, false, 0, false, false, 0)
+		inputSources, err := sourcer.SourceInputsAndCaches(logger, 0, inputs)
+		Expect(err).ToNot(HaveOccurred())
+
+		var streamable int
+		for _, v := range inputSources {
+			if _, ok := v.Source().(worker.StreamableArtifactSource); ok {
+				Expect(v.DestinationPath()).To(Equal("restored_cache"))
+				Expect(v.Source()).To(BeStreamableWithContent(content{".": []byte("cache")}))
+				streamable++
+			} else {
+				Expect(v.Source()).ToNot(ExistOnWorker(new(workerfakes.FakeWorker)))
+			}
+		}
+
+		Expect(inputSources).To(HaveLen(3))
+		Expect(streamable).To(Equal(1))
+	})
 })
 
 var _ = Describe("StreamableArtifactSource", func() {
@@ -105,8 +134,11 @@ var _ = Describe("StreamableArtifactSource", func() {
 		fakeVolume      *workerfakes.FakeVolume
 		fakeArtifact    *runtimefakes.FakeArtifact
 
-		enabledP2pStreaming bool
-		p2pStreamingTimeout time.Duration
+		enabledP2pStreaming   bool
+		p2pStreamingTimeout   time.Duration
+		enabledDeltaStreaming bool
+		verifyResourceCaches  bool
+		verificationPeriod    time.Duration
 
 		artifactSource worker.StreamableArtifactSource
 		comp           compression.Compression
@@ -123,13 +155,16 @@ var _ = Describe("StreamableArtifactSource", func() {
 
 		enabledP2pStreaming = false
 		p2pStreamingTimeout = 15 * time.Minute
+		enabledDeltaStreaming = false
+		verifyResourceCaches = false
+		verificationPeriod = 0
 
 		testLogger = lager.NewLogger("test")
 		disaster = errors.New("disaster")
 	})
 
 	JustBeforeEach(func() {
-		artifactSource = worker.NewStreamableArtifactSource(fakeArtifact, fakeVolume, comp, enabledP2pStreaming, p2pStreamingTimeout)
+		artifactSource = worker.NewStreamableArtifactSource(fakeArtifact, fakeVolume, comp, enabledP2pStreaming, p2pStreamingTimeout, enabledDeltaStreaming, verifyResourceCaches, verificationPeriod)
 	})
 
 	Context("StreamTo", func() {
@@ -189,6 +224,66 @@ var _ = Describe("StreamableArtifactSource", func() {
 			})
 		})
 
+		Context("when resource caches are verified", func() {
+			BeforeEach(func() {
+				verifyResourceCaches = true
+				verificationPeriod = time.Hour
+				fakeVolume.StreamOutReturns(gbytes.NewBuffer(), nil)
+			})
+
+			Context("when the volume is a resource cache", func() {
+				BeforeEach(func() {
+					fakeVolume.GetResourceCacheIDReturns(42)
+				})
+
+				Context("when the volume is intact", func() {
+					BeforeEach(func() {
+						fakeVolume.VerifyResourceCacheReturns(true, nil)
+					})
+
+					It("streams the volume", func() {
+						Expect(fakeVolume.VerifyResourceCacheCallCount()).To(Equal(1))
+						Expect(streamToErr).ToNot(HaveOccurred())
+						Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+					})
+
+					It("verifies the volume at most once per verification period", func() {
+						_, _, period := fakeVolume.VerifyResourceCacheArgsForCall(0)
+						Expect(period).To(Equal(time.Hour))
+					})
+				})
+
+				Context("when the volume is corrupted", func() {
+					BeforeEach(func() {
+						fakeVolume.VerifyResourceCacheReturns(false, nil)
+					})
+
+					It("does not stream the volume", func() {
+						Expect(streamToErr).To(Equal(worker.ErrResourceCacheCorrupted))
+						Expect(fakeVolume.StreamOutCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the volume cannot be verified", func() {
+					BeforeEach(func() {
+						fakeVolume.VerifyResourceCacheReturns(false, disaster)
+					})
+
+					It("streams the volume anyway", func() {
+						Expect(streamToErr).ToNot(HaveOccurred())
+						Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the volume is not a resource cache", func() {
+				It("does not verify it", func() {
+					Expect(fakeVolume.VerifyResourceCacheCallCount()).To(BeZero())
+					Expect(streamToErr).ToNot(HaveOccurred())
+				})
+			})
+		})
+
 		Context("p2p", func() {
 			BeforeEach(func() {
 				enabledP2pStreaming = true
@@ -253,6 +348,219 @@ var _ = Describe("StreamableArtifactSource", func() {
 		})
 	})
 
+	Context("StreamDeltaTo", func() {
+		var (
+			fakeDestinationVolume *workerfakes.FakeVolume
+			streamDeltaToErr      error
+		)
+
+		BeforeEach(func() {
+			fakeDestinationVolume = new(workerfakes.FakeVolume)
+			fakeDestinationVolume.HandleReturns("some-dest-handle")
+		})
+
+		JustBeforeEach(func() {
+			streamDeltaToErr = artifactSource.StreamDeltaTo(context.TODO(), fakeDestinationVolume)
+		})
+
+		Context("via atc", func() {
+			var manifest *gbytes.Buffer
+			var outStream *gbytes.Buffer
+
+			BeforeEach(func() {
+				manifest = gbytes.NewBuffer()
+				fakeDestinationVolume.DeltaManifestReturns(manifest, nil)
+
+				outStream = gbytes.NewBuffer()
+				fakeVolume.DeltaStreamOutReturns(outStream, nil)
+			})
+
+			It("streams the delta against the destination's manifest", func() {
+				Expect(streamDeltaToErr).ToNot(HaveOccurred())
+
+				Expect(fakeVolume.DeltaStreamOutCallCount()).To(Equal(1))
+				_, encoding, base := fakeVolume.DeltaStreamOutArgsForCall(0)
+				Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+				Expect(base).To(Equal(manifest))
+
+				Expect(fakeDestinationVolume.DeltaStreamInCallCount()).To(Equal(1))
+				_, encoding, delta := fakeDestinationVolume.DeltaStreamInArgsForCall(0)
+				Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+				Expect(delta).To(Equal(outStream))
+			})
+
+			It("closes the manifest and the delta", func() {
+				Expect(manifest.Closed()).To(BeTrue())
+				Expect(outStream.Closed()).To(BeTrue())
+			})
+
+			Context("when getting the destination's manifest fails", func() {
+				BeforeEach(func() {
+					fakeDestinationVolume.DeltaManifestReturns(nil, disaster)
+				})
+
+				It("returns the err", func() {
+					Expect(streamDeltaToErr).To(Equal(disaster))
+					Expect(fakeVolume.DeltaStreamOutCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when streaming in to the destination fails", func() {
+				BeforeEach(func() {
+					fakeDestinationVolume.DeltaStreamInReturns(disaster)
+				})
+
+				It("returns the err", func() {
+					Expect(streamDeltaToErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Context("p2p", func() {
+			BeforeEach(func() {
+				enabledP2pStreaming = true
+				fakeDestinationVolume.GetDeltaP2pUrlReturns("some-url", nil)
+			})
+
+			It("has the source stream the delta directly to the destination", func() {
+				Expect(streamDeltaToErr).ToNot(HaveOccurred())
+
+				Expect(fakeVolume.DeltaStreamP2pOutCallCount()).To(Equal(1))
+				_, destUrl, destHandle, encoding := fakeVolume.DeltaStreamP2pOutArgsForCall(0)
+				Expect(destUrl).To(Equal("some-url"))
+				Expect(destHandle).To(Equal("some-dest-handle"))
+				Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+			})
+
+			Context("when GetDeltaP2pUrl fails", func() {
+				BeforeEach(func() {
+					fakeDestinationVolume.GetDeltaP2pUrlReturns("", disaster)
+				})
+
+				It("returns the err", func() {
+					Expect(streamDeltaToErr).To(Equal(disaster))
+					Expect(fakeVolume.DeltaStreamP2pOutCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
+	Context("DeltaBaseOn", func() {
+		var (
+			fakeWorker    *workerfakes.FakeWorker
+			baseVolume    worker.Volume
+			foundBase     bool
+			deltaErr      error
+			fakeBase      *workerfakes.FakeVolume
+			resourceCache *dbfakes.FakeUsedResourceCache
+		)
+
+		BeforeEach(func() {
+			fakeWorker = new(workerfakes.FakeWorker)
+
+			resourceCache = new(dbfakes.FakeUsedResourceCache)
+			fakeWorker.FindResourceCacheForVolumeReturns(resourceCache, true, nil)
+
+			fakeBase = new(workerfakes.FakeVolume)
+			fakeBase.SupportsDeltaStreamingReturns(true)
+			fakeWorker.FindDeltaBaseForResourceCacheReturns(fakeBase, true, nil)
+
+			fakeVolume.SupportsDeltaStreamingReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			baseVolume, foundBase, deltaErr = artifactSource.DeltaBaseOn(testLogger, fakeWorker)
+		})
+
+		Context("when delta streaming is disabled", func() {
+			It("does not find a base", func() {
+				Expect(deltaErr).ToNot(HaveOccurred())
+				Expect(foundBase).To(BeFalse())
+				Expect(fakeWorker.FindDeltaBaseForResourceCacheCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when delta streaming is enabled", func() {
+			BeforeEach(func() {
+				enabledDeltaStreaming = true
+			})
+
+			It("finds a base for the volume's resource cache on the worker", func() {
+				Expect(deltaErr).ToNot(HaveOccurred())
+				Expect(foundBase).To(BeTrue())
+				Expect(baseVolume).To(Equal(fakeBase))
+
+				Expect(fakeWorker.FindResourceCacheForVolumeArgsForCall(0)).To(Equal(fakeVolume))
+				_, actualResourceCache := fakeWorker.FindDeltaBaseForResourceCacheArgsForCall(0)
+				Expect(actualResourceCache).To(Equal(resourceCache))
+			})
+
+			Context("when the volume does not have a resource cache", func() {
+				BeforeEach(func() {
+					fakeWorker.FindResourceCacheForVolumeReturns(nil, false, nil)
+				})
+
+				It("does not find a base", func() {
+					Expect(deltaErr).ToNot(HaveOccurred())
+					Expect(foundBase).To(BeFalse())
+				})
+			})
+
+			Context("when looking up the resource cache fails", func() {
+				BeforeEach(func() {
+					fakeWorker.FindResourceCacheForVolumeReturns(nil, false, disaster)
+				})
+
+				It("returns the err", func() {
+					Expect(deltaErr).To(Equal(disaster))
+				})
+			})
+
+			Context("when the source's worker does not support delta streaming", func() {
+				BeforeEach(func() {
+					fakeVolume.SupportsDeltaStreamingReturns(false)
+				})
+
+				It("does not find a base", func() {
+					Expect(deltaErr).ToNot(HaveOccurred())
+					Expect(foundBase).To(BeFalse())
+					Expect(fakeWorker.FindDeltaBaseForResourceCacheCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the destination worker does not support delta streaming", func() {
+				BeforeEach(func() {
+					fakeBase.SupportsDeltaStreamingReturns(false)
+				})
+
+				It("does not find a base", func() {
+					Expect(deltaErr).ToNot(HaveOccurred())
+					Expect(foundBase).To(BeFalse())
+				})
+			})
+
+			Context("when the source is a keyed cache", func() {
+				JustBeforeEach(func() {
+					fakeWorker.FindDeltaBaseForTaskCacheReturns(fakeBase, true, nil)
+
+					keyedCache := runtime.KeyedCacheArtifact{VolumeHandle: "some-handle", JobID: 42, Path: ".m2"}
+					artifactSource = worker.NewStreamableArtifactSource(keyedCache, fakeVolume, comp, enabledP2pStreaming, p2pStreamingTimeout, enabledDeltaStreaming, verifyResourceCaches, verificationPeriod)
+					baseVolume, foundBase, deltaErr = artifactSource.DeltaBaseOn(testLogger, fakeWorker)
+				})
+
+				It("finds the job's cache for the path on the worker", func() {
+					Expect(deltaErr).ToNot(HaveOccurred())
+					Expect(foundBase).To(BeTrue())
+					Expect(baseVolume).To(Equal(fakeBase))
+
+					_, jobID, path := fakeWorker.FindDeltaBaseForTaskCacheArgsForCall(0)
+					Expect(jobID).To(Equal(42))
+					Expect(path).To(Equal(".m2"))
+				})
+			})
+		})
+	})
+
 	Context("StreamFile", func() {
 		var (
 			streamFileErr    error
@@ -3,7 +3,11 @@ package worker
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/url"
 	"path"
 	"strconv"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker/transport"
 )
 
 const taskProcessID = "task"
@@ -77,6 +82,21 @@ type client struct {
 	worker Worker
 }
 
+// WorkerEvictedError is returned when a step fails because the worker it was
+// running on was evicted, in which case it can be run again on another worker.
+type WorkerEvictedError struct {
+	WorkerName string
+	Err        error
+}
+
+func (err WorkerEvictedError) Error() string {
+	return fmt.Sprintf("worker %s was evicted: %s", err.WorkerName, err.Err)
+}
+
+func (err WorkerEvictedError) Unwrap() error {
+	return err.Err
+}
+
 type TaskResult struct {
 	ExitStatus   int
 	VolumeMounts []VolumeMount
@@ -118,9 +138,11 @@ func (client *client) RunCheckStep(
 	processSpec runtime.ProcessSpec,
 	eventDelegate runtime.StartingEventDelegate,
 	checkable resource.Resource,
-) (CheckResult, error) {
+) (result CheckResult, err error) {
 	logger := lagerctx.FromContext(ctx)
 
+	defer func() { err = client.checkEvicted(ctx, 0, err) }()
+
 	container, err := client.worker.FindOrCreateContainer(
 		ctx,
 		logger,
@@ -149,10 +171,18 @@ func (client *client) RunTaskStep(
 	metadata db.ContainerMetadata,
 	processSpec runtime.ProcessSpec,
 	eventDelegate runtime.StartingEventDelegate,
-) (TaskResult, error) {
+) (result TaskResult, err error) {
 	logger := lagerctx.FromContext(ctx)
 
-	container, err := client.worker.FindOrCreateContainer(
+	var container Container
+	defer func() {
+		if container != nil {
+			recordStepHealth(ctx, client.worker, err)
+		}
+	}()
+	defer func() { err = client.checkEvicted(ctx, result.ExitStatus, err) }()
+
+	container, err = client.worker.FindOrCreateContainer(
 		ctx,
 		logger,
 		owner,
@@ -270,9 +300,11 @@ func (client *client) RunGetStep(
 	eventDelegate runtime.StartingEventDelegate,
 	resourceCache db.UsedResourceCache,
 	resource resource.Resource,
-) (GetResult, error) {
+) (result GetResult, err error) {
 	logger := lagerctx.FromContext(ctx)
 
+	defer func() { err = client.checkEvicted(ctx, result.ExitStatus, err) }()
+
 	sign, err := resource.Signature()
 	if err != nil {
 		return GetResult{}, err
@@ -306,10 +338,18 @@ func (client *client) RunPutStep(
 	spec runtime.ProcessSpec,
 	eventDelegate runtime.StartingEventDelegate,
 	resource resource.Resource,
-) (PutResult, error) {
+) (result PutResult, err error) {
 	logger := lagerctx.FromContext(ctx)
 
-	container, err := client.worker.FindOrCreateContainer(
+	var container Container
+	defer func() {
+		if container != nil {
+			recordStepHealth(ctx, client.worker, err)
+		}
+	}()
+	defer func() { err = client.checkEvicted(ctx, result.ExitStatus, err) }()
+
+	container, err = client.worker.FindOrCreateContainer(
 		ctx,
 		logger,
 		owner,
@@ -355,6 +395,74 @@ func (client *client) RunPutStep(
 	}, nil
 }
 
+// checkEvicted wraps the error of a step in a WorkerEvictedError if the worker
+// was evicted while the step was running on it.
+//
+// The worker's state is only looked up when the step was interrupted in a way
+// that the worker going away causes: losing the connection to the worker, or
+// the step's process getting killed, which makes it exit with a status above
+// 128 rather than error.
+func (client *client) checkEvicted(ctx context.Context, exitStatus int, err error) error {
+	if ctx.Err() != nil || !interrupted(exitStatus, err) {
+		return err
+	}
+
+	if !client.worker.Evicted(lagerctx.FromContext(ctx)) {
+		return err
+	}
+
+	if err == nil {
+		err = fmt.Errorf("process was killed (exit status %d)", exitStatus)
+	}
+
+	return WorkerEvictedError{
+		WorkerName: client.worker.Name(),
+		Err:        err,
+	}
+}
+
+func interrupted(exitStatus int, err error) bool {
+	var scriptErr runtime.ErrResourceScriptFailed
+	if errors.As(err, &scriptErr) {
+		return scriptErr.ExitStatus > 128
+	}
+
+	if err == nil {
+		return exitStatus > 128
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &transport.WorkerMissingError{}) ||
+		errors.As(err, &transport.WorkerUnreachableError{}) ||
+		errors.As(err, &netErr) ||
+		errors.As(err, &urlErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// recordStepHealth records whether running a step's process on the worker
+// errored. It must only be called once the step's container exists, so that
+// failing to fetch its image or credentials does not count against the
+// worker, and it ignores other errors which are not the worker's fault
+// either: the step being aborted or timing out, resource scripts failing, and
+// the worker being evicted.
+func recordStepHealth(ctx context.Context, worker Worker, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	if errors.As(err, &WorkerEvictedError{}) {
+		return
+	}
+
+	if errors.As(err, &runtime.ErrResourceScriptFailed{}) {
+		err = nil
+	}
+
+	worker.RecordHealth(lagerctx.FromContext(ctx), db.WorkerHealthStep, err != nil)
+}
+
 func lockName(resourceJSON []byte, workerName string) string {
 	jsonRes := append(resourceJSON, []byte(workerName)...)
 	return fmt.Sprintf("%x", sha256.Sum256(jsonRes))
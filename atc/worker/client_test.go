@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"path"
 
 	"code.cloudfoundry.org/garden"
@@ -555,6 +556,31 @@ var _ = Describe("Client", func() {
 					})
 				})
 
+				Context("when the process gets killed", func() {
+					BeforeEach(func() {
+						fakeProcessExitCode = 128 + 9
+						fakeProcess.WaitReturns(fakeProcessExitCode, nil)
+					})
+
+					It("returns the exit status", func() {
+						Expect(status).To(Equal(fakeProcessExitCode))
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					Context("because the worker was evicted", func() {
+						BeforeEach(func() {
+							fakeWorker.NameReturns("some-worker")
+							fakeWorker.EvictedReturns(true)
+						})
+
+						It("returns a WorkerEvictedError", func() {
+							var evictedErr worker.WorkerEvictedError
+							Expect(errors.As(err, &evictedErr)).To(BeTrue())
+							Expect(evictedErr.WorkerName).To(Equal("some-worker"))
+						})
+					})
+				})
+
 				Context("when the process exits with an error", func() {
 					disaster := errors.New("process failed")
 					BeforeEach(func() {
@@ -956,6 +982,13 @@ var _ = Describe("Client", func() {
 						Expect(status).To(Equal(10))
 						Expect(err).To(BeNil())
 					})
+
+					It("does not count against the health of the worker", func() {
+						Expect(fakeWorker.RecordHealthCallCount()).To(Equal(1))
+						_, event, failed := fakeWorker.RecordHealthArgsForCall(0)
+						Expect(event).To(Equal(db.WorkerHealthStep))
+						Expect(failed).To(BeFalse())
+					})
 				})
 
 				Context("when the error is NOT ErrResourceScriptFailed", func() {
@@ -970,6 +1003,48 @@ var _ = Describe("Client", func() {
 						Expect(err).To(Equal(disasterErr))
 					})
 
+					It("records the errored step against the worker", func() {
+						Expect(fakeWorker.RecordHealthCallCount()).To(Equal(1))
+						_, event, failed := fakeWorker.RecordHealthArgsForCall(0)
+						Expect(event).To(Equal(db.WorkerHealthStep))
+						Expect(failed).To(BeTrue())
+					})
+
+					It("does not look up whether the worker was evicted", func() {
+						Expect(fakeWorker.EvictedCallCount()).To(BeZero())
+					})
+
+					Context("when the connection to the worker was lost", func() {
+						var connErr error
+
+						BeforeEach(func() {
+							connErr = fmt.Errorf("stream: %w", io.ErrUnexpectedEOF)
+							fakeResource.PutReturns(runtime.VersionResult{}, connErr)
+						})
+
+						It("returns the error", func() {
+							Expect(err).To(Equal(connErr))
+						})
+
+						Context("because the worker was evicted", func() {
+							BeforeEach(func() {
+								fakeWorker.NameReturns("some-worker")
+								fakeWorker.EvictedReturns(true)
+							})
+
+							It("returns a WorkerEvictedError", func() {
+								Expect(err).To(Equal(worker.WorkerEvictedError{
+									WorkerName: "some-worker",
+									Err:        connErr,
+								}))
+								Expect(errors.Is(err, io.ErrUnexpectedEOF)).To(BeTrue())
+							})
+
+							It("does not count against the health of the worker", func() {
+								Expect(fakeWorker.RecordHealthCallCount()).To(BeZero())
+							})
+						})
+					})
 				})
 			})
 
@@ -1001,6 +1076,10 @@ var _ = Describe("Client", func() {
 				Expect(err).To(Equal(disasterErr))
 				Expect(versionResult).To(Equal(runtime.VersionResult{}))
 			})
+
+			It("does not count against the health of the worker", func() {
+				Expect(fakeWorker.RecordHealthCallCount()).To(BeZero())
+			})
 		})
 	})
 })
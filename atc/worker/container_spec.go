@@ -1,10 +1,13 @@
 package worker
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -40,6 +43,11 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Files to place in a tmpfs at secretFilesDir, keyed by their path relative
+	// to it. Unlike Env, they don't end up in the environment of every process
+	// in the container.
+	SecretFiles map[string]string
 }
 
 // The below methods cause ContainerSpec to fulfill the
@@ -88,13 +96,40 @@ type ImageSpec struct {
 	ImageURL            string
 	ImageArtifactSource StreamableArtifactSource
 	Privileged          bool
+
+	// ImageDigest is the content digest of a registry image. When set, the
+	// image is taken from the worker's layer store, and only fetched through
+	// FetchImageArtifact if the store does not hold it yet.
+	ImageDigest        string
+	FetchImageArtifact func(context.Context) (StreamableArtifactSource, error)
 }
 
 type ContainerLimits struct {
 	CPU    *uint64
 	Memory *uint64
+	Pids   *uint64
+
+	IOReadBPS   *uint64
+	IOWriteBPS  *uint64
+	IOReadIOPS  *uint64
+	IOWriteIOPS *uint64
 }
 
+// Garden has no notion of block IO limits, so they are passed to the runtime
+// as container properties instead. The same goes for the tmpfs holding secret
+// files, whose location is passed as a property.
+const (
+	ioReadBPSPropertyName   = "concourse:io-read-bps"
+	ioWriteBPSPropertyName  = "concourse:io-write-bps"
+	ioReadIOPSPropertyName  = "concourse:io-read-iops"
+	ioWriteIOPSPropertyName = "concourse:io-write-iops"
+
+	secretFilesPropertyName = "concourse:secret-files-dir"
+)
+
+// secretFilesDir is where the secret files of a task end up in its container.
+const secretFilesDir = "/run/secrets"
+
 type inputSource struct {
 	source ArtifactSource
 	path   string
@@ -122,9 +157,52 @@ func (cl ContainerLimits) ToGardenLimits() garden.Limits {
 	} else {
 		gardenLimits.Memory = garden.MemoryLimits{LimitInBytes: *cl.Memory}
 	}
+	if cl.Pids != nil {
+		gardenLimits.Pid = garden.PidLimits{Max: *cl.Pids}
+	}
 	return gardenLimits
 }
 
+// GardenProperties returns the container properties carrying the limits
+// which cannot be expressed as garden.Limits.
+func (cl ContainerLimits) GardenProperties() garden.Properties {
+	properties := garden.Properties{}
+	for name, limit := range map[string]*uint64{
+		ioReadBPSPropertyName:   cl.IOReadBPS,
+		ioWriteBPSPropertyName:  cl.IOWriteBPS,
+		ioReadIOPSPropertyName:  cl.IOReadIOPS,
+		ioWriteIOPSPropertyName: cl.IOWriteIOPS,
+	} {
+		if limit != nil && *limit > 0 {
+			properties[name] = strconv.FormatUint(*limit, 10)
+		}
+	}
+	return properties
+}
+
+// Required returns the kinds of limits that a worker has to support in order
+// to enforce these limits.
+func (cl ContainerLimits) Required() []string {
+	set := func(limit *uint64) bool {
+		return limit != nil && *limit > 0
+	}
+
+	var required []string
+	if set(cl.CPU) {
+		required = append(required, atc.ContainerLimitCPU)
+	}
+	if set(cl.Memory) {
+		required = append(required, atc.ContainerLimitMemory)
+	}
+	if set(cl.Pids) {
+		required = append(required, atc.ContainerLimitPids)
+	}
+	if set(cl.IOReadBPS) || set(cl.IOWriteBPS) || set(cl.IOReadIOPS) || set(cl.IOWriteIOPS) {
+		required = append(required, atc.ContainerLimitIO)
+	}
+	return required
+}
+
 func (spec WorkerSpec) Description() string {
 	var attrs []string
 
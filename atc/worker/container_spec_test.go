@@ -0,0 +1,64 @@
+package worker_test
+
+import (
+	"code.cloudfoundry.org/garden"
+	. "github.com/concourse/concourse/atc/worker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerLimits", func() {
+	var limits ContainerLimits
+
+	BeforeEach(func() {
+		cpu := uint64(512)
+		memory := uint64(1024)
+		pids := uint64(100)
+		readBPS := uint64(4096)
+		writeIOPS := uint64(0)
+
+		limits = ContainerLimits{
+			CPU:         &cpu,
+			Memory:      &memory,
+			Pids:        &pids,
+			IOReadBPS:   &readBPS,
+			IOWriteIOPS: &writeIOPS,
+		}
+	})
+
+	Describe("ToGardenLimits", func() {
+		It("converts the limits supported by garden", func() {
+			Expect(limits.ToGardenLimits()).To(Equal(garden.Limits{
+				CPU:    garden.CPULimits{LimitInShares: 512},
+				Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				Pid:    garden.PidLimits{Max: 100},
+			}))
+		})
+	})
+
+	Describe("GardenProperties", func() {
+		It("carries the io limits which are set", func() {
+			Expect(limits.GardenProperties()).To(Equal(garden.Properties{
+				"concourse:io-read-bps": "4096",
+			}))
+		})
+	})
+
+	Describe("Required", func() {
+		It("returns the kinds of limits which are set", func() {
+			Expect(limits.Required()).To(Equal([]string{"cpu", "memory", "pids", "io"}))
+		})
+
+		Context("when limits are unlimited", func() {
+			BeforeEach(func() {
+				zero := uint64(0)
+				limits = ContainerLimits{CPU: &zero, IOWriteBPS: &zero}
+			})
+
+			It("does not require them", func() {
+				Expect(limits.Required()).To(BeEmpty())
+			})
+		})
+	})
+})
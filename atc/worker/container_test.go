@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/lager"
@@ -103,6 +104,7 @@ var _ = Describe("RunScript", func() {
 			fakeDBWorker,
 			fakeResourceCacheFactory,
 			0,
+			worker.NewHealthRecorder(clock.NewClock(), db.WorkerQuarantine{}),
 		)
 
 		fakeCreatedContainer.HandleReturns("some-handle")
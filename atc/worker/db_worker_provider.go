@@ -7,10 +7,12 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/worker/gclient"
 	"github.com/concourse/concourse/atc/worker/transport"
+	"github.com/concourse/concourse/volumedelta"
 	"github.com/concourse/retryhttp"
 	"github.com/cppforlife/go-semi-semantic/version"
 )
@@ -60,6 +62,7 @@ type dbWorkerProvider struct {
 	workerVersion                     version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
 	gardenRequestTimeout              time.Duration
+	healthRecorder                    *HealthRecorder
 }
 
 func NewDBWorkerProvider(
@@ -77,6 +80,7 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion version.Version,
 	baggageclaimResponseHeaderTimeout, gardenRequestTimeout time.Duration,
+	quarantine db.WorkerQuarantine,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -94,6 +98,7 @@ func NewDBWorkerProvider(
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
 		gardenRequestTimeout:              gardenRequestTimeout,
+		healthRecorder:                    NewHealthRecorder(clock.NewClock(), quarantine),
 	}
 }
 
@@ -222,8 +227,26 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, savedWork
 		},
 	))
 
+	// the worker's delta server, if it runs one, sits in front of its
+	// baggageclaim server and is reached through the same address
+	var dClient volumedelta.Client
+	if hasFeature(savedWorker.Features(), atc.WorkerFeatureDeltaVolumeStreaming) {
+		dClient = volumedelta.NewClient("", &http.Client{
+			Transport: transport.NewBaggageclaimRoundTripper(
+				savedWorker.Name(),
+				savedWorker.BaggageclaimURL(),
+				provider.dbWorkerFactory,
+				&http.Transport{
+					DisableKeepAlives:     true,
+					ResponseHeaderTimeout: provider.baggageclaimResponseHeaderTimeout,
+				},
+			),
+		})
+	}
+
 	volumeClient := NewVolumeClient(
 		bClient,
+		dClient,
 		savedWorker,
 		clock.NewClock(),
 		provider.lockFactory,
@@ -243,5 +266,6 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, savedWork
 		savedWorker,
 		provider.dbResourceCacheFactory,
 		buildContainersCount,
+		provider.healthRecorder,
 	)
 }
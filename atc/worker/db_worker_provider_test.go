@@ -181,6 +181,7 @@ var _ = Describe("DBProvider", func() {
 			wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
 			gardenRequestTimeout,
+			db.WorkerQuarantine{},
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
@@ -37,13 +37,19 @@ type FetchSourceFactory interface {
 
 type fetchSourceFactory struct {
 	resourceCacheFactory db.ResourceCacheFactory
+	recordChecksums      bool
 }
 
+// NewFetchSourceFactory constructs a factory for fetching resources. With
+// recordChecksums, a checksum of each fetched volume is recorded so that
+// resource caches can be verified.
 func NewFetchSourceFactory(
 	resourceCacheFactory db.ResourceCacheFactory,
+	recordChecksums bool,
 ) FetchSourceFactory {
 	return &fetchSourceFactory{
 		resourceCacheFactory: resourceCacheFactory,
+		recordChecksums:      recordChecksums,
 	}
 }
 
@@ -67,6 +73,7 @@ func (r *fetchSourceFactory) NewFetchSource(
 		processSpec:            processSpec,
 		containerMetadata:      containerMetadata,
 		dbResourceCacheFactory: r.resourceCacheFactory,
+		recordChecksum:         r.recordChecksums,
 	}
 }
 
@@ -80,6 +87,7 @@ type fetchSource struct {
 	processSpec            runtime.ProcessSpec
 	containerMetadata      db.ContainerMetadata
 	dbResourceCacheFactory db.ResourceCacheFactory
+	recordChecksum         bool
 }
 
 func (s *fetchSource) Find() (GetResult, Volume, bool, error) {
@@ -158,6 +166,7 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 	}
 
 	vr, err := s.resource.Get(ctx, s.processSpec, container)
+	recordStepHealth(ctx, s.worker, err)
 	if err != nil {
 		sLog.Error("failed-to-fetch-resource", err)
 		// TODO: Is this compatible with previous behaviour of returning a nil when error type is NOT ErrResourceScriptFailed
@@ -184,6 +193,14 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return GetResult{}, nil, err
 	}
 
+	if s.recordChecksum {
+		// without a checksum, the cache is only left unverified
+		err = volume.RecordResourceCacheChecksum(ctx)
+		if err != nil {
+			sLog.Error("failed-to-record-resource-cache-checksum", err)
+		}
+	}
+
 	err = s.dbResourceCacheFactory.UpdateResourceCacheMetadata(s.cache, vr.Metadata)
 	if err != nil {
 		s.logger.Error("failed-to-update-resource-cache-metadata", err, lager.Data{"resource-cache": s.cache})
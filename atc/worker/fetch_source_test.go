@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -34,12 +35,16 @@ var _ = Describe("FetchSource", func() {
 		metadata                 db.ContainerMetadata
 		owner                    db.ContainerOwner
 
+		logger          lager.Logger
+		getProcessSpec  runtime.ProcessSpec
+		recordChecksums bool
+
 		ctx    context.Context
 		cancel func()
 	)
 
 	BeforeEach(func() {
-		logger := lagertest.NewTestLogger("test")
+		logger = lagertest.NewTestLogger("test")
 		fakeContainer = new(workerfakes.FakeContainer)
 
 		ctx, cancel = context.WithCancel(context.Background())
@@ -87,12 +92,16 @@ var _ = Describe("FetchSource", func() {
 			{Name: "some", Value: "metadata"},
 		}, nil)
 
-		getProcessSpec := runtime.ProcessSpec{
+		getProcessSpec = runtime.ProcessSpec{
 			Path: "/opt/resource/in",
 			Args: []string{resource.ResourcesDir("get")},
 		}
 
-		fetchSourceFactory = worker.NewFetchSourceFactory(fakeResourceCacheFactory)
+		recordChecksums = false
+	})
+
+	JustBeforeEach(func() {
+		fetchSourceFactory = worker.NewFetchSourceFactory(fakeResourceCacheFactory, recordChecksums)
 		fetchSource = fetchSourceFactory.NewFetchSource(
 			logger,
 			fakeWorker,
@@ -231,6 +240,13 @@ var _ = Describe("FetchSource", func() {
 				Expect(fakeResource.GetCallCount()).To(Equal(1))
 			})
 
+			It("records the step against the health of the worker", func() {
+				Expect(fakeWorker.RecordHealthCallCount()).To(Equal(1))
+				_, event, failed := fakeWorker.RecordHealthArgsForCall(0)
+				Expect(event).To(Equal(db.WorkerHealthStep))
+				Expect(failed).To(BeFalse())
+			})
+
 			It("initializes cache", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeVolume.InitializeResourceCacheCallCount()).To(Equal(1))
@@ -238,6 +254,30 @@ var _ = Describe("FetchSource", func() {
 				Expect(rc).To(Equal(fakeUsedResourceCache))
 			})
 
+			It("does not record a checksum of the volume", func() {
+				Expect(fakeVolume.RecordResourceCacheChecksumCallCount()).To(BeZero())
+			})
+
+			Context("when checksums are recorded", func() {
+				BeforeEach(func() {
+					recordChecksums = true
+				})
+
+				It("records a checksum of the volume", func() {
+					Expect(fakeVolume.RecordResourceCacheChecksumCallCount()).To(Equal(1))
+				})
+
+				Context("when recording the checksum fails", func() {
+					BeforeEach(func() {
+						fakeVolume.RecordResourceCacheChecksumReturns(errors.New("nope"))
+					})
+
+					It("leaves the cache unverified", func() {
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+			})
+
 			It("updates resource cache metadata", func() {
 				Expect(fakeResourceCacheFactory.UpdateResourceCacheMetadataCallCount()).To(Equal(1))
 				passedResourceCache, versionResultMetadata := fakeResourceCacheFactory.UpdateResourceCacheMetadataArgsForCall(0)
@@ -257,6 +297,23 @@ var _ = Describe("FetchSource", func() {
 					Expect(err).To(HaveOccurred())
 					Expect(err).To(Equal(disaster))
 				})
+
+				It("records the errored step against the worker", func() {
+					Expect(fakeWorker.RecordHealthCallCount()).To(Equal(1))
+					_, _, failed := fakeWorker.RecordHealthArgsForCall(0)
+					Expect(failed).To(BeTrue())
+				})
+			})
+
+			Context("when creating the container fails", func() {
+				BeforeEach(func() {
+					fakeWorker.FindOrCreateContainerReturns(nil, errors.New("no image"))
+				})
+
+				It("does not count against the health of the worker", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(fakeWorker.RecordHealthCallCount()).To(BeZero())
+				})
 			})
 
 			It("returns a successful GetResult and volume with fetched bits", func() {
@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// healthFlushInterval is how long the outcomes of health events on a worker
+// are aggregated in memory before they are written to the database.
+const healthFlushInterval = time.Minute
+
+// HealthRecorder aggregates the outcomes of health events on workers in
+// memory, so that the database is not written to on every container creation,
+// volume stream and step.
+//
+// The outcomes of a worker are written once they have been pending for the
+// flush interval, or right away when they may get the worker quarantined.
+// Outcomes of a worker which sees no further events stay pending until it
+// does.
+type HealthRecorder struct {
+	clock      clock.Clock
+	quarantine db.WorkerQuarantine
+
+	pendingLock sync.Mutex
+	pending     map[string]*pendingHealth
+}
+
+type pendingHealth struct {
+	outcomes db.WorkerHealthOutcomes
+	since    time.Time
+}
+
+func NewHealthRecorder(clock clock.Clock, quarantine db.WorkerQuarantine) *HealthRecorder {
+	return &HealthRecorder{
+		clock:      clock,
+		quarantine: quarantine,
+		pending:    map[string]*pendingHealth{},
+	}
+}
+
+// Record adds the outcome of an event on the worker, returning whether the
+// worker is quarantined.
+func (recorder *HealthRecorder) Record(dbWorker db.Worker, event db.WorkerHealthEvent, failed bool) (bool, error) {
+	outcomes, flush := recorder.add(dbWorker, db.WorkerHealthOutcome{
+		Event:  event,
+		Failed: failed,
+	})
+	if !flush {
+		return dbWorker.Quarantined(), nil
+	}
+
+	return dbWorker.RecordHealth(outcomes, recorder.quarantine)
+}
+
+func (recorder *HealthRecorder) add(dbWorker db.Worker, outcome db.WorkerHealthOutcome) (db.WorkerHealthOutcomes, bool) {
+	recorder.pendingLock.Lock()
+	defer recorder.pendingLock.Unlock()
+
+	pending, found := recorder.pending[dbWorker.Name()]
+	if !found {
+		pending = &pendingHealth{since: recorder.clock.Now()}
+		recorder.pending[dbWorker.Name()] = pending
+	}
+
+	pending.outcomes = append(pending.outcomes, outcome)
+
+	flush := recorder.clock.Since(pending.since) >= healthFlushInterval ||
+		(outcome.Failed && recorder.mayQuarantine(dbWorker, pending.outcomes))
+	if !flush {
+		return nil, false
+	}
+
+	delete(recorder.pending, dbWorker.Name())
+
+	return pending.outcomes, true
+}
+
+func (recorder *HealthRecorder) mayQuarantine(dbWorker db.Worker, outcomes db.WorkerHealthOutcomes) bool {
+	if recorder.quarantine.Threshold <= 0 || dbWorker.Quarantined() {
+		return false
+	}
+
+	return outcomes.Score(dbWorker.HealthScore()) < recorder.quarantine.Threshold
+}
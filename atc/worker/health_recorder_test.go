@@ -0,0 +1,146 @@
+package worker_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HealthRecorder", func() {
+	var (
+		fakeClock    *fakeclock.FakeClock
+		fakeDBWorker *dbfakes.FakeWorker
+		quarantine   db.WorkerQuarantine
+
+		recorder *HealthRecorder
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		fakeDBWorker = new(dbfakes.FakeWorker)
+		fakeDBWorker.NameReturns("some-worker")
+		fakeDBWorker.HealthScoreReturns(1)
+
+		quarantine = db.WorkerQuarantine{Threshold: 0.5, MaxFraction: 0.5}
+	})
+
+	JustBeforeEach(func() {
+		recorder = NewHealthRecorder(fakeClock, quarantine)
+	})
+
+	It("aggregates outcomes in memory", func() {
+		_, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, false)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = recorder.Record(fakeDBWorker, db.WorkerHealthStep, true)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeDBWorker.RecordHealthCallCount()).To(BeZero())
+	})
+
+	It("writes the outcomes once they have been pending for the flush interval", func() {
+		_, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, true)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeClock.Increment(time.Minute)
+
+		_, err = recorder.Record(fakeDBWorker, db.WorkerHealthVolumeStreaming, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeDBWorker.RecordHealthCallCount()).To(Equal(1))
+		outcomes, actualQuarantine := fakeDBWorker.RecordHealthArgsForCall(0)
+		Expect(outcomes).To(Equal(db.WorkerHealthOutcomes{
+			{Event: db.WorkerHealthStep, Failed: true},
+			{Event: db.WorkerHealthVolumeStreaming, Failed: false},
+		}))
+		Expect(actualQuarantine).To(Equal(quarantine))
+
+		By("starting over afterwards")
+		_, err = recorder.Record(fakeDBWorker, db.WorkerHealthStep, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeDBWorker.RecordHealthCallCount()).To(Equal(1))
+	})
+
+	It("aggregates the outcomes of each worker separately", func() {
+		otherDBWorker := new(dbfakes.FakeWorker)
+		otherDBWorker.NameReturns("other-worker")
+
+		_, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeClock.Increment(time.Minute)
+
+		_, err = recorder.Record(otherDBWorker, db.WorkerHealthStep, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeDBWorker.RecordHealthCallCount()).To(BeZero())
+		Expect(otherDBWorker.RecordHealthCallCount()).To(BeZero())
+	})
+
+	Context("when a failure may get the worker quarantined", func() {
+		BeforeEach(func() {
+			fakeDBWorker.HealthScoreReturns(0.5)
+			fakeDBWorker.RecordHealthReturns(true, nil)
+		})
+
+		It("writes the outcomes right away", func() {
+			_, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeDBWorker.RecordHealthCallCount()).To(BeZero())
+
+			quarantined, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quarantined).To(BeTrue())
+
+			Expect(fakeDBWorker.RecordHealthCallCount()).To(Equal(1))
+			outcomes, _ := fakeDBWorker.RecordHealthArgsForCall(0)
+			Expect(outcomes).To(HaveLen(2))
+		})
+
+		Context("when the worker is already quarantined", func() {
+			BeforeEach(func() {
+				fakeDBWorker.QuarantinedReturns(true)
+			})
+
+			It("keeps aggregating", func() {
+				quarantined, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(quarantined).To(BeTrue())
+				Expect(fakeDBWorker.RecordHealthCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when quarantining is disabled", func() {
+			BeforeEach(func() {
+				quarantine = db.WorkerQuarantine{}
+			})
+
+			It("keeps aggregating", func() {
+				_, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeDBWorker.RecordHealthCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when writing the outcomes fails", func() {
+		BeforeEach(func() {
+			fakeDBWorker.RecordHealthReturns(false, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			_, err := recorder.Record(fakeDBWorker, db.WorkerHealthStep, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeClock.Increment(time.Minute)
+			_, err = recorder.Record(fakeDBWorker, db.WorkerHealthStep, false)
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+})
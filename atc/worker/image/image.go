@@ -3,8 +3,10 @@ package image
 import (
 	"context"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"path"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
@@ -132,6 +134,141 @@ func (i *imageProvidedByPreviousStepOnDifferentWorker) FetchForContainer(
 	}, nil
 }
 
+type imageFromLayerStore struct {
+	worker       worker.Worker
+	imageSpec    worker.ImageSpec
+	teamID       int
+	volumeClient worker.VolumeClient
+}
+
+func (i *imageFromLayerStore) FetchForContainer(
+	ctx context.Context,
+	logger lager.Logger,
+	container db.CreatingContainer,
+) (worker.FetchedImage, error) {
+	ctx, span := tracing.StartSpan(ctx, "imageFromLayerStore.FetchForContainer", tracing.Attrs{
+		"container_id": container.Handle(),
+		"digest":       i.imageSpec.ImageDigest,
+	})
+	defer span.End()
+
+	storeVolume, found, err := i.volumeClient.FindVolumeForLayerStore(
+		logger,
+		i.imageSpec.ImageDigest,
+		i.imageSpec.Privileged,
+	)
+	if err != nil {
+		logger.Error("failed-to-find-layer-store-volume", err)
+		return worker.FetchedImage{}, err
+	}
+
+	if !found {
+		source, err := i.imageSpec.FetchImageArtifact(ctx)
+		if err != nil {
+			logger.Error("failed-to-fetch-image-artifact", err)
+			return worker.FetchedImage{}, err
+		}
+
+		artifactVolume, existsOnWorker, err := source.ExistsOn(logger, i.worker)
+		if err != nil {
+			logger.Error("failed-to-check-if-volume-exists-on-worker", err)
+			return worker.FetchedImage{}, err
+		}
+
+		imageSpec := i.imageSpec
+		imageSpec.ImageArtifactSource = source
+
+		// fetched onto this very worker, so a copy-on-write volume of the
+		// fetched image is cheaper than copying it into the store
+		if existsOnWorker {
+			sameWorkerImage := &imageProvidedByPreviousStepOnSameWorker{
+				artifactVolume: artifactVolume,
+				imageSpec:      imageSpec,
+				teamID:         i.teamID,
+				volumeClient:   i.volumeClient,
+			}
+
+			return sameWorkerImage.FetchForContainer(ctx, logger, container)
+		}
+
+		storeVolume, err = i.volumeClient.FindOrCreateVolumeForLayerStore(
+			logger,
+			i.imageSpec.ImageDigest,
+			i.imageSpec.Privileged,
+			func(volume baggageclaim.Volume) error {
+				return populateLayerStoreVolume(ctx, source, volume)
+			},
+		)
+		if err != nil {
+			logger.Error("failed-to-find-or-create-layer-store-volume", err)
+			return worker.FetchedImage{}, err
+		}
+	}
+
+	imageVolume, err := i.volumeClient.FindOrCreateVolumeForContainer(
+		logger,
+		worker.VolumeSpec{
+			Strategy: baggageclaim.COWStrategy{Parent: storeVolume},
+			Properties: worker.VolumeProperties{
+				atc.LayerStoreParentProperty: storeVolume.Handle(),
+			},
+			Privileged: i.imageSpec.Privileged,
+		},
+		container,
+		i.teamID,
+		"/",
+	)
+	if err != nil {
+		logger.Error("failed-to-create-layer-store-cow-volume", err)
+		return worker.FetchedImage{}, err
+	}
+
+	properties, err := storeVolume.Properties()
+	if err != nil {
+		logger.Error("failed-to-get-layer-store-volume-properties", err)
+		return worker.FetchedImage{}, err
+	}
+
+	metadata, err := loadMetadata(ioutil.NopCloser(strings.NewReader(properties[atc.LayerStoreMetadataProperty])))
+	if err != nil {
+		return worker.FetchedImage{}, err
+	}
+
+	imageURL := url.URL{
+		Scheme: RawRootFSScheme,
+		Path:   path.Join(imageVolume.Path(), "rootfs"),
+	}
+
+	return worker.FetchedImage{
+		Metadata:   metadata,
+		URL:        imageURL.String(),
+		Privileged: i.imageSpec.Privileged,
+	}, nil
+}
+
+// populateLayerStoreVolume streams the image into the layer store volume and
+// keeps its metadata in the volume's properties.
+func populateLayerStoreVolume(ctx context.Context, source worker.StreamableArtifactSource, volume baggageclaim.Volume) error {
+	err := source.StreamTo(ctx, volume)
+	if err != nil {
+		return err
+	}
+
+	imageMetadataReader, err := source.StreamFile(ctx, ImageMetadataFile)
+	if err != nil {
+		return err
+	}
+
+	defer imageMetadataReader.Close()
+
+	metadata, err := ioutil.ReadAll(imageMetadataReader)
+	if err != nil {
+		return err
+	}
+
+	return volume.SetProperty(atc.LayerStoreMetadataProperty, string(metadata))
+}
+
 type imageFromBaseResourceType struct {
 	worker           worker.Worker
 	resourceTypeName string
@@ -22,6 +22,15 @@ func (f *imageFactory) GetImage(
 	imageSpec worker.ImageSpec,
 	teamID int,
 ) (worker.Image, error) {
+	if imageSpec.ImageDigest != "" {
+		return &imageFromLayerStore{
+			worker:       worker,
+			imageSpec:    imageSpec,
+			teamID:       teamID,
+			volumeClient: volumeClient,
+		}, nil
+	}
+
 	if imageSpec.ImageArtifactSource != nil {
 		artifactVolume, existsOnWorker, err := imageSpec.ImageArtifactSource.ExistsOn(logger, worker)
 		if err != nil {
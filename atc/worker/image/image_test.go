@@ -106,6 +106,197 @@ var _ = Describe("Image", func() {
 		})
 	})
 
+	Describe("imageFromLayerStore", func() {
+		var (
+			fakeImageArtifactSource   *workerfakes.FakeStreamableArtifactSource
+			fetchCount                int
+			fakeStoreVolume           *baggageclaimfakes.FakeVolume
+			fakeContainerRootfsVolume *workerfakes.FakeVolume
+
+			fetchedImage worker.FetchedImage
+			fetchErr     error
+		)
+
+		BeforeEach(func() {
+			fakeImageArtifactSource = new(workerfakes.FakeStreamableArtifactSource)
+			metadataReader := ioutil.NopCloser(strings.NewReader(
+				`{"env": ["A=1", "B=2"], "user":"image-volume-user"}`,
+			))
+			fakeImageArtifactSource.StreamFileReturns(metadataReader, nil)
+			fetchCount = 0
+
+			fakeStoreVolume = new(baggageclaimfakes.FakeVolume)
+			fakeStoreVolume.HandleReturns("store-handle")
+			fakeStoreVolume.PropertiesReturns(baggageclaim.VolumeProperties{
+				atc.LayerStoreMetadataProperty: `{"env": ["A=1", "B=2"], "user":"image-volume-user"}`,
+			}, nil)
+			fakeVolumeClient.FindVolumeForLayerStoreReturns(fakeStoreVolume, true, nil)
+
+			fakeContainerRootfsVolume = new(workerfakes.FakeVolume)
+			fakeContainerRootfsVolume.PathReturns("some-path")
+			fakeVolumeClient.FindOrCreateVolumeForContainerReturns(fakeContainerRootfsVolume, nil)
+
+			var err error
+			img, err = imageFactory.GetImage(
+				logger,
+				fakeWorker,
+				fakeVolumeClient,
+				worker.ImageSpec{
+					ImageDigest: "sha256:some-digest",
+					FetchImageArtifact: func(context.Context) (worker.StreamableArtifactSource, error) {
+						fetchCount++
+						return fakeImageArtifactSource, nil
+					},
+					Privileged: true,
+				},
+				42,
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			fetchedImage, fetchErr = img.FetchForContainer(ctx, logger, fakeContainer)
+		})
+
+		It("looks up the layer store volume for the digest", func() {
+			Expect(fakeVolumeClient.FindVolumeForLayerStoreCallCount()).To(Equal(1))
+			_, digest, privileged := fakeVolumeClient.FindVolumeForLayerStoreArgsForCall(0)
+			Expect(digest).To(Equal("sha256:some-digest"))
+			Expect(privileged).To(BeTrue())
+		})
+
+		It("does not fetch the image", func() {
+			Expect(fetchCount).To(BeZero())
+			Expect(fakeVolumeClient.FindOrCreateVolumeForLayerStoreCallCount()).To(BeZero())
+		})
+
+		It("creates a cow volume of the layer store volume for the container", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+
+			Expect(fakeVolumeClient.FindOrCreateVolumeForContainerCallCount()).To(Equal(1))
+			_, volumeSpec, container, teamID, path := fakeVolumeClient.FindOrCreateVolumeForContainerArgsForCall(0)
+			Expect(volumeSpec).To(Equal(worker.VolumeSpec{
+				Strategy: baggageclaim.COWStrategy{Parent: fakeStoreVolume},
+				Properties: worker.VolumeProperties{
+					atc.LayerStoreParentProperty: "store-handle",
+				},
+				Privileged: true,
+			}))
+			Expect(container).To(Equal(fakeContainer))
+			Expect(teamID).To(Equal(42))
+			Expect(path).To(Equal("/"))
+		})
+
+		It("returns fetched image with the metadata kept in the layer store volume", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+
+			Expect(fetchedImage).To(Equal(worker.FetchedImage{
+				Metadata: worker.ImageMetadata{
+					Env:  []string{"A=1", "B=2"},
+					User: "image-volume-user",
+				},
+				URL:        "raw://some-path/rootfs",
+				Privileged: true,
+			}))
+			Expect(fakeImageArtifactSource.StreamFileCallCount()).To(BeZero())
+		})
+
+		Context("when the layer store does not hold the image", func() {
+			BeforeEach(func() {
+				fakeVolumeClient.FindVolumeForLayerStoreReturns(nil, false, nil)
+				fakeVolumeClient.FindOrCreateVolumeForLayerStoreReturns(fakeStoreVolume, nil)
+			})
+
+			It("fetches the image", func() {
+				Expect(fetchErr).NotTo(HaveOccurred())
+				Expect(fetchCount).To(Equal(1))
+			})
+
+			It("finds or creates the layer store volume for the digest", func() {
+				Expect(fakeVolumeClient.FindOrCreateVolumeForLayerStoreCallCount()).To(Equal(1))
+				_, digest, privileged, _ := fakeVolumeClient.FindOrCreateVolumeForLayerStoreArgsForCall(0)
+				Expect(digest).To(Equal("sha256:some-digest"))
+				Expect(privileged).To(BeTrue())
+			})
+
+			It("populates the layer store volume by streaming the artifact and keeping its metadata", func() {
+				_, _, _, populate := fakeVolumeClient.FindOrCreateVolumeForLayerStoreArgsForCall(0)
+				Expect(fakeImageArtifactSource.StreamToCallCount()).To(Equal(0))
+
+				populatedVolume := new(baggageclaimfakes.FakeVolume)
+				Expect(populate(populatedVolume)).To(Succeed())
+
+				Expect(fakeImageArtifactSource.StreamToCallCount()).To(Equal(1))
+				_, dest := fakeImageArtifactSource.StreamToArgsForCall(0)
+				Expect(dest).To(Equal(populatedVolume))
+
+				Expect(populatedVolume.SetPropertyCallCount()).To(Equal(1))
+				key, value := populatedVolume.SetPropertyArgsForCall(0)
+				Expect(key).To(Equal(atc.LayerStoreMetadataProperty))
+				Expect(value).To(MatchJSON(`{"env": ["A=1", "B=2"], "user":"image-volume-user"}`))
+			})
+
+			It("creates a cow volume of the layer store volume for the container", func() {
+				Expect(fakeVolumeClient.FindOrCreateVolumeForContainerCallCount()).To(Equal(1))
+				_, volumeSpec, _, _, _ := fakeVolumeClient.FindOrCreateVolumeForContainerArgsForCall(0)
+				Expect(volumeSpec.Strategy).To(Equal(baggageclaim.COWStrategy{Parent: fakeStoreVolume}))
+			})
+
+			Context("when the image was fetched onto the same worker", func() {
+				var fakeArtifactVolume *workerfakes.FakeVolume
+				var cowStrategy baggageclaim.COWStrategy
+
+				BeforeEach(func() {
+					fakeArtifactVolume = new(workerfakes.FakeVolume)
+					cowStrategy = baggageclaim.COWStrategy{
+						Parent: new(baggageclaimfakes.FakeVolume),
+					}
+					fakeArtifactVolume.COWStrategyReturns(cowStrategy)
+					fakeImageArtifactSource.ExistsOnReturns(fakeArtifactVolume, true, nil)
+
+					fakeVolumeClient.FindOrCreateCOWVolumeForContainerReturns(fakeContainerRootfsVolume, nil)
+				})
+
+				It("creates a cow volume of the fetched artifact instead of populating the store", func() {
+					Expect(fetchErr).NotTo(HaveOccurred())
+					Expect(fakeVolumeClient.FindOrCreateVolumeForLayerStoreCallCount()).To(BeZero())
+
+					Expect(fakeVolumeClient.FindOrCreateCOWVolumeForContainerCallCount()).To(Equal(1))
+					_, volumeSpec, _, volume, _, _ := fakeVolumeClient.FindOrCreateCOWVolumeForContainerArgsForCall(0)
+					Expect(volumeSpec.Strategy).To(Equal(cowStrategy))
+					Expect(volume).To(Equal(fakeArtifactVolume))
+				})
+
+				It("returns fetched image", func() {
+					Expect(fetchedImage.URL).To(Equal("raw://some-path/rootfs"))
+					Expect(fetchedImage.Metadata.User).To(Equal("image-volume-user"))
+				})
+			})
+
+			Context("when looking for the fetched image on the worker fails", func() {
+				BeforeEach(func() {
+					fakeImageArtifactSource.ExistsOnReturns(nil, false, errors.New("some error"))
+				})
+
+				It("returns an error", func() {
+					Expect(fetchErr).To(HaveOccurred())
+					Expect(fakeVolumeClient.FindOrCreateVolumeForContainerCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the layer store volume cannot be created", func() {
+				BeforeEach(func() {
+					fakeVolumeClient.FindOrCreateVolumeForLayerStoreReturns(nil, errors.New("some error"))
+				})
+
+				It("returns an error", func() {
+					Expect(fetchErr).To(HaveOccurred())
+					Expect(fakeVolumeClient.FindOrCreateVolumeForContainerCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
 	Describe("imageProvidedByPreviousStepOnDifferentWorker", func() {
 		var (
 			fakeArtifactVolume        *workerfakes.FakeVolume
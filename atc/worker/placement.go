@@ -3,6 +3,7 @@ package worker
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"strings"
@@ -12,7 +13,7 @@ import (
 )
 
 type ContainerPlacementStrategyOptions struct {
-	ContainerPlacementStrategy   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
+	ContainerPlacementStrategy   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" choice:"healthiest" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
 	MaxActiveTasksPerWorker      int      `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	MaxActiveContainersPerWorker int      `long:"max-active-containers-per-worker" default:"0" description:"Maximum allowed number of active containers per worker. Has effect only when used with limit-active-containers placement strategy. 0 means no limit."`
 	MaxActiveVolumesPerWorker    int      `long:"max-active-volumes-per-worker" default:"0" description:"Maximum allowed number of active volumes per worker. Has effect only when used with limit-active-volumes placement strategy. 0 means no limit."`
@@ -95,6 +96,9 @@ func NewChainPlacementStrategy(opts ContainerPlacementStrategyOptions) (*ChainPl
 		case "volume-locality":
 			cps.nodes = append(cps.nodes, newVolumeLocalityStrategy(strategy))
 
+		case "healthiest":
+			cps.nodes = append(cps.nodes, newHealthiestStrategy(strategy))
+
 		default:
 			return nil, fmt.Errorf("invalid container placement strategy %s", strategy)
 		}
@@ -400,3 +404,42 @@ func (strategy *LimitActiveVolumesStrategy) Pick(logger lager.Logger, worker Wor
 
 func (strategy *LimitActiveVolumesStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
 }
+
+// Strategy which orders candidate workers based off their health score, so that
+// workers which have recently been failing to create containers, stream volumes
+// or run steps are only used when healthier ones are not available
+type HealthiestStrategy struct {
+	NamedPlacementStrategy
+}
+
+const healthScoreBuckets = 10
+
+func newHealthiestStrategy(name string) ContainerPlacementStrategy {
+	return &HealthiestStrategy{
+		NamedPlacementStrategy{name},
+	}
+}
+
+func (strategy *HealthiestStrategy) Order(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	candidates := append([]Worker(nil), workers...)
+	scores := make(map[Worker]float64, len(candidates))
+
+	// scores are bucketed so that workers which are about equally healthy are
+	// left for other strategies (or chance) to order
+	for _, worker := range workers {
+		scores[worker] = math.Floor(worker.HealthScore() * healthScoreBuckets)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i]] > scores[candidates[j]]
+	})
+
+	return candidates, nil
+}
+
+func (strategy *HealthiestStrategy) Pick(logger lager.Logger, worker Worker, spec ContainerSpec) error {
+	return nil
+}
+
+func (strategy *HealthiestStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
+}
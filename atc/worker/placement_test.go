@@ -676,6 +676,49 @@ var _ = Describe("ContainerPlacementStrategy", func() {
 		})
 	})
 
+	Describe("healthiest", func() {
+		JustBeforeEach(func() {
+			strategy, strategyErr = NewChainPlacementStrategy(ContainerPlacementStrategyOptions{
+				ContainerPlacementStrategy: []string{"healthiest"},
+			})
+			Expect(strategyErr).ToNot(HaveOccurred())
+		})
+
+		Describe("strategy.Order", func() {
+			JustBeforeEach(func() {
+				order(true)
+			})
+
+			Context("with multiple workers", func() {
+				BeforeEach(func() {
+					workerFakes[0].HealthScoreReturns(0.5)
+					workerFakes[1].HealthScoreReturns(0.1)
+					workerFakes[2].HealthScoreReturns(1)
+				})
+
+				It("orders workers from the healthiest to the least healthy", func() {
+					Expect(orderedWorkers).To(Equal([]Worker{workers[2], workers[0], workers[1]}))
+				})
+
+				Context("when multiple are about equally healthy", func() {
+					BeforeEach(func() {
+						workerFakes[0].HealthScoreReturns(0.98)
+						workerFakes[2].HealthScoreReturns(0.95)
+					})
+
+					It("orders them randomly", func() {
+						Consistently(func() []Worker {
+							return order(true)
+						}).Should(SatisfyAny(
+							Equal([]Worker{workers[0], workers[2], workers[1]}),
+							Equal([]Worker{workers[2], workers[0], workers[1]}),
+						))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("Chained placement strategy", func() {
 		Describe("strategy.Order", func() {
 			Context("fewest-build-containers,volume-locality", func() {
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/hashicorp/go-multierror"
@@ -18,6 +19,10 @@ import (
 
 const WorkerPollingInterval = 5 * time.Second
 
+// WaitingStepTTL is how long a step waiting for a worker continues to count
+// towards the demand for workers without being heartbeated.
+const WaitingStepTTL = 3 * WorkerPollingInterval
+
 //go:generate counterfeiter . Pool
 
 type NoCompatibleWorkersError struct {
@@ -28,6 +33,47 @@ func (err NoCompatibleWorkersError) Error() string {
 	return fmt.Sprintf("no workers satisfying: %s", err.Spec.Description())
 }
 
+// PrivilegedOnRootlessWorkersError is returned when a privileged container
+// could only be placed on rootless workers, which are unable to run them.
+type PrivilegedOnRootlessWorkersError struct {
+	Spec WorkerSpec
+}
+
+func (err PrivilegedOnRootlessWorkersError) Error() string {
+	return fmt.Sprintf(
+		"privileged containers are not supported by rootless workers, and all workers satisfying %s are rootless",
+		err.Spec.Description(),
+	)
+}
+
+// SecretFilesNotSupportedError is returned when a container with secret files
+// could only be placed on workers unable to keep them off the disk.
+type SecretFilesNotSupportedError struct {
+	Spec WorkerSpec
+}
+
+func (err SecretFilesNotSupportedError) Error() string {
+	return fmt.Sprintf(
+		"secret files are only supported by workers running the containerd runtime without rootless mode, and no workers satisfying %s do",
+		err.Spec.Description(),
+	)
+}
+
+// UnsupportedContainerLimitsError is returned when none of the workers
+// satisfying a spec are able to enforce the limits set on the container.
+type UnsupportedContainerLimitsError struct {
+	Spec   WorkerSpec
+	Limits []string
+}
+
+func (err UnsupportedContainerLimitsError) Error() string {
+	return fmt.Sprintf(
+		"no workers satisfying %s support %s container limits",
+		err.Spec.Description(),
+		strings.Join(err.Limits, ", "),
+	)
+}
+
 type Pool interface {
 	FindContainer(lager.Logger, int, string) (Container, bool, error)
 	VolumeFinder
@@ -63,14 +109,16 @@ type VolumeFinder interface {
 }
 
 type pool struct {
-	provider WorkerProvider
-	waker    chan bool
+	provider           WorkerProvider
+	waitingStepFactory db.WaitingStepFactory
+	waker              chan bool
 }
 
-func NewPool(provider WorkerProvider) Pool {
+func NewPool(provider WorkerProvider, waitingStepFactory db.WaitingStepFactory) Pool {
 	return &pool{
-		provider: provider,
-		waker:    make(chan bool),
+		provider:           provider,
+		waitingStepFactory: waitingStepFactory,
+		waker:              make(chan bool),
 	}
 }
 
@@ -87,6 +135,10 @@ func (pool *pool) allSatisfying(logger lager.Logger, spec WorkerSpec) ([]Worker,
 	compatibleTeamWorkers := []Worker{}
 	compatibleGeneralWorkers := []Worker{}
 	for _, worker := range workers {
+		if worker.Quarantined() {
+			continue
+		}
+
 		compatible := worker.Satisfies(logger, spec)
 		if compatible {
 			if worker.IsOwnedByTeam() {
@@ -106,6 +158,69 @@ func (pool *pool) allSatisfying(logger lager.Logger, spec WorkerSpec) ([]Worker,
 	return compatibleGeneralWorkers, nil
 }
 
+func withoutRootless(workers []Worker) []Worker {
+	rootful := []Worker{}
+	for _, worker := range workers {
+		if !worker.Rootless() {
+			rootful = append(rootful, worker)
+		}
+	}
+
+	return rootful
+}
+
+func withSupportedLimits(workers []Worker, required []string) []Worker {
+	supporting := []Worker{}
+	for _, worker := range workers {
+		if supportsLimits(worker, required) {
+			supporting = append(supporting, worker)
+		}
+	}
+
+	return supporting
+}
+
+func supportsLimits(worker Worker, required []string) bool {
+	supported := worker.SupportedContainerLimits()
+
+	for _, limit := range required {
+		found := false
+		for _, s := range supported {
+			if s == limit {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func withFeature(workers []Worker, feature string) []Worker {
+	featured := []Worker{}
+	for _, worker := range workers {
+		if hasFeature(worker.Features(), feature) {
+			featured = append(featured, worker)
+		}
+	}
+
+	return featured
+}
+
+func hasFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (pool *pool) findWorkerWithContainer(
 	logger lager.Logger,
 	compatible []Worker,
@@ -179,6 +294,31 @@ func (pool *pool) findWorker(
 		return nil, nil
 	}
 
+	if containerSpec.ImageSpec.Privileged {
+		compatibleWorkers = withoutRootless(compatibleWorkers)
+		if len(compatibleWorkers) == 0 {
+			return nil, PrivilegedOnRootlessWorkersError{Spec: workerSpec}
+		}
+	}
+
+	requiredLimits := containerSpec.Limits.Required()
+	if len(requiredLimits) > 0 {
+		compatibleWorkers = withSupportedLimits(compatibleWorkers, requiredLimits)
+		if len(compatibleWorkers) == 0 {
+			return nil, UnsupportedContainerLimitsError{
+				Spec:   workerSpec,
+				Limits: requiredLimits,
+			}
+		}
+	}
+
+	if len(containerSpec.SecretFiles) > 0 {
+		compatibleWorkers = withFeature(compatibleWorkers, atc.WorkerFeatureSecretFiles)
+		if len(compatibleWorkers) == 0 {
+			return nil, SecretFilesNotSupportedError{Spec: workerSpec}
+		}
+	}
+
 	worker, err := pool.findWorkerWithContainer(
 		logger,
 		compatibleWorkers,
@@ -270,6 +410,7 @@ func (pool *pool) SelectWorker(
 
 	var worker Client
 	var pollingTicker *time.Ticker
+	var waitingStep db.WaitingStep
 	for {
 		var err error
 		worker, err = pool.findWorker(ctx, owner, containerSpec, workerSpec, strategy)
@@ -296,6 +437,11 @@ func (pool *pool) SelectWorker(
 			metric.Metrics.StepsWaiting[labels].Inc()
 			defer metric.Metrics.StepsWaiting[labels].Dec()
 
+			waitingStep = pool.startWaiting(logger, workerSpec)
+			if waitingStep != nil {
+				defer pool.stopWaiting(logger, waitingStep)
+			}
+
 			if callbacks != nil {
 				callbacks.WaitingForWorker(logger)
 			}
@@ -308,6 +454,13 @@ func (pool *pool) SelectWorker(
 		case <-pollingTicker.C:
 		case <-pool.waker:
 		}
+
+		if waitingStep != nil {
+			err = waitingStep.Heartbeat(WaitingStepTTL)
+			if err != nil {
+				logger.Error("failed-to-heartbeat-waiting-step", err)
+			}
+		}
 	}
 
 	elapsed := time.Since(started)
@@ -319,6 +472,30 @@ func (pool *pool) SelectWorker(
 	return worker, elapsed, nil
 }
 
+// startWaiting records that a step is waiting for a worker, so that it counts
+// towards the demand for workers. Failing to do so is only logged, as it
+// should not hold up the step.
+func (pool *pool) startWaiting(logger lager.Logger, workerSpec WorkerSpec) db.WaitingStep {
+	waitingStep, err := pool.waitingStepFactory.CreateWaitingStep(db.WaitingStepSpec{
+		Platform: workerSpec.Platform,
+		Tags:     workerSpec.Tags,
+		TeamID:   workerSpec.TeamID,
+	}, WaitingStepTTL)
+	if err != nil {
+		logger.Error("failed-to-create-waiting-step", err)
+		return nil
+	}
+
+	return waitingStep
+}
+
+func (pool *pool) stopWaiting(logger lager.Logger, waitingStep db.WaitingStep) {
+	err := waitingStep.Delete()
+	if err != nil {
+		logger.Error("failed-to-delete-waiting-step", err)
+	}
+}
+
 func (pool *pool) ReleaseWorker(
 	ctx context.Context,
 	containerSpec ContainerSpec,
@@ -22,8 +22,10 @@ import (
 
 var _ = Describe("Pool", func() {
 	var (
-		logger       *lagertest.TestLogger
-		fakeProvider *workerfakes.FakeWorkerProvider
+		logger                 *lagertest.TestLogger
+		fakeProvider           *workerfakes.FakeWorkerProvider
+		fakeWaitingStepFactory *dbfakes.FakeWaitingStepFactory
+		fakeWaitingStep        *dbfakes.FakeWaitingStep
 
 		pool Pool
 	)
@@ -31,8 +33,11 @@ var _ = Describe("Pool", func() {
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
+		fakeWaitingStepFactory = new(dbfakes.FakeWaitingStepFactory)
+		fakeWaitingStep = new(dbfakes.FakeWaitingStep)
+		fakeWaitingStepFactory.CreateWaitingStepReturns(fakeWaitingStep, nil)
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(fakeProvider, fakeWaitingStepFactory)
 	})
 
 	Describe("FindContainer", func() {
@@ -405,6 +410,22 @@ var _ = Describe("Pool", func() {
 					})
 				})
 
+				Context("when some of the satisfying workers are quarantined", func() {
+					BeforeEach(func() {
+						workerFakes[0].SatisfiesReturns(true)
+						workerFakes[1].SatisfiesReturns(true)
+						workerFakes[1].QuarantinedReturns(true)
+						workerFakes[2].SatisfiesReturns(true)
+
+						fakeProvider.RunningWorkersReturns(workers, nil)
+					})
+
+					It("excludes the quarantined workers", func() {
+						_, satisfyingWorkers, _ := fakeStrategy.OrderArgsForCall(0)
+						Expect(satisfyingWorkers).To(ConsistOf(workers[0], workers[2]))
+					})
+				})
+
 				Context("when team workers and general workers satisfy the spec", func() {
 					BeforeEach(func() {
 						extraFake := new(workerfakes.FakeWorker)
@@ -451,6 +472,131 @@ var _ = Describe("Pool", func() {
 					})
 				})
 
+				Context("when the container is privileged", func() {
+					BeforeEach(func() {
+						containerSpec.ImageSpec.Privileged = true
+
+						workerFakes[0].SatisfiesReturns(true)
+						workerFakes[1].SatisfiesReturns(true)
+						workerFakes[2].SatisfiesReturns(false)
+
+						fakeProvider.RunningWorkersReturns(workers, nil)
+					})
+
+					Context("when some of the satisfying workers are rootless", func() {
+						BeforeEach(func() {
+							workerFakes[0].RootlessReturns(true)
+						})
+
+						It("excludes the rootless workers", func() {
+							_, satisfyingWorkers, _ := fakeStrategy.OrderArgsForCall(0)
+							Expect(satisfyingWorkers).To(ConsistOf(workerFakes[1]))
+						})
+					})
+
+					Context("when all of the satisfying workers are rootless", func() {
+						BeforeEach(func() {
+							workerFakes[0].RootlessReturns(true)
+							workerFakes[1].RootlessReturns(true)
+						})
+
+						It("returns an error", func() {
+							Expect(selectErr).To(Equal(PrivilegedOnRootlessWorkersError{Spec: workerSpec}))
+							Expect(selectErr.Error()).To(ContainSubstring("privileged containers are not supported by rootless workers"))
+						})
+
+						It("does not try to place the container", func() {
+							Expect(fakeStrategy.OrderCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the container has limits", func() {
+					BeforeEach(func() {
+						pids := uint64(100)
+						readBPS := uint64(1024)
+						containerSpec.Limits = ContainerLimits{
+							Pids:      &pids,
+							IOReadBPS: &readBPS,
+						}
+
+						workerFakes[0].SatisfiesReturns(true)
+						workerFakes[1].SatisfiesReturns(true)
+						workerFakes[2].SatisfiesReturns(false)
+
+						fakeProvider.RunningWorkersReturns(workers, nil)
+					})
+
+					Context("when some of the satisfying workers support the limits", func() {
+						BeforeEach(func() {
+							workerFakes[0].SupportedContainerLimitsReturns([]string{"cpu", "memory"})
+							workerFakes[1].SupportedContainerLimitsReturns([]string{"cpu", "memory", "pids", "io"})
+						})
+
+						It("excludes the workers which do not support them", func() {
+							_, satisfyingWorkers, _ := fakeStrategy.OrderArgsForCall(0)
+							Expect(satisfyingWorkers).To(ConsistOf(workerFakes[1]))
+						})
+					})
+
+					Context("when none of the satisfying workers support the limits", func() {
+						BeforeEach(func() {
+							workerFakes[0].SupportedContainerLimitsReturns([]string{"cpu", "memory", "pids"})
+							workerFakes[1].SupportedContainerLimitsReturns([]string{"cpu", "memory"})
+						})
+
+						It("returns an error", func() {
+							Expect(selectErr).To(Equal(UnsupportedContainerLimitsError{
+								Spec:   workerSpec,
+								Limits: []string{"pids", "io"},
+							}))
+							Expect(selectErr.Error()).To(ContainSubstring("support pids, io container limits"))
+						})
+
+						It("does not try to place the container", func() {
+							Expect(fakeStrategy.OrderCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the container has secret files", func() {
+					BeforeEach(func() {
+						containerSpec.SecretFiles = map[string]string{"some-key": "some-secret"}
+
+						workerFakes[0].SatisfiesReturns(true)
+						workerFakes[1].SatisfiesReturns(true)
+						workerFakes[2].SatisfiesReturns(false)
+
+						fakeProvider.RunningWorkersReturns(workers, nil)
+					})
+
+					Context("when some of the satisfying workers support secret files", func() {
+						BeforeEach(func() {
+							workerFakes[1].FeaturesReturns([]string{atc.WorkerFeatureSecretFiles})
+						})
+
+						It("excludes the workers which do not", func() {
+							_, satisfyingWorkers, _ := fakeStrategy.OrderArgsForCall(0)
+							Expect(satisfyingWorkers).To(ConsistOf(workerFakes[1]))
+						})
+					})
+
+					Context("when none of the satisfying workers support secret files", func() {
+						BeforeEach(func() {
+							workerFakes[0].FeaturesReturns([]string{atc.WorkerFeatureDeltaVolumeStreaming})
+						})
+
+						It("returns an error", func() {
+							Expect(selectErr).To(Equal(SecretFilesNotSupportedError{Spec: workerSpec}))
+							Expect(selectErr.Error()).To(ContainSubstring("secret files are only supported by workers running the containerd runtime"))
+						})
+
+						It("does not try to place the container", func() {
+							Expect(fakeStrategy.OrderCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("with compatible workers available", func() {
 					BeforeEach(func() {
 						workerFakes[0].SatisfiesReturns(true)
@@ -483,6 +629,10 @@ var _ = Describe("Pool", func() {
 							Expect(fakeStrategy.OrderCallCount()).To(Equal(1))
 							Expect(selectedWorker.Name()).To(Equal(workers[0].Name()))
 						})
+
+						It("does not count the step as waiting", func() {
+							Expect(fakeWaitingStepFactory.CreateWaitingStepCallCount()).To(BeZero())
+						})
 					})
 
 					Context("when strategy returns multiple workers", func() {
@@ -561,6 +711,20 @@ var _ = Describe("Pool", func() {
 					Expect(selectErr).To(Equal(selectCtx.Err()))
 					Expect(fakeProvider.RunningWorkersCallCount()).To(Equal(2))
 				})
+
+				It("counts the step as waiting while it polls", func() {
+					Expect(fakeWaitingStepFactory.CreateWaitingStepCallCount()).To(Equal(1))
+					spec, ttl := fakeWaitingStepFactory.CreateWaitingStepArgsForCall(0)
+					Expect(spec).To(Equal(db.WaitingStepSpec{
+						Platform: workerSpec.Platform,
+						Tags:     workerSpec.Tags,
+						TeamID:   workerSpec.TeamID,
+					}))
+					Expect(ttl).To(Equal(WaitingStepTTL))
+
+					Expect(fakeWaitingStep.HeartbeatCallCount()).To(Equal(1))
+					Expect(fakeWaitingStep.DeleteCallCount()).To(Equal(1))
+				})
 			})
 
 			Context("with no compatible workers available", func() {
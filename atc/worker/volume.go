@@ -2,14 +2,26 @@ package worker
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"github.com/concourse/concourse/tracing"
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/volumedelta"
 )
 
+// ErrDeltaStreamingNotSupported is returned when streaming a delta to or from
+// a volume on a worker which does not advertise
+// atc.WorkerFeatureDeltaVolumeStreaming.
+var ErrDeltaStreamingNotSupported = errors.New("worker does not support delta volume streaming")
+
 //go:generate counterfeiter . Volume
 
 type Volume interface {
@@ -27,12 +39,24 @@ type Volume interface {
 	GetStreamInP2pUrl(ctx context.Context, path string) (string, error)
 	StreamP2pOut(ctx context.Context, path string, destUrl string, encoding baggageclaim.Encoding) error
 
+	SupportsDeltaStreaming() bool
+	DeltaManifest(ctx context.Context) (io.ReadCloser, error)
+	DeltaStreamOut(ctx context.Context, encoding baggageclaim.Encoding, base io.Reader) (io.ReadCloser, error)
+	DeltaStreamIn(ctx context.Context, encoding baggageclaim.Encoding, delta io.Reader) error
+
+	GetDeltaP2pUrl(ctx context.Context) (string, error)
+	DeltaStreamP2pOut(ctx context.Context, destUrl string, destHandle string, encoding baggageclaim.Encoding) error
+
 	COWStrategy() baggageclaim.COWStrategy
 
 	InitializeResourceCache(db.UsedResourceCache) error
+	RecordResourceCacheChecksum(context.Context) error
+	VerifyResourceCache(ctx context.Context, logger lager.Logger, period time.Duration) (bool, error)
 	GetResourceCacheID() int
 	InitializeTaskCache(logger lager.Logger, jobID int, stepName string, path string, privileged bool) error
+	InitializeKeyedCache(ctx context.Context, logger lager.Logger, jobID int, path string, key string, privileged bool) error
 	InitializeArtifact(name string, buildID int) (db.WorkerArtifact, error)
+	InitializeStepOutput(name string, buildID int, planID atc.PlanID, expiresAt time.Time) (db.WorkerArtifact, error)
 
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
 
@@ -49,6 +73,7 @@ type volume struct {
 	bcVolume     baggageclaim.Volume
 	dbVolume     db.CreatedVolume
 	volumeClient VolumeClient
+	deltaClient  volumedelta.Client
 }
 
 type byMountPath []VolumeMount
@@ -69,11 +94,13 @@ func NewVolume(
 	bcVolume baggageclaim.Volume,
 	dbVolume db.CreatedVolume,
 	volumeClient VolumeClient,
+	deltaClient volumedelta.Client,
 ) Volume {
 	return &volume{
 		bcVolume:     bcVolume,
 		dbVolume:     dbVolume,
 		volumeClient: volumeClient,
+		deltaClient:  deltaClient,
 	}
 }
 
@@ -113,6 +140,60 @@ func (v *volume) StreamP2pOut(ctx context.Context, path string, destUrl string,
 	return v.bcVolume.StreamP2pOut(ctx, path, destUrl, encoding)
 }
 
+// SupportsDeltaStreaming returns whether the volume's worker runs the delta
+// server, without which none of the Delta methods can be used.
+func (v *volume) SupportsDeltaStreaming() bool {
+	return v.deltaClient != nil
+}
+
+func (v *volume) DeltaManifest(ctx context.Context) (io.ReadCloser, error) {
+	if v.deltaClient == nil {
+		return nil, ErrDeltaStreamingNotSupported
+	}
+
+	return v.deltaClient.Manifest(ctx, v.Handle())
+}
+
+func (v *volume) DeltaStreamOut(ctx context.Context, encoding baggageclaim.Encoding, base io.Reader) (io.ReadCloser, error) {
+	if v.deltaClient == nil {
+		return nil, ErrDeltaStreamingNotSupported
+	}
+
+	return v.deltaClient.StreamOut(ctx, v.Handle(), encoding, base)
+}
+
+func (v *volume) DeltaStreamIn(ctx context.Context, encoding baggageclaim.Encoding, delta io.Reader) error {
+	if v.deltaClient == nil {
+		return ErrDeltaStreamingNotSupported
+	}
+
+	_, span := tracing.StartSpan(ctx, "volume.DeltaStreamIn", tracing.Attrs{
+		"destination-volume": v.Handle(),
+		"destination-worker": v.WorkerName(),
+	})
+
+	err := v.deltaClient.StreamIn(ctx, v.Handle(), encoding, delta)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (v *volume) GetDeltaP2pUrl(ctx context.Context) (string, error) {
+	if v.deltaClient == nil {
+		return "", ErrDeltaStreamingNotSupported
+	}
+
+	return v.deltaClient.GetP2pUrl(ctx)
+}
+
+func (v *volume) DeltaStreamP2pOut(ctx context.Context, destUrl string, destHandle string, encoding baggageclaim.Encoding) error {
+	if v.deltaClient == nil {
+		return ErrDeltaStreamingNotSupported
+	}
+
+	return v.deltaClient.StreamP2pOut(ctx, v.Handle(), encoding, destUrl, destHandle)
+}
+
 func (v *volume) Properties() (baggageclaim.VolumeProperties, error) {
 	return v.bcVolume.Properties()
 }
@@ -135,6 +216,73 @@ func (v *volume) InitializeResourceCache(urc db.UsedResourceCache) error {
 	return v.dbVolume.InitializeResourceCache(urc)
 }
 
+// RecordResourceCacheChecksum records a checksum of the contents of the
+// volume, once initialized as a resource cache, so that the cache can be
+// verified later. Computing it reads every file in the volume.
+func (v *volume) RecordResourceCacheChecksum(ctx context.Context) error {
+	checksum, err := v.checksum(ctx)
+	if err != nil {
+		return err
+	}
+
+	return v.dbVolume.RecordResourceCacheChecksum(checksum)
+}
+
+// VerifyResourceCache compares the volume's contents against the checksum
+// recorded when it was initialized as a resource cache. If they no longer
+// match, the cache is invalidated so that it is fetched again, and false is
+// returned. Volumes without a recorded checksum, or which were verified
+// within the period, are assumed to be intact.
+func (v *volume) VerifyResourceCache(ctx context.Context, logger lager.Logger, period time.Duration) (bool, error) {
+	expected, verifiedAt, found, err := v.dbVolume.ResourceCacheChecksum()
+	if err != nil {
+		return false, err
+	}
+
+	if !found || time.Since(verifiedAt) < period {
+		return true, nil
+	}
+
+	actual, err := v.checksum(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if actual == expected {
+		return true, v.dbVolume.MarkResourceCacheVerified()
+	}
+
+	logger.Info("resource-cache-corrupted", lager.Data{
+		"volume":   v.Handle(),
+		"worker":   v.WorkerName(),
+		"expected": expected,
+		"actual":   actual,
+	})
+
+	metric.Metrics.ResourceCacheCorruptions.Inc()
+
+	return false, v.dbVolume.InvalidateResourceCache()
+}
+
+// checksum hashes the volume's delta manifest, which lists the digest of
+// every file along with its path, mode and ownership in lexical order.
+func (v *volume) checksum(ctx context.Context) (string, error) {
+	manifest, err := v.DeltaManifest(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer manifest.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, manifest)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func (v *volume) GetResourceCacheID() int {
 	return v.dbVolume.GetResourceCacheID()
 }
@@ -143,6 +291,10 @@ func (v *volume) InitializeArtifact(name string, buildID int) (db.WorkerArtifact
 	return v.dbVolume.InitializeArtifact(name, buildID)
 }
 
+func (v *volume) InitializeStepOutput(name string, buildID int, planID atc.PlanID, expiresAt time.Time) (db.WorkerArtifact, error) {
+	return v.dbVolume.InitializeStepOutput(name, buildID, planID, expiresAt)
+}
+
 func (v *volume) InitializeTaskCache(
 	logger lager.Logger,
 	jobID int,
@@ -176,6 +328,56 @@ func (v *volume) InitializeTaskCache(
 	return importVolume.InitializeTaskCache(logger, jobID, stepName, path, privileged)
 }
 
+func (v *volume) InitializeKeyedCache(
+	ctx context.Context,
+	logger lager.Logger,
+	jobID int,
+	path string,
+	key string,
+	privileged bool,
+) error {
+	// the size is needed for enforcing the limits on keyed caches, so the
+	// cache is not saved at all rather than saved as empty
+	size, err := v.size(ctx)
+	if err != nil {
+		logger.Info("keyed-cache-not-saved", lager.Data{"error": err.Error()})
+		return nil
+	}
+
+	if v.dbVolume.ParentHandle() == "" {
+		return v.dbVolume.InitializeKeyedCache(jobID, path, key, size)
+	}
+
+	logger.Debug("creating-an-import-volume", lager.Data{"path": v.bcVolume.Path()})
+
+	// the cache must outlive the volume it was copied on write from
+	importVolume, err := v.volumeClient.CreateVolume(
+		logger,
+		VolumeSpec{
+			Strategy:   baggageclaim.ImportStrategy{Path: v.bcVolume.Path()},
+			Privileged: privileged,
+		},
+		v.dbVolume.TeamID(),
+		v.WorkerName(),
+		db.VolumeTypeKeyedCache,
+	)
+	if err != nil {
+		return err
+	}
+
+	return importVolume.InitializeKeyedCache(ctx, logger, jobID, path, key, privileged)
+}
+
+// size adds up the sizes of the files in the volume from their metadata, on
+// the worker's delta server.
+func (v *volume) size(ctx context.Context) (int64, error) {
+	if v.deltaClient == nil {
+		return 0, ErrDeltaStreamingNotSupported
+	}
+
+	return v.deltaClient.Size(ctx, v.Handle())
+}
+
 func (v *volume) CreateChildForContainer(creatingContainer db.CreatingContainer, mountPath string) (db.CreatingVolume, error) {
 	return v.dbVolume.CreateChildForContainer(creatingContainer, mountPath)
 }
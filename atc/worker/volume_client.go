@@ -3,14 +3,18 @@ package worker
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/volumedelta"
+	uuid "github.com/nu7hatch/gouuid"
 )
 
 const creatingVolumeRetryDelay = 1 * time.Second
@@ -50,6 +54,15 @@ type VolumeClient interface {
 		lager.Logger,
 		db.UsedResourceCache,
 	) (Volume, bool, error)
+	FindDeltaBaseForResourceCache(
+		lager.Logger,
+		db.UsedResourceCache,
+	) (Volume, bool, error)
+	FindDeltaBaseForTaskCache(
+		logger lager.Logger,
+		jobID int,
+		path string,
+	) (Volume, bool, error)
 	FindVolumeForTaskCache(
 		logger lager.Logger,
 		teamID int,
@@ -68,6 +81,17 @@ type VolumeClient interface {
 	FindOrCreateVolumeForResourceCerts(
 		logger lager.Logger,
 	) (volume Volume, found bool, err error)
+	FindVolumeForLayerStore(
+		logger lager.Logger,
+		digest string,
+		privileged bool,
+	) (baggageclaim.Volume, bool, error)
+	FindOrCreateVolumeForLayerStore(
+		logger lager.Logger,
+		digest string,
+		privileged bool,
+		populate func(baggageclaim.Volume) error,
+	) (baggageclaim.Volume, error)
 
 	LookupVolume(lager.Logger, string) (Volume, bool, error)
 }
@@ -102,6 +126,7 @@ var ErrBaseResourceTypeNotFound = errors.New("base resource type not found")
 
 type volumeClient struct {
 	baggageclaimClient              baggageclaim.Client
+	deltaClient                     volumedelta.Client
 	lockFactory                     lock.LockFactory
 	dbVolumeRepository              db.VolumeRepository
 	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory
@@ -113,6 +138,7 @@ type volumeClient struct {
 
 func NewVolumeClient(
 	baggageclaimClient baggageclaim.Client,
+	deltaClient volumedelta.Client,
 	dbWorker db.Worker,
 	clock clock.Clock,
 
@@ -124,6 +150,7 @@ func NewVolumeClient(
 ) VolumeClient {
 	return &volumeClient{
 		baggageclaimClient:              baggageclaimClient,
+		deltaClient:                     deltaClient,
 		lockFactory:                     lockFactory,
 		dbVolumeRepository:              dbVolumeRepository,
 		dbWorkerBaseResourceTypeFactory: dbWorkerBaseResourceTypeFactory,
@@ -244,7 +271,68 @@ func (c *volumeClient) FindVolumeForResourceCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return NewVolume(bcVolume, dbVolume, c, c.deltaClient), true, nil
+}
+
+// FindDeltaBaseForResourceCache finds a volume on the worker holding another
+// version of the resource cache's resource, against which a delta can be
+// streamed.
+func (c *volumeClient) FindDeltaBaseForResourceCache(
+	logger lager.Logger,
+	usedResourceCache db.UsedResourceCache,
+) (Volume, bool, error) {
+	dbVolume, found, err := c.dbVolumeRepository.FindResourceCacheBaseVolume(c.dbWorker.Name(), usedResourceCache)
+	if err != nil {
+		logger.Error("failed-to-lookup-resource-cache-base-volume-in-db", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	bcVolume, found, err := c.baggageclaimClient.LookupVolume(logger, dbVolume.Handle())
+	if err != nil {
+		logger.Error("failed-to-lookup-volume-in-bc", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	return NewVolume(bcVolume, dbVolume, c, c.deltaClient), true, nil
+}
+
+// FindDeltaBaseForTaskCache finds a volume on the worker holding the job's
+// cache for the path, against which a delta of a keyed cache saved elsewhere
+// can be streamed.
+func (c *volumeClient) FindDeltaBaseForTaskCache(
+	logger lager.Logger,
+	jobID int,
+	path string,
+) (Volume, bool, error) {
+	dbVolume, found, err := c.dbVolumeRepository.FindTaskCacheBaseVolume(c.dbWorker.Name(), jobID, path)
+	if err != nil {
+		logger.Error("failed-to-lookup-task-cache-base-volume-in-db", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	bcVolume, found, err := c.baggageclaimClient.LookupVolume(logger, dbVolume.Handle())
+	if err != nil {
+		logger.Error("failed-to-lookup-volume-in-bc", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	return NewVolume(bcVolume, dbVolume, c, c.deltaClient), true, nil
 }
 
 func (c *volumeClient) CreateVolumeForTaskCache(
@@ -360,7 +448,162 @@ func (c *volumeClient) FindVolumeForTaskCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return NewVolume(bcVolume, dbVolume, c, c.deltaClient), true, nil
+}
+
+// FindVolumeForLayerStore returns the worker's populated layer store volume
+// for the given image digest, if any.
+func (c *volumeClient) FindVolumeForLayerStore(
+	logger lager.Logger,
+	digest string,
+	privileged bool,
+) (baggageclaim.Volume, bool, error) {
+	logger = logger.Session("find-volume-for-layer-store", lager.Data{
+		"digest": digest,
+	})
+
+	return c.findLayerStoreVolume(logger, layerStoreProperties(digest, privileged))
+}
+
+// FindOrCreateVolumeForLayerStore returns the worker's layer store volume for
+// the given image digest, calling populate to fill a new one if the image is
+// not stored yet. Layer store volumes are not tracked in the database; see
+// atc.LayerStoreDigestProperty.
+func (c *volumeClient) FindOrCreateVolumeForLayerStore(
+	logger lager.Logger,
+	digest string,
+	privileged bool,
+	populate func(baggageclaim.Volume) error,
+) (baggageclaim.Volume, error) {
+	logger = logger.Session("find-or-create-volume-for-layer-store", lager.Data{
+		"digest": digest,
+	})
+
+	properties := layerStoreProperties(digest, privileged)
+
+	bcVolume, found, err := c.findLayerStoreVolume(logger, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		return bcVolume, nil
+	}
+
+	lock, acquired, err := c.lockFactory.Acquire(logger, lock.NewLayerStorePopulatingLockID(c.dbWorker.Name(), digest))
+	if err != nil {
+		logger.Error("failed-to-acquire-layer-store-populating-lock", err)
+		return nil, err
+	}
+
+	if !acquired {
+		c.clock.Sleep(creatingVolumeRetryDelay)
+		return c.FindOrCreateVolumeForLayerStore(logger, digest, privileged, populate)
+	}
+
+	defer lock.Release()
+
+	// another ATC may have populated it while we were waiting for the lock
+	bcVolume, found, err = c.findLayerStoreVolume(logger, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		return bcVolume, nil
+	}
+
+	handle, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeProperties := baggageclaim.VolumeProperties{
+		atc.LayerStoreLastUsedProperty: c.lastUsed(),
+	}
+	for k, v := range properties {
+		volumeProperties[k] = v
+	}
+
+	bcVolume, err = c.baggageclaimClient.CreateVolume(
+		logger.Session("create-volume"),
+		handle.String(),
+		baggageclaim.VolumeSpec{
+			Strategy:   baggageclaim.EmptyStrategy{},
+			Properties: volumeProperties,
+			Privileged: privileged,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-to-create-volume", err)
+		return nil, err
+	}
+
+	err = populate(bcVolume)
+	if err != nil {
+		logger.Error("failed-to-populate-volume", err)
+
+		destroyErr := bcVolume.Destroy()
+		if destroyErr != nil {
+			logger.Error("failed-to-destroy-unpopulated-volume", destroyErr)
+		}
+
+		return nil, err
+	}
+
+	err = bcVolume.SetProperty(atc.LayerStoreReadyProperty, "true")
+	if err != nil {
+		logger.Error("failed-to-mark-volume-ready", err)
+		return nil, err
+	}
+
+	logger.Debug("populated", lager.Data{"handle": bcVolume.Handle()})
+
+	return bcVolume, nil
+}
+
+func (c *volumeClient) findLayerStoreVolume(
+	logger lager.Logger,
+	properties baggageclaim.VolumeProperties,
+) (baggageclaim.Volume, bool, error) {
+	readyProperties := baggageclaim.VolumeProperties{
+		atc.LayerStoreReadyProperty: "true",
+	}
+	for k, v := range properties {
+		readyProperties[k] = v
+	}
+
+	bcVolumes, err := c.baggageclaimClient.ListVolumes(logger.Session("list-volumes"), readyProperties)
+	if err != nil {
+		logger.Error("failed-to-list-layer-store-volumes", err)
+		return nil, false, err
+	}
+
+	if len(bcVolumes) == 0 {
+		return nil, false, nil
+	}
+
+	bcVolume := bcVolumes[0]
+
+	err = bcVolume.SetProperty(atc.LayerStoreLastUsedProperty, c.lastUsed())
+	if err != nil {
+		logger.Error("failed-to-update-last-used", err)
+		return nil, false, err
+	}
+
+	return bcVolume, true, nil
+}
+
+func layerStoreProperties(digest string, privileged bool) baggageclaim.VolumeProperties {
+	return baggageclaim.VolumeProperties{
+		atc.LayerStoreProperty:           "true",
+		atc.LayerStoreDigestProperty:     digest,
+		atc.LayerStorePrivilegedProperty: strconv.FormatBool(privileged),
+	}
+}
+
+func (c *volumeClient) lastUsed() string {
+	return strconv.FormatInt(c.clock.Now().Unix(), 10)
 }
 
 func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume, bool, error) {
@@ -384,7 +627,7 @@ func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume,
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return NewVolume(bcVolume, dbVolume, c, c.deltaClient), true, nil
 }
 
 func (c *volumeClient) findOrCreateVolume(
@@ -418,7 +661,7 @@ func (c *volumeClient) findOrCreateVolume(
 
 		logger.Debug("found-created-volume")
 
-		return NewVolume(bcVolume, createdVolume, c), nil
+		return NewVolume(bcVolume, createdVolume, c, c.deltaClient), nil
 	}
 
 	if creatingVolume != nil {
@@ -492,5 +735,5 @@ func (c *volumeClient) findOrCreateVolume(
 
 	logger.Debug("created")
 
-	return NewVolume(bcVolume, createdVolume, c), nil
+	return NewVolume(bcVolume, createdVolume, c, c.deltaClient), nil
 }
@@ -9,12 +9,14 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/volumedelta/volumedeltafakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -25,6 +27,7 @@ var _ = Describe("VolumeClient", func() {
 		testLogger *lagertest.TestLogger
 
 		fakeBaggageclaimClient            *baggageclaimfakes.FakeClient
+		fakeDeltaClient                   *volumedeltafakes.FakeClient
 		fakeLockFactory                   *lockfakes.FakeLockFactory
 		fakeDBVolumeRepository            *dbfakes.FakeVolumeRepository
 		fakeWorkerBaseResourceTypeFactory *dbfakes.FakeWorkerBaseResourceTypeFactory
@@ -38,6 +41,7 @@ var _ = Describe("VolumeClient", func() {
 
 	BeforeEach(func() {
 		fakeBaggageclaimClient = new(baggageclaimfakes.FakeClient)
+		fakeDeltaClient = new(volumedeltafakes.FakeClient)
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		dbWorker = new(dbfakes.FakeWorker)
@@ -53,6 +57,7 @@ var _ = Describe("VolumeClient", func() {
 
 		volumeClient = worker.NewVolumeClient(
 			fakeBaggageclaimClient,
+			fakeDeltaClient,
 			dbWorker,
 			fakeClock,
 
@@ -249,7 +254,7 @@ var _ = Describe("VolumeClient", func() {
 
 			It("creates volume in baggageclaim", func() {
 				Expect(foundOrCreatedErr).NotTo(HaveOccurred())
-				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient, fakeDeltaClient)))
 				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
 			})
 
@@ -433,7 +438,7 @@ var _ = Describe("VolumeClient", func() {
 
 			It("creates volume in baggageclaim", func() {
 				Expect(foundOrCreatedErr).NotTo(HaveOccurred())
-				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient, fakeDeltaClient)))
 				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
 			})
 		})
@@ -637,13 +642,125 @@ var _ = Describe("VolumeClient", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
 
-						Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient)))
+						Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient, fakeDeltaClient)))
 					})
 				})
 			})
 		})
 	})
 
+	Describe("FindDeltaBaseForResourceCache", func() {
+		var usedResourceCache *dbfakes.FakeUsedResourceCache
+
+		BeforeEach(func() {
+			usedResourceCache = new(dbfakes.FakeUsedResourceCache)
+		})
+
+		Context("when no base volume exists in db", func() {
+			BeforeEach(func() {
+				fakeDBVolumeRepository.FindResourceCacheBaseVolumeReturns(nil, false, nil)
+			})
+
+			It("returns false", func() {
+				_, found, err := volumeClient.FindDeltaBaseForResourceCache(testLogger, usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a base volume exists in db", func() {
+			var dbVolume *dbfakes.FakeCreatedVolume
+
+			BeforeEach(func() {
+				dbVolume = new(dbfakes.FakeCreatedVolume)
+				dbVolume.HandleReturns("some-base-handle")
+				fakeDBVolumeRepository.FindResourceCacheBaseVolumeReturns(dbVolume, true, nil)
+			})
+
+			It("looks it up for the worker and resource cache", func() {
+				_, _, err := volumeClient.FindDeltaBaseForResourceCache(testLogger, usedResourceCache)
+				Expect(err).NotTo(HaveOccurred())
+
+				workerName, actualResourceCache := fakeDBVolumeRepository.FindResourceCacheBaseVolumeArgsForCall(0)
+				Expect(workerName).To(Equal(dbWorker.Name()))
+				Expect(actualResourceCache).To(Equal(usedResourceCache))
+			})
+
+			Context("when the volume does not exist in baggageclaim", func() {
+				BeforeEach(func() {
+					fakeBaggageclaimClient.LookupVolumeReturns(nil, false, nil)
+				})
+
+				It("returns false", func() {
+					_, found, err := volumeClient.FindDeltaBaseForResourceCache(testLogger, usedResourceCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+
+			Context("when the volume exists in baggageclaim", func() {
+				var bcVolume *baggageclaimfakes.FakeVolume
+
+				BeforeEach(func() {
+					bcVolume = new(baggageclaimfakes.FakeVolume)
+					fakeBaggageclaimClient.LookupVolumeReturns(bcVolume, true, nil)
+				})
+
+				It("returns the volume", func() {
+					volume, found, err := volumeClient.FindDeltaBaseForResourceCache(testLogger, usedResourceCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient, fakeDeltaClient)))
+
+					_, handle := fakeBaggageclaimClient.LookupVolumeArgsForCall(0)
+					Expect(handle).To(Equal("some-base-handle"))
+				})
+			})
+		})
+	})
+
+	Describe("FindDeltaBaseForTaskCache", func() {
+		Context("when no base volume exists in db", func() {
+			BeforeEach(func() {
+				fakeDBVolumeRepository.FindTaskCacheBaseVolumeReturns(nil, false, nil)
+			})
+
+			It("returns false", func() {
+				_, found, err := volumeClient.FindDeltaBaseForTaskCache(testLogger, 42, "some-path")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a base volume exists in db and baggageclaim", func() {
+			var dbVolume *dbfakes.FakeCreatedVolume
+			var bcVolume *baggageclaimfakes.FakeVolume
+
+			BeforeEach(func() {
+				dbVolume = new(dbfakes.FakeCreatedVolume)
+				dbVolume.HandleReturns("some-base-handle")
+				fakeDBVolumeRepository.FindTaskCacheBaseVolumeReturns(dbVolume, true, nil)
+
+				bcVolume = new(baggageclaimfakes.FakeVolume)
+				fakeBaggageclaimClient.LookupVolumeReturns(bcVolume, true, nil)
+			})
+
+			It("returns the volume for the worker, job and path", func() {
+				volume, found, err := volumeClient.FindDeltaBaseForTaskCache(testLogger, 42, "some-path")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient, fakeDeltaClient)))
+
+				workerName, jobID, path := fakeDBVolumeRepository.FindTaskCacheBaseVolumeArgsForCall(0)
+				Expect(workerName).To(Equal(dbWorker.Name()))
+				Expect(jobID).To(Equal(42))
+				Expect(path).To(Equal("some-path"))
+			})
+		})
+	})
+
 	Describe("CreateVolume", func() {
 		var err error
 		var workerVolume worker.Volume
@@ -738,7 +855,7 @@ var _ = Describe("VolumeClient", func() {
 
 							It("returns a new volume with the bg volume and created volume", func() {
 								Expect(err).NotTo(HaveOccurred())
-								Expect(workerVolume).To(Equal(worker.NewVolume(fakeBGVolume, fakeCreatedVolume, volumeClient)))
+								Expect(workerVolume).To(Equal(worker.NewVolume(fakeBGVolume, fakeCreatedVolume, volumeClient, fakeDeltaClient)))
 							})
 						})
 					})
@@ -747,6 +864,194 @@ var _ = Describe("VolumeClient", func() {
 		})
 	})
 
+	Describe("FindVolumeForLayerStore", func() {
+		var (
+			fakeStoreVolume *baggageclaimfakes.FakeVolume
+
+			storeVolume baggageclaim.Volume
+			found       bool
+			findErr     error
+		)
+
+		BeforeEach(func() {
+			fakeStoreVolume = new(baggageclaimfakes.FakeVolume)
+		})
+
+		JustBeforeEach(func() {
+			storeVolume, found, findErr = volumeClient.FindVolumeForLayerStore(testLogger, "sha256:some-digest", false)
+		})
+
+		Context("when a ready volume exists for the digest", func() {
+			BeforeEach(func() {
+				fakeBaggageclaimClient.ListVolumesReturns(baggageclaim.Volumes{fakeStoreVolume}, nil)
+			})
+
+			It("returns it and marks it as used", func() {
+				Expect(findErr).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(storeVolume).To(Equal(fakeStoreVolume))
+
+				_, properties := fakeBaggageclaimClient.ListVolumesArgsForCall(0)
+				Expect(properties).To(Equal(baggageclaim.VolumeProperties{
+					atc.LayerStoreProperty:           "true",
+					atc.LayerStoreDigestProperty:     "sha256:some-digest",
+					atc.LayerStorePrivilegedProperty: "false",
+					atc.LayerStoreReadyProperty:      "true",
+				}))
+
+				Expect(fakeStoreVolume.SetPropertyCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when no ready volume exists for the digest", func() {
+			It("does not create one", func() {
+				Expect(findErr).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("FindOrCreateVolumeForLayerStore", func() {
+		var (
+			fakeStoreVolume *baggageclaimfakes.FakeVolume
+			populated       []baggageclaim.Volume
+			populateErr     error
+
+			storeVolume baggageclaim.Volume
+			storeErr    error
+		)
+
+		BeforeEach(func() {
+			fakeStoreVolume = new(baggageclaimfakes.FakeVolume)
+			populated = nil
+			populateErr = nil
+		})
+
+		JustBeforeEach(func() {
+			storeVolume, storeErr = volumeClient.FindOrCreateVolumeForLayerStore(
+				testLogger,
+				"sha256:some-digest",
+				true,
+				func(volume baggageclaim.Volume) error {
+					populated = append(populated, volume)
+					return populateErr
+				},
+			)
+		})
+
+		Context("when a ready volume exists for the digest", func() {
+			BeforeEach(func() {
+				fakeBaggageclaimClient.ListVolumesReturns(baggageclaim.Volumes{fakeStoreVolume}, nil)
+			})
+
+			It("returns it", func() {
+				Expect(storeErr).ToNot(HaveOccurred())
+				Expect(storeVolume).To(Equal(fakeStoreVolume))
+			})
+
+			It("looks it up by digest and privilege", func() {
+				_, properties := fakeBaggageclaimClient.ListVolumesArgsForCall(0)
+				Expect(properties).To(Equal(baggageclaim.VolumeProperties{
+					atc.LayerStoreProperty:           "true",
+					atc.LayerStoreDigestProperty:     "sha256:some-digest",
+					atc.LayerStorePrivilegedProperty: "true",
+					atc.LayerStoreReadyProperty:      "true",
+				}))
+			})
+
+			It("marks it as used", func() {
+				Expect(fakeStoreVolume.SetPropertyCallCount()).To(Equal(1))
+				key, value := fakeStoreVolume.SetPropertyArgsForCall(0)
+				Expect(key).To(Equal(atc.LayerStoreLastUsedProperty))
+				Expect(value).To(Equal("123"))
+			})
+
+			It("does not populate a new volume", func() {
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(0))
+				Expect(populated).To(BeEmpty())
+			})
+		})
+
+		Context("when no volume exists for the digest", func() {
+			BeforeEach(func() {
+				fakeLockFactory.AcquireReturns(fakeLock, true, nil)
+				fakeBaggageclaimClient.CreateVolumeReturns(fakeStoreVolume, nil)
+			})
+
+			It("acquires the populating lock for the worker and digest", func() {
+				Expect(fakeLockFactory.AcquireCallCount()).To(Equal(1))
+				_, lockID := fakeLockFactory.AcquireArgsForCall(0)
+				Expect(lockID).To(Equal(lock.NewLayerStorePopulatingLockID("some-worker", "sha256:some-digest")))
+				Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+			})
+
+			It("creates an empty volume with the layer store properties", func() {
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
+				_, _, spec := fakeBaggageclaimClient.CreateVolumeArgsForCall(0)
+				Expect(spec).To(Equal(baggageclaim.VolumeSpec{
+					Strategy: baggageclaim.EmptyStrategy{},
+					Properties: baggageclaim.VolumeProperties{
+						atc.LayerStoreProperty:           "true",
+						atc.LayerStoreDigestProperty:     "sha256:some-digest",
+						atc.LayerStorePrivilegedProperty: "true",
+						atc.LayerStoreLastUsedProperty:   "123",
+					},
+					Privileged: true,
+				}))
+			})
+
+			It("populates it and marks it as ready", func() {
+				Expect(storeErr).ToNot(HaveOccurred())
+				Expect(storeVolume).To(Equal(fakeStoreVolume))
+				Expect(populated).To(Equal([]baggageclaim.Volume{fakeStoreVolume}))
+
+				Expect(fakeStoreVolume.SetPropertyCallCount()).To(Equal(1))
+				key, value := fakeStoreVolume.SetPropertyArgsForCall(0)
+				Expect(key).To(Equal(atc.LayerStoreReadyProperty))
+				Expect(value).To(Equal("true"))
+			})
+
+			Context("when populating fails", func() {
+				BeforeEach(func() {
+					populateErr = errors.New("nope")
+				})
+
+				It("destroys the volume and returns the error", func() {
+					Expect(storeErr).To(Equal(populateErr))
+					Expect(fakeStoreVolume.DestroyCallCount()).To(Equal(1))
+					Expect(fakeStoreVolume.SetPropertyCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when another ATC populated it while waiting for the lock", func() {
+				BeforeEach(func() {
+					fakeBaggageclaimClient.ListVolumesReturnsOnCall(0, nil, nil)
+					fakeBaggageclaimClient.ListVolumesReturnsOnCall(1, baggageclaim.Volumes{fakeStoreVolume}, nil)
+				})
+
+				It("returns it without populating a new volume", func() {
+					Expect(storeErr).ToNot(HaveOccurred())
+					Expect(storeVolume).To(Equal(fakeStoreVolume))
+					Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(0))
+					Expect(populated).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when listing volumes fails", func() {
+			disaster := errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeBaggageclaimClient.ListVolumesReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(storeErr).To(Equal(disaster))
+			})
+		})
+	})
+
 	Describe("LookupVolume", func() {
 		var handle string
 
@@ -763,6 +1068,7 @@ var _ = Describe("VolumeClient", func() {
 		JustBeforeEach(func() {
 			_, found, lookupErr = worker.NewVolumeClient(
 				fakeBaggageclaimClient,
+				fakeDeltaClient,
 				dbWorker,
 				fakeClock,
 
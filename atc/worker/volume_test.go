@@ -0,0 +1,213 @@
+package worker_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/volumedelta/volumedeltafakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volume", func() {
+	var (
+		testLogger *lagertest.TestLogger
+
+		fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+		fakeDBVolume           *dbfakes.FakeCreatedVolume
+		fakeDeltaClient        *volumedeltafakes.FakeClient
+
+		manifest         string
+		manifestChecksum string
+
+		volume worker.Volume
+	)
+
+	BeforeEach(func() {
+		testLogger = lagertest.NewTestLogger("test")
+
+		fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+		fakeBaggageclaimVolume.HandleReturns("some-handle")
+
+		fakeDBVolume = new(dbfakes.FakeCreatedVolume)
+		fakeDeltaClient = new(volumedeltafakes.FakeClient)
+
+		manifest = `{"path":"some-file","mode":420,"uid":0,"gid":0,"size":12,"digest":"some-digest"}` + "\n"
+		hash := sha256.Sum256([]byte(manifest))
+		manifestChecksum = hex.EncodeToString(hash[:])
+
+		fakeDeltaClient.ManifestStub = func(context.Context, string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(manifest)), nil
+		}
+
+		volume = worker.NewVolume(fakeBaggageclaimVolume, fakeDBVolume, nil, fakeDeltaClient)
+	})
+
+	Describe("RecordResourceCacheChecksum", func() {
+		var recordErr error
+
+		JustBeforeEach(func() {
+			recordErr = volume.RecordResourceCacheChecksum(context.TODO())
+		})
+
+		It("records a checksum of the volume's manifest", func() {
+			Expect(recordErr).ToNot(HaveOccurred())
+
+			_, handle := fakeDeltaClient.ManifestArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+
+			Expect(fakeDBVolume.RecordResourceCacheChecksumCallCount()).To(Equal(1))
+			Expect(fakeDBVolume.RecordResourceCacheChecksumArgsForCall(0)).To(Equal(manifestChecksum))
+		})
+
+		Context("when the manifest cannot be fetched", func() {
+			BeforeEach(func() {
+				fakeDeltaClient.ManifestStub = nil
+				fakeDeltaClient.ManifestReturns(nil, errors.New("nope"))
+			})
+
+			It("errors without recording a checksum", func() {
+				Expect(recordErr).To(HaveOccurred())
+				Expect(fakeDBVolume.RecordResourceCacheChecksumCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("InitializeKeyedCache", func() {
+		var initErr error
+
+		BeforeEach(func() {
+			fakeDeltaClient.SizeReturns(1024, nil)
+		})
+
+		JustBeforeEach(func() {
+			initErr = volume.InitializeKeyedCache(context.TODO(), testLogger, 42, "some-path", "some-key", false)
+		})
+
+		It("saves the cache with the size of the volume", func() {
+			Expect(initErr).ToNot(HaveOccurred())
+
+			_, handle := fakeDeltaClient.SizeArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+
+			Expect(fakeDBVolume.InitializeKeyedCacheCallCount()).To(Equal(1))
+			jobID, path, key, size := fakeDBVolume.InitializeKeyedCacheArgsForCall(0)
+			Expect(jobID).To(Equal(42))
+			Expect(path).To(Equal("some-path"))
+			Expect(key).To(Equal("some-key"))
+			Expect(size).To(Equal(int64(1024)))
+		})
+
+		Context("when the size cannot be measured", func() {
+			BeforeEach(func() {
+				fakeDeltaClient.SizeReturns(0, errors.New("nope"))
+			})
+
+			It("does not save the cache", func() {
+				Expect(initErr).ToNot(HaveOccurred())
+				Expect(fakeDBVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the worker does not run the delta server", func() {
+			BeforeEach(func() {
+				volume = worker.NewVolume(fakeBaggageclaimVolume, fakeDBVolume, nil, nil)
+			})
+
+			It("does not save the cache", func() {
+				Expect(initErr).ToNot(HaveOccurred())
+				Expect(fakeDBVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("VerifyResourceCache", func() {
+		var (
+			intact    bool
+			verifyErr error
+		)
+
+		JustBeforeEach(func() {
+			intact, verifyErr = volume.VerifyResourceCache(context.TODO(), testLogger, time.Hour)
+		})
+
+		Context("when a checksum was recorded", func() {
+			Context("when it matches", func() {
+				BeforeEach(func() {
+					fakeDBVolume.ResourceCacheChecksumReturns(manifestChecksum, time.Now().Add(-2*time.Hour), true, nil)
+				})
+
+				It("marks the cache as verified", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+					Expect(intact).To(BeTrue())
+					Expect(fakeDBVolume.MarkResourceCacheVerifiedCallCount()).To(Equal(1))
+					Expect(fakeDBVolume.InvalidateResourceCacheCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when it does not match", func() {
+				BeforeEach(func() {
+					fakeDBVolume.ResourceCacheChecksumReturns("some-other-checksum", time.Now().Add(-2*time.Hour), true, nil)
+
+					// reset the counter
+					metric.Metrics.ResourceCacheCorruptions.Delta()
+				})
+
+				It("invalidates the cache", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+					Expect(intact).To(BeFalse())
+					Expect(fakeDBVolume.InvalidateResourceCacheCallCount()).To(Equal(1))
+					Expect(fakeDBVolume.MarkResourceCacheVerifiedCallCount()).To(BeZero())
+				})
+
+				It("counts the corruption", func() {
+					Expect(metric.Metrics.ResourceCacheCorruptions.Delta()).To(Equal(float64(1)))
+				})
+			})
+
+			Context("when the manifest cannot be fetched", func() {
+				BeforeEach(func() {
+					fakeDBVolume.ResourceCacheChecksumReturns(manifestChecksum, time.Now().Add(-2*time.Hour), true, nil)
+					fakeDeltaClient.ManifestStub = nil
+					fakeDeltaClient.ManifestReturns(nil, errors.New("nope"))
+				})
+
+				It("errors without invalidating the cache", func() {
+					Expect(verifyErr).To(HaveOccurred())
+					Expect(fakeDBVolume.InvalidateResourceCacheCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when it was verified within the period", func() {
+			BeforeEach(func() {
+				fakeDBVolume.ResourceCacheChecksumReturns("some-other-checksum", time.Now().Add(-time.Minute), true, nil)
+			})
+
+			It("assumes the cache is intact", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				Expect(intact).To(BeTrue())
+				Expect(fakeDeltaClient.ManifestCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when no checksum was recorded", func() {
+			It("assumes the cache is intact", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				Expect(intact).To(BeTrue())
+				Expect(fakeDeltaClient.ManifestCallCount()).To(BeZero())
+			})
+		})
+	})
+})
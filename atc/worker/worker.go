@@ -44,6 +44,13 @@ type Worker interface {
 	Uptime() time.Duration
 	IsOwnedByTeam() bool
 	Ephemeral() bool
+	Rootless() bool
+	SupportedContainerLimits() []string
+	Features() []string
+	HealthScore() float64
+	Quarantined() bool
+	Evicted(lager.Logger) bool
+	RecordHealth(lager.Logger, db.WorkerHealthEvent, bool)
 	IsVersionCompatible(lager.Logger, version.Version) bool
 	Satisfies(lager.Logger, WorkerSpec) bool
 	FindContainerByHandle(lager.Logger, int, string) (Container, bool, error)
@@ -58,6 +65,8 @@ type Worker interface {
 
 	FindVolumeForResourceCache(logger lager.Logger, resourceCache db.UsedResourceCache) (Volume, bool, error)
 	FindResourceCacheForVolume(volume Volume) (db.UsedResourceCache, bool, error)
+	FindDeltaBaseForResourceCache(logger lager.Logger, resourceCache db.UsedResourceCache) (Volume, bool, error)
+	FindDeltaBaseForTaskCache(logger lager.Logger, jobID int, path string) (Volume, bool, error)
 	FindVolumeForTaskCache(lager.Logger, int, int, string, string) (Volume, bool, error)
 	Fetch(
 		context.Context,
@@ -95,6 +104,8 @@ type gardenWorker struct {
 	dbWorker        db.Worker
 	buildContainers int
 	helper          workerHelper
+
+	healthRecorder *HealthRecorder
 }
 
 // NewGardenWorker constructs a Worker using the gardenWorker runtime implementation and allows container and volume
@@ -113,6 +124,7 @@ func NewGardenWorker(
 	// TODO: numBuildContainers is only needed for placement strategy but this
 	// method is called in ContainerProvider.FindOrCreateContainer as well and
 	// hence we pass in 0 values for numBuildContainers everywhere.
+	healthRecorder *HealthRecorder,
 ) Worker {
 	workerHelper := workerHelper{
 		gardenClient:  gardenClient,
@@ -131,6 +143,7 @@ func NewGardenWorker(
 		resourceCacheFactory: resourceCacheFactory,
 		buildContainers:      numBuildContainers,
 		helper:               workerHelper,
+		healthRecorder:       healthRecorder,
 	}
 }
 
@@ -184,6 +197,14 @@ func (worker *gardenWorker) FindVolumeForResourceCache(logger lager.Logger, reso
 	return worker.volumeClient.FindVolumeForResourceCache(logger, resourceCache)
 }
 
+func (worker *gardenWorker) FindDeltaBaseForResourceCache(logger lager.Logger, resourceCache db.UsedResourceCache) (Volume, bool, error) {
+	return worker.volumeClient.FindDeltaBaseForResourceCache(logger, resourceCache)
+}
+
+func (worker *gardenWorker) FindDeltaBaseForTaskCache(logger lager.Logger, jobID int, path string) (Volume, bool, error) {
+	return worker.volumeClient.FindDeltaBaseForTaskCache(logger, jobID, path)
+}
+
 func (worker *gardenWorker) FindResourceCacheForVolume(volume Volume) (db.UsedResourceCache, bool, error) {
 	if volume.GetResourceCacheID() != 0 {
 		return worker.resourceCacheFactory.FindResourceCacheByID(volume.GetResourceCacheID())
@@ -325,6 +346,7 @@ func (worker *gardenWorker) findOrCreateContainer(
 		logger.Debug("creating-garden-container")
 
 		gardenContainer, err = worker.helper.createGardenContainer(containerSpec, fetchedImage, creatingContainer.Handle(), bindMounts)
+		worker.RecordHealth(logger, db.WorkerHealthContainerCreation, err != nil)
 		if err != nil {
 			_, failedErr := creatingContainer.Failed()
 			if failedErr != nil {
@@ -336,6 +358,21 @@ func (worker *gardenWorker) findOrCreateContainer(
 			return nil, err
 		}
 
+		if len(containerSpec.SecretFiles) > 0 {
+			err = worker.helper.streamSecretFiles(gardenContainer, containerSpec.SecretFiles)
+			if err != nil {
+				logger.Error("failed-to-stream-secret-files", err)
+
+				_, failedErr := creatingContainer.Failed()
+				if failedErr != nil {
+					logger.Error("failed-to-mark-container-as-failed", failedErr)
+				}
+
+				_ = worker.gardenClient.Destroy(containerHandle)
+
+				return nil, fmt.Errorf("stream secret files: %w", err)
+			}
+		}
 	}
 
 	logger.Debug("created-container-in-garden")
@@ -618,23 +655,59 @@ func (worker *gardenWorker) cloneRemoteVolumes(
 	for i, nonLocalInput := range nonLocals {
 		// this is to ensure each go func gets its own non changing copy of the iterator
 		i, nonLocalInput := i, nonLocalInput
-		inputVolume, err := worker.volumeClient.FindOrCreateVolumeForContainer(
-			logger,
-			VolumeSpec{
-				Strategy:   baggageclaim.EmptyStrategy{},
-				Privileged: privileged,
-			},
-			container,
-			teamID,
-			nonLocalInput.desiredMountPath,
-		)
+
+		streamable, isStreamable := nonLocalInput.desiredArtifact.(StreamableArtifactSource)
+
+		var baseVolume Volume
+		var foundBase bool
+		if isStreamable {
+			var err error
+			baseVolume, foundBase, err = streamable.DeltaBaseOn(logger, worker)
+			if err != nil {
+				return []VolumeMount{}, err
+			}
+		}
+
+		var inputVolume Volume
+		var err error
+		if foundBase {
+			// start from an older version already on the worker, and only
+			// stream what has changed since
+			inputVolume, err = worker.volumeClient.FindOrCreateCOWVolumeForContainer(
+				logger,
+				VolumeSpec{
+					Strategy:   baseVolume.COWStrategy(),
+					Privileged: privileged,
+				},
+				container,
+				baseVolume,
+				teamID,
+				nonLocalInput.desiredMountPath,
+			)
+		} else {
+			inputVolume, err = worker.volumeClient.FindOrCreateVolumeForContainer(
+				logger,
+				VolumeSpec{
+					Strategy:   baggageclaim.EmptyStrategy{},
+					Privileged: privileged,
+				},
+				container,
+				teamID,
+				nonLocalInput.desiredMountPath,
+			)
+		}
 		if err != nil {
 			return []VolumeMount{}, err
 		}
 
 		g.Go(func() error {
-			if streamable, ok := nonLocalInput.desiredArtifact.(StreamableArtifactSource); ok {
-				err = streamable.StreamTo(groupCtx, inputVolume)
+			if foundBase {
+				err := streamable.StreamDeltaTo(groupCtx, inputVolume)
+				if err != nil {
+					return err
+				}
+			} else if isStreamable {
+				err := streamable.StreamTo(groupCtx, inputVolume)
 				if err != nil {
 					return err
 				}
@@ -648,7 +721,11 @@ func (worker *gardenWorker) cloneRemoteVolumes(
 			return nil
 		})
 	}
-	if err := g.Wait(); err != nil {
+	err := g.Wait()
+	if ctx.Err() == nil {
+		worker.RecordHealth(logger, db.WorkerHealthVolumeStreaming, err != nil)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -703,6 +780,69 @@ func (worker *gardenWorker) Ephemeral() bool {
 	return worker.dbWorker.Ephemeral()
 }
 
+func (worker *gardenWorker) Rootless() bool {
+	return worker.dbWorker.Rootless()
+}
+
+func (worker *gardenWorker) SupportedContainerLimits() []string {
+	limits := worker.dbWorker.SupportedContainerLimits()
+	if len(limits) == 0 {
+		return atc.DefaultSupportedContainerLimits
+	}
+
+	return limits
+}
+
+func (worker *gardenWorker) Features() []string {
+	return worker.dbWorker.Features()
+}
+
+func (worker *gardenWorker) HealthScore() float64 {
+	return worker.dbWorker.HealthScore()
+}
+
+func (worker *gardenWorker) Quarantined() bool {
+	return worker.dbWorker.Quarantined()
+}
+
+// Evicted returns whether the worker has been evicted since it was loaded,
+// meaning that it's about to go away.
+//
+// Failing to find out is only logged and treated as the worker not being
+// evicted, so that errors are reported as they would've been otherwise.
+func (worker *gardenWorker) Evicted(logger lager.Logger) bool {
+	found, err := worker.dbWorker.Reload()
+	if err != nil {
+		logger.Error("failed-to-reload-worker", err)
+		return false
+	}
+
+	return found && worker.dbWorker.State() == db.WorkerStateEvicting
+}
+
+// RecordHealth updates the health score of the worker with the outcome of an
+// event, quarantining it once the score drops below the quarantine threshold.
+// Outcomes are aggregated by the HealthRecorder before they're written.
+//
+// Failing to record the outcome is only logged, as it should not affect the
+// operation that the outcome is of.
+func (worker *gardenWorker) RecordHealth(logger lager.Logger, event db.WorkerHealthEvent, failed bool) {
+	wasQuarantined := worker.dbWorker.Quarantined()
+
+	quarantined, err := worker.healthRecorder.Record(worker.dbWorker, event, failed)
+	if err != nil {
+		logger.Error("failed-to-record-worker-health", err, lager.Data{"event": event})
+		return
+	}
+
+	if quarantined && !wasQuarantined {
+		logger.Info("quarantined-worker", lager.Data{
+			"worker": worker.Name(),
+			"score":  worker.dbWorker.HealthScore(),
+		})
+	}
+}
+
 func (worker *gardenWorker) BuildContainers() int {
 	return worker.buildContainers
 }
@@ -1,8 +1,11 @@
 package worker
 
 import (
+	"archive/tar"
+	"bytes"
 	"fmt"
 	"path/filepath"
+	"sort"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
@@ -33,6 +36,14 @@ func (w workerHelper) createGardenContainer(
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
+	for name, value := range containerSpec.Limits.GardenProperties() {
+		gardenProperties[name] = value
+	}
+
+	if len(containerSpec.SecretFiles) > 0 {
+		gardenProperties[secretFilesPropertyName] = secretFilesDir
+	}
+
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
 	if w.dbWorker.HTTPProxyURL() != "" {
@@ -59,6 +70,75 @@ func (w workerHelper) createGardenContainer(
 		})
 }
 
+// streamSecretFiles writes the secret files into the tmpfs the runtime set up
+// for them. Files are owned by root and readable by any user (0444), as the
+// user that the task's processes run as isn't known up front.
+func (w workerHelper) streamSecretFiles(gardenContainer gclient.Container, secretFiles map[string]string) error {
+	paths := make([]string, 0, len(secretFiles))
+	for path := range secretFiles {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	buf := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(buf)
+
+	dirs := map[string]bool{}
+	for _, path := range paths {
+		for dir := filepath.Dir(path); dir != "." && !dirs[dir]; dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	sortedDirs := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sortedDirs = append(sortedDirs, dir)
+	}
+
+	sort.Strings(sortedDirs)
+
+	for _, dir := range sortedDirs {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     dir + "/",
+			Typeflag: tar.TypeDir,
+			Mode:     0755,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, path := range paths {
+		content := []byte(secretFiles[path])
+
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:     path,
+			Typeflag: tar.TypeReg,
+			Mode:     0444,
+			Size:     int64(len(content)),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tarWriter.Write(content)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return gardenContainer.StreamIn(garden.StreamInSpec{
+		Path:      secretFilesDir,
+		TarStream: buf,
+	})
+}
+
 func (w workerHelper) constructGardenWorkerContainer(
 	logger lager.Logger,
 	createdContainer db.CreatedContainer,
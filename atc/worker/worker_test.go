@@ -1,12 +1,15 @@
 package worker_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
@@ -71,6 +74,8 @@ var _ = Describe("Worker", func() {
 
 		findOrCreateErr       error
 		findOrCreateContainer Container
+
+		quarantine db.WorkerQuarantine
 	)
 
 	BeforeEach(func() {
@@ -200,6 +205,8 @@ var _ = Describe("Worker", func() {
 
 		fakeGardenContainer = new(gclientfakes.FakeContainer)
 		fakeGardenClient.CreateReturns(fakeGardenContainer, nil)
+
+		quarantine = db.WorkerQuarantine{}
 	})
 
 	JustBeforeEach(func() {
@@ -225,6 +232,7 @@ var _ = Describe("Worker", func() {
 			fakeDBWorker,
 			fakeResourceCacheFactory,
 			0,
+			NewHealthRecorder(clock.NewClock(), quarantine),
 		)
 	})
 
@@ -530,6 +538,114 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("RecordHealth", func() {
+		BeforeEach(func() {
+			quarantine = db.WorkerQuarantine{Threshold: 0.5, MaxFraction: 0.5}
+			fakeDBWorker.HealthScoreReturns(0.5)
+		})
+
+		JustBeforeEach(func() {
+			gardenWorker.RecordHealth(logger, db.WorkerHealthContainerCreation, true)
+		})
+
+		It("records the outcome in the database once it may quarantine the worker", func() {
+			Expect(fakeDBWorker.RecordHealthCallCount()).To(Equal(1))
+			outcomes, actualQuarantine := fakeDBWorker.RecordHealthArgsForCall(0)
+			Expect(outcomes).To(Equal(db.WorkerHealthOutcomes{
+				{Event: db.WorkerHealthContainerCreation, Failed: true},
+			}))
+			Expect(actualQuarantine).To(Equal(quarantine))
+		})
+
+		Context("when the worker becomes quarantined", func() {
+			BeforeEach(func() {
+				fakeDBWorker.RecordHealthReturns(true, nil)
+			})
+
+			It("logs it", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.quarantined-worker"))
+			})
+		})
+
+		Context("when the worker was already quarantined", func() {
+			BeforeEach(func() {
+				fakeDBWorker.QuarantinedReturns(true)
+				fakeDBWorker.RecordHealthReturns(true, nil)
+			})
+
+			It("does not log it again", func() {
+				Expect(logger.LogMessages()).ToNot(ContainElement("test.quarantined-worker"))
+			})
+		})
+
+		Context("when recording the outcome fails", func() {
+			BeforeEach(func() {
+				fakeDBWorker.RecordHealthReturns(false, errors.New("nope"))
+			})
+
+			It("logs the error", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.failed-to-record-worker-health"))
+			})
+		})
+	})
+
+	Describe("Evicted", func() {
+		var evicted bool
+
+		BeforeEach(func() {
+			fakeDBWorker.ReloadReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			evicted = gardenWorker.Evicted(logger)
+		})
+
+		It("reloads the worker", func() {
+			Expect(fakeDBWorker.ReloadCallCount()).To(Equal(1))
+		})
+
+		Context("when the worker is evicting", func() {
+			BeforeEach(func() {
+				fakeDBWorker.StateReturns(db.WorkerStateEvicting)
+			})
+
+			It("returns true", func() {
+				Expect(evicted).To(BeTrue())
+			})
+		})
+
+		Context("when the worker is running", func() {
+			BeforeEach(func() {
+				fakeDBWorker.StateReturns(db.WorkerStateRunning)
+			})
+
+			It("returns false", func() {
+				Expect(evicted).To(BeFalse())
+			})
+		})
+
+		Context("when the worker is gone", func() {
+			BeforeEach(func() {
+				fakeDBWorker.ReloadReturns(false, nil)
+			})
+
+			It("returns false", func() {
+				Expect(evicted).To(BeFalse())
+			})
+		})
+
+		Context("when reloading the worker fails", func() {
+			BeforeEach(func() {
+				fakeDBWorker.ReloadReturns(false, errors.New("nope"))
+			})
+
+			It("logs the error and returns false", func() {
+				Expect(evicted).To(BeFalse())
+				Expect(logger.LogMessages()).To(ContainElement("test.failed-to-reload-worker"))
+			})
+		})
+	})
+
 	Describe("Satisfies", func() {
 		var (
 			spec WorkerSpec
@@ -1245,6 +1361,42 @@ var _ = Describe("Worker", func() {
 					Expect(ioutil.ReadAll(from)).To(Equal([]byte("some-stream")))
 				})
 
+				Context("when a delta base for a remote input exists on the worker", func() {
+					var fakeBaseVolume *workerfakes.FakeVolume
+
+					BeforeEach(func() {
+						fakeBaseVolume = new(workerfakes.FakeVolume)
+						fakeBaseVolume.COWStrategyReturns(baggageclaim.COWStrategy{Parent: new(baggageclaimfakes.FakeVolume)})
+						fakeRemoteInputAS.DeltaBaseOnReturns(fakeBaseVolume, true, nil)
+
+						fakeVolumeClient.FindOrCreateCOWVolumeForContainerStub = func(logger lager.Logger, volumeSpec VolumeSpec, creatingContainer db.CreatingContainer, parent Volume, teamID int, mountPath string) (Volume, error) {
+							if mountPath == "/some/work-dir/remote-input" {
+								Expect(parent).To(Equal(fakeBaseVolume))
+							} else {
+								Expect(parent).To(Equal(fakeLocalVolume))
+							}
+
+							volumeSpecs[mountPath] = volumeSpec
+
+							return stubbedVolumes[mountPath], nil
+						}
+					})
+
+					It("creates the input volume as a copy-on-write child of the base", func() {
+						Expect(volumeSpecs["/some/work-dir/remote-input"]).To(Equal(VolumeSpec{
+							Strategy: fakeBaseVolume.COWStrategy(),
+						}))
+					})
+
+					It("streams only the delta into it", func() {
+						Expect(fakeRemoteInputAS.StreamToCallCount()).To(Equal(0))
+						Expect(fakeRemoteInputAS.StreamDeltaToCallCount()).To(Equal(1))
+
+						_, dest := fakeRemoteInputAS.StreamDeltaToArgsForCall(0)
+						Expect(dest).To(Equal(fakeRemoteInputContainerVolume))
+					})
+				})
+
 				It("marks container as created", func() {
 					Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
 				})
@@ -1320,6 +1472,73 @@ var _ = Describe("Worker", func() {
 					})
 				})
 
+				Context("when the container has secret files", func() {
+					BeforeEach(func() {
+						containerSpec.SecretFiles = map[string]string{
+							"key.pem":         "some-key",
+							"aws/credentials": "some-credentials",
+						}
+					})
+
+					It("asks the runtime for a secrets directory", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(HaveKeyWithValue("concourse:secret-files-dir", "/run/secrets"))
+					})
+
+					It("streams the secret files into the container", func() {
+						Expect(fakeGardenContainer.StreamInCallCount()).To(Equal(1))
+
+						spec := fakeGardenContainer.StreamInArgsForCall(0)
+						Expect(spec.Path).To(Equal("/run/secrets"))
+
+						files := map[string]string{}
+						tarReader := tar.NewReader(spec.TarStream)
+						for {
+							hdr, err := tarReader.Next()
+							if err == io.EOF {
+								break
+							}
+							Expect(err).ToNot(HaveOccurred())
+
+							if hdr.Typeflag == tar.TypeDir {
+								files[hdr.Name] = ""
+								continue
+							}
+
+							content, err := ioutil.ReadAll(tarReader)
+							Expect(err).ToNot(HaveOccurred())
+							files[hdr.Name] = string(content)
+						}
+
+						Expect(files).To(Equal(map[string]string{
+							"aws/":            "",
+							"aws/credentials": "some-credentials",
+							"key.pem":         "some-key",
+						}))
+					})
+
+					It("does not put the secrets in the environment", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Env).ToNot(ContainElement(ContainSubstring("some-key")))
+					})
+
+					Context("when streaming the secret files fails", func() {
+						BeforeEach(func() {
+							fakeGardenContainer.StreamInReturns(disasterErr)
+						})
+
+						It("returns an error", func() {
+							Expect(errors.Is(findOrCreateErr, disasterErr)).To(BeTrue())
+						})
+
+						It("marks the container as failed and destroys it", func() {
+							Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+							Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(0))
+							Expect(fakeGardenClient.DestroyCallCount()).To(Equal(1))
+						})
+					})
+				})
+
 				Context("when failing to create container in garden", func() {
 					BeforeEach(func() {
 						fakeGardenClient.CreateReturns(nil, disasterErr)
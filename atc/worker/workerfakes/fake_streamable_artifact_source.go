@@ -11,6 +11,22 @@ import (
 )
 
 type FakeStreamableArtifactSource struct {
+	DeltaBaseOnStub        func(lager.Logger, worker.Worker) (worker.Volume, bool, error)
+	deltaBaseOnMutex       sync.RWMutex
+	deltaBaseOnArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.Worker
+	}
+	deltaBaseOnReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	deltaBaseOnReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	ExistsOnStub        func(lager.Logger, worker.Worker) (worker.Volume, bool, error)
 	existsOnMutex       sync.RWMutex
 	existsOnArgsForCall []struct {
@@ -27,6 +43,18 @@ type FakeStreamableArtifactSource struct {
 		result2 bool
 		result3 error
 	}
+	StreamDeltaToStub        func(context.Context, worker.Volume) error
+	streamDeltaToMutex       sync.RWMutex
+	streamDeltaToArgsForCall []struct {
+		arg1 context.Context
+		arg2 worker.Volume
+	}
+	streamDeltaToReturns struct {
+		result1 error
+	}
+	streamDeltaToReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StreamFileStub        func(context.Context, string) (io.ReadCloser, error)
 	streamFileMutex       sync.RWMutex
 	streamFileArgsForCall []struct {
@@ -57,6 +85,74 @@ type FakeStreamableArtifactSource struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStreamableArtifactSource) DeltaBaseOn(arg1 lager.Logger, arg2 worker.Worker) (worker.Volume, bool, error) {
+	fake.deltaBaseOnMutex.Lock()
+	ret, specificReturn := fake.deltaBaseOnReturnsOnCall[len(fake.deltaBaseOnArgsForCall)]
+	fake.deltaBaseOnArgsForCall = append(fake.deltaBaseOnArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.Worker
+	}{arg1, arg2})
+	stub := fake.DeltaBaseOnStub
+	fakeReturns := fake.deltaBaseOnReturns
+	fake.recordInvocation("DeltaBaseOn", []interface{}{arg1, arg2})
+	fake.deltaBaseOnMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeStreamableArtifactSource) DeltaBaseOnCallCount() int {
+	fake.deltaBaseOnMutex.RLock()
+	defer fake.deltaBaseOnMutex.RUnlock()
+	return len(fake.deltaBaseOnArgsForCall)
+}
+
+func (fake *FakeStreamableArtifactSource) DeltaBaseOnCalls(stub func(lager.Logger, worker.Worker) (worker.Volume, bool, error)) {
+	fake.deltaBaseOnMutex.Lock()
+	defer fake.deltaBaseOnMutex.Unlock()
+	fake.DeltaBaseOnStub = stub
+}
+
+func (fake *FakeStreamableArtifactSource) DeltaBaseOnArgsForCall(i int) (lager.Logger, worker.Worker) {
+	fake.deltaBaseOnMutex.RLock()
+	defer fake.deltaBaseOnMutex.RUnlock()
+	argsForCall := fake.deltaBaseOnArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStreamableArtifactSource) DeltaBaseOnReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.deltaBaseOnMutex.Lock()
+	defer fake.deltaBaseOnMutex.Unlock()
+	fake.DeltaBaseOnStub = nil
+	fake.deltaBaseOnReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeStreamableArtifactSource) DeltaBaseOnReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.deltaBaseOnMutex.Lock()
+	defer fake.deltaBaseOnMutex.Unlock()
+	fake.DeltaBaseOnStub = nil
+	if fake.deltaBaseOnReturnsOnCall == nil {
+		fake.deltaBaseOnReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.deltaBaseOnReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeStreamableArtifactSource) ExistsOn(arg1 lager.Logger, arg2 worker.Worker) (worker.Volume, bool, error) {
 	fake.existsOnMutex.Lock()
 	ret, specificReturn := fake.existsOnReturnsOnCall[len(fake.existsOnArgsForCall)]
@@ -125,6 +221,68 @@ func (fake *FakeStreamableArtifactSource) ExistsOnReturnsOnCall(i int, result1 w
 	}{result1, result2, result3}
 }
 
+func (fake *FakeStreamableArtifactSource) StreamDeltaTo(arg1 context.Context, arg2 worker.Volume) error {
+	fake.streamDeltaToMutex.Lock()
+	ret, specificReturn := fake.streamDeltaToReturnsOnCall[len(fake.streamDeltaToArgsForCall)]
+	fake.streamDeltaToArgsForCall = append(fake.streamDeltaToArgsForCall, struct {
+		arg1 context.Context
+		arg2 worker.Volume
+	}{arg1, arg2})
+	stub := fake.StreamDeltaToStub
+	fakeReturns := fake.streamDeltaToReturns
+	fake.recordInvocation("StreamDeltaTo", []interface{}{arg1, arg2})
+	fake.streamDeltaToMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStreamableArtifactSource) StreamDeltaToCallCount() int {
+	fake.streamDeltaToMutex.RLock()
+	defer fake.streamDeltaToMutex.RUnlock()
+	return len(fake.streamDeltaToArgsForCall)
+}
+
+func (fake *FakeStreamableArtifactSource) StreamDeltaToCalls(stub func(context.Context, worker.Volume) error) {
+	fake.streamDeltaToMutex.Lock()
+	defer fake.streamDeltaToMutex.Unlock()
+	fake.StreamDeltaToStub = stub
+}
+
+func (fake *FakeStreamableArtifactSource) StreamDeltaToArgsForCall(i int) (context.Context, worker.Volume) {
+	fake.streamDeltaToMutex.RLock()
+	defer fake.streamDeltaToMutex.RUnlock()
+	argsForCall := fake.streamDeltaToArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStreamableArtifactSource) StreamDeltaToReturns(result1 error) {
+	fake.streamDeltaToMutex.Lock()
+	defer fake.streamDeltaToMutex.Unlock()
+	fake.StreamDeltaToStub = nil
+	fake.streamDeltaToReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStreamableArtifactSource) StreamDeltaToReturnsOnCall(i int, result1 error) {
+	fake.streamDeltaToMutex.Lock()
+	defer fake.streamDeltaToMutex.Unlock()
+	fake.StreamDeltaToStub = nil
+	if fake.streamDeltaToReturnsOnCall == nil {
+		fake.streamDeltaToReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamDeltaToReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStreamableArtifactSource) StreamFile(arg1 context.Context, arg2 string) (io.ReadCloser, error) {
 	fake.streamFileMutex.Lock()
 	ret, specificReturn := fake.streamFileReturnsOnCall[len(fake.streamFileArgsForCall)]
@@ -255,8 +413,12 @@ func (fake *FakeStreamableArtifactSource) StreamToReturnsOnCall(i int, result1 e
 func (fake *FakeStreamableArtifactSource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deltaBaseOnMutex.RLock()
+	defer fake.deltaBaseOnMutex.RUnlock()
 	fake.existsOnMutex.RLock()
 	defer fake.existsOnMutex.RUnlock()
+	fake.streamDeltaToMutex.RLock()
+	defer fake.streamDeltaToMutex.RUnlock()
 	fake.streamFileMutex.RLock()
 	defer fake.streamFileMutex.RUnlock()
 	fake.streamToMutex.RLock()
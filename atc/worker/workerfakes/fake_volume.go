@@ -5,9 +5,11 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -37,6 +39,61 @@ type FakeVolume struct {
 		result1 db.CreatingVolume
 		result2 error
 	}
+	DeltaManifestStub        func(context.Context) (io.ReadCloser, error)
+	deltaManifestMutex       sync.RWMutex
+	deltaManifestArgsForCall []struct {
+		arg1 context.Context
+	}
+	deltaManifestReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	deltaManifestReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	DeltaStreamInStub        func(context.Context, baggageclaim.Encoding, io.Reader) error
+	deltaStreamInMutex       sync.RWMutex
+	deltaStreamInArgsForCall []struct {
+		arg1 context.Context
+		arg2 baggageclaim.Encoding
+		arg3 io.Reader
+	}
+	deltaStreamInReturns struct {
+		result1 error
+	}
+	deltaStreamInReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeltaStreamOutStub        func(context.Context, baggageclaim.Encoding, io.Reader) (io.ReadCloser, error)
+	deltaStreamOutMutex       sync.RWMutex
+	deltaStreamOutArgsForCall []struct {
+		arg1 context.Context
+		arg2 baggageclaim.Encoding
+		arg3 io.Reader
+	}
+	deltaStreamOutReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	deltaStreamOutReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	DeltaStreamP2pOutStub        func(context.Context, string, string, baggageclaim.Encoding) error
+	deltaStreamP2pOutMutex       sync.RWMutex
+	deltaStreamP2pOutArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 baggageclaim.Encoding
+	}
+	deltaStreamP2pOutReturns struct {
+		result1 error
+	}
+	deltaStreamP2pOutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DestroyStub        func() error
 	destroyMutex       sync.RWMutex
 	destroyArgsForCall []struct {
@@ -47,6 +104,19 @@ type FakeVolume struct {
 	destroyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetDeltaP2pUrlStub        func(context.Context) (string, error)
+	getDeltaP2pUrlMutex       sync.RWMutex
+	getDeltaP2pUrlArgsForCall []struct {
+		arg1 context.Context
+	}
+	getDeltaP2pUrlReturns struct {
+		result1 string
+		result2 error
+	}
+	getDeltaP2pUrlReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	GetResourceCacheIDStub        func() int
 	getResourceCacheIDMutex       sync.RWMutex
 	getResourceCacheIDArgsForCall []struct {
@@ -95,6 +165,22 @@ type FakeVolume struct {
 		result1 db.WorkerArtifact
 		result2 error
 	}
+	InitializeKeyedCacheStub        func(context.Context, lager.Logger, int, string, string, bool) error
+	initializeKeyedCacheMutex       sync.RWMutex
+	initializeKeyedCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int
+		arg4 string
+		arg5 string
+		arg6 bool
+	}
+	initializeKeyedCacheReturns struct {
+		result1 error
+	}
+	initializeKeyedCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InitializeResourceCacheStub        func(db.UsedResourceCache) error
 	initializeResourceCacheMutex       sync.RWMutex
 	initializeResourceCacheArgsForCall []struct {
@@ -106,6 +192,22 @@ type FakeVolume struct {
 	initializeResourceCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitializeStepOutputStub        func(string, int, atc.PlanID, time.Time) (db.WorkerArtifact, error)
+	initializeStepOutputMutex       sync.RWMutex
+	initializeStepOutputArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 atc.PlanID
+		arg4 time.Time
+	}
+	initializeStepOutputReturns struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
+	initializeStepOutputReturnsOnCall map[int]struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}
 	InitializeTaskCacheStub        func(lager.Logger, int, string, string, bool) error
 	initializeTaskCacheMutex       sync.RWMutex
 	initializeTaskCacheArgsForCall []struct {
@@ -143,6 +245,17 @@ type FakeVolume struct {
 		result1 baggageclaim.VolumeProperties
 		result2 error
 	}
+	RecordResourceCacheChecksumStub        func(context.Context) error
+	recordResourceCacheChecksumMutex       sync.RWMutex
+	recordResourceCacheChecksumArgsForCall []struct {
+		arg1 context.Context
+	}
+	recordResourceCacheChecksumReturns struct {
+		result1 error
+	}
+	recordResourceCacheChecksumReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPrivilegedStub        func(bool) error
 	setPrivilegedMutex       sync.RWMutex
 	setPrivilegedArgsForCall []struct {
@@ -209,6 +322,31 @@ type FakeVolume struct {
 	streamP2pOutReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SupportsDeltaStreamingStub        func() bool
+	supportsDeltaStreamingMutex       sync.RWMutex
+	supportsDeltaStreamingArgsForCall []struct {
+	}
+	supportsDeltaStreamingReturns struct {
+		result1 bool
+	}
+	supportsDeltaStreamingReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	VerifyResourceCacheStub        func(context.Context, lager.Logger, time.Duration) (bool, error)
+	verifyResourceCacheMutex       sync.RWMutex
+	verifyResourceCacheArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 time.Duration
+	}
+	verifyResourceCacheReturns struct {
+		result1 bool
+		result2 error
+	}
+	verifyResourceCacheReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	WorkerNameStub        func() string
 	workerNameMutex       sync.RWMutex
 	workerNameArgsForCall []struct {
@@ -341,6 +479,263 @@ func (fake *FakeVolume) CreateChildForContainerReturnsOnCall(i int, result1 db.C
 	}{result1, result2}
 }
 
+func (fake *FakeVolume) DeltaManifest(arg1 context.Context) (io.ReadCloser, error) {
+	fake.deltaManifestMutex.Lock()
+	ret, specificReturn := fake.deltaManifestReturnsOnCall[len(fake.deltaManifestArgsForCall)]
+	fake.deltaManifestArgsForCall = append(fake.deltaManifestArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeltaManifestStub
+	fakeReturns := fake.deltaManifestReturns
+	fake.recordInvocation("DeltaManifest", []interface{}{arg1})
+	fake.deltaManifestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) DeltaManifestCallCount() int {
+	fake.deltaManifestMutex.RLock()
+	defer fake.deltaManifestMutex.RUnlock()
+	return len(fake.deltaManifestArgsForCall)
+}
+
+func (fake *FakeVolume) DeltaManifestCalls(stub func(context.Context) (io.ReadCloser, error)) {
+	fake.deltaManifestMutex.Lock()
+	defer fake.deltaManifestMutex.Unlock()
+	fake.DeltaManifestStub = stub
+}
+
+func (fake *FakeVolume) DeltaManifestArgsForCall(i int) context.Context {
+	fake.deltaManifestMutex.RLock()
+	defer fake.deltaManifestMutex.RUnlock()
+	argsForCall := fake.deltaManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolume) DeltaManifestReturns(result1 io.ReadCloser, result2 error) {
+	fake.deltaManifestMutex.Lock()
+	defer fake.deltaManifestMutex.Unlock()
+	fake.DeltaManifestStub = nil
+	fake.deltaManifestReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) DeltaManifestReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.deltaManifestMutex.Lock()
+	defer fake.deltaManifestMutex.Unlock()
+	fake.DeltaManifestStub = nil
+	if fake.deltaManifestReturnsOnCall == nil {
+		fake.deltaManifestReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.deltaManifestReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) DeltaStreamIn(arg1 context.Context, arg2 baggageclaim.Encoding, arg3 io.Reader) error {
+	fake.deltaStreamInMutex.Lock()
+	ret, specificReturn := fake.deltaStreamInReturnsOnCall[len(fake.deltaStreamInArgsForCall)]
+	fake.deltaStreamInArgsForCall = append(fake.deltaStreamInArgsForCall, struct {
+		arg1 context.Context
+		arg2 baggageclaim.Encoding
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	stub := fake.DeltaStreamInStub
+	fakeReturns := fake.deltaStreamInReturns
+	fake.recordInvocation("DeltaStreamIn", []interface{}{arg1, arg2, arg3})
+	fake.deltaStreamInMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) DeltaStreamInCallCount() int {
+	fake.deltaStreamInMutex.RLock()
+	defer fake.deltaStreamInMutex.RUnlock()
+	return len(fake.deltaStreamInArgsForCall)
+}
+
+func (fake *FakeVolume) DeltaStreamInCalls(stub func(context.Context, baggageclaim.Encoding, io.Reader) error) {
+	fake.deltaStreamInMutex.Lock()
+	defer fake.deltaStreamInMutex.Unlock()
+	fake.DeltaStreamInStub = stub
+}
+
+func (fake *FakeVolume) DeltaStreamInArgsForCall(i int) (context.Context, baggageclaim.Encoding, io.Reader) {
+	fake.deltaStreamInMutex.RLock()
+	defer fake.deltaStreamInMutex.RUnlock()
+	argsForCall := fake.deltaStreamInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolume) DeltaStreamInReturns(result1 error) {
+	fake.deltaStreamInMutex.Lock()
+	defer fake.deltaStreamInMutex.Unlock()
+	fake.DeltaStreamInStub = nil
+	fake.deltaStreamInReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) DeltaStreamInReturnsOnCall(i int, result1 error) {
+	fake.deltaStreamInMutex.Lock()
+	defer fake.deltaStreamInMutex.Unlock()
+	fake.DeltaStreamInStub = nil
+	if fake.deltaStreamInReturnsOnCall == nil {
+		fake.deltaStreamInReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deltaStreamInReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) DeltaStreamOut(arg1 context.Context, arg2 baggageclaim.Encoding, arg3 io.Reader) (io.ReadCloser, error) {
+	fake.deltaStreamOutMutex.Lock()
+	ret, specificReturn := fake.deltaStreamOutReturnsOnCall[len(fake.deltaStreamOutArgsForCall)]
+	fake.deltaStreamOutArgsForCall = append(fake.deltaStreamOutArgsForCall, struct {
+		arg1 context.Context
+		arg2 baggageclaim.Encoding
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	stub := fake.DeltaStreamOutStub
+	fakeReturns := fake.deltaStreamOutReturns
+	fake.recordInvocation("DeltaStreamOut", []interface{}{arg1, arg2, arg3})
+	fake.deltaStreamOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) DeltaStreamOutCallCount() int {
+	fake.deltaStreamOutMutex.RLock()
+	defer fake.deltaStreamOutMutex.RUnlock()
+	return len(fake.deltaStreamOutArgsForCall)
+}
+
+func (fake *FakeVolume) DeltaStreamOutCalls(stub func(context.Context, baggageclaim.Encoding, io.Reader) (io.ReadCloser, error)) {
+	fake.deltaStreamOutMutex.Lock()
+	defer fake.deltaStreamOutMutex.Unlock()
+	fake.DeltaStreamOutStub = stub
+}
+
+func (fake *FakeVolume) DeltaStreamOutArgsForCall(i int) (context.Context, baggageclaim.Encoding, io.Reader) {
+	fake.deltaStreamOutMutex.RLock()
+	defer fake.deltaStreamOutMutex.RUnlock()
+	argsForCall := fake.deltaStreamOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolume) DeltaStreamOutReturns(result1 io.ReadCloser, result2 error) {
+	fake.deltaStreamOutMutex.Lock()
+	defer fake.deltaStreamOutMutex.Unlock()
+	fake.DeltaStreamOutStub = nil
+	fake.deltaStreamOutReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) DeltaStreamOutReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.deltaStreamOutMutex.Lock()
+	defer fake.deltaStreamOutMutex.Unlock()
+	fake.DeltaStreamOutStub = nil
+	if fake.deltaStreamOutReturnsOnCall == nil {
+		fake.deltaStreamOutReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.deltaStreamOutReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) DeltaStreamP2pOut(arg1 context.Context, arg2 string, arg3 string, arg4 baggageclaim.Encoding) error {
+	fake.deltaStreamP2pOutMutex.Lock()
+	ret, specificReturn := fake.deltaStreamP2pOutReturnsOnCall[len(fake.deltaStreamP2pOutArgsForCall)]
+	fake.deltaStreamP2pOutArgsForCall = append(fake.deltaStreamP2pOutArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 baggageclaim.Encoding
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DeltaStreamP2pOutStub
+	fakeReturns := fake.deltaStreamP2pOutReturns
+	fake.recordInvocation("DeltaStreamP2pOut", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deltaStreamP2pOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) DeltaStreamP2pOutCallCount() int {
+	fake.deltaStreamP2pOutMutex.RLock()
+	defer fake.deltaStreamP2pOutMutex.RUnlock()
+	return len(fake.deltaStreamP2pOutArgsForCall)
+}
+
+func (fake *FakeVolume) DeltaStreamP2pOutCalls(stub func(context.Context, string, string, baggageclaim.Encoding) error) {
+	fake.deltaStreamP2pOutMutex.Lock()
+	defer fake.deltaStreamP2pOutMutex.Unlock()
+	fake.DeltaStreamP2pOutStub = stub
+}
+
+func (fake *FakeVolume) DeltaStreamP2pOutArgsForCall(i int) (context.Context, string, string, baggageclaim.Encoding) {
+	fake.deltaStreamP2pOutMutex.RLock()
+	defer fake.deltaStreamP2pOutMutex.RUnlock()
+	argsForCall := fake.deltaStreamP2pOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolume) DeltaStreamP2pOutReturns(result1 error) {
+	fake.deltaStreamP2pOutMutex.Lock()
+	defer fake.deltaStreamP2pOutMutex.Unlock()
+	fake.DeltaStreamP2pOutStub = nil
+	fake.deltaStreamP2pOutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) DeltaStreamP2pOutReturnsOnCall(i int, result1 error) {
+	fake.deltaStreamP2pOutMutex.Lock()
+	defer fake.deltaStreamP2pOutMutex.Unlock()
+	fake.DeltaStreamP2pOutStub = nil
+	if fake.deltaStreamP2pOutReturnsOnCall == nil {
+		fake.deltaStreamP2pOutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deltaStreamP2pOutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) Destroy() error {
 	fake.destroyMutex.Lock()
 	ret, specificReturn := fake.destroyReturnsOnCall[len(fake.destroyArgsForCall)]
@@ -394,6 +789,70 @@ func (fake *FakeVolume) DestroyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) GetDeltaP2pUrl(arg1 context.Context) (string, error) {
+	fake.getDeltaP2pUrlMutex.Lock()
+	ret, specificReturn := fake.getDeltaP2pUrlReturnsOnCall[len(fake.getDeltaP2pUrlArgsForCall)]
+	fake.getDeltaP2pUrlArgsForCall = append(fake.getDeltaP2pUrlArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetDeltaP2pUrlStub
+	fakeReturns := fake.getDeltaP2pUrlReturns
+	fake.recordInvocation("GetDeltaP2pUrl", []interface{}{arg1})
+	fake.getDeltaP2pUrlMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) GetDeltaP2pUrlCallCount() int {
+	fake.getDeltaP2pUrlMutex.RLock()
+	defer fake.getDeltaP2pUrlMutex.RUnlock()
+	return len(fake.getDeltaP2pUrlArgsForCall)
+}
+
+func (fake *FakeVolume) GetDeltaP2pUrlCalls(stub func(context.Context) (string, error)) {
+	fake.getDeltaP2pUrlMutex.Lock()
+	defer fake.getDeltaP2pUrlMutex.Unlock()
+	fake.GetDeltaP2pUrlStub = stub
+}
+
+func (fake *FakeVolume) GetDeltaP2pUrlArgsForCall(i int) context.Context {
+	fake.getDeltaP2pUrlMutex.RLock()
+	defer fake.getDeltaP2pUrlMutex.RUnlock()
+	argsForCall := fake.getDeltaP2pUrlArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolume) GetDeltaP2pUrlReturns(result1 string, result2 error) {
+	fake.getDeltaP2pUrlMutex.Lock()
+	defer fake.getDeltaP2pUrlMutex.Unlock()
+	fake.GetDeltaP2pUrlStub = nil
+	fake.getDeltaP2pUrlReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) GetDeltaP2pUrlReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDeltaP2pUrlMutex.Lock()
+	defer fake.getDeltaP2pUrlMutex.Unlock()
+	fake.GetDeltaP2pUrlStub = nil
+	if fake.getDeltaP2pUrlReturnsOnCall == nil {
+		fake.getDeltaP2pUrlReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDeltaP2pUrlReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) GetResourceCacheID() int {
 	fake.getResourceCacheIDMutex.Lock()
 	ret, specificReturn := fake.getResourceCacheIDReturnsOnCall[len(fake.getResourceCacheIDArgsForCall)]
@@ -630,6 +1089,72 @@ func (fake *FakeVolume) InitializeArtifactReturnsOnCall(i int, result1 db.Worker
 	}{result1, result2}
 }
 
+func (fake *FakeVolume) InitializeKeyedCache(arg1 context.Context, arg2 lager.Logger, arg3 int, arg4 string, arg5 string, arg6 bool) error {
+	fake.initializeKeyedCacheMutex.Lock()
+	ret, specificReturn := fake.initializeKeyedCacheReturnsOnCall[len(fake.initializeKeyedCacheArgsForCall)]
+	fake.initializeKeyedCacheArgsForCall = append(fake.initializeKeyedCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int
+		arg4 string
+		arg5 string
+		arg6 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.InitializeKeyedCacheStub
+	fakeReturns := fake.initializeKeyedCacheReturns
+	fake.recordInvocation("InitializeKeyedCache", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.initializeKeyedCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheCallCount() int {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	return len(fake.initializeKeyedCacheArgsForCall)
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheCalls(stub func(context.Context, lager.Logger, int, string, string, bool) error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = stub
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheArgsForCall(i int) (context.Context, lager.Logger, int, string, string, bool) {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	argsForCall := fake.initializeKeyedCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheReturns(result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	fake.initializeKeyedCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheReturnsOnCall(i int, result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	if fake.initializeKeyedCacheReturnsOnCall == nil {
+		fake.initializeKeyedCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeKeyedCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) InitializeResourceCache(arg1 db.UsedResourceCache) error {
 	fake.initializeResourceCacheMutex.Lock()
 	ret, specificReturn := fake.initializeResourceCacheReturnsOnCall[len(fake.initializeResourceCacheArgsForCall)]
@@ -691,6 +1216,73 @@ func (fake *FakeVolume) InitializeResourceCacheReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeVolume) InitializeStepOutput(arg1 string, arg2 int, arg3 atc.PlanID, arg4 time.Time) (db.WorkerArtifact, error) {
+	fake.initializeStepOutputMutex.Lock()
+	ret, specificReturn := fake.initializeStepOutputReturnsOnCall[len(fake.initializeStepOutputArgsForCall)]
+	fake.initializeStepOutputArgsForCall = append(fake.initializeStepOutputArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 atc.PlanID
+		arg4 time.Time
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.InitializeStepOutputStub
+	fakeReturns := fake.initializeStepOutputReturns
+	fake.recordInvocation("InitializeStepOutput", []interface{}{arg1, arg2, arg3, arg4})
+	fake.initializeStepOutputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) InitializeStepOutputCallCount() int {
+	fake.initializeStepOutputMutex.RLock()
+	defer fake.initializeStepOutputMutex.RUnlock()
+	return len(fake.initializeStepOutputArgsForCall)
+}
+
+func (fake *FakeVolume) InitializeStepOutputCalls(stub func(string, int, atc.PlanID, time.Time) (db.WorkerArtifact, error)) {
+	fake.initializeStepOutputMutex.Lock()
+	defer fake.initializeStepOutputMutex.Unlock()
+	fake.InitializeStepOutputStub = stub
+}
+
+func (fake *FakeVolume) InitializeStepOutputArgsForCall(i int) (string, int, atc.PlanID, time.Time) {
+	fake.initializeStepOutputMutex.RLock()
+	defer fake.initializeStepOutputMutex.RUnlock()
+	argsForCall := fake.initializeStepOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolume) InitializeStepOutputReturns(result1 db.WorkerArtifact, result2 error) {
+	fake.initializeStepOutputMutex.Lock()
+	defer fake.initializeStepOutputMutex.Unlock()
+	fake.InitializeStepOutputStub = nil
+	fake.initializeStepOutputReturns = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) InitializeStepOutputReturnsOnCall(i int, result1 db.WorkerArtifact, result2 error) {
+	fake.initializeStepOutputMutex.Lock()
+	defer fake.initializeStepOutputMutex.Unlock()
+	fake.InitializeStepOutputStub = nil
+	if fake.initializeStepOutputReturnsOnCall == nil {
+		fake.initializeStepOutputReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerArtifact
+			result2 error
+		})
+	}
+	fake.initializeStepOutputReturnsOnCall[i] = struct {
+		result1 db.WorkerArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) InitializeTaskCache(arg1 lager.Logger, arg2 int, arg3 string, arg4 string, arg5 bool) error {
 	fake.initializeTaskCacheMutex.Lock()
 	ret, specificReturn := fake.initializeTaskCacheReturnsOnCall[len(fake.initializeTaskCacheArgsForCall)]
@@ -865,6 +1457,67 @@ func (fake *FakeVolume) PropertiesReturnsOnCall(i int, result1 baggageclaim.Volu
 	}{result1, result2}
 }
 
+func (fake *FakeVolume) RecordResourceCacheChecksum(arg1 context.Context) error {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	ret, specificReturn := fake.recordResourceCacheChecksumReturnsOnCall[len(fake.recordResourceCacheChecksumArgsForCall)]
+	fake.recordResourceCacheChecksumArgsForCall = append(fake.recordResourceCacheChecksumArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.RecordResourceCacheChecksumStub
+	fakeReturns := fake.recordResourceCacheChecksumReturns
+	fake.recordInvocation("RecordResourceCacheChecksum", []interface{}{arg1})
+	fake.recordResourceCacheChecksumMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) RecordResourceCacheChecksumCallCount() int {
+	fake.recordResourceCacheChecksumMutex.RLock()
+	defer fake.recordResourceCacheChecksumMutex.RUnlock()
+	return len(fake.recordResourceCacheChecksumArgsForCall)
+}
+
+func (fake *FakeVolume) RecordResourceCacheChecksumCalls(stub func(context.Context) error) {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	defer fake.recordResourceCacheChecksumMutex.Unlock()
+	fake.RecordResourceCacheChecksumStub = stub
+}
+
+func (fake *FakeVolume) RecordResourceCacheChecksumArgsForCall(i int) context.Context {
+	fake.recordResourceCacheChecksumMutex.RLock()
+	defer fake.recordResourceCacheChecksumMutex.RUnlock()
+	argsForCall := fake.recordResourceCacheChecksumArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVolume) RecordResourceCacheChecksumReturns(result1 error) {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	defer fake.recordResourceCacheChecksumMutex.Unlock()
+	fake.RecordResourceCacheChecksumStub = nil
+	fake.recordResourceCacheChecksumReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) RecordResourceCacheChecksumReturnsOnCall(i int, result1 error) {
+	fake.recordResourceCacheChecksumMutex.Lock()
+	defer fake.recordResourceCacheChecksumMutex.Unlock()
+	fake.RecordResourceCacheChecksumStub = nil
+	if fake.recordResourceCacheChecksumReturnsOnCall == nil {
+		fake.recordResourceCacheChecksumReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordResourceCacheChecksumReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) SetPrivileged(arg1 bool) error {
 	fake.setPrivilegedMutex.Lock()
 	ret, specificReturn := fake.setPrivilegedReturnsOnCall[len(fake.setPrivilegedArgsForCall)]
@@ -1182,6 +1835,125 @@ func (fake *FakeVolume) StreamP2pOutReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) SupportsDeltaStreaming() bool {
+	fake.supportsDeltaStreamingMutex.Lock()
+	ret, specificReturn := fake.supportsDeltaStreamingReturnsOnCall[len(fake.supportsDeltaStreamingArgsForCall)]
+	fake.supportsDeltaStreamingArgsForCall = append(fake.supportsDeltaStreamingArgsForCall, struct {
+	}{})
+	stub := fake.SupportsDeltaStreamingStub
+	fakeReturns := fake.supportsDeltaStreamingReturns
+	fake.recordInvocation("SupportsDeltaStreaming", []interface{}{})
+	fake.supportsDeltaStreamingMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) SupportsDeltaStreamingCallCount() int {
+	fake.supportsDeltaStreamingMutex.RLock()
+	defer fake.supportsDeltaStreamingMutex.RUnlock()
+	return len(fake.supportsDeltaStreamingArgsForCall)
+}
+
+func (fake *FakeVolume) SupportsDeltaStreamingCalls(stub func() bool) {
+	fake.supportsDeltaStreamingMutex.Lock()
+	defer fake.supportsDeltaStreamingMutex.Unlock()
+	fake.SupportsDeltaStreamingStub = stub
+}
+
+func (fake *FakeVolume) SupportsDeltaStreamingReturns(result1 bool) {
+	fake.supportsDeltaStreamingMutex.Lock()
+	defer fake.supportsDeltaStreamingMutex.Unlock()
+	fake.SupportsDeltaStreamingStub = nil
+	fake.supportsDeltaStreamingReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeVolume) SupportsDeltaStreamingReturnsOnCall(i int, result1 bool) {
+	fake.supportsDeltaStreamingMutex.Lock()
+	defer fake.supportsDeltaStreamingMutex.Unlock()
+	fake.SupportsDeltaStreamingStub = nil
+	if fake.supportsDeltaStreamingReturnsOnCall == nil {
+		fake.supportsDeltaStreamingReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.supportsDeltaStreamingReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeVolume) VerifyResourceCache(arg1 context.Context, arg2 lager.Logger, arg3 time.Duration) (bool, error) {
+	fake.verifyResourceCacheMutex.Lock()
+	ret, specificReturn := fake.verifyResourceCacheReturnsOnCall[len(fake.verifyResourceCacheArgsForCall)]
+	fake.verifyResourceCacheArgsForCall = append(fake.verifyResourceCacheArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.VerifyResourceCacheStub
+	fakeReturns := fake.verifyResourceCacheReturns
+	fake.recordInvocation("VerifyResourceCache", []interface{}{arg1, arg2, arg3})
+	fake.verifyResourceCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) VerifyResourceCacheCallCount() int {
+	fake.verifyResourceCacheMutex.RLock()
+	defer fake.verifyResourceCacheMutex.RUnlock()
+	return len(fake.verifyResourceCacheArgsForCall)
+}
+
+func (fake *FakeVolume) VerifyResourceCacheCalls(stub func(context.Context, lager.Logger, time.Duration) (bool, error)) {
+	fake.verifyResourceCacheMutex.Lock()
+	defer fake.verifyResourceCacheMutex.Unlock()
+	fake.VerifyResourceCacheStub = stub
+}
+
+func (fake *FakeVolume) VerifyResourceCacheArgsForCall(i int) (context.Context, lager.Logger, time.Duration) {
+	fake.verifyResourceCacheMutex.RLock()
+	defer fake.verifyResourceCacheMutex.RUnlock()
+	argsForCall := fake.verifyResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolume) VerifyResourceCacheReturns(result1 bool, result2 error) {
+	fake.verifyResourceCacheMutex.Lock()
+	defer fake.verifyResourceCacheMutex.Unlock()
+	fake.VerifyResourceCacheStub = nil
+	fake.verifyResourceCacheReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) VerifyResourceCacheReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.verifyResourceCacheMutex.Lock()
+	defer fake.verifyResourceCacheMutex.Unlock()
+	fake.VerifyResourceCacheStub = nil
+	if fake.verifyResourceCacheReturnsOnCall == nil {
+		fake.verifyResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.verifyResourceCacheReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) WorkerName() string {
 	fake.workerNameMutex.Lock()
 	ret, specificReturn := fake.workerNameReturnsOnCall[len(fake.workerNameArgsForCall)]
@@ -1242,8 +2014,18 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.cOWStrategyMutex.RUnlock()
 	fake.createChildForContainerMutex.RLock()
 	defer fake.createChildForContainerMutex.RUnlock()
+	fake.deltaManifestMutex.RLock()
+	defer fake.deltaManifestMutex.RUnlock()
+	fake.deltaStreamInMutex.RLock()
+	defer fake.deltaStreamInMutex.RUnlock()
+	fake.deltaStreamOutMutex.RLock()
+	defer fake.deltaStreamOutMutex.RUnlock()
+	fake.deltaStreamP2pOutMutex.RLock()
+	defer fake.deltaStreamP2pOutMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
+	fake.getDeltaP2pUrlMutex.RLock()
+	defer fake.getDeltaP2pUrlMutex.RUnlock()
 	fake.getResourceCacheIDMutex.RLock()
 	defer fake.getResourceCacheIDMutex.RUnlock()
 	fake.getStreamInP2pUrlMutex.RLock()
@@ -1252,14 +2034,20 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.handleMutex.RUnlock()
 	fake.initializeArtifactMutex.RLock()
 	defer fake.initializeArtifactMutex.RUnlock()
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
+	fake.initializeStepOutputMutex.RLock()
+	defer fake.initializeStepOutputMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
 	fake.pathMutex.RLock()
 	defer fake.pathMutex.RUnlock()
 	fake.propertiesMutex.RLock()
 	defer fake.propertiesMutex.RUnlock()
+	fake.recordResourceCacheChecksumMutex.RLock()
+	defer fake.recordResourceCacheChecksumMutex.RUnlock()
 	fake.setPrivilegedMutex.RLock()
 	defer fake.setPrivilegedMutex.RUnlock()
 	fake.setPropertyMutex.RLock()
@@ -1270,6 +2058,10 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.streamOutMutex.RUnlock()
 	fake.streamP2pOutMutex.RLock()
 	defer fake.streamP2pOutMutex.RUnlock()
+	fake.supportsDeltaStreamingMutex.RLock()
+	defer fake.supportsDeltaStreamingMutex.RUnlock()
+	fake.verifyResourceCacheMutex.RLock()
+	defer fake.verifyResourceCacheMutex.RUnlock()
 	fake.workerNameMutex.RLock()
 	defer fake.workerNameMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
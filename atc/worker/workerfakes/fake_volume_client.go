@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -45,6 +46,39 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
+	FindDeltaBaseForResourceCacheStub        func(lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)
+	findDeltaBaseForResourceCacheMutex       sync.RWMutex
+	findDeltaBaseForResourceCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.UsedResourceCache
+	}
+	findDeltaBaseForResourceCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findDeltaBaseForResourceCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	FindDeltaBaseForTaskCacheStub        func(lager.Logger, int, string) (worker.Volume, bool, error)
+	findDeltaBaseForTaskCacheMutex       sync.RWMutex
+	findDeltaBaseForTaskCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 string
+	}
+	findDeltaBaseForTaskCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findDeltaBaseForTaskCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	FindOrCreateCOWVolumeForContainerStub        func(lager.Logger, worker.VolumeSpec, db.CreatingContainer, worker.Volume, int, string) (worker.Volume, error)
 	findOrCreateCOWVolumeForContainerMutex       sync.RWMutex
 	findOrCreateCOWVolumeForContainerArgsForCall []struct {
@@ -96,6 +130,22 @@ type FakeVolumeClient struct {
 		result1 worker.Volume
 		result2 error
 	}
+	FindOrCreateVolumeForLayerStoreStub        func(lager.Logger, string, bool, func(baggageclaim.Volume) error) (baggageclaim.Volume, error)
+	findOrCreateVolumeForLayerStoreMutex       sync.RWMutex
+	findOrCreateVolumeForLayerStoreArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 bool
+		arg4 func(baggageclaim.Volume) error
+	}
+	findOrCreateVolumeForLayerStoreReturns struct {
+		result1 baggageclaim.Volume
+		result2 error
+	}
+	findOrCreateVolumeForLayerStoreReturnsOnCall map[int]struct {
+		result1 baggageclaim.Volume
+		result2 error
+	}
 	FindOrCreateVolumeForResourceCertsStub        func(lager.Logger) (worker.Volume, bool, error)
 	findOrCreateVolumeForResourceCertsMutex       sync.RWMutex
 	findOrCreateVolumeForResourceCertsArgsForCall []struct {
@@ -111,6 +161,23 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	FindVolumeForLayerStoreStub        func(lager.Logger, string, bool) (baggageclaim.Volume, bool, error)
+	findVolumeForLayerStoreMutex       sync.RWMutex
+	findVolumeForLayerStoreArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 bool
+	}
+	findVolumeForLayerStoreReturns struct {
+		result1 baggageclaim.Volume
+		result2 bool
+		result3 error
+	}
+	findVolumeForLayerStoreReturnsOnCall map[int]struct {
+		result1 baggageclaim.Volume
+		result2 bool
+		result3 error
+	}
 	FindVolumeForResourceCacheStub        func(lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)
 	findVolumeForResourceCacheMutex       sync.RWMutex
 	findVolumeForResourceCacheArgsForCall []struct {
@@ -303,6 +370,143 @@ func (fake *FakeVolumeClient) CreateVolumeForTaskCacheReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeClient) FindDeltaBaseForResourceCache(arg1 lager.Logger, arg2 db.UsedResourceCache) (worker.Volume, bool, error) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	ret, specificReturn := fake.findDeltaBaseForResourceCacheReturnsOnCall[len(fake.findDeltaBaseForResourceCacheArgsForCall)]
+	fake.findDeltaBaseForResourceCacheArgsForCall = append(fake.findDeltaBaseForResourceCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.UsedResourceCache
+	}{arg1, arg2})
+	stub := fake.FindDeltaBaseForResourceCacheStub
+	fakeReturns := fake.findDeltaBaseForResourceCacheReturns
+	fake.recordInvocation("FindDeltaBaseForResourceCache", []interface{}{arg1, arg2})
+	fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForResourceCacheCallCount() int {
+	fake.findDeltaBaseForResourceCacheMutex.RLock()
+	defer fake.findDeltaBaseForResourceCacheMutex.RUnlock()
+	return len(fake.findDeltaBaseForResourceCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForResourceCacheCalls(stub func(lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	defer fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	fake.FindDeltaBaseForResourceCacheStub = stub
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForResourceCacheArgsForCall(i int) (lager.Logger, db.UsedResourceCache) {
+	fake.findDeltaBaseForResourceCacheMutex.RLock()
+	defer fake.findDeltaBaseForResourceCacheMutex.RUnlock()
+	argsForCall := fake.findDeltaBaseForResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	defer fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	fake.FindDeltaBaseForResourceCacheStub = nil
+	fake.findDeltaBaseForResourceCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForResourceCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	defer fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	fake.FindDeltaBaseForResourceCacheStub = nil
+	if fake.findDeltaBaseForResourceCacheReturnsOnCall == nil {
+		fake.findDeltaBaseForResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findDeltaBaseForResourceCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForTaskCache(arg1 lager.Logger, arg2 int, arg3 string) (worker.Volume, bool, error) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	ret, specificReturn := fake.findDeltaBaseForTaskCacheReturnsOnCall[len(fake.findDeltaBaseForTaskCacheArgsForCall)]
+	fake.findDeltaBaseForTaskCacheArgsForCall = append(fake.findDeltaBaseForTaskCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.FindDeltaBaseForTaskCacheStub
+	fakeReturns := fake.findDeltaBaseForTaskCacheReturns
+	fake.recordInvocation("FindDeltaBaseForTaskCache", []interface{}{arg1, arg2, arg3})
+	fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForTaskCacheCallCount() int {
+	fake.findDeltaBaseForTaskCacheMutex.RLock()
+	defer fake.findDeltaBaseForTaskCacheMutex.RUnlock()
+	return len(fake.findDeltaBaseForTaskCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForTaskCacheCalls(stub func(lager.Logger, int, string) (worker.Volume, bool, error)) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	defer fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	fake.FindDeltaBaseForTaskCacheStub = stub
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForTaskCacheArgsForCall(i int) (lager.Logger, int, string) {
+	fake.findDeltaBaseForTaskCacheMutex.RLock()
+	defer fake.findDeltaBaseForTaskCacheMutex.RUnlock()
+	argsForCall := fake.findDeltaBaseForTaskCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForTaskCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	defer fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	fake.FindDeltaBaseForTaskCacheStub = nil
+	fake.findDeltaBaseForTaskCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) FindDeltaBaseForTaskCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	defer fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	fake.FindDeltaBaseForTaskCacheStub = nil
+	if fake.findDeltaBaseForTaskCacheReturnsOnCall == nil {
+		fake.findDeltaBaseForTaskCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findDeltaBaseForTaskCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeClient) FindOrCreateCOWVolumeForContainer(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 db.CreatingContainer, arg4 worker.Volume, arg5 int, arg6 string) (worker.Volume, error) {
 	fake.findOrCreateCOWVolumeForContainerMutex.Lock()
 	ret, specificReturn := fake.findOrCreateCOWVolumeForContainerReturnsOnCall[len(fake.findOrCreateCOWVolumeForContainerArgsForCall)]
@@ -507,6 +711,73 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForContainerReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeVolumeClient) FindOrCreateVolumeForLayerStore(arg1 lager.Logger, arg2 string, arg3 bool, arg4 func(baggageclaim.Volume) error) (baggageclaim.Volume, error) {
+	fake.findOrCreateVolumeForLayerStoreMutex.Lock()
+	ret, specificReturn := fake.findOrCreateVolumeForLayerStoreReturnsOnCall[len(fake.findOrCreateVolumeForLayerStoreArgsForCall)]
+	fake.findOrCreateVolumeForLayerStoreArgsForCall = append(fake.findOrCreateVolumeForLayerStoreArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 bool
+		arg4 func(baggageclaim.Volume) error
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.FindOrCreateVolumeForLayerStoreStub
+	fakeReturns := fake.findOrCreateVolumeForLayerStoreReturns
+	fake.recordInvocation("FindOrCreateVolumeForLayerStore", []interface{}{arg1, arg2, arg3, arg4})
+	fake.findOrCreateVolumeForLayerStoreMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForLayerStoreCallCount() int {
+	fake.findOrCreateVolumeForLayerStoreMutex.RLock()
+	defer fake.findOrCreateVolumeForLayerStoreMutex.RUnlock()
+	return len(fake.findOrCreateVolumeForLayerStoreArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForLayerStoreCalls(stub func(lager.Logger, string, bool, func(baggageclaim.Volume) error) (baggageclaim.Volume, error)) {
+	fake.findOrCreateVolumeForLayerStoreMutex.Lock()
+	defer fake.findOrCreateVolumeForLayerStoreMutex.Unlock()
+	fake.FindOrCreateVolumeForLayerStoreStub = stub
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForLayerStoreArgsForCall(i int) (lager.Logger, string, bool, func(baggageclaim.Volume) error) {
+	fake.findOrCreateVolumeForLayerStoreMutex.RLock()
+	defer fake.findOrCreateVolumeForLayerStoreMutex.RUnlock()
+	argsForCall := fake.findOrCreateVolumeForLayerStoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForLayerStoreReturns(result1 baggageclaim.Volume, result2 error) {
+	fake.findOrCreateVolumeForLayerStoreMutex.Lock()
+	defer fake.findOrCreateVolumeForLayerStoreMutex.Unlock()
+	fake.FindOrCreateVolumeForLayerStoreStub = nil
+	fake.findOrCreateVolumeForLayerStoreReturns = struct {
+		result1 baggageclaim.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForLayerStoreReturnsOnCall(i int, result1 baggageclaim.Volume, result2 error) {
+	fake.findOrCreateVolumeForLayerStoreMutex.Lock()
+	defer fake.findOrCreateVolumeForLayerStoreMutex.Unlock()
+	fake.FindOrCreateVolumeForLayerStoreStub = nil
+	if fake.findOrCreateVolumeForLayerStoreReturnsOnCall == nil {
+		fake.findOrCreateVolumeForLayerStoreReturnsOnCall = make(map[int]struct {
+			result1 baggageclaim.Volume
+			result2 error
+		})
+	}
+	fake.findOrCreateVolumeForLayerStoreReturnsOnCall[i] = struct {
+		result1 baggageclaim.Volume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCerts(arg1 lager.Logger) (worker.Volume, bool, error) {
 	fake.findOrCreateVolumeForResourceCertsMutex.Lock()
 	ret, specificReturn := fake.findOrCreateVolumeForResourceCertsReturnsOnCall[len(fake.findOrCreateVolumeForResourceCertsArgsForCall)]
@@ -574,6 +845,75 @@ func (fake *FakeVolumeClient) FindOrCreateVolumeForResourceCertsReturnsOnCall(i
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) FindVolumeForLayerStore(arg1 lager.Logger, arg2 string, arg3 bool) (baggageclaim.Volume, bool, error) {
+	fake.findVolumeForLayerStoreMutex.Lock()
+	ret, specificReturn := fake.findVolumeForLayerStoreReturnsOnCall[len(fake.findVolumeForLayerStoreArgsForCall)]
+	fake.findVolumeForLayerStoreArgsForCall = append(fake.findVolumeForLayerStoreArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.FindVolumeForLayerStoreStub
+	fakeReturns := fake.findVolumeForLayerStoreReturns
+	fake.recordInvocation("FindVolumeForLayerStore", []interface{}{arg1, arg2, arg3})
+	fake.findVolumeForLayerStoreMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolumeClient) FindVolumeForLayerStoreCallCount() int {
+	fake.findVolumeForLayerStoreMutex.RLock()
+	defer fake.findVolumeForLayerStoreMutex.RUnlock()
+	return len(fake.findVolumeForLayerStoreArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindVolumeForLayerStoreCalls(stub func(lager.Logger, string, bool) (baggageclaim.Volume, bool, error)) {
+	fake.findVolumeForLayerStoreMutex.Lock()
+	defer fake.findVolumeForLayerStoreMutex.Unlock()
+	fake.FindVolumeForLayerStoreStub = stub
+}
+
+func (fake *FakeVolumeClient) FindVolumeForLayerStoreArgsForCall(i int) (lager.Logger, string, bool) {
+	fake.findVolumeForLayerStoreMutex.RLock()
+	defer fake.findVolumeForLayerStoreMutex.RUnlock()
+	argsForCall := fake.findVolumeForLayerStoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolumeClient) FindVolumeForLayerStoreReturns(result1 baggageclaim.Volume, result2 bool, result3 error) {
+	fake.findVolumeForLayerStoreMutex.Lock()
+	defer fake.findVolumeForLayerStoreMutex.Unlock()
+	fake.FindVolumeForLayerStoreStub = nil
+	fake.findVolumeForLayerStoreReturns = struct {
+		result1 baggageclaim.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeClient) FindVolumeForLayerStoreReturnsOnCall(i int, result1 baggageclaim.Volume, result2 bool, result3 error) {
+	fake.findVolumeForLayerStoreMutex.Lock()
+	defer fake.findVolumeForLayerStoreMutex.Unlock()
+	fake.FindVolumeForLayerStoreStub = nil
+	if fake.findVolumeForLayerStoreReturnsOnCall == nil {
+		fake.findVolumeForLayerStoreReturnsOnCall = make(map[int]struct {
+			result1 baggageclaim.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findVolumeForLayerStoreReturnsOnCall[i] = struct {
+		result1 baggageclaim.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeVolumeClient) FindVolumeForResourceCache(arg1 lager.Logger, arg2 db.UsedResourceCache) (worker.Volume, bool, error) {
 	fake.findVolumeForResourceCacheMutex.Lock()
 	ret, specificReturn := fake.findVolumeForResourceCacheReturnsOnCall[len(fake.findVolumeForResourceCacheArgsForCall)]
@@ -788,14 +1128,22 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.createVolumeMutex.RUnlock()
 	fake.createVolumeForTaskCacheMutex.RLock()
 	defer fake.createVolumeForTaskCacheMutex.RUnlock()
+	fake.findDeltaBaseForResourceCacheMutex.RLock()
+	defer fake.findDeltaBaseForResourceCacheMutex.RUnlock()
+	fake.findDeltaBaseForTaskCacheMutex.RLock()
+	defer fake.findDeltaBaseForTaskCacheMutex.RUnlock()
 	fake.findOrCreateCOWVolumeForContainerMutex.RLock()
 	defer fake.findOrCreateCOWVolumeForContainerMutex.RUnlock()
 	fake.findOrCreateVolumeForBaseResourceTypeMutex.RLock()
 	defer fake.findOrCreateVolumeForBaseResourceTypeMutex.RUnlock()
 	fake.findOrCreateVolumeForContainerMutex.RLock()
 	defer fake.findOrCreateVolumeForContainerMutex.RUnlock()
+	fake.findOrCreateVolumeForLayerStoreMutex.RLock()
+	defer fake.findOrCreateVolumeForLayerStoreMutex.RUnlock()
 	fake.findOrCreateVolumeForResourceCertsMutex.RLock()
 	defer fake.findOrCreateVolumeForResourceCertsMutex.RUnlock()
+	fake.findVolumeForLayerStoreMutex.RLock()
+	defer fake.findVolumeForLayerStoreMutex.RUnlock()
 	fake.findVolumeForResourceCacheMutex.RLock()
 	defer fake.findVolumeForResourceCacheMutex.RUnlock()
 	fake.findVolumeForTaskCacheMutex.RLock()
@@ -122,6 +122,27 @@ type FakeWorker struct {
 	ephemeralReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	EvictedStub        func(lager.Logger) bool
+	evictedMutex       sync.RWMutex
+	evictedArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	evictedReturns struct {
+		result1 bool
+	}
+	evictedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	FeaturesStub        func() []string
+	featuresMutex       sync.RWMutex
+	featuresArgsForCall []struct {
+	}
+	featuresReturns struct {
+		result1 []string
+	}
+	featuresReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	FetchStub        func(context.Context, lager.Logger, db.ContainerMetadata, worker.Worker, worker.ContainerSpec, runtime.ProcessSpec, resource.Resource, db.ContainerOwner, db.UsedResourceCache, string) (worker.GetResult, worker.Volume, error)
 	fetchMutex       sync.RWMutex
 	fetchArgsForCall []struct {
@@ -163,6 +184,39 @@ type FakeWorker struct {
 		result2 bool
 		result3 error
 	}
+	FindDeltaBaseForResourceCacheStub        func(lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)
+	findDeltaBaseForResourceCacheMutex       sync.RWMutex
+	findDeltaBaseForResourceCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.UsedResourceCache
+	}
+	findDeltaBaseForResourceCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findDeltaBaseForResourceCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	FindDeltaBaseForTaskCacheStub        func(lager.Logger, int, string) (worker.Volume, bool, error)
+	findDeltaBaseForTaskCacheMutex       sync.RWMutex
+	findDeltaBaseForTaskCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 string
+	}
+	findDeltaBaseForTaskCacheReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	findDeltaBaseForTaskCacheReturnsOnCall map[int]struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
 	FindOrCreateContainerStub        func(context.Context, lager.Logger, db.ContainerOwner, db.ContainerMetadata, worker.ContainerSpec) (worker.Container, error)
 	findOrCreateContainerMutex       sync.RWMutex
 	findOrCreateContainerArgsForCall []struct {
@@ -240,6 +294,16 @@ type FakeWorker struct {
 	gardenClientReturnsOnCall map[int]struct {
 		result1 gclient.Client
 	}
+	HealthScoreStub        func() float64
+	healthScoreMutex       sync.RWMutex
+	healthScoreArgsForCall []struct {
+	}
+	healthScoreReturns struct {
+		result1 float64
+	}
+	healthScoreReturnsOnCall map[int]struct {
+		result1 float64
+	}
 	IncreaseActiveTasksStub        func() (int, error)
 	increaseActiveTasksMutex       sync.RWMutex
 	increaseActiveTasksArgsForCall []struct {
@@ -300,6 +364,23 @@ type FakeWorker struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	QuarantinedStub        func() bool
+	quarantinedMutex       sync.RWMutex
+	quarantinedArgsForCall []struct {
+	}
+	quarantinedReturns struct {
+		result1 bool
+	}
+	quarantinedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	RecordHealthStub        func(lager.Logger, db.WorkerHealthEvent, bool)
+	recordHealthMutex       sync.RWMutex
+	recordHealthArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.WorkerHealthEvent
+		arg3 bool
+	}
 	ResourceTypesStub        func() []atc.WorkerResourceType
 	resourceTypesMutex       sync.RWMutex
 	resourceTypesArgsForCall []struct {
@@ -310,6 +391,16 @@ type FakeWorker struct {
 	resourceTypesReturnsOnCall map[int]struct {
 		result1 []atc.WorkerResourceType
 	}
+	RootlessStub        func() bool
+	rootlessMutex       sync.RWMutex
+	rootlessArgsForCall []struct {
+	}
+	rootlessReturns struct {
+		result1 bool
+	}
+	rootlessReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	SatisfiesStub        func(lager.Logger, worker.WorkerSpec) bool
 	satisfiesMutex       sync.RWMutex
 	satisfiesArgsForCall []struct {
@@ -322,6 +413,16 @@ type FakeWorker struct {
 	satisfiesReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	SupportedContainerLimitsStub        func() []string
+	supportedContainerLimitsMutex       sync.RWMutex
+	supportedContainerLimitsArgsForCall []struct {
+	}
+	supportedContainerLimitsReturns struct {
+		result1 []string
+	}
+	supportedContainerLimitsReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	TagsStub        func() atc.Tags
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -857,6 +958,120 @@ func (fake *FakeWorker) EphemeralReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Evicted(arg1 lager.Logger) bool {
+	fake.evictedMutex.Lock()
+	ret, specificReturn := fake.evictedReturnsOnCall[len(fake.evictedArgsForCall)]
+	fake.evictedArgsForCall = append(fake.evictedArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	stub := fake.EvictedStub
+	fakeReturns := fake.evictedReturns
+	fake.recordInvocation("Evicted", []interface{}{arg1})
+	fake.evictedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) EvictedCallCount() int {
+	fake.evictedMutex.RLock()
+	defer fake.evictedMutex.RUnlock()
+	return len(fake.evictedArgsForCall)
+}
+
+func (fake *FakeWorker) EvictedCalls(stub func(lager.Logger) bool) {
+	fake.evictedMutex.Lock()
+	defer fake.evictedMutex.Unlock()
+	fake.EvictedStub = stub
+}
+
+func (fake *FakeWorker) EvictedArgsForCall(i int) lager.Logger {
+	fake.evictedMutex.RLock()
+	defer fake.evictedMutex.RUnlock()
+	argsForCall := fake.evictedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) EvictedReturns(result1 bool) {
+	fake.evictedMutex.Lock()
+	defer fake.evictedMutex.Unlock()
+	fake.EvictedStub = nil
+	fake.evictedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) EvictedReturnsOnCall(i int, result1 bool) {
+	fake.evictedMutex.Lock()
+	defer fake.evictedMutex.Unlock()
+	fake.EvictedStub = nil
+	if fake.evictedReturnsOnCall == nil {
+		fake.evictedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.evictedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) Features() []string {
+	fake.featuresMutex.Lock()
+	ret, specificReturn := fake.featuresReturnsOnCall[len(fake.featuresArgsForCall)]
+	fake.featuresArgsForCall = append(fake.featuresArgsForCall, struct {
+	}{})
+	stub := fake.FeaturesStub
+	fakeReturns := fake.featuresReturns
+	fake.recordInvocation("Features", []interface{}{})
+	fake.featuresMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) FeaturesCallCount() int {
+	fake.featuresMutex.RLock()
+	defer fake.featuresMutex.RUnlock()
+	return len(fake.featuresArgsForCall)
+}
+
+func (fake *FakeWorker) FeaturesCalls(stub func() []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = stub
+}
+
+func (fake *FakeWorker) FeaturesReturns(result1 []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = nil
+	fake.featuresReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) FeaturesReturnsOnCall(i int, result1 []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = nil
+	if fake.featuresReturnsOnCall == nil {
+		fake.featuresReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.featuresReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) Fetch(arg1 context.Context, arg2 lager.Logger, arg3 db.ContainerMetadata, arg4 worker.Worker, arg5 worker.ContainerSpec, arg6 runtime.ProcessSpec, arg7 resource.Resource, arg8 db.ContainerOwner, arg9 db.UsedResourceCache, arg10 string) (worker.GetResult, worker.Volume, error) {
 	fake.fetchMutex.Lock()
 	ret, specificReturn := fake.fetchReturnsOnCall[len(fake.fetchArgsForCall)]
@@ -1002,6 +1217,143 @@ func (fake *FakeWorker) FindContainerByHandleReturnsOnCall(i int, result1 worker
 	}{result1, result2, result3}
 }
 
+func (fake *FakeWorker) FindDeltaBaseForResourceCache(arg1 lager.Logger, arg2 db.UsedResourceCache) (worker.Volume, bool, error) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	ret, specificReturn := fake.findDeltaBaseForResourceCacheReturnsOnCall[len(fake.findDeltaBaseForResourceCacheArgsForCall)]
+	fake.findDeltaBaseForResourceCacheArgsForCall = append(fake.findDeltaBaseForResourceCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.UsedResourceCache
+	}{arg1, arg2})
+	stub := fake.FindDeltaBaseForResourceCacheStub
+	fakeReturns := fake.findDeltaBaseForResourceCacheReturns
+	fake.recordInvocation("FindDeltaBaseForResourceCache", []interface{}{arg1, arg2})
+	fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) FindDeltaBaseForResourceCacheCallCount() int {
+	fake.findDeltaBaseForResourceCacheMutex.RLock()
+	defer fake.findDeltaBaseForResourceCacheMutex.RUnlock()
+	return len(fake.findDeltaBaseForResourceCacheArgsForCall)
+}
+
+func (fake *FakeWorker) FindDeltaBaseForResourceCacheCalls(stub func(lager.Logger, db.UsedResourceCache) (worker.Volume, bool, error)) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	defer fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	fake.FindDeltaBaseForResourceCacheStub = stub
+}
+
+func (fake *FakeWorker) FindDeltaBaseForResourceCacheArgsForCall(i int) (lager.Logger, db.UsedResourceCache) {
+	fake.findDeltaBaseForResourceCacheMutex.RLock()
+	defer fake.findDeltaBaseForResourceCacheMutex.RUnlock()
+	argsForCall := fake.findDeltaBaseForResourceCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) FindDeltaBaseForResourceCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	defer fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	fake.FindDeltaBaseForResourceCacheStub = nil
+	fake.findDeltaBaseForResourceCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) FindDeltaBaseForResourceCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForResourceCacheMutex.Lock()
+	defer fake.findDeltaBaseForResourceCacheMutex.Unlock()
+	fake.FindDeltaBaseForResourceCacheStub = nil
+	if fake.findDeltaBaseForResourceCacheReturnsOnCall == nil {
+		fake.findDeltaBaseForResourceCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findDeltaBaseForResourceCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) FindDeltaBaseForTaskCache(arg1 lager.Logger, arg2 int, arg3 string) (worker.Volume, bool, error) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	ret, specificReturn := fake.findDeltaBaseForTaskCacheReturnsOnCall[len(fake.findDeltaBaseForTaskCacheArgsForCall)]
+	fake.findDeltaBaseForTaskCacheArgsForCall = append(fake.findDeltaBaseForTaskCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.FindDeltaBaseForTaskCacheStub
+	fakeReturns := fake.findDeltaBaseForTaskCacheReturns
+	fake.recordInvocation("FindDeltaBaseForTaskCache", []interface{}{arg1, arg2, arg3})
+	fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWorker) FindDeltaBaseForTaskCacheCallCount() int {
+	fake.findDeltaBaseForTaskCacheMutex.RLock()
+	defer fake.findDeltaBaseForTaskCacheMutex.RUnlock()
+	return len(fake.findDeltaBaseForTaskCacheArgsForCall)
+}
+
+func (fake *FakeWorker) FindDeltaBaseForTaskCacheCalls(stub func(lager.Logger, int, string) (worker.Volume, bool, error)) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	defer fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	fake.FindDeltaBaseForTaskCacheStub = stub
+}
+
+func (fake *FakeWorker) FindDeltaBaseForTaskCacheArgsForCall(i int) (lager.Logger, int, string) {
+	fake.findDeltaBaseForTaskCacheMutex.RLock()
+	defer fake.findDeltaBaseForTaskCacheMutex.RUnlock()
+	argsForCall := fake.findDeltaBaseForTaskCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorker) FindDeltaBaseForTaskCacheReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	defer fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	fake.FindDeltaBaseForTaskCacheStub = nil
+	fake.findDeltaBaseForTaskCacheReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWorker) FindDeltaBaseForTaskCacheReturnsOnCall(i int, result1 worker.Volume, result2 bool, result3 error) {
+	fake.findDeltaBaseForTaskCacheMutex.Lock()
+	defer fake.findDeltaBaseForTaskCacheMutex.Unlock()
+	fake.FindDeltaBaseForTaskCacheStub = nil
+	if fake.findDeltaBaseForTaskCacheReturnsOnCall == nil {
+		fake.findDeltaBaseForTaskCacheReturnsOnCall = make(map[int]struct {
+			result1 worker.Volume
+			result2 bool
+			result3 error
+		})
+	}
+	fake.findDeltaBaseForTaskCacheReturnsOnCall[i] = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) FindOrCreateContainer(arg1 context.Context, arg2 lager.Logger, arg3 db.ContainerOwner, arg4 db.ContainerMetadata, arg5 worker.ContainerSpec) (worker.Container, error) {
 	fake.findOrCreateContainerMutex.Lock()
 	ret, specificReturn := fake.findOrCreateContainerReturnsOnCall[len(fake.findOrCreateContainerArgsForCall)]
@@ -1329,6 +1681,59 @@ func (fake *FakeWorker) GardenClientReturnsOnCall(i int, result1 gclient.Client)
 	}{result1}
 }
 
+func (fake *FakeWorker) HealthScore() float64 {
+	fake.healthScoreMutex.Lock()
+	ret, specificReturn := fake.healthScoreReturnsOnCall[len(fake.healthScoreArgsForCall)]
+	fake.healthScoreArgsForCall = append(fake.healthScoreArgsForCall, struct {
+	}{})
+	stub := fake.HealthScoreStub
+	fakeReturns := fake.healthScoreReturns
+	fake.recordInvocation("HealthScore", []interface{}{})
+	fake.healthScoreMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) HealthScoreCallCount() int {
+	fake.healthScoreMutex.RLock()
+	defer fake.healthScoreMutex.RUnlock()
+	return len(fake.healthScoreArgsForCall)
+}
+
+func (fake *FakeWorker) HealthScoreCalls(stub func() float64) {
+	fake.healthScoreMutex.Lock()
+	defer fake.healthScoreMutex.Unlock()
+	fake.HealthScoreStub = stub
+}
+
+func (fake *FakeWorker) HealthScoreReturns(result1 float64) {
+	fake.healthScoreMutex.Lock()
+	defer fake.healthScoreMutex.Unlock()
+	fake.HealthScoreStub = nil
+	fake.healthScoreReturns = struct {
+		result1 float64
+	}{result1}
+}
+
+func (fake *FakeWorker) HealthScoreReturnsOnCall(i int, result1 float64) {
+	fake.healthScoreMutex.Lock()
+	defer fake.healthScoreMutex.Unlock()
+	fake.HealthScoreStub = nil
+	if fake.healthScoreReturnsOnCall == nil {
+		fake.healthScoreReturnsOnCall = make(map[int]struct {
+			result1 float64
+		})
+	}
+	fake.healthScoreReturnsOnCall[i] = struct {
+		result1 float64
+	}{result1}
+}
+
 func (fake *FakeWorker) IncreaseActiveTasks() (int, error) {
 	fake.increaseActiveTasksMutex.Lock()
 	ret, specificReturn := fake.increaseActiveTasksReturnsOnCall[len(fake.increaseActiveTasksArgsForCall)]
@@ -1621,6 +2026,93 @@ func (fake *FakeWorker) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Quarantined() bool {
+	fake.quarantinedMutex.Lock()
+	ret, specificReturn := fake.quarantinedReturnsOnCall[len(fake.quarantinedArgsForCall)]
+	fake.quarantinedArgsForCall = append(fake.quarantinedArgsForCall, struct {
+	}{})
+	stub := fake.QuarantinedStub
+	fakeReturns := fake.quarantinedReturns
+	fake.recordInvocation("Quarantined", []interface{}{})
+	fake.quarantinedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) QuarantinedCallCount() int {
+	fake.quarantinedMutex.RLock()
+	defer fake.quarantinedMutex.RUnlock()
+	return len(fake.quarantinedArgsForCall)
+}
+
+func (fake *FakeWorker) QuarantinedCalls(stub func() bool) {
+	fake.quarantinedMutex.Lock()
+	defer fake.quarantinedMutex.Unlock()
+	fake.QuarantinedStub = stub
+}
+
+func (fake *FakeWorker) QuarantinedReturns(result1 bool) {
+	fake.quarantinedMutex.Lock()
+	defer fake.quarantinedMutex.Unlock()
+	fake.QuarantinedStub = nil
+	fake.quarantinedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) QuarantinedReturnsOnCall(i int, result1 bool) {
+	fake.quarantinedMutex.Lock()
+	defer fake.quarantinedMutex.Unlock()
+	fake.QuarantinedStub = nil
+	if fake.quarantinedReturnsOnCall == nil {
+		fake.quarantinedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.quarantinedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) RecordHealth(arg1 lager.Logger, arg2 db.WorkerHealthEvent, arg3 bool) {
+	fake.recordHealthMutex.Lock()
+	fake.recordHealthArgsForCall = append(fake.recordHealthArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.WorkerHealthEvent
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.RecordHealthStub
+	fake.recordInvocation("RecordHealth", []interface{}{arg1, arg2, arg3})
+	fake.recordHealthMutex.Unlock()
+	if stub != nil {
+		fake.RecordHealthStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeWorker) RecordHealthCallCount() int {
+	fake.recordHealthMutex.RLock()
+	defer fake.recordHealthMutex.RUnlock()
+	return len(fake.recordHealthArgsForCall)
+}
+
+func (fake *FakeWorker) RecordHealthCalls(stub func(lager.Logger, db.WorkerHealthEvent, bool)) {
+	fake.recordHealthMutex.Lock()
+	defer fake.recordHealthMutex.Unlock()
+	fake.RecordHealthStub = stub
+}
+
+func (fake *FakeWorker) RecordHealthArgsForCall(i int) (lager.Logger, db.WorkerHealthEvent, bool) {
+	fake.recordHealthMutex.RLock()
+	defer fake.recordHealthMutex.RUnlock()
+	argsForCall := fake.recordHealthArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeWorker) ResourceTypes() []atc.WorkerResourceType {
 	fake.resourceTypesMutex.Lock()
 	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
@@ -1674,6 +2166,59 @@ func (fake *FakeWorker) ResourceTypesReturnsOnCall(i int, result1 []atc.WorkerRe
 	}{result1}
 }
 
+func (fake *FakeWorker) Rootless() bool {
+	fake.rootlessMutex.Lock()
+	ret, specificReturn := fake.rootlessReturnsOnCall[len(fake.rootlessArgsForCall)]
+	fake.rootlessArgsForCall = append(fake.rootlessArgsForCall, struct {
+	}{})
+	stub := fake.RootlessStub
+	fakeReturns := fake.rootlessReturns
+	fake.recordInvocation("Rootless", []interface{}{})
+	fake.rootlessMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RootlessCallCount() int {
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
+	return len(fake.rootlessArgsForCall)
+}
+
+func (fake *FakeWorker) RootlessCalls(stub func() bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = stub
+}
+
+func (fake *FakeWorker) RootlessReturns(result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	fake.rootlessReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) RootlessReturnsOnCall(i int, result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	if fake.rootlessReturnsOnCall == nil {
+		fake.rootlessReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.rootlessReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) Satisfies(arg1 lager.Logger, arg2 worker.WorkerSpec) bool {
 	fake.satisfiesMutex.Lock()
 	ret, specificReturn := fake.satisfiesReturnsOnCall[len(fake.satisfiesArgsForCall)]
@@ -1736,6 +2281,59 @@ func (fake *FakeWorker) SatisfiesReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) SupportedContainerLimits() []string {
+	fake.supportedContainerLimitsMutex.Lock()
+	ret, specificReturn := fake.supportedContainerLimitsReturnsOnCall[len(fake.supportedContainerLimitsArgsForCall)]
+	fake.supportedContainerLimitsArgsForCall = append(fake.supportedContainerLimitsArgsForCall, struct {
+	}{})
+	stub := fake.SupportedContainerLimitsStub
+	fakeReturns := fake.supportedContainerLimitsReturns
+	fake.recordInvocation("SupportedContainerLimits", []interface{}{})
+	fake.supportedContainerLimitsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsCallCount() int {
+	fake.supportedContainerLimitsMutex.RLock()
+	defer fake.supportedContainerLimitsMutex.RUnlock()
+	return len(fake.supportedContainerLimitsArgsForCall)
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsCalls(stub func() []string) {
+	fake.supportedContainerLimitsMutex.Lock()
+	defer fake.supportedContainerLimitsMutex.Unlock()
+	fake.SupportedContainerLimitsStub = stub
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsReturns(result1 []string) {
+	fake.supportedContainerLimitsMutex.Lock()
+	defer fake.supportedContainerLimitsMutex.Unlock()
+	fake.SupportedContainerLimitsStub = nil
+	fake.supportedContainerLimitsReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) SupportedContainerLimitsReturnsOnCall(i int, result1 []string) {
+	fake.supportedContainerLimitsMutex.Lock()
+	defer fake.supportedContainerLimitsMutex.Unlock()
+	fake.SupportedContainerLimitsStub = nil
+	if fake.supportedContainerLimitsReturnsOnCall == nil {
+		fake.supportedContainerLimitsReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.supportedContainerLimitsReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) Tags() atc.Tags {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -1863,10 +2461,18 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.descriptionMutex.RUnlock()
 	fake.ephemeralMutex.RLock()
 	defer fake.ephemeralMutex.RUnlock()
+	fake.evictedMutex.RLock()
+	defer fake.evictedMutex.RUnlock()
+	fake.featuresMutex.RLock()
+	defer fake.featuresMutex.RUnlock()
 	fake.fetchMutex.RLock()
 	defer fake.fetchMutex.RUnlock()
 	fake.findContainerByHandleMutex.RLock()
 	defer fake.findContainerByHandleMutex.RUnlock()
+	fake.findDeltaBaseForResourceCacheMutex.RLock()
+	defer fake.findDeltaBaseForResourceCacheMutex.RUnlock()
+	fake.findDeltaBaseForTaskCacheMutex.RLock()
+	defer fake.findDeltaBaseForTaskCacheMutex.RUnlock()
 	fake.findOrCreateContainerMutex.RLock()
 	defer fake.findOrCreateContainerMutex.RUnlock()
 	fake.findResourceCacheForVolumeMutex.RLock()
@@ -1877,6 +2483,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.findVolumeForTaskCacheMutex.RUnlock()
 	fake.gardenClientMutex.RLock()
 	defer fake.gardenClientMutex.RUnlock()
+	fake.healthScoreMutex.RLock()
+	defer fake.healthScoreMutex.RUnlock()
 	fake.increaseActiveTasksMutex.RLock()
 	defer fake.increaseActiveTasksMutex.RUnlock()
 	fake.isOwnedByTeamMutex.RLock()
@@ -1887,10 +2495,18 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.lookupVolumeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.quarantinedMutex.RLock()
+	defer fake.quarantinedMutex.RUnlock()
+	fake.recordHealthMutex.RLock()
+	defer fake.recordHealthMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
 	fake.satisfiesMutex.RLock()
 	defer fake.satisfiesMutex.RUnlock()
+	fake.supportedContainerLimitsMutex.RLock()
+	defer fake.supportedContainerLimitsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.uptimeMutex.RLock()
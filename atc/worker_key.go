@@ -0,0 +1,29 @@
+package atc
+
+// WorkerKey is a public key authorized to register workers for a team. Unlike
+// the keys configured on the TSA, worker keys are stored in the database and
+// may be enrolled and revoked without restarting the web nodes.
+type WorkerKey struct {
+	ID          int    `json:"id"`
+	TeamName    string `json:"team_name"`
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint"`
+	CreatedAt   int64  `json:"created_at"`
+	LastUsedAt  int64  `json:"last_used_at,omitempty"`
+	RevokedAt   int64  `json:"revoked_at,omitempty"`
+}
+
+// WorkerEnrollmentToken allows a worker to enroll its key for a team until
+// the token expires. Each token may only be used once.
+type WorkerEnrollmentToken struct {
+	Token     string `json:"token"`
+	TeamName  string `json:"team_name"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// WorkerKeyEnrollment is sent by the TSA on behalf of a worker connecting
+// with an enrollment token.
+type WorkerKeyEnrollment struct {
+	Token     string `json:"token"`
+	PublicKey string `json:"public_key"`
+}
@@ -51,13 +51,17 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.GetBuildStepOutput:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
 		case atc.PruneWorker,
 			atc.LandWorker,
 			atc.RetireWorker,
+			atc.EvictWorker,
+			atc.QuarantineWorker,
+			atc.ReleaseWorker,
 			atc.ListDestroyingVolumes,
 			atc.ListDestroyingContainers,
 			atc.ReportWorkerContainers,
@@ -86,6 +90,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// authenticated
 		case atc.ListWorkers,
 			atc.RegisterWorker,
+			atc.EnrollWorkerKey,
+			atc.AuthorizeWorkerKey,
+			atc.UseWorkerKey,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
 			atc.ListTeamBuilds,
@@ -108,10 +115,12 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// admin
 		case atc.GetLogLevel,
+			atc.GetWorkerDemand,
 			atc.DestroyTeam,
 			atc.ListActiveUsersSince,
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
+			atc.ListAllCredentialUsages,
 			atc.SetWall,
 			atc.ClearWall:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
@@ -134,8 +143,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.PinResourceVersion,
+			atc.PromoteResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.ListResourceChecks,
 			atc.GetConfig,
 			atc.GetCC,
 			atc.GetVersionsDB,
@@ -151,8 +162,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SaveConfig,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
+			atc.ListJobCaches,
+			atc.ClearJobCaches,
 			atc.CreateArtifact,
 			atc.ScheduleJob,
+			atc.CreateWorkerEnrollmentToken,
+			atc.ListWorkerKeys,
+			atc.RevokeWorkerKey,
+			atc.ListTeamCredentialUsages,
+			atc.ClearCredentialCache,
 			atc.GetArtifact:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
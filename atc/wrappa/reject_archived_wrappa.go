@@ -33,6 +33,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.PinResourceVersion,
+			atc.PromoteResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.RerunJobBuild:
@@ -48,12 +49,16 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListBuildArtifacts,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
+			atc.GetBuildStepOutput,
 			atc.AbortBuild,
 			atc.PruneWorker,
 			atc.LandWorker,
 			atc.ReportWorkerContainers,
 			atc.ReportWorkerVolumes,
 			atc.RetireWorker,
+			atc.EvictWorker,
+			atc.QuarantineWorker,
+			atc.ReleaseWorker,
 			atc.ListDestroyingContainers,
 			atc.ListDestroyingVolumes,
 			atc.GetPipeline,
@@ -70,6 +75,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListResources,
 			atc.ListResourceTypes,
 			atc.ListResourceVersions,
+			atc.ListResourceChecks,
 			atc.GetResourceCausality,
 			atc.GetResourceVersion,
 			atc.CreateBuild,
@@ -79,9 +85,16 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListVolumes,
 			atc.ListTeamBuilds,
 			atc.ListWorkers,
+			atc.GetWorkerDemand,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
+			atc.CreateWorkerEnrollmentToken,
+			atc.ListWorkerKeys,
+			atc.RevokeWorkerKey,
+			atc.EnrollWorkerKey,
+			atc.AuthorizeWorkerKey,
+			atc.UseWorkerKey,
 			atc.GetTeam,
 			atc.SetTeam,
 			atc.RenameTeam,
@@ -101,6 +114,9 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetLogLevel,
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
+			atc.ListTeamCredentialUsages,
+			atc.ListAllCredentialUsages,
+			atc.ClearCredentialCache,
 			atc.ListActiveUsersSince,
 			atc.SetWall,
 			atc.ClearWall,
@@ -118,6 +134,8 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.HidePipeline,
 			atc.CreatePipelineBuild,
 			atc.ClearTaskCache,
+			atc.ListJobCaches,
+			atc.ClearJobCaches,
 			atc.CreateArtifact,
 			atc.GetArtifact:
 
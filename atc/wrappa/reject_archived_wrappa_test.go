@@ -40,6 +40,7 @@ var _ = Describe("RejectArchivedWrappa", func() {
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.PinResourceVersion,
+			atc.PromoteResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.RerunJobBuild,
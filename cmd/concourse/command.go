@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/concourse/concourse/atc/atccmd"
+	"github.com/concourse/concourse/worker/evict"
 	"github.com/concourse/concourse/worker/land"
 	"github.com/concourse/concourse/worker/retire"
 	"github.com/concourse/concourse/worker/workercmd"
@@ -19,6 +20,7 @@ type ConcourseCommand struct {
 
 	LandWorker   land.LandWorkerCommand     `command:"land-worker" description:"Safely drain a worker's assignments for temporary downtime."`
 	RetireWorker retire.RetireWorkerCommand `command:"retire-worker" description:"Safely remove a worker from the cluster permanently."`
+	EvictWorker  evict.EvictWorkerCommand   `command:"evict-worker" description:"Immediately stop placing work on a worker which is about to be terminated, rerunning its steps elsewhere."`
 
 	GenerateKey GenerateKeyCommand `command:"generate-key" description:"Generate RSA key for use with Concourse components."`
 }
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type CachesCommand struct {
+	Job  flaghelpers.JobFlag `short:"j" long:"job" required:"true" description:"Name of a job to list the keyed caches of"`
+	Json bool                `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *CachesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	caches, found, err := target.Team().JobCaches(command.Job.PipelineRef, command.Job.JobName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' or job '%s' not found\n", command.Job.PipelineRef.String(), command.Job.JobName)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(caches)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "path", Color: color.New(color.Bold)},
+			{Contents: "key", Color: color.New(color.Bold)},
+			{Contents: "size", Color: color.New(color.Bold)},
+			{Contents: "worker", Color: color.New(color.Bold)},
+			{Contents: "saved", Color: color.New(color.Bold)},
+			{Contents: "last used", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, cache := range caches {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: cache.Path},
+			{Contents: cache.Key},
+			{Contents: formatSize(cache.Size)},
+			{Contents: cache.WorkerName},
+			{Contents: time.Unix(cache.CreatedAt, 0).Format(timeDateLayout)},
+			{Contents: time.Unix(cache.LastUsed, 0).Format(timeDateLayout)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type CheckHistoryCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of a resource to list the checks of"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *CheckHistoryCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	checks, found, err := target.Team().ResourceChecks(command.Resource.PipelineRef, command.Resource.ResourceName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' or resource '%s' not found\n", command.Resource.PipelineRef.String(), command.Resource.ResourceName)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(checks)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "start", Color: color.New(color.Bold)},
+			{Contents: "end", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+			{Contents: "versions found", Color: color.New(color.Bold)},
+			{Contents: "latest version", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, check := range checks {
+		startTimeCell, endTimeCell, durationCell := populateTimeCells(time.Unix(check.StartTime, 0), time.Unix(check.EndTime, 0))
+
+		fields := []string{}
+		for k, v := range check.LatestVersion {
+			fields = append(fields, k+":"+v)
+		}
+
+		sort.Strings(fields)
+
+		latestVersionCell := ui.TableCell{Contents: strings.Join(fields, ",")}
+		if len(fields) == 0 {
+			latestVersionCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: strconv.Itoa(check.ID)},
+			ui.BuildStatusCell(check.Status),
+			startTimeCell,
+			endTimeCell,
+			durationCell,
+			{Contents: strconv.Itoa(check.VersionsFound)},
+			latestVersionCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
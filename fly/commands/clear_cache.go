@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/vito/go-interact/interact"
+)
+
+type ClearCacheCommand struct {
+	Job             flaghelpers.JobFlag `short:"j" long:"job" required:"true" description:"Job to clear keyed caches from"`
+	Key             string              `short:"k" long:"key" default:"" description:"Only clear the cache saved under this key"`
+	SkipInteractive bool                `short:"n" long:"non-interactive" description:"Clear the cache(s) without confirmation"`
+}
+
+func (command *ClearCacheCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	warningMsg := fmt.Sprintf("!!! this will remove the keyed cache(s) for `%s/%s`",
+		command.Job.PipelineRef.String(), command.Job.JobName)
+	if len(command.Key) > 0 {
+		warningMsg += fmt.Sprintf(", saved under `%s`", command.Key)
+	}
+	warningMsg += "\n"
+	fmt.Println(warningMsg)
+
+	confirm := command.SkipInteractive
+	if !confirm {
+		err := interact.NewInteraction("are you sure?").Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	numRemoved, err := target.Team().ClearJobCaches(command.Job.PipelineRef, command.Job.JobName, command.Key)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err
+	}
+
+	fmt.Printf("%d caches removed\n", numRemoved)
+	return nil
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ClearCredentialCacheCommand struct {
+	Path string `short:"p" long:"path" description:"Only clear the cached secrets of the var with the given path"`
+}
+
+func (command *ClearCredentialCacheCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = target.Team().ClearCredentialCache(command.Path)
+	if err != nil {
+		return err
+	}
+
+	if command.Path != "" {
+		fmt.Printf("cleared cached secrets of '%s' for team '%s'\n", command.Path, target.Team().Name())
+	} else {
+		fmt.Printf("cleared cached secrets for team '%s'\n", target.Team().Name())
+	}
+
+	return nil
+}
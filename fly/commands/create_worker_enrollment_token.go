@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type CreateWorkerEnrollmentTokenCommand struct {
+	TTL  time.Duration `long:"ttl" default:"1h" description:"How long the token may be used for, up to 24h"`
+	Json bool          `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *CreateWorkerEnrollmentTokenCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	token, err := target.Team().CreateWorkerEnrollmentToken(command.TTL)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(token)
+	}
+
+	fmt.Println(token.Token)
+	fmt.Println()
+	fmt.Printf("start a worker with --tsa-enrollment-token to enroll its key for team '%s'.\n", token.TeamName)
+	fmt.Printf("the token may be used once, until %s.\n", time.Unix(token.ExpiresAt, 0).Format(timeDateLayout))
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type CredentialUsagesCommand struct {
+	Path    string `short:"p" long:"path" description:"Only show the usages of the var with the given path"`
+	All     bool   `short:"a" long:"all" description:"Show usages across all teams"`
+	Resolve bool   `short:"r" long:"resolve" description:"Look up each var in the credential managers, showing the ones which can't be resolved"`
+	Json    bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *CredentialUsagesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var usages []atc.CredentialUsage
+	if command.All {
+		usages, err = target.Client().ListAllCredentialUsages(command.Path, command.Resolve)
+	} else {
+		usages, err = target.Team().CredentialUsages(command.Path, command.Resolve)
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(usages)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	headers := []string{"pipeline", "type", "name", "var"}
+	if command.All {
+		headers = append([]string{"team"}, headers...)
+	}
+
+	if command.Resolve {
+		headers = append(headers, "unresolved", "resolved by")
+	}
+
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range headers {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, usage := range usages {
+		pipelineRef := atc.PipelineRef{
+			Name:         usage.PipelineName,
+			InstanceVars: usage.PipelineInstanceVars,
+		}
+
+		varName := usage.Path
+		if usage.VarSource != "" {
+			varName = usage.VarSource + ":" + usage.Path
+		}
+
+		row := ui.TableRow{}
+		if command.All {
+			row = append(row, ui.TableCell{Contents: usage.TeamName})
+		}
+
+		row = append(row,
+			ui.TableCell{Contents: pipelineRef.String()},
+			ui.TableCell{Contents: usage.Type},
+			ui.TableCell{Contents: usage.Name},
+			ui.TableCell{Contents: varName},
+		)
+
+		if command.Resolve {
+			unresolvedCell := ui.TableCell{Contents: "no"}
+			if usage.Unresolved {
+				unresolvedCell = ui.TableCell{Contents: "yes", Color: ui.FailedColor}
+			}
+
+			resolvedByCell := ui.TableCell{Contents: usage.ResolvedBy}
+			if usage.ResolvedBy == "" {
+				resolvedByCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+			}
+
+			row = append(row, unresolvedCell, resolvedByCell)
+		}
+
+		table.Data = append(table.Data, row)
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/go-archive/tgzfs"
+)
+
+type DownloadOutputCommand struct {
+	Job    flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job the build belongs to"`
+	Build  string              `short:"b" long:"build" required:"true" description:"If job is specified: build number. If job not specified: build id"`
+	Step   string              `short:"s" long:"step" required:"true" description:"Name of the task step that produced the output"`
+	Output string              `short:"o" long:"output" required:"true" description:"Name of the output to download"`
+	Dir    string              `short:"d" long:"dir" description:"Directory to download the output into (default: ./<output>)"`
+}
+
+func (command *DownloadOutputCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	buildPlan, found, err := target.Client().BuildPlan(build.ID)
+	if err != nil {
+		return err
+	}
+
+	if !found || buildPlan.Plan == nil {
+		return fmt.Errorf("build has no plan")
+	}
+
+	var plan interface{}
+	err = json.Unmarshal(*buildPlan.Plan, &plan)
+	if err != nil {
+		return err
+	}
+
+	planIDs := taskPlanIDs(plan, command.Step)
+	if len(planIDs) == 0 {
+		return fmt.Errorf("build has no task step named '%s'", command.Step)
+	}
+
+	dir := command.Dir
+	if dir == "" {
+		dir = command.Output
+	}
+
+	// the same step can appear more than once, e.g. within attempts, so go
+	// with the most recent one that still has the output around
+	for i := len(planIDs) - 1; i >= 0; i-- {
+		output, found, err := target.Client().BuildStepOutput(build.ID, planIDs[i], command.Output, "")
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			continue
+		}
+
+		defer output.Close()
+
+		err = os.MkdirAll(dir, 0755)
+		if err != nil {
+			return err
+		}
+
+		err = tgzfs.Extract(output, dir)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("downloaded '%s' to %s\n", command.Output, dir)
+		return nil
+	}
+
+	return fmt.Errorf("output '%s' of step '%s' no longer exists", command.Output, command.Step)
+}
+
+// taskPlanIDs walks a public build plan and returns the IDs of the task steps
+// with the given name, in the order they appear.
+func taskPlanIDs(plan interface{}, stepName string) []atc.PlanID {
+	var ids []atc.PlanID
+
+	switch node := plan.(type) {
+	case map[string]interface{}:
+		if task, ok := node["task"].(map[string]interface{}); ok && task["name"] == stepName {
+			if id, ok := node["id"].(string); ok {
+				ids = append(ids, atc.PlanID(id))
+			}
+		}
+
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			ids = append(ids, taskPlanIDs(node[key], stepName)...)
+		}
+	case []interface{}:
+		for _, child := range node {
+			ids = append(ids, taskPlanIDs(child, stepName)...)
+		}
+	}
+
+	return ids
+}
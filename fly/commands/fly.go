@@ -62,18 +62,23 @@ type FlyCommand struct {
 	Resources              ResourcesCommand              `command:"resources"                  alias:"rs"   description:"List the resources in the pipeline"`
 	ResourceVersions       ResourceVersionsCommand       `command:"resource-versions"          alias:"rvs"  description:"List the versions of a resource"`
 	CheckResource          CheckResourceCommand          `command:"check-resource"             alias:"cr"   description:"Check a resource"`
+	CheckHistory           CheckHistoryCommand           `command:"check-history"              alias:"ch"   description:"List the recent checks of a resource"`
 	PinResource            PinResourceCommand            `command:"pin-resource"               alias:"pr"   description:"Pin a version to a resource"`
 	UnpinResource          UnpinResourceCommand          `command:"unpin-resource"             alias:"ur"   description:"Unpin a resource"`
 	EnableResourceVersion  EnableResourceVersionCommand  `command:"enable-resource-version"    alias:"erv"  description:"Enable a version of a resource"`
 	DisableResourceVersion DisableResourceVersionCommand `command:"disable-resource-version"   alias:"drv"  description:"Disable a version of a resource"`
+	PromoteVersion         PromoteVersionCommand         `command:"promote-version"            alias:"pv"   description:"Mark a version of a resource as having passed a job"`
 
 	CheckResourceType CheckResourceTypeCommand `command:"check-resource-type" alias:"crt"  description:"Check a resource-type"`
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
+	Caches         CachesCommand         `command:"caches"           alias:"kc"  description:"List the keyed caches saved by a job"`
+	ClearCache     ClearCacheCommand     `command:"clear-cache"      alias:"cc"  description:"Clears keyed caches saved by a job"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
-	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	Builds         BuildsCommand         `command:"builds"          alias:"bs" description:"List builds data"`
+	AbortBuild     AbortBuildCommand     `command:"abort-build"     alias:"ab" description:"Abort a build"`
+	RerunBuild     RerunBuildCommand     `command:"rerun-build"     alias:"rb" description:"Rerun a build"`
+	DownloadOutput DownloadOutputCommand `command:"download-output" alias:"do" description:"Download an output of a task step from a build"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
@@ -81,7 +86,18 @@ type FlyCommand struct {
 
 	Workers     WorkersCommand     `command:"workers" alias:"ws" description:"List the registered workers"`
 	LandWorker  LandWorkerCommand  `command:"land-worker" alias:"lw" description:"Land a worker"`
-	PruneWorker PruneWorkerCommand `command:"prune-worker" alias:"pw" description:"Prune a stalled, landing, landed, or retiring worker"`
+	EvictWorker EvictWorkerCommand `command:"evict-worker" alias:"ew" description:"Evict a worker which is about to go away, rerunning its steps elsewhere"`
+	PruneWorker PruneWorkerCommand `command:"prune-worker" alias:"pw" description:"Prune a stalled, landing, landed, retiring, or evicting worker"`
+
+	QuarantineWorker QuarantineWorkerCommand `command:"quarantine-worker" alias:"qw" description:"Stop placing new containers on a worker"`
+	ReleaseWorker    ReleaseWorkerCommand    `command:"release-worker" alias:"rlw" description:"Release a quarantined worker and reset its health score"`
+
+	WorkerKeys                  WorkerKeysCommand                  `command:"worker-keys" alias:"wks" description:"List the keys enrolled to register workers for the team"`
+	CreateWorkerEnrollmentToken CreateWorkerEnrollmentTokenCommand `command:"create-worker-enrollment-token" alias:"cwet" description:"Create a short-lived token for enrolling a worker's key for the team"`
+	RevokeWorkerKey             RevokeWorkerKeyCommand             `command:"revoke-worker-key" alias:"rwk" description:"Revoke an enrolled worker key, disconnecting workers using it"`
+
+	CredentialUsages     CredentialUsagesCommand     `command:"credential-usages" alias:"cus" description:"List the pipelines, jobs and resources using each credential var"`
+	ClearCredentialCache ClearCredentialCacheCommand `command:"clear-credential-cache" alias:"ccc" description:"Clear the cached secrets of the team on every web node, e.g. after rotating a secret"`
 
 	Curl CurlCommand `command:"curl" alias:"c" description:"curl the api"`
 
@@ -2,8 +2,10 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
@@ -16,6 +18,7 @@ type PinResourceCommand struct {
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
 	Version  *atc.Version             `short:"v" long:"version" description:"Version of the resource to pin. The given key value pair(s) has to be an exact match but not all fields are needed. In the case of multiple resource versions matched, it will pin the latest one."`
 	Comment  string                   `short:"c" long:"comment" description:"Message to be saved to the pinned resource. Resource has to be pinned otherwise --version should be specified to pin the resource first."`
+	For      time.Duration            `long:"for" value-name:"DURATION" description:"Automatically unpin the version after the given duration (e.g. 4h). Requires --version."`
 }
 
 func (command *PinResourceCommand) Execute([]string) error {
@@ -50,13 +53,25 @@ func (command *PinResourceCommand) Execute([]string) error {
 		}
 	}
 
+	if command.For < 0 || (command.For > 0 && command.Version == nil) {
+		return errors.New("--for must be a positive duration and can only be used with --version")
+	}
+
 	if command.Version != nil {
 		latestResourceVersion, err := GetLatestResourceVersion(team, command.Resource, *command.Version)
 		if err != nil {
 			return err
 		}
 
-		pinned, err := team.PinResourceVersion(pipelineRef, command.Resource.ResourceName, latestResourceVersion.ID)
+		pin := atc.PinVersionRequestBody{
+			PinComment: command.Comment,
+		}
+
+		if command.For > 0 {
+			pin.ExpiresIn = command.For.String()
+		}
+
+		pinned, err := team.PinResourceVersion(pipelineRef, command.Resource.ResourceName, latestResourceVersion.ID, pin)
 
 		if err != nil {
 			return err
@@ -69,12 +84,20 @@ func (command *PinResourceCommand) Execute([]string) error {
 			}
 
 			fmt.Printf("pinned '%s/%s' with version %s\n", pipelineRef.String(), command.Resource.ResourceName, string(versionBytes))
+
+			if command.Comment != "" {
+				fmt.Printf("pin comment '%s' is saved\n", command.Comment)
+			}
+
+			if command.For > 0 {
+				fmt.Printf("pin expires in %s\n", command.For)
+			}
 		} else {
 			displayhelpers.Failf("could not pin '%s/%s', make sure the resource exists\n", pipelineRef.String(), command.Resource.ResourceName)
 		}
 	}
 
-	if command.Comment != "" {
+	if command.Version == nil && command.Comment != "" {
 		saved, err := team.SetPinComment(pipelineRef, command.Resource.ResourceName, command.Comment)
 
 		if err != nil {
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type PromoteVersionCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+	Version  *atc.Version             `short:"v" long:"version" required:"true" value-name:"KEY:VALUE" description:"Version of the resource to promote. The given key value pair(s) has to be an exact match but not all fields are needed. In the case of multiple resource versions matched, it will promote the latest one."`
+	Job      flaghelpers.JobFlag      `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of the job the version should be marked as having passed"`
+}
+
+func (command *PromoteVersionCommand) Execute([]string) error {
+	if command.Job.PipelineRef.String() != command.Resource.PipelineRef.String() {
+		return fmt.Errorf("resource '%s' and job '%s' must belong to the same pipeline", command.Resource.String(), command.Job.String())
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := target.Team()
+
+	latestResourceVer, err := GetLatestResourceVersion(team, command.Resource, *command.Version)
+	if err != nil {
+		return err
+	}
+
+	build, found, err := team.PromoteResourceVersion(command.Resource.PipelineRef, command.Resource.ResourceName, latestResourceVer.ID, command.Job.JobName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("could not promote '%s', make sure the resource version exists", command.Resource.String())
+	}
+
+	versionBytes, err := json.Marshal(latestResourceVer.Version)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("promoted '%s' version %s past '%s' (build #%s)\n", command.Resource.String(), string(versionBytes), command.Job.String(), build.Name)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type QuarantineWorkerCommand struct {
+	Worker flaghelpers.WorkerFlag `short:"w"  long:"worker" required:"true" description:"Worker to quarantine"`
+}
+
+func (command *QuarantineWorkerCommand) Execute(args []string) error {
+	workerName := command.Worker.Name()
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = target.Client().QuarantineWorker(workerName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("quarantined '%s'\n", workerName)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ReleaseWorkerCommand struct {
+	Worker flaghelpers.WorkerFlag `short:"w"  long:"worker" required:"true" description:"Worker to release"`
+}
+
+func (command *ReleaseWorkerCommand) Execute(args []string) error {
+	workerName := command.Worker.Name()
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = target.Client().ReleaseWorker(workerName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("released '%s'\n", workerName)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type RevokeWorkerKeyCommand struct {
+	Key int `short:"k" long:"key" required:"true" description:"ID of the worker key to revoke, as shown by 'fly worker-keys'"`
+}
+
+func (command *RevokeWorkerKeyCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	revoked, err := target.Team().RevokeWorkerKey(command.Key)
+	if err != nil {
+		return err
+	}
+
+	if !revoked {
+		return fmt.Errorf("worker key '%d' not found", command.Key)
+	}
+
+	fmt.Printf("revoked worker key '%d'; workers using it will be disconnected\n", command.Key)
+
+	return nil
+}
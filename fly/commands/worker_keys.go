@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type WorkerKeysCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *WorkerKeysCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	keys, err := target.Team().ListWorkerKeys()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(keys)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "fingerprint", Color: color.New(color.Bold)},
+			{Contents: "enrolled", Color: color.New(color.Bold)},
+			{Contents: "last used", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, key := range keys {
+		lastUsedCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if key.LastUsedAt != 0 {
+			lastUsedCell = ui.TableCell{Contents: time.Unix(key.LastUsedAt, 0).Format(timeDateLayout)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(key.ID)},
+			{Contents: key.Fingerprint},
+			{Contents: time.Unix(key.CreatedAt, 0).Format(timeDateLayout)},
+			lastUsedCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
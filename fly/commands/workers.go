@@ -132,6 +132,7 @@ func (command *WorkersCommand) tableFor(workers []worker) ui.Table {
 			ui.TableCell{Contents: "baggageclaim url", Color: color.New(color.Bold)},
 			ui.TableCell{Contents: "active tasks", Color: color.New(color.Bold)},
 			ui.TableCell{Contents: "resource types", Color: color.New(color.Bold)},
+			ui.TableCell{Contents: "health", Color: color.New(color.Bold)},
 		)
 	}
 
@@ -159,6 +160,7 @@ func (command *WorkersCommand) tableFor(workers []worker) ui.Table {
 			row = append(row, stringOrDefault(w.BaggageclaimURL))
 			row = append(row, stringOrDefault(strconv.Itoa(w.ActiveTasks)))
 			row = append(row, stringOrDefault(strings.Join(resourceTypes, ", ")))
+			row = append(row, w.healthCell())
 		}
 
 		table.Data = append(table.Data, row)
@@ -220,3 +222,21 @@ func (w *worker) ageCell() ui.TableCell {
 
 	return column
 }
+
+func (w *worker) healthCell() ui.TableCell {
+	var column ui.TableCell
+	if w.Health == nil {
+		column.Contents = "n/a"
+		column.Color = color.New(color.Faint)
+		return column
+	}
+
+	column.Contents = strconv.FormatFloat(w.Health.Score, 'f', 2, 64)
+
+	if w.Health.Quarantined {
+		column.Contents += " (quarantined)"
+		column.Color = color.New(color.FgRed)
+	}
+
+	return column
+}
@@ -0,0 +1,140 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("caches", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "caches", "-j", "some-pipeline/some-job")
+		})
+
+		Context("when the job exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/caches"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.KeyedCache{
+							{
+								ID:         1,
+								Path:       ".m2",
+								Key:        "maven-abc",
+								Size:       1536,
+								WorkerName: "some-worker",
+								CreatedAt:  time.Now().Unix(),
+								LastUsed:   time.Now().Unix(),
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists the job's caches", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+				Expect(sess.Out).To(gbytes.Say(`\.m2\s+maven-abc\s+1\.5 KiB\s+some-worker`))
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/caches"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline 'some-pipeline' or job 'some-job' not found"))
+			})
+		})
+	})
+
+	Describe("clear-cache", func() {
+		var (
+			stdin io.Writer
+			args  []string
+			sess  *gexec.Session
+
+			expectedQuery string
+			expectedURL   = "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/caches"
+		)
+
+		BeforeEach(func() {
+			args = []string{"-j", "some-pipeline/some-job"}
+			expectedQuery = ""
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", expectedURL, expectedQuery),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ClearTaskCacheResponse{CachesRemoved: 2}),
+				),
+			)
+
+			var err error
+
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "clear-cache"}, args...)...)
+			stdin, err = flyCmd.StdinPipe()
+			Expect(err).NotTo(HaveOccurred())
+
+			sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		yes := func() {
+			Eventually(sess).Should(gbytes.Say(`are you sure\? \[yN\]: `))
+			fmt.Fprintf(stdin, "y\n")
+		}
+
+		It("clears the job's caches if the user says yes", func() {
+			Eventually(sess).Should(gbytes.Say("!!! this will remove the keyed cache\\(s\\) for `some-pipeline/some-job`"))
+			yes()
+			Eventually(sess).Should(gbytes.Say("2 caches removed"))
+			Eventually(sess).Should(gexec.Exit(0))
+		})
+
+		It("bails out if the user says no", func() {
+			Eventually(sess).Should(gbytes.Say(`are you sure\? \[yN\]: `))
+			fmt.Fprintf(stdin, "n\n")
+			Eventually(sess).Should(gbytes.Say(`bailing out`))
+			Eventually(sess).Should(gexec.Exit(0))
+		})
+
+		Context("when a key is specified", func() {
+			BeforeEach(func() {
+				args = append(args, "--key", "maven-abc", "-n")
+				expectedQuery = "key=maven-abc"
+			})
+
+			It("only clears the cache under the key", func() {
+				Eventually(sess).Should(gbytes.Say("saved under `maven-abc`"))
+				Eventually(sess).Should(gbytes.Say("2 caches removed"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,175 @@
+package integration_test
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("check-history", func() {
+		var (
+			flyCmd *exec.Cmd
+
+			expectedURL   = "/api/v1/teams/main/pipelines/pipeline/resources/foo/checks"
+			expectedQuery = "vars.branch=%22master%22"
+
+			succeededStartTime = time.Date(2021, time.February, 10, 12, 0, 0, 0, time.UTC)
+			succeededEndTime   = succeededStartTime.Add(42 * time.Second)
+			erroredStartTime   = succeededStartTime.Add(-time.Minute)
+			erroredEndTime     = erroredStartTime.Add(30 * time.Second)
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-history", "-r", "pipeline/branch:master/foo")
+		})
+
+		Context("when checks are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+						ghttp.RespondWithJSONEncoded(200, []atc.ResourceCheck{
+							{
+								ID:            2,
+								Status:        atc.StatusSucceeded,
+								StartTime:     succeededStartTime.Unix(),
+								EndTime:       succeededEndTime.Unix(),
+								Duration:      42,
+								VersionsFound: 2,
+								LatestVersion: atc.Version{"version": "2", "another": "field"},
+								EventsURL:     "/api/v1/builds/2/events",
+							},
+							{
+								ID:        1,
+								Status:    atc.StatusErrored,
+								StartTime: erroredStartTime.Unix(),
+								EndTime:   erroredEndTime.Unix(),
+								Duration:  30,
+								EventsURL: "/api/v1/builds/1/events",
+							},
+						}),
+					),
+				)
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"id": 2,
+							"status": "succeeded",
+							"start_time": 1612958400,
+							"end_time": 1612958442,
+							"duration": 42,
+							"versions_found": 2,
+							"latest_version": {"version": "2", "another": "field"},
+							"events_url": "/api/v1/builds/2/events"
+						},
+						{
+							"id": 1,
+							"status": "errored",
+							"start_time": 1612958340,
+							"end_time": 1612958370,
+							"duration": 30,
+							"versions_found": 0,
+							"events_url": "/api/v1/builds/1/events"
+						}
+					]`))
+				})
+			})
+
+			It("lists the checks", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "start", Color: color.New(color.Bold)},
+						{Contents: "end", Color: color.New(color.Bold)},
+						{Contents: "duration", Color: color.New(color.Bold)},
+						{Contents: "versions found", Color: color.New(color.Bold)},
+						{Contents: "latest version", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "2"},
+							{Contents: "succeeded"},
+							{Contents: succeededStartTime.Local().Format(timeDateLayout)},
+							{Contents: succeededEndTime.Local().Format(timeDateLayout)},
+							{Contents: "42s"},
+							{Contents: "2"},
+							{Contents: "another:field,version:2"},
+						},
+						{
+							{Contents: "1"},
+							{Contents: "errored"},
+							{Contents: erroredStartTime.Local().Format(timeDateLayout)},
+							{Contents: erroredEndTime.Local().Format(timeDateLayout)},
+							{Contents: "30s"},
+							{Contents: "0"},
+							{Contents: "n/a"},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the resource is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+						ghttp.RespondWith(404, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("pipeline 'pipeline/branch:master' or resource 'foo' not found"))
+			})
+		})
+
+		Context("and the api returns an internal server error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
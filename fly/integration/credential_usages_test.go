@@ -0,0 +1,89 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("credential-usages", func() {
+		var (
+			flyCmd *exec.Cmd
+			usages []atc.CredentialUsage
+		)
+
+		BeforeEach(func() {
+			usages = []atc.CredentialUsage{
+				{
+					TeamName:     "main",
+					PipelineID:   1,
+					PipelineName: "some-pipeline",
+					Type:         atc.CredentialUsageJob,
+					Name:         "some-job",
+					Path:         "some-var",
+					ResolvedBy:   "some-manager",
+				},
+				{
+					TeamName:     "main",
+					PipelineID:   1,
+					PipelineName: "some-pipeline",
+					Type:         atc.CredentialUsageResource,
+					Name:         "some-resource",
+					VarSource:    "some-source",
+					Path:         "some-var",
+					Unresolved:   true,
+				},
+			}
+		})
+
+		Context("for the team", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "credential-usages", "--path", "some-var", "--resolve")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/credentials/usages", "path=some-var&resolve="),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, usages),
+					),
+				)
+			})
+
+			It("lists the usages, flagging the unresolved ones", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+				Expect(sess.Out).To(gbytes.Say(`some-pipeline\s+job\s+some-job\s+some-var\s+no\s+some-manager`))
+				Expect(sess.Out).To(gbytes.Say(`some-pipeline\s+resource\s+some-resource\s+some-source:some-var\s+yes\s+n/a`))
+			})
+		})
+
+		Context("across all teams", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "credential-usages", "--all")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/credentials/usages", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, usages[:1]),
+					),
+				)
+			})
+
+			It("lists the usages along with their team", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+				Expect(sess.Out).To(gbytes.Say(`main\s+some-pipeline\s+job\s+some-job\s+some-var\s*\n`))
+			})
+		})
+	})
+})
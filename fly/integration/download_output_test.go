@@ -0,0 +1,186 @@
+package integration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("DownloadOutput", func() {
+	var (
+		tmpdir string
+		plan   json.RawMessage
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "fly-download-output")
+		Expect(err).NotTo(HaveOccurred())
+
+		plan = json.RawMessage(`{
+			"id": "1",
+			"do": [
+				{"id": "2", "get": {"name": "some-input"}},
+				{"id": "3", "retry": [
+					{"id": "4", "task": {"name": "some-task"}},
+					{"id": "5", "task": {"name": "some-task"}}
+				]}
+			]
+		}`)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	outputArchive := func() []byte {
+		buf := new(bytes.Buffer)
+		gzWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzWriter)
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: "./some-file", Typeflag: tar.TypeReg, Mode: 0644, Size: 12})).To(Succeed())
+		_, err := tarWriter.Write([]byte("some-content"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzWriter.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	Context("when the build and step exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 23}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/plan"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PublicBuildPlan{Schema: "exec.v2", Plan: &plan}),
+				),
+			)
+		})
+
+		Context("when the latest attempt of the step still has the output", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/steps/5/outputs/some-output"),
+						ghttp.RespondWith(http.StatusOK, outputArchive()),
+					),
+				)
+			})
+
+			It("extracts the output into the given directory", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "download-output", "-b", "23", "-s", "some-task", "-o", "some-output", "-d", tmpdir)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("downloaded 'some-output' to " + tmpdir))
+
+				content, err := ioutil.ReadFile(filepath.Join(tmpdir, "some-file"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("some-content"))
+			})
+		})
+
+		Context("when only an earlier attempt of the step has the output", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/steps/5/outputs/some-output"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/steps/4/outputs/some-output"),
+						ghttp.RespondWith(http.StatusOK, outputArchive()),
+					),
+				)
+			})
+
+			It("downloads the output of the earlier attempt", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "download-output", "-b", "23", "-s", "some-task", "-o", "some-output", "-d", tmpdir)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(filepath.Join(tmpdir, "some-file")).To(BeAnExistingFile())
+			})
+		})
+
+		Context("when the output no longer exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/steps/5/outputs/some-output"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/steps/4/outputs/some-output"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "download-output", "-b", "23", "-s", "some-task", "-o", "some-output", "-d", tmpdir)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("error: output 'some-output' of step 'some-task' no longer exists"))
+			})
+		})
+
+		Context("when the build has no such task step", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "download-output", "-b", "23", "-s", "some-input", "-o", "some-output", "-d", tmpdir)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("error: build has no task step named 'some-input'"))
+			})
+		})
+	})
+
+	Context("when the build does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "download-output", "-b", "23", "-s", "some-task", "-o", "some-output")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("error: build does not exist"))
+		})
+	})
+})
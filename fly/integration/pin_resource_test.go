@@ -165,13 +165,9 @@ var _ = Describe("Fly CLI", func() {
 					),
 					ghttp.CombineHandlers(
 						ghttp.VerifyRequest("PUT", pinVersionPath, "vars.branch=%22master%22"),
+						ghttp.VerifyJSONRepresenting(atc.PinVersionRequestBody{PinComment: "some pin message"}),
 						ghttp.RespondWith(pinVersionStatus, nil),
 					),
-					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("PUT", commentPath),
-						ghttp.VerifyJSONRepresenting(atc.SetPinCommentRequestBody{PinComment: "some pin message"}),
-						ghttp.RespondWith(saveCommentStatus, nil),
-					),
 				)
 
 				var err error
@@ -180,14 +176,13 @@ var _ = Describe("Fly CLI", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
-			Context("when the resource and versions exist, pinning succeeds and saving the comment succeeds", func() {
+			Context("when the resource and versions exist and pinning succeeds", func() {
 				BeforeEach(func() {
 					listVersionsStatus = http.StatusOK
 					pinVersionStatus = http.StatusOK
-					saveCommentStatus = http.StatusOK
 				})
 
-				It("saves the pin comment", func() {
+				It("saves the pin comment along with the pin", func() {
 					Eventually(sess.Out).Should(gbytes.Say(fmt.Sprintf("pin comment 'some pin message' is saved\n")))
 					<-sess.Exited
 					Expect(sess.ExitCode()).To(Equal(0))
@@ -198,7 +193,6 @@ var _ = Describe("Fly CLI", func() {
 				BeforeEach(func() {
 					listVersionsStatus = http.StatusNotFound
 					pinVersionStatus = http.StatusOK
-					saveCommentStatus = http.StatusOK
 				})
 
 				It("errors", func() {
@@ -212,7 +206,6 @@ var _ = Describe("Fly CLI", func() {
 				BeforeEach(func() {
 					listVersionsStatus = http.StatusOK
 					pinVersionStatus = http.StatusNotFound
-					saveCommentStatus = http.StatusOK
 				})
 
 				It("errors", func() {
@@ -223,6 +216,50 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when an expiry is provided", func() {
+			BeforeEach(func() {
+				listVersionsStatus = http.StatusOK
+				pinVersionStatus = http.StatusOK
+			})
+
+			It("pins the version with the expiry", func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", listVersionsPath, strings.Join(expectedQueryParams, "&")),
+						ghttp.RespondWithJSONEncoded(listVersionsStatus, []atc.ResourceVersion{versionToPin}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", pinVersionPath, "vars.branch=%22master%22"),
+						ghttp.VerifyJSONRepresenting(atc.PinVersionRequestBody{ExpiresIn: "4h0m0s"}),
+						ghttp.RespondWith(pinVersionStatus, nil),
+					),
+				)
+
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pin-resource", "-r", pipelineResource, "-v", pinVersion, "--for", "4h")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say(fmt.Sprintf("pinned '%s' with version {\"some\":\"value\"}\n", pipelineResource)))
+				Eventually(sess.Out).Should(gbytes.Say("pin expires in 4h0m0s\n"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+			})
+
+			It("errors when no version is given", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pin-resource", "-r", pipelineResource, "-c", "some pin message", "--for", "4h")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("--for must be a positive duration and can only be used with --version"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+
 		Context("when comment is provided without version and saving the comment fails", func() {
 			BeforeEach(func() {
 				saveCommentStatus = http.StatusNotFound
@@ -0,0 +1,148 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("promote-version", func() {
+		var (
+			expectedGetStatus    int
+			expectedPutStatus    int
+			promotePath, getPath string
+			err                  error
+			teamName             = "main"
+			pipelineName         = "pipeline"
+			resourceName         = "resource"
+			jobName              = "job"
+			resourceVersionID    = "42"
+			promoteVersion       = "some:value"
+			pipelineRef          = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
+			pipelineResource     = fmt.Sprintf("%s/%s", pipelineRef.String(), resourceName)
+			pipelineJob          = fmt.Sprintf("%s/%s", pipelineRef.String(), jobName)
+			expectedVersion      = atc.ResourceVersion{
+				ID:      42,
+				Version: atc.Version{"some": "value"},
+				Enabled: true,
+			}
+		)
+
+		Context("make sure the command exists", func() {
+			It("calls the promote-version command", func() {
+				flyCmd := exec.Command(flyPath, "promote-version")
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+
+				Expect(err).ToNot(HaveOccurred())
+				Consistently(sess.Err).ShouldNot(gbytes.Say("error: Unknown command"))
+
+				<-sess.Exited
+			})
+		})
+
+		Context("when the job is in a different pipeline than the resource", func() {
+			It("errors without calling the api", func() {
+				requestCount := len(atcServer.ReceivedRequests())
+
+				flyCmd := exec.Command(flyPath, "-t", targetName, "promote-version", "-r", pipelineResource, "-v", promoteVersion, "-j", "other-pipeline/job")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("must belong to the same pipeline"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+
+				Expect(atcServer.ReceivedRequests()).To(HaveLen(requestCount))
+			})
+		})
+
+		Context("when the resource, version and job are specified", func() {
+			BeforeEach(func() {
+				getPath, err = atc.Routes.CreatePathForRoute(atc.ListResourceVersions, rata.Params{
+					"pipeline_name": pipelineName,
+					"team_name":     teamName,
+					"resource_name": resourceName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				promotePath, err = atc.Routes.CreatePathForRoute(atc.PromoteResourceVersion, rata.Params{
+					"pipeline_name":              pipelineName,
+					"team_name":                  teamName,
+					"resource_name":              resourceName,
+					"resource_config_version_id": resourceVersionID,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", getPath, "filter=some:value&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(expectedGetStatus, []atc.ResourceVersion{expectedVersion}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", promotePath, "job=job&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(expectedPutStatus, atc.Build{ID: 123, Name: "7", Status: atc.StatusSucceeded}),
+					),
+				)
+			})
+
+			Context("when the resource version exists", func() {
+				BeforeEach(func() {
+					expectedGetStatus = http.StatusOK
+					expectedPutStatus = http.StatusOK
+				})
+
+				It("promotes the version past the job", func() {
+					Expect(func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "promote-version", "-r", pipelineResource, "-v", promoteVersion, "-j", pipelineJob)
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess.Out).Should(gbytes.Say(fmt.Sprintf("promoted '%s' version {\"some\":\"value\"} past '%s' \\(build #7\\)\n", pipelineResource, pipelineJob)))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+					}).To(Change(func() int {
+						return len(atcServer.ReceivedRequests())
+					}).By(3))
+				})
+			})
+
+			Context("when the resource version does not exist", func() {
+				BeforeEach(func() {
+					expectedGetStatus = http.StatusOK
+					expectedPutStatus = http.StatusNotFound
+				})
+
+				It("fails to promote", func() {
+					Expect(func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "promote-version", "-r", pipelineResource, "-v", promoteVersion, "-j", pipelineJob)
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(sess.Err).Should(gbytes.Say(fmt.Sprintf("could not promote '%s', make sure the resource version exists", pipelineResource)))
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(1))
+					}).To(Change(func() int {
+						return len(atcServer.ReceivedRequests())
+					}).By(3))
+				})
+			})
+		})
+	})
+})
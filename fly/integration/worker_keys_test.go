@@ -0,0 +1,120 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("worker-keys", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "worker-keys")
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/worker-keys"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.WorkerKey{
+						{
+							ID:          1,
+							TeamName:    "main",
+							PublicKey:   "ssh-ed25519 AAAA",
+							Fingerprint: "SHA256:some-fingerprint",
+							CreatedAt:   1000,
+						},
+					}),
+				),
+			)
+		})
+
+		It("lists the team's worker keys", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+			Expect(sess.Out).To(gbytes.Say(`1\s+SHA256:some-fingerprint\s+\S+\s+n/a`))
+		})
+	})
+
+	Describe("create-worker-enrollment-token", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "create-worker-enrollment-token", "--ttl", "10m")
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/main/worker-enrollment-tokens", "ttl=10m0s"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.WorkerEnrollmentToken{
+						Token:     "some-token",
+						TeamName:  "main",
+						ExpiresAt: 1613140000,
+					}),
+				),
+			)
+		})
+
+		It("prints the token", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+			Expect(sess.Out).To(gbytes.Say("some-token"))
+			Expect(sess.Out).To(gbytes.Say("--tsa-enrollment-token"))
+		})
+	})
+
+	Describe("revoke-worker-key", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "revoke-worker-key", "-k", "42")
+		})
+
+		Context("when the key exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/worker-keys/42"),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("revokes the key", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+				Expect(sess.Out).To(gbytes.Say("revoked worker key '42'"))
+			})
+		})
+
+		Context("when the key does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/worker-keys/42"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("fails", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+				Expect(sess.Err).To(gbytes.Say("worker key '42' not found"))
+			})
+		})
+	})
+})
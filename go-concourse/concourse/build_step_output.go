@@ -0,0 +1,41 @@
+package concourse
+
+import (
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildStepOutput(buildID int, planID atc.PlanID, name string, format string) (io.ReadCloser, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+		"plan_id":  string(planID),
+		"name":     name,
+	}
+
+	var query url.Values
+	if format != "" {
+		query = url.Values{atc.StepOutputQueryFormat: {format}}
+	}
+
+	response := internal.Response{}
+	err := client.connection.Send(internal.Request{
+		RequestName:        atc.GetBuildStepOutput,
+		Params:             params,
+		Query:              query,
+		ReturnResponseBody: true,
+	}, &response)
+
+	switch err.(type) {
+	case nil:
+		return response.Result.(io.ReadCloser), true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
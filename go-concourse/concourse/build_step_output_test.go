@@ -0,0 +1,72 @@
+package concourse_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Step Outputs", func() {
+	Describe("BuildStepOutput", func() {
+		expectedURL := "/api/v1/builds/1234/steps/some-plan/outputs/some-output"
+
+		Context("when the output exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "format=zip"),
+						ghttp.RespondWith(http.StatusOK, "some-archive"),
+					),
+				)
+			})
+
+			It("returns the contents of the output", func() {
+				output, found, err := client.BuildStepOutput(1234, "some-plan", "some-output", "zip")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				defer output.Close()
+
+				contents, err := ioutil.ReadAll(output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-archive"))
+			})
+		})
+
+		Context("when the output does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildStepOutput(1234, "some-plan", "some-output", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusInternalServerError, nil),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, _, err := client.BuildStepOutput(1234, "some-plan", "some-output", "")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -50,3 +50,28 @@ func (team *team) CheckResource(pipelineRef atc.PipelineRef, resourceName string
 		return build, false, err
 	}
 }
+
+func (team *team) ResourceChecks(pipelineRef atc.PipelineRef, resourceName string) ([]atc.ResourceCheck, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"resource_name": resourceName,
+		"team_name":     team.Name(),
+	}
+
+	var checks []atc.ResourceCheck
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListResourceChecks,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &checks,
+	})
+	switch err.(type) {
+	case nil:
+		return checks, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
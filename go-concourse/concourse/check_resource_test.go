@@ -107,3 +107,83 @@ var _ = Describe("CheckResource", func() {
 		})
 	})
 })
+
+var _ = Describe("ResourceChecks", func() {
+	var (
+		expectedURL   = "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/checks"
+		expectedQuery = "vars.branch=%22master%22"
+		pipelineRef   = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		checks    []atc.ResourceCheck
+		found     bool
+		clientErr error
+	)
+
+	JustBeforeEach(func() {
+		checks, found, clientErr = team.ResourceChecks(pipelineRef, "myresource")
+	})
+
+	Context("when the server returns the checks", func() {
+		var expectedChecks []atc.ResourceCheck
+
+		BeforeEach(func() {
+			expectedChecks = []atc.ResourceCheck{
+				{
+					ID:            123,
+					Status:        atc.StatusSucceeded,
+					StartTime:     100,
+					EndTime:       142,
+					Duration:      42,
+					VersionsFound: 1,
+					LatestVersion: atc.Version{"ref": "abc"},
+					EventsURL:     "/api/v1/builds/123/events",
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedChecks),
+				),
+			)
+		})
+
+		It("returns the checks", func() {
+			Expect(clientErr).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(checks).To(Equal(expectedChecks))
+		})
+	})
+
+	Context("when the server returns a 404", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("returns false for found and a nil error", func() {
+			Expect(clientErr).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the server returns a 500", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, expectedQuery),
+					ghttp.RespondWith(http.StatusInternalServerError, ""),
+				),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(clientErr).To(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
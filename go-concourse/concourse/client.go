@@ -21,10 +21,15 @@ type Client interface {
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
 	AbortBuild(buildID string) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildStepOutput(buildID int, planID atc.PlanID, name string, format string) (io.ReadCloser, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
+	WorkerDemand() (atc.WorkerDemand, error)
 	PruneWorker(workerName string) error
 	LandWorker(workerName string) error
+	EvictWorker(workerName string) error
+	QuarantineWorker(workerName string) error
+	ReleaseWorker(workerName string) error
 	GetInfo() (atc.Info, error)
 	GetCLIReader(arch, platform string) (io.ReadCloser, http.Header, error)
 	ListPipelines() ([]atc.Pipeline, error)
@@ -34,6 +39,7 @@ type Client interface {
 	Team(teamName string) Team
 	UserInfo() (atc.UserInfo, error)
 	ListActiveUsersSince(since time.Time) ([]atc.User, error)
+	ListAllCredentialUsages(path string, resolve bool) ([]atc.CredentialUsage, error)
 }
 
 type client struct {
@@ -81,6 +81,24 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildStepOutputStub        func(int, atc.PlanID, string, string) (io.ReadCloser, bool, error)
+	buildStepOutputMutex       sync.RWMutex
+	buildStepOutputArgsForCall []struct {
+		arg1 int
+		arg2 atc.PlanID
+		arg3 string
+		arg4 string
+	}
+	buildStepOutputReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	buildStepOutputReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -96,6 +114,17 @@ type FakeClient struct {
 		result2 concourse.Pagination
 		result3 error
 	}
+	EvictWorkerStub        func(string) error
+	evictWorkerMutex       sync.RWMutex
+	evictWorkerArgsForCall []struct {
+		arg1 string
+	}
+	evictWorkerReturns struct {
+		result1 error
+	}
+	evictWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	FindTeamStub        func(string) (concourse.Team, error)
 	findTeamMutex       sync.RWMutex
 	findTeamArgsForCall []struct {
@@ -171,6 +200,20 @@ type FakeClient struct {
 		result1 []atc.User
 		result2 error
 	}
+	ListAllCredentialUsagesStub        func(string, bool) ([]atc.CredentialUsage, error)
+	listAllCredentialUsagesMutex       sync.RWMutex
+	listAllCredentialUsagesArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	listAllCredentialUsagesReturns struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
+	listAllCredentialUsagesReturnsOnCall map[int]struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
 	ListAllJobsStub        func() ([]atc.Job, error)
 	listAllJobsMutex       sync.RWMutex
 	listAllJobsArgsForCall []struct {
@@ -243,6 +286,28 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	QuarantineWorkerStub        func(string) error
+	quarantineWorkerMutex       sync.RWMutex
+	quarantineWorkerArgsForCall []struct {
+		arg1 string
+	}
+	quarantineWorkerReturns struct {
+		result1 error
+	}
+	quarantineWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseWorkerStub        func(string) error
+	releaseWorkerMutex       sync.RWMutex
+	releaseWorkerArgsForCall []struct {
+		arg1 string
+	}
+	releaseWorkerReturns struct {
+		result1 error
+	}
+	releaseWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -290,6 +355,18 @@ type FakeClient struct {
 		result1 atc.UserInfo
 		result2 error
 	}
+	WorkerDemandStub        func() (atc.WorkerDemand, error)
+	workerDemandMutex       sync.RWMutex
+	workerDemandArgsForCall []struct {
+	}
+	workerDemandReturns struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}
+	workerDemandReturnsOnCall map[int]struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -620,6 +697,76 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildStepOutput(arg1 int, arg2 atc.PlanID, arg3 string, arg4 string) (io.ReadCloser, bool, error) {
+	fake.buildStepOutputMutex.Lock()
+	ret, specificReturn := fake.buildStepOutputReturnsOnCall[len(fake.buildStepOutputArgsForCall)]
+	fake.buildStepOutputArgsForCall = append(fake.buildStepOutputArgsForCall, struct {
+		arg1 int
+		arg2 atc.PlanID
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.BuildStepOutputStub
+	fakeReturns := fake.buildStepOutputReturns
+	fake.recordInvocation("BuildStepOutput", []interface{}{arg1, arg2, arg3, arg4})
+	fake.buildStepOutputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildStepOutputCallCount() int {
+	fake.buildStepOutputMutex.RLock()
+	defer fake.buildStepOutputMutex.RUnlock()
+	return len(fake.buildStepOutputArgsForCall)
+}
+
+func (fake *FakeClient) BuildStepOutputCalls(stub func(int, atc.PlanID, string, string) (io.ReadCloser, bool, error)) {
+	fake.buildStepOutputMutex.Lock()
+	defer fake.buildStepOutputMutex.Unlock()
+	fake.BuildStepOutputStub = stub
+}
+
+func (fake *FakeClient) BuildStepOutputArgsForCall(i int) (int, atc.PlanID, string, string) {
+	fake.buildStepOutputMutex.RLock()
+	defer fake.buildStepOutputMutex.RUnlock()
+	argsForCall := fake.buildStepOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) BuildStepOutputReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.buildStepOutputMutex.Lock()
+	defer fake.buildStepOutputMutex.Unlock()
+	fake.BuildStepOutputStub = nil
+	fake.buildStepOutputReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildStepOutputReturnsOnCall(i int, result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.buildStepOutputMutex.Lock()
+	defer fake.buildStepOutputMutex.Unlock()
+	fake.BuildStepOutputStub = nil
+	if fake.buildStepOutputReturnsOnCall == nil {
+		fake.buildStepOutputReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildStepOutputReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -687,6 +834,67 @@ func (fake *FakeClient) BuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) EvictWorker(arg1 string) error {
+	fake.evictWorkerMutex.Lock()
+	ret, specificReturn := fake.evictWorkerReturnsOnCall[len(fake.evictWorkerArgsForCall)]
+	fake.evictWorkerArgsForCall = append(fake.evictWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.EvictWorkerStub
+	fakeReturns := fake.evictWorkerReturns
+	fake.recordInvocation("EvictWorker", []interface{}{arg1})
+	fake.evictWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) EvictWorkerCallCount() int {
+	fake.evictWorkerMutex.RLock()
+	defer fake.evictWorkerMutex.RUnlock()
+	return len(fake.evictWorkerArgsForCall)
+}
+
+func (fake *FakeClient) EvictWorkerCalls(stub func(string) error) {
+	fake.evictWorkerMutex.Lock()
+	defer fake.evictWorkerMutex.Unlock()
+	fake.EvictWorkerStub = stub
+}
+
+func (fake *FakeClient) EvictWorkerArgsForCall(i int) string {
+	fake.evictWorkerMutex.RLock()
+	defer fake.evictWorkerMutex.RUnlock()
+	argsForCall := fake.evictWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) EvictWorkerReturns(result1 error) {
+	fake.evictWorkerMutex.Lock()
+	defer fake.evictWorkerMutex.Unlock()
+	fake.EvictWorkerStub = nil
+	fake.evictWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) EvictWorkerReturnsOnCall(i int, result1 error) {
+	fake.evictWorkerMutex.Lock()
+	defer fake.evictWorkerMutex.Unlock()
+	fake.EvictWorkerStub = nil
+	if fake.evictWorkerReturnsOnCall == nil {
+		fake.evictWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.evictWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) FindTeam(arg1 string) (concourse.Team, error) {
 	fake.findTeamMutex.Lock()
 	ret, specificReturn := fake.findTeamReturnsOnCall[len(fake.findTeamArgsForCall)]
@@ -1053,6 +1261,71 @@ func (fake *FakeClient) ListActiveUsersSinceReturnsOnCall(i int, result1 []atc.U
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListAllCredentialUsages(arg1 string, arg2 bool) ([]atc.CredentialUsage, error) {
+	fake.listAllCredentialUsagesMutex.Lock()
+	ret, specificReturn := fake.listAllCredentialUsagesReturnsOnCall[len(fake.listAllCredentialUsagesArgsForCall)]
+	fake.listAllCredentialUsagesArgsForCall = append(fake.listAllCredentialUsagesArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.ListAllCredentialUsagesStub
+	fakeReturns := fake.listAllCredentialUsagesReturns
+	fake.recordInvocation("ListAllCredentialUsages", []interface{}{arg1, arg2})
+	fake.listAllCredentialUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListAllCredentialUsagesCallCount() int {
+	fake.listAllCredentialUsagesMutex.RLock()
+	defer fake.listAllCredentialUsagesMutex.RUnlock()
+	return len(fake.listAllCredentialUsagesArgsForCall)
+}
+
+func (fake *FakeClient) ListAllCredentialUsagesCalls(stub func(string, bool) ([]atc.CredentialUsage, error)) {
+	fake.listAllCredentialUsagesMutex.Lock()
+	defer fake.listAllCredentialUsagesMutex.Unlock()
+	fake.ListAllCredentialUsagesStub = stub
+}
+
+func (fake *FakeClient) ListAllCredentialUsagesArgsForCall(i int) (string, bool) {
+	fake.listAllCredentialUsagesMutex.RLock()
+	defer fake.listAllCredentialUsagesMutex.RUnlock()
+	argsForCall := fake.listAllCredentialUsagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ListAllCredentialUsagesReturns(result1 []atc.CredentialUsage, result2 error) {
+	fake.listAllCredentialUsagesMutex.Lock()
+	defer fake.listAllCredentialUsagesMutex.Unlock()
+	fake.ListAllCredentialUsagesStub = nil
+	fake.listAllCredentialUsagesReturns = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListAllCredentialUsagesReturnsOnCall(i int, result1 []atc.CredentialUsage, result2 error) {
+	fake.listAllCredentialUsagesMutex.Lock()
+	defer fake.listAllCredentialUsagesMutex.Unlock()
+	fake.ListAllCredentialUsagesStub = nil
+	if fake.listAllCredentialUsagesReturnsOnCall == nil {
+		fake.listAllCredentialUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.CredentialUsage
+			result2 error
+		})
+	}
+	fake.listAllCredentialUsagesReturnsOnCall[i] = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListAllJobs() ([]atc.Job, error) {
 	fake.listAllJobsMutex.Lock()
 	ret, specificReturn := fake.listAllJobsReturnsOnCall[len(fake.listAllJobsArgsForCall)]
@@ -1402,6 +1675,128 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) QuarantineWorker(arg1 string) error {
+	fake.quarantineWorkerMutex.Lock()
+	ret, specificReturn := fake.quarantineWorkerReturnsOnCall[len(fake.quarantineWorkerArgsForCall)]
+	fake.quarantineWorkerArgsForCall = append(fake.quarantineWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.QuarantineWorkerStub
+	fakeReturns := fake.quarantineWorkerReturns
+	fake.recordInvocation("QuarantineWorker", []interface{}{arg1})
+	fake.quarantineWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) QuarantineWorkerCallCount() int {
+	fake.quarantineWorkerMutex.RLock()
+	defer fake.quarantineWorkerMutex.RUnlock()
+	return len(fake.quarantineWorkerArgsForCall)
+}
+
+func (fake *FakeClient) QuarantineWorkerCalls(stub func(string) error) {
+	fake.quarantineWorkerMutex.Lock()
+	defer fake.quarantineWorkerMutex.Unlock()
+	fake.QuarantineWorkerStub = stub
+}
+
+func (fake *FakeClient) QuarantineWorkerArgsForCall(i int) string {
+	fake.quarantineWorkerMutex.RLock()
+	defer fake.quarantineWorkerMutex.RUnlock()
+	argsForCall := fake.quarantineWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) QuarantineWorkerReturns(result1 error) {
+	fake.quarantineWorkerMutex.Lock()
+	defer fake.quarantineWorkerMutex.Unlock()
+	fake.QuarantineWorkerStub = nil
+	fake.quarantineWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) QuarantineWorkerReturnsOnCall(i int, result1 error) {
+	fake.quarantineWorkerMutex.Lock()
+	defer fake.quarantineWorkerMutex.Unlock()
+	fake.QuarantineWorkerStub = nil
+	if fake.quarantineWorkerReturnsOnCall == nil {
+		fake.quarantineWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.quarantineWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ReleaseWorker(arg1 string) error {
+	fake.releaseWorkerMutex.Lock()
+	ret, specificReturn := fake.releaseWorkerReturnsOnCall[len(fake.releaseWorkerArgsForCall)]
+	fake.releaseWorkerArgsForCall = append(fake.releaseWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleaseWorkerStub
+	fakeReturns := fake.releaseWorkerReturns
+	fake.recordInvocation("ReleaseWorker", []interface{}{arg1})
+	fake.releaseWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ReleaseWorkerCallCount() int {
+	fake.releaseWorkerMutex.RLock()
+	defer fake.releaseWorkerMutex.RUnlock()
+	return len(fake.releaseWorkerArgsForCall)
+}
+
+func (fake *FakeClient) ReleaseWorkerCalls(stub func(string) error) {
+	fake.releaseWorkerMutex.Lock()
+	defer fake.releaseWorkerMutex.Unlock()
+	fake.ReleaseWorkerStub = stub
+}
+
+func (fake *FakeClient) ReleaseWorkerArgsForCall(i int) string {
+	fake.releaseWorkerMutex.RLock()
+	defer fake.releaseWorkerMutex.RUnlock()
+	argsForCall := fake.releaseWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ReleaseWorkerReturns(result1 error) {
+	fake.releaseWorkerMutex.Lock()
+	defer fake.releaseWorkerMutex.Unlock()
+	fake.ReleaseWorkerStub = nil
+	fake.releaseWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ReleaseWorkerReturnsOnCall(i int, result1 error) {
+	fake.releaseWorkerMutex.Lock()
+	defer fake.releaseWorkerMutex.Unlock()
+	fake.ReleaseWorkerStub = nil
+	if fake.releaseWorkerReturnsOnCall == nil {
+		fake.releaseWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1637,6 +2032,62 @@ func (fake *FakeClient) UserInfoReturnsOnCall(i int, result1 atc.UserInfo, resul
 	}{result1, result2}
 }
 
+func (fake *FakeClient) WorkerDemand() (atc.WorkerDemand, error) {
+	fake.workerDemandMutex.Lock()
+	ret, specificReturn := fake.workerDemandReturnsOnCall[len(fake.workerDemandArgsForCall)]
+	fake.workerDemandArgsForCall = append(fake.workerDemandArgsForCall, struct {
+	}{})
+	stub := fake.WorkerDemandStub
+	fakeReturns := fake.workerDemandReturns
+	fake.recordInvocation("WorkerDemand", []interface{}{})
+	fake.workerDemandMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) WorkerDemandCallCount() int {
+	fake.workerDemandMutex.RLock()
+	defer fake.workerDemandMutex.RUnlock()
+	return len(fake.workerDemandArgsForCall)
+}
+
+func (fake *FakeClient) WorkerDemandCalls(stub func() (atc.WorkerDemand, error)) {
+	fake.workerDemandMutex.Lock()
+	defer fake.workerDemandMutex.Unlock()
+	fake.WorkerDemandStub = stub
+}
+
+func (fake *FakeClient) WorkerDemandReturns(result1 atc.WorkerDemand, result2 error) {
+	fake.workerDemandMutex.Lock()
+	defer fake.workerDemandMutex.Unlock()
+	fake.WorkerDemandStub = nil
+	fake.workerDemandReturns = struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) WorkerDemandReturnsOnCall(i int, result1 atc.WorkerDemand, result2 error) {
+	fake.workerDemandMutex.Lock()
+	defer fake.workerDemandMutex.Unlock()
+	fake.WorkerDemandStub = nil
+	if fake.workerDemandReturnsOnCall == nil {
+		fake.workerDemandReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerDemand
+			result2 error
+		})
+	}
+	fake.workerDemandReturnsOnCall[i] = struct {
+		result1 atc.WorkerDemand
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1650,8 +2101,12 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildStepOutputMutex.RLock()
+	defer fake.buildStepOutputMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.evictWorkerMutex.RLock()
+	defer fake.evictWorkerMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
@@ -1664,6 +2119,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.landWorkerMutex.RUnlock()
 	fake.listActiveUsersSinceMutex.RLock()
 	defer fake.listActiveUsersSinceMutex.RUnlock()
+	fake.listAllCredentialUsagesMutex.RLock()
+	defer fake.listAllCredentialUsagesMutex.RUnlock()
 	fake.listAllJobsMutex.RLock()
 	defer fake.listAllJobsMutex.RUnlock()
 	fake.listBuildArtifactsMutex.RLock()
@@ -1676,6 +2133,10 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.quarantineWorkerMutex.RLock()
+	defer fake.quarantineWorkerMutex.RUnlock()
+	fake.releaseWorkerMutex.RLock()
+	defer fake.releaseWorkerMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
@@ -1684,6 +2145,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.uRLMutex.RUnlock()
 	fake.userInfoMutex.RLock()
 	defer fake.userInfoMutex.RUnlock()
+	fake.workerDemandMutex.RLock()
+	defer fake.workerDemandMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,6 +4,7 @@ package concoursefakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse"
@@ -142,6 +143,32 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	ClearCredentialCacheStub        func(string) error
+	clearCredentialCacheMutex       sync.RWMutex
+	clearCredentialCacheArgsForCall []struct {
+		arg1 string
+	}
+	clearCredentialCacheReturns struct {
+		result1 error
+	}
+	clearCredentialCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ClearJobCachesStub        func(atc.PipelineRef, string, string) (int64, error)
+	clearJobCachesMutex       sync.RWMutex
+	clearJobCachesArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}
+	clearJobCachesReturns struct {
+		result1 int64
+		result2 error
+	}
+	clearJobCachesReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	ClearTaskCacheStub        func(atc.PipelineRef, string, string, string) (int64, error)
 	clearTaskCacheMutex       sync.RWMutex
 	clearTaskCacheArgsForCall []struct {
@@ -253,6 +280,33 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateWorkerEnrollmentTokenStub        func(time.Duration) (atc.WorkerEnrollmentToken, error)
+	createWorkerEnrollmentTokenMutex       sync.RWMutex
+	createWorkerEnrollmentTokenArgsForCall []struct {
+		arg1 time.Duration
+	}
+	createWorkerEnrollmentTokenReturns struct {
+		result1 atc.WorkerEnrollmentToken
+		result2 error
+	}
+	createWorkerEnrollmentTokenReturnsOnCall map[int]struct {
+		result1 atc.WorkerEnrollmentToken
+		result2 error
+	}
+	CredentialUsagesStub        func(string, bool) ([]atc.CredentialUsage, error)
+	credentialUsagesMutex       sync.RWMutex
+	credentialUsagesArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	credentialUsagesReturns struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
+	credentialUsagesReturnsOnCall map[int]struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}
 	DeletePipelineStub        func(atc.PipelineRef) (bool, error)
 	deletePipelineMutex       sync.RWMutex
 	deletePipelineArgsForCall []struct {
@@ -421,6 +475,22 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	JobCachesStub        func(atc.PipelineRef, string) ([]atc.KeyedCache, bool, error)
+	jobCachesMutex       sync.RWMutex
+	jobCachesArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	jobCachesReturns struct {
+		result1 []atc.KeyedCache
+		result2 bool
+		result3 error
+	}
+	jobCachesReturnsOnCall map[int]struct {
+		result1 []atc.KeyedCache
+		result2 bool
+		result3 error
+	}
 	ListContainersStub        func(map[string]string) ([]atc.Container, error)
 	listContainersMutex       sync.RWMutex
 	listContainersArgsForCall []struct {
@@ -484,6 +554,18 @@ type FakeTeam struct {
 		result1 []atc.Volume
 		result2 error
 	}
+	ListWorkerKeysStub        func() ([]atc.WorkerKey, error)
+	listWorkerKeysMutex       sync.RWMutex
+	listWorkerKeysArgsForCall []struct {
+	}
+	listWorkerKeysReturns struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
+	listWorkerKeysReturnsOnCall map[int]struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -532,12 +614,13 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PinResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
+	PinResourceVersionStub        func(atc.PipelineRef, string, int, atc.PinVersionRequestBody) (bool, error)
 	pinResourceVersionMutex       sync.RWMutex
 	pinResourceVersionArgsForCall []struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
+		arg4 atc.PinVersionRequestBody
 	}
 	pinResourceVersionReturns struct {
 		result1 bool
@@ -597,6 +680,24 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PromoteResourceVersionStub        func(atc.PipelineRef, string, int, string) (atc.Build, bool, error)
+	promoteResourceVersionMutex       sync.RWMutex
+	promoteResourceVersionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	promoteResourceVersionReturns struct {
+		result1 atc.Build
+		result2 bool
+		result3 error
+	}
+	promoteResourceVersionReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 bool
+		result3 error
+	}
 	RenamePipelineStub        func(string, string) (bool, []concourse.ConfigWarning, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -660,6 +761,22 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	ResourceChecksStub        func(atc.PipelineRef, string) ([]atc.ResourceCheck, bool, error)
+	resourceChecksMutex       sync.RWMutex
+	resourceChecksArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	resourceChecksReturns struct {
+		result1 []atc.ResourceCheck
+		result2 bool
+		result3 error
+	}
+	resourceChecksReturnsOnCall map[int]struct {
+		result1 []atc.ResourceCheck
+		result2 bool
+		result3 error
+	}
 	ResourceVersionsStub        func(atc.PipelineRef, string, concourse.Page, atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error)
 	resourceVersionsMutex       sync.RWMutex
 	resourceVersionsArgsForCall []struct {
@@ -680,6 +797,19 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	RevokeWorkerKeyStub        func(int) (bool, error)
+	revokeWorkerKeyMutex       sync.RWMutex
+	revokeWorkerKeyArgsForCall []struct {
+		arg1 int
+	}
+	revokeWorkerKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeWorkerKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ScheduleJobStub        func(atc.PipelineRef, string) (bool, error)
 	scheduleJobMutex       sync.RWMutex
 	scheduleJobArgsForCall []struct {
@@ -1350,6 +1480,133 @@ func (fake *FakeTeam) CheckResourceTypeReturnsOnCall(i int, result1 atc.Build, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ClearCredentialCache(arg1 string) error {
+	fake.clearCredentialCacheMutex.Lock()
+	ret, specificReturn := fake.clearCredentialCacheReturnsOnCall[len(fake.clearCredentialCacheArgsForCall)]
+	fake.clearCredentialCacheArgsForCall = append(fake.clearCredentialCacheArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ClearCredentialCacheStub
+	fakeReturns := fake.clearCredentialCacheReturns
+	fake.recordInvocation("ClearCredentialCache", []interface{}{arg1})
+	fake.clearCredentialCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ClearCredentialCacheCallCount() int {
+	fake.clearCredentialCacheMutex.RLock()
+	defer fake.clearCredentialCacheMutex.RUnlock()
+	return len(fake.clearCredentialCacheArgsForCall)
+}
+
+func (fake *FakeTeam) ClearCredentialCacheCalls(stub func(string) error) {
+	fake.clearCredentialCacheMutex.Lock()
+	defer fake.clearCredentialCacheMutex.Unlock()
+	fake.ClearCredentialCacheStub = stub
+}
+
+func (fake *FakeTeam) ClearCredentialCacheArgsForCall(i int) string {
+	fake.clearCredentialCacheMutex.RLock()
+	defer fake.clearCredentialCacheMutex.RUnlock()
+	argsForCall := fake.clearCredentialCacheArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ClearCredentialCacheReturns(result1 error) {
+	fake.clearCredentialCacheMutex.Lock()
+	defer fake.clearCredentialCacheMutex.Unlock()
+	fake.ClearCredentialCacheStub = nil
+	fake.clearCredentialCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) ClearCredentialCacheReturnsOnCall(i int, result1 error) {
+	fake.clearCredentialCacheMutex.Lock()
+	defer fake.clearCredentialCacheMutex.Unlock()
+	fake.ClearCredentialCacheStub = nil
+	if fake.clearCredentialCacheReturnsOnCall == nil {
+		fake.clearCredentialCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearCredentialCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) ClearJobCaches(arg1 atc.PipelineRef, arg2 string, arg3 string) (int64, error) {
+	fake.clearJobCachesMutex.Lock()
+	ret, specificReturn := fake.clearJobCachesReturnsOnCall[len(fake.clearJobCachesArgsForCall)]
+	fake.clearJobCachesArgsForCall = append(fake.clearJobCachesArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ClearJobCachesStub
+	fakeReturns := fake.clearJobCachesReturns
+	fake.recordInvocation("ClearJobCaches", []interface{}{arg1, arg2, arg3})
+	fake.clearJobCachesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ClearJobCachesCallCount() int {
+	fake.clearJobCachesMutex.RLock()
+	defer fake.clearJobCachesMutex.RUnlock()
+	return len(fake.clearJobCachesArgsForCall)
+}
+
+func (fake *FakeTeam) ClearJobCachesCalls(stub func(atc.PipelineRef, string, string) (int64, error)) {
+	fake.clearJobCachesMutex.Lock()
+	defer fake.clearJobCachesMutex.Unlock()
+	fake.ClearJobCachesStub = stub
+}
+
+func (fake *FakeTeam) ClearJobCachesArgsForCall(i int) (atc.PipelineRef, string, string) {
+	fake.clearJobCachesMutex.RLock()
+	defer fake.clearJobCachesMutex.RUnlock()
+	argsForCall := fake.clearJobCachesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) ClearJobCachesReturns(result1 int64, result2 error) {
+	fake.clearJobCachesMutex.Lock()
+	defer fake.clearJobCachesMutex.Unlock()
+	fake.ClearJobCachesStub = nil
+	fake.clearJobCachesReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ClearJobCachesReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.clearJobCachesMutex.Lock()
+	defer fake.clearJobCachesMutex.Unlock()
+	fake.ClearJobCachesStub = nil
+	if fake.clearJobCachesReturnsOnCall == nil {
+		fake.clearJobCachesReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.clearJobCachesReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ClearTaskCache(arg1 atc.PipelineRef, arg2 string, arg3 string, arg4 string) (int64, error) {
 	fake.clearTaskCacheMutex.Lock()
 	ret, specificReturn := fake.clearTaskCacheReturnsOnCall[len(fake.clearTaskCacheArgsForCall)]
@@ -1833,6 +2090,135 @@ func (fake *FakeTeam) CreatePipelineBuildReturnsOnCall(i int, result1 atc.Build,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateWorkerEnrollmentToken(arg1 time.Duration) (atc.WorkerEnrollmentToken, error) {
+	fake.createWorkerEnrollmentTokenMutex.Lock()
+	ret, specificReturn := fake.createWorkerEnrollmentTokenReturnsOnCall[len(fake.createWorkerEnrollmentTokenArgsForCall)]
+	fake.createWorkerEnrollmentTokenArgsForCall = append(fake.createWorkerEnrollmentTokenArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.CreateWorkerEnrollmentTokenStub
+	fakeReturns := fake.createWorkerEnrollmentTokenReturns
+	fake.recordInvocation("CreateWorkerEnrollmentToken", []interface{}{arg1})
+	fake.createWorkerEnrollmentTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateWorkerEnrollmentTokenCallCount() int {
+	fake.createWorkerEnrollmentTokenMutex.RLock()
+	defer fake.createWorkerEnrollmentTokenMutex.RUnlock()
+	return len(fake.createWorkerEnrollmentTokenArgsForCall)
+}
+
+func (fake *FakeTeam) CreateWorkerEnrollmentTokenCalls(stub func(time.Duration) (atc.WorkerEnrollmentToken, error)) {
+	fake.createWorkerEnrollmentTokenMutex.Lock()
+	defer fake.createWorkerEnrollmentTokenMutex.Unlock()
+	fake.CreateWorkerEnrollmentTokenStub = stub
+}
+
+func (fake *FakeTeam) CreateWorkerEnrollmentTokenArgsForCall(i int) time.Duration {
+	fake.createWorkerEnrollmentTokenMutex.RLock()
+	defer fake.createWorkerEnrollmentTokenMutex.RUnlock()
+	argsForCall := fake.createWorkerEnrollmentTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateWorkerEnrollmentTokenReturns(result1 atc.WorkerEnrollmentToken, result2 error) {
+	fake.createWorkerEnrollmentTokenMutex.Lock()
+	defer fake.createWorkerEnrollmentTokenMutex.Unlock()
+	fake.CreateWorkerEnrollmentTokenStub = nil
+	fake.createWorkerEnrollmentTokenReturns = struct {
+		result1 atc.WorkerEnrollmentToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateWorkerEnrollmentTokenReturnsOnCall(i int, result1 atc.WorkerEnrollmentToken, result2 error) {
+	fake.createWorkerEnrollmentTokenMutex.Lock()
+	defer fake.createWorkerEnrollmentTokenMutex.Unlock()
+	fake.CreateWorkerEnrollmentTokenStub = nil
+	if fake.createWorkerEnrollmentTokenReturnsOnCall == nil {
+		fake.createWorkerEnrollmentTokenReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerEnrollmentToken
+			result2 error
+		})
+	}
+	fake.createWorkerEnrollmentTokenReturnsOnCall[i] = struct {
+		result1 atc.WorkerEnrollmentToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CredentialUsages(arg1 string, arg2 bool) ([]atc.CredentialUsage, error) {
+	fake.credentialUsagesMutex.Lock()
+	ret, specificReturn := fake.credentialUsagesReturnsOnCall[len(fake.credentialUsagesArgsForCall)]
+	fake.credentialUsagesArgsForCall = append(fake.credentialUsagesArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.CredentialUsagesStub
+	fakeReturns := fake.credentialUsagesReturns
+	fake.recordInvocation("CredentialUsages", []interface{}{arg1, arg2})
+	fake.credentialUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CredentialUsagesCallCount() int {
+	fake.credentialUsagesMutex.RLock()
+	defer fake.credentialUsagesMutex.RUnlock()
+	return len(fake.credentialUsagesArgsForCall)
+}
+
+func (fake *FakeTeam) CredentialUsagesCalls(stub func(string, bool) ([]atc.CredentialUsage, error)) {
+	fake.credentialUsagesMutex.Lock()
+	defer fake.credentialUsagesMutex.Unlock()
+	fake.CredentialUsagesStub = stub
+}
+
+func (fake *FakeTeam) CredentialUsagesArgsForCall(i int) (string, bool) {
+	fake.credentialUsagesMutex.RLock()
+	defer fake.credentialUsagesMutex.RUnlock()
+	argsForCall := fake.credentialUsagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) CredentialUsagesReturns(result1 []atc.CredentialUsage, result2 error) {
+	fake.credentialUsagesMutex.Lock()
+	defer fake.credentialUsagesMutex.Unlock()
+	fake.CredentialUsagesStub = nil
+	fake.credentialUsagesReturns = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CredentialUsagesReturnsOnCall(i int, result1 []atc.CredentialUsage, result2 error) {
+	fake.credentialUsagesMutex.Lock()
+	defer fake.credentialUsagesMutex.Unlock()
+	fake.CredentialUsagesStub = nil
+	if fake.credentialUsagesReturnsOnCall == nil {
+		fake.credentialUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.CredentialUsage
+			result2 error
+		})
+	}
+	fake.credentialUsagesReturnsOnCall[i] = struct {
+		result1 []atc.CredentialUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DeletePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.deletePipelineMutex.Lock()
 	ret, specificReturn := fake.deletePipelineReturnsOnCall[len(fake.deletePipelineArgsForCall)]
@@ -2608,6 +2994,74 @@ func (fake *FakeTeam) JobBuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) JobCaches(arg1 atc.PipelineRef, arg2 string) ([]atc.KeyedCache, bool, error) {
+	fake.jobCachesMutex.Lock()
+	ret, specificReturn := fake.jobCachesReturnsOnCall[len(fake.jobCachesArgsForCall)]
+	fake.jobCachesArgsForCall = append(fake.jobCachesArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.JobCachesStub
+	fakeReturns := fake.jobCachesReturns
+	fake.recordInvocation("JobCaches", []interface{}{arg1, arg2})
+	fake.jobCachesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) JobCachesCallCount() int {
+	fake.jobCachesMutex.RLock()
+	defer fake.jobCachesMutex.RUnlock()
+	return len(fake.jobCachesArgsForCall)
+}
+
+func (fake *FakeTeam) JobCachesCalls(stub func(atc.PipelineRef, string) ([]atc.KeyedCache, bool, error)) {
+	fake.jobCachesMutex.Lock()
+	defer fake.jobCachesMutex.Unlock()
+	fake.JobCachesStub = stub
+}
+
+func (fake *FakeTeam) JobCachesArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.jobCachesMutex.RLock()
+	defer fake.jobCachesMutex.RUnlock()
+	argsForCall := fake.jobCachesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) JobCachesReturns(result1 []atc.KeyedCache, result2 bool, result3 error) {
+	fake.jobCachesMutex.Lock()
+	defer fake.jobCachesMutex.Unlock()
+	fake.JobCachesStub = nil
+	fake.jobCachesReturns = struct {
+		result1 []atc.KeyedCache
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) JobCachesReturnsOnCall(i int, result1 []atc.KeyedCache, result2 bool, result3 error) {
+	fake.jobCachesMutex.Lock()
+	defer fake.jobCachesMutex.Unlock()
+	fake.JobCachesStub = nil
+	if fake.jobCachesReturnsOnCall == nil {
+		fake.jobCachesReturnsOnCall = make(map[int]struct {
+			result1 []atc.KeyedCache
+			result2 bool
+			result3 error
+		})
+	}
+	fake.jobCachesReturnsOnCall[i] = struct {
+		result1 []atc.KeyedCache
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ListContainers(arg1 map[string]string) ([]atc.Container, error) {
 	fake.listContainersMutex.Lock()
 	ret, specificReturn := fake.listContainersReturnsOnCall[len(fake.listContainersArgsForCall)]
@@ -2912,6 +3366,62 @@ func (fake *FakeTeam) ListVolumesReturnsOnCall(i int, result1 []atc.Volume, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	fake.listWorkerKeysMutex.Lock()
+	ret, specificReturn := fake.listWorkerKeysReturnsOnCall[len(fake.listWorkerKeysArgsForCall)]
+	fake.listWorkerKeysArgsForCall = append(fake.listWorkerKeysArgsForCall, struct {
+	}{})
+	stub := fake.ListWorkerKeysStub
+	fakeReturns := fake.listWorkerKeysReturns
+	fake.recordInvocation("ListWorkerKeys", []interface{}{})
+	fake.listWorkerKeysMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListWorkerKeysCallCount() int {
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
+	return len(fake.listWorkerKeysArgsForCall)
+}
+
+func (fake *FakeTeam) ListWorkerKeysCalls(stub func() ([]atc.WorkerKey, error)) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = stub
+}
+
+func (fake *FakeTeam) ListWorkerKeysReturns(result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	fake.listWorkerKeysReturns = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListWorkerKeysReturnsOnCall(i int, result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	if fake.listWorkerKeysReturnsOnCall == nil {
+		fake.listWorkerKeysReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.listWorkerKeysReturnsOnCall[i] = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -3160,20 +3670,21 @@ func (fake *FakeTeam) PausePipelineReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) PinResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) PinResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int, arg4 atc.PinVersionRequestBody) (bool, error) {
 	fake.pinResourceVersionMutex.Lock()
 	ret, specificReturn := fake.pinResourceVersionReturnsOnCall[len(fake.pinResourceVersionArgsForCall)]
 	fake.pinResourceVersionArgsForCall = append(fake.pinResourceVersionArgsForCall, struct {
 		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
-	}{arg1, arg2, arg3})
+		arg4 atc.PinVersionRequestBody
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.PinResourceVersionStub
 	fakeReturns := fake.pinResourceVersionReturns
-	fake.recordInvocation("PinResourceVersion", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("PinResourceVersion", []interface{}{arg1, arg2, arg3, arg4})
 	fake.pinResourceVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3187,17 +3698,17 @@ func (fake *FakeTeam) PinResourceVersionCallCount() int {
 	return len(fake.pinResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) PinResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
+func (fake *FakeTeam) PinResourceVersionCalls(stub func(atc.PipelineRef, string, int, atc.PinVersionRequestBody) (bool, error)) {
 	fake.pinResourceVersionMutex.Lock()
 	defer fake.pinResourceVersionMutex.Unlock()
 	fake.PinResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) PinResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
+func (fake *FakeTeam) PinResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int, atc.PinVersionRequestBody) {
 	fake.pinResourceVersionMutex.RLock()
 	defer fake.pinResourceVersionMutex.RUnlock()
 	argsForCall := fake.pinResourceVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTeam) PinResourceVersionReturns(result1 bool, result2 error) {
@@ -3434,6 +3945,76 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PromoteResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int, arg4 string) (atc.Build, bool, error) {
+	fake.promoteResourceVersionMutex.Lock()
+	ret, specificReturn := fake.promoteResourceVersionReturnsOnCall[len(fake.promoteResourceVersionArgsForCall)]
+	fake.promoteResourceVersionArgsForCall = append(fake.promoteResourceVersionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PromoteResourceVersionStub
+	fakeReturns := fake.promoteResourceVersionReturns
+	fake.recordInvocation("PromoteResourceVersion", []interface{}{arg1, arg2, arg3, arg4})
+	fake.promoteResourceVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PromoteResourceVersionCallCount() int {
+	fake.promoteResourceVersionMutex.RLock()
+	defer fake.promoteResourceVersionMutex.RUnlock()
+	return len(fake.promoteResourceVersionArgsForCall)
+}
+
+func (fake *FakeTeam) PromoteResourceVersionCalls(stub func(atc.PipelineRef, string, int, string) (atc.Build, bool, error)) {
+	fake.promoteResourceVersionMutex.Lock()
+	defer fake.promoteResourceVersionMutex.Unlock()
+	fake.PromoteResourceVersionStub = stub
+}
+
+func (fake *FakeTeam) PromoteResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int, string) {
+	fake.promoteResourceVersionMutex.RLock()
+	defer fake.promoteResourceVersionMutex.RUnlock()
+	argsForCall := fake.promoteResourceVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) PromoteResourceVersionReturns(result1 atc.Build, result2 bool, result3 error) {
+	fake.promoteResourceVersionMutex.Lock()
+	defer fake.promoteResourceVersionMutex.Unlock()
+	fake.PromoteResourceVersionStub = nil
+	fake.promoteResourceVersionReturns = struct {
+		result1 atc.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PromoteResourceVersionReturnsOnCall(i int, result1 atc.Build, result2 bool, result3 error) {
+	fake.promoteResourceVersionMutex.Lock()
+	defer fake.promoteResourceVersionMutex.Unlock()
+	fake.PromoteResourceVersionStub = nil
+	if fake.promoteResourceVersionReturnsOnCall == nil {
+		fake.promoteResourceVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.promoteResourceVersionReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, []concourse.ConfigWarning, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -3704,6 +4285,74 @@ func (fake *FakeTeam) ResourceReturnsOnCall(i int, result1 atc.Resource, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ResourceChecks(arg1 atc.PipelineRef, arg2 string) ([]atc.ResourceCheck, bool, error) {
+	fake.resourceChecksMutex.Lock()
+	ret, specificReturn := fake.resourceChecksReturnsOnCall[len(fake.resourceChecksArgsForCall)]
+	fake.resourceChecksArgsForCall = append(fake.resourceChecksArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ResourceChecksStub
+	fakeReturns := fake.resourceChecksReturns
+	fake.recordInvocation("ResourceChecks", []interface{}{arg1, arg2})
+	fake.resourceChecksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ResourceChecksCallCount() int {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	return len(fake.resourceChecksArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceChecksCalls(stub func(atc.PipelineRef, string) ([]atc.ResourceCheck, bool, error)) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = stub
+}
+
+func (fake *FakeTeam) ResourceChecksArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	argsForCall := fake.resourceChecksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ResourceChecksReturns(result1 []atc.ResourceCheck, result2 bool, result3 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	fake.resourceChecksReturns = struct {
+		result1 []atc.ResourceCheck
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ResourceChecksReturnsOnCall(i int, result1 []atc.ResourceCheck, result2 bool, result3 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	if fake.resourceChecksReturnsOnCall == nil {
+		fake.resourceChecksReturnsOnCall = make(map[int]struct {
+			result1 []atc.ResourceCheck
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resourceChecksReturnsOnCall[i] = struct {
+		result1 []atc.ResourceCheck
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ResourceVersions(arg1 atc.PipelineRef, arg2 string, arg3 concourse.Page, arg4 atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
 	fake.resourceVersionsMutex.Lock()
 	ret, specificReturn := fake.resourceVersionsReturnsOnCall[len(fake.resourceVersionsArgsForCall)]
@@ -3777,6 +4426,70 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) RevokeWorkerKey(arg1 int) (bool, error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.revokeWorkerKeyReturnsOnCall[len(fake.revokeWorkerKeyArgsForCall)]
+	fake.revokeWorkerKeyArgsForCall = append(fake.revokeWorkerKeyArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RevokeWorkerKeyStub
+	fakeReturns := fake.revokeWorkerKeyReturns
+	fake.recordInvocation("RevokeWorkerKey", []interface{}{arg1})
+	fake.revokeWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyCallCount() int {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	return len(fake.revokeWorkerKeyArgsForCall)
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyCalls(stub func(int) (bool, error)) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = stub
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyArgsForCall(i int) int {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	argsForCall := fake.revokeWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyReturns(result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	fake.revokeWorkerKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	if fake.revokeWorkerKeyReturnsOnCall == nil {
+		fake.revokeWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeWorkerKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ScheduleJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.scheduleJobMutex.Lock()
 	ret, specificReturn := fake.scheduleJobReturnsOnCall[len(fake.scheduleJobArgsForCall)]
@@ -4190,6 +4903,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.checkResourceMutex.RUnlock()
 	fake.checkResourceTypeMutex.RLock()
 	defer fake.checkResourceTypeMutex.RUnlock()
+	fake.clearCredentialCacheMutex.RLock()
+	defer fake.clearCredentialCacheMutex.RUnlock()
+	fake.clearJobCachesMutex.RLock()
+	defer fake.clearJobCachesMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
 	fake.createArtifactMutex.RLock()
@@ -4204,6 +4921,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createOrUpdatePipelineConfigMutex.RUnlock()
 	fake.createPipelineBuildMutex.RLock()
 	defer fake.createPipelineBuildMutex.RUnlock()
+	fake.createWorkerEnrollmentTokenMutex.RLock()
+	defer fake.createWorkerEnrollmentTokenMutex.RUnlock()
+	fake.credentialUsagesMutex.RLock()
+	defer fake.credentialUsagesMutex.RUnlock()
 	fake.deletePipelineMutex.RLock()
 	defer fake.deletePipelineMutex.RUnlock()
 	fake.destroyTeamMutex.RLock()
@@ -4228,6 +4949,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.jobBuildMutex.RUnlock()
 	fake.jobBuildsMutex.RLock()
 	defer fake.jobBuildsMutex.RUnlock()
+	fake.jobCachesMutex.RLock()
+	defer fake.jobCachesMutex.RUnlock()
 	fake.listContainersMutex.RLock()
 	defer fake.listContainersMutex.RUnlock()
 	fake.listJobsMutex.RLock()
@@ -4238,6 +4961,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.listResourcesMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.orderingPipelinesMutex.RLock()
@@ -4254,6 +4979,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineBuildsMutex.RUnlock()
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
+	fake.promoteResourceVersionMutex.RLock()
+	defer fake.promoteResourceVersionMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
@@ -4262,8 +4989,12 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.rerunJobBuildMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
 	fake.scheduleJobMutex.RLock()
 	defer fake.scheduleJobMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
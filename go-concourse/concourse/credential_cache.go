@@ -0,0 +1,26 @@
+package concourse
+
+import (
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ClearCredentialCache(path string) error {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	queryParams := url.Values{}
+	if path != "" {
+		queryParams.Add(atc.CredentialCacheQueryPath, path)
+	}
+
+	return team.connection.Send(internal.Request{
+		RequestName: atc.ClearCredentialCache,
+		Params:      params,
+		Query:       queryParams,
+	}, nil)
+}
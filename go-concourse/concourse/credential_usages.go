@@ -0,0 +1,51 @@
+package concourse
+
+import (
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) CredentialUsages(path string, resolve bool) ([]atc.CredentialUsage, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	var usages []atc.CredentialUsage
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListTeamCredentialUsages,
+		Params:      params,
+		Query:       credentialUsagesQuery(path, resolve),
+	}, &internal.Response{
+		Result: &usages,
+	})
+
+	return usages, err
+}
+
+func (client *client) ListAllCredentialUsages(path string, resolve bool) ([]atc.CredentialUsage, error) {
+	var usages []atc.CredentialUsage
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListAllCredentialUsages,
+		Query:       credentialUsagesQuery(path, resolve),
+	}, &internal.Response{
+		Result: &usages,
+	})
+
+	return usages, err
+}
+
+func credentialUsagesQuery(path string, resolve bool) url.Values {
+	query := url.Values{}
+	if path != "" {
+		query.Add(atc.CredentialUsagesQueryPath, path)
+	}
+
+	if resolve {
+		query.Add(atc.CredentialUsagesResolve, "")
+	}
+
+	return query
+}
@@ -0,0 +1,99 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Credential Usages", func() {
+	var expectedUsages []atc.CredentialUsage
+
+	BeforeEach(func() {
+		expectedUsages = []atc.CredentialUsage{
+			{
+				TeamName:     "some-team",
+				PipelineID:   1,
+				PipelineName: "some-pipeline",
+				Type:         atc.CredentialUsageResource,
+				Name:         "some-resource",
+				Path:         "some-var",
+				Unresolved:   true,
+			},
+		}
+	})
+
+	Describe("CredentialUsages", func() {
+		Context("without a path", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/credentials/usages", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsages),
+					),
+				)
+			})
+
+			It("returns the team's usages", func() {
+				usages, err := team.CredentialUsages("", false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usages).To(Equal(expectedUsages))
+			})
+		})
+
+		Context("with a path", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/credentials/usages", "path=some-var"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsages),
+					),
+				)
+			})
+
+			It("returns the usages of the var", func() {
+				usages, err := team.CredentialUsages("some-var", false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usages).To(Equal(expectedUsages))
+			})
+		})
+
+		Context("when resolving the vars", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/credentials/usages", "resolve="),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsages),
+					),
+				)
+			})
+
+			It("asks for the vars to be resolved", func() {
+				usages, err := team.CredentialUsages("", true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usages).To(Equal(expectedUsages))
+			})
+		})
+	})
+
+	Describe("ListAllCredentialUsages", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/credentials/usages", "path=some-var&resolve="),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsages),
+				),
+			)
+		})
+
+		It("returns the usages from every team", func() {
+			usages, err := client.ListAllCredentialUsages("some-var", true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(Equal(expectedUsages))
+		})
+	})
+})
@@ -196,3 +196,55 @@ func (team *team) ClearTaskCache(pipelineRef atc.PipelineRef, jobName string, st
 		return ctcResponse.CachesRemoved, nil
 	}
 }
+
+func (team *team) JobCaches(pipelineRef atc.PipelineRef, jobName string) ([]atc.KeyedCache, bool, error) {
+	params := rata.Params{
+		"team_name":     team.Name(),
+		"pipeline_name": pipelineRef.Name,
+		"job_name":      jobName,
+	}
+
+	var caches []atc.KeyedCache
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListJobCaches,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &caches,
+	})
+	switch err.(type) {
+	case nil:
+		return caches, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (team *team) ClearJobCaches(pipelineRef atc.PipelineRef, jobName string, key string) (int64, error) {
+	params := rata.Params{
+		"team_name":     team.Name(),
+		"pipeline_name": pipelineRef.Name,
+		"job_name":      jobName,
+	}
+
+	queryParams := url.Values{}
+	if len(key) > 0 {
+		queryParams.Add(atc.ClearJobCachesQueryKey, key)
+	}
+
+	var response atc.ClearTaskCacheResponse
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ClearJobCaches,
+		Params:      params,
+		Query:       merge(queryParams, pipelineRef.QueryParams()),
+	}, &internal.Response{
+		Result: &response,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return response.CachesRemoved, nil
+}
@@ -630,4 +630,87 @@ var _ = Describe("ATC Handler Jobs", func() {
 		})
 	})
 
+	Describe("JobCaches", func() {
+		var pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		Context("when the job exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/caches", "vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.KeyedCache{
+							{ID: 1, Path: ".m2", Key: "maven-abc", Size: 1024},
+						}),
+					),
+				)
+			})
+
+			It("returns the job's caches", func() {
+				caches, found, err := team.JobCaches(pipelineRef, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(caches).To(Equal([]atc.KeyedCache{
+					{ID: 1, Path: ".m2", Key: "maven-abc", Size: 1024},
+				}))
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/caches"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.JobCaches(pipelineRef, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ClearJobCaches", func() {
+		var (
+			expectedQuery string
+			key           string
+			pipelineRef   = atc.PipelineRef{Name: "mypipeline"}
+		)
+
+		BeforeEach(func() {
+			expectedQuery = ""
+			key = ""
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/caches", expectedQuery),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ClearTaskCacheResponse{CachesRemoved: 2}),
+				),
+			)
+		})
+
+		It("returns the number of caches removed", func() {
+			numDeleted, err := team.ClearJobCaches(pipelineRef, "myjob", key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numDeleted).To(Equal(int64(2)))
+		})
+
+		Context("when a key is given", func() {
+			BeforeEach(func() {
+				key = "maven-abc"
+				expectedQuery = "key=maven-abc"
+			})
+
+			It("passes the key along", func() {
+				numDeleted, err := team.ClearJobCaches(pipelineRef, "myjob", key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numDeleted).To(Equal(int64(2)))
+			})
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/concourse/concourse/atc"
@@ -58,8 +59,38 @@ func (team *team) EnableResourceVersion(pipelineRef atc.PipelineRef, resourceNam
 	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.EnableResourceVersion)
 }
 
-func (team *team) PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error) {
-	return team.sendResourceVersion(pipelineRef, resourceName, resourceVersionID, atc.PinResourceVersion)
+func (team *team) PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, pin atc.PinVersionRequestBody) (bool, error) {
+	params := rata.Params{
+		"pipeline_name":              pipelineRef.Name,
+		"resource_name":              resourceName,
+		"resource_config_version_id": strconv.Itoa(resourceVersionID),
+		"team_name":                  team.Name(),
+	}
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(pin)
+	if err != nil {
+		return false, fmt.Errorf("Unable to marshal pin: %s", err)
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.PinResourceVersion,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+		Params: params,
+		Query:  pipelineRef.QueryParams(),
+		Body:   buffer,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
 }
 
 func (team *team) UnpinResource(pipelineRef atc.PipelineRef, resourceName string) (bool, error) {
@@ -123,6 +154,39 @@ func (team *team) SetPinComment(pipelineRef atc.PipelineRef, resourceName string
 
 }
 
+func (team *team) PromoteResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, jobName string) (atc.Build, bool, error) {
+	params := rata.Params{
+		"pipeline_name":              pipelineRef.Name,
+		"resource_name":              resourceName,
+		"resource_config_version_id": strconv.Itoa(resourceVersionID),
+		"team_name":                  team.Name(),
+	}
+
+	query := pipelineRef.QueryParams()
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("job", jobName)
+
+	var build atc.Build
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.PromoteResourceVersion,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	switch err.(type) {
+	case nil:
+		return build, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.Build{}, false, nil
+	default:
+		return atc.Build{}, false, err
+	}
+}
+
 func (team *team) sendResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, resourceVersionReq string) (bool, error) {
 	params := rata.Params{
 		"pipeline_name":              pipelineRef.Name,
@@ -359,8 +359,14 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			expectedURL       = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/versions/%s/pin", pipelineName, resourceName, strconv.Itoa(resourceVersionID))
 			expectedQuery     = "vars.branch=%22master%22"
 			pipelineRef       = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
+			pin               atc.PinVersionRequestBody
 		)
 
+		BeforeEach(func() {
+			pin = atc.PinVersionRequestBody{}
+			expectedBody = []byte("{}\n")
+		})
+
 		JustBeforeEach(func() {
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
@@ -378,7 +384,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the pin resource and returns no error", func() {
 				Expect(func() {
-					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID, pin)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(pinned).To(BeTrue())
 				}).To(Change(func() int {
@@ -387,6 +393,20 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			})
 		})
 
+		Context("when the pin has a comment and an expiry", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+				pin = atc.PinVersionRequestBody{PinComment: "hotfix", ExpiresIn: "4h0m0s"}
+				expectedBody = []byte(`{"pin_comment":"hotfix","expires_in":"4h0m0s"}` + "\n")
+			})
+
+			It("sends them in the request body", func() {
+				pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID, pin)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pinned).To(BeTrue())
+			})
+		})
+
 		Context("when the resource does not exist", func() {
 			BeforeEach(func() {
 				expectedStatus = http.StatusNotFound
@@ -394,7 +414,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the pin resource and returns an error", func() {
 				Expect(func() {
-					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID, pin)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(pinned).To(BeFalse())
 				}).To(Change(func() int {
@@ -410,7 +430,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the pin resource and returns an error", func() {
 				Expect(func() {
-					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID)
+					pinned, err := team.PinResourceVersion(pipelineRef, resourceName, resourceVersionID, pin)
 					Expect(err).To(HaveOccurred())
 					Expect(pinned).To(BeFalse())
 				}).To(Change(func() int {
@@ -420,6 +440,74 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 		})
 	})
 
+	Describe("PromoteResourceVersion", func() {
+		var (
+			expectedStatus    int
+			expectedBuild     atc.Build
+			pipelineName      = "banana"
+			resourceName      = "myresource"
+			resourceVersionID = 42
+			expectedURL       = fmt.Sprintf("/api/v1/teams/some-team/pipelines/%s/resources/%s/versions/%s/promote", pipelineName, resourceName, strconv.Itoa(resourceVersionID))
+			expectedQuery     = "job=some-job&vars.branch=%22master%22"
+			pipelineRef       = atc.PipelineRef{Name: pipelineName, InstanceVars: atc.InstanceVars{"branch": "master"}}
+		)
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:      123,
+				Name:    "7",
+				Status:  atc.StatusSucceeded,
+				JobName: "some-job",
+			}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, expectedQuery),
+					ghttp.RespondWithJSONEncoded(expectedStatus, expectedBuild),
+				),
+			)
+		})
+
+		Context("when the version is promoted", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("returns the promotion build", func() {
+				build, found, err := team.PromoteResourceVersion(pipelineRef, resourceName, resourceVersionID, "some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build).To(Equal(expectedBuild))
+			})
+		})
+
+		Context("when the resource, version or job does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.PromoteResourceVersion(pipelineRef, resourceName, resourceVersionID, "some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the promote call fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				_, found, err := team.PromoteResourceVersion(pipelineRef, resourceName, resourceVersionID, "some-job")
+				Expect(err).To(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("UnpinResource", func() {
 		var (
 			expectedStatus int
@@ -2,6 +2,7 @@ package concourse
 
 import (
 	"io"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -48,6 +49,8 @@ type Team interface {
 	UnpauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
 
 	ClearTaskCache(pipelineRef atc.PipelineRef, jobName string, stepName string, cachePath string) (int64, error)
+	JobCaches(pipelineRef atc.PipelineRef, jobName string) ([]atc.KeyedCache, bool, error)
+	ClearJobCaches(pipelineRef atc.PipelineRef, jobName string, key string) (int64, error)
 
 	Resource(pipelineRef atc.PipelineRef, resourceName string) (atc.Resource, bool, error)
 	ListResources(pipelineRef atc.PipelineRef) ([]atc.Resource, error)
@@ -55,13 +58,16 @@ type Team interface {
 	ResourceVersions(pipelineRef atc.PipelineRef, resourceName string, page Page, filter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
 	CheckResource(pipelineRef atc.PipelineRef, resourceName string, version atc.Version) (atc.Build, bool, error)
 	CheckResourceType(pipelineRef atc.PipelineRef, resourceTypeName string, version atc.Version) (atc.Build, bool, error)
+	ResourceChecks(pipelineRef atc.PipelineRef, resourceName string) ([]atc.ResourceCheck, bool, error)
 	DisableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
 	EnableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
 
-	PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
+	PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, pin atc.PinVersionRequestBody) (bool, error)
 	UnpinResource(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
 	SetPinComment(pipelineRef atc.PipelineRef, resourceName string, comment string) (bool, error)
 
+	PromoteResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int, jobName string) (atc.Build, bool, error)
+
 	BuildsWithVersionAsInput(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
 	BuildsWithVersionAsOutput(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
 
@@ -74,6 +80,13 @@ type Team interface {
 
 	CreateArtifact(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	GetArtifact(int) (io.ReadCloser, error)
+
+	CreateWorkerEnrollmentToken(ttl time.Duration) (atc.WorkerEnrollmentToken, error)
+	ListWorkerKeys() ([]atc.WorkerKey, error)
+	RevokeWorkerKey(id int) (bool, error)
+
+	CredentialUsages(path string, resolve bool) ([]atc.CredentialUsage, error)
+	ClearCredentialCache(path string) error
 }
 
 type team struct {
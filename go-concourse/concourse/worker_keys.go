@@ -0,0 +1,70 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) CreateWorkerEnrollmentToken(ttl time.Duration) (atc.WorkerEnrollmentToken, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	queryParams := url.Values{}
+	if ttl != 0 {
+		queryParams.Add("ttl", ttl.String())
+	}
+
+	var token atc.WorkerEnrollmentToken
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.CreateWorkerEnrollmentToken,
+		Params:      params,
+		Query:       queryParams,
+	}, &internal.Response{
+		Result: &token,
+	})
+
+	return token, err
+}
+
+func (team *team) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	var keys []atc.WorkerKey
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListWorkerKeys,
+		Params:      params,
+	}, &internal.Response{
+		Result: &keys,
+	})
+
+	return keys, err
+}
+
+func (team *team) RevokeWorkerKey(id int) (bool, error) {
+	params := rata.Params{
+		"team_name":     team.Name(),
+		"worker_key_id": strconv.Itoa(id),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.RevokeWorkerKey,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,103 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Worker Keys", func() {
+	Describe("CreateWorkerEnrollmentToken", func() {
+		var expectedToken atc.WorkerEnrollmentToken
+
+		BeforeEach(func() {
+			expectedToken = atc.WorkerEnrollmentToken{
+				Token:     "some-token",
+				TeamName:  "some-team",
+				ExpiresAt: 1613140000,
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/worker-enrollment-tokens", "ttl=10m0s"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedToken),
+				),
+			)
+		})
+
+		It("returns the token", func() {
+			token, err := team.CreateWorkerEnrollmentToken(10 * time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal(expectedToken))
+		})
+	})
+
+	Describe("ListWorkerKeys", func() {
+		var expectedKeys []atc.WorkerKey
+
+		BeforeEach(func() {
+			expectedKeys = []atc.WorkerKey{
+				{
+					ID:          1,
+					TeamName:    "some-team",
+					PublicKey:   "ssh-ed25519 AAAA",
+					Fingerprint: "SHA256:some-fingerprint",
+					CreatedAt:   1000,
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/worker-keys"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedKeys),
+				),
+			)
+		})
+
+		It("returns the team's keys", func() {
+			keys, err := team.ListWorkerKeys()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal(expectedKeys))
+		})
+	})
+
+	Describe("RevokeWorkerKey", func() {
+		var status int
+
+		BeforeEach(func() {
+			status = http.StatusNoContent
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/worker-keys/42"),
+					ghttp.RespondWith(status, nil),
+				),
+			)
+		})
+
+		It("revokes the key", func() {
+			revoked, err := team.RevokeWorkerKey(42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		Context("when the key does not exist", func() {
+			BeforeEach(func() {
+				status = http.StatusNotFound
+			})
+
+			It("returns false", func() {
+				revoked, err := team.RevokeWorkerKey(42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revoked).To(BeFalse())
+			})
+		})
+	})
+})
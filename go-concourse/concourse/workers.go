@@ -30,6 +30,16 @@ func (client *client) ListWorkers() ([]atc.Worker, error) {
 	return workers, err
 }
 
+func (client *client) WorkerDemand() (atc.WorkerDemand, error) {
+	var demand atc.WorkerDemand
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetWorkerDemand,
+	}, &internal.Response{
+		Result: &demand,
+	})
+	return demand, err
+}
+
 func (client *client) SaveWorker(worker atc.Worker, ttl *time.Duration) (*atc.Worker, error) {
 	buffer := &bytes.Buffer{}
 	err := json.NewEncoder(buffer).Encode(worker)
@@ -92,3 +102,42 @@ func (client *client) LandWorker(workerName string) error {
 
 	return err
 }
+
+func (client *client) EvictWorker(workerName string) error {
+	params := rata.Params{"worker_name": workerName}
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.EvictWorker,
+		Params:      params,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, nil)
+
+	return err
+}
+
+func (client *client) QuarantineWorker(workerName string) error {
+	params := rata.Params{"worker_name": workerName}
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.QuarantineWorker,
+		Params:      params,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, nil)
+
+	return err
+}
+
+func (client *client) ReleaseWorker(workerName string) error {
+	params := rata.Params{"worker_name": workerName}
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ReleaseWorker,
+		Params:      params,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, nil)
+
+	return err
+}
@@ -46,6 +46,41 @@ var _ = Describe("ATC Handler Workers", func() {
 		})
 	})
 
+	Describe("WorkerDemand", func() {
+		var expectedDemand atc.WorkerDemand
+
+		BeforeEach(func() {
+			expectedURL := "/api/v1/workers/demand"
+
+			expectedDemand = atc.WorkerDemand{
+				Pools: []atc.WorkerPoolDemand{
+					{
+						WorkerPool: atc.WorkerPool{
+							Platform: "linux",
+							Tags:     []string{},
+						},
+						Workers:      3,
+						WaitingSteps: 2,
+					},
+				},
+				QueuedBuilds: map[string]int{"main": 1},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDemand),
+				),
+			)
+		})
+
+		It("returns the worker demand", func() {
+			demand, err := client.WorkerDemand()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(demand).To(Equal(expectedDemand))
+		})
+	})
+
 	Describe("SaveWorker", func() {
 		var worker atc.Worker
 		BeforeEach(func() {
@@ -160,4 +195,106 @@ var _ = Describe("ATC Handler Workers", func() {
 			})
 		})
 	})
+
+	Describe("EvictWorker", func() {
+		Context("when succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/evict"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("evicts the worker", func() {
+				err := client.EvictWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("failing to evict worker", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/evict"),
+						ghttp.RespondWith(http.StatusInternalServerError, nil),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				err := client.EvictWorker("some-worker")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("QuarantineWorker", func() {
+		Context("when succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/quarantine"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("quarantines the worker", func() {
+				err := client.QuarantineWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("failing to quarantine worker", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/quarantine"),
+						ghttp.RespondWith(http.StatusInternalServerError, nil),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				err := client.QuarantineWorker("some-worker")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ReleaseWorker", func() {
+		Context("when succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/release"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("releases the worker", func() {
+				err := client.ReleaseWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("failing to release worker", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/release"),
+						ghttp.RespondWith(http.StatusInternalServerError, nil),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				err := client.ReleaseWorker("some-worker")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -55,9 +55,17 @@ type Client struct {
 
 	PrivateKey *rsa.PrivateKey
 
+	// If set, the worker's key is enrolled for the token's team when the SSH
+	// gateway doesn't authorize it yet.
+	EnrollmentToken string
+
 	Worker atc.Worker
 }
 
+// EnrollmentUserPrefix prefixes the enrollment token in the SSH user name
+// when connecting with a key which may need to be enrolled.
+const EnrollmentUserPrefix = "enroll:"
+
 // RegisterOptions contains required configuration for the registration.
 type RegisterOptions struct {
 	// The local Garden network and address to forward through the SSH gateway.
@@ -212,6 +220,24 @@ func (client *Client) Retire(ctx context.Context) error {
 	return client.run(ctx, sshClient, "retire-worker", os.Stdout)
 }
 
+// Evict invokes the 'evict-worker' command, which transitions the worker to
+// 'evicting' so that no more work is placed on it, without waiting for it to
+// drain. This is meant for workers which are about to be terminated, e.g. upon
+// receiving a spot instance termination notice.
+func (client *Client) Evict(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx)
+
+	sshClient, _, err := client.dial(ctx, 0)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return err
+	}
+
+	defer sshClient.Close()
+
+	return client.run(ctx, sshClient, "evict-worker", os.Stdout)
+}
+
 // Delete invokes the 'delete-worker' command, which will immediately
 // unregister the worker without draining, causing any existing registrations
 // to exit.
@@ -343,10 +369,15 @@ func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh
 		return nil, nil, fmt.Errorf("private key not provided")
 	}
 
+	user := "beacon" // doesn't matter
+	if client.EnrollmentToken != "" {
+		user = EnrollmentUserPrefix + client.EnrollmentToken
+	}
+
 	clientConfig := &ssh.ClientConfig{
 		Config: atc.DefaultSSHConfig(),
 
-		User: "beacon", // doesn't matter
+		User: user,
 
 		HostKeyCallback: client.checkHostKey,
 
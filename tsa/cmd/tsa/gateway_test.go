@@ -0,0 +1,198 @@
+package main_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	gclient "code.cloudfoundry.org/garden/client"
+	gconn "code.cloudfoundry.org/garden/client/connection"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Worker gateway", func() {
+	var (
+		certSubject pkix.Name
+		trustedCA   bool
+
+		gatewayClient *tsa.GatewayClient
+	)
+
+	BeforeEach(func() {
+		certSubject = pkix.Name{CommonName: "some-worker"}
+		trustedCA = true
+	})
+
+	JustBeforeEach(func() {
+		ca, caKey := gatewayCA, gatewayCAKey
+		if !trustedCA {
+			ca, caKey = generateCertificate(pkix.Name{CommonName: "some-other-ca"}, nil, nil)
+		}
+
+		cert, key := generateCertificate(certSubject, ca, caKey)
+
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(gatewayCA)
+
+		gatewayClient = &tsa.GatewayClient{
+			Hosts: []string{fmt.Sprintf("127.0.0.1:%d", gatewayPort)},
+			TLSConfig: &tls.Config{
+				Certificates: []tls.Certificate{{
+					Certificate: [][]byte{cert.Raw},
+					PrivateKey:  key,
+				}},
+				RootCAs: rootCAs,
+			},
+			Worker: tsaClient.Worker,
+		}
+	})
+
+	Describe("Register", func() {
+		var (
+			registered  chan atc.Worker
+			cancel      context.CancelFunc
+			registerErr chan error
+		)
+
+		BeforeEach(func() {
+			registered = make(chan atc.Worker, 100)
+
+			atcServer.RouteToHandler("POST", "/api/v1/workers", func(w http.ResponseWriter, r *http.Request) {
+				var worker atc.Worker
+				Expect(json.NewDecoder(r.Body).Decode(&worker)).To(Succeed())
+				registered <- worker
+			})
+
+			atcServer.RouteToHandler("PUT", "/api/v1/workers/some-worker/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+				var worker atc.Worker
+				Expect(json.NewDecoder(r.Body).Decode(&worker)).To(Succeed())
+				json.NewEncoder(w).Encode(worker)
+			})
+
+			baggageclaimServer.RouteToHandler("GET", "/volumes", ghttp.RespondWithJSONEncoded(200, []string{}))
+		})
+
+		JustBeforeEach(func() {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+
+			registerErr = make(chan error, 1)
+			go func() {
+				registerErr <- gatewayClient.Register(lagerctx.NewContext(ctx, lagertest.NewTestLogger("test")), tsa.RegisterOptions{
+					LocalGardenNetwork:       "tcp",
+					LocalGardenAddr:          gardenAddr,
+					LocalBaggageclaimNetwork: "tcp",
+					LocalBaggageclaimAddr:    baggageclaimServer.Addr(),
+
+					// don't wait for the test's garden connection to be closed
+					ConnectionDrainTimeout: time.Second,
+				})
+			}()
+		})
+
+		AfterEach(func() {
+			cancel()
+			Eventually(registerErr, 10*time.Second).Should(Receive())
+		})
+
+		It("registers the worker with forwarded addresses tunneled to it", func() {
+			var worker atc.Worker
+			Eventually(registered, 10*time.Second).Should(Receive(&worker))
+
+			Expect(worker.Name).To(Equal("some-worker"))
+			Expect(worker.GardenAddr).To(HavePrefix(forwardHost + ":"))
+
+			gardenClient := gclient.New(gconn.New("tcp", worker.GardenAddr))
+			Expect(gardenClient.Ping()).To(Succeed())
+
+			// the heartbeater lists volumes through the baggageclaim tunnel
+			Eventually(baggageclaimServer.ReceivedRequests, 10*time.Second).ShouldNot(BeEmpty())
+		})
+
+		Context("when the certificate is issued for another worker", func() {
+			BeforeEach(func() {
+				certSubject = pkix.Name{CommonName: "some-other-worker"}
+			})
+
+			It("fails without registering", func() {
+				Eventually(registerErr, 10*time.Second).Should(Receive(MatchError(ContainSubstring("certificate is issued for worker some-other-worker"))))
+				Expect(registered).ToNot(Receive())
+
+				// received by the assertion above
+				registerErr <- nil
+			})
+		})
+	})
+
+	Describe("Land", func() {
+		var landErr error
+
+		JustBeforeEach(func() {
+			landErr = gatewayClient.Land(context.TODO())
+		})
+
+		BeforeEach(func() {
+			atcServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v1/workers/some-worker/land"),
+				ghttp.RespondWith(200, nil, nil),
+			))
+		})
+
+		It("sends a request to the ATC to land the worker", func() {
+			Expect(landErr).ToNot(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the certificate is restricted to another team", func() {
+			BeforeEach(func() {
+				certSubject = pkix.Name{
+					CommonName:         "some-worker",
+					OrganizationalUnit: []string{"team:some-team"},
+				}
+			})
+
+			It("fails", func() {
+				Expect(landErr).To(MatchError(ContainSubstring("authorized for team some-team, but worker is global")))
+				Expect(atcServer.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+
+		Context("when the certificate is not issued by the configured CA", func() {
+			BeforeEach(func() {
+				trustedCA = false
+			})
+
+			It("fails", func() {
+				Expect(landErr).To(HaveOccurred())
+				Expect(atcServer.ReceivedRequests()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ReportVolumes", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v1/volumes/report", "worker_name=some-worker"),
+				ghttp.VerifyJSONRepresenting([]string{"some-handle", "some-other-handle"}),
+				ghttp.RespondWith(204, nil, nil),
+			))
+		})
+
+		It("reports the handles to the ATC", func() {
+			err := gatewayClient.ReportVolumes(context.TODO(), []string{"some-handle", "some-other-handle"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
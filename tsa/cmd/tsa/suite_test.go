@@ -1,12 +1,18 @@
 package main_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
 	"os/exec"
 	"path/filepath"
 	"strconv"
@@ -80,6 +86,11 @@ var (
 	otherTeamKeyFile    string
 	otherTeamPubKeyFile string
 
+	gatewayPort       int
+	gatewayCA         *x509.Certificate
+	gatewayCAKey      *ecdsa.PrivateKey
+	gatewayCACertFile string
+
 	tsaRunner *ginkgomon.Runner
 	tsaClient *tsa.Client
 )
@@ -87,6 +98,7 @@ var (
 var _ = BeforeEach(func() {
 	tsaPort = 9800 + GinkgoParallelNode()
 	tsaDebugPort = 9900 + GinkgoParallelNode()
+	gatewayPort = 9700 + GinkgoParallelNode()
 
 	gardenPort := 9001 + GinkgoParallelNode()
 	gardenAddr = fmt.Sprintf("127.0.0.1:%d", gardenPort)
@@ -111,6 +123,9 @@ var _ = BeforeEach(func() {
 	atcServer = ghttp.NewServer()
 	authServer = ghttp.NewServer()
 
+	// no worker keys are enrolled in the database unless a test says otherwise
+	atcServer.RouteToHandler("GET", "/api/v1/worker-keys/authorized", ghttp.RespondWith(http.StatusNotFound, nil))
+
 	authServer.AppendHandlers(ghttp.CombineHandlers(
 		ghttp.VerifyRequest("POST", "/token"),
 		ghttp.VerifyBasicAuth("some-client", "some-client-secret"),
@@ -147,6 +162,11 @@ var _ = BeforeEach(func() {
 	forwardHost, err = localip.LocalIP()
 	Expect(err).NotTo(HaveOccurred())
 
+	gatewayCA, gatewayCAKey = generateCertificate(pkix.Name{CommonName: "some-ca"}, nil, nil)
+	gatewayCACertFile, _ = writeCertificate(gatewayCA, gatewayCAKey)
+
+	gatewayCertFile, gatewayKeyFile := writeCertificate(generateCertificate(pkix.Name{CommonName: "127.0.0.1"}, gatewayCA, gatewayCAKey))
+
 	tsaCommand := exec.Command(
 		tsaPath,
 		"--bind-port", strconv.Itoa(tsaPort),
@@ -162,6 +182,11 @@ var _ = BeforeEach(func() {
 		"--atc-url", atcServer.URL(),
 		"--garden-request-timeout", gardenRequestTimeout.String(),
 		"--heartbeat-interval", heartbeatInterval.String(),
+		"--worker-key-check-interval", heartbeatInterval.String(),
+		"--gateway-bind-port", strconv.Itoa(gatewayPort),
+		"--gateway-tls-cert", gatewayCertFile,
+		"--gateway-tls-key", gatewayKeyFile,
+		"--gateway-client-ca-cert", gatewayCACertFile,
 	)
 
 	tsaRunner = ginkgomon.New(ginkgomon.Config{
@@ -228,3 +253,54 @@ func generateSSHKeypair() (string, string, *rsa.PrivateKey, ssh.PublicKey) {
 
 	return privateKeyPath, publicKeyPath, privateKey, publicKeyRsa
 }
+
+// generateCertificate generates a certificate signed by the given CA, or a
+// self-signed CA certificate if none is given.
+func generateCertificate(subject pkix.Name, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	if ca == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		ca = template
+		caKey = key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+
+	return cert, key
+}
+
+func writeCertificate(cert *x509.Certificate, key *ecdsa.PrivateKey) (string, string) {
+	path, err := ioutil.TempDir("", "tsa-cert")
+	Expect(err).NotTo(HaveOccurred())
+
+	certPath := filepath.Join(path, "cert.pem")
+	keyPath := filepath.Join(path, "key.pem")
+
+	err = ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600)
+	Expect(err).NotTo(HaveOccurred())
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	Expect(err).NotTo(HaveOccurred())
+
+	return certPath, keyPath
+}
@@ -0,0 +1,249 @@
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ = Describe("Worker keys enrolled in the database", func() {
+	var (
+		enrolledKey       ssh.PublicKey
+		enrolledKeyL      sync.Mutex
+		enrolledKeyTeam   string
+		enrolledKeyActive bool
+		enrolledKeyUsed   chan struct{}
+	)
+
+	BeforeEach(func() {
+		_, _, tsaClient.PrivateKey, enrolledKey = generateSSHKeypair()
+		tsaClient.Worker.Team = "some-team"
+
+		enrolledKeyTeam = "some-team"
+		enrolledKeyActive = true
+		enrolledKeyUsed = make(chan struct{}, 10)
+
+		atcServer.RouteToHandler("GET", "/api/v1/worker-keys/authorized", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("fingerprint")).To(Equal(ssh.FingerprintSHA256(enrolledKey)))
+
+			enrolledKeyL.Lock()
+			defer enrolledKeyL.Unlock()
+
+			if !enrolledKeyActive {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			json.NewEncoder(w).Encode(atc.WorkerKey{
+				ID:          1,
+				TeamName:    enrolledKeyTeam,
+				Fingerprint: ssh.FingerprintSHA256(enrolledKey),
+			})
+		})
+
+		atcServer.RouteToHandler("PUT", "/api/v1/worker-keys/used", func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Query().Get("fingerprint")).To(Equal(ssh.FingerprintSHA256(enrolledKey)))
+			enrolledKeyUsed <- struct{}{}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+
+	Describe("landing", func() {
+		var landErr error
+
+		BeforeEach(func() {
+			atcServer.RouteToHandler("PUT", "/api/v1/workers/some-worker/land", ghttp.RespondWith(http.StatusOK, nil))
+		})
+
+		JustBeforeEach(func() {
+			landErr = tsaClient.Land(context.TODO())
+		})
+
+		It("authorizes the key for its team", func() {
+			Expect(landErr).ToNot(HaveOccurred())
+		})
+
+		It("records that the key was used", func() {
+			Expect(enrolledKeyUsed).To(Receive())
+		})
+
+		Context("when the key is enrolled for some other team", func() {
+			BeforeEach(func() {
+				enrolledKeyTeam = "some-other-team"
+			})
+
+			It("fails", func() {
+				Expect(landErr).To(HaveOccurred())
+			})
+		})
+
+		Context("when the key has been revoked", func() {
+			BeforeEach(func() {
+				atcServer.RouteToHandler("GET", "/api/v1/worker-keys/authorized", ghttp.RespondWithJSONEncoded(http.StatusOK, atc.WorkerKey{
+					ID:          1,
+					TeamName:    "some-team",
+					Fingerprint: ssh.FingerprintSHA256(enrolledKey),
+					RevokedAt:   1234,
+				}))
+			})
+
+			It("returns *HandshakeError", func() {
+				Expect(landErr).To(BeAssignableToTypeOf(&tsa.HandshakeError{}))
+			})
+		})
+
+		Context("when the key is not enrolled", func() {
+			BeforeEach(func() {
+				enrolledKeyActive = false
+			})
+
+			It("returns *HandshakeError", func() {
+				Expect(landErr).To(BeAssignableToTypeOf(&tsa.HandshakeError{}))
+			})
+
+			It("does not look the key up again right away", func() {
+				Expect(tsaClient.Land(context.TODO())).To(BeAssignableToTypeOf(&tsa.HandshakeError{}))
+
+				lookups := 0
+				for _, request := range atcServer.ReceivedRequests() {
+					if request.URL.Path == "/api/v1/worker-keys/authorized" {
+						lookups++
+					}
+				}
+
+				Expect(lookups).To(Equal(1))
+			})
+
+			Context("when connecting with an enrollment token", func() {
+				BeforeEach(func() {
+					tsaClient.EnrollmentToken = "some-token"
+				})
+
+				AfterEach(func() {
+					tsaClient.EnrollmentToken = ""
+				})
+
+				Context("when the token is valid", func() {
+					BeforeEach(func() {
+						atcServer.RouteToHandler("POST", "/api/v1/worker-keys/enroll", func(w http.ResponseWriter, r *http.Request) {
+							var enrollment atc.WorkerKeyEnrollment
+							Expect(json.NewDecoder(r.Body).Decode(&enrollment)).To(Succeed())
+							Expect(enrollment.Token).To(Equal("some-token"))
+
+							key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(enrollment.PublicKey))
+							Expect(err).ToNot(HaveOccurred())
+							Expect(key.Marshal()).To(Equal(enrolledKey.Marshal()))
+
+							w.WriteHeader(http.StatusCreated)
+							json.NewEncoder(w).Encode(atc.WorkerKey{
+								ID:          1,
+								TeamName:    "some-team",
+								Fingerprint: ssh.FingerprintSHA256(key),
+							})
+						})
+					})
+
+					It("enrolls the key and authorizes it for the token's team", func() {
+						Expect(landErr).ToNot(HaveOccurred())
+						Eventually(tsaRunner.Buffer()).Should(gbytes.Say("enrolled"))
+					})
+
+					It("does not record the key as used", func() {
+						Expect(enrolledKeyUsed).ToNot(Receive())
+					})
+				})
+
+				Context("when the token is rejected", func() {
+					BeforeEach(func() {
+						atcServer.RouteToHandler("POST", "/api/v1/worker-keys/enroll", ghttp.RespondWith(http.StatusForbidden, nil))
+					})
+
+					It("closes the connection once the handshake completes", func() {
+						Expect(landErr).To(HaveOccurred())
+						Eventually(tsaRunner.Buffer()).Should(gbytes.Say("failed-to-enroll"))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("registering", func() {
+		var (
+			registered  chan struct{}
+			registerErr chan error
+			cancel      context.CancelFunc
+		)
+
+		BeforeEach(func() {
+			atcServer.RouteToHandler("POST", "/api/v1/workers", ghttp.RespondWith(http.StatusOK, nil))
+			atcServer.RouteToHandler("PUT", "/api/v1/workers/some-worker/heartbeat", func(w http.ResponseWriter, r *http.Request) {
+				var worker atc.Worker
+				Expect(json.NewDecoder(r.Body).Decode(&worker)).To(Succeed())
+				json.NewEncoder(w).Encode(worker)
+			})
+			baggageclaimServer.RouteToHandler("GET", "/volumes", ghttp.RespondWithJSONEncoded(200, []string{}))
+
+			registered = make(chan struct{})
+			registerErr = make(chan error, 1)
+
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+
+			go func() {
+				registerErr <- tsaClient.Register(lagerctx.NewContext(ctx, lagertest.NewTestLogger("test")), tsa.RegisterOptions{
+					LocalGardenNetwork:       "tcp",
+					LocalGardenAddr:          gardenAddr,
+					LocalBaggageclaimNetwork: "tcp",
+					LocalBaggageclaimAddr:    baggageclaimServer.Addr(),
+					RegisteredFunc: func() {
+						close(registered)
+					},
+				})
+				close(registerErr)
+			}()
+		})
+
+		AfterEach(func() {
+			cancel()
+			<-registerErr
+		})
+
+		It("disconnects the worker once its key is revoked", func() {
+			Eventually(registered).Should(BeClosed())
+			Consistently(registerErr, 2*heartbeatInterval).ShouldNot(Receive())
+
+			atcServer.RouteToHandler("GET", "/api/v1/worker-keys/authorized", ghttp.RespondWithJSONEncoded(http.StatusOK, atc.WorkerKey{
+				ID:          1,
+				TeamName:    "some-team",
+				Fingerprint: ssh.FingerprintSHA256(enrolledKey),
+				RevokedAt:   1234,
+			}))
+
+			Eventually(tsaRunner.Buffer(), 5*heartbeatInterval).Should(gbytes.Say("worker-key-revoked"))
+			Eventually(registerErr, 5*heartbeatInterval).Should(Receive())
+		})
+
+		It("disconnects the worker once its key is gone", func() {
+			Eventually(registered).Should(BeClosed())
+			Consistently(registerErr, 2*heartbeatInterval).ShouldNot(Receive())
+
+			enrolledKeyL.Lock()
+			enrolledKeyActive = false
+			enrolledKeyL.Unlock()
+
+			Eventually(tsaRunner.Buffer(), 5*heartbeatInterval).Should(gbytes.Say("worker-key-revoked"))
+			Eventually(registerErr, 5*heartbeatInterval).Should(Receive())
+		})
+	})
+})
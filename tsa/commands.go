@@ -5,6 +5,7 @@ const (
 
 	LandWorker   = "land-worker"
 	RetireWorker = "retire-worker"
+	EvictWorker  = "evict-worker"
 	DeleteWorker = "delete-worker"
 
 	ReportContainers      = "report-containers"
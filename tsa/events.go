@@ -3,6 +3,7 @@ package tsa
 import (
 	"encoding/json"
 	"io"
+	"sync"
 )
 
 type EventType string
@@ -10,28 +11,50 @@ type EventType string
 const (
 	EventTypeRegistered  EventType = "registered"
 	EventTypeHeartbeated EventType = "heartbeated"
+
+	// EventTypeTunnel is only sent by the worker gateway, asking the worker to
+	// open a tunnel for a connection accepted on one of its forwarded ports.
+	EventTypeTunnel EventType = "tunnel"
 )
 
 type Event struct {
 	Type EventType `json:"event"`
+
+	// Set for EventTypeTunnel: the forward the connection was accepted for,
+	// and the ID with which the worker should open the tunnel.
+	Forward string `json:"forward,omitempty"`
+	Tunnel  string `json:"tunnel,omitempty"`
 }
 
 type EventWriter struct {
-	enc *json.Encoder
+	enc  *json.Encoder
+	lock *sync.Mutex
 }
 
 func NewEventWriter(dest io.Writer) EventWriter {
 	return EventWriter{
-		enc: json.NewEncoder(dest),
+		enc:  json.NewEncoder(dest),
+		lock: new(sync.Mutex),
 	}
 }
 
 func (w EventWriter) Registered() error {
-	return w.enc.Encode(Event{Type: EventTypeRegistered})
+	return w.encode(Event{Type: EventTypeRegistered})
 }
 
 func (w EventWriter) Heartbeated() error {
-	return w.enc.Encode(Event{Type: EventTypeHeartbeated})
+	return w.encode(Event{Type: EventTypeHeartbeated})
+}
+
+func (w EventWriter) Tunnel(forward string, id string) error {
+	return w.encode(Event{Type: EventTypeTunnel, Forward: forward, Tunnel: id})
+}
+
+func (w EventWriter) encode(event Event) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.enc.Encode(event)
 }
 
 type EventReader struct {
@@ -0,0 +1,35 @@
+package tsa
+
+// The worker gateway is an alternative to the SSH gateway, serving the same
+// commands over HTTP/2 and identifying workers by their TLS client
+// certificate rather than by an authorized SSH key.
+//
+// A command is run by POSTing the worker's JSON to GatewayCommandPath followed
+// by the command name, e.g. /commands/land-worker. Handles reported by
+// report-containers and report-volumes are passed as 'handle' query
+// parameters. The command's output is streamed in the response body; if the
+// command fails after the response has started, the error is sent in the
+// GatewayErrorTrailer.
+//
+// Garden and baggageclaim traffic is tunneled over the same HTTP/2 connection
+// as the forward-worker command. Whenever a connection is accepted on one of
+// the worker's forwarded ports, a tunnel event is sent in the forward-worker
+// response, and the worker opens a stream to GatewayTunnelPath followed by
+// the event's tunnel ID. The request and response bodies of that stream carry
+// the connection's traffic in either direction.
+const (
+	GatewayCommandPath = "/commands/"
+	GatewayTunnelPath  = "/tunnels/"
+
+	GatewayErrorTrailer = "X-Concourse-Error"
+
+	GatewayGardenForward       = "garden"
+	GatewayBaggageclaimForward = "baggageclaim"
+)
+
+// GatewayTeamPrefix is the prefix of an organizational unit in a worker's
+// certificate subject restricting it to the workers of a team, e.g.
+// "team:main". Certificates without such an organizational unit may register
+// global workers and workers of any team, like keys given to the SSH gateway
+// via --authorized-keys.
+const GatewayTeamPrefix = "team:"
@@ -0,0 +1,422 @@
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"golang.org/x/net/http2"
+)
+
+// GatewayClient is used to communicate with a pool of remote worker gateways,
+// the HTTPS alternative to the SSH gateways. It provides the same operations
+// as Client.
+type GatewayClient struct {
+	// Gateway addresses, e.g. "web.example.com:2223".
+	Hosts []string
+
+	// Must contain the worker's client certificate and the CA to verify the
+	// gateway's certificate with.
+	TLSConfig *tls.Config
+
+	Worker atc.Worker
+}
+
+// GatewayError is returned when a command fails on the worker gateway.
+type GatewayError struct {
+	Command string
+	Message string
+}
+
+func (err *GatewayError) Error() string {
+	return fmt.Sprintf("%s failed: %s", err.Command, err.Message)
+}
+
+// Register invokes the 'forward-worker' command, and opens a tunnel to the
+// configured Garden/Baggageclaim addresses whenever the gateway asks for one.
+// All traffic goes through a single HTTP/2 connection so that tunnels reach
+// the gateway holding the registration.
+//
+// If the context is canceled, the command is canceled, which stops
+// heartbeating; the connection is kept open until the tunnels in flight have
+// closed. If a ConnectionDrainTimeout is configured, the connection will be
+// terminated after no data has gone to/from the gateway for the configured
+// duration.
+func (client *GatewayClient) Register(ctx context.Context, opts RegisterOptions) error {
+	logger := lagerctx.FromContext(ctx)
+
+	conn, host, err := client.dial(ctx, opts.ConnectionDrainTimeout)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	go client.keepAlive(ctx, conn)
+
+	// the command outlives the context only long enough to be canceled;
+	// tunnels must keep working while the gateway drains connections
+	cmdCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-cmdCtx.Done():
+		}
+	}()
+
+	resp, err := client.start(cmdCtx, conn, host, ForwardWorker, nil)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	tunnels := new(sync.WaitGroup)
+
+	events := NewEventReader(resp.Body)
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				logger.Error("failed-to-read-event", err)
+				return err
+			}
+
+			break
+		}
+
+		switch ev.Type {
+		case EventTypeRegistered:
+			if opts.RegisteredFunc != nil {
+				opts.RegisteredFunc()
+			}
+
+		case EventTypeHeartbeated:
+			if opts.HeartbeatedFunc != nil {
+				opts.HeartbeatedFunc()
+			}
+
+		case EventTypeTunnel:
+			network, addr := opts.LocalGardenNetwork, opts.LocalGardenAddr
+			if ev.Forward == GatewayBaggageclaimForward {
+				network, addr = opts.LocalBaggageclaimNetwork, opts.LocalBaggageclaimAddr
+			}
+
+			tunnels.Add(1)
+			go func(id string) {
+				defer tunnels.Done()
+				client.openTunnel(ctx, conn, host, id, network, addr)
+			}(ev.Tunnel)
+		}
+	}
+
+	if ctx.Err() != nil {
+		logger.Info("draining-tunnels")
+		tunnels.Wait()
+
+		if opts.ConnectionDrainTimeout != 0 && conn.idledOut() {
+			return ErrConnectionDrainTimeout
+		}
+
+		return nil
+	}
+
+	return commandError(ForwardWorker, resp)
+}
+
+// Land invokes the 'land-worker' command. See Client.Land.
+func (client *GatewayClient) Land(ctx context.Context) error {
+	return client.run(ctx, LandWorker, nil, os.Stdout)
+}
+
+// Retire invokes the 'retire-worker' command. See Client.Retire.
+func (client *GatewayClient) Retire(ctx context.Context) error {
+	return client.run(ctx, RetireWorker, nil, os.Stdout)
+}
+
+// Evict invokes the 'evict-worker' command. See Client.Evict.
+func (client *GatewayClient) Evict(ctx context.Context) error {
+	return client.run(ctx, EvictWorker, nil, os.Stdout)
+}
+
+// Delete invokes the 'delete-worker' command. See Client.Delete.
+func (client *GatewayClient) Delete(ctx context.Context) error {
+	return client.run(ctx, DeleteWorker, nil, os.Stdout)
+}
+
+// ContainersToDestroy invokes the 'sweep-containers' command, returning a list
+// of handles to be destroyed.
+func (client *GatewayClient) ContainersToDestroy(ctx context.Context) ([]string, error) {
+	return client.sweep(ctx, SweepContainers)
+}
+
+// ReportContainers invokes the 'report-containers' command, sending a list of
+// the worker's container handles to Concourse.
+func (client *GatewayClient) ReportContainers(ctx context.Context, handles []string) error {
+	return client.run(ctx, ReportContainers, url.Values{"handle": handles}, os.Stdout)
+}
+
+// VolumesToDestroy invokes the 'sweep-volumes' command, returning a list of
+// handles to be destroyed.
+func (client *GatewayClient) VolumesToDestroy(ctx context.Context) ([]string, error) {
+	return client.sweep(ctx, SweepVolumes)
+}
+
+// ReportVolumes invokes the 'report-volumes' command, sending a list of the
+// worker's volume handles to Concourse.
+func (client *GatewayClient) ReportVolumes(ctx context.Context, handles []string) error {
+	return client.run(ctx, ReportVolumes, url.Values{"handle": handles}, os.Stdout)
+}
+
+func (client *GatewayClient) sweep(ctx context.Context, command string) ([]string, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	out := new(bytes.Buffer)
+	err := client.run(ctx, command, nil, out)
+	if err != nil {
+		return nil, err
+	}
+
+	var handles []string
+	err = json.Unmarshal(out.Bytes(), &handles)
+	if err != nil {
+		logger.Error("failed-to-unmarshal-handles", err)
+		return nil, err
+	}
+
+	return handles, nil
+}
+
+func (client *GatewayClient) run(ctx context.Context, command string, query url.Values, stdout io.Writer) error {
+	logger := lagerctx.WithSession(ctx, "run", lager.Data{
+		"command": command,
+	})
+
+	conn, host, err := client.dial(ctx, 0)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	resp, err := client.start(ctx, conn, host, command, query)
+	if err != nil {
+		logger.Error("failed-to-start-command", err)
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	_, err = io.Copy(stdout, resp.Body)
+	if err != nil {
+		logger.Error("failed-to-read-output", err)
+		return err
+	}
+
+	err = commandError(command, resp)
+	if err != nil {
+		logger.Error("command-failed", err)
+		return err
+	}
+
+	logger.Debug("command-exited")
+	return nil
+}
+
+func (client *GatewayClient) start(ctx context.Context, conn *gatewayConn, host string, command string, query url.Values) (*http.Response, error) {
+	workerPayload, err := json.Marshal(client.Worker)
+	if err != nil {
+		return nil, err
+	}
+
+	commandURL := url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     GatewayCommandPath + command,
+		RawQuery: query.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, commandURL.String(), bytes.NewBuffer(workerPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := conn.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		message, _ := ioutil.ReadAll(resp.Body)
+
+		return nil, &GatewayError{
+			Command: command,
+			Message: fmt.Sprintf("%s: %s", resp.Status, bytes.TrimSpace(message)),
+		}
+	}
+
+	return resp, nil
+}
+
+// commandError returns the error reported by the gateway once the response
+// body has been read.
+func commandError(command string, resp *http.Response) error {
+	message := resp.Trailer.Get(GatewayErrorTrailer)
+	if message != "" {
+		return &GatewayError{Command: command, Message: message}
+	}
+
+	return nil
+}
+
+func (client *GatewayClient) openTunnel(ctx context.Context, conn *gatewayConn, host string, id string, network string, addr string) {
+	logger := lagerctx.WithSession(ctx, "tunnel", lager.Data{
+		"network": network,
+		"addr":    addr,
+	})
+
+	localConn, err := net.Dial(network, addr)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return
+	}
+
+	defer localConn.Close()
+
+	tunnelURL := url.URL{
+		Scheme: "https",
+		Host:   host,
+		Path:   GatewayTunnelPath + id,
+	}
+
+	req, err := http.NewRequest(http.MethodPost, tunnelURL.String(), localConn)
+	if err != nil {
+		logger.Error("failed-to-create-request", err)
+		return
+	}
+
+	resp, err := conn.RoundTrip(req)
+	if err != nil {
+		logger.Error("failed-to-open-tunnel", err)
+		return
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Info("tunnel-rejected", lager.Data{"status": resp.Status})
+		return
+	}
+
+	_, _ = io.Copy(localConn, resp.Body)
+}
+
+func (client *GatewayClient) keepAlive(ctx context.Context, conn *gatewayConn) {
+	logger := lagerctx.WithSession(ctx, "keepalive")
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			pingCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			err := conn.Ping(pingCtx)
+			cancel()
+
+			if err != nil && ctx.Err() == nil {
+				logger.Error("failed-to-ping", err)
+				conn.Close()
+				return
+			}
+
+		case <-ctx.Done():
+			logger.Debug("stopping")
+			return
+		}
+	}
+}
+
+func (client *GatewayClient) dial(ctx context.Context, idleTimeout time.Duration) (*gatewayConn, string, error) {
+	logger := lagerctx.WithSession(ctx, "dial")
+
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 15 * time.Second,
+	}
+
+	shuffled := make([]string, len(client.Hosts))
+	copy(shuffled, client.Hosts)
+	shuffle(sort.StringSlice(shuffled))
+
+	for _, host := range shuffled {
+		tcpConn, err := dialer.DialContext(ctx, "tcp", host)
+		if err != nil {
+			logger.Error("failed-to-connect-to-gateway", err)
+			continue
+		}
+
+		var conn net.Conn = tcpConn
+		if idleTimeout != 0 {
+			conn = &timeoutConn{
+				Conn:        tcpConn,
+				IdleTimeout: idleTimeout,
+			}
+		}
+
+		tlsConfig := client.TLSConfig.Clone()
+		tlsConfig.NextProtos = []string{http2.NextProtoTLS}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(host)
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		err = tlsConn.HandshakeContext(ctx)
+		if err != nil {
+			tcpConn.Close()
+			return nil, "", fmt.Errorf("failed to establish TLS connection with gateway: %w", err)
+		}
+
+		clientConn, err := new(http2.Transport).NewClientConn(tlsConn)
+		if err != nil {
+			tlsConn.Close()
+			return nil, "", err
+		}
+
+		return &gatewayConn{ClientConn: clientConn, conn: conn}, host, nil
+	}
+
+	return nil, "", ErrAllGatewaysUnreachable
+}
+
+type gatewayConn struct {
+	*http2.ClientConn
+
+	conn net.Conn
+}
+
+func (conn *gatewayConn) idledOut() bool {
+	timeoutConn, ok := conn.conn.(*timeoutConn)
+	return ok && timeoutConn.TimedOut()
+}
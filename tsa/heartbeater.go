@@ -281,10 +281,49 @@ func (heartbeater *Heartbeater) pingWorker(logger lager.Logger) (atc.Worker, boo
 
 	registration.ActiveContainers = len(containers)
 	registration.ActiveVolumes = len(volumes)
+	registration.ContainerMetrics = heartbeater.containerMetrics(logger, containers)
 
 	return registration, true
 }
 
+// containerMetrics sums up the metrics of the containers. Containers whose
+// metrics could not be retrieved are skipped; if none could be retrieved, no
+// metrics are reported at all.
+func (heartbeater *Heartbeater) containerMetrics(logger lager.Logger, containers []gclient.Container) *atc.WorkerContainerMetrics {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	handles := make([]string, len(containers))
+	for i, container := range containers {
+		handles[i] = container.Handle()
+	}
+
+	entries, err := heartbeater.gardenClient.BulkMetrics(handles)
+	if err != nil {
+		logger.Info("failed-to-fetch-container-metrics", lager.Data{"error": err.Error()})
+		return nil
+	}
+
+	var metrics *atc.WorkerContainerMetrics
+	for _, entry := range entries {
+		if entry.Err != nil {
+			continue
+		}
+
+		if metrics == nil {
+			metrics = &atc.WorkerContainerMetrics{}
+		}
+
+		metrics.MemoryBytes += entry.Metrics.MemoryStat.TotalUsageTowardLimit
+		metrics.CPUNanoseconds += entry.Metrics.CPUStat.Usage
+		metrics.NetworkRxBytes += entry.Metrics.NetworkStat.RxBytes
+		metrics.NetworkTxBytes += entry.Metrics.NetworkStat.TxBytes
+	}
+
+	return metrics
+}
+
 func (heartbeater *Heartbeater) ttl() time.Duration {
 	return heartbeater.interval * 2
 }
@@ -3,6 +3,7 @@ package tsa_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -271,6 +272,58 @@ var _ = Describe("Heartbeater", func() {
 					Eventually(clientWriter).Should(gbytes.Say(`{"event":"heartbeated"}`))
 				})
 			})
+
+			Context("when Garden reports container metrics", func() {
+				BeforeEach(func() {
+					fakeATC1.AppendHandlers(verifyRegister)
+
+					fakeGardenClient.BulkMetricsReturns(map[string]garden.ContainerMetricsEntry{
+						"container-1": {
+							Metrics: garden.Metrics{
+								MemoryStat:  garden.ContainerMemoryStat{TotalUsageTowardLimit: 100},
+								CPUStat:     garden.ContainerCPUStat{Usage: 1000},
+								NetworkStat: garden.ContainerNetworkStat{RxBytes: 1, TxBytes: 2},
+							},
+						},
+						"container-2": {
+							Metrics: garden.Metrics{
+								MemoryStat:  garden.ContainerMemoryStat{TotalUsageTowardLimit: 200},
+								CPUStat:     garden.ContainerCPUStat{Usage: 2000},
+								NetworkStat: garden.ContainerNetworkStat{RxBytes: 3, TxBytes: 4},
+							},
+						},
+						"container-3": {
+							Err: garden.NewError("no metrics"),
+						},
+					}, nil)
+				})
+
+				It("registers with the summed up container metrics", func() {
+					expectedWorker.ActiveContainers = 2
+					expectedWorker.ActiveVolumes = 3
+					expectedWorker.ContainerMetrics = &atc.WorkerContainerMetrics{
+						MemoryBytes:    300,
+						CPUNanoseconds: 3000,
+						NetworkRxBytes: 4,
+						NetworkTxBytes: 6,
+					}
+					Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+				})
+			})
+
+			Context("when Garden fails to report container metrics", func() {
+				BeforeEach(func() {
+					fakeATC1.AppendHandlers(verifyRegister)
+
+					fakeGardenClient.BulkMetricsReturns(nil, errors.New("not implemented"))
+				})
+
+				It("registers without container metrics", func() {
+					expectedWorker.ActiveContainers = 2
+					expectedWorker.ActiveVolumes = 3
+					Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+				})
+			})
 		})
 
 		Context("when heartbeat returns worker is landed", func() {
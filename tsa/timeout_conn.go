@@ -1,26 +1,47 @@
 package tsa
 
 import (
+	"errors"
 	"net"
+	"sync/atomic"
 	"time"
 )
 
 type timeoutConn struct {
 	net.Conn
 	IdleTimeout time.Duration
+
+	timedOut int32
 }
 
 func (c *timeoutConn) Write(p []byte) (int, error) {
 	c.updateDeadline()
-	return c.Conn.Write(p)
+	n, err := c.Conn.Write(p)
+	c.recordTimeout(err)
+	return n, err
 }
 
 func (c *timeoutConn) Read(b []byte) (int, error) {
 	c.updateDeadline()
-	return c.Conn.Read(b)
+	n, err := c.Conn.Read(b)
+	c.recordTimeout(err)
+	return n, err
+}
+
+// TimedOut returns whether the connection was idle for longer than the
+// timeout.
+func (c *timeoutConn) TimedOut() bool {
+	return atomic.LoadInt32(&c.timedOut) == 1
 }
 
 func (c *timeoutConn) updateDeadline() {
 	idleDeadline := time.Now().Add(c.IdleTimeout)
 	c.Conn.SetDeadline(idleDeadline)
 }
+
+func (c *timeoutConn) recordTimeout(err error) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		atomic.StoreInt32(&c.timedOut, 1)
+	}
+}
@@ -3,6 +3,9 @@ package tsacmd
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -44,6 +47,13 @@ type TSACommand struct {
 	TeamAuthorizedKeys     map[string]flag.AuthorizedKeys `long:"team-authorized-keys" value-name:"NAME:PATH" description:"Path to file containing keys to authorize, in SSH authorized_keys format (one public key per line)."`
 	TeamAuthorizedKeysFile flag.File                      `long:"team-authorized-keys-file" description:"Path to file containing a YAML array of teams and their authorized SSH keys, e.g. [{team:foo,ssh_keys:[key1,key2]}]."`
 
+	WorkerKeyCheckInterval time.Duration `long:"worker-key-check-interval" default:"30s" description:"Interval on which to check that the keys of workers authorized through the database haven't been revoked, disconnecting them otherwise."`
+
+	GatewayBindPort     uint16    `long:"gateway-bind-port" description:"Port on which to listen for workers connecting over HTTPS, as an alternative to SSH. Workers are identified by their TLS client certificate instead of an authorized key. Disabled unless set."`
+	GatewayTLSCert      flag.File `long:"gateway-tls-cert" description:"File containing the certificate to serve the worker gateway with."`
+	GatewayTLSKey       flag.File `long:"gateway-tls-key" description:"File containing the private key of the worker gateway certificate."`
+	GatewayClientCACert flag.File `long:"gateway-client-ca-cert" description:"File containing the CA certificate(s) that worker certificates must be issued by. A certificate's common name is the name of the worker it may register; an organizational unit of the form 'team:NAME' restricts it to workers of that team."`
+
 	ATCURLs []flag.URL `long:"atc-url" required:"true" description:"ATC API endpoints to which workers will be registered."`
 
 	ClientID     string   `long:"client-id" default:"concourse-worker" description:"Client used to fetch a token from the auth server. NOTE: if you change this value you will also need to change the --system-claim-value flag so the atc knows to allow requests from this client."`
@@ -114,11 +124,6 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 		lock:         &sync.RWMutex{},
 	}
 
-	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
-	if err != nil {
-		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
-	}
-
 	listenAddr := fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
 
 	authConfig := clientcredentials.Config{
@@ -134,16 +139,25 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 	httpClient := oauth2.NewClient(ctx, tokenSource)
 
 	server := &server{
-		logger:               logger,
-		heartbeatInterval:    cmd.HeartbeatInterval,
-		cprInterval:          1 * time.Second,
-		atcEndpointPicker:    atcEndpointPicker,
-		forwardHost:          cmd.PeerAddress,
-		config:               config,
-		httpClient:           httpClient,
-		sessionTeam:          sessionAuthTeam,
-		gardenRequestTimeout: cmd.GardenRequestTimeout,
+		logger:                 logger,
+		heartbeatInterval:      cmd.HeartbeatInterval,
+		cprInterval:            1 * time.Second,
+		workerKeyCheckInterval: cmd.WorkerKeyCheckInterval,
+		atcEndpointPicker:      atcEndpointPicker,
+		forwardHost:            cmd.PeerAddress,
+		httpClient:             httpClient,
+		sessionTeam:            sessionAuthTeam,
+		unknownWorkerKeys:      newUnknownWorkerKeys(),
+		gardenRequestTimeout:   cmd.GardenRequestTimeout,
+	}
+
+	config, err := cmd.configureSSHServer(logger, sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, server.unknownWorkerKeys, server.workerKeyAuthorizer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
 	}
+
+	server.config = config
+
 	// Starts a goroutine whose purpose is to listen to the
 	// SIGHUP syscall and reload configuration upon receiving the signal.
 	// For now it only reloads the TSACommand.AuthorizedKeys but
@@ -172,7 +186,7 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 			}
 
 			// Reconfigure the SSH server with the new keys
-			config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
+			config, err := cmd.configureSSHServer(logger, sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, server.unknownWorkerKeys, server.workerKeyAuthorizer)
 			if err != nil {
 				logger.Error("failed to configure SSH server: %s", err)
 				continue
@@ -182,7 +196,52 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 		}
 	}()
 
-	return serverRunner{logger, server, listenAddr}, nil
+	runner := serverRunner{
+		logger:     logger,
+		server:     server,
+		listenAddr: listenAddr,
+	}
+
+	if cmd.GatewayBindPort != 0 {
+		tlsConfig, err := cmd.gatewayTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure worker gateway: %s", err)
+		}
+
+		runner.gateway = newGateway(server)
+		runner.gatewayListenAddr = fmt.Sprintf("%s:%d", cmd.BindIP, cmd.GatewayBindPort)
+		runner.gatewayTLSConfig = tlsConfig
+	}
+
+	return runner, nil
+}
+
+func (cmd *TSACommand) gatewayTLSConfig() (*tls.Config, error) {
+	if cmd.GatewayTLSCert == "" || cmd.GatewayTLSKey == "" || cmd.GatewayClientCACert == "" {
+		return nil, errors.New("--gateway-tls-cert, --gateway-tls-key and --gateway-client-ca-cert must be specified")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cmd.GatewayTLSCert.Path(), cmd.GatewayTLSKey.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %s", err)
+	}
+
+	caCert, err := ioutil.ReadFile(cmd.GatewayClientCACert.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA certificate: %s", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("no certificates found in client CA certificate file")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
 }
 
 func (cmd *TSACommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
@@ -238,7 +297,14 @@ func (cmd *TSACommand) loadTeamAuthorizedKeys() ([]TeamAuthKeys, error) {
 	return teamKeys, nil
 }
 
-func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys) (*ssh.ServerConfig, error) {
+func (cmd *TSACommand) configureSSHServer(
+	logger lager.Logger,
+	sessionAuthTeam *sessionTeam,
+	authorizedKeys []ssh.PublicKey,
+	teamAuthorizedKeys []TeamAuthKeys,
+	unknownWorkerKeys *unknownWorkerKeys,
+	workerKeyAuthorizer func() *tsa.WorkerKeyAuthorizer,
+) (*ssh.ServerConfig, error) {
 	certChecker := &ssh.CertChecker{
 		IsUserAuthority: func(key ssh.PublicKey) bool {
 			return false
@@ -264,7 +330,7 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 				}
 			}
 
-			return nil, fmt.Errorf("unknown public key")
+			return authorizeWorkerKey(logger, unknownWorkerKeys, workerKeyAuthorizer(), conn, key)
 		},
 	}
 
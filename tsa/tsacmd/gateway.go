@@ -0,0 +1,391 @@
+package tsacmd
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/tsa"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// how long to wait for a worker to open a tunnel for an accepted connection
+const tunnelTimeout = 10 * time.Second
+
+// gateway serves the same commands as the SSH server over HTTP/2, as
+// described in tsa/gateway.go. The TLS configuration of the HTTP server is
+// responsible for verifying the worker's client certificate.
+type gateway struct {
+	server *server
+
+	tunnelsL sync.Mutex
+	tunnels  map[string]*pendingTunnel
+}
+
+func newGateway(server *server) *gateway {
+	return &gateway{
+		server:  server,
+		tunnels: map[string]*pendingTunnel{},
+	}
+}
+
+type pendingTunnel struct {
+	worker string
+	conn   net.Conn
+
+	claimed chan struct{}
+	closed  chan struct{}
+}
+
+func (gateway *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := gateway.server.logger.Session("gateway", lager.Data{
+		"remote": r.RemoteAddr,
+		"path":   r.URL.Path,
+	})
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		http.Error(w, "client certificate required", http.StatusUnauthorized)
+		return
+	}
+
+	// the certificate's common name is the only thing binding the connection
+	// to a worker, so certificates without one can't be used
+	cert := r.TLS.PeerCertificates[0]
+	if cert.Subject.CommonName == "" {
+		http.Error(w, "client certificate has no worker name", http.StatusForbidden)
+		return
+	}
+
+	state := certificateState(cert)
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, tsa.GatewayCommandPath):
+		gateway.handleCommand(logger, w, r, state)
+	case strings.HasPrefix(r.URL.Path, tsa.GatewayTunnelPath):
+		gateway.handleTunnel(logger, w, r, state)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func certificateState(cert *x509.Certificate) ConnState {
+	state := ConnState{
+		Worker: cert.Subject.CommonName,
+	}
+
+	for _, unit := range cert.Subject.OrganizationalUnit {
+		if strings.HasPrefix(unit, tsa.GatewayTeamPrefix) {
+			state.Team = strings.TrimPrefix(unit, tsa.GatewayTeamPrefix)
+		}
+	}
+
+	return state
+}
+
+func (gateway *gateway) handleCommand(logger lager.Logger, w http.ResponseWriter, r *http.Request, state ConnState) {
+	command := strings.TrimPrefix(r.URL.Path, tsa.GatewayCommandPath)
+
+	argv := []string{command}
+	switch command {
+	case tsa.ForwardWorker:
+		argv = append(argv,
+			"--garden", tsa.GatewayGardenForward,
+			"--baggageclaim", tsa.GatewayBaggageclaimForward,
+		)
+	case tsa.ReportContainers, tsa.ReportVolumes:
+		argv = append(argv, r.URL.Query()["handle"]...)
+	}
+
+	request, _, err := gateway.server.parseRequest(strings.Join(argv, " "))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid command: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	out := &flushWriter{w: w, f: flusher}
+
+	cmdLogger := logger.Session("command", lager.Data{
+		"command": command,
+		"worker":  state.Worker,
+	})
+
+	ctx := lagerctx.NewContext(r.Context(), cmdLogger)
+
+	if command == tsa.ForwardWorker {
+		forwards := make(chan ForwardedTCPIP, maxForwards)
+
+		for _, forward := range []string{tsa.GatewayGardenForward, tsa.GatewayBaggageclaimForward} {
+			forwarded, listener, err := gateway.listen(ctx, state.Worker, forward, tsa.NewEventWriter(out))
+			if err != nil {
+				cmdLogger.Error("failed-to-listen", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			// the forward-worker command closes the listeners when it is done
+			// with them, but not when it fails
+			defer listener.Close()
+
+			forwards <- forwarded
+		}
+
+		state.ForwardedTCPIPs = forwards
+	}
+
+	w.Header().Set("Trailer", tsa.GatewayErrorTrailer)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err = request.Handle(ctx, state, struct {
+		io.Reader
+		io.Writer
+	}{r.Body, out})
+	if err != nil {
+		cmdLogger.Error("exited-with-error", err)
+		w.Header().Set(tsa.GatewayErrorTrailer, strings.ReplaceAll(err.Error(), "\n", " "))
+		return
+	}
+
+	cmdLogger.Debug("exited-successfully")
+}
+
+func (gateway *gateway) listen(
+	ctx context.Context,
+	worker string,
+	forward string,
+	events tsa.EventWriter,
+) (ForwardedTCPIP, net.Listener, error) {
+	logger := lagerctx.FromContext(ctx).Session("forward", lager.Data{
+		"forward": forward,
+	})
+
+	listener, err := net.Listen("tcp", "0.0.0.0:0")
+	if err != nil {
+		return ForwardedTCPIP{}, nil, err
+	}
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	logger = logger.WithData(lager.Data{
+		"addr": listener.Addr().String(),
+	})
+
+	logger.Debug("listening")
+
+	drain := make(chan struct{})
+	wait := new(sync.WaitGroup)
+
+	wait.Add(1)
+	go gateway.forwardTunnels(lagerctx.NewContext(ctx, logger), drain, wait, listener, worker, forward, events)
+
+	return ForwardedTCPIP{
+		Logger: logger,
+
+		BindAddr:  forward,
+		BoundPort: uint32(port),
+
+		Drain: drain,
+
+		wg: wait,
+	}, listener, nil
+}
+
+func (gateway *gateway) forwardTunnels(
+	ctx context.Context,
+	drain <-chan struct{},
+	connsWg *sync.WaitGroup,
+	listener net.Listener,
+	worker string,
+	forward string,
+	events tsa.EventWriter,
+) {
+	defer connsWg.Done()
+
+	logger := lagerctx.FromContext(ctx)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	interrupted := false
+	go func() {
+		select {
+		case <-drain:
+			logger.Debug("draining")
+			interrupted = true
+			listener.Close()
+		case <-done:
+			logger.Debug("done")
+		}
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !interrupted {
+				logger.Debug("stopped-accepting", lager.Data{"error": err.Error()})
+			}
+
+			break
+		}
+
+		connsWg.Add(1)
+
+		go func() {
+			defer connsWg.Done()
+
+			gateway.requestTunnel(
+				lagerctx.NewContext(ctx, logger.Session("tunnel")),
+				conn,
+				worker,
+				forward,
+				events,
+			)
+		}()
+	}
+}
+
+// requestTunnel asks the worker to open a tunnel for the accepted connection
+// and waits for the tunnel to close.
+func (gateway *gateway) requestTunnel(ctx context.Context, conn net.Conn, worker string, forward string, events tsa.EventWriter) {
+	logger := lagerctx.FromContext(ctx)
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		logger.Error("failed-to-generate-tunnel-id", err)
+		conn.Close()
+		return
+	}
+
+	tunnel := &pendingTunnel{
+		worker:  worker,
+		conn:    conn,
+		claimed: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+
+	gateway.tunnelsL.Lock()
+	gateway.tunnels[id.String()] = tunnel
+	gateway.tunnelsL.Unlock()
+
+	err = events.Tunnel(forward, id.String())
+	if err != nil {
+		logger.Error("failed-to-request-tunnel", err)
+	}
+
+	select {
+	case <-tunnel.claimed:
+		<-tunnel.closed
+		return
+	case <-time.After(tunnelTimeout):
+		logger.Info("tunnel-never-opened")
+	case <-ctx.Done():
+	}
+
+	gateway.tunnelsL.Lock()
+	_, unclaimed := gateway.tunnels[id.String()]
+	delete(gateway.tunnels, id.String())
+	gateway.tunnelsL.Unlock()
+
+	if unclaimed {
+		conn.Close()
+		return
+	}
+
+	// claimed in the meantime
+	<-tunnel.closed
+}
+
+func (gateway *gateway) handleTunnel(logger lager.Logger, w http.ResponseWriter, r *http.Request, state ConnState) {
+	id := strings.TrimPrefix(r.URL.Path, tsa.GatewayTunnelPath)
+
+	gateway.tunnelsL.Lock()
+	tunnel, found := gateway.tunnels[id]
+	if found && tunnel.worker == state.Worker {
+		delete(gateway.tunnels, id)
+	}
+	gateway.tunnelsL.Unlock()
+
+	if !found || tunnel.worker != state.Worker {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	close(tunnel.claimed)
+	defer close(tunnel.closed)
+	defer tunnel.conn.Close()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	toWorker := make(chan struct{})
+	go func() {
+		defer close(toWorker)
+		_, _ = io.Copy(&flushWriter{w: w, f: flusher}, tunnel.conn)
+	}()
+
+	fromWorker := make(chan struct{})
+	go func() {
+		defer close(fromWorker)
+		_, _ = io.Copy(tunnel.conn, r.Body)
+	}()
+
+	// if either end breaks, close the connection so that both copies are
+	// unblocked; the response may not be written once the handler returns
+	select {
+	case <-toWorker:
+	case <-fromWorker:
+	case <-r.Context().Done():
+	}
+
+	tunnel.conn.Close()
+	<-toWorker
+
+	logger.Debug("tunnel-closed", lager.Data{"tunnel": id})
+}
+
+// flushWriter flushes each write so that output is streamed to the worker,
+// and serializes writes from the heartbeater and the tunnel events.
+type flushWriter struct {
+	lock sync.Mutex
+	w    io.Writer
+	f    http.Flusher
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	n, err := w.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	w.f.Flush()
+
+	return n, nil
+}
@@ -0,0 +1,73 @@
+package tsacmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/tsa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Gateway", func() {
+	var (
+		gw       *gateway
+		cert     *x509.Certificate
+		path     string
+		recorder *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		gw = newGateway(&server{
+			logger: lagertest.NewTestLogger("test"),
+		})
+
+		cert = &x509.Certificate{
+			Subject: pkix.Name{CommonName: "some-worker"},
+		}
+
+		path = tsa.GatewayTunnelPath + "some-tunnel"
+	})
+
+	JustBeforeEach(func() {
+		request := httptest.NewRequest(http.MethodPost, path, nil)
+		request.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+		}
+
+		recorder = httptest.NewRecorder()
+		gw.ServeHTTP(recorder, request)
+	})
+
+	It("serves workers with a named certificate", func() {
+		// the tunnel doesn't exist
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	Context("when the certificate has no common name", func() {
+		BeforeEach(func() {
+			cert.Subject.CommonName = ""
+		})
+
+		It("rejects it", func() {
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("when the certificate only names a team", func() {
+		BeforeEach(func() {
+			cert.Subject = pkix.Name{
+				OrganizationalUnit: []string{tsa.GatewayTeamPrefix + "some-team"},
+			}
+			path = tsa.GatewayCommandPath + tsa.LandWorker
+		})
+
+		It("rejects it before running any command", func() {
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
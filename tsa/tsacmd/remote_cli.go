@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -14,11 +15,10 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/worker/gclient"
 	"github.com/concourse/concourse/tsa"
-	"golang.org/x/crypto/ssh"
 )
 
 type request interface {
-	Handle(context.Context, ConnState, ssh.Channel) error
+	Handle(context.Context, ConnState, io.ReadWriter) error
 }
 
 type forwardWorkerRequest struct {
@@ -28,7 +28,7 @@ type forwardWorkerRequest struct {
 	baggageclaimAddr string
 }
 
-func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	logger := lagerctx.FromContext(ctx)
 
 	var worker atc.Worker
@@ -37,7 +37,7 @@ func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, cha
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -139,7 +139,11 @@ type landWorkerRequest struct {
 	server *server
 }
 
-func checkTeam(state ConnState, worker atc.Worker) error {
+func authorize(state ConnState, worker atc.Worker) error {
+	if state.Worker != "" && worker.Name != state.Worker {
+		return fmt.Errorf("certificate is issued for worker %s, but worker is named %s", state.Worker, worker.Name)
+	}
+
 	if state.Team == "" {
 		// global keys can be used for all teams
 		return nil
@@ -156,14 +160,14 @@ func checkTeam(state ConnState, worker atc.Worker) error {
 	return nil
 }
 
-func (req landWorkerRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req landWorkerRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
 	if err != nil {
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -177,14 +181,14 @@ type retireWorkerRequest struct {
 	server *server
 }
 
-func (req retireWorkerRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req retireWorkerRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
 	if err != nil {
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -194,18 +198,39 @@ func (req retireWorkerRequest) Handle(ctx context.Context, state ConnState, chan
 	}).Retire(ctx, worker)
 }
 
+type evictWorkerRequest struct {
+	server *server
+}
+
+func (req evictWorkerRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
+	var worker atc.Worker
+	err := json.NewDecoder(channel).Decode(&worker)
+	if err != nil {
+		return err
+	}
+
+	if err := authorize(state, worker); err != nil {
+		return err
+	}
+
+	return (&tsa.Evicter{
+		ATCEndpoint: req.server.atcEndpointPicker.Pick(),
+		HTTPClient:  req.server.httpClient,
+	}).Evict(ctx, worker)
+}
+
 type deleteWorkerRequest struct {
 	server *server
 }
 
-func (req deleteWorkerRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req deleteWorkerRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
 	if err != nil {
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -219,14 +244,14 @@ type sweepContainersRequest struct {
 	server *server
 }
 
-func (req sweepContainersRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req sweepContainersRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
 	if err != nil {
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -253,14 +278,14 @@ type reportContainersRequest struct {
 	containerHandles []string
 }
 
-func (req reportContainersRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req reportContainersRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
 	if err != nil {
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -275,14 +300,14 @@ type sweepVolumesRequest struct {
 	server *server
 }
 
-func (req sweepVolumesRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req sweepVolumesRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
 	if err != nil {
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -309,14 +334,14 @@ type reportVolumesRequest struct {
 	volumeHandles []string
 }
 
-func (req reportVolumesRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+func (req reportVolumesRequest) Handle(ctx context.Context, state ConnState, channel io.ReadWriter) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
 	if err != nil {
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := authorize(state, worker); err != nil {
 		return err
 	}
 
@@ -21,15 +21,17 @@ import (
 const maxForwards = 2
 
 type server struct {
-	logger               lager.Logger
-	atcEndpointPicker    tsa.EndpointPicker
-	heartbeatInterval    time.Duration
-	cprInterval          time.Duration
-	gardenRequestTimeout time.Duration
-	forwardHost          string
-	config               *ssh.ServerConfig
-	httpClient           *http.Client
-	sessionTeam          *sessionTeam
+	logger                 lager.Logger
+	atcEndpointPicker      tsa.EndpointPicker
+	heartbeatInterval      time.Duration
+	cprInterval            time.Duration
+	workerKeyCheckInterval time.Duration
+	gardenRequestTimeout   time.Duration
+	forwardHost            string
+	config                 *ssh.ServerConfig
+	httpClient             *http.Client
+	sessionTeam            *sessionTeam
+	unknownWorkerKeys      *unknownWorkerKeys
 }
 
 type sessionTeam struct {
@@ -63,6 +65,11 @@ func (s *sessionTeam) AuthorizedTeamFor(sessionID string) string {
 type ConnState struct {
 	Team string
 
+	// Worker is the name of the worker the connection is restricted to. It is
+	// only set for connections through the worker gateway, where it is the
+	// common name of the worker's certificate.
+	Worker string
+
 	ForwardedTCPIPs <-chan ForwardedTCPIP
 }
 
@@ -116,11 +123,25 @@ func (server *server) handshake(logger lager.Logger, netConn net.Conn) {
 
 	sessionID := string(conn.SessionID())
 
+	team := server.sessionTeam.AuthorizedTeamFor(sessionID)
+
+	if conn.Permissions != nil {
+		if fingerprint, found := conn.Permissions.Extensions[workerKeyFingerprintExtension]; found {
+			team, err = server.completeWorkerKeyAuth(ctx, conn.Permissions)
+			if err != nil {
+				logger.Info("worker-key-auth-failed", lager.Data{"error": err.Error()})
+				return
+			}
+
+			go server.watchWorkerKey(ctx, conn, fingerprint)
+		}
+	}
+
 	forwardedTCPIPs := make(chan ForwardedTCPIP, maxForwards)
 	go server.handleForwardRequests(ctx, conn, reqs, forwardedTCPIPs)
 
 	state := ConnState{
-		Team: server.sessionTeam.AuthorizedTeamFor(sessionID),
+		Team: team,
 
 		ForwardedTCPIPs: forwardedTCPIPs,
 	}
@@ -516,6 +537,10 @@ func (server *server) parseRequest(cli string) (request, string, error) {
 		req = retireWorkerRequest{
 			server: server,
 		}
+	case tsa.EvictWorker:
+		req = evictWorkerRequest{
+			server: server,
+		}
 	case tsa.DeleteWorker:
 		req = deleteWorkerRequest{
 			server: server,
@@ -1,8 +1,11 @@
 package tsacmd
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 
 	"code.cloudfoundry.org/lager"
@@ -14,6 +17,11 @@ type serverRunner struct {
 	server *server
 
 	listenAddr string
+
+	// the worker gateway is only run if configured
+	gateway           *gateway
+	gatewayListenAddr string
+	gatewayTLSConfig  *tls.Config
 }
 
 func (runner serverRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -22,6 +30,36 @@ func (runner serverRunner) Run(signals <-chan os.Signal, ready chan<- struct{})
 		return fmt.Errorf("failed to listen on %s: %s", runner.listenAddr, err)
 	}
 
+	var gatewayServer *http.Server
+	gatewayExited := make(chan error, 1)
+
+	if runner.gateway != nil {
+		gatewayListener, err := net.Listen("tcp", runner.gatewayListenAddr)
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("failed to listen on %s: %s", runner.gatewayListenAddr, err)
+		}
+
+		gatewayServer = &http.Server{
+			Handler:   runner.gateway,
+			TLSConfig: runner.gatewayTLSConfig,
+		}
+
+		go func() {
+			// the certificates are given by the TLS config
+			err := gatewayServer.ServeTLS(gatewayListener, "", "")
+			if errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+
+			gatewayExited <- err
+		}()
+
+		runner.logger.Info("gateway-listening", lager.Data{
+			"addr": runner.gatewayListenAddr,
+		})
+	}
+
 	runner.logger.Info("listening")
 
 	close(ready)
@@ -36,6 +74,19 @@ func (runner serverRunner) Run(signals <-chan os.Signal, ready chan<- struct{})
 	for {
 		select {
 		case <-exited:
+			if gatewayServer != nil {
+				gatewayServer.Close()
+			}
+
+			return nil
+		case err := <-gatewayExited:
+			listener.Close()
+			<-exited
+
+			if err != nil {
+				return fmt.Errorf("worker gateway exited: %s", err)
+			}
+
 			return nil
 		case <-signals:
 			listener.Close()
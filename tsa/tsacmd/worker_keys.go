@@ -0,0 +1,299 @@
+package tsacmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// workerKeyFingerprintExtension is set in the permissions of connections
+	// authorized by a key enrolled in the database, so that the key can be
+	// re-checked for as long as the connection is open.
+	workerKeyFingerprintExtension = "concourse-worker-key-fingerprint"
+
+	// workerKeyTeamExtension is set to the team the key is enrolled for.
+	workerKeyTeamExtension = "concourse-worker-key-team"
+
+	// workerKeyEnrollmentExtension and workerKeyPublicKeyExtension are set
+	// when the key is to be enrolled with an enrollment token once the
+	// handshake has proven that the worker holds the private key.
+	workerKeyEnrollmentExtension = "concourse-worker-key-enrollment-token"
+	workerKeyPublicKeyExtension  = "concourse-worker-key-public-key"
+)
+
+// how long to wait for the ATC when authorizing or enrolling a key
+const workerKeyRequestTimeout = 10 * time.Second
+
+const (
+	// how long a key which the ATC doesn't know about is rejected without
+	// asking the ATC again
+	unknownWorkerKeyTTL = time.Minute
+
+	// how many keys each remote host may look up on the ATC per second, and
+	// in a burst
+	workerKeyLookupRate  = 1
+	workerKeyLookupBurst = 10
+
+	// how long the lookup limit of a remote host is kept around once it
+	// stops connecting
+	workerKeyLookupIdleTTL = 10 * time.Minute
+)
+
+var errTooManyWorkerKeyLookups = errors.New("too many worker key lookups")
+
+// authorizeWorkerKey authorizes a key which is not configured on the TSA
+// against the keys enrolled in the database. Keys which aren't enrolled are
+// accepted if the worker connected with an enrollment token, in which case
+// they get enrolled once the handshake completes.
+//
+// Nothing is written to the database here, as the callback runs before the
+// client has proven that it holds the private key.
+func authorizeWorkerKey(
+	logger lager.Logger,
+	unknownKeys *unknownWorkerKeys,
+	authorizer *tsa.WorkerKeyAuthorizer,
+	conn ssh.ConnMetadata,
+	key ssh.PublicKey,
+) (*ssh.Permissions, error) {
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	logger = logger.Session("authorize-worker-key", lager.Data{
+		"remote":      conn.RemoteAddr().String(),
+		"fingerprint": fingerprint,
+	})
+
+	workerKey, found, err := unknownKeys.Lookup(remoteHost(conn.RemoteAddr()), fingerprint, func() (atc.WorkerKey, bool, error) {
+		ctx, cancel := context.WithTimeout(lagerctx.NewContext(context.Background(), logger), workerKeyRequestTimeout)
+		defer cancel()
+
+		return authorizer.Authorize(ctx, fingerprint)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize public key: %w", err)
+	}
+
+	if found {
+		return &ssh.Permissions{
+			Extensions: map[string]string{
+				workerKeyFingerprintExtension: fingerprint,
+				workerKeyTeamExtension:        workerKey.TeamName,
+			},
+		}, nil
+	}
+
+	if !strings.HasPrefix(conn.User(), tsa.EnrollmentUserPrefix) {
+		return nil, errors.New("unknown public key")
+	}
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			workerKeyFingerprintExtension: fingerprint,
+			workerKeyEnrollmentExtension:  strings.TrimPrefix(conn.User(), tsa.EnrollmentUserPrefix),
+			workerKeyPublicKeyExtension:   string(ssh.MarshalAuthorizedKey(key)),
+		},
+	}, nil
+}
+
+// completeWorkerKeyAuth runs once the handshake of a connection authorized
+// through the database has completed. It enrolls the key if the worker
+// connected with an enrollment token, or records that the key was used
+// otherwise, returning the team the key is enrolled for.
+func (server *server) completeWorkerKeyAuth(ctx context.Context, permissions *ssh.Permissions) (string, error) {
+	fingerprint := permissions.Extensions[workerKeyFingerprintExtension]
+
+	logger := lagerctx.WithSession(ctx, "complete-worker-key-auth", lager.Data{
+		"fingerprint": fingerprint,
+	})
+
+	ctx, cancel := context.WithTimeout(lagerctx.NewContext(ctx, logger), workerKeyRequestTimeout)
+	defer cancel()
+
+	token, enrolling := permissions.Extensions[workerKeyEnrollmentExtension]
+	if !enrolling {
+		err := server.workerKeyAuthorizer().Use(ctx, fingerprint)
+		if err != nil {
+			logger.Error("failed-to-record-use", err)
+		}
+
+		return permissions.Extensions[workerKeyTeamExtension], nil
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(permissions.Extensions[workerKeyPublicKeyExtension]))
+	if err != nil {
+		return "", err
+	}
+
+	workerKey, err := server.workerKeyAuthorizer().Enroll(ctx, token, key)
+	if err != nil {
+		logger.Info("failed-to-enroll", lager.Data{"error": err.Error()})
+		return "", fmt.Errorf("failed to enroll public key: %w", err)
+	}
+
+	server.unknownWorkerKeys.Forget(fingerprint)
+
+	logger.Info("enrolled", lager.Data{"team": workerKey.TeamName})
+
+	return workerKey.TeamName, nil
+}
+
+func (server *server) workerKeyAuthorizer() *tsa.WorkerKeyAuthorizer {
+	return &tsa.WorkerKeyAuthorizer{
+		ATCEndpoint: server.atcEndpointPicker.Pick(),
+		HTTPClient:  server.httpClient,
+	}
+}
+
+// watchWorkerKey closes the connection once its key has been revoked. The key
+// is only read, never written to, on every check. Errors reaching the ATC are
+// ignored so that workers stay connected through ATC outages.
+func (server *server) watchWorkerKey(ctx context.Context, conn *ssh.ServerConn, fingerprint string) {
+	logger := lagerctx.WithSession(ctx, "watch-worker-key", lager.Data{
+		"fingerprint": fingerprint,
+	})
+
+	ticker := time.NewTicker(server.workerKeyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(lagerctx.NewContext(ctx, logger), workerKeyRequestTimeout)
+			workerKey, found, err := server.workerKeyAuthorizer().Authorize(checkCtx, fingerprint)
+			cancel()
+
+			if err != nil {
+				logger.Error("failed-to-check-worker-key", err)
+				continue
+			}
+
+			if !found || workerKey.RevokedAt != 0 {
+				logger.Info("worker-key-revoked", lager.Data{"revoked-at": workerKey.RevokedAt})
+				conn.Close()
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// unknownWorkerKeys remembers the keys which the ATC did not know about for a
+// while, and rate-limits looking up the others per remote host, so that
+// clients offering unknown keys can't flood the ATC with lookups nor keep
+// workers connecting from elsewhere from being authorized.
+type unknownWorkerKeys struct {
+	lock     sync.Mutex
+	expires  map[string]time.Time
+	limiters map[string]*hostLimiter
+}
+
+type hostLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+func newUnknownWorkerKeys() *unknownWorkerKeys {
+	return &unknownWorkerKeys{
+		expires:  map[string]time.Time{},
+		limiters: map[string]*hostLimiter{},
+	}
+}
+
+// Lookup returns the enrolled key with the given fingerprint using the lookup
+// function, unless the key was unknown the last time it was looked up. Keys
+// which have been revoked are treated as unknown.
+func (keys *unknownWorkerKeys) Lookup(host string, fingerprint string, lookup func() (atc.WorkerKey, bool, error)) (atc.WorkerKey, bool, error) {
+	keys.lock.Lock()
+	expires, unknown := keys.expires[fingerprint]
+	keys.lock.Unlock()
+
+	if unknown && time.Now().Before(expires) {
+		return atc.WorkerKey{}, false, nil
+	}
+
+	if !keys.allow(host) {
+		return atc.WorkerKey{}, false, errTooManyWorkerKeyLookups
+	}
+
+	workerKey, found, err := lookup()
+	if err != nil {
+		return atc.WorkerKey{}, false, err
+	}
+
+	if !found || workerKey.RevokedAt != 0 {
+		keys.remember(fingerprint)
+		return atc.WorkerKey{}, false, nil
+	}
+
+	return workerKey, true, nil
+}
+
+// Forget stops treating the key as unknown, e.g. once it has been enrolled.
+func (keys *unknownWorkerKeys) Forget(fingerprint string) {
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	delete(keys.expires, fingerprint)
+}
+
+func (keys *unknownWorkerKeys) allow(host string) bool {
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	now := time.Now()
+	for h, limiter := range keys.limiters {
+		if now.Sub(limiter.lastUsed) > workerKeyLookupIdleTTL {
+			delete(keys.limiters, h)
+		}
+	}
+
+	limiter, found := keys.limiters[host]
+	if !found {
+		limiter = &hostLimiter{
+			limiter: rate.NewLimiter(workerKeyLookupRate, workerKeyLookupBurst),
+		}
+		keys.limiters[host] = limiter
+	}
+
+	limiter.lastUsed = now
+
+	return limiter.limiter.AllowN(now, 1)
+}
+
+func (keys *unknownWorkerKeys) remember(fingerprint string) {
+	keys.lock.Lock()
+	defer keys.lock.Unlock()
+
+	now := time.Now()
+	for f, expires := range keys.expires {
+		if now.After(expires) {
+			delete(keys.expires, f)
+		}
+	}
+
+	keys.expires[fingerprint] = now.Add(unknownWorkerKeyTTL)
+}
+
+// remoteHost is the host part of a remote address, so that every connection
+// from the same host shares its lookup limit.
+func remoteHost(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+
+	return host
+}
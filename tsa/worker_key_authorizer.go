@@ -0,0 +1,157 @@
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrInvalidEnrollmentToken is returned when the ATC rejects an enrollment
+// token, either because it is unknown or because it has expired or already
+// been used.
+var ErrInvalidEnrollmentToken = errors.New("enrollment token is invalid or has expired")
+
+// WorkerKeyAuthorizer checks worker keys against the keys enrolled in the
+// ATC's database.
+type WorkerKeyAuthorizer struct {
+	ATCEndpoint *rata.RequestGenerator
+	HTTPClient  *http.Client
+}
+
+// Authorize returns the enrolled key with the given SHA256 fingerprint, or
+// false if no such key is enrolled.
+func (a *WorkerKeyAuthorizer) Authorize(ctx context.Context, fingerprint string) (atc.WorkerKey, bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	request, err := a.ATCEndpoint.CreateRequest(atc.AuthorizeWorkerKey, nil, nil)
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return atc.WorkerKey{}, false, err
+	}
+
+	request.URL.RawQuery = url.Values{
+		"fingerprint": {fingerprint},
+	}.Encode()
+
+	response, err := a.HTTPClient.Do(request.WithContext(ctx))
+	if err != nil {
+		logger.Error("failed-to-authorize-key", err)
+		return atc.WorkerKey{}, false, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return atc.WorkerKey{}, false, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return atc.WorkerKey{}, false, badResponse(logger, response)
+	}
+
+	var workerKey atc.WorkerKey
+	err = json.NewDecoder(response.Body).Decode(&workerKey)
+	if err != nil {
+		logger.Error("failed-to-decode-worker-key", err)
+		return atc.WorkerKey{}, false, err
+	}
+
+	return workerKey, true, nil
+}
+
+// Use records that a worker connected with the key with the given SHA256
+// fingerprint.
+func (a *WorkerKeyAuthorizer) Use(ctx context.Context, fingerprint string) error {
+	logger := lagerctx.FromContext(ctx)
+
+	request, err := a.ATCEndpoint.CreateRequest(atc.UseWorkerKey, nil, nil)
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return err
+	}
+
+	request.URL.RawQuery = url.Values{
+		"fingerprint": {fingerprint},
+	}.Encode()
+
+	response, err := a.HTTPClient.Do(request.WithContext(ctx))
+	if err != nil {
+		logger.Error("failed-to-use-key", err)
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return badResponse(logger, response)
+	}
+
+	return nil
+}
+
+// Enroll enrolls the key for the team the enrollment token was created for.
+func (a *WorkerKeyAuthorizer) Enroll(ctx context.Context, token string, key ssh.PublicKey) (atc.WorkerKey, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	payload, err := json.Marshal(atc.WorkerKeyEnrollment{
+		Token:     token,
+		PublicKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+	})
+	if err != nil {
+		return atc.WorkerKey{}, err
+	}
+
+	request, err := a.ATCEndpoint.CreateRequest(atc.EnrollWorkerKey, nil, bytes.NewBuffer(payload))
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return atc.WorkerKey{}, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := a.HTTPClient.Do(request.WithContext(ctx))
+	if err != nil {
+		logger.Error("failed-to-enroll-key", err)
+		return atc.WorkerKey{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusForbidden {
+		return atc.WorkerKey{}, ErrInvalidEnrollmentToken
+	}
+
+	if response.StatusCode != http.StatusCreated {
+		return atc.WorkerKey{}, badResponse(logger, response)
+	}
+
+	var workerKey atc.WorkerKey
+	err = json.NewDecoder(response.Body).Decode(&workerKey)
+	if err != nil {
+		logger.Error("failed-to-decode-worker-key", err)
+		return atc.WorkerKey{}, err
+	}
+
+	return workerKey, nil
+}
+
+func badResponse(logger lager.Logger, response *http.Response) error {
+	logger.Error("bad-response", nil, lager.Data{
+		"status-code": response.StatusCode,
+	})
+
+	b, _ := httputil.DumpResponse(response, true)
+	return fmt.Errorf("bad-response (%d): %s", response.StatusCode, string(b))
+}
@@ -0,0 +1,176 @@
+package tsa_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("WorkerKeyAuthorizer", func() {
+	var (
+		authorizer *tsa.WorkerKeyAuthorizer
+
+		ctx     context.Context
+		key     ssh.PublicKey
+		fakeATC *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		fakeATC = ghttp.NewServer()
+
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err = ssh.NewPublicKey(pub)
+		Expect(err).NotTo(HaveOccurred())
+
+		token := &oauth2.Token{TokenType: "Bearer", AccessToken: "yo"}
+		httpClient := oauth2.NewClient(oauth2.NoContext, oauth2.StaticTokenSource(token))
+
+		authorizer = &tsa.WorkerKeyAuthorizer{
+			ATCEndpoint: rata.NewRequestGenerator(fakeATC.URL(), atc.Routes),
+			HTTPClient:  httpClient,
+		}
+	})
+
+	AfterEach(func() {
+		fakeATC.Close()
+	})
+
+	Describe("Authorize", func() {
+		Context("when the key is enrolled", func() {
+			BeforeEach(func() {
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/worker-keys/authorized"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.URL.Query().Get("fingerprint")).To(Equal(ssh.FingerprintSHA256(key)))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.WorkerKey{
+						ID:       1,
+						TeamName: "some-team",
+					}),
+				))
+			})
+
+			It("returns the key's team", func() {
+				workerKey, found, err := authorizer.Authorize(ctx, ssh.FingerprintSHA256(key))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(workerKey.TeamName).To(Equal("some-team"))
+			})
+		})
+
+		Context("when the key is not enrolled", func() {
+			BeforeEach(func() {
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/worker-keys/authorized"),
+					ghttp.RespondWith(http.StatusNotFound, nil),
+				))
+			})
+
+			It("returns false", func() {
+				_, found, err := authorizer.Authorize(ctx, ssh.FingerprintSHA256(key))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the ATC fails", func() {
+			BeforeEach(func() {
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/worker-keys/authorized"),
+					ghttp.RespondWith(http.StatusInternalServerError, nil),
+				))
+			})
+
+			It("returns an error", func() {
+				_, _, err := authorizer.Authorize(ctx, ssh.FingerprintSHA256(key))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Use", func() {
+		Context("when the ATC records the use", func() {
+			BeforeEach(func() {
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/worker-keys/used", "fingerprint="+url.QueryEscape(ssh.FingerprintSHA256(key))),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				))
+			})
+
+			It("succeeds", func() {
+				Expect(authorizer.Use(ctx, ssh.FingerprintSHA256(key))).To(Succeed())
+			})
+		})
+
+		Context("when the ATC fails", func() {
+			BeforeEach(func() {
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/worker-keys/used"),
+					ghttp.RespondWith(http.StatusInternalServerError, nil),
+				))
+			})
+
+			It("returns an error", func() {
+				Expect(authorizer.Use(ctx, ssh.FingerprintSHA256(key))).ToNot(Succeed())
+			})
+		})
+	})
+
+	Describe("Enroll", func() {
+		Context("when the token is valid", func() {
+			BeforeEach(func() {
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/worker-keys/enroll"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+					ghttp.VerifyJSONRepresenting(atc.WorkerKeyEnrollment{
+						Token:     "some-token",
+						PublicKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+					}),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.WorkerKey{
+						ID:       1,
+						TeamName: "some-team",
+					}),
+				))
+			})
+
+			It("enrolls the key", func() {
+				workerKey, err := authorizer.Enroll(ctx, "some-token", key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(workerKey.TeamName).To(Equal("some-team"))
+			})
+		})
+
+		Context("when the token is rejected", func() {
+			BeforeEach(func() {
+				fakeATC.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/worker-keys/enroll"),
+					ghttp.RespondWith(http.StatusForbidden, nil),
+				))
+			})
+
+			It("returns ErrInvalidEnrollmentToken", func() {
+				_, err := authorizer.Enroll(ctx, "some-token", key)
+				Expect(err).To(Equal(tsa.ErrInvalidEnrollmentToken))
+			})
+		})
+	})
+})
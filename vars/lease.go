@@ -0,0 +1,46 @@
+package vars
+
+import "time"
+
+// Lease is the lease on a dynamic secret, e.g. credentials generated by one of
+// Vault's secrets engines, which are only valid until the lease expires or is
+// revoked.
+type Lease struct {
+	ID        string
+	Duration  time.Duration
+	Renewable bool
+
+	// Keeper renews and revokes the lease with the credential manager which
+	// issued it.
+	Keeper LeaseKeeper
+}
+
+//go:generate counterfeiter . LeaseKeeper
+
+// LeaseKeeper renews and revokes the leases of dynamic secrets.
+type LeaseKeeper interface {
+	// RenewLease extends the lease by the given increment, returning the
+	// duration the lease is actually valid for.
+	RenewLease(leaseID string, increment time.Duration) (time.Duration, error)
+
+	RevokeLease(leaseID string) error
+}
+
+// LeasedVariables is implemented by Variables whose values may be backed by a
+// lease.
+type LeasedVariables interface {
+	Variables
+
+	GetLeased(Reference) (interface{}, *Lease, bool, error)
+}
+
+// GetLeased looks up the var along with its lease, if the variables support
+// leases and the value is backed by one.
+func GetLeased(variables Variables, ref Reference) (interface{}, *Lease, bool, error) {
+	if leased, ok := variables.(LeasedVariables); ok {
+		return leased.GetLeased(ref)
+	}
+
+	val, found, err := variables.Get(ref)
+	return val, nil, found, err
+}
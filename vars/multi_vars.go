@@ -21,6 +21,17 @@ func (m MultiVars) Get(ref Reference) (interface{}, bool, error) {
 	return nil, false, nil
 }
 
+func (m MultiVars) GetLeased(ref Reference) (interface{}, *Lease, bool, error) {
+	for _, vars := range m.varss {
+		val, lease, found, err := GetLeased(vars, ref)
+		if found || err != nil {
+			return val, lease, found, err
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
 func (m MultiVars) List() ([]Reference, error) {
 	var allRefs []Reference
 
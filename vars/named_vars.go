@@ -18,6 +18,20 @@ func (m NamedVariables) Get(ref Reference) (interface{}, bool, error) {
 	return nil, false, MissingSourceError{Name: ref.String(), Source: ref.Source}
 }
 
+// GetLeased is the same as Get, but also returns the lease backing the var, if
+// any.
+func (m NamedVariables) GetLeased(ref Reference) (interface{}, *Lease, bool, error) {
+	if ref.Source == "" {
+		return nil, nil, false, nil
+	}
+
+	if vars, ok := m[ref.Source]; ok {
+		return GetLeased(vars, ref.WithoutSource())
+	}
+
+	return nil, nil, false, MissingSourceError{Name: ref.String(), Source: ref.Source}
+}
+
 func (m NamedVariables) List() ([]Reference, error) {
 	var allRefs []Reference
 
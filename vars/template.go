@@ -28,6 +28,46 @@ func (t Template) ExtraVarNames() []string {
 	return interpolator{}.extractVarNames(string(t.bytes))
 }
 
+// References returns the vars referenced throughout the template, in the
+// order they appear in. Each var is only returned once, no matter how many of
+// its fields are referenced.
+func (t Template) References() ([]Reference, error) {
+	var obj interface{}
+
+	err := yaml.Unmarshal(t.bytes, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []Reference
+	seen := map[string]bool{}
+
+	i := interpolator{}
+	err = i.walkStrings(obj, func(value string) error {
+		for _, name := range i.extractVarNames(value) {
+			ref, err := ParseReference(name)
+			if err != nil {
+				return err
+			}
+
+			id := identifier(ref)
+			if seen[id] {
+				continue
+			}
+
+			seen[id] = true
+			refs = append(refs, ref)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
 func (t Template) Evaluate(vars Variables, opts EvaluateOpts) ([]byte, error) {
 	var obj interface{}
 
@@ -125,6 +165,48 @@ func (i interpolator) Interpolate(node interface{}, tracker varsTracker) (interf
 	return node, nil
 }
 
+func (i interpolator) walkStrings(node interface{}, visit func(string) error) error {
+	switch typedNode := node.(type) {
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(typedNode))
+		values := map[string]interface{}{}
+		for k, v := range typedNode {
+			key := fmt.Sprintf("%v", k)
+			keys = append(keys, key)
+			values[key] = v
+		}
+
+		// map iteration order is random, so go in key order to keep the
+		// references in a stable order
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			err := visit(key)
+			if err != nil {
+				return err
+			}
+
+			err = i.walkStrings(values[key], visit)
+			if err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for _, x := range typedNode {
+			err := i.walkStrings(x, visit)
+			if err != nil {
+				return err
+			}
+		}
+
+	case string:
+		return visit(typedNode)
+	}
+
+	return nil
+}
+
 func (i interpolator) extractVarNames(value string) []string {
 	var names []string
 
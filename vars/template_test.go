@@ -10,6 +10,33 @@ import (
 )
 
 var _ = Describe("Template", func() {
+	Describe("References", func() {
+		It("returns each var referenced throughout the template once", func() {
+			template := NewTemplate([]byte(`
+source:
+  uri: ((repo-uri))
+  private_key: ((vault:deploy.private_key))
+  keys: [((key-a)), "prefix-((key-b))-((key-a))"]
+  public_key: ((vault:deploy.public_key))
+`))
+
+			refs, err := template.References()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(refs).To(Equal([]Reference{
+				{Path: "key-a", Fields: []string{}},
+				{Path: "key-b", Fields: []string{}},
+				{Source: "vault", Path: "deploy", Fields: []string{"private_key"}},
+				{Path: "repo-uri", Fields: []string{}},
+			}))
+		})
+
+		It("returns no references when there are none", func() {
+			refs, err := NewTemplate([]byte(`{"some": "config"}`)).References()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(refs).To(BeEmpty())
+		})
+	})
+
 	It("can interpolate values into a struct with byte slice", func() {
 		template := NewTemplate([]byte("((key))"))
 		vars := StaticVariables{"key": "foo"}
@@ -15,6 +15,9 @@ type Tracker struct {
 	// Considering in-parallel steps, a lock is need.
 	lock              sync.RWMutex
 	interpolatedCreds map[string]string
+
+	// set once a var backed by a lease has been tracked
+	leased bool
 }
 
 func NewTracker(on bool) *Tracker {
@@ -35,6 +38,25 @@ func (t *Tracker) Track(varRef Reference, val interface{}) {
 	t.track(varRef, val)
 }
 
+// TrackLeased tracks a var backed by a lease. Dynamic secrets are always
+// tracked, even if redaction is disabled.
+func (t *Tracker) TrackLeased(varRef Reference, val interface{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.leased = true
+	t.track(varRef, val)
+}
+
+// RedactionEnabled returns whether the tracked vars should be redacted, i.e.
+// when redaction is enabled or a var backed by a lease has been tracked.
+func (t *Tracker) RedactionEnabled() bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.Enabled || t.leased
+}
+
 func (t *Tracker) track(varRef Reference, val interface{}) {
 	switch v := val.(type) {
 	case map[interface{}]interface{}:
@@ -74,9 +96,13 @@ type CredVarsTracker struct {
 }
 
 func (t *CredVarsTracker) Get(ref Reference) (interface{}, bool, error) {
-	val, found, err := t.CredVars.Get(ref)
+	val, lease, found, err := GetLeased(t.CredVars, ref)
 	if found {
-		t.Tracker.Track(ref, val)
+		if lease != nil {
+			t.Tracker.TrackLeased(ref, val)
+		} else {
+			t.Tracker.Track(ref, val)
+		}
 	}
 	return val, found, err
 }
@@ -0,0 +1,193 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package varsfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/vars"
+)
+
+type FakeLeaseKeeper struct {
+	RenewLeaseStub        func(string, time.Duration) (time.Duration, error)
+	renewLeaseMutex       sync.RWMutex
+	renewLeaseArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	renewLeaseReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	renewLeaseReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 error
+	}
+	RevokeLeaseStub        func(string) error
+	revokeLeaseMutex       sync.RWMutex
+	revokeLeaseArgsForCall []struct {
+		arg1 string
+	}
+	revokeLeaseReturns struct {
+		result1 error
+	}
+	revokeLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLeaseKeeper) RenewLease(arg1 string, arg2 time.Duration) (time.Duration, error) {
+	fake.renewLeaseMutex.Lock()
+	ret, specificReturn := fake.renewLeaseReturnsOnCall[len(fake.renewLeaseArgsForCall)]
+	fake.renewLeaseArgsForCall = append(fake.renewLeaseArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.RenewLeaseStub
+	fakeReturns := fake.renewLeaseReturns
+	fake.recordInvocation("RenewLease", []interface{}{arg1, arg2})
+	fake.renewLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLeaseKeeper) RenewLeaseCallCount() int {
+	fake.renewLeaseMutex.RLock()
+	defer fake.renewLeaseMutex.RUnlock()
+	return len(fake.renewLeaseArgsForCall)
+}
+
+func (fake *FakeLeaseKeeper) RenewLeaseCalls(stub func(string, time.Duration) (time.Duration, error)) {
+	fake.renewLeaseMutex.Lock()
+	defer fake.renewLeaseMutex.Unlock()
+	fake.RenewLeaseStub = stub
+}
+
+func (fake *FakeLeaseKeeper) RenewLeaseArgsForCall(i int) (string, time.Duration) {
+	fake.renewLeaseMutex.RLock()
+	defer fake.renewLeaseMutex.RUnlock()
+	argsForCall := fake.renewLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLeaseKeeper) RenewLeaseReturns(result1 time.Duration, result2 error) {
+	fake.renewLeaseMutex.Lock()
+	defer fake.renewLeaseMutex.Unlock()
+	fake.RenewLeaseStub = nil
+	fake.renewLeaseReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeaseKeeper) RenewLeaseReturnsOnCall(i int, result1 time.Duration, result2 error) {
+	fake.renewLeaseMutex.Lock()
+	defer fake.renewLeaseMutex.Unlock()
+	fake.RenewLeaseStub = nil
+	if fake.renewLeaseReturnsOnCall == nil {
+		fake.renewLeaseReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 error
+		})
+	}
+	fake.renewLeaseReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeaseKeeper) RevokeLease(arg1 string) error {
+	fake.revokeLeaseMutex.Lock()
+	ret, specificReturn := fake.revokeLeaseReturnsOnCall[len(fake.revokeLeaseArgsForCall)]
+	fake.revokeLeaseArgsForCall = append(fake.revokeLeaseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RevokeLeaseStub
+	fakeReturns := fake.revokeLeaseReturns
+	fake.recordInvocation("RevokeLease", []interface{}{arg1})
+	fake.revokeLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeaseKeeper) RevokeLeaseCallCount() int {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	return len(fake.revokeLeaseArgsForCall)
+}
+
+func (fake *FakeLeaseKeeper) RevokeLeaseCalls(stub func(string) error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = stub
+}
+
+func (fake *FakeLeaseKeeper) RevokeLeaseArgsForCall(i int) string {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	argsForCall := fake.revokeLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeaseKeeper) RevokeLeaseReturns(result1 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	fake.revokeLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseKeeper) RevokeLeaseReturnsOnCall(i int, result1 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	if fake.revokeLeaseReturnsOnCall == nil {
+		fake.revokeLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseKeeper) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.renewLeaseMutex.RLock()
+	defer fake.renewLeaseMutex.RUnlock()
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLeaseKeeper) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ vars.LeaseKeeper = new(FakeLeaseKeeper)
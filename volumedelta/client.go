@@ -0,0 +1,161 @@
+// Package volumedelta holds the protocol for streaming only what differs
+// between two volumes, shared by the ATC and the delta server on workers.
+package volumedelta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/api"
+	"github.com/tedsuo/rata"
+)
+
+//go:generate counterfeiter . Client
+
+// Client talks to the delta server which workers run in front of their
+// Baggageclaim server.
+type Client interface {
+	// Manifest returns the manifest of the volume, as read by ReadManifest.
+	Manifest(ctx context.Context, handle string) (io.ReadCloser, error)
+
+	// StreamOut returns everything in the volume which differs from the given
+	// base manifest.
+	StreamOut(ctx context.Context, handle string, encoding baggageclaim.Encoding, base io.Reader) (io.ReadCloser, error)
+
+	// StreamIn applies a delta returned by StreamOut to the volume.
+	StreamIn(ctx context.Context, handle string, encoding baggageclaim.Encoding, delta io.Reader) error
+
+	// GetP2pUrl returns the URL at which other workers can reach the server.
+	GetP2pUrl(ctx context.Context) (string, error)
+
+	// StreamP2pOut has the worker compute the delta between the volume and the
+	// destination volume on another worker and stream it there directly.
+	StreamP2pOut(ctx context.Context, handle string, encoding baggageclaim.Encoding, destURL string, destHandle string) error
+
+	// Size returns the total size of the files in the volume, like
+	// `du --apparent-size` would, without reading any of them.
+	Size(ctx context.Context, handle string) (int64, error)
+}
+
+type client struct {
+	requestGenerator *rata.RequestGenerator
+	httpClient       *http.Client
+}
+
+func NewClient(apiURL string, httpClient *http.Client) Client {
+	return &client{
+		requestGenerator: rata.NewRequestGenerator(apiURL, Routes),
+		httpClient:       httpClient,
+	}
+}
+
+func (c *client) Manifest(ctx context.Context, handle string) (io.ReadCloser, error) {
+	response, err := c.do(ctx, GetManifest, rata.Params{"handle": handle}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (c *client) StreamOut(ctx context.Context, handle string, encoding baggageclaim.Encoding, base io.Reader) (io.ReadCloser, error) {
+	response, err := c.do(ctx, StreamOut, rata.Params{"handle": handle}, url.Values{
+		"encoding": {string(encoding)},
+	}, base)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (c *client) StreamIn(ctx context.Context, handle string, encoding baggageclaim.Encoding, delta io.Reader) error {
+	response, err := c.do(ctx, StreamIn, rata.Params{"handle": handle}, url.Values{
+		"encoding": {string(encoding)},
+	}, delta)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func (c *client) GetP2pUrl(ctx context.Context) (string, error) {
+	response, err := c.do(ctx, GetP2pUrl, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+func (c *client) StreamP2pOut(ctx context.Context, handle string, encoding baggageclaim.Encoding, destURL string, destHandle string) error {
+	response, err := c.do(ctx, StreamP2pOut, rata.Params{"handle": handle}, url.Values{
+		"encoding":    {string(encoding)},
+		"dest_url":    {destURL},
+		"dest_handle": {destHandle},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func (c *client) Size(ctx context.Context, handle string) (int64, error) {
+	response, err := c.do(ctx, GetSize, rata.Params{"handle": handle}, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(string(body), 10, 64)
+}
+
+func (c *client) do(ctx context.Context, route string, params rata.Params, query url.Values, body io.Reader) (*http.Response, error) {
+	request, err := c.requestGenerator.CreateRequest(route, params, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.URL.RawQuery = query.Encode()
+
+	response, err := c.httpClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+
+		var errorResponse api.ErrorResponse
+		err := json.NewDecoder(response.Body).Decode(&errorResponse)
+		if err != nil || errorResponse.Message == "" {
+			return nil, fmt.Errorf("delta streaming failed: %s", response.Status)
+		}
+
+		return nil, fmt.Errorf("delta streaming failed: %s", errorResponse.Message)
+	}
+
+	return response, nil
+}
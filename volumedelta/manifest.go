@@ -0,0 +1,44 @@
+package volumedelta
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// Entry describes a single file, directory or symlink in a volume. Paths are
+// relative to the root of the volume and always use forward slashes.
+type Entry struct {
+	Path     string      `json:"path"`
+	Mode     os.FileMode `json:"mode"`
+	UID      int         `json:"uid"`
+	GID      int         `json:"gid"`
+	Size     int64       `json:"size,omitempty"`
+	Linkname string      `json:"linkname,omitempty"`
+	Digest   string      `json:"digest,omitempty"`
+}
+
+// Manifest maps each path in a volume to its entry. It is what the
+// destination of a delta stream advertises about the base it already holds.
+type Manifest map[string]Entry
+
+// ReadManifest reads a manifest written as a stream of JSON entries, as
+// returned by Client.Manifest.
+func ReadManifest(r io.Reader) (Manifest, error) {
+	manifest := Manifest{}
+
+	dec := json.NewDecoder(r)
+	for {
+		var entry Entry
+		err := dec.Decode(&entry)
+		if err == io.EOF {
+			return manifest, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		manifest[entry.Path] = entry
+	}
+}
@@ -0,0 +1,21 @@
+package volumedelta
+
+import "github.com/tedsuo/rata"
+
+const (
+	GetManifest  = "GetManifest"
+	StreamOut    = "StreamOut"
+	StreamIn     = "StreamIn"
+	StreamP2pOut = "StreamP2pOut"
+	GetP2pUrl    = "GetP2pUrl"
+	GetSize      = "GetSize"
+)
+
+var Routes = rata.Routes{
+	{Path: "/volumes/:handle/delta/manifest", Method: "GET", Name: GetManifest},
+	{Path: "/volumes/:handle/delta/stream-out", Method: "POST", Name: StreamOut},
+	{Path: "/volumes/:handle/delta/stream-in", Method: "PUT", Name: StreamIn},
+	{Path: "/volumes/:handle/delta/stream-p2p-out", Method: "PUT", Name: StreamP2pOut},
+	{Path: "/delta/p2p-url", Method: "GET", Name: GetP2pUrl},
+	{Path: "/volumes/:handle/delta/size", Method: "GET", Name: GetSize},
+}
@@ -0,0 +1,528 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package volumedeltafakes
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/volumedelta"
+)
+
+type FakeClient struct {
+	GetP2pUrlStub        func(context.Context) (string, error)
+	getP2pUrlMutex       sync.RWMutex
+	getP2pUrlArgsForCall []struct {
+		arg1 context.Context
+	}
+	getP2pUrlReturns struct {
+		result1 string
+		result2 error
+	}
+	getP2pUrlReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ManifestStub        func(context.Context, string) (io.ReadCloser, error)
+	manifestMutex       sync.RWMutex
+	manifestArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	manifestReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	manifestReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	SizeStub        func(context.Context, string) (int64, error)
+	sizeMutex       sync.RWMutex
+	sizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	sizeReturns struct {
+		result1 int64
+		result2 error
+	}
+	sizeReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	StreamInStub        func(context.Context, string, baggageclaim.Encoding, io.Reader) error
+	streamInMutex       sync.RWMutex
+	streamInArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 io.Reader
+	}
+	streamInReturns struct {
+		result1 error
+	}
+	streamInReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamOutStub        func(context.Context, string, baggageclaim.Encoding, io.Reader) (io.ReadCloser, error)
+	streamOutMutex       sync.RWMutex
+	streamOutArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 io.Reader
+	}
+	streamOutReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	streamOutReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	StreamP2pOutStub        func(context.Context, string, baggageclaim.Encoding, string, string) error
+	streamP2pOutMutex       sync.RWMutex
+	streamP2pOutArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 string
+		arg5 string
+	}
+	streamP2pOutReturns struct {
+		result1 error
+	}
+	streamP2pOutReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) GetP2pUrl(arg1 context.Context) (string, error) {
+	fake.getP2pUrlMutex.Lock()
+	ret, specificReturn := fake.getP2pUrlReturnsOnCall[len(fake.getP2pUrlArgsForCall)]
+	fake.getP2pUrlArgsForCall = append(fake.getP2pUrlArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetP2pUrlStub
+	fakeReturns := fake.getP2pUrlReturns
+	fake.recordInvocation("GetP2pUrl", []interface{}{arg1})
+	fake.getP2pUrlMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetP2pUrlCallCount() int {
+	fake.getP2pUrlMutex.RLock()
+	defer fake.getP2pUrlMutex.RUnlock()
+	return len(fake.getP2pUrlArgsForCall)
+}
+
+func (fake *FakeClient) GetP2pUrlCalls(stub func(context.Context) (string, error)) {
+	fake.getP2pUrlMutex.Lock()
+	defer fake.getP2pUrlMutex.Unlock()
+	fake.GetP2pUrlStub = stub
+}
+
+func (fake *FakeClient) GetP2pUrlArgsForCall(i int) context.Context {
+	fake.getP2pUrlMutex.RLock()
+	defer fake.getP2pUrlMutex.RUnlock()
+	argsForCall := fake.getP2pUrlArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) GetP2pUrlReturns(result1 string, result2 error) {
+	fake.getP2pUrlMutex.Lock()
+	defer fake.getP2pUrlMutex.Unlock()
+	fake.GetP2pUrlStub = nil
+	fake.getP2pUrlReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetP2pUrlReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getP2pUrlMutex.Lock()
+	defer fake.getP2pUrlMutex.Unlock()
+	fake.GetP2pUrlStub = nil
+	if fake.getP2pUrlReturnsOnCall == nil {
+		fake.getP2pUrlReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getP2pUrlReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Manifest(arg1 context.Context, arg2 string) (io.ReadCloser, error) {
+	fake.manifestMutex.Lock()
+	ret, specificReturn := fake.manifestReturnsOnCall[len(fake.manifestArgsForCall)]
+	fake.manifestArgsForCall = append(fake.manifestArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ManifestStub
+	fakeReturns := fake.manifestReturns
+	fake.recordInvocation("Manifest", []interface{}{arg1, arg2})
+	fake.manifestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ManifestCallCount() int {
+	fake.manifestMutex.RLock()
+	defer fake.manifestMutex.RUnlock()
+	return len(fake.manifestArgsForCall)
+}
+
+func (fake *FakeClient) ManifestCalls(stub func(context.Context, string) (io.ReadCloser, error)) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = stub
+}
+
+func (fake *FakeClient) ManifestArgsForCall(i int) (context.Context, string) {
+	fake.manifestMutex.RLock()
+	defer fake.manifestMutex.RUnlock()
+	argsForCall := fake.manifestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ManifestReturns(result1 io.ReadCloser, result2 error) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = nil
+	fake.manifestReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ManifestReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = nil
+	if fake.manifestReturnsOnCall == nil {
+		fake.manifestReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.manifestReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Size(arg1 context.Context, arg2 string) (int64, error) {
+	fake.sizeMutex.Lock()
+	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
+	fake.sizeArgsForCall = append(fake.sizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SizeStub
+	fakeReturns := fake.sizeReturns
+	fake.recordInvocation("Size", []interface{}{arg1, arg2})
+	fake.sizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) SizeCallCount() int {
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	return len(fake.sizeArgsForCall)
+}
+
+func (fake *FakeClient) SizeCalls(stub func(context.Context, string) (int64, error)) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = stub
+}
+
+func (fake *FakeClient) SizeArgsForCall(i int) (context.Context, string) {
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	argsForCall := fake.sizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) SizeReturns(result1 int64, result2 error) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	fake.sizeReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) SizeReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	if fake.sizeReturnsOnCall == nil {
+		fake.sizeReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.sizeReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StreamIn(arg1 context.Context, arg2 string, arg3 baggageclaim.Encoding, arg4 io.Reader) error {
+	fake.streamInMutex.Lock()
+	ret, specificReturn := fake.streamInReturnsOnCall[len(fake.streamInArgsForCall)]
+	fake.streamInArgsForCall = append(fake.streamInArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.StreamInStub
+	fakeReturns := fake.streamInReturns
+	fake.recordInvocation("StreamIn", []interface{}{arg1, arg2, arg3, arg4})
+	fake.streamInMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) StreamInCallCount() int {
+	fake.streamInMutex.RLock()
+	defer fake.streamInMutex.RUnlock()
+	return len(fake.streamInArgsForCall)
+}
+
+func (fake *FakeClient) StreamInCalls(stub func(context.Context, string, baggageclaim.Encoding, io.Reader) error) {
+	fake.streamInMutex.Lock()
+	defer fake.streamInMutex.Unlock()
+	fake.StreamInStub = stub
+}
+
+func (fake *FakeClient) StreamInArgsForCall(i int) (context.Context, string, baggageclaim.Encoding, io.Reader) {
+	fake.streamInMutex.RLock()
+	defer fake.streamInMutex.RUnlock()
+	argsForCall := fake.streamInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) StreamInReturns(result1 error) {
+	fake.streamInMutex.Lock()
+	defer fake.streamInMutex.Unlock()
+	fake.StreamInStub = nil
+	fake.streamInReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) StreamInReturnsOnCall(i int, result1 error) {
+	fake.streamInMutex.Lock()
+	defer fake.streamInMutex.Unlock()
+	fake.StreamInStub = nil
+	if fake.streamInReturnsOnCall == nil {
+		fake.streamInReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamInReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) StreamOut(arg1 context.Context, arg2 string, arg3 baggageclaim.Encoding, arg4 io.Reader) (io.ReadCloser, error) {
+	fake.streamOutMutex.Lock()
+	ret, specificReturn := fake.streamOutReturnsOnCall[len(fake.streamOutArgsForCall)]
+	fake.streamOutArgsForCall = append(fake.streamOutArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.StreamOutStub
+	fakeReturns := fake.streamOutReturns
+	fake.recordInvocation("StreamOut", []interface{}{arg1, arg2, arg3, arg4})
+	fake.streamOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) StreamOutCallCount() int {
+	fake.streamOutMutex.RLock()
+	defer fake.streamOutMutex.RUnlock()
+	return len(fake.streamOutArgsForCall)
+}
+
+func (fake *FakeClient) StreamOutCalls(stub func(context.Context, string, baggageclaim.Encoding, io.Reader) (io.ReadCloser, error)) {
+	fake.streamOutMutex.Lock()
+	defer fake.streamOutMutex.Unlock()
+	fake.StreamOutStub = stub
+}
+
+func (fake *FakeClient) StreamOutArgsForCall(i int) (context.Context, string, baggageclaim.Encoding, io.Reader) {
+	fake.streamOutMutex.RLock()
+	defer fake.streamOutMutex.RUnlock()
+	argsForCall := fake.streamOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeClient) StreamOutReturns(result1 io.ReadCloser, result2 error) {
+	fake.streamOutMutex.Lock()
+	defer fake.streamOutMutex.Unlock()
+	fake.StreamOutStub = nil
+	fake.streamOutReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StreamOutReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.streamOutMutex.Lock()
+	defer fake.streamOutMutex.Unlock()
+	fake.StreamOutStub = nil
+	if fake.streamOutReturnsOnCall == nil {
+		fake.streamOutReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.streamOutReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StreamP2pOut(arg1 context.Context, arg2 string, arg3 baggageclaim.Encoding, arg4 string, arg5 string) error {
+	fake.streamP2pOutMutex.Lock()
+	ret, specificReturn := fake.streamP2pOutReturnsOnCall[len(fake.streamP2pOutArgsForCall)]
+	fake.streamP2pOutArgsForCall = append(fake.streamP2pOutArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 baggageclaim.Encoding
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.StreamP2pOutStub
+	fakeReturns := fake.streamP2pOutReturns
+	fake.recordInvocation("StreamP2pOut", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.streamP2pOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) StreamP2pOutCallCount() int {
+	fake.streamP2pOutMutex.RLock()
+	defer fake.streamP2pOutMutex.RUnlock()
+	return len(fake.streamP2pOutArgsForCall)
+}
+
+func (fake *FakeClient) StreamP2pOutCalls(stub func(context.Context, string, baggageclaim.Encoding, string, string) error) {
+	fake.streamP2pOutMutex.Lock()
+	defer fake.streamP2pOutMutex.Unlock()
+	fake.StreamP2pOutStub = stub
+}
+
+func (fake *FakeClient) StreamP2pOutArgsForCall(i int) (context.Context, string, baggageclaim.Encoding, string, string) {
+	fake.streamP2pOutMutex.RLock()
+	defer fake.streamP2pOutMutex.RUnlock()
+	argsForCall := fake.streamP2pOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) StreamP2pOutReturns(result1 error) {
+	fake.streamP2pOutMutex.Lock()
+	defer fake.streamP2pOutMutex.Unlock()
+	fake.StreamP2pOutStub = nil
+	fake.streamP2pOutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) StreamP2pOutReturnsOnCall(i int, result1 error) {
+	fake.streamP2pOutMutex.Lock()
+	defer fake.streamP2pOutMutex.Unlock()
+	fake.StreamP2pOutStub = nil
+	if fake.streamP2pOutReturnsOnCall == nil {
+		fake.streamP2pOutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamP2pOutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getP2pUrlMutex.RLock()
+	defer fake.getP2pUrlMutex.RUnlock()
+	fake.manifestMutex.RLock()
+	defer fake.manifestMutex.RUnlock()
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	fake.streamInMutex.RLock()
+	defer fake.streamInMutex.RUnlock()
+	fake.streamOutMutex.RLock()
+	defer fake.streamOutMutex.RUnlock()
+	fake.streamP2pOutMutex.RLock()
+	defer fake.streamP2pOutMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ volumedelta.Client = new(FakeClient)
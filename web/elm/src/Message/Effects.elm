@@ -90,6 +90,9 @@ port syncStickyBuildLogHeaders : () -> Cmd msg
 port scrollToId : ( String, String ) -> Cmd msg
 
 
+port promptForPinComment : Int -> Cmd msg
+
+
 type alias StickyHeaderConfig =
     { pageHeaderHeight : Float
     , pageBodyClass : String
@@ -153,7 +156,8 @@ type Effect
     | LoadExternal String
     | NavigateTo String
     | ModifyUrl String
-    | DoPinVersion Concourse.VersionedResourceIdentifier
+    | PromptForPinComment Concourse.VersionedResourceIdentifier
+    | DoPinVersion Concourse.VersionedResourceIdentifier String
     | DoUnpinVersion Concourse.ResourceIdentifier
     | DoToggleVersion VersionToggleAction VersionId
     | DoCheck Concourse.ResourceIdentifier
@@ -394,10 +398,20 @@ runEffect effect key csrfToken =
                 , Json.Encode.list Concourse.encodeResource resources
                 )
 
-        DoPinVersion id ->
+        PromptForPinComment id ->
+            promptForPinComment id.versionID
+
+        DoPinVersion id comment ->
             Api.put
                 (Endpoints.PinResourceVersion |> Endpoints.ResourceVersion id)
                 csrfToken
+                |> Api.withJsonBody
+                    (Json.Encode.object
+                        [ ( "pin_comment"
+                          , Json.Encode.string comment
+                          )
+                        ]
+                    )
                 |> Api.request
                 |> Task.attempt VersionPinned
 
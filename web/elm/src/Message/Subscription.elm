@@ -56,6 +56,9 @@ port rawHttpResponse : (String -> msg) -> Sub msg
 port scrolledToId : (( String, String ) -> msg) -> Sub msg
 
 
+port pinCommentPrompted : (( Int, Maybe String ) -> msg) -> Sub msg
+
+
 type alias Position =
     { x : Float
     , y : Float
@@ -86,6 +89,7 @@ type Subscription
     | OnTokenSentToFly
     | OnLocalStorageReceived
     | OnScrolledToId
+    | OnPinCommentPrompted
 
 
 type Delivery
@@ -109,6 +113,7 @@ type Delivery
     | FavoritedPipelinesReceived (Result Json.Decode.Error (Set DatabaseID))
     | FavoritedInstanceGroupsReceived (Result Json.Decode.Error (Set ( Concourse.TeamName, Concourse.PipelineName )))
     | ScrolledToId ( String, String )
+    | PinCommentPrompted ( Int, Maybe String )
     | Noop
 
 
@@ -184,6 +189,9 @@ runSubscription s =
         OnScrolledToId ->
             scrolledToId ScrolledToId
 
+        OnPinCommentPrompted ->
+            pinCommentPrompted PinCommentPrompted
+
 
 decodePosition : Json.Decode.Decoder Position
 decodePosition =
@@ -39,6 +39,7 @@ type alias Model =
         , currentPage : Page
         , versions : Paginated Version
         , pinCommentLoading : Bool
+        , pinningComment : String
         , textAreaFocused : Bool
         , icon : Maybe String
         , isEditing : Bool
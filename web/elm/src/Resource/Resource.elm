@@ -148,6 +148,7 @@ init flags =
                 }
             , now = Nothing
             , pinCommentLoading = False
+            , pinningComment = ""
             , textAreaFocused = False
             , isUserMenuExpanded = False
             , icon = Nothing
@@ -296,6 +297,7 @@ subscriptions model =
     , OnKeyDown
     , OnKeyUp
     , OnWindowResize
+    , OnPinCommentPrompted
     ]
         ++ (case buildEventsUrl of
                 Nothing ->
@@ -506,32 +508,47 @@ handleCallback callback session ( model, effects ) =
                                 ++ Login.userDisplayName user
                                 ++ " at "
                                 ++ formatDate session.timeZone time
-                    in
-                    ( { model
-                        | pinnedVersion =
+
+                        pinnedTo comment =
                             model.versions.content
                                 |> List.Extra.find (\v -> Just v.id == pinningTo)
                                 |> Maybe.map .version
-                                |> Maybe.map
-                                    (PinnedDynamicallyTo
-                                        { comment = commentText
-                                        , pristineComment = ""
-                                        }
-                                    )
+                                |> Maybe.map (PinnedDynamicallyTo comment)
                                 |> Maybe.withDefault NotPinned
-                      }
-                    , effects
-                        ++ [ SetPinComment
-                                model.resourceIdentifier
-                                commentText
-                           ]
-                    )
+                    in
+                    if String.isEmpty model.pinningComment then
+                        ( { model
+                            | pinnedVersion =
+                                pinnedTo
+                                    { comment = commentText
+                                    , pristineComment = ""
+                                    }
+                          }
+                        , effects
+                            ++ [ SetPinComment
+                                    model.resourceIdentifier
+                                    commentText
+                               ]
+                        )
+
+                    else
+                        -- the comment was already sent along with the pin
+                        ( { model
+                            | pinnedVersion =
+                                pinnedTo
+                                    { comment = model.pinningComment
+                                    , pristineComment = model.pinningComment
+                                    }
+                            , pinningComment = ""
+                          }
+                        , effects
+                        )
 
                 _ ->
                     ( model, effects )
 
         VersionPinned (Err _) ->
-            ( { model | pinnedVersion = NotPinned }
+            ( { model | pinnedVersion = NotPinned, pinningComment = "" }
             , effects
             )
 
@@ -680,6 +697,23 @@ handleDelivery session delivery ( model, effects ) =
             , effects ++ [ SyncTextareaHeight ResourceCommentTextarea ]
             )
 
+        PinCommentPrompted ( versionID, Just comment ) ->
+            case
+                model.versions.content
+                    |> List.Extra.find (\v -> v.id.versionID == versionID)
+            of
+                Just version ->
+                    ( { model
+                        | pinnedVersion =
+                            Pinned.startPinningTo version.id model.pinnedVersion
+                        , pinningComment = comment
+                      }
+                    , effects ++ [ DoPinVersion version.id comment ]
+                    )
+
+                Nothing ->
+                    ( model, effects )
+
         EventsReceived (Ok envelopes) ->
             let
                 ended =
@@ -776,24 +810,18 @@ update msg ( model, effects ) =
                                     )
 
                                 else
-                                    ( { model
-                                        | pinnedVersion =
-                                            Pinned.startPinningTo versionID model.pinnedVersion
-                                      }
+                                    ( model
                                     , effects
-                                        ++ [ DoPinVersion vn.id ]
+                                        ++ [ PromptForPinComment vn.id ]
                                     )
                             )
                         |> Maybe.withDefault ( model, effects )
 
                 NotPinned ->
-                    ( { model
-                        | pinnedVersion =
-                            Pinned.startPinningTo versionID model.pinnedVersion
-                      }
+                    ( model
                     , case version of
                         Just _ ->
-                            effects ++ [ DoPinVersion versionID ]
+                            effects ++ [ PromptForPinComment versionID ]
 
                         Nothing ->
                             effects
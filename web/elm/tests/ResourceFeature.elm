@@ -9,6 +9,7 @@ import Html.Attributes as Attr
 import Message.Callback as Callback
 import Message.Effects as Effects
 import Message.Message as Message exposing (DomID(..))
+import Message.Subscription as Subscription
 import Message.TopLevelMessage exposing (TopLevelMessage(..))
 import Resource.Resource as Resource
 import Test exposing (Test, describe, test)
@@ -26,10 +27,16 @@ all =
                 >> and iAmLookingAtAVersionOtherThanThePinnedOne
                 >> when iAmLookingAtThePinButton
                 >> then_ iSeeItIsClickable
-        , test "clicking unpinned version sends PinResource request" <|
+        , test "clicking unpinned version prompts for a pin comment" <|
             given iAmOnTheResourcePage
                 >> and theResourceIsAlreadyPinned
                 >> when iClickTheVersionThatIsNotPinned
+                >> then_ myBrowserPromptsForAPinComment
+        , test "answering the pin comment prompt sends PinResource request" <|
+            given iAmOnTheResourcePage
+                >> and theResourceIsAlreadyPinned
+                >> and iClickTheVersionThatIsNotPinned
+                >> when iAnswerThePinCommentPrompt
                 >> then_ myBrowserSendsAPinResourceRequest
         , test "clicking pinned version sends UnpinResource request" <|
             given iAmOnTheResourcePage
@@ -107,6 +114,12 @@ iClickTheVersionThatIsNotPinned =
     Application.update (Update <| (Message.Click <| PinButton unpinnedVersionID))
 
 
+iAnswerThePinCommentPrompt =
+    Tuple.first
+        >> Application.handleDelivery
+            (Subscription.PinCommentPrompted ( unpinnedVersionID.versionID, Just "rolling back" ))
+
+
 iClickTheVersionThatIsPinned =
     Application.update (Update <| (Message.Click <| PinButton pinnedVersionID))
 
@@ -123,8 +136,12 @@ resourceID =
     Data.resourceId
 
 
+myBrowserPromptsForAPinComment =
+    Tuple.second >> Common.contains (Effects.PromptForPinComment unpinnedVersionID)
+
+
 myBrowserSendsAPinResourceRequest =
-    Tuple.second >> Common.contains (Effects.DoPinVersion unpinnedVersionID)
+    Tuple.second >> Common.contains (Effects.DoPinVersion unpinnedVersionID "rolling back")
 
 
 myBrowserSendsAnUnpinResourceRequest =
@@ -1265,6 +1265,8 @@ all =
                                 )
                                 session
                             |> Resource.update (Click <| PinButton otherVersionID)
+                            |> Resource.handleDelivery session
+                                (PinCommentPrompted ( otherVersionID.versionID, Just "" ))
                             |> Tuple.first
                             |> Resource.versions
                             |> List.map .pinState
@@ -1313,6 +1315,8 @@ all =
                                 )
                                 session
                             |> Resource.update (Click <| PinButton otherVersionID)
+                            |> Resource.handleDelivery session
+                                (PinCommentPrompted ( otherVersionID.versionID, Just "" ))
                             |> Resource.handleCallback
                                 (Callback.VersionPinned <| Ok ())
                                 session
@@ -1364,6 +1368,8 @@ all =
                                 )
                                 session
                             |> Resource.update (Click <| PinButton otherVersionID)
+                            |> Resource.handleDelivery session
+                                (PinCommentPrompted ( otherVersionID.versionID, Just "" ))
                             |> Resource.handleCallback
                                 (Callback.ResourceFetched <|
                                     Ok (Data.resource (Just version))
@@ -2436,6 +2442,37 @@ all =
                                         "pinned by some-user at Jan 1 1970 12:00:00 AM"
                                     ]
                         ]
+                    , describe "when pinning with a comment succeeds" <|
+                        let
+                            onSuccess =
+                                setup
+                                    >> update (Message.Message.Click <| Message.Message.PinButton versionID)
+                                    >> Tuple.first
+                                    >> Application.handleDelivery
+                                        (PinCommentPrompted ( versionID.versionID, Just "rolling back" ))
+                                    >> Tuple.first
+                                    >> Application.handleCallback
+                                        (Callback.VersionPinned <| Ok ())
+                        in
+                        [ test "fills in comment input with the given comment" <|
+                            onSuccess
+                                >> Tuple.first
+                                >> commentBar
+                                >> Query.find [ tag "textarea" ]
+                                >> Query.has [ attribute <| Attr.value "rolling back" ]
+                        , test "does not overwrite the comment" <|
+                            onSuccess
+                                >> Tuple.second
+                                >> Expect.equal []
+                        ]
+                    , test "cancelling the comment prompt does not pin" <|
+                        setup
+                            >> update (Message.Message.Click <| Message.Message.PinButton versionID)
+                            >> Tuple.first
+                            >> Application.handleDelivery
+                                (PinCommentPrompted ( versionID.versionID, Nothing ))
+                            >> Tuple.second
+                            >> Expect.equal []
                     , test "clicked button shows unpinned state when pinning fails" <|
                         afterClick
                             >> Application.handleCallback
@@ -3614,6 +3651,9 @@ clickToPin : Models.VersionId -> Application.Model -> Application.Model
 clickToPin vid =
     update (Message.Message.Click <| Message.Message.PinButton vid)
         >> Tuple.first
+        >> Application.handleDelivery
+            (PinCommentPrompted ( vid.versionID, Just "" ))
+        >> Tuple.first
 
 
 clickToUnpin : Application.Model -> Application.Model
@@ -100,6 +100,13 @@ app.ports.deleteFromLocalStorage.subscribe(function(key) {
   localStorage.removeItem(key);
 });
 
+app.ports.promptForPinComment.subscribe(function(versionID) {
+  const comment = window.prompt("Why is this version being pinned? (leave blank for the default comment)");
+  setTimeout(function() {
+    app.ports.pinCommentPrompted.send([versionID, comment]);
+  }, 0);
+});
+
 
 const csrfTokenKey = "csrf_token";
 const favoritedPipelinesKey = "favorited_pipelines";
@@ -14,7 +14,7 @@ import (
 
 func NewBeaconRunner(
 	logger lager.Logger,
-	tsaClient *tsa.Client,
+	tsaClient TSAClient,
 	rebalanceInterval time.Duration,
 	connectionDrainTimeout time.Duration,
 	gardenAddr string,
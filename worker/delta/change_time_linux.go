@@ -0,0 +1,15 @@
+package delta
+
+import (
+	"os"
+	"syscall"
+)
+
+func fileChangeTime(info os.FileInfo) int64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0
+	}
+
+	return stat.Ctim.Nano()
+}
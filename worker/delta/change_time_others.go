@@ -0,0 +1,11 @@
+// +build !linux
+
+package delta
+
+import "os"
+
+// fileChangeTime is only available on Linux; elsewhere, cached digests are
+// reused based on the size and modification time alone.
+func fileChangeTime(info os.FileInfo) int64 {
+	return 0
+}
@@ -0,0 +1,299 @@
+package delta
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/volumedelta"
+)
+
+// whiteoutRecord is set on tar entries marking a path which exists in the
+// base but not in the source, and must be removed from the destination.
+//
+// This is carried as a PAX record rather than as an OCI-style '.wh.' file so
+// that it can't be confused with a file that's actually in the volume.
+const whiteoutRecord = "CONCOURSE.delta.whiteout"
+
+// Write writes a tar stream containing everything in the directory which is
+// missing from or differs from the base, followed by whiteouts for everything
+// in the base which no longer exists.
+//
+// Files are compared by content, mode and ownership; modification times are
+// not compared, as they rarely agree between two fetches of the same data.
+func Write(w io.Writer, dir string, base volumedelta.Manifest, ownership Ownership, digests *Digests) error {
+	tw := tar.NewWriter(w)
+
+	seen := map[string]bool{}
+
+	err := walk(dir, ownership, func(entry volumedelta.Entry, path string, info os.FileInfo) error {
+		seen[entry.Path] = true
+
+		unchanged, err := matches(entry, path, info, base, digests)
+		if err != nil {
+			return err
+		}
+
+		if unchanged {
+			return nil
+		}
+
+		return writeEntry(tw, entry, path)
+	})
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	for path := range base {
+		if !seen[path] {
+			removed = append(removed, path)
+		}
+	}
+
+	sort.Strings(removed)
+
+	var lastRemoved string
+	for _, path := range removed {
+		// removing a directory removes everything in it
+		if lastRemoved != "" && strings.HasPrefix(path, lastRemoved+"/") {
+			continue
+		}
+
+		err := tw.WriteHeader(&tar.Header{
+			Name:       path,
+			Typeflag:   tar.TypeReg,
+			Format:     tar.FormatPAX,
+			PAXRecords: map[string]string{whiteoutRecord: "true"},
+		})
+		if err != nil {
+			return err
+		}
+
+		lastRemoved = path
+	}
+
+	return tw.Close()
+}
+
+func matches(entry volumedelta.Entry, path string, info os.FileInfo, base volumedelta.Manifest, digests *Digests) (bool, error) {
+	baseEntry, found := base[entry.Path]
+	if !found {
+		return false, nil
+	}
+
+	if entry.Mode != baseEntry.Mode ||
+		entry.UID != baseEntry.UID ||
+		entry.GID != baseEntry.GID ||
+		entry.Size != baseEntry.Size ||
+		entry.Linkname != baseEntry.Linkname {
+		return false, nil
+	}
+
+	if !entry.Mode.IsRegular() {
+		return true, nil
+	}
+
+	digest, err := digests.Digest(entry.Path, path, info)
+	if err != nil {
+		return false, err
+	}
+
+	return digest == baseEntry.Digest, nil
+}
+
+func writeEntry(tw *tar.Writer, entry volumedelta.Entry, path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, entry.Linkname)
+	if err != nil {
+		return err
+	}
+
+	header.Name = entry.Path
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	header.Uid = entry.UID
+	header.Gid = entry.GID
+	header.Uname = ""
+	header.Gname = ""
+
+	err = tw.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.CopyN(tw, file, header.Size)
+	return err
+}
+
+// Apply extracts a tar stream written by Write into the directory, which is
+// expected to already contain the base the stream was computed against.
+func Apply(r io.Reader, dir string, ownership Ownership) error {
+	tr := tar.NewReader(r)
+
+	chown := os.Geteuid() == 0
+
+	var dirs []*tar.Header
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		target, err := securePath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+
+		if header.PAXRecords[whiteoutRecord] != "" {
+			if _, err := os.Lstat(target); err == nil {
+				err = os.RemoveAll(target)
+				if err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			return err
+		}
+
+		existing, err := os.Lstat(target)
+		if err == nil && !(existing.IsDir() && header.Typeflag == tar.TypeDir) {
+			err = os.RemoveAll(target)
+			if err != nil {
+				return err
+			}
+		}
+
+		mode := header.FileInfo().Mode()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, mode.Perm())
+			dirs = append(dirs, header)
+		case tar.TypeReg:
+			err = writeFile(target, mode.Perm(), tr)
+		case tar.TypeSymlink:
+			err = os.Symlink(header.Linkname, target)
+		case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+			err = mknod(target, header.Typeflag, uint32(mode.Perm()), header.Devmajor, header.Devminor)
+		default:
+			err = fmt.Errorf("unsupported tar entry type '%c' for '%s'", header.Typeflag, header.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if chown {
+			err = os.Lchown(target, ownership.onDisk(header.Uid), ownership.onDisk(header.Gid))
+			if err != nil {
+				return err
+			}
+		}
+
+		if header.Typeflag == tar.TypeSymlink {
+			continue
+		}
+
+		// chmod after chown, which clears setuid and setgid bits
+		err = os.Chmod(target, mode)
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeDir {
+			err = os.Chtimes(target, header.ModTime, header.ModTime)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// set directory times last, as writing into them changes them
+	for i := len(dirs) - 1; i >= 0; i-- {
+		target := filepath.Join(dir, filepath.FromSlash(dirs[i].Name))
+
+		err := os.Chtimes(target, dirs[i].ModTime, dirs[i].ModTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path string, perm os.FileMode, r io.Reader) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, r)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// securePath resolves the tar entry name within the directory, refusing names
+// which escape it either lexically or by way of a symlink in the volume.
+func securePath(dir string, name string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(rel) || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid path in delta: %s", name)
+	}
+
+	parent := dir
+	for _, component := range strings.Split(filepath.Dir(rel), string(filepath.Separator)) {
+		if component == "." {
+			break
+		}
+
+		parent = filepath.Join(parent, component)
+
+		info, err := os.Lstat(parent)
+		if os.IsNotExist(err) {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("invalid path in delta: %s traverses a symlink", name)
+		}
+	}
+
+	return filepath.Join(dir, rel), nil
+}
@@ -0,0 +1,260 @@
+package delta_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/concourse/concourse/volumedelta"
+	"github.com/concourse/concourse/worker/delta"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Delta", func() {
+	var (
+		source string
+		dest   string
+
+		ownership delta.Ownership
+	)
+
+	BeforeEach(func() {
+		var err error
+		source, err = ioutil.TempDir("", "delta-source")
+		Expect(err).ToNot(HaveOccurred())
+
+		dest, err = ioutil.TempDir("", "delta-dest")
+		Expect(err).ToNot(HaveOccurred())
+
+		ownership = delta.Ownership{Privileged: true}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(source)).To(Succeed())
+		Expect(os.RemoveAll(dest)).To(Succeed())
+	})
+
+	writeFile := func(dir, path, content string) {
+		full := filepath.Join(dir, path)
+		Expect(os.MkdirAll(filepath.Dir(full), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(full, []byte(content), 0644)).To(Succeed())
+	}
+
+	manifest := func(dir string) volumedelta.Manifest {
+		buf := new(bytes.Buffer)
+		Expect(delta.WriteManifest(buf, dir, ownership, nil)).To(Succeed())
+
+		manifest, err := volumedelta.ReadManifest(buf)
+		Expect(err).ToNot(HaveOccurred())
+
+		return manifest
+	}
+
+	streamDelta := func() []string {
+		buf := new(bytes.Buffer)
+		Expect(delta.Write(buf, source, manifest(dest), ownership, nil)).To(Succeed())
+
+		var names []string
+		tr := tar.NewReader(bytes.NewReader(buf.Bytes()))
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+			names = append(names, header.Name)
+		}
+
+		Expect(delta.Apply(buf, dest, ownership)).To(Succeed())
+
+		return names
+	}
+
+	Describe("WriteManifest", func() {
+		BeforeEach(func() {
+			writeFile(source, "some-dir/some-file", "some-content")
+			Expect(os.Symlink("some-dir/some-file", filepath.Join(source, "some-link"))).To(Succeed())
+		})
+
+		It("describes everything in the directory", func() {
+			manifest := manifest(source)
+			Expect(manifest).To(HaveLen(3))
+
+			Expect(manifest["some-dir"].Mode.IsDir()).To(BeTrue())
+
+			Expect(manifest["some-dir/some-file"].Size).To(Equal(int64(len("some-content"))))
+			Expect(manifest["some-dir/some-file"].Digest).To(Equal("sha256:0a8cac771ca188eacc57e2c96c31f5611925c5ecedccb16b8c236d6c0d325112"))
+
+			Expect(manifest["some-link"].Linkname).To(Equal("some-dir/some-file"))
+		})
+
+		Context("when the volume is unprivileged", func() {
+			BeforeEach(func() {
+				if os.Geteuid() != 0 {
+					Skip("must be run as root to chown files")
+				}
+
+				ownership = delta.Ownership{Privileged: false, MaxID: 65534}
+
+				Expect(os.Lchown(filepath.Join(source, "some-dir/some-file"), 65534, 65534)).To(Succeed())
+			})
+
+			It("describes files owned by the max ID as owned by root", func() {
+				Expect(manifest(source)["some-dir/some-file"].UID).To(Equal(0))
+				Expect(manifest(source)["some-dir/some-file"].GID).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Write and Apply", func() {
+		BeforeEach(func() {
+			writeFile(dest, "unchanged", "same")
+			writeFile(dest, "changed", "old")
+			writeFile(dest, "removed", "gone")
+			writeFile(dest, "removed-dir/some-file", "gone")
+			writeFile(dest, "became-file/some-file", "gone")
+
+			writeFile(source, "unchanged", "same")
+			writeFile(source, "changed", "new")
+			writeFile(source, "added/some-file", "new")
+			writeFile(source, "became-file", "new")
+		})
+
+		It("only streams what differs from the base", func() {
+			Expect(streamDelta()).To(ConsistOf(
+				"added/",
+				"added/some-file",
+				"became-file",
+				"changed",
+				"became-file/some-file",
+				"removed",
+				"removed-dir",
+			))
+		})
+
+		It("leaves the destination with the same contents as the source", func() {
+			streamDelta()
+
+			sourceManifest := manifest(source)
+			destManifest := manifest(dest)
+
+			Expect(destManifest).To(HaveLen(len(sourceManifest)))
+			for path, entry := range sourceManifest {
+				Expect(destManifest).To(HaveKey(path))
+				Expect(destManifest[path].Digest).To(Equal(entry.Digest))
+				Expect(destManifest[path].Mode).To(Equal(entry.Mode))
+			}
+		})
+
+		Context("when the destination already matches", func() {
+			BeforeEach(func() {
+				streamDelta()
+			})
+
+			It("streams nothing", func() {
+				Expect(streamDelta()).To(BeEmpty())
+			})
+		})
+
+		Context("when a file's mode changes", func() {
+			BeforeEach(func() {
+				Expect(os.Chmod(filepath.Join(source, "unchanged"), 0755)).To(Succeed())
+			})
+
+			It("streams the file", func() {
+				Expect(streamDelta()).To(ContainElement("unchanged"))
+
+				info, err := os.Stat(filepath.Join(dest, "unchanged"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0755)))
+			})
+		})
+	})
+
+	Describe("Digests", func() {
+		var cachePath string
+		var path string
+		var info os.FileInfo
+
+		BeforeEach(func() {
+			cachePath = filepath.Join(dest, "digests.json")
+			path = filepath.Join(source, "some-file")
+
+			writeFile(source, "some-file", "some-content")
+
+			var err error
+			info, err = os.Lstat(path)
+			Expect(err).ToNot(HaveOccurred())
+
+			digests := delta.LoadDigests(cachePath)
+			_, err = digests.Digest("some-file", path, info)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digests.Save()).To(Succeed())
+		})
+
+		tamper := func() {
+			contents, err := ioutil.ReadFile(cachePath)
+			Expect(err).ToNot(HaveOccurred())
+
+			digest, err := delta.LoadDigests(filepath.Join(dest, "empty.json")).Digest("some-file", path, info)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring(digest))
+
+			contents = bytes.Replace(contents, []byte(digest), []byte("sha256:cached"), 1)
+			Expect(ioutil.WriteFile(cachePath, contents, 0644)).To(Succeed())
+		}
+
+		It("reuses the cached digest of files which haven't changed", func() {
+			tamper()
+
+			digest, err := delta.LoadDigests(cachePath).Digest("some-file", path, info)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal("sha256:cached"))
+		})
+
+		It("hashes files which changed again, even if their size and modification time didn't", func() {
+			tamper()
+
+			time.Sleep(10 * time.Millisecond)
+			writeFile(source, "some-file", "same-length!")
+			Expect(os.Chtimes(path, info.ModTime(), info.ModTime())).To(Succeed())
+
+			changed, err := os.Lstat(path)
+			Expect(err).ToNot(HaveOccurred())
+
+			digest, err := delta.LoadDigests(cachePath).Digest("some-file", path, changed)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(HavePrefix("sha256:"))
+			Expect(digest).ToNot(Equal("sha256:cached"))
+		})
+	})
+
+	Describe("Apply", func() {
+		It("refuses paths escaping the directory", func() {
+			buf := new(bytes.Buffer)
+			tw := tar.NewWriter(buf)
+			Expect(tw.WriteHeader(&tar.Header{Name: "../escaped", Typeflag: tar.TypeReg, Mode: 0644})).To(Succeed())
+			Expect(tw.Close()).To(Succeed())
+
+			Expect(delta.Apply(buf, dest, ownership)).ToNot(Succeed())
+		})
+
+		It("refuses paths traversing symlinks", func() {
+			Expect(os.Symlink(source, filepath.Join(dest, "some-link"))).To(Succeed())
+
+			buf := new(bytes.Buffer)
+			tw := tar.NewWriter(buf)
+			Expect(tw.WriteHeader(&tar.Header{Name: "some-link/escaped", Typeflag: tar.TypeReg, Mode: 0644})).To(Succeed())
+			Expect(tw.Close()).To(Succeed())
+
+			Expect(delta.Apply(buf, dest, ownership)).ToNot(Succeed())
+			Expect(filepath.Join(source, "escaped")).ToNot(BeAnExistingFile())
+		})
+	})
+})
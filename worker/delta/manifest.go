@@ -0,0 +1,277 @@
+package delta
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/concourse/concourse/volumedelta"
+)
+
+// Ownership translates the UIDs and GIDs of a volume's files between how they
+// are stored on disk and how they are seen from within containers.
+//
+// Baggageclaim stores files owned by root in unprivileged volumes as the
+// maximum valid ID, so that they can't be used to escalate privileges from
+// within a user namespace. Entries in manifests and deltas always carry the
+// container's view, so that volumes can be diffed regardless of whether the
+// source and destination are privileged.
+type Ownership struct {
+	Privileged bool
+
+	// MaxID is the maximum valid UID and GID on the worker. It is zero if
+	// user namespaces are not being used.
+	MaxID int
+}
+
+func (o Ownership) logical(id int) int {
+	if !o.Privileged && o.MaxID != 0 && id == o.MaxID {
+		return 0
+	}
+
+	return id
+}
+
+func (o Ownership) onDisk(id int) int {
+	if !o.Privileged && o.MaxID != 0 && id == 0 {
+		return o.MaxID
+	}
+
+	return id
+}
+
+// WriteManifest walks the directory and writes an entry for everything in it
+// as a stream of JSON objects. Digests are taken from the cache where the
+// files haven't changed since they were last hashed.
+func WriteManifest(w io.Writer, dir string, ownership Ownership, digests *Digests) error {
+	enc := json.NewEncoder(w)
+
+	return walk(dir, ownership, func(entry volumedelta.Entry, path string, info os.FileInfo) error {
+		if entry.Mode.IsRegular() {
+			digest, err := digests.Digest(entry.Path, path, info)
+			if err != nil {
+				return err
+			}
+
+			entry.Digest = digest
+		}
+
+		return enc.Encode(entry)
+	})
+}
+
+// Size adds up the sizes of the regular files in the directory. Only their
+// metadata is read, so it is as cheap as `du --apparent-size`.
+func Size(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// walk calls the callback for everything in the directory other than the
+// directory itself, in lexical order. Digests are left for the caller to
+// compute, as they are only needed for files which might be unchanged.
+func walk(dir string, ownership Ownership, cb func(volumedelta.Entry, string, os.FileInfo) error) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		uid, gid := fileOwner(info)
+
+		entry := volumedelta.Entry{
+			Path: filepath.ToSlash(rel),
+			Mode: info.Mode(),
+			UID:  ownership.logical(uid),
+			GID:  ownership.logical(gid),
+		}
+
+		switch {
+		case info.Mode().IsRegular():
+			entry.Size = info.Size()
+		case info.Mode()&os.ModeSymlink != 0:
+			entry.Linkname, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		return cb(entry, path, info)
+	})
+}
+
+// Digests caches the digests of a volume's files, so that a volume is only
+// hashed in full the first time a manifest or delta is computed for it.
+//
+// A cached digest is reused for as long as the file's size, modification time
+// and change time stay the same. The change time can't be set from user
+// space, so rewriting a file and restoring its modification time is still
+// noticed.
+//
+// A nil *Digests hashes every file.
+type Digests struct {
+	path string
+
+	lock  sync.Mutex
+	files map[string]cachedDigest
+	used  map[string]bool
+	dirty bool
+}
+
+type cachedDigest struct {
+	Size       int64  `json:"size"`
+	ModTime    int64  `json:"mtime"`
+	ChangeTime int64  `json:"ctime"`
+	Digest     string `json:"digest"`
+}
+
+// LoadDigests loads the digests cached in the file at the path. The cache
+// starts out empty if the file is missing or can't be read.
+func LoadDigests(path string) *Digests {
+	digests := &Digests{
+		path:  path,
+		files: map[string]cachedDigest{},
+		used:  map[string]bool{},
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err == nil {
+		_ = json.Unmarshal(contents, &digests.files)
+	}
+
+	return digests
+}
+
+// Digest returns the digest of the file at the path, which is rel within the
+// volume.
+func (digests *Digests) Digest(rel string, path string, info os.FileInfo) (string, error) {
+	if digests == nil {
+		return fileDigest(path)
+	}
+
+	key := cachedDigest{
+		Size:       info.Size(),
+		ModTime:    info.ModTime().UnixNano(),
+		ChangeTime: fileChangeTime(info),
+	}
+
+	digests.lock.Lock()
+	cached, found := digests.files[rel]
+	digests.used[rel] = true
+	digests.lock.Unlock()
+
+	if found {
+		key.Digest = cached.Digest
+		if key == cached {
+			return cached.Digest, nil
+		}
+	}
+
+	digest, err := fileDigest(path)
+	if err != nil {
+		return "", err
+	}
+
+	key.Digest = digest
+
+	digests.lock.Lock()
+	digests.files[rel] = key
+	digests.dirty = true
+	digests.lock.Unlock()
+
+	return digest, nil
+}
+
+// Save writes the digests of the files which were looked up back to the
+// cache, forgetting the others, if any digest had to be computed.
+func (digests *Digests) Save() error {
+	if digests == nil {
+		return nil
+	}
+
+	digests.lock.Lock()
+	defer digests.lock.Unlock()
+
+	if !digests.dirty {
+		return nil
+	}
+
+	files := map[string]cachedDigest{}
+	for rel := range digests.used {
+		if cached, found := digests.files[rel]; found {
+			files[rel] = cached
+		}
+	}
+
+	contents, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(digests.path), filepath.Base(digests.path))
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(contents)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	// concurrent requests for the same volume each replace the cache as a
+	// whole; whichever finishes last wins
+	return os.Rename(tmp.Name(), digests.path)
+}
+
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,33 @@
+// +build !windows
+
+package delta
+
+import (
+	"archive/tar"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+func fileOwner(info os.FileInfo) (int, int) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0
+	}
+
+	return int(stat.Uid), int(stat.Gid)
+}
+
+func mknod(path string, typeflag byte, perm uint32, major, minor int64) error {
+	switch typeflag {
+	case tar.TypeChar:
+		perm |= unix.S_IFCHR
+	case tar.TypeBlock:
+		perm |= unix.S_IFBLK
+	case tar.TypeFifo:
+		perm |= unix.S_IFIFO
+	}
+
+	return unix.Mknod(path, perm, int(unix.Mkdev(uint32(major), uint32(minor))))
+}
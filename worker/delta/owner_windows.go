@@ -0,0 +1,14 @@
+package delta
+
+import (
+	"errors"
+	"os"
+)
+
+func fileOwner(info os.FileInfo) (int, int) {
+	return 0, 0
+}
+
+func mknod(path string, typeflag byte, perm uint32, major, minor int64) error {
+	return errors.New("device files are not supported on windows")
+}
@@ -0,0 +1,427 @@
+package delta
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/api"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/volumedelta"
+	"github.com/tedsuo/rata"
+)
+
+var ErrVolumeNotFound = errors.New("volume not found")
+var ErrGetP2pUrlFailed = errors.New("failed to get p2p url")
+
+// digestsFile is the name of the file in which the digests of a volume's files
+// are cached, next to the directory holding the volume's data.
+const digestsFile = "delta-digests.json"
+
+// Server serves delta streams in front of the worker's Baggageclaim server.
+// Requests for any other route are proxied through to Baggageclaim, so that
+// the ATC can reach both through the same forwarded address.
+//
+// Volumes are read and written directly in Baggageclaim's volumes directory,
+// as Baggageclaim's API has no way to compare or remove individual files. The
+// digests of each volume's files are cached alongside its data, so that they
+// go away along with the volume.
+type Server struct {
+	logger lager.Logger
+
+	volumesDir string
+	maxID      int
+
+	p2pInterfacePattern *regexp.Regexp
+	p2pInterfaceFamily  int
+	p2pPort             uint16
+
+	httpClient   *http.Client
+	router       http.Handler
+	baggageclaim http.Handler
+}
+
+func NewServer(
+	logger lager.Logger,
+	volumesDir string,
+	maxID int,
+	baggageclaimURL *url.URL,
+	p2pInterfacePattern *regexp.Regexp,
+	p2pInterfaceFamily int,
+	p2pPort uint16,
+) (*Server, error) {
+	proxy := httputil.NewSingleHostReverseProxy(baggageclaimURL)
+
+	// stream-in and stream-out requests are large; don't buffer them
+	proxy.FlushInterval = -1
+
+	server := &Server{
+		logger: logger,
+
+		volumesDir: volumesDir,
+		maxID:      maxID,
+
+		p2pInterfacePattern: p2pInterfacePattern,
+		p2pInterfaceFamily:  p2pInterfaceFamily,
+		p2pPort:             p2pPort,
+
+		httpClient:   &http.Client{},
+		baggageclaim: proxy,
+	}
+
+	router, err := rata.NewRouter(volumedelta.Routes, rata.Handlers{
+		volumedelta.GetManifest:  http.HandlerFunc(server.GetManifest),
+		volumedelta.StreamOut:    http.HandlerFunc(server.StreamOut),
+		volumedelta.StreamIn:     http.HandlerFunc(server.StreamIn),
+		volumedelta.StreamP2pOut: http.HandlerFunc(server.StreamP2pOut),
+		volumedelta.GetP2pUrl:    http.HandlerFunc(server.GetP2pUrl),
+		volumedelta.GetSize:      http.HandlerFunc(server.GetSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	server.router = router
+
+	return server, nil
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if isDeltaRoute(r.URL.Path) {
+		server.router.ServeHTTP(w, r)
+	} else {
+		server.baggageclaim.ServeHTTP(w, r)
+	}
+}
+
+func isDeltaRoute(path string) bool {
+	if strings.HasPrefix(path, "/delta/") {
+		return true
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	return len(segments) == 4 && segments[0] == "volumes" && segments[2] == "delta"
+}
+
+func (server *Server) GetManifest(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+
+	logger := server.logger.Session("get-manifest", lager.Data{"volume": handle})
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	dataPath, ownership, digests, err := server.lookupVolume(handle)
+	if err != nil {
+		respondWithLookupError(logger, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = WriteManifest(w, dataPath, ownership, digests)
+	if err != nil {
+		// too late to change the status; the client will fail to decode the
+		// truncated stream
+		logger.Error("failed-to-write-manifest", err)
+		return
+	}
+
+	saveDigests(logger, digests)
+}
+
+func (server *Server) StreamOut(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	encoding := baggageclaim.Encoding(r.URL.Query().Get("encoding"))
+
+	logger := server.logger.Session("stream-out", lager.Data{
+		"volume":   handle,
+		"encoding": encoding,
+	})
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	dataPath, ownership, digests, err := server.lookupVolume(handle)
+	if err != nil {
+		respondWithLookupError(logger, w, err)
+		return
+	}
+
+	comp, err := compression.ForEncoding(encoding)
+	if err != nil {
+		api.RespondWithError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	base, err := volumedelta.ReadManifest(r.Body)
+	if err != nil {
+		logger.Error("failed-to-read-base-manifest", err)
+		api.RespondWithError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	err = server.writeDelta(w, comp, dataPath, base, ownership, digests)
+	if err != nil {
+		logger.Error("failed-to-write-delta", err)
+		return
+	}
+
+	saveDigests(logger, digests)
+}
+
+func (server *Server) StreamIn(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	encoding := baggageclaim.Encoding(r.URL.Query().Get("encoding"))
+
+	logger := server.logger.Session("stream-in", lager.Data{
+		"volume":   handle,
+		"encoding": encoding,
+	})
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	dataPath, ownership, _, err := server.lookupVolume(handle)
+	if err != nil {
+		respondWithLookupError(logger, w, err)
+		return
+	}
+
+	comp, err := compression.ForEncoding(encoding)
+	if err != nil {
+		api.RespondWithError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	reader, err := comp.NewReader(r.Body)
+	if err != nil {
+		logger.Error("failed-to-decompress", err)
+		api.RespondWithError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	defer reader.Close()
+
+	err = Apply(reader, dataPath, ownership)
+	if err != nil {
+		logger.Error("failed-to-apply-delta", err)
+		api.RespondWithError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (server *Server) StreamP2pOut(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	encoding := baggageclaim.Encoding(r.URL.Query().Get("encoding"))
+	destURL := r.URL.Query().Get("dest_url")
+	destHandle := r.URL.Query().Get("dest_handle")
+
+	logger := server.logger.Session("stream-p2p-out", lager.Data{
+		"volume":      handle,
+		"encoding":    encoding,
+		"dest-url":    destURL,
+		"dest-handle": destHandle,
+	})
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	dataPath, ownership, digests, err := server.lookupVolume(handle)
+	if err != nil {
+		respondWithLookupError(logger, w, err)
+		return
+	}
+
+	comp, err := compression.ForEncoding(encoding)
+	if err != nil {
+		api.RespondWithError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	dest := volumedelta.NewClient(destURL, server.httpClient)
+
+	manifest, err := dest.Manifest(r.Context(), destHandle)
+	if err != nil {
+		logger.Error("failed-to-get-base-manifest", err)
+		api.RespondWithError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	base, err := volumedelta.ReadManifest(manifest)
+	manifest.Close()
+	if err != nil {
+		logger.Error("failed-to-read-base-manifest", err)
+		api.RespondWithError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(server.writeDelta(pw, comp, dataPath, base, ownership, digests))
+	}()
+
+	err = dest.StreamIn(r.Context(), destHandle, encoding, pr)
+	pr.Close()
+	if err != nil {
+		logger.Error("failed-to-stream-in", err)
+		api.RespondWithError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	saveDigests(logger, digests)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (server *Server) GetSize(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+
+	logger := server.logger.Session("get-size", lager.Data{"volume": handle})
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	dataPath, _, _, err := server.lookupVolume(handle)
+	if err != nil {
+		respondWithLookupError(logger, w, err)
+		return
+	}
+
+	size, err := Size(dataPath)
+	if err != nil {
+		logger.Error("failed-to-get-size", err)
+		api.RespondWithError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprint(w, size)
+}
+
+func (server *Server) GetP2pUrl(w http.ResponseWriter, r *http.Request) {
+	logger := server.logger.Session("get-p2p-url")
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		logger.Error("failed-to-list-interfaces", err)
+		api.RespondWithError(w, ErrGetP2pUrlFailed, http.StatusInternalServerError)
+		return
+	}
+
+	for _, iface := range ifaces {
+		if !server.p2pInterfacePattern.MatchString(iface.Name) {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			logger.Error("failed-to-list-addresses", err)
+			api.RespondWithError(w, ErrGetP2pUrlFailed, http.StatusInternalServerError)
+			return
+		}
+
+		for _, addr := range addrs {
+			var ip net.IP
+			switch v := addr.(type) {
+			case *net.IPNet:
+				ip = v.IP
+			case *net.IPAddr:
+				ip = v.IP
+			}
+
+			isIPv4 := ip.To4() != nil
+			if isIPv4 != (server.p2pInterfaceFamily != 6) {
+				continue
+			}
+
+			fmt.Fprintf(w, "http://%s", net.JoinHostPort(ip.String(), fmt.Sprint(server.p2pPort)))
+			return
+		}
+	}
+
+	api.RespondWithError(w, ErrGetP2pUrlFailed, http.StatusInternalServerError)
+}
+
+func (server *Server) writeDelta(w io.Writer, comp compression.Compression, dataPath string, base volumedelta.Manifest, ownership Ownership, digests *Digests) error {
+	compressor, err := comp.NewWriter(w)
+	if err != nil {
+		return err
+	}
+
+	err = Write(compressor, dataPath, base, ownership, digests)
+	if err != nil {
+		compressor.Close()
+		return err
+	}
+
+	return compressor.Close()
+}
+
+// lookupVolume returns the path to the volume's data, following the layout of
+// Baggageclaim's filesystem, along with how its files' ownership is stored and
+// the cached digests of its files.
+func (server *Server) lookupVolume(handle string) (string, Ownership, *Digests, error) {
+	if handle == "" || handle == "." || handle == ".." || strings.ContainsAny(handle, `/\`) {
+		return "", Ownership{}, nil, ErrVolumeNotFound
+	}
+
+	volumePath := filepath.Join(server.volumesDir, "live", handle)
+
+	dataPath := filepath.Join(volumePath, "volume")
+	if _, err := os.Stat(dataPath); err != nil {
+		if os.IsNotExist(err) {
+			return "", Ownership{}, nil, ErrVolumeNotFound
+		}
+
+		return "", Ownership{}, nil, err
+	}
+
+	ownership := Ownership{MaxID: server.maxID}
+
+	privileged, err := os.Open(filepath.Join(volumePath, "privileged.json"))
+	if err != nil && !os.IsNotExist(err) {
+		return "", Ownership{}, nil, err
+	}
+
+	if err == nil {
+		defer privileged.Close()
+
+		err = json.NewDecoder(privileged).Decode(&ownership.Privileged)
+		if err != nil {
+			return "", Ownership{}, nil, err
+		}
+	}
+
+	return dataPath, ownership, LoadDigests(filepath.Join(volumePath, digestsFile)), nil
+}
+
+// saveDigests caches the digests computed while serving a request. Failing to
+// do so only means they are computed again next time.
+func saveDigests(logger lager.Logger, digests *Digests) {
+	err := digests.Save()
+	if err != nil {
+		logger.Error("failed-to-save-digests", err)
+	}
+}
+
+func respondWithLookupError(logger lager.Logger, w http.ResponseWriter, err error) {
+	if err == ErrVolumeNotFound {
+		logger.Info("volume-not-found")
+		api.RespondWithError(w, err, http.StatusNotFound)
+		return
+	}
+
+	logger.Error("failed-to-lookup-volume", err)
+	api.RespondWithError(w, err, http.StatusInternalServerError)
+}
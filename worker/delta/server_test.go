@@ -0,0 +1,163 @@
+package delta_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/volumedelta"
+	"github.com/concourse/concourse/worker/delta"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		fakeBaggageclaim *ghttp.Server
+
+		sourceDir    string
+		sourceServer *httptest.Server
+		sourceClient volumedelta.Client
+
+		destDir    string
+		destServer *httptest.Server
+		destClient volumedelta.Client
+	)
+
+	newServer := func() (string, *httptest.Server) {
+		volumesDir, err := ioutil.TempDir("", "delta-volumes")
+		Expect(err).ToNot(HaveOccurred())
+
+		baggageclaimURL, err := url.Parse(fakeBaggageclaim.URL())
+		Expect(err).ToNot(HaveOccurred())
+
+		server, err := delta.NewServer(
+			lagertest.NewTestLogger("delta"),
+			volumesDir,
+			0,
+			baggageclaimURL,
+			regexp.MustCompile("lo"),
+			4,
+			7788,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		return volumesDir, httptest.NewServer(server)
+	}
+
+	writeFile := func(volumesDir, handle, path, content string) {
+		full := filepath.Join(volumesDir, "live", handle, "volume", path)
+		Expect(os.MkdirAll(filepath.Dir(full), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(full, []byte(content), 0644)).To(Succeed())
+	}
+
+	readFile := func(volumesDir, handle, path string) string {
+		content, err := ioutil.ReadFile(filepath.Join(volumesDir, "live", handle, "volume", path))
+		Expect(err).ToNot(HaveOccurred())
+		return string(content)
+	}
+
+	BeforeEach(func() {
+		fakeBaggageclaim = ghttp.NewServer()
+
+		sourceDir, sourceServer = newServer()
+		sourceClient = volumedelta.NewClient(sourceServer.URL, http.DefaultClient)
+
+		destDir, destServer = newServer()
+		destClient = volumedelta.NewClient(destServer.URL, http.DefaultClient)
+
+		writeFile(sourceDir, "source-volume", "unchanged", "same")
+		writeFile(sourceDir, "source-volume", "changed", "new")
+
+		writeFile(destDir, "dest-volume", "unchanged", "same")
+		writeFile(destDir, "dest-volume", "changed", "old")
+		writeFile(destDir, "dest-volume", "removed", "gone")
+	})
+
+	AfterEach(func() {
+		sourceServer.Close()
+		destServer.Close()
+		fakeBaggageclaim.Close()
+
+		Expect(os.RemoveAll(sourceDir)).To(Succeed())
+		Expect(os.RemoveAll(destDir)).To(Succeed())
+	})
+
+	It("proxies other requests through to baggageclaim", func() {
+		fakeBaggageclaim.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/volumes/some-volume"),
+			ghttp.RespondWith(http.StatusOK, `{"handle":"some-volume"}`),
+		))
+
+		response, err := http.Get(sourceServer.URL + "/volumes/some-volume")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(`{"handle":"some-volume"}`))
+	})
+
+	Describe("streaming through the client", func() {
+		It("brings the destination up to date with the source", func() {
+			manifest, err := destClient.Manifest(context.TODO(), "dest-volume")
+			Expect(err).ToNot(HaveOccurred())
+
+			out, err := sourceClient.StreamOut(context.TODO(), "source-volume", baggageclaim.ZstdEncoding, manifest)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = destClient.StreamIn(context.TODO(), "dest-volume", baggageclaim.ZstdEncoding, out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(readFile(destDir, "dest-volume", "changed")).To(Equal("new"))
+			Expect(readFile(destDir, "dest-volume", "unchanged")).To(Equal("same"))
+			Expect(filepath.Join(destDir, "live", "dest-volume", "volume", "removed")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Describe("streaming p2p", func() {
+		It("brings the destination up to date with the source", func() {
+			err := sourceClient.StreamP2pOut(context.TODO(), "source-volume", baggageclaim.GzipEncoding, destServer.URL, "dest-volume")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(readFile(destDir, "dest-volume", "changed")).To(Equal("new"))
+			Expect(filepath.Join(destDir, "live", "dest-volume", "volume", "removed")).ToNot(BeAnExistingFile())
+		})
+
+		It("advertises its address on the p2p interface", func() {
+			p2pURL, err := sourceClient.GetP2pUrl(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(p2pURL).To(Equal("http://127.0.0.1:7788"))
+		})
+	})
+
+	Describe("measuring the size of a volume", func() {
+		It("adds up the sizes of its files", func() {
+			size, err := sourceClient.Size(context.TODO(), "source-volume")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size).To(Equal(int64(len("same") + len("new"))))
+		})
+	})
+
+	Context("when the volume does not exist", func() {
+		It("returns an error", func() {
+			_, err := sourceClient.Manifest(context.TODO(), "bogus")
+			Expect(err).To(MatchError(ContainSubstring("volume not found")))
+
+			_, err = sourceClient.StreamOut(context.TODO(), "bogus", baggageclaim.GzipEncoding, bytes.NewBufferString(""))
+			Expect(err).To(MatchError(ContainSubstring("volume not found")))
+
+			_, err = sourceClient.Size(context.TODO(), "bogus")
+			Expect(err).To(MatchError(ContainSubstring("volume not found")))
+		})
+	})
+})
@@ -0,0 +1,33 @@
+package evict
+
+import (
+	"context"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/worker"
+)
+
+type EvictWorkerCommand struct {
+	TSA worker.TSAConfig `group:"TSA Configuration" namespace:"tsa" required:"true"`
+
+	WorkerName string `long:"name" required:"true" description:"The name of the worker you wish to evict."`
+	WorkerTeam string `long:"team" description:"The team name of the worker you wish to evict."`
+}
+
+func (cmd *EvictWorkerCommand) Execute(args []string) error {
+	logger := lager.NewLogger("evict-worker")
+	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
+
+	client, err := cmd.TSA.Client(atc.Worker{
+		Name: cmd.WorkerName,
+		Team: cmd.WorkerTeam,
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.Evict(lagerctx.NewContext(context.Background(), logger))
+}
@@ -8,6 +8,7 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -29,6 +30,10 @@ type GardenBackend struct {
 	rootfsManager RootfsManager
 	userNamespace UserNamespace
 	initBinPath   string
+	storePath     string
+	rootless      bool
+	blockDevices  []bespec.BlockDevice
+	secretFiles   SecretFiles
 
 	maxContainers  int
 	requestTimeout time.Duration
@@ -101,6 +106,42 @@ func WithInitBinPath(initBinPath string) GardenBackendOpt {
 	}
 }
 
+// WithStorePath configures the path whose filesystem backs the storage of
+// containers, used for reporting the disk capacity.
+//
+func WithStorePath(storePath string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.storePath = storePath
+	}
+}
+
+// WithRootless configures the backend for a containerd running in rootless
+// mode, where privileged containers are rejected.
+//
+func WithRootless() GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.rootless = true
+	}
+}
+
+// WithBlockDevices configures the block devices whose IO gets throttled for
+// containers with IO limits.
+//
+func WithBlockDevices(devices []bespec.BlockDevice) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.blockDevices = devices
+	}
+}
+
+// WithSecretFiles configures the tmpfs mounts holding the secret files of
+// containers. Without it, containers with secret files are rejected.
+//
+func WithSecretFiles(s SecretFiles) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.secretFiles = s
+	}
+}
+
 // NewGardenBackend instantiates a GardenBackend with tweakable configurations passed as Config.
 //
 func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b GardenBackend, err error) {
@@ -141,6 +182,10 @@ func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b
 		b.userNamespace = NewUserNamespace()
 	}
 
+	if b.storePath == "" {
+		b.storePath = "/"
+	}
+
 	// Because the garden server is created programmatically in the integration tests, add
 	// a sane default path
 	if b.initBinPath == "" {
@@ -189,6 +234,10 @@ func (b *GardenBackend) Ping() (err error) {
 func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container, error) {
 	ctx := context.Background()
 
+	if b.rootless && gdnSpec.Privileged {
+		return nil, ErrPrivilegedRootless
+	}
+
 	cont, err := b.createContainer(ctx, gdnSpec)
 	if err != nil {
 		return nil, fmt.Errorf("new container: %w", err)
@@ -203,6 +252,7 @@ func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container,
 		cont,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
@@ -229,6 +279,37 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 		return nil, fmt.Errorf("garden spec to oci spec: %w", err)
 	}
 
+	ioLimits, err := bespec.ParseIOLimits(gdnSpec.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("io limits: %w", err)
+	}
+
+	if !ioLimits.Unlimited() {
+		if len(b.blockDevices) == 0 {
+			return nil, ErrIOLimitsNotSupported
+		}
+
+		oci.Linux.Resources.BlockIO = bespec.OciBlockIO(ioLimits, b.blockDevices)
+	}
+
+	secretFilesDir, hasSecretFiles := gdnSpec.Properties[bespec.SecretFilesProperty]
+	if hasSecretFiles {
+		if b.secretFiles == nil {
+			return nil, ErrSecretFilesNotSupported
+		}
+
+		if !filepath.IsAbs(secretFilesDir) {
+			return nil, ErrInvalidInput("secret files dir must be absolute")
+		}
+
+		secretFilesPath, err := b.secretFiles.Mount(gdnSpec.Handle)
+		if err != nil {
+			return nil, fmt.Errorf("secret files mount: %w", err)
+		}
+
+		oci.Mounts = append(oci.Mounts, bespec.SecretFilesMount(secretFilesPath, secretFilesDir))
+	}
+
 	netMounts, err := b.network.SetupMounts(gdnSpec.Handle)
 	if err != nil {
 		return nil, fmt.Errorf("network setup mounts: %w", err)
@@ -236,7 +317,12 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 
 	oci.Mounts = append(oci.Mounts, netMounts...)
 
-	return b.client.NewContainer(ctx, gdnSpec.Handle, gdnSpec.Properties, oci)
+	cont, err := b.client.NewContainer(ctx, gdnSpec.Handle, gdnSpec.Properties, oci)
+	if err != nil && hasSecretFiles {
+		_ = b.secretFiles.Unmount(gdnSpec.Handle)
+	}
+
+	return cont, err
 }
 
 func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container) error {
@@ -245,11 +331,18 @@ func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container
 		return fmt.Errorf("new task: %w", err)
 	}
 
-	err = b.network.Add(ctx, task)
+	properties, err := b.network.Add(ctx, task)
 	if err != nil {
 		return fmt.Errorf("network add: %w", err)
 	}
 
+	if len(properties) > 0 {
+		_, err = cont.SetLabels(ctx, properties)
+		if err != nil {
+			return fmt.Errorf("set network labels: %w", err)
+		}
+	}
+
 	return task.Start(ctx)
 }
 
@@ -278,7 +371,7 @@ func (b *GardenBackend) Destroy(handle string) error {
 			return fmt.Errorf("deleting container: %w", err)
 		}
 
-		return nil
+		return b.removeSecretFiles(handle)
 	}
 
 	err = b.killer.Kill(ctx, task, KillGracefully)
@@ -301,6 +394,22 @@ func (b *GardenBackend) Destroy(handle string) error {
 		return fmt.Errorf("deleting container: %w", err)
 	}
 
+	return b.removeSecretFiles(handle)
+}
+
+// removeSecretFiles gets rid of the tmpfs holding the secret files of a
+// container, if it has one.
+//
+func (b *GardenBackend) removeSecretFiles(handle string) error {
+	if b.secretFiles == nil {
+		return nil
+	}
+
+	err := b.secretFiles.Unmount(handle)
+	if err != nil {
+		return fmt.Errorf("secret files unmount: %w", err)
+	}
+
 	return nil
 }
 
@@ -325,6 +434,7 @@ func (b *GardenBackend) Containers(properties garden.Properties) (containers []g
 			containerdContainer,
 			b.killer,
 			b.rootfsManager,
+			b.network,
 		)
 	}
 
@@ -347,6 +457,7 @@ func (b *GardenBackend) Lookup(handle string) (garden.Container, error) {
 		containerdContainer,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
@@ -366,24 +477,80 @@ func (b *GardenBackend) GraceTime(container garden.Container) (duration time.Dur
 	return duration
 }
 
-// Capacity - Not Implemented
+// Capacity returns the total memory of the host, the size of the filesystem
+// backing the containers' storage, and the max number of containers.
 //
 func (b *GardenBackend) Capacity() (capacity garden.Capacity, err error) {
-	err = ErrNotImplemented
+	memory, err := totalMemory(filepath.Join(procRoot, "meminfo"))
+	if err != nil {
+		err = fmt.Errorf("total memory: %w", err)
+		return
+	}
+
+	disk, err := totalDisk(b.storePath)
+	if err != nil {
+		err = fmt.Errorf("total disk: %w", err)
+		return
+	}
+
+	capacity = garden.Capacity{
+		MemoryInBytes: memory,
+		DiskInBytes:   disk,
+		MaxContainers: uint64(b.maxContainers),
+	}
+
 	return
 }
 
-// BulkInfo - Not Implemented
+// BulkInfo retrieves the info of each of the containers specified by
+// `handles`. Failures to retrieve the info of a container are reported in
+// its entry rather than failing the whole call.
 //
 func (b *GardenBackend) BulkInfo(handles []string) (info map[string]garden.ContainerInfoEntry, err error) {
-	err = ErrNotImplemented
+	info = make(map[string]garden.ContainerInfoEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			info[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerInfo, err := container.Info()
+		if err != nil {
+			info[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		info[handle] = garden.ContainerInfoEntry{Info: containerInfo}
+	}
+
 	return
 }
 
-// BulkMetrics - Not Implemented
+// BulkMetrics retrieves the metrics of each of the containers specified by
+// `handles`. Failures to retrieve the metrics of a container are reported in
+// its entry rather than failing the whole call.
 //
 func (b *GardenBackend) BulkMetrics(handles []string) (metrics map[string]garden.ContainerMetricsEntry, err error) {
-	err = ErrNotImplemented
+	metrics = make(map[string]garden.ContainerMetricsEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerMetrics, err := container.Metrics()
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		metrics[handle] = garden.ContainerMetricsEntry{Metrics: containerMetrics}
+	}
+
 	return
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -146,6 +147,24 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreateContainerStoresNetworkAddresses() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.network.AddReturns(garden.Properties{
+		runtime.NetworkContainerIPKey: "10.80.0.2",
+	}, nil)
+
+	s.client.NewContainerReturns(fakeContainer, nil)
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, fakeContainer.SetLabelsCallCount())
+	_, labels := fakeContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{runtime.NetworkContainerIPKey: "10.80.0.2"}, labels)
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
@@ -522,3 +541,250 @@ func (s *BackendSuite) TestGraceTimeReturnsDuration() {
 	result := s.backend.GraceTime(fakeContainer)
 	s.Equal(time.Duration(123), result)
 }
+
+func (s *BackendSuite) TestCapacity() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithMaxContainers(10),
+		runtime.WithStorePath(s.T().TempDir()),
+	)
+	s.NoError(err)
+
+	capacity, err := backend.Capacity()
+	s.NoError(err)
+	s.NotZero(capacity.MemoryInBytes)
+	s.NotZero(capacity.DiskInBytes)
+	s.Equal(uint64(10), capacity.MaxContainers)
+}
+
+func (s *BackendSuite) TestCapacityMissingStorePath() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithStorePath("/this/does/not/exist"),
+	)
+	s.NoError(err)
+
+	_, err = backend.Capacity()
+	s.Error(err)
+}
+
+func (s *BackendSuite) TestBulkInfo() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.SpecReturns(&specs.Spec{}, nil)
+	fakeContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	s.client.GetContainerStub = func(_ context.Context, handle string) (containerd.Container, error) {
+		if handle == "missing" {
+			return nil, errors.New("not found")
+		}
+
+		return fakeContainer, nil
+	}
+
+	info, err := s.backend.BulkInfo([]string{"handle", "missing"})
+	s.NoError(err)
+	s.Len(info, 2)
+
+	s.Nil(info["handle"].Err)
+	s.Equal("stopped", info["handle"].Info.State)
+	s.NotNil(info["missing"].Err)
+}
+
+func (s *BackendSuite) TestBulkMetrics() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.TaskReturns(nil, errors.New("task-err"))
+
+	s.client.GetContainerStub = func(_ context.Context, handle string) (containerd.Container, error) {
+		if handle == "missing" {
+			return nil, errors.New("not found")
+		}
+
+		return fakeContainer, nil
+	}
+
+	metrics, err := s.backend.BulkMetrics([]string{"handle", "missing"})
+	s.NoError(err)
+	s.Len(metrics, 2)
+
+	s.NotNil(metrics["handle"].Err)
+	s.Contains(metrics["handle"].Err.Error(), "task-err")
+	s.NotNil(metrics["missing"].Err)
+}
+
+func (s *BackendSuite) TestCreateRootlessRejectsPrivileged() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithRootless(),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Privileged: true,
+	})
+	s.Equal(runtime.ErrPrivilegedRootless, err)
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateRootlessGetsOwnNetworkNamespace() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithRootless(),
+	)
+	s.NoError(err)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err = backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, s.client.NewContainerCallCount())
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Contains(oci.Linux.Namespaces, specs.LinuxNamespace{Type: specs.NetworkNamespace})
+}
+
+func (s *BackendSuite) TestCreateWithIOLimits() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithBlockDevices([]bespec.BlockDevice{{Major: 8, Minor: 0}}),
+	)
+	s.NoError(err)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err = backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			bespec.IOWriteBPSProperty: "1048576",
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.client.NewContainerCallCount())
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.NotNil(oci.Linux.Resources.BlockIO)
+	s.Len(oci.Linux.Resources.BlockIO.ThrottleWriteBpsDevice, 1)
+	s.Equal(uint64(1048576), oci.Linux.Resources.BlockIO.ThrottleWriteBpsDevice[0].Rate)
+	s.Equal(int64(8), oci.Linux.Resources.BlockIO.ThrottleWriteBpsDevice[0].Major)
+	s.Empty(oci.Linux.Resources.BlockIO.ThrottleReadBpsDevice)
+}
+
+func (s *BackendSuite) TestCreateWithIOLimitsWithoutBlockDevices() {
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			bespec.IOReadIOPSProperty: "100",
+		},
+	})
+	s.True(errors.Is(err, runtime.ErrIOLimitsNotSupported))
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateWithSecretFiles() {
+	secretFiles := new(runtimefakes.FakeSecretFiles)
+	secretFiles.MountReturns("/work-dir/secrets/handle", nil)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSecretFiles(secretFiles),
+	)
+	s.NoError(err)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err = backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			bespec.SecretFilesProperty: "/run/secrets",
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, secretFiles.MountCallCount())
+	s.Equal("handle", secretFiles.MountArgsForCall(0))
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Contains(oci.Mounts, bespec.SecretFilesMount("/work-dir/secrets/handle", "/run/secrets"))
+}
+
+func (s *BackendSuite) TestCreateWithSecretFilesUnmountsOnFailure() {
+	secretFiles := new(runtimefakes.FakeSecretFiles)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSecretFiles(secretFiles),
+	)
+	s.NoError(err)
+
+	s.client.NewContainerReturns(nil, errors.New("new-container-failed"))
+
+	_, err = backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			bespec.SecretFilesProperty: "/run/secrets",
+		},
+	})
+	s.Error(err)
+
+	s.Equal(1, secretFiles.UnmountCallCount())
+	s.Equal("handle", secretFiles.UnmountArgsForCall(0))
+}
+
+func (s *BackendSuite) TestCreateWithSecretFilesNotSupported() {
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			bespec.SecretFilesProperty: "/run/secrets",
+		},
+	})
+	s.True(errors.Is(err, runtime.ErrSecretFilesNotSupported))
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestDestroyUnmountsSecretFiles() {
+	secretFiles := new(runtimefakes.FakeSecretFiles)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSecretFiles(secretFiles),
+	)
+	s.NoError(err)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.TaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.GetContainerReturns(fakeContainer, nil)
+
+	err = backend.Destroy("handle")
+	s.NoError(err)
+
+	s.Equal(1, secretFiles.UnmountCallCount())
+	s.Equal("handle", secretFiles.UnmountArgsForCall(0))
+}
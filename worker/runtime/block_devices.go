@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+)
+
+// SysfsRoot is the mountpoint of the sys filesystem.
+//
+const SysfsRoot = "/sys"
+
+// WholeDisk resolves a block device to the disk it belongs to, as block IO
+// can only be throttled for whole disks, not their partitions.
+//
+func WholeDisk(sysfs string, device bespec.BlockDevice) (bespec.BlockDevice, error) {
+	dir := filepath.Join(sysfs, "dev", "block", fmt.Sprintf("%d:%d", device.Major, device.Minor))
+
+	_, err := os.Stat(dir)
+	if err != nil {
+		return bespec.BlockDevice{}, fmt.Errorf("block device %d:%d: %w", device.Major, device.Minor, err)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "partition"))
+	if os.IsNotExist(err) {
+		return device, nil
+	}
+	if err != nil {
+		return bespec.BlockDevice{}, fmt.Errorf("stat partition: %w", err)
+	}
+
+	// `dev/block/<major>:<minor>` links to the device's directory, which for
+	// a partition is nested under the disk's.
+	//
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return bespec.BlockDevice{}, fmt.Errorf("resolve device dir: %w", err)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(filepath.Dir(resolved), "dev"))
+	if err != nil {
+		return bespec.BlockDevice{}, fmt.Errorf("read disk dev: %w", err)
+	}
+
+	var disk bespec.BlockDevice
+	_, err = fmt.Sscanf(strings.TrimSpace(string(content)), "%d:%d", &disk.Major, &disk.Minor)
+	if err != nil {
+		return bespec.BlockDevice{}, fmt.Errorf("parse disk dev: %w", err)
+	}
+
+	return disk, nil
+}
@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"fmt"
+
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+	"github.com/containerd/cgroups"
+	"golang.org/x/sys/unix"
+)
+
+// BlockDevices retrieves the disks backing the filesystem that `path` belongs
+// to, so that the block IO of containers writing to it can be throttled.
+//
+func BlockDevices(path string) ([]bespec.BlockDevice, error) {
+	var stat unix.Stat_t
+
+	err := unix.Stat(path, &stat)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	device := bespec.BlockDevice{
+		Major: int64(unix.Major(uint64(stat.Dev))),
+		Minor: int64(unix.Minor(uint64(stat.Dev))),
+	}
+
+	// anonymous devices (e.g., overlay or tmpfs) are not backed by a disk
+	//
+	if device.Major == 0 {
+		return nil, fmt.Errorf("%s is not backed by a block device", path)
+	}
+
+	disk, err := WholeDisk(SysfsRoot, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return []bespec.BlockDevice{disk}, nil
+}
+
+// CgroupsUnified indicates whether the host runs with the cgroups v2 unified
+// hierarchy, which is required for throttling buffered block IO.
+//
+func CgroupsUnified() bool {
+	return cgroups.Mode() == cgroups.Unified
+}
@@ -0,0 +1,19 @@
+// +build !linux
+
+package runtime
+
+import (
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+)
+
+// BlockDevices is only supported on Linux.
+//
+func BlockDevices(path string) ([]bespec.BlockDevice, error) {
+	return nil, ErrNotImplemented
+}
+
+// CgroupsUnified is only supported on Linux.
+//
+func CgroupsUnified() bool {
+	return false
+}
@@ -0,0 +1,59 @@
+package runtime_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/worker/runtime"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BlockDevicesSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	sysfs string
+}
+
+func (s *BlockDevicesSuite) SetupTest() {
+	var err error
+	s.sysfs, err = ioutil.TempDir("", "sysfs")
+	s.NoError(err)
+
+	// a disk (8:0) with a single partition (8:1)
+	//
+	disk := filepath.Join(s.sysfs, "devices", "pci0000:00", "block", "sda")
+	partition := filepath.Join(disk, "sda1")
+	s.NoError(os.MkdirAll(partition, 0755))
+	s.NoError(ioutil.WriteFile(filepath.Join(disk, "dev"), []byte("8:0\n"), 0644))
+	s.NoError(ioutil.WriteFile(filepath.Join(partition, "dev"), []byte("8:1\n"), 0644))
+	s.NoError(ioutil.WriteFile(filepath.Join(partition, "partition"), []byte("1\n"), 0644))
+
+	s.NoError(os.MkdirAll(filepath.Join(s.sysfs, "dev", "block"), 0755))
+	s.NoError(os.Symlink(disk, filepath.Join(s.sysfs, "dev", "block", "8:0")))
+	s.NoError(os.Symlink(partition, filepath.Join(s.sysfs, "dev", "block", "8:1")))
+}
+
+func (s *BlockDevicesSuite) TearDownTest() {
+	os.RemoveAll(s.sysfs)
+}
+
+func (s *BlockDevicesSuite) TestWholeDiskOfDisk() {
+	disk, err := runtime.WholeDisk(s.sysfs, bespec.BlockDevice{Major: 8, Minor: 0})
+	s.NoError(err)
+	s.Equal(bespec.BlockDevice{Major: 8, Minor: 0}, disk)
+}
+
+func (s *BlockDevicesSuite) TestWholeDiskOfPartition() {
+	disk, err := runtime.WholeDisk(s.sysfs, bespec.BlockDevice{Major: 8, Minor: 1})
+	s.NoError(err)
+	s.Equal(bespec.BlockDevice{Major: 8, Minor: 0}, disk)
+}
+
+func (s *BlockDevicesSuite) TestWholeDiskOfUnknownDevice() {
+	_, err := runtime.WholeDisk(s.sysfs, bespec.BlockDevice{Major: 253, Minor: 3})
+	s.Error(err)
+}
@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// totalMemory retrieves the total amount of usable memory (in bytes) from a
+// file in the `/proc/meminfo` format.
+//
+func totalMemory(meminfo string) (uint64, error) {
+	f, err := os.Open(meminfo)
+	if err != nil {
+		return 0, fmt.Errorf("open: %w", err)
+	}
+	defer f.Close()
+
+	return parseMemTotal(f)
+}
+
+func parseMemTotal(r io.Reader) (uint64, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse memtotal: %w", err)
+		}
+
+		if len(fields) > 2 && fields[2] == "kB" {
+			value *= 1024
+		}
+
+		return value, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("memtotal not found")
+}
+
+// totalDisk retrieves the size (in bytes) of the filesystem that `path`
+// belongs to.
+//
+func totalDisk(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("statfs: %w", err)
+	}
+
+	return stat.Blocks * uint64(stat.Bsize), nil
+}
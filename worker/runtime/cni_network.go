@@ -1,11 +1,16 @@
 package runtime
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime/iptables"
 	"github.com/containerd/containerd"
 	"github.com/containerd/go-cni"
@@ -49,6 +54,11 @@ const (
 	binariesDir = "/usr/local/concourse/bin"
 
 	ipTablesAdminChainName = "CONCOURSE-OPERATOR"
+
+	// procRoot is the mountpoint of the proc filesystem used for retrieving
+	// network statistics of tasks.
+	//
+	procRoot = "/proc"
 )
 
 var (
@@ -160,6 +170,15 @@ func WithRestrictedNetworks(restrictedNetworks []string) CNINetworkOpt {
 	}
 }
 
+// WithProcRoot changes the default location of the proc filesystem used for
+// retrieving network statistics.
+//
+func WithProcRoot(dir string) CNINetworkOpt {
+	return func(n *cniNetwork) {
+		n.procRoot = dir
+	}
+}
+
 // WithIptables allows for a custom implementation of the iptables.Iptables interface
 // to be provided.
 func WithIptables(ipt iptables.Iptables) CNINetworkOpt {
@@ -176,6 +195,7 @@ type cniNetwork struct {
 	binariesDir        string
 	restrictedNetworks []string
 	ipt                iptables.Iptables
+	procRoot           string
 }
 
 var _ Network = (*cniNetwork)(nil)
@@ -195,6 +215,10 @@ func NewCNINetwork(opts ...CNINetworkOpt) (*cniNetwork, error) {
 		n.binariesDir = binariesDir
 	}
 
+	if n.procRoot == "" {
+		n.procRoot = procRoot
+	}
+
 	if n.store == nil {
 		n.store = NewFileStore(fileStoreWorkDir)
 	}
@@ -226,44 +250,7 @@ func NewCNINetwork(opts ...CNINetworkOpt) (*cniNetwork, error) {
 }
 
 func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
-	if handle == "" {
-		return nil, ErrInvalidInput("empty handle")
-	}
-
-	etcHosts, err := n.store.Create(
-		filepath.Join(handle, "/hosts"),
-		[]byte("127.0.0.1 localhost"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating /etc/hosts: %w", err)
-	}
-
-	resolvContents, err := n.generateResolvConfContents()
-	if err != nil {
-		return nil, fmt.Errorf("generating resolv.conf: %w", err)
-	}
-
-	resolvConf, err := n.store.Create(
-		filepath.Join(handle, "/resolv.conf"),
-		resolvContents,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating /etc/resolv.conf: %w", err)
-	}
-
-	return []specs.Mount{
-		{
-			Destination: "/etc/hosts",
-			Type:        "bind",
-			Source:      etcHosts,
-			Options:     []string{"bind", "rw"},
-		}, {
-			Destination: "/etc/resolv.conf",
-			Type:        "bind",
-			Source:      resolvConf,
-			Options:     []string{"bind", "rw"},
-		},
-	}, nil
+	return setupEtcMounts(n.store, handle, n.nameServers)
 }
 
 func (n cniNetwork) SetupRestrictedNetworks() error {
@@ -289,48 +276,127 @@ func (n cniNetwork) SetupRestrictedNetworks() error {
 	return nil
 }
 
-func (n cniNetwork) generateResolvConfContents() ([]byte, error) {
-	contents := ""
-	resolvConfEntries := n.nameServers
-	var err error
+func (n cniNetwork) Add(ctx context.Context, task containerd.Task) (garden.Properties, error) {
+	if task == nil {
+		return nil, ErrInvalidInput("nil task")
+	}
 
-	if len(n.nameServers) == 0 {
-		resolvConfEntries, err = ParseHostResolveConf("/etc/resolv.conf")
+	id, netns := netId(task), netNsPath(task)
+
+	result, err := n.client.Setup(ctx, id, netns)
+	if err != nil {
+		return nil, fmt.Errorf("cni net setup: %w", err)
+	}
+
+	properties := garden.Properties{}
+	if result == nil {
+		return properties, nil
 	}
 
-	contents = strings.Join(resolvConfEntries, "\n")
+	// only interfaces living in the container's namespace (i.e., not the
+	// bridge) have a sandbox set.
+	//
+	for _, iface := range result.Interfaces {
+		if iface == nil || iface.Sandbox == "" {
+			continue
+		}
+
+		for _, ipConfig := range iface.IPConfigs {
+			if ipConfig == nil || ipConfig.IP == nil {
+				continue
+			}
+
+			properties[NetworkContainerIPKey] = ipConfig.IP.String()
+			if ipConfig.Gateway != nil {
+				properties[NetworkHostIPKey] = ipConfig.Gateway.String()
+			}
 
-	return []byte(contents), err
+			return properties, nil
+		}
+	}
+
+	return properties, nil
 }
 
-func (n cniNetwork) Add(ctx context.Context, task containerd.Task) error {
+func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
 	if task == nil {
 		return ErrInvalidInput("nil task")
 	}
 
 	id, netns := netId(task), netNsPath(task)
 
-	_, err := n.client.Setup(ctx, id, netns)
+	err := n.client.Remove(ctx, id, netns)
 	if err != nil {
-		return fmt.Errorf("cni net setup: %w", err)
+		return fmt.Errorf("cni net teardown: %w", err)
 	}
 
 	return nil
 }
 
-func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
+func (n cniNetwork) Stats(ctx context.Context, task containerd.Task) (garden.ContainerNetworkStat, error) {
 	if task == nil {
-		return ErrInvalidInput("nil task")
+		return garden.ContainerNetworkStat{}, ErrInvalidInput("nil task")
 	}
 
-	id, netns := netId(task), netNsPath(task)
+	// /proc/<pid>/net/dev reports the interfaces of the network namespace
+	// that the process belongs to.
+	//
+	f, err := os.Open(filepath.Join(n.procRoot, strconv.Itoa(int(task.Pid())), "net", "dev"))
+	if err != nil {
+		return garden.ContainerNetworkStat{}, fmt.Errorf("open net dev: %w", err)
+	}
+	defer f.Close()
 
-	err := n.client.Remove(ctx, id, netns)
+	stats, err := parseNetDev(f)
 	if err != nil {
-		return fmt.Errorf("cni net teardown: %w", err)
+		return garden.ContainerNetworkStat{}, fmt.Errorf("parse net dev: %w", err)
 	}
 
-	return nil
+	return stats, nil
+}
+
+// parseNetDev sums up the received and transmitted bytes of every interface
+// but the loopback one listed in the `/proc/<pid>/net/dev` format.
+//
+func parseNetDev(r io.Reader) (garden.ContainerNetworkStat, error) {
+	var stats garden.ContainerNetworkStat
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			// header lines
+			continue
+		}
+
+		if strings.TrimSpace(parts[0]) == "lo" {
+			continue
+		}
+
+		fields := strings.Fields(parts[1])
+		if len(fields) < 9 {
+			return garden.ContainerNetworkStat{}, fmt.Errorf("malformed line: %q", scanner.Text())
+		}
+
+		rx, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return garden.ContainerNetworkStat{}, fmt.Errorf("rx bytes: %w", err)
+		}
+
+		tx, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			return garden.ContainerNetworkStat{}, fmt.Errorf("tx bytes: %w", err)
+		}
+
+		stats.RxBytes += rx
+		stats.TxBytes += tx
+	}
+
+	if err := scanner.Err(); err != nil {
+		return garden.ContainerNetworkStat{}, err
+	}
+
+	return stats, nil
 }
 
 func netId(task containerd.Task) string {
@@ -3,12 +3,18 @@ package runtime_test
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/iptables/iptablesfakes"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/go-cni"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -169,7 +175,7 @@ func (s *CNINetworkSuite) TestSetupRestrictedNetworksCreatesEmptyAdminChain() {
 }
 
 func (s *CNINetworkSuite) TestAddNilTask() {
-	err := s.network.Add(context.Background(), nil)
+	_, err := s.network.Add(context.Background(), nil)
 	s.EqualError(err, "nil task")
 }
 
@@ -177,7 +183,7 @@ func (s *CNINetworkSuite) TestAddSetupErrors() {
 	s.cni.SetupReturns(nil, errors.New("setup-err"))
 	task := new(libcontainerdfakes.FakeTask)
 
-	err := s.network.Add(context.Background(), task)
+	_, err := s.network.Add(context.Background(), task)
 	s.EqualError(errors.Unwrap(err), "setup-err")
 }
 
@@ -186,7 +192,7 @@ func (s *CNINetworkSuite) TestAdd() {
 	task.PidReturns(123)
 	task.IDReturns("id")
 
-	err := s.network.Add(context.Background(), task)
+	_, err := s.network.Add(context.Background(), task)
 	s.NoError(err)
 
 	s.Equal(1, s.cni.SetupCallCount())
@@ -195,6 +201,82 @@ func (s *CNINetworkSuite) TestAdd() {
 	s.Equal("/proc/123/ns/net", netns)
 }
 
+func (s *CNINetworkSuite) TestAddReturnsAddresses() {
+	s.cni.SetupReturns(&cni.CNIResult{
+		Interfaces: map[string]*cni.Config{
+			"concourse0": {
+				IPConfigs: []*cni.IPConfig{
+					{IP: net.ParseIP("10.80.0.1")},
+				},
+			},
+			"eth0": {
+				Sandbox: "/proc/123/ns/net",
+				IPConfigs: []*cni.IPConfig{
+					{IP: net.ParseIP("10.80.0.2"), Gateway: net.ParseIP("10.80.0.1")},
+				},
+			},
+		},
+	}, nil)
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(123)
+
+	properties, err := s.network.Add(context.Background(), task)
+	s.NoError(err)
+	s.Equal(garden.Properties{
+		runtime.NetworkContainerIPKey: "10.80.0.2",
+		runtime.NetworkHostIPKey:      "10.80.0.1",
+	}, properties)
+}
+
+func (s *CNINetworkSuite) TestStatsNilTask() {
+	_, err := s.network.Stats(context.Background(), nil)
+	s.EqualError(err, "nil task")
+}
+
+func (s *CNINetworkSuite) TestStatsNoProcFile() {
+	network, err := runtime.NewCNINetwork(
+		runtime.WithCNIFileStore(s.store),
+		runtime.WithCNIClient(s.cni),
+		runtime.WithIptables(s.iptables),
+		runtime.WithProcRoot(s.T().TempDir()),
+	)
+	s.NoError(err)
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(123)
+
+	_, err = network.Stats(context.Background(), task)
+	s.Error(err)
+}
+
+func (s *CNINetworkSuite) TestStatsSumsNonLoopbackInterfaces() {
+	procRoot := s.T().TempDir()
+	s.NoError(os.MkdirAll(filepath.Join(procRoot, "123", "net"), 0755))
+	s.NoError(ioutil.WriteFile(filepath.Join(procRoot, "123", "net", "dev"), []byte(
+		`Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  eth0:    2048      20    0    0    0     0          0         0      512       5    0    0    0     0       0          0
+  eth1:     100       1    0    0    0     0          0         0       10       1    0    0    0     0       0          0
+`), 0644))
+
+	network, err := runtime.NewCNINetwork(
+		runtime.WithCNIFileStore(s.store),
+		runtime.WithCNIClient(s.cni),
+		runtime.WithIptables(s.iptables),
+		runtime.WithProcRoot(procRoot),
+	)
+	s.NoError(err)
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(123)
+
+	stats, err := network.Stats(context.Background(), task)
+	s.NoError(err)
+	s.Equal(garden.ContainerNetworkStat{RxBytes: 2148, TxBytes: 522}, stats)
+}
+
 func (s *CNINetworkSuite) TestRemoveNilTask() {
 	err := s.network.Remove(context.Background(), nil)
 	s.EqualError(err, "nil task")
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/go-archive/tarfs"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl"
 	uuid "github.com/nu7hatch/gouuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -28,17 +35,20 @@ type Container struct {
 	container     containerd.Container
 	killer        Killer
 	rootfsManager RootfsManager
+	network       Network
 }
 
 func NewContainer(
 	container containerd.Container,
 	killer Killer,
 	rootfsManager RootfsManager,
+	network Network,
 ) *Container {
 	return &Container{
 		container:     container,
 		killer:        killer,
 		rootfsManager: rootfsManager,
+		network:       network,
 	}
 }
 
@@ -222,28 +232,284 @@ func (c *Container) RemoveProperty(name string) (err error) {
 	return
 }
 
-// Info - Not Implemented
-func (c *Container) Info() (info garden.ContainerInfo, err error) {
-	err = ErrNotImplemented
-	return
+// Info retrieves the state, processes, properties and addresses of the
+// container.
+//
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	ctx := context.Background()
+
+	properties, err := c.Properties()
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	containerSpec, err := c.container.Spec(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("container spec: %w", err)
+	}
+
+	info := garden.ContainerInfo{
+		State:       "stopped",
+		Events:      []string{},
+		HostIP:      properties[NetworkHostIPKey],
+		ContainerIP: properties[NetworkContainerIPKey],
+		ProcessIDs:  []string{},
+		Properties:  properties,
+		MappedPorts: []garden.PortMapping{},
+	}
+
+	if containerSpec.Root != nil {
+		info.ContainerPath = containerSpec.Root.Path
+	}
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return info, nil
+		}
+
+		return garden.ContainerInfo{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	status, err := task.Status(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task status: %w", err)
+	}
+
+	if status.Status == containerd.Running {
+		info.State = "active"
+	}
+
+	procs, err := task.Pids(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task pids: %w", err)
+	}
+
+	for _, proc := range procs {
+		info.ProcessIDs = append(info.ProcessIDs, strconv.FormatUint(uint64(proc.Pid), 10))
+	}
+
+	return info, nil
 }
 
-// Metrics - Not Implemented
-func (c *Container) Metrics() (metrics garden.Metrics, err error) {
-	err = ErrNotImplemented
-	return
+// Metrics retrieves the cgroup (v1 or v2) and network statistics of the
+// container's task.
+//
+func (c *Container) Metrics() (garden.Metrics, error) {
+	ctx := context.Background()
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	taskMetrics, err := task.Metrics(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task metrics: %w", err)
+	}
+
+	data, err := typeurl.UnmarshalAny(taskMetrics.Data)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("unmarshal metrics: %w", err)
+	}
+
+	metrics, err := cgroupMetrics(data)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	metrics.NetworkStat, err = c.network.Stats(ctx, task)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("network stats: %w", err)
+	}
+
+	info, err := c.container.Info(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("container info: %w", err)
+	}
+
+	metrics.Age = time.Since(info.CreatedAt)
+
+	return metrics, nil
 }
 
-// StreamIn - Not Implemented
-func (c *Container) StreamIn(spec garden.StreamInSpec) (err error) {
-	err = ErrNotImplemented
-	return
+// StreamIn extracts the tar stream into the directory `spec.Path` of the
+// container.
+//
+func (c *Container) StreamIn(spec garden.StreamInSpec) error {
+	dest, err := c.hostPath(spec.Path)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dest, 0755)
+	if err != nil {
+		return fmt.Errorf("create destination: %w", err)
+	}
+
+	err = tarfs.Extract(spec.TarStream, dest)
+	if err != nil {
+		return fmt.Errorf("extract: %w", err)
+	}
+
+	return nil
 }
 
-// StreamOut - Not Implemented
-func (c *Container) StreamOut(spec garden.StreamOutSpec) (readCloser io.ReadCloser, err error) {
-	err = ErrNotImplemented
-	return
+// StreamOut streams `spec.Path` out of the container as a tar stream. If the
+// path ends in a `/`, only the contents of the directory are streamed.
+//
+func (c *Container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	src, err := c.hostPath(spec.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("stat source: %w", err)
+	}
+
+	workDir, path := filepath.Dir(src), filepath.Base(src)
+	if strings.HasSuffix(spec.Path, "/") {
+		workDir, path = src, "."
+	}
+
+	r, w := io.Pipe()
+
+	go func() {
+		w.CloseWithError(tarfs.Compress(w, workDir, path))
+	}()
+
+	return r, nil
+}
+
+// hostPath resolves a path inside the container to the corresponding path on
+// the host, taking bind mounts into account.
+//
+func (c *Container) hostPath(path string) (string, error) {
+	containerSpec, err := c.container.Spec(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("container spec: %w", err)
+	}
+
+	return resolveHostPath(*containerSpec, path)
+}
+
+// maxSymlinks is the number of symlinks which are followed while resolving a
+// path before giving up, mirroring the kernel's limit.
+const maxSymlinks = 255
+
+// resolveHostPath follows the symlinks along the path the way they would be
+// followed from within the container: absolute link targets are relative to
+// the container's root, and `..` never goes above it. This way a symlink
+// planted in the rootfs or in a volume can never point the runtime at a path
+// on the host outside of the container.
+//
+func resolveHostPath(containerSpec specs.Spec, path string) (string, error) {
+	if containerSpec.Root == nil || containerSpec.Root.Path == "" {
+		return "", fmt.Errorf("container has no rootfs")
+	}
+
+	var (
+		resolved  = "/"
+		remaining = filepath.Clean("/" + path)
+		symlinks  = 0
+	)
+
+	for remaining != "" {
+		var component string
+		if i := strings.IndexRune(remaining, '/'); i == -1 {
+			component, remaining = remaining, ""
+		} else {
+			component, remaining = remaining[:i], remaining[i+1:]
+		}
+
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+
+		next := filepath.Join(resolved, component)
+
+		hostNext, err := mountedHostPath(containerSpec, next)
+		if err != nil {
+			return "", err
+		}
+
+		info, err := os.Lstat(hostNext)
+		if os.IsNotExist(err) {
+			// nothing below this point exists, so there are no more symlinks
+			// to follow
+			resolved = filepath.Join(next, remaining)
+			break
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("lstat: %w", err)
+		}
+
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		symlinks++
+		if symlinks > maxSymlinks {
+			return "", fmt.Errorf("resolve %s: too many levels of symbolic links", path)
+		}
+
+		target, err := os.Readlink(hostNext)
+		if err != nil {
+			return "", fmt.Errorf("readlink: %w", err)
+		}
+
+		if filepath.IsAbs(target) {
+			resolved = "/"
+		}
+
+		remaining = target + "/" + remaining
+	}
+
+	return mountedHostPath(containerSpec, resolved)
+}
+
+// mountedHostPath maps an absolute, symlink-free path inside the container to
+// the host, either within the bind mount it belongs to or within the rootfs.
+//
+func mountedHostPath(containerSpec specs.Spec, path string) (string, error) {
+	var (
+		match    *specs.Mount
+		matchLen int
+	)
+
+	for i, mount := range containerSpec.Mounts {
+		if mount.Type != "bind" {
+			continue
+		}
+
+		dest := filepath.Clean(mount.Destination)
+		if path != dest && !strings.HasPrefix(path, strings.TrimSuffix(dest, "/")+"/") {
+			continue
+		}
+
+		if len(dest) > matchLen {
+			match, matchLen = &containerSpec.Mounts[i], len(dest)
+		}
+	}
+
+	if match != nil {
+		rel, err := filepath.Rel(filepath.Clean(match.Destination), path)
+		if err != nil {
+			return "", fmt.Errorf("relative path: %w", err)
+		}
+
+		return filepath.Join(match.Source, rel), nil
+	}
+
+	return filepath.Join(containerSpec.Root.Path, path), nil
 }
 
 // SetGraceTime stores the grace time as a containerd label with key "garden.grace-time"
@@ -1,13 +1,21 @@
 package runtime_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +31,7 @@ type ContainerSuite struct {
 	containerdTask      *libcontainerdfakes.FakeTask
 	rootfsManager       *runtimefakes.FakeRootfsManager
 	killer              *runtimefakes.FakeKiller
+	network             *runtimefakes.FakeNetwork
 }
 
 func (s *ContainerSuite) SetupTest() {
@@ -31,11 +40,13 @@ func (s *ContainerSuite) SetupTest() {
 	s.containerdTask = new(libcontainerdfakes.FakeTask)
 	s.rootfsManager = new(runtimefakes.FakeRootfsManager)
 	s.killer = new(runtimefakes.FakeKiller)
+	s.network = new(runtimefakes.FakeNetwork)
 
 	s.container = runtime.NewContainer(
 		s.containerdContainer,
 		s.killer,
 		s.rootfsManager,
+		s.network,
 	)
 }
 
@@ -314,6 +325,258 @@ func (s *ContainerSuite) TestPropertyReturnsValue() {
 	s.Equal("some-value", result)
 }
 
+func (s *ContainerSuite) TestInfoGetSpecFails() {
+	expectedErr := errors.New("get-spec-error")
+	s.containerdContainer.SpecReturns(nil, expectedErr)
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestInfoWithoutTask() {
+	s.containerdContainer.LabelsReturns(garden.Properties{
+		runtime.NetworkContainerIPKey: "10.80.0.2",
+		runtime.NetworkHostIPKey:      "10.80.0.1",
+	}, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: "/rootfs"},
+	}, nil)
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("stopped", info.State)
+	s.Equal("/rootfs", info.ContainerPath)
+	s.Equal("10.80.0.2", info.ContainerIP)
+	s.Equal("10.80.0.1", info.HostIP)
+	s.Empty(info.ProcessIDs)
+}
+
+func (s *ContainerSuite) TestInfoTaskLookupFails() {
+	s.containerdContainer.SpecReturns(&specs.Spec{}, nil)
+	s.containerdContainer.TaskReturns(nil, errors.New("task-err"))
+
+	_, err := s.container.Info()
+	s.EqualError(errors.Unwrap(err), "task-err")
+}
+
+func (s *ContainerSuite) TestInfoRunningTask() {
+	s.containerdContainer.SpecReturns(&specs.Spec{}, nil)
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+	s.containerdTask.PidsReturns([]containerd.ProcessInfo{{Pid: 123}, {Pid: 456}}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("active", info.State)
+	s.Equal([]string{"123", "456"}, info.ProcessIDs)
+}
+
+func (s *ContainerSuite) TestMetricsTaskLookupFails() {
+	s.containerdContainer.TaskReturns(nil, errors.New("task-err"))
+
+	_, err := s.container.Metrics()
+	s.EqualError(errors.Unwrap(err), "task-err")
+}
+
+func (s *ContainerSuite) TestMetricsTaskMetricsFails() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(nil, errors.New("metrics-err"))
+
+	_, err := s.container.Metrics()
+	s.EqualError(errors.Unwrap(err), "metrics-err")
+}
+
+func (s *ContainerSuite) TestStreamInExtractsIntoRootfs() {
+	rootfs := s.T().TempDir()
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/some/dir",
+		TarStream: tarStream(s.T(), map[string]string{"file": "contents"}),
+	})
+	s.NoError(err)
+
+	contents, err := ioutil.ReadFile(filepath.Join(rootfs, "some", "dir", "file"))
+	s.NoError(err)
+	s.Equal("contents", string(contents))
+}
+
+func (s *ContainerSuite) TestStreamInExtractsIntoBindMounts() {
+	rootfs, volume := s.T().TempDir(), s.T().TempDir()
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+		Mounts: []specs.Mount{
+			{Type: "bind", Source: "/elsewhere", Destination: "/tmp/build"},
+			{Type: "bind", Source: volume, Destination: "/tmp/build/input"},
+		},
+	}, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/tmp/build/input/dir",
+		TarStream: tarStream(s.T(), map[string]string{"file": "contents"}),
+	})
+	s.NoError(err)
+
+	contents, err := ioutil.ReadFile(filepath.Join(volume, "dir", "file"))
+	s.NoError(err)
+	s.Equal("contents", string(contents))
+}
+
+func (s *ContainerSuite) TestStreamInDoesNotFollowSymlinksOutOfTheContainer() {
+	rootfs, outside := s.T().TempDir(), s.T().TempDir()
+	s.NoError(os.Symlink(outside, filepath.Join(rootfs, "absolute")))
+	s.NoError(os.Symlink("../../../../../../../.."+outside, filepath.Join(rootfs, "relative")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	for _, link := range []string{"absolute", "relative"} {
+		s.T().Run(link, func(t *testing.T) {
+			err := s.container.StreamIn(garden.StreamInSpec{
+				Path:      "/" + link + "/dir",
+				TarStream: tarStream(t, map[string]string{"file": "contents"}),
+			})
+			s.NoError(err)
+
+			_, err = os.Stat(filepath.Join(outside, "dir", "file"))
+			s.True(os.IsNotExist(err))
+
+			contents, err := ioutil.ReadFile(filepath.Join(rootfs, outside, "dir", "file"))
+			s.NoError(err)
+			s.Equal("contents", string(contents))
+		})
+	}
+}
+
+func (s *ContainerSuite) TestStreamInFollowsSymlinksIntoBindMounts() {
+	rootfs, volume := s.T().TempDir(), s.T().TempDir()
+	s.NoError(os.Symlink("/tmp/build/input", filepath.Join(rootfs, "input")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+		Mounts: []specs.Mount{
+			{Type: "bind", Source: volume, Destination: "/tmp/build/input"},
+		},
+	}, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/input/dir",
+		TarStream: tarStream(s.T(), map[string]string{"file": "contents"}),
+	})
+	s.NoError(err)
+
+	contents, err := ioutil.ReadFile(filepath.Join(volume, "dir", "file"))
+	s.NoError(err)
+	s.Equal("contents", string(contents))
+}
+
+func (s *ContainerSuite) TestStreamOutDoesNotFollowSymlinksOutOfTheContainer() {
+	rootfs, outside := s.T().TempDir(), s.T().TempDir()
+	s.NoError(ioutil.WriteFile(filepath.Join(outside, "secret"), []byte("host secret"), 0644))
+	s.NoError(os.Symlink(outside, filepath.Join(rootfs, "link")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	_, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/link/"})
+	s.Error(err)
+}
+
+func (s *ContainerSuite) TestStreamInSymlinkLoop() {
+	rootfs := s.T().TempDir()
+	s.NoError(os.Symlink("/loop", filepath.Join(rootfs, "loop")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/loop/dir",
+		TarStream: tarStream(s.T(), map[string]string{"file": "contents"}),
+	})
+	s.EqualError(err, "resolve /loop/dir: too many levels of symbolic links")
+}
+
+func (s *ContainerSuite) TestStreamOutDirectory() {
+	rootfs := s.T().TempDir()
+	s.NoError(os.MkdirAll(filepath.Join(rootfs, "some", "dir"), 0755))
+	s.NoError(ioutil.WriteFile(filepath.Join(rootfs, "some", "dir", "file"), []byte("contents"), 0644))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	for _, tc := range []struct {
+		path     string
+		expected string
+	}{
+		{path: "/some/dir", expected: "dir/file"},
+		{path: "/some/dir/", expected: "file"},
+	} {
+		s.T().Run(tc.path, func(t *testing.T) {
+			stream, err := s.container.StreamOut(garden.StreamOutSpec{Path: tc.path})
+			s.NoError(err)
+			defer stream.Close()
+
+			contents := readTarStream(t, stream)
+			s.Equal("contents", contents[tc.expected])
+		})
+	}
+}
+
+func (s *ContainerSuite) TestStreamOutMissingPath() {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: s.T().TempDir()},
+	}, nil)
+
+	_, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/missing"})
+	s.Error(err)
+}
+
+func tarStream(t *testing.T, files map[string]string) io.Reader {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+
+	for name, contents := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0644,
+			Size: int64(len(contents)),
+		})
+		require.NoError(t, err)
+
+		_, err = tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+
+	return buf
+}
+
+func readTarStream(t *testing.T, r io.Reader) map[string]string {
+	files := map[string]string{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		contents, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+
+		files[filepath.Clean(hdr.Name)] = string(contents)
+	}
+
+	return files
+}
+
 func (s *ContainerSuite) TestCurrentCPULimitsGetInfoFails() {
 	expectedErr := errors.New("get-spec-error")
 	s.containerdContainer.SpecReturns(nil, expectedErr)
@@ -27,4 +27,20 @@ var (
 	// ErrNotImplemented indicates that a method is not implemented.
 	//
 	ErrNotImplemented = errors.New("not implemented")
+
+	// ErrPrivilegedRootless indicates that a privileged container was
+	// requested from a backend running in rootless mode.
+	//
+	ErrPrivilegedRootless = errors.New("privileged containers are not supported in rootless mode")
+
+	// ErrIOLimitsNotSupported indicates that block IO limits were requested
+	// from a backend with no block devices to throttle.
+	//
+	ErrIOLimitsNotSupported = errors.New("io limits are not supported by this worker")
+
+	// ErrSecretFilesNotSupported indicates that secret files were requested
+	// from a backend which can't mount a tmpfs for them, e.g. because it runs
+	// in rootless mode.
+	//
+	ErrSecretFilesNotSupported = errors.New("secret files are not supported by this worker")
 )
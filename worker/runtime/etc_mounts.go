@@ -0,0 +1,71 @@
+package runtime
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// setupEtcMounts creates the `/etc/hosts` and `/etc/resolv.conf` files for a
+// container under the file store, returning the mounts that place them in
+// the container.
+//
+// `nameServers` are `nameserver <ip>` entries; when empty, the ones from the
+// host's `/etc/resolv.conf` are used.
+//
+func setupEtcMounts(store FileStore, handle string, nameServers []string) ([]specs.Mount, error) {
+	if handle == "" {
+		return nil, ErrInvalidInput("empty handle")
+	}
+
+	etcHosts, err := store.Create(
+		filepath.Join(handle, "/hosts"),
+		[]byte("127.0.0.1 localhost"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating /etc/hosts: %w", err)
+	}
+
+	resolvContents, err := generateResolvConfContents(nameServers)
+	if err != nil {
+		return nil, fmt.Errorf("generating resolv.conf: %w", err)
+	}
+
+	resolvConf, err := store.Create(
+		filepath.Join(handle, "/resolv.conf"),
+		resolvContents,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating /etc/resolv.conf: %w", err)
+	}
+
+	return []specs.Mount{
+		{
+			Destination: "/etc/hosts",
+			Type:        "bind",
+			Source:      etcHosts,
+			Options:     []string{"bind", "rw"},
+		}, {
+			Destination: "/etc/resolv.conf",
+			Type:        "bind",
+			Source:      resolvConf,
+			Options:     []string{"bind", "rw"},
+		},
+	}, nil
+}
+
+func generateResolvConfContents(nameServers []string) ([]byte, error) {
+	contents := ""
+	resolvConfEntries := nameServers
+	var err error
+
+	if len(nameServers) == 0 {
+		resolvConfEntries, err = ParseHostResolveConf("/etc/resolv.conf")
+	}
+
+	contents = strings.Join(resolvConfEntries, "\n")
+
+	return []byte(contents), err
+}
@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/garden"
+	v1 "github.com/containerd/containerd/metrics/types/v1"
+	v2 "github.com/containerd/containerd/metrics/types/v2"
+)
+
+// cgroupMetrics converts the metrics reported by a containerd task (either
+// from cgroups v1 or v2) into garden metrics.
+//
+func cgroupMetrics(data interface{}) (garden.Metrics, error) {
+	switch m := data.(type) {
+	case *v1.Metrics:
+		return cgroupV1Metrics(m), nil
+	case *v2.Metrics:
+		return cgroupV2Metrics(m), nil
+	default:
+		return garden.Metrics{}, fmt.Errorf("unsupported metrics type %T", data)
+	}
+}
+
+func cgroupV1Metrics(m *v1.Metrics) garden.Metrics {
+	var metrics garden.Metrics
+
+	if m.CPU != nil && m.CPU.Usage != nil {
+		metrics.CPUStat = garden.ContainerCPUStat{
+			Usage:  m.CPU.Usage.Total,
+			User:   m.CPU.Usage.User,
+			System: m.CPU.Usage.Kernel,
+		}
+	}
+
+	if m.Pids != nil {
+		metrics.PidStat = garden.ContainerPidStat{
+			Current: m.Pids.Current,
+			Max:     m.Pids.Limit,
+		}
+	}
+
+	if mem := m.Memory; mem != nil {
+		metrics.MemoryStat = garden.ContainerMemoryStat{
+			ActiveAnon:              mem.ActiveAnon,
+			ActiveFile:              mem.ActiveFile,
+			Cache:                   mem.Cache,
+			HierarchicalMemoryLimit: mem.HierarchicalMemoryLimit,
+			InactiveAnon:            mem.InactiveAnon,
+			InactiveFile:            mem.InactiveFile,
+			MappedFile:              mem.MappedFile,
+			Pgfault:                 mem.PgFault,
+			Pgmajfault:              mem.PgMajFault,
+			Pgpgin:                  mem.PgPgIn,
+			Pgpgout:                 mem.PgPgOut,
+			Rss:                     mem.RSS,
+			TotalActiveAnon:         mem.TotalActiveAnon,
+			TotalActiveFile:         mem.TotalActiveFile,
+			TotalCache:              mem.TotalCache,
+			TotalInactiveAnon:       mem.TotalInactiveAnon,
+			TotalInactiveFile:       mem.TotalInactiveFile,
+			TotalMappedFile:         mem.TotalMappedFile,
+			TotalPgfault:            mem.TotalPgFault,
+			TotalPgmajfault:         mem.TotalPgMajFault,
+			TotalPgpgin:             mem.TotalPgPgIn,
+			TotalPgpgout:            mem.TotalPgPgOut,
+			TotalRss:                mem.TotalRSS,
+			TotalUnevictable:        mem.TotalUnevictable,
+			Unevictable:             mem.Unevictable,
+			HierarchicalMemswLimit:  mem.HierarchicalSwapLimit,
+		}
+
+		if mem.Swap != nil {
+			metrics.MemoryStat.Swap = mem.Swap.Usage
+			metrics.MemoryStat.TotalSwap = mem.Swap.Usage
+		}
+
+		if mem.Usage != nil {
+			metrics.MemoryStat.TotalUsageTowardLimit = usageTowardLimit(mem.Usage.Usage, mem.TotalInactiveFile)
+		}
+	}
+
+	return metrics
+}
+
+func cgroupV2Metrics(m *v2.Metrics) garden.Metrics {
+	var metrics garden.Metrics
+
+	// cgroups v2 reports cpu usage in microseconds rather than nanoseconds
+	//
+	if m.CPU != nil {
+		metrics.CPUStat = garden.ContainerCPUStat{
+			Usage:  m.CPU.UsageUsec * 1000,
+			User:   m.CPU.UserUsec * 1000,
+			System: m.CPU.SystemUsec * 1000,
+		}
+	}
+
+	if m.Pids != nil {
+		metrics.PidStat = garden.ContainerPidStat{
+			Current: m.Pids.Current,
+			Max:     m.Pids.Limit,
+		}
+	}
+
+	if mem := m.Memory; mem != nil {
+		metrics.MemoryStat = garden.ContainerMemoryStat{
+			ActiveAnon:              mem.ActiveAnon,
+			ActiveFile:              mem.ActiveFile,
+			Cache:                   mem.File,
+			HierarchicalMemoryLimit: mem.UsageLimit,
+			InactiveAnon:            mem.InactiveAnon,
+			InactiveFile:            mem.InactiveFile,
+			MappedFile:              mem.FileMapped,
+			Pgfault:                 mem.Pgfault,
+			Pgmajfault:              mem.Pgmajfault,
+			Rss:                     mem.Anon,
+			Unevictable:             mem.Unevictable,
+			Swap:                    mem.SwapUsage,
+			HierarchicalMemswLimit:  mem.SwapLimit,
+			TotalUsageTowardLimit:   usageTowardLimit(mem.Usage, mem.InactiveFile),
+		}
+	}
+
+	return metrics
+}
+
+// usageTowardLimit excludes the inactive page cache (which the kernel is free
+// to reclaim) from the memory usage.
+//
+func usageTowardLimit(usage, inactiveFile uint64) uint64 {
+	if inactiveFile > usage {
+		return 0
+	}
+
+	return usage - inactiveFile
+}
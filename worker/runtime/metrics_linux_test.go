@@ -0,0 +1,77 @@
+package runtime_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	cgroupsv1 "github.com/containerd/cgroups/stats/v1"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/containers"
+	v1 "github.com/containerd/containerd/metrics/types/v1"
+	v2 "github.com/containerd/containerd/metrics/types/v2"
+	"github.com/containerd/typeurl"
+)
+
+func (s *ContainerSuite) setupTaskMetrics(data interface{}) {
+	any, err := typeurl.MarshalAny(data)
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.InfoReturns(containers.Container{
+		CreatedAt: time.Now().Add(-time.Minute),
+	}, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{Data: any}, nil)
+	s.network.StatsReturns(garden.ContainerNetworkStat{RxBytes: 10, TxBytes: 20}, nil)
+}
+
+func (s *ContainerSuite) TestMetricsCgroupsV1() {
+	s.setupTaskMetrics(&v1.Metrics{
+		CPU: &v1.CPUStat{
+			Usage: &v1.CPUUsage{Total: 300, User: 200, Kernel: 100},
+		},
+		Pids: &v1.PidsStat{Current: 3, Limit: 10},
+		Memory: &v1.MemoryStat{
+			RSS:               100,
+			TotalInactiveFile: 50,
+			Usage:             &cgroupsv1.MemoryEntry{Usage: 150},
+		},
+	})
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+	s.Equal(garden.ContainerCPUStat{Usage: 300, User: 200, System: 100}, metrics.CPUStat)
+	s.Equal(garden.ContainerPidStat{Current: 3, Max: 10}, metrics.PidStat)
+	s.Equal(uint64(100), metrics.MemoryStat.Rss)
+	s.Equal(uint64(100), metrics.MemoryStat.TotalUsageTowardLimit)
+	s.Equal(garden.ContainerNetworkStat{RxBytes: 10, TxBytes: 20}, metrics.NetworkStat)
+	s.True(metrics.Age >= time.Minute)
+}
+
+func (s *ContainerSuite) TestMetricsCgroupsV2() {
+	s.setupTaskMetrics(&v2.Metrics{
+		CPU:  &v2.CPUStat{UsageUsec: 3, UserUsec: 2, SystemUsec: 1},
+		Pids: &v2.PidsStat{Current: 3, Limit: 10},
+		Memory: &v2.MemoryStat{
+			Anon:         100,
+			InactiveFile: 50,
+			Usage:        150,
+		},
+	})
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+	s.Equal(garden.ContainerCPUStat{Usage: 3000, User: 2000, System: 1000}, metrics.CPUStat)
+	s.Equal(garden.ContainerPidStat{Current: 3, Max: 10}, metrics.PidStat)
+	s.Equal(uint64(100), metrics.MemoryStat.Rss)
+	s.Equal(uint64(100), metrics.MemoryStat.TotalUsageTowardLimit)
+	s.Equal(garden.ContainerNetworkStat{RxBytes: 10, TxBytes: 20}, metrics.NetworkStat)
+}
+
+func (s *ContainerSuite) TestMetricsNetworkStatsFails() {
+	s.setupTaskMetrics(&v1.Metrics{})
+	s.network.StatsReturns(garden.ContainerNetworkStat{}, errors.New("stats-err"))
+
+	_, err := s.container.Metrics()
+	s.EqualError(errors.Unwrap(err), "stats-err")
+}
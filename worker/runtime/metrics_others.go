@@ -0,0 +1,13 @@
+// +build !linux
+
+package runtime
+
+import (
+	"code.cloudfoundry.org/garden"
+)
+
+// cgroupMetrics is only supported on Linux.
+//
+func cgroupMetrics(data interface{}) (garden.Metrics, error) {
+	return garden.Metrics{}, ErrNotImplemented
+}
@@ -3,10 +3,23 @@ package runtime
 import (
 	"context"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/containerd/containerd"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
+const (
+	// NetworkContainerIPKey is the property under which the IP address of
+	// the container side of the network is stored.
+	//
+	NetworkContainerIPKey = "garden.network.container-ip"
+
+	// NetworkHostIPKey is the property under which the IP address of the
+	// gateway on the host side of the network is stored.
+	//
+	NetworkHostIPKey = "garden.network.host-ip"
+)
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Network
 
 type Network interface {
@@ -20,11 +33,18 @@ type Network interface {
 	//
 	SetupRestrictedNetworks() (err error)
 
-	// Add adds a task to the network.
+	// Add adds a task to the network, returning the properties (see
+	// NetworkContainerIPKey and NetworkHostIPKey) describing the addresses
+	// assigned to it.
 	//
-	Add(ctx context.Context, task containerd.Task) (err error)
+	Add(ctx context.Context, task containerd.Task) (properties garden.Properties, err error)
 
 	// Removes a task from the network.
 	//
 	Remove(ctx context.Context, task containerd.Task) (err error)
+
+	// Stats retrieves the number of bytes received and transmitted by the
+	// task over the network.
+	//
+	Stats(ctx context.Context, task containerd.Task) (stats garden.ContainerNetworkStat, err error)
 }
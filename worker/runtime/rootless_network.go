@@ -0,0 +1,340 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/containerd/containerd"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// RootlessNetworkSlirp4netns connects containers through slirp4netns.
+	//
+	RootlessNetworkSlirp4netns = "slirp4netns"
+
+	// RootlessNetworkPasta connects containers through pasta.
+	//
+	RootlessNetworkPasta = "pasta"
+
+	// RootlessDNSServer is the address at which both slirp4netns and pasta
+	// (when told to) forward DNS queries to the host's resolver.
+	//
+	RootlessDNSServer = "10.0.2.3"
+
+	// rootlessContainerIP and rootlessHostIP are the addresses which both
+	// slirp4netns and pasta (when told to) assign to the container side and
+	// to the gateway of the user-mode network.
+	//
+	rootlessContainerIP = "10.0.2.100"
+	rootlessHostIP      = "10.0.2.2"
+
+	// rootlessInterface is the name of the tap device created in the
+	// container's network namespace.
+	//
+	rootlessInterface = "tap0"
+)
+
+// rootlessNetwork is the network used when containerd runs in rootless mode.
+//
+// Without root on the host, veth pairs and bridges can't be created. Instead,
+// each container gets a network namespace of its own, which gets connected to
+// the outside world by an unprivileged user-mode network stack (slirp4netns
+// or pasta) started for that container alone.
+//
+type rootlessNetwork struct {
+	store       FileStore
+	nameServers []string
+
+	stack    string
+	bin      string
+	mtu      int
+	procRoot string
+
+	// processes keeps track of the network stack process of each task, by
+	// task ID, so that it can be stopped once the task is removed from the
+	// network.
+	//
+	processesLock sync.Mutex
+	processes     map[string]*os.Process
+}
+
+var _ Network = (*rootlessNetwork)(nil)
+
+// RootlessNetworkOpt defines a functional option that when applied, modifies
+// the configuration of a rootlessNetwork.
+//
+type RootlessNetworkOpt func(n *rootlessNetwork)
+
+// WithRootlessNetworkStack configures the user-mode network stack to connect
+// containers through - either RootlessNetworkSlirp4netns or
+// RootlessNetworkPasta.
+//
+func WithRootlessNetworkStack(stack string) RootlessNetworkOpt {
+	return func(n *rootlessNetwork) {
+		n.stack = stack
+	}
+}
+
+// WithRootlessNetworkBin configures the path to the network stack's
+// executable. Defaults to the name of the stack, resolved from $PATH.
+//
+func WithRootlessNetworkBin(bin string) RootlessNetworkOpt {
+	return func(n *rootlessNetwork) {
+		n.bin = bin
+	}
+}
+
+// WithRootlessNetworkMTU configures the MTU of the containers' interfaces.
+//
+func WithRootlessNetworkMTU(mtu int) RootlessNetworkOpt {
+	return func(n *rootlessNetwork) {
+		n.mtu = mtu
+	}
+}
+
+// WithRootlessProcRoot configures the mountpoint of the proc filesystem
+// through which the tasks' namespaces and network statistics are found.
+//
+func WithRootlessProcRoot(dir string) RootlessNetworkOpt {
+	return func(n *rootlessNetwork) {
+		n.procRoot = dir
+	}
+}
+
+// NewRootlessNetwork instantiates the network used in rootless mode, storing
+// the containers' `/etc/hosts` and `/etc/resolv.conf` under `store`.
+//
+func NewRootlessNetwork(store FileStore, nameServers []string, opts ...RootlessNetworkOpt) *rootlessNetwork {
+	n := &rootlessNetwork{
+		store:     store,
+		stack:     RootlessNetworkSlirp4netns,
+		procRoot:  procRoot,
+		processes: map[string]*os.Process{},
+	}
+
+	for _, ns := range nameServers {
+		n.nameServers = append(n.nameServers, "nameserver "+ns)
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	if n.bin == "" {
+		n.bin = n.stack
+	}
+
+	return n
+}
+
+func (n *rootlessNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
+	return setupEtcMounts(n.store, handle, n.nameServers)
+}
+
+// SetupRestrictedNetworks is a no-op - without root, there's no way of
+// installing iptables rules on the host.
+//
+func (n *rootlessNetwork) SetupRestrictedNetworks() error {
+	return nil
+}
+
+// Add starts the user-mode network stack for the task's network namespace,
+// waiting for it to have configured the namespace's interface.
+//
+func (n *rootlessNetwork) Add(ctx context.Context, task containerd.Task) (garden.Properties, error) {
+	if task == nil {
+		return nil, ErrInvalidInput("nil task")
+	}
+
+	var (
+		process *os.Process
+		err     error
+	)
+
+	switch n.stack {
+	case RootlessNetworkSlirp4netns:
+		process, err = n.startSlirp4netns(ctx, task.Pid())
+	case RootlessNetworkPasta:
+		process, err = n.startPasta(ctx, task.Pid())
+	default:
+		err = fmt.Errorf("unknown rootless network stack '%s'", n.stack)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	n.processesLock.Lock()
+	n.processes[task.ID()] = process
+	n.processesLock.Unlock()
+
+	return garden.Properties{
+		NetworkContainerIPKey: rootlessContainerIP,
+		NetworkHostIPKey:      rootlessHostIP,
+	}, nil
+}
+
+// startSlirp4netns starts slirp4netns as a child process, using its ready-fd
+// to find out when the interface is configured.
+//
+func (n *rootlessNetwork) startSlirp4netns(ctx context.Context, pid uint32) (*os.Process, error) {
+	ready, readyW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("ready pipe: %w", err)
+	}
+	defer ready.Close()
+
+	args := []string{
+		"--configure",
+		"--disable-host-loopback",
+		"--ready-fd=3",
+		"--userns-path=" + n.nsPath(pid, "user"),
+		"--netns-type=path",
+	}
+
+	if n.mtu != 0 {
+		args = append(args, "--mtu="+strconv.Itoa(n.mtu))
+	}
+
+	cmd := exec.Command(n.bin, append(args, n.nsPath(pid, "net"), rootlessInterface)...)
+	cmd.ExtraFiles = []*os.File{readyW}
+
+	err = cmd.Start()
+	readyW.Close()
+	if err != nil {
+		return nil, fmt.Errorf("start slirp4netns: %w", err)
+	}
+
+	// reap the process once it exits, which happens when it gets stopped or
+	// when the namespace goes away
+	go cmd.Wait()
+
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := ready.Read(make([]byte, 1))
+		readErr <- err
+	}()
+
+	select {
+	case err = <-readErr:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return nil, fmt.Errorf("wait for slirp4netns: %w", err)
+	}
+
+	return cmd.Process, nil
+}
+
+// startPasta runs pasta, which daemonizes once the interface is configured,
+// leaving its pid behind in a pid file.
+//
+func (n *rootlessNetwork) startPasta(ctx context.Context, pid uint32) (*os.Process, error) {
+	pidFile, err := ioutil.TempFile("", "pasta-pid")
+	if err != nil {
+		return nil, fmt.Errorf("pid file: %w", err)
+	}
+	pidFile.Close()
+	defer os.Remove(pidFile.Name())
+
+	args := []string{
+		"--config-net",
+		"--quiet",
+		"--no-map-gw",
+		"--address", rootlessContainerIP,
+		"--netmask", "24",
+		"--gateway", rootlessHostIP,
+		"--dns-forward", RootlessDNSServer,
+		"--pid", pidFile.Name(),
+		"--userns", n.nsPath(pid, "user"),
+		"--netns", n.nsPath(pid, "net"),
+	}
+
+	if n.mtu != 0 {
+		args = append(args, "--mtu", strconv.Itoa(n.mtu))
+	}
+
+	output, err := exec.CommandContext(ctx, n.bin, args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("run pasta: %w: %s", err, output)
+	}
+
+	contents, err := ioutil.ReadFile(pidFile.Name())
+	if err != nil {
+		return nil, fmt.Errorf("read pasta pid: %w", err)
+	}
+
+	pastaPid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return nil, fmt.Errorf("parse pasta pid: %w", err)
+	}
+
+	process, err := os.FindProcess(pastaPid)
+	if err != nil {
+		return nil, fmt.Errorf("find pasta process: %w", err)
+	}
+
+	return process, nil
+}
+
+func (n *rootlessNetwork) nsPath(pid uint32, ns string) string {
+	return filepath.Join(n.procRoot, strconv.Itoa(int(pid)), "ns", ns)
+}
+
+// Remove stops the network stack of the task.
+//
+func (n *rootlessNetwork) Remove(ctx context.Context, task containerd.Task) error {
+	if task == nil {
+		return ErrInvalidInput("nil task")
+	}
+
+	n.processesLock.Lock()
+	process, found := n.processes[task.ID()]
+	delete(n.processes, task.ID())
+	n.processesLock.Unlock()
+
+	if !found {
+		return nil
+	}
+
+	err := process.Kill()
+	if err != nil && err != os.ErrProcessDone {
+		return fmt.Errorf("stop %s: %w", n.stack, err)
+	}
+
+	return nil
+}
+
+// Stats retrieves the traffic of the task's network namespace, just like the
+// CNI network does.
+//
+func (n *rootlessNetwork) Stats(ctx context.Context, task containerd.Task) (garden.ContainerNetworkStat, error) {
+	if task == nil {
+		return garden.ContainerNetworkStat{}, ErrInvalidInput("nil task")
+	}
+
+	f, err := os.Open(filepath.Join(n.procRoot, strconv.Itoa(int(task.Pid())), "net", "dev"))
+	if err != nil {
+		return garden.ContainerNetworkStat{}, fmt.Errorf("open net dev: %w", err)
+	}
+	defer f.Close()
+
+	stats, err := parseNetDev(f)
+	if err != nil {
+		return garden.ContainerNetworkStat{}, fmt.Errorf("parse net dev: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,192 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RootlessNetworkSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	network runtime.Network
+	store   *runtimefakes.FakeFileStore
+}
+
+func (s *RootlessNetworkSuite) SetupTest() {
+	s.store = new(runtimefakes.FakeFileStore)
+	s.network = runtime.NewRootlessNetwork(s.store, []string{"10.0.2.3"})
+}
+
+func (s *RootlessNetworkSuite) TestSetupMountsEmptyHandle() {
+	_, err := s.network.SetupMounts("")
+	s.EqualError(err, "empty handle")
+}
+
+func (s *RootlessNetworkSuite) TestSetupMountsFailToCreateHosts() {
+	s.store.CreateReturnsOnCall(0, "", errors.New("create-hosts-err"))
+
+	_, err := s.network.SetupMounts("some-handle")
+	s.EqualError(errors.Unwrap(err), "create-hosts-err")
+}
+
+func (s *RootlessNetworkSuite) TestSetupMountsUsesNameServers() {
+	s.store.CreateReturnsOnCall(0, "/tmp/handle/etc/hosts", nil)
+	s.store.CreateReturnsOnCall(1, "/tmp/handle/etc/resolv.conf", nil)
+
+	mounts, err := s.network.SetupMounts("some-handle")
+	s.NoError(err)
+	s.Len(mounts, 2)
+
+	_, resolvConfContents := s.store.CreateArgsForCall(1)
+	s.Equal("nameserver 10.0.2.3", string(resolvConfContents))
+}
+
+func (s *RootlessNetworkSuite) TestSetupRestrictedNetworksIsNoop() {
+	s.NoError(s.network.SetupRestrictedNetworks())
+}
+
+func (s *RootlessNetworkSuite) TestAddNilTask() {
+	_, err := s.network.Add(context.Background(), nil)
+	s.EqualError(err, "nil task")
+}
+
+func (s *RootlessNetworkSuite) TestAddStartsSlirp4netnsForTheTask() {
+	bin := fakeNetworkStack(s.T(), `printf 1 >&3; exec sleep 60`)
+	network := runtime.NewRootlessNetwork(s.store, nil, runtime.WithRootlessNetworkBin(bin))
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.IDReturns("some-task")
+	task.PidReturns(1234)
+
+	properties, err := network.Add(context.Background(), task)
+	s.NoError(err)
+	defer network.Remove(context.Background(), task)
+
+	s.Equal(garden.Properties{
+		runtime.NetworkContainerIPKey: "10.0.2.100",
+		runtime.NetworkHostIPKey:      "10.0.2.2",
+	}, properties)
+
+	args := fakeNetworkStackArgs(s.T(), bin)
+	s.Contains(args, "--configure")
+	s.Contains(args, "--userns-path=/proc/1234/ns/user")
+	s.Equal([]string{"/proc/1234/ns/net", "tap0"}, args[len(args)-2:])
+}
+
+func (s *RootlessNetworkSuite) TestAddSlirp4netnsExitsEarly() {
+	bin := fakeNetworkStack(s.T(), `exit 1`)
+	network := runtime.NewRootlessNetwork(s.store, nil, runtime.WithRootlessNetworkBin(bin))
+
+	_, err := network.Add(context.Background(), new(libcontainerdfakes.FakeTask))
+	s.Error(err)
+	s.Contains(err.Error(), "wait for slirp4netns")
+}
+
+func (s *RootlessNetworkSuite) TestAddStartsPastaForTheTask() {
+	bin := fakeNetworkStack(s.T(), `
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--pid" ]; then
+    sleep 60 >/dev/null 2>&1 &
+    echo $! > "$2"
+  fi
+  shift
+done`)
+	network := runtime.NewRootlessNetwork(s.store, nil,
+		runtime.WithRootlessNetworkStack(runtime.RootlessNetworkPasta),
+		runtime.WithRootlessNetworkBin(bin),
+	)
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.IDReturns("some-task")
+	task.PidReturns(1234)
+
+	properties, err := network.Add(context.Background(), task)
+	s.NoError(err)
+	defer network.Remove(context.Background(), task)
+
+	s.Equal("10.0.2.100", properties[runtime.NetworkContainerIPKey])
+
+	args := fakeNetworkStackArgs(s.T(), bin)
+	s.Contains(args, "--config-net")
+	s.Contains(args, "/proc/1234/ns/user")
+	s.Contains(args, "/proc/1234/ns/net")
+}
+
+func (s *RootlessNetworkSuite) TestRemoveStopsTheNetworkStack() {
+	bin := fakeNetworkStack(s.T(), `echo $$ > "$0.pid"; printf 1 >&3; exec sleep 60`)
+	network := runtime.NewRootlessNetwork(s.store, nil, runtime.WithRootlessNetworkBin(bin))
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.IDReturns("some-task")
+
+	_, err := network.Add(context.Background(), task)
+	s.NoError(err)
+
+	contents, err := ioutil.ReadFile(bin + ".pid")
+	s.NoError(err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	s.NoError(err)
+
+	s.NoError(network.Remove(context.Background(), task))
+
+	s.Eventually(func() bool {
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *RootlessNetworkSuite) TestRemoveUnknownTask() {
+	s.NoError(s.network.Remove(context.Background(), new(libcontainerdfakes.FakeTask)))
+}
+
+func (s *RootlessNetworkSuite) TestStatsSumsNonLoopbackInterfaces() {
+	procRoot := s.T().TempDir()
+	s.NoError(os.MkdirAll(filepath.Join(procRoot, "123", "net"), 0755))
+	s.NoError(ioutil.WriteFile(filepath.Join(procRoot, "123", "net", "dev"), []byte(
+		`Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  tap0:    2048      20    0    0    0     0          0         0      512       5    0    0    0     0       0          0
+`), 0644))
+
+	network := runtime.NewRootlessNetwork(s.store, nil, runtime.WithRootlessProcRoot(procRoot))
+
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(123)
+
+	stats, err := network.Stats(context.Background(), task)
+	s.NoError(err)
+	s.Equal(garden.ContainerNetworkStat{RxBytes: 2048, TxBytes: 512}, stats)
+}
+
+// fakeNetworkStack writes a shell script standing in for slirp4netns or
+// pasta, which records its arguments next to itself before running `script`.
+func fakeNetworkStack(t *testing.T, script string) string {
+	bin := filepath.Join(t.TempDir(), "network-stack")
+	err := ioutil.WriteFile(bin, []byte("#!/bin/sh\nprintf '%s\\n' \"$@\" > \"$0.args\"\n"+script+"\n"), 0755)
+	require.NoError(t, err)
+
+	return bin
+}
+
+func fakeNetworkStackArgs(t *testing.T, bin string) []string {
+	contents, err := ioutil.ReadFile(bin + ".args")
+	require.NoError(t, err)
+
+	return strings.Split(strings.TrimSpace(string(contents)), "\n")
+}
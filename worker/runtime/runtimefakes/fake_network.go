@@ -5,23 +5,26 @@ import (
 	"context"
 	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/containerd/containerd"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 type FakeNetwork struct {
-	AddStub        func(context.Context, containerd.Task) error
+	AddStub        func(context.Context, containerd.Task) (garden.Properties, error)
 	addMutex       sync.RWMutex
 	addArgsForCall []struct {
 		arg1 context.Context
 		arg2 containerd.Task
 	}
 	addReturns struct {
-		result1 error
+		result1 garden.Properties
+		result2 error
 	}
 	addReturnsOnCall map[int]struct {
-		result1 error
+		result1 garden.Properties
+		result2 error
 	}
 	RemoveStub        func(context.Context, containerd.Task) error
 	removeMutex       sync.RWMutex
@@ -58,11 +61,25 @@ type FakeNetwork struct {
 	setupRestrictedNetworksReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StatsStub        func(context.Context, containerd.Task) (garden.ContainerNetworkStat, error)
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+		arg1 context.Context
+		arg2 containerd.Task
+	}
+	statsReturns struct {
+		result1 garden.ContainerNetworkStat
+		result2 error
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 garden.ContainerNetworkStat
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
+func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) (garden.Properties, error) {
 	fake.addMutex.Lock()
 	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
 	fake.addArgsForCall = append(fake.addArgsForCall, struct {
@@ -77,9 +94,9 @@ func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeNetwork) AddCallCount() int {
@@ -88,7 +105,7 @@ func (fake *FakeNetwork) AddCallCount() int {
 	return len(fake.addArgsForCall)
 }
 
-func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) error) {
+func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) (garden.Properties, error)) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = stub
@@ -101,27 +118,30 @@ func (fake *FakeNetwork) AddArgsForCall(i int) (context.Context, containerd.Task
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeNetwork) AddReturns(result1 error) {
+func (fake *FakeNetwork) AddReturns(result1 garden.Properties, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	fake.addReturns = struct {
-		result1 error
-	}{result1}
+		result1 garden.Properties
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 error) {
+func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 garden.Properties, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	if fake.addReturnsOnCall == nil {
 		fake.addReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 garden.Properties
+			result2 error
 		})
 	}
 	fake.addReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 garden.Properties
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeNetwork) Remove(arg1 context.Context, arg2 containerd.Task) error {
@@ -303,6 +323,71 @@ func (fake *FakeNetwork) SetupRestrictedNetworksReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeNetwork) Stats(arg1 context.Context, arg2 containerd.Task) (garden.ContainerNetworkStat, error) {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+		arg1 context.Context
+		arg2 containerd.Task
+	}{arg1, arg2})
+	stub := fake.StatsStub
+	fakeReturns := fake.statsReturns
+	fake.recordInvocation("Stats", []interface{}{arg1, arg2})
+	fake.statsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNetwork) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *FakeNetwork) StatsCalls(stub func(context.Context, containerd.Task) (garden.ContainerNetworkStat, error)) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *FakeNetwork) StatsArgsForCall(i int) (context.Context, containerd.Task) {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	argsForCall := fake.statsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNetwork) StatsReturns(result1 garden.ContainerNetworkStat, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 garden.ContainerNetworkStat
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetwork) StatsReturnsOnCall(i int, result1 garden.ContainerNetworkStat, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 garden.ContainerNetworkStat
+			result2 error
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 garden.ContainerNetworkStat
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeNetwork) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -314,6 +399,8 @@ func (fake *FakeNetwork) Invocations() map[string][][]interface{} {
 	defer fake.setupMountsMutex.RUnlock()
 	fake.setupRestrictedNetworksMutex.RLock()
 	defer fake.setupRestrictedNetworksMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
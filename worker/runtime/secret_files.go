@@ -0,0 +1,40 @@
+package runtime
+
+import (
+	"path/filepath"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . SecretFiles
+
+// SecretFiles manages the tmpfs mounts holding the secret files of
+// containers, so that they never touch the disk.
+//
+type SecretFiles interface {
+	// Mount mounts a tmpfs for the secret files of a container, returning
+	// its path on the host.
+	//
+	Mount(handle string) (path string, err error)
+
+	// Unmount unmounts and removes the tmpfs of a container, if it has one.
+	//
+	Unmount(handle string) (err error)
+}
+
+type tmpfsSecretFiles struct {
+	root string
+}
+
+var _ SecretFiles = (*tmpfsSecretFiles)(nil)
+
+// NewSecretFiles creates a SecretFiles which mounts the tmpfs of each
+// container under `root`.
+//
+func NewSecretFiles(root string) *tmpfsSecretFiles {
+	return &tmpfsSecretFiles{
+		root: root,
+	}
+}
+
+func (s tmpfsSecretFiles) path(handle string) string {
+	return filepath.Join(s.root, handle)
+}
@@ -0,0 +1,60 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// secretFilesSize caps the size of the tmpfs holding a container's secret
+// files.
+//
+const secretFilesSize = "4m"
+
+func (s tmpfsSecretFiles) Mount(handle string) (string, error) {
+	if handle == "" {
+		return "", ErrInvalidInput("empty handle")
+	}
+
+	path := s.path(handle)
+
+	err := os.MkdirAll(path, 0700)
+	if err != nil {
+		return "", fmt.Errorf("mkdirall: %w", err)
+	}
+
+	err = unix.Mount(
+		"tmpfs",
+		path,
+		"tmpfs",
+		unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC,
+		"mode=0755,size="+secretFilesSize,
+	)
+	if err != nil {
+		return "", fmt.Errorf("mount tmpfs: %w", err)
+	}
+
+	return path, nil
+}
+
+func (s tmpfsSecretFiles) Unmount(handle string) error {
+	if handle == "" {
+		return ErrInvalidInput("empty handle")
+	}
+
+	path := s.path(handle)
+
+	err := unix.Unmount(path, 0)
+	if err != nil && !errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.ENOENT) {
+		return fmt.Errorf("unmount tmpfs: %w", err)
+	}
+
+	err = os.RemoveAll(path)
+	if err != nil {
+		return fmt.Errorf("remove all: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package runtime
+
+// Mount is only supported on Linux.
+//
+func (s tmpfsSecretFiles) Mount(handle string) (string, error) {
+	return "", ErrNotImplemented
+}
+
+// Unmount is only supported on Linux.
+//
+func (s tmpfsSecretFiles) Unmount(handle string) error {
+	return nil
+}
@@ -0,0 +1,103 @@
+package spec
+
+import (
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/garden"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// Garden has no notion of block IO limits, so they're passed in the container
+// properties instead.
+//
+const (
+	IOReadBPSProperty   = "concourse:io-read-bps"
+	IOWriteBPSProperty  = "concourse:io-write-bps"
+	IOReadIOPSProperty  = "concourse:io-read-iops"
+	IOWriteIOPSProperty = "concourse:io-write-iops"
+)
+
+// IOLimits represents the throttling of the block IO of a container, with
+// zero meaning unlimited.
+//
+type IOLimits struct {
+	ReadBPS   uint64
+	WriteBPS  uint64
+	ReadIOPS  uint64
+	WriteIOPS uint64
+}
+
+// Unlimited indicates whether no block IO limits were set at all.
+//
+func (l IOLimits) Unlimited() bool {
+	return l == IOLimits{}
+}
+
+// BlockDevice identifies a block device by its major and minor numbers.
+//
+type BlockDevice struct {
+	Major int64
+	Minor int64
+}
+
+// ParseIOLimits retrieves the block IO limits from the properties of a
+// container.
+//
+func ParseIOLimits(properties garden.Properties) (limits IOLimits, err error) {
+	for property, limit := range map[string]*uint64{
+		IOReadBPSProperty:   &limits.ReadBPS,
+		IOWriteBPSProperty:  &limits.WriteBPS,
+		IOReadIOPSProperty:  &limits.ReadIOPS,
+		IOWriteIOPSProperty: &limits.WriteIOPS,
+	} {
+		value, found := properties[property]
+		if !found {
+			continue
+		}
+
+		*limit, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("parse %s: %w", property, err)
+			return
+		}
+	}
+
+	return
+}
+
+// OciBlockIO converts block IO limits into throttling rules for each of the
+// devices given.
+//
+// Under cgroups v2, these end up as `io.max` entries, which (unlike cgroups
+// v1's `blkio`) also throttle buffered writes.
+//
+func OciBlockIO(limits IOLimits, devices []BlockDevice) *specs.LinuxBlockIO {
+	if limits.Unlimited() || len(devices) == 0 {
+		return nil
+	}
+
+	throttle := func(rate uint64) []specs.LinuxThrottleDevice {
+		if rate == 0 {
+			return nil
+		}
+
+		var throttled []specs.LinuxThrottleDevice
+		for _, device := range devices {
+			throttledDevice := specs.LinuxThrottleDevice{Rate: rate}
+			throttledDevice.Major = device.Major
+			throttledDevice.Minor = device.Minor
+
+			throttled = append(throttled, throttledDevice)
+		}
+
+		return throttled
+	}
+
+	return &specs.LinuxBlockIO{
+		ThrottleReadBpsDevice:   throttle(limits.ReadBPS),
+		ThrottleWriteBpsDevice:  throttle(limits.WriteBPS),
+		ThrottleReadIOPSDevice:  throttle(limits.ReadIOPS),
+		ThrottleWriteIOPSDevice: throttle(limits.WriteIOPS),
+	}
+}
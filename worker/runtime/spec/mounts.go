@@ -80,3 +80,25 @@ func clearReadOnly(m *specs.Mount) {
 	}
 	m.Options = opt
 }
+
+// SecretFilesProperty is the container property requesting a tmpfs for the
+// container's secret files, set to where it should be mounted. Garden has no
+// notion of tmpfs mounts, so this is the only way to ask for one.
+//
+const SecretFilesProperty = "concourse:secret-files-dir"
+
+// SecretFilesMount places the tmpfs holding a container's secret files, found
+// at `source` on the host, at `destination` in the container.
+//
+// The tmpfs is mounted on the host rather than in the container so that the
+// files can be streamed into it before the container's processes run. It is
+// read-only from within the container.
+//
+func SecretFilesMount(source, destination string) specs.Mount {
+	return specs.Mount{
+		Source:      source,
+		Destination: destination,
+		Type:        "bind",
+		Options:     []string{"bind", "ro", "nosuid", "nodev", "noexec"},
+	}
+}
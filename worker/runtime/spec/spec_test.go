@@ -335,6 +335,41 @@ func (s *SpecSuite) TestOciResourceLimits() {
 	}
 }
 
+func (s *SpecSuite) TestParseIOLimits() {
+	limits, err := spec.ParseIOLimits(garden.Properties{
+		spec.IOReadBPSProperty:   "1024",
+		spec.IOWriteIOPSProperty: "100",
+		"unrelated":              "property",
+	})
+	s.NoError(err)
+	s.Equal(spec.IOLimits{ReadBPS: 1024, WriteIOPS: 100}, limits)
+
+	_, err = spec.ParseIOLimits(garden.Properties{
+		spec.IOWriteBPSProperty: "fast",
+	})
+	s.Error(err)
+}
+
+func (s *SpecSuite) TestOciBlockIO() {
+	devices := []spec.BlockDevice{{Major: 8, Minor: 0}, {Major: 259, Minor: 0}}
+
+	s.Nil(spec.OciBlockIO(spec.IOLimits{}, devices))
+	s.Nil(spec.OciBlockIO(spec.IOLimits{ReadBPS: 1024}, nil))
+
+	blockIO := spec.OciBlockIO(spec.IOLimits{ReadBPS: 1024, WriteIOPS: 100}, devices)
+	s.NotNil(blockIO)
+	s.Empty(blockIO.ThrottleWriteBpsDevice)
+	s.Empty(blockIO.ThrottleReadIOPSDevice)
+
+	s.Len(blockIO.ThrottleReadBpsDevice, 2)
+	s.Equal(int64(8), blockIO.ThrottleReadBpsDevice[0].Major)
+	s.Equal(int64(259), blockIO.ThrottleReadBpsDevice[1].Major)
+	s.Equal(uint64(1024), blockIO.ThrottleReadBpsDevice[1].Rate)
+
+	s.Len(blockIO.ThrottleWriteIOPSDevice, 2)
+	s.Equal(uint64(100), blockIO.ThrottleWriteIOPSDevice[0].Rate)
+}
+
 func (s *SpecSuite) TestOciCgroupsPath() {
 	for _, tc := range []struct {
 		desc       string
@@ -9,6 +9,7 @@ import (
 
 func TestSuite(t *testing.T) {
 	suite.Run(t, &BackendSuite{Assertions: require.New(t)})
+	suite.Run(t, &BlockDevicesSuite{Assertions: require.New(t)})
 	suite.Run(t, &CNINetworkSuite{Assertions: require.New(t)})
 	suite.Run(t, &ContainerSuite{Assertions: require.New(t)})
 	suite.Run(t, &FileStoreSuite{Assertions: require.New(t)})
@@ -16,6 +17,7 @@ func TestSuite(t *testing.T) {
 	suite.Run(t, &ProcessKillerSuite{Assertions: require.New(t)})
 	suite.Run(t, &ProcessSuite{Assertions: require.New(t)})
 	suite.Run(t, &RootfsManagerSuite{Assertions: require.New(t)})
+	suite.Run(t, &RootlessNetworkSuite{Assertions: require.New(t)})
 	suite.Run(t, &UserNamespaceSuite{Assertions: require.New(t)})
 	suite.Run(t, &TimeoutLockSuite{Assertions: require.New(t)})
 	suite.Run(t, &ResolveconfParserSuite{Assertions: require.New(t)})
@@ -4,7 +4,10 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 const (
@@ -30,10 +33,49 @@ func (s *userNamespace) MaxValidIds() (uint32, uint32, error) {
 	return maxValidUid, maxValidGid, nil
 }
 
+// rootlessUserNamespace determines the valid ids from the user namespace that
+// rootlesskit sets up for containerd, rather than the one of the current
+// process.
+//
+type rootlessUserNamespace struct {
+	stateDir string
+	procRoot string
+}
+
+// NewRootlessUserNamespace returns a UserNamespace for the user namespace of
+// the child process of the rootlesskit instance whose state lives under
+// `stateDir`.
+//
+func NewRootlessUserNamespace(stateDir string) UserNamespace {
+	return &rootlessUserNamespace{
+		stateDir: stateDir,
+		procRoot: procRoot,
+	}
+}
+
+func (s *rootlessUserNamespace) MaxValidIds() (uint32, uint32, error) {
+	pid, err := ioutil.ReadFile(filepath.Join(s.stateDir, "child_pid"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("read rootlesskit child pid: %w", err)
+	}
+
+	procDir := filepath.Join(s.procRoot, strings.TrimSpace(string(pid)))
+
+	maxValidUid, err := maxValidFromFile(filepath.Join(procDir, "uid_map"))
+	if err != nil {
+		return 0, 0, err
+	}
+	maxValidGid, err := maxValidFromFile(filepath.Join(procDir, "gid_map"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return maxValidUid, maxValidGid, nil
+}
+
 func maxValidFromFile(fname string) (uint32, error) {
-	f, err := os.Open(uidMap)
+	f, err := os.Open(fname)
 	if err != nil {
-		return 0, fmt.Errorf("open %s: %w", uidMap, err)
+		return 0, fmt.Errorf("open %s: %w", fname, err)
 	}
 	defer f.Close()
 
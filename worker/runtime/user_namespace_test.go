@@ -2,6 +2,10 @@ package runtime_test
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/concourse/concourse/worker/runtime"
@@ -68,3 +72,24 @@ func (s *UserNamespaceSuite) TestMaxValid() {
 		})
 	}
 }
+
+func (s *UserNamespaceSuite) TestRootlessMaxValidIdsMissingChildPid() {
+	userns := runtime.NewRootlessUserNamespace(s.T().TempDir())
+
+	_, _, err := userns.MaxValidIds()
+	s.Error(err)
+}
+
+func (s *UserNamespaceSuite) TestRootlessMaxValidIdsReadsChildMappings() {
+	stateDir := s.T().TempDir()
+	err := ioutil.WriteFile(filepath.Join(stateDir, "child_pid"), []byte(strconv.Itoa(os.Getpid())+"\n"), 0644)
+	s.NoError(err)
+
+	expectedUid, expectedGid, err := runtime.NewUserNamespace().MaxValidIds()
+	s.NoError(err)
+
+	uid, gid, err := runtime.NewRootlessUserNamespace(stateDir).MaxValidIds()
+	s.NoError(err)
+	s.Equal(expectedUid, uid)
+	s.Equal(expectedGid, gid)
+}
@@ -13,6 +13,7 @@ type TSAClient interface {
 
 	Land(context.Context) error
 	Retire(context.Context) error
+	Evict(context.Context) error
 	Delete(context.Context) error
 
 	ReportContainers(context.Context, []string) error
@@ -1,6 +1,12 @@
 package worker
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"github.com/concourse/flag"
@@ -9,14 +15,72 @@ import (
 type TSAConfig struct {
 	Hosts            []string            `long:"host" default:"127.0.0.1:2222" description:"TSA host to forward the worker through. Can be specified multiple times."`
 	PublicKey        flag.AuthorizedKeys `long:"public-key" description:"File containing a public key to expect from the TSA."`
-	WorkerPrivateKey *flag.PrivateKey    `long:"worker-private-key" required:"true" description:"File containing the private key to use when authenticating to the TSA."`
+	WorkerPrivateKey *flag.PrivateKey    `long:"worker-private-key" description:"File containing the private key to use when authenticating to the TSA. Required unless registering through the worker gateway."`
+	EnrollmentToken  string              `long:"enrollment-token" description:"Token created with 'fly create-worker-enrollment-token' to enroll the worker's key for a team, if it isn't authorized yet."`
+
+	GatewayHosts  []string  `long:"gateway-host" description:"Worker gateway host to register the worker through over HTTPS instead of SSH. Can be specified multiple times."`
+	GatewayCACert flag.File `long:"gateway-ca-cert" description:"File containing the CA certificate to verify the worker gateway's certificate with. Defaults to the system CAs."`
+	WorkerCert    flag.File `long:"worker-cert" description:"File containing the worker's client certificate for the worker gateway. Its common name must be the worker's name."`
+	WorkerKey     flag.File `long:"worker-key" description:"File containing the private key of the worker's client certificate."`
 }
 
-func (config TSAConfig) Client(worker atc.Worker) *tsa.Client {
+// Client returns a client for the worker gateway if any gateway hosts are
+// configured, and for the SSH gateway otherwise.
+func (config TSAConfig) Client(worker atc.Worker) (TSAClient, error) {
+	if len(config.GatewayHosts) > 0 {
+		tlsConfig, err := config.gatewayTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		return &tsa.GatewayClient{
+			Hosts:     config.GatewayHosts,
+			TLSConfig: tlsConfig,
+			Worker:    worker,
+		}, nil
+	}
+
+	if config.WorkerPrivateKey == nil {
+		return nil, errors.New("--tsa-worker-private-key must be specified unless --tsa-gateway-host is")
+	}
+
 	return &tsa.Client{
 		Hosts:      config.Hosts,
 		HostKeys:   config.PublicKey.Keys,
 		PrivateKey: config.WorkerPrivateKey.PrivateKey,
-		Worker:     worker,
+
+		EnrollmentToken: config.EnrollmentToken,
+
+		Worker: worker,
+	}, nil
+}
+
+func (config TSAConfig) gatewayTLSConfig() (*tls.Config, error) {
+	if config.WorkerCert == "" || config.WorkerKey == "" {
+		return nil, errors.New("--tsa-worker-cert and --tsa-worker-key must be specified to use the worker gateway")
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.WorkerCert.Path(), config.WorkerKey.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load worker certificate: %s", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
 	}
+
+	if config.GatewayCACert != "" {
+		caCert, err := ioutil.ReadFile(config.GatewayCACert.Path())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gateway CA certificate: %s", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no certificates found in gateway CA certificate file")
+		}
+	}
+
+	return tlsConfig, nil
 }
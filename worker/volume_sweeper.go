@@ -3,22 +3,38 @@ package worker
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+)
+
+// layerStoreGracePeriod is how long a layer store volume is kept after it was
+// last used, regardless of the store's size, so that it is not evicted
+// between being found and a container's copy-on-write volume being created
+// from it. Unpopulated volumes older than layerStoreStaleAfter are assumed to
+// have been abandoned by an ATC while populating them.
+const (
+	layerStoreGracePeriod = 5 * time.Minute
+	layerStoreStaleAfter  = time.Hour
 )
 
 // volumeSweeper is an ifrit.Runner that periodically reports and
-// garbage-collects a worker's volumes
+// garbage-collects a worker's volumes, and evicts the least recently used
+// volumes from the worker's layer store once it grows beyond its maximum size
 type volumeSweeper struct {
-	logger             lager.Logger
-	interval           time.Duration
-	tsaClient          TSAClient
-	baggageclaimClient baggageclaim.Client
-	maxInFlight        uint16
+	logger               lager.Logger
+	interval             time.Duration
+	tsaClient            TSAClient
+	baggageclaimClient   baggageclaim.Client
+	maxInFlight          uint16
+	layerStoreMaxSize    int64
 }
 
 func NewVolumeSweeper(
@@ -27,13 +43,15 @@ func NewVolumeSweeper(
 	tsaClient TSAClient,
 	bcClient baggageclaim.Client,
 	maxInFlight uint16,
+	layerStoreMaxSize int64,
 ) *volumeSweeper {
 	return &volumeSweeper{
-		logger:             logger,
-		interval:           sweepInterval,
-		tsaClient:          tsaClient,
-		baggageclaimClient: bcClient,
-		maxInFlight:        maxInFlight,
+		logger:               logger,
+		interval:             sweepInterval,
+		tsaClient:            tsaClient,
+		baggageclaimClient:   bcClient,
+		maxInFlight:          maxInFlight,
+		layerStoreMaxSize:    layerStoreMaxSize,
 	}
 }
 
@@ -57,19 +75,9 @@ func (sweeper *volumeSweeper) Run(signals <-chan os.Signal, ready chan<- struct{
 func (sweeper *volumeSweeper) sweep(logger lager.Logger) {
 	ctx := lagerctx.NewContext(context.Background(), logger)
 
-	volumes, err := sweeper.baggageclaimClient.ListVolumes(logger.Session("list-volumes"), baggageclaim.VolumeProperties{})
+	layerStoreVolumes, err := sweeper.reportVolumes(ctx, logger)
 	if err != nil {
-		logger.Error("failed-to-list-volumes", err)
-	} else {
-		handles := []string{}
-		for _, volume := range volumes {
-			handles = append(handles, volume.Handle())
-		}
-
-		err := sweeper.tsaClient.ReportVolumes(ctx, handles)
-		if err != nil {
-			logger.Error("failed-to-report-volumes", err)
-		}
+		logger.Error("failed-to-report-volumes", err)
 	}
 
 	volumeHandles, err := sweeper.tsaClient.VolumesToDestroy(ctx)
@@ -95,4 +103,190 @@ func (sweeper *volumeSweeper) sweep(logger lager.Logger) {
 		}
 		wg.Wait()
 	}
+
+	if len(layerStoreVolumes) > 0 {
+		sweeper.evictLayerStoreVolumes(logger.Session("evict-layer-store-volumes"), layerStoreVolumes)
+	}
+}
+
+// reportVolumes reports the worker's volumes to the ATC, leaving out the
+// layer store volumes, which are returned instead. Layer store volumes are
+// managed by the worker and would otherwise be destroyed as unknown volumes.
+func (sweeper *volumeSweeper) reportVolumes(ctx context.Context, logger lager.Logger) (baggageclaim.Volumes, error) {
+	volumes, err := sweeper.baggageclaimClient.ListVolumes(logger.Session("list-volumes"), baggageclaim.VolumeProperties{})
+	if err != nil {
+		return nil, err
+	}
+
+	// listed after all volumes so that any layer store volume created in
+	// between is still left out
+	layerStoreVolumes, err := sweeper.baggageclaimClient.ListVolumes(logger.Session("list-layer-store-volumes"), baggageclaim.VolumeProperties{
+		atc.LayerStoreProperty: "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	layerStoreHandles := map[string]bool{}
+	for _, volume := range layerStoreVolumes {
+		layerStoreHandles[volume.Handle()] = true
+	}
+
+	handles := []string{}
+	for _, volume := range volumes {
+		if layerStoreHandles[volume.Handle()] {
+			continue
+		}
+
+		handles = append(handles, volume.Handle())
+	}
+
+	err = sweeper.tsaClient.ReportVolumes(ctx, handles)
+	if err != nil {
+		return nil, err
+	}
+
+	return layerStoreVolumes, nil
+}
+
+type layerStoreVolume struct {
+	volume   baggageclaim.Volume
+	ready    bool
+	lastUsed time.Time
+	size     int64
+}
+
+func (sweeper *volumeSweeper) evictLayerStoreVolumes(logger lager.Logger, volumes baggageclaim.Volumes) {
+	now := time.Now()
+
+	var ready []layerStoreVolume
+	var totalSize int64
+	for _, volume := range volumes {
+		properties, err := volume.Properties()
+		if err != nil {
+			logger.WithData(lager.Data{"handle": volume.Handle()}).Error("failed-to-get-properties", err)
+			continue
+		}
+
+		lastUsed, err := strconv.ParseInt(properties[atc.LayerStoreLastUsedProperty], 10, 64)
+		if err != nil {
+			logger.WithData(lager.Data{"handle": volume.Handle()}).Error("failed-to-parse-last-used", err)
+			continue
+		}
+
+		storeVolume := layerStoreVolume{
+			volume:   volume,
+			ready:    properties[atc.LayerStoreReadyProperty] == "true",
+			lastUsed: time.Unix(lastUsed, 0),
+		}
+
+		if !storeVolume.ready {
+			if now.Sub(storeVolume.lastUsed) > layerStoreStaleAfter {
+				sweeper.evictLayerStoreVolume(logger, storeVolume)
+			}
+
+			continue
+		}
+
+		storeVolume.size, err = sweeper.layerStoreVolumeSize(volume, properties)
+		if err != nil {
+			logger.WithData(lager.Data{"handle": volume.Handle()}).Error("failed-to-get-size", err)
+			continue
+		}
+
+		ready = append(ready, storeVolume)
+		totalSize += storeVolume.size
+	}
+
+	if totalSize <= sweeper.layerStoreMaxSize {
+		return
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].lastUsed.Before(ready[j].lastUsed)
+	})
+
+	for _, storeVolume := range ready {
+		if totalSize <= sweeper.layerStoreMaxSize {
+			break
+		}
+
+		if now.Sub(storeVolume.lastUsed) < layerStoreGracePeriod {
+			// sorted by last use, so the rest are recent too
+			break
+		}
+
+		children, err := sweeper.baggageclaimClient.ListVolumes(logger.Session("list-children"), baggageclaim.VolumeProperties{
+			atc.LayerStoreParentProperty: storeVolume.volume.Handle(),
+		})
+		if err != nil {
+			logger.WithData(lager.Data{"handle": storeVolume.volume.Handle()}).Error("failed-to-list-children", err)
+			continue
+		}
+
+		if len(children) > 0 {
+			continue
+		}
+
+		if sweeper.evictLayerStoreVolume(logger, storeVolume) {
+			totalSize -= storeVolume.size
+		}
+	}
+}
+
+// layerStoreVolumeSize returns the size of a populated layer store volume,
+// measuring it the first time around. Populated volumes never change, so the
+// size is kept in the volume's properties from then on.
+func (sweeper *volumeSweeper) layerStoreVolumeSize(volume baggageclaim.Volume, properties baggageclaim.VolumeProperties) (int64, error) {
+	if size, found := properties[atc.LayerStoreSizeProperty]; found {
+		return strconv.ParseInt(size, 10, 64)
+	}
+
+	size, err := diskUsage(volume.Path())
+	if err != nil {
+		return 0, err
+	}
+
+	err = volume.SetProperty(atc.LayerStoreSizeProperty, strconv.FormatInt(size, 10))
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// diskUsage sums up the sizes of the regular files under dir.
+func diskUsage(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
+
+func (sweeper *volumeSweeper) evictLayerStoreVolume(logger lager.Logger, storeVolume layerStoreVolume) bool {
+	handle := storeVolume.volume.Handle()
+
+	err := sweeper.baggageclaimClient.DestroyVolume(logger.Session("destroy-volume"), handle)
+	if err != nil {
+		logger.WithData(lager.Data{"handle": handle}).Error("failed-to-destroy-volume", err)
+		return false
+	}
+
+	logger.Debug("evicted", lager.Data{
+		"handle":    handle,
+		"ready":     storeVolume.ready,
+		"last-used": storeVolume.lastUsed,
+	})
+
+	return true
 }
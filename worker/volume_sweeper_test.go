@@ -0,0 +1,201 @@
+package worker_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/worker"
+	"github.com/concourse/concourse/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volume Sweeper", func() {
+	const (
+		sweepInterval        = 10 * time.Millisecond
+		maxInFlight          = uint16(1)
+		layerStoreMaxSize = int64(1024)
+	)
+
+	var (
+		testLogger = lagertest.NewTestLogger("volume-sweeper")
+
+		fakeTSAClient          *workerfakes.FakeTSAClient
+		fakeBaggageclaimClient *baggageclaimfakes.FakeClient
+
+		regularVolume     *baggageclaimfakes.FakeVolume
+		layerStoreVolumes baggageclaim.Volumes
+		children          map[string]baggageclaim.Volumes
+
+		osSignal chan os.Signal
+		exited   chan struct{}
+	)
+
+	storeVolume := func(handle string, ready bool, lastUsed time.Time) *baggageclaimfakes.FakeVolume {
+		volume := new(baggageclaimfakes.FakeVolume)
+		volume.HandleReturns(handle)
+		volume.PropertiesReturns(baggageclaim.VolumeProperties{
+			atc.LayerStoreProperty:         "true",
+			atc.LayerStoreReadyProperty:    strconv.FormatBool(ready),
+			atc.LayerStoreLastUsedProperty: strconv.FormatInt(lastUsed.Unix(), 10),
+			atc.LayerStoreSizeProperty:     "1000",
+		}, nil)
+		return volume
+	}
+
+	destroyedHandles := func() []string {
+		handles := []string{}
+		for i := 0; i < fakeBaggageclaimClient.DestroyVolumeCallCount(); i++ {
+			_, handle := fakeBaggageclaimClient.DestroyVolumeArgsForCall(i)
+			handles = append(handles, handle)
+		}
+		return handles
+	}
+
+	BeforeEach(func() {
+		osSignal = make(chan os.Signal)
+		exited = make(chan struct{})
+
+		fakeTSAClient = new(workerfakes.FakeTSAClient)
+		fakeBaggageclaimClient = new(baggageclaimfakes.FakeClient)
+
+		regularVolume = new(baggageclaimfakes.FakeVolume)
+		regularVolume.HandleReturns("regular-handle")
+
+		layerStoreVolumes = nil
+		children = map[string]baggageclaim.Volumes{}
+
+		fakeBaggageclaimClient.ListVolumesStub = func(_ lager.Logger, properties baggageclaim.VolumeProperties) (baggageclaim.Volumes, error) {
+			if parent, ok := properties[atc.LayerStoreParentProperty]; ok {
+				return children[parent], nil
+			}
+
+			if properties[atc.LayerStoreProperty] == "true" {
+				return layerStoreVolumes, nil
+			}
+
+			return append(baggageclaim.Volumes{regularVolume}, layerStoreVolumes...), nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		sweeper := worker.NewVolumeSweeper(testLogger, sweepInterval, fakeTSAClient, fakeBaggageclaimClient, maxInFlight, layerStoreMaxSize)
+
+		go func() {
+			_ = sweeper.Run(osSignal, make(chan struct{}))
+			close(exited)
+		}()
+	})
+
+	AfterEach(func() {
+		close(osSignal)
+		<-exited
+	})
+
+	Context("when the worker has layer store volumes", func() {
+		BeforeEach(func() {
+			layerStoreVolumes = baggageclaim.Volumes{
+				storeVolume("recent-handle", true, time.Now()),
+				storeVolume("old-handle", true, time.Now().Add(-time.Hour)),
+			}
+		})
+
+		It("does not report them to the ATC", func() {
+			Eventually(fakeTSAClient.ReportVolumesCallCount).Should(BeNumerically(">", 0))
+			_, handles := fakeTSAClient.ReportVolumesArgsForCall(0)
+			Expect(handles).To(Equal([]string{"regular-handle"}))
+		})
+
+		It("evicts the least recently used ones beyond the maximum size", func() {
+			Eventually(destroyedHandles).Should(ContainElement("old-handle"))
+			Consistently(destroyedHandles).ShouldNot(ContainElement("recent-handle"))
+		})
+
+		Context("when the least recently used one is still in use by a container", func() {
+			BeforeEach(func() {
+				children["old-handle"] = baggageclaim.Volumes{new(baggageclaimfakes.FakeVolume)}
+			})
+
+			It("does not evict it", func() {
+				Eventually(fakeTSAClient.ReportVolumesCallCount).Should(BeNumerically(">", 1))
+				Consistently(destroyedHandles).Should(BeEmpty())
+			})
+		})
+	})
+
+	Context("when a layer store volume was used within the grace period", func() {
+		BeforeEach(func() {
+			layerStoreVolumes = baggageclaim.Volumes{
+				storeVolume("recent-handle", true, time.Now()),
+				storeVolume("less-recent-handle", true, time.Now().Add(-time.Minute)),
+			}
+		})
+
+		It("does not evict it even beyond the maximum size", func() {
+			Eventually(fakeTSAClient.ReportVolumesCallCount).Should(BeNumerically(">", 1))
+			Consistently(destroyedHandles).Should(BeEmpty())
+		})
+	})
+
+	Context("when the size of a layer store volume is not known yet", func() {
+		var (
+			dir              string
+			unmeasuredVolume *baggageclaimfakes.FakeVolume
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "layer-store-volume")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.MkdirAll(filepath.Join(dir, "rootfs", "bin"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "metadata.json"), make([]byte, 24), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "rootfs", "bin", "sh"), make([]byte, 100), 0755)).To(Succeed())
+
+			unmeasuredVolume = new(baggageclaimfakes.FakeVolume)
+			unmeasuredVolume.HandleReturns("unmeasured-handle")
+			unmeasuredVolume.PathReturns(dir)
+			unmeasuredVolume.PropertiesReturns(baggageclaim.VolumeProperties{
+				atc.LayerStoreProperty:         "true",
+				atc.LayerStoreReadyProperty:    "true",
+				atc.LayerStoreLastUsedProperty: strconv.FormatInt(time.Now().Unix(), 10),
+			}, nil)
+
+			layerStoreVolumes = baggageclaim.Volumes{unmeasuredVolume}
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("measures it and keeps the size in its properties", func() {
+			Eventually(unmeasuredVolume.SetPropertyCallCount).Should(BeNumerically(">", 0))
+			key, value := unmeasuredVolume.SetPropertyArgsForCall(0)
+			Expect(key).To(Equal(atc.LayerStoreSizeProperty))
+			Expect(value).To(Equal("124"))
+		})
+	})
+
+	Context("when a layer store volume was abandoned while being populated", func() {
+		BeforeEach(func() {
+			layerStoreVolumes = baggageclaim.Volumes{
+				storeVolume("abandoned-handle", false, time.Now().Add(-2*time.Hour)),
+				storeVolume("populating-handle", false, time.Now()),
+			}
+		})
+
+		It("evicts it", func() {
+			Eventually(destroyedHandles).Should(ContainElement("abandoned-handle"))
+			Consistently(destroyedHandles).ShouldNot(ContainElement("populating-handle"))
+		})
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"github.com/concourse/concourse/worker/mtu"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 )
@@ -39,6 +40,22 @@ func WriteDefaultContainerdConfig(dest string) error {
 oom_score = -999
 disabled_plugins = ["cri", "aufs", "btrfs", "zfs"]
 `
+	return writeContainerdConfig(dest, config)
+}
+
+// WriteRootlessContainerdConfig writes the default containerd configuration
+// file for running in rootless mode to a destination.
+func WriteRootlessContainerdConfig(dest string) error {
+	// same as the default configuration, except for `oom_score`: lowering it
+	// requires CAP_SYS_RESOURCE in the initial user namespace.
+	//
+	const config = `
+disabled_plugins = ["cri", "aufs", "btrfs", "zfs"]
+`
+	return writeContainerdConfig(dest, config)
+}
+
+func writeContainerdConfig(dest, config string) error {
 	err := ioutil.WriteFile(dest, []byte(config), 0755)
 	if err != nil {
 		return fmt.Errorf("write file %s: %w", dest, err)
@@ -60,6 +77,80 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 	)
 
 	backendOpts := []runtime.GardenBackendOpt{}
+
+	var (
+		network runtime.Network
+		err     error
+	)
+
+	if cmd.Containerd.Rootless {
+		network = cmd.rootlessNetwork(dnsServers)
+
+		backendOpts = append(backendOpts,
+			runtime.WithRootless(),
+			runtime.WithUserNamespace(runtime.NewRootlessUserNamespace(cmd.rootlesskitStateDir())),
+		)
+	} else {
+		network, err = cmd.cniNetwork(dnsServers)
+		if err != nil {
+			return nil, err
+		}
+
+		// mounting a tmpfs requires privileges that rootless mode lacks
+		backendOpts = append(backendOpts,
+			runtime.WithSecretFiles(runtime.NewSecretFiles(filepath.Join(cmd.WorkDir.Path(), "secrets"))),
+		)
+	}
+
+	backendOpts = append(backendOpts,
+		runtime.WithNetwork(network),
+		runtime.WithRequestTimeout(cmd.Containerd.RequestTimeout),
+		runtime.WithMaxContainers(cmd.Containerd.MaxContainers),
+		runtime.WithInitBinPath(cmd.Containerd.InitBin),
+		runtime.WithStorePath(cmd.WorkDir.Path()),
+		runtime.WithBlockDevices(cmd.blockDevices(logger)),
+	)
+
+	gardenBackend, err := runtime.NewGardenBackend(
+		libcontainerd.New(containerdAddr, namespace, cmd.Containerd.RequestTimeout),
+		backendOpts...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("containerd containerd init: %w", err)
+	}
+
+	server := server.New("tcp", cmd.bindAddr(),
+		graceTime,
+		&gardenBackend,
+		logger,
+	)
+
+	return gardenServerRunner{logger, server}, nil
+}
+
+// blockDevices determines the disks that the IO of containers with IO limits
+// gets throttled on: the ones backing the work dir, where both volumes and
+// containerd's state live.
+//
+// IO limits are only enforced with cgroups v2, as cgroups v1 is unable to
+// throttle buffered writes.
+func (cmd *WorkerCommand) blockDevices(logger lager.Logger) []bespec.BlockDevice {
+	if !runtime.CgroupsUnified() {
+		return nil
+	}
+
+	devices, err := runtime.BlockDevices(cmd.WorkDir.Path())
+	if err != nil {
+		logger.Info("io-limits-not-supported", lager.Data{"error": err.Error()})
+		return nil
+	}
+
+	return devices
+}
+
+// cniNetwork sets up the network that puts containers behind a bridge with
+// CNI plugins.
+func (cmd *WorkerCommand) cniNetwork(dnsServers []string) (runtime.Network, error) {
 	networkOpts := []runtime.CNINetworkOpt{runtime.WithCNIBinariesDir(cmd.Containerd.CNIPluginsDir)}
 
 	if len(dnsServers) > 0 {
@@ -86,41 +177,81 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 		return nil, fmt.Errorf("new cni network: %w", err)
 	}
 
-	backendOpts = append(backendOpts,
-		runtime.WithNetwork(cniNetwork),
-		runtime.WithRequestTimeout(cmd.Containerd.RequestTimeout),
-		runtime.WithMaxContainers(cmd.Containerd.MaxContainers),
-		runtime.WithInitBinPath(cmd.Containerd.InitBin),
-	)
+	return cniNetwork, nil
+}
 
-	gardenBackend, err := runtime.NewGardenBackend(
-		libcontainerd.New(containerdAddr, namespace, cmd.Containerd.RequestTimeout),
-		backendOpts...,
+// rootlessNetwork sets up the network which connects each container's
+// network namespace to the outside world through its own instance of the
+// configured user-mode network stack.
+func (cmd *WorkerCommand) rootlessNetwork(dnsServers []string) runtime.Network {
+	// both slirp4netns and pasta forward DNS queries sent to their built-in
+	// resolver to the host's.
+	if len(dnsServers) == 0 {
+		dnsServers = []string{runtime.RootlessDNSServer}
+	}
+
+	return runtime.NewRootlessNetwork(
+		runtime.NewFileStore(filepath.Join(cmd.WorkDir.Path(), "network")),
+		dnsServers,
+		runtime.WithRootlessNetworkStack(cmd.Containerd.RootlessNetwork),
+		runtime.WithRootlessNetworkMTU(cmd.Containerd.Network.MTU),
 	)
+}
+
+func (cmd *WorkerCommand) rootlesskitStateDir() string {
+	return filepath.Join(cmd.WorkDir.Path(), "rootlesskit")
+}
+
+// rootlesskitCommand wraps the containerd command so that it runs inside the
+// user and network namespaces set up by rootlesskit.
+func (cmd *WorkerCommand) rootlesskitCommand(containerdArgs ...string) (*exec.Cmd, error) {
+	stateDir := cmd.rootlesskitStateDir()
+
+	// rootlesskit refuses to reuse the state left behind by a previous run
+	err := os.RemoveAll(stateDir)
 	if err != nil {
-		return nil, fmt.Errorf("containerd containerd init: %w", err)
+		return nil, fmt.Errorf("remove rootlesskit state dir: %w", err)
 	}
 
-	server := server.New("tcp", cmd.bindAddr(),
-		graceTime,
-		&gardenBackend,
-		logger,
-	)
+	bin := "rootlesskit"
+	if cmd.Containerd.RootlesskitBin != "" {
+		bin = cmd.Containerd.RootlesskitBin
+	}
 
-	return gardenServerRunner{logger, server}, nil
+	args := []string{
+		"--net=" + cmd.Containerd.RootlessNetwork,
+		"--copy-up=/etc",
+		"--copy-up=/run",
+		"--disable-host-loopback",
+		// let the overlays which baggageclaim mounts from outside of
+		// rootlesskit's mount namespace propagate into it
+		"--propagation=rslave",
+		"--state-dir=" + stateDir,
+	}
+
+	if cmd.Containerd.Network.MTU != 0 {
+		args = append(args, fmt.Sprintf("--mtu=%d", cmd.Containerd.Network.MTU))
+	}
+
+	return exec.Command(bin, append(args, containerdArgs...)...), nil
 }
 
 // containerdRunner spawns a containerd and a Garden server process for use as the container
 // runtime of Concourse.
 func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, error) {
-	const sock = "/run/containerd/containerd.sock"
-
 	var (
+		sock   = "/run/containerd/containerd.sock"
 		config = filepath.Join(cmd.WorkDir.Path(), "containerd.toml")
 		root   = filepath.Join(cmd.WorkDir.Path(), "containerd")
 		bin    = "containerd"
 	)
 
+	if cmd.Containerd.Rootless {
+		// /run is not writable without root; besides, the socket must be
+		// reachable from outside of rootlesskit's mount namespace.
+		sock = filepath.Join(cmd.WorkDir.Path(), "containerd.sock")
+	}
+
 	err := os.MkdirAll(root, 0755)
 	if err != nil {
 		return nil, err
@@ -129,7 +260,12 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 	if cmd.Containerd.Config.Path() != "" {
 		config = cmd.Containerd.Config.Path()
 	} else {
-		err := WriteDefaultContainerdConfig(config)
+		writeConfig := WriteDefaultContainerdConfig
+		if cmd.Containerd.Rootless {
+			writeConfig = WriteRootlessContainerdConfig
+		}
+
+		err := writeConfig(config)
 		if err != nil {
 			return nil, fmt.Errorf("write default containerd config: %w", err)
 		}
@@ -139,11 +275,19 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 		bin = cmd.Containerd.Bin
 	}
 
-	command := exec.Command(bin,
-		"--address="+sock,
-		"--root="+root,
-		"--config="+config,
-	)
+	containerdArgs := []string{
+		"--address=" + sock,
+		"--root=" + root,
+		"--config=" + config,
+	}
+
+	command := exec.Command(bin, containerdArgs...)
+	if cmd.Containerd.Rootless {
+		command, err = cmd.rootlesskitCommand(append([]string{bin}, containerdArgs...)...)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	command.Stdout = os.Stdout
 	command.Stderr = os.Stderr
@@ -3,17 +3,22 @@ package workercmd
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim/baggageclaimcmd"
 	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/concourse/baggageclaim/uidgid"
 	"github.com/concourse/concourse"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/worker/gclient"
 	concourseCmd "github.com/concourse/concourse/cmd"
 	"github.com/concourse/concourse/worker"
+	"github.com/concourse/concourse/worker/delta"
 	"github.com/concourse/flag"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
@@ -43,6 +48,7 @@ type WorkerCommand struct {
 	SweepInterval               time.Duration `long:"sweep-interval" default:"30s" description:"Interval on which containers and volumes will be garbage collected from the worker."`
 	VolumeSweeperMaxInFlight    uint16        `long:"volume-sweeper-max-in-flight" default:"3" description:"Maximum number of volumes which can be swept in parallel."`
 	ContainerSweeperMaxInFlight uint16        `long:"container-sweeper-max-in-flight" default:"5" description:"Maximum number of containers which can be swept in parallel."`
+	LayerStoreMaxSize           int64         `long:"layer-store-max-size" default:"10737418240" description:"Maximum total size in bytes of the registry images kept in the worker's layer store. The least recently used ones are evicted when sweeping once it is exceeded."`
 
 	RebalanceInterval time.Duration `long:"rebalance-interval" default:"4h" description:"Duration after which the registration should be swapped to another random SSH gateway."`
 
@@ -60,6 +66,9 @@ type WorkerCommand struct {
 
 	Baggageclaim baggageclaimcmd.BaggageclaimCommand `group:"Baggageclaim Configuration" namespace:"baggageclaim"`
 
+	EnableDeltaVolumeStreaming bool   `long:"enable-delta-volume-streaming" description:"Serve delta volume streaming requests in front of Baggageclaim. The ATC then reaches Baggageclaim through the delta server, which proxies all other requests through to it. Keyed caches are only saved on workers serving it, as it measures their size."`
+	VolumeDeltaBindPort        uint16 `long:"volume-delta-bind-port" default:"7789" description:"Port on which to listen for delta volume streaming requests. Listens on the Baggageclaim bind IP."`
+
 	ResourceTypes flag.Dir `long:"resource-types" description:"Path to directory containing resource types the worker should advertise."`
 
 	Logger flag.Lager
@@ -93,6 +102,16 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	var deltaServer *delta.Server
+	if cmd.EnableDeltaVolumeStreaming {
+		deltaServer, err = cmd.volumeDeltaServer(logger.Session("volume-delta"))
+		if err != nil {
+			return nil, err
+		}
+
+		atcWorker.Features = append(atcWorker.Features, atc.WorkerFeatureDeltaVolumeStreaming)
+	}
+
 	healthChecker := worker.NewHealthChecker(
 		logger.Session("healthchecker"),
 		cmd.baggageclaimURL(),
@@ -100,7 +119,10 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		cmd.HealthCheckTimeout,
 	)
 
-	tsaClient := cmd.TSA.Client(atcWorker)
+	tsaClient, err := cmd.TSA.Client(atcWorker)
+	if err != nil {
+		return nil, err
+	}
 
 	beaconRunner := worker.NewBeaconRunner(
 		logger.Session("beacon-runner"),
@@ -108,7 +130,7 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		cmd.RebalanceInterval,
 		cmd.ConnectionDrainTimeout,
 		cmd.gardenAddr(),
-		cmd.baggageclaimAddr(),
+		cmd.forwardedBaggageclaimAddr(),
 	)
 
 	gardenClient := gclient.BasicGardenClientWithRequestTimeout(
@@ -151,6 +173,7 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		tsaClient,
 		baggageclaimClient,
 		cmd.VolumeSweeperMaxInFlight,
+		cmd.LayerStoreMaxSize,
 	)
 
 	var members grouper.Members
@@ -210,6 +233,16 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		},
 	}...)
 
+	if deltaServer != nil {
+		members = append(members, grouper.Member{
+			Name: "volume-delta",
+			Runner: concourseCmd.NewLoggingRunner(
+				logger.Session("volume-delta-runner"),
+				http_server.New(cmd.volumeDeltaAddr(), deltaServer),
+			),
+		})
+	}
+
 	return grouper.NewParallel(os.Interrupt, members), nil
 }
 
@@ -237,6 +270,20 @@ func (cmd *WorkerCommand) baggageclaimURL() string {
 	return fmt.Sprintf("http://%s", cmd.baggageclaimAddr())
 }
 
+func (cmd *WorkerCommand) volumeDeltaAddr() string {
+	return fmt.Sprintf("%s:%d", cmd.Baggageclaim.BindIP, cmd.VolumeDeltaBindPort)
+}
+
+// forwardedBaggageclaimAddr is the address through which the ATC reaches
+// Baggageclaim, which is the delta server sitting in front of it if enabled.
+func (cmd *WorkerCommand) forwardedBaggageclaimAddr() string {
+	if cmd.EnableDeltaVolumeStreaming {
+		return cmd.volumeDeltaAddr()
+	}
+
+	return cmd.baggageclaimAddr()
+}
+
 func (cmd *WorkerCommand) workerName() (string, error) {
 	if cmd.Worker.Name != "" {
 		return cmd.Worker.Name, nil
@@ -257,5 +304,65 @@ func (cmd *WorkerCommand) baggageclaimRunner(logger lager.Logger) (ifrit.Runner,
 
 	cmd.Baggageclaim.OverlaysDir = filepath.Join(cmd.WorkDir.Path(), "overlays")
 
+	if cmd.rootless() {
+		// volumes are managed from outside of rootlesskit's user namespace.
+		// btrfs subvolumes can't be set up there without root, but overlays
+		// can when the worker runs in a user namespace of its own on a kernel
+		// allowing unprivileged overlay mounts, which is much cheaper than
+		// copying volumes around.
+		overlay := overlayMountable(cmd.Baggageclaim.OverlaysDir)
+
+		switch cmd.Baggageclaim.Driver {
+		case "detect":
+			if overlay {
+				cmd.Baggageclaim.Driver = "overlay"
+			} else {
+				logger.Info("unprivileged-overlay-not-supported-using-naive-driver")
+				cmd.Baggageclaim.Driver = "naive"
+			}
+		case "naive":
+		case "overlay":
+			if !overlay {
+				return nil, fmt.Errorf("baggageclaim driver 'overlay' requires unprivileged overlay mounts in rootless mode, which are not supported here")
+			}
+		default:
+			return nil, fmt.Errorf("baggageclaim driver '%s' is not supported in rootless mode", cmd.Baggageclaim.Driver)
+		}
+	}
+
 	return cmd.Baggageclaim.Runner(nil)
 }
+
+// volumeDeltaServer must be called after baggageclaimRunner, which configures
+// the volumes directory.
+func (cmd *WorkerCommand) volumeDeltaServer(logger lager.Logger) (*delta.Server, error) {
+	baggageclaimURL, err := url.Parse(cmd.baggageclaimURL())
+	if err != nil {
+		return nil, err
+	}
+
+	p2pInterfacePattern, err := regexp.Compile(cmd.Baggageclaim.P2pInterfaceNamePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// unprivileged volumes store files owned by root as owned by the max ID,
+	// matching Baggageclaim's namespacing
+	var maxID int
+	if !cmd.Baggageclaim.DisableUserNamespaces && uidgid.Supported() {
+		maxID = uidgid.MustGetMaxValidUID()
+		if gid := uidgid.MustGetMaxValidGID(); gid < maxID {
+			maxID = gid
+		}
+	}
+
+	return delta.NewServer(
+		logger,
+		cmd.Baggageclaim.VolumesDir.Path(),
+		maxID,
+		baggageclaimURL,
+		p2pInterfacePattern,
+		cmd.Baggageclaim.P2pInterfaceFamily,
+		cmd.VolumeDeltaBindPort,
+	)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/flag"
 	"github.com/jessevdk/go-flags"
 	"github.com/tedsuo/ifrit"
+	"golang.org/x/sys/unix"
 )
 
 type Certs struct {
@@ -43,6 +44,10 @@ type ContainerdRuntime struct {
 	CNIPluginsDir  string        `long:"cni-plugins-dir" default:"/usr/local/concourse/bin" description:"Path to CNI network plugins."`
 	RequestTimeout time.Duration `long:"request-timeout" default:"5m" description:"How long to wait for requests to Containerd to complete. 0 means no timeout."`
 
+	Rootless        bool   `long:"rootless" description:"Run containerd without root privileges, inside a user namespace set up by rootlesskit. Privileged containers are not supported, and volumes are managed with the 'overlay' Baggageclaim driver when unprivileged overlay mounts are possible, or the 'naive' one otherwise."`
+	RootlesskitBin  string `long:"rootlesskit-bin" description:"Path to a rootlesskit executable (non-absolute names get resolved from $PATH)."`
+	RootlessNetwork string `long:"rootless-network" default:"slirp4netns" choice:"slirp4netns" choice:"pasta" description:"User-mode network stack connecting containers to the outside world in rootless mode."`
+
 	Network struct {
 		ExternalIP flag.IP `long:"external-ip" description:"IP address to use to reach container's mapped ports. Autodetected if not specified."`
 		//TODO can DNSConfig be simplifed to just a bool rather than struct with a bool?
@@ -70,18 +75,28 @@ func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Comman
 // endpoints that allow the ATC to make container related requests to the worker.
 // The runner may also include additional processes such as the runtime's daemon or a DNS proxy server.
 func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, ifrit.Runner, error) {
-	err := cmd.checkRoot()
+	err := cmd.verifyRuntimeFlags()
 	if err != nil {
 		return atc.Worker{}, nil, err
 	}
 
-	err = cmd.verifyRuntimeFlags()
-	if err != nil {
-		return atc.Worker{}, nil, err
+	if !cmd.rootless() {
+		err = cmd.checkRoot()
+		if err != nil {
+			return atc.Worker{}, nil, err
+		}
 	}
 
 	worker := cmd.Worker.Worker()
 	worker.Platform = "linux"
+	worker.Rootless = cmd.rootless()
+	worker.SupportedContainerLimits = cmd.supportedContainerLimits(logger)
+
+	// only the containerd runtime mounts a tmpfs for secret files, which
+	// rootless mode lacks the privileges for
+	if cmd.Runtime == containerdRuntime && !cmd.rootless() {
+		worker.Features = append(worker.Features, atc.WorkerFeatureSecretFiles)
+	}
 
 	if cmd.Certs.Dir != "" {
 		worker.CertsPath = &cmd.Certs.Dir
@@ -134,6 +149,68 @@ func trySetConcourseDirInPATH() {
 
 var ErrNotRoot = errors.New("worker must be run as root")
 
+// rootless tells whether the worker runs without root privileges, which is
+// only supported by the containerd runtime.
+func (cmd *WorkerCommand) rootless() bool {
+	return cmd.Runtime == containerdRuntime && cmd.Containerd.Rootless
+}
+
+// overlayMountable tells whether an overlay filesystem can be mounted under
+// dir, which in rootless mode depends on the worker running in a user
+// namespace of its own and on the kernel allowing overlay mounts there.
+func overlayMountable(dir string) bool {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return false
+	}
+
+	probe, err := ioutil.TempDir(dir, "probe")
+	if err != nil {
+		return false
+	}
+	defer os.RemoveAll(probe)
+
+	var (
+		lower  = filepath.Join(probe, "lower")
+		upper  = filepath.Join(probe, "upper")
+		work   = filepath.Join(probe, "work")
+		merged = filepath.Join(probe, "merged")
+	)
+
+	for _, d := range []string{lower, upper, work, merged} {
+		err = os.Mkdir(d, 0755)
+		if err != nil {
+			return false
+		}
+	}
+
+	err = unix.Mount("overlay", merged, "overlay", 0, fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", lower, upper, work))
+	if err != nil {
+		return false
+	}
+
+	_ = unix.Unmount(merged, 0)
+
+	return true
+}
+
+// supportedContainerLimits lists the kinds of container limits that the
+// runtime is able to enforce.
+func (cmd *WorkerCommand) supportedContainerLimits(logger lager.Logger) []string {
+	switch cmd.Runtime {
+	case containerdRuntime:
+		limits := []string{atc.ContainerLimitCPU, atc.ContainerLimitMemory, atc.ContainerLimitPids}
+		if len(cmd.blockDevices(logger)) > 0 {
+			limits = append(limits, atc.ContainerLimitIO)
+		}
+		return limits
+	case guardianRuntime:
+		return []string{atc.ContainerLimitCPU, atc.ContainerLimitMemory, atc.ContainerLimitPids}
+	default:
+		return nil
+	}
+}
+
 func (cmd *WorkerCommand) checkRoot() error {
 	currentUser, err := user.Current()
 	if err != nil {
@@ -237,6 +314,10 @@ func (cmd *WorkerCommand) verifyRuntimeFlags() error {
 		if cmd.hasFlags(guardianEnvPrefix) {
 			return fmt.Errorf("cannot use %s environment variables with Containerd", guardianEnvPrefix)
 		}
+
+		if cmd.Containerd.Rootless && len(cmd.Containerd.Network.RestrictedNetworks) > 0 {
+			return fmt.Errorf("restricted networks cannot be enforced in rootless mode")
+		}
 	case cmd.Runtime == guardianRuntime:
 		if cmd.hasFlags(containerdEnvPrefix) {
 			return fmt.Errorf("cannot use %s environment variables with Guardian", containerdEnvPrefix)
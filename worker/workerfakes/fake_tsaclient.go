@@ -34,6 +34,17 @@ type FakeTSAClient struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EvictStub        func(context.Context) error
+	evictMutex       sync.RWMutex
+	evictArgsForCall []struct {
+		arg1 context.Context
+	}
+	evictReturns struct {
+		result1 error
+	}
+	evictReturnsOnCall map[int]struct {
+		result1 error
+	}
 	LandStub        func(context.Context) error
 	landMutex       sync.RWMutex
 	landArgsForCall []struct {
@@ -234,6 +245,67 @@ func (fake *FakeTSAClient) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTSAClient) Evict(arg1 context.Context) error {
+	fake.evictMutex.Lock()
+	ret, specificReturn := fake.evictReturnsOnCall[len(fake.evictArgsForCall)]
+	fake.evictArgsForCall = append(fake.evictArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EvictStub
+	fakeReturns := fake.evictReturns
+	fake.recordInvocation("Evict", []interface{}{arg1})
+	fake.evictMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTSAClient) EvictCallCount() int {
+	fake.evictMutex.RLock()
+	defer fake.evictMutex.RUnlock()
+	return len(fake.evictArgsForCall)
+}
+
+func (fake *FakeTSAClient) EvictCalls(stub func(context.Context) error) {
+	fake.evictMutex.Lock()
+	defer fake.evictMutex.Unlock()
+	fake.EvictStub = stub
+}
+
+func (fake *FakeTSAClient) EvictArgsForCall(i int) context.Context {
+	fake.evictMutex.RLock()
+	defer fake.evictMutex.RUnlock()
+	argsForCall := fake.evictArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTSAClient) EvictReturns(result1 error) {
+	fake.evictMutex.Lock()
+	defer fake.evictMutex.Unlock()
+	fake.EvictStub = nil
+	fake.evictReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTSAClient) EvictReturnsOnCall(i int, result1 error) {
+	fake.evictMutex.Lock()
+	defer fake.evictMutex.Unlock()
+	fake.EvictStub = nil
+	if fake.evictReturnsOnCall == nil {
+		fake.evictReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.evictReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTSAClient) Land(arg1 context.Context) error {
 	fake.landMutex.Lock()
 	ret, specificReturn := fake.landReturnsOnCall[len(fake.landArgsForCall)]
@@ -623,6 +695,8 @@ func (fake *FakeTSAClient) Invocations() map[string][][]interface{} {
 	defer fake.containersToDestroyMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.evictMutex.RLock()
+	defer fake.evictMutex.RUnlock()
 	fake.landMutex.RLock()
 	defer fake.landMutex.RUnlock()
 	fake.registerMutex.RLock()